@@ -0,0 +1,58 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+// Package authinfo encrypts the credentials the repository providers store in their organization records
+package authinfo
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"io"
+)
+
+// ErrCipherTextTooShort is returned when the cipher text doesn't even hold the IV nonce
+var ErrCipherTextTooShort = errors.New("cipher text is too short")
+
+// Encrypt encrypts the message with AES in the cipher feedback (CFB) mode, the IV nonce is stored at the beginning
+// of the returned cipher text
+func Encrypt(key, message []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	cipherText := make([]byte, aes.BlockSize+len(message))
+	iv := cipherText[:aes.BlockSize]
+	if _, err = io.ReadFull(rand.Reader, iv); err != nil {
+		return nil, err
+	}
+
+	//nolint:staticcheck // CFB mode required for backward compatibility with the stored credentials
+	cfb := cipher.NewCFBEncrypter(block, iv)
+	cfb.XORKeyStream(cipherText[aes.BlockSize:], message)
+
+	return cipherText, nil
+}
+
+// Decrypt decrypts the cipher text produced by Encrypt
+func Decrypt(key, cipherText []byte) ([]byte, error) {
+	if len(cipherText) < aes.BlockSize {
+		return nil, ErrCipherTextTooShort
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	iv := cipherText[:aes.BlockSize]
+	message := make([]byte, len(cipherText)-aes.BlockSize)
+
+	//nolint:staticcheck // CFB mode required for backward compatibility with the stored credentials
+	cfb := cipher.NewCFBDecrypter(block, iv)
+	cfb.XORKeyStream(message, cipherText[aes.BlockSize:])
+
+	return message, nil
+}
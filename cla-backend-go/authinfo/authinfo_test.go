@@ -0,0 +1,28 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package authinfo
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	key := bytes.Repeat([]byte("k"), 32)
+
+	cipherText, err := Encrypt(key, []byte("access-token"))
+	assert.NoError(t, err)
+	assert.NotContains(t, string(cipherText), "access-token")
+
+	message, err := Decrypt(key, cipherText)
+	assert.NoError(t, err)
+	assert.Equal(t, "access-token", string(message))
+}
+
+func TestDecryptRejectsShortCipherText(t *testing.T) {
+	_, err := Decrypt(bytes.Repeat([]byte("k"), 32), []byte("short"))
+	assert.ErrorIs(t, err, ErrCipherTextTooShort)
+}
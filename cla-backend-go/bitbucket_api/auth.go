@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// AuthorizeURL is the Bitbucket OAuth2 authorization endpoint
+	AuthorizeURL = "https://bitbucket.org/site/oauth2/authorize"
+	oauthURL     = "https://bitbucket.org/site/oauth2/access_token"
+)
+
+// RefreshOauthToken common routine to refresh the Bitbucket token
+func RefreshOauthToken(refreshToken string) (*OauthSuccessResponse, error) {
+	f := logrus.Fields{
+		"functionName": "bitbucket.auth.RefreshOauthToken",
+	}
+
+	return requestOauthToken(f, map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	})
+}
+
+// FetchOauthCredentials is responsible for fetching the credentials from bitbucket for an already started Oauth process (access_token, refresh_token)
+func FetchOauthCredentials(code string) (*OauthSuccessResponse, error) {
+	f := logrus.Fields{
+		"functionName": "bitbucket.auth.FetchOauthCredentials",
+		"redirectURI":  config.GetConfig().Bitbucket.RedirectURI,
+	}
+
+	return requestOauthToken(f, map[string]string{
+		"grant_type": "authorization_code",
+		"code":       code,
+	})
+}
+
+// requestOauthToken invokes the token endpoint, the OAuth consumer authenticates with its key and secret
+//
+// For info on this authorization flow, see: https://developer.atlassian.com/cloud/bitbucket/oauth-2/
+func requestOauthToken(f logrus.Fields, formData map[string]string) (*OauthSuccessResponse, error) {
+	bitbucketConfig := config.GetConfig().Bitbucket
+	if len(bitbucketConfig.AppClientID) > 4 {
+		f["bitbucketClientID"] = fmt.Sprintf("%s...%s", bitbucketConfig.AppClientID[0:4], bitbucketConfig.AppClientID[len(bitbucketConfig.AppClientID)-4:])
+	} else {
+		return nil, errors.New("bitbucket application client ID value is not set - value is empty or malformed")
+	}
+	if len(bitbucketConfig.AppClientSecret) <= 4 {
+		return nil, errors.New("bitbucket application client secret value is not set - value is empty or malformed")
+	}
+
+	resp, err := resty.New().R().
+		SetBasicAuth(bitbucketConfig.AppClientID, bitbucketConfig.AppClientSecret).
+		SetFormData(formData).
+		SetResult(&OauthSuccessResponse{}).
+		Post(oauthURL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem invoking Bitbucket auth token exchange to: %s", oauthURL)
+		return nil, err
+	}
+
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		msg := fmt.Sprintf("problem invoking Bitbucket auth token exchange to: %s with status code: %d, response: %s", oauthURL, resp.StatusCode(), string(resp.Body()))
+		log.WithFields(f).Warn(msg)
+		return nil, errors.New(msg)
+	}
+
+	result, ok := resp.Result().(*OauthSuccessResponse)
+	if !ok || result.AccessToken == "" {
+		log.WithFields(f).Warnf("error fetching oauth credentials from bitbucket - non success response: %+v", resp)
+		return nil, errors.New("error fetching oauth credentials from bitbucket")
+	}
+
+	return result, nil
+}
+
+// DataCenterOAuthConfig returns the OAuth2 configuration of the incoming application link registered on the Data
+// Center instance, Data Center acts as the OAuth2 provider identifying the contributors signing from a pull request
+func DataCenterOAuthConfig(serverURL, clientID, clientSecret, redirectURL string) *oauth2.Config {
+	serverURL = NormalizeServerURL(serverURL)
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  serverURL + "/rest/oauth2/latest/authorize",
+			TokenURL: serverURL + "/rest/oauth2/latest/token",
+		},
+		RedirectURL: redirectURL,
+		Scopes:      []string{"PUBLIC_REPOS"},
+	}
+}
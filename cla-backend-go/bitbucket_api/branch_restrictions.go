@@ -0,0 +1,57 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// requirePassingBuildsKind is the branch restriction blocking the merge until the build statuses pass
+const requirePassingBuildsKind = "require_passing_builds_to_merge"
+
+// BranchRestriction is a Bitbucket branch restriction
+type BranchRestriction struct {
+	ID              int    `json:"id,omitempty"`
+	Kind            string `json:"kind"`
+	BranchMatchKind string `json:"branch_match_kind"`
+	Pattern         string `json:"pattern"`
+	Value           int    `json:"value"`
+}
+
+// SetBranchRestriction requires a passing build status before merging into the branch. An existing restriction of
+// the same kind for the branch is left in place.
+func (c *Client) SetBranchRestriction(workspace, repository, branch string) error {
+	if c.IsDataCenter() {
+		return c.dataCenterSetRequiredBuild(workspace, repository, branch)
+	}
+	query := url.Values{}
+	query.Set("kind", requirePassingBuildsKind)
+	query.Set("pattern", branch)
+
+	restrictionsURL := c.endpoint("repositories", workspace, RepositoryRef(repository), "branch-restrictions")
+	var found bool
+	err := c.paginate(restrictionsURL, query, func(value json.RawMessage) (bool, error) {
+		var restriction BranchRestriction
+		if err := json.Unmarshal(value, &restriction); err != nil {
+			return false, err
+		}
+		if restriction.Kind == requirePassingBuildsKind && restriction.Pattern == branch {
+			found = true
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil || found {
+		return err
+	}
+
+	return c.do(http.MethodPost, restrictionsURL, &BranchRestriction{
+		Kind:            requirePassingBuildsKind,
+		BranchMatchKind: "glob",
+		Pattern:         branch,
+		Value:           1,
+	}, nil)
+}
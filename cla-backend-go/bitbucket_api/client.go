@@ -0,0 +1,311 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/linuxfoundation/easycla/cla-backend-go/authinfo"
+)
+
+const (
+	// DefaultBaseURL is the Bitbucket Cloud REST API base URL
+	DefaultBaseURL = "https://api.bitbucket.org/2.0"
+	// dataCenterAPIPath is the REST API path of the Bitbucket Data Center instances
+	dataCenterAPIPath = "/rest/api/1.0"
+
+	// maxPages guards the paginated listings against an endless next link
+	maxPages = 100
+	// pageLength is the page size requested for the paginated listings - 100 is the Bitbucket maximum for most endpoints
+	pageLength = 100
+)
+
+// OauthSuccessResponse is success response from Bitbucket
+type OauthSuccessResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int    `json:"expires_in"`
+	RefreshToken string `json:"refresh_token"`
+	Scopes       string `json:"scopes"`
+}
+
+// Client is a minimal Bitbucket REST API client authenticated with an access token. The client targets Bitbucket
+// Cloud, or the Bitbucket Data Center instance when the server URL is set - the Data Center projects take the place
+// of the Cloud workspaces.
+type Client struct {
+	baseURL    string
+	serverURL  string
+	restClient *resty.Client
+}
+
+// APIError is returned when Bitbucket responds with a non 2xx status code
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	return fmt.Sprintf("bitbucket %s %s failed with status code: %d, response: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if the error is a Bitbucket 404 response
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// NewBitbucketOauthClient creates a new bitbucket client from the given oauth info, authInfo is encrypted
+func NewBitbucketOauthClient(authInfo string, bitbucketApp *App) (*Client, error) {
+	return NewBitbucketClient("", authInfo, bitbucketApp)
+}
+
+// NewBitbucketClient creates a new bitbucket client of the Data Center instance from the given encrypted auth info,
+// an empty server URL targets Bitbucket Cloud
+func NewBitbucketClient(serverURL, authInfo string, bitbucketApp *App) (*Client, error) {
+	if authInfo == "" {
+		return nil, errors.New("unable to decrypt auth info - authentication info input is nil")
+	}
+	if bitbucketApp == nil || bitbucketApp.bitbucketAppID == "" || bitbucketApp.bitbucketAppPrivateKey == "" || bitbucketApp.bitbucketAppSecret == "" {
+		return nil, errors.New("unable to decrypt auth info - Bitbucket app structure is nil or empty")
+	}
+
+	oauthResp, err := DecryptAuthInfo(authInfo, bitbucketApp)
+	if err != nil {
+		return nil, err
+	}
+
+	if oauthResp == nil {
+		return nil, errors.New("unable to decrypt auth info - value is nil")
+	}
+
+	return NewBitbucketClientFromAccessToken(serverURL, oauthResp.AccessToken), nil
+}
+
+// NewBitbucketOauthClientFromAccessToken creates a new bitbucket client from the given access token
+func NewBitbucketOauthClientFromAccessToken(accessToken string) *Client {
+	return newClient(DefaultBaseURL, accessToken)
+}
+
+// NewBitbucketClientFromAccessToken creates a new bitbucket client of the Data Center instance from the given access
+// token, an empty server URL targets Bitbucket Cloud
+func NewBitbucketClientFromAccessToken(serverURL, accessToken string) *Client {
+	serverURL = NormalizeServerURL(serverURL)
+	if serverURL == "" {
+		return newClient(DefaultBaseURL, accessToken)
+	}
+	client := newClient(serverURL+dataCenterAPIPath, accessToken)
+	client.serverURL = serverURL
+	return client
+}
+
+func newClient(baseURL, accessToken string) *Client {
+	return &Client{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		restClient: resty.New().
+			SetAuthToken(accessToken).
+			SetHeader("Accept", "application/json"),
+	}
+}
+
+// NormalizeServerURL trims the trailing slash of the Data Center server URL so the URL can be compared
+func NormalizeServerURL(serverURL string) string {
+	return strings.TrimSuffix(strings.TrimSpace(serverURL), "/")
+}
+
+// IsDataCenter returns true if the client targets a Bitbucket Data Center instance
+func (c *Client) IsDataCenter() bool {
+	return c.serverURL != ""
+}
+
+// ServerURL returns the base URL of the Data Center instance, empty for Bitbucket Cloud
+func (c *Client) ServerURL() string {
+	return c.serverURL
+}
+
+// endpoint returns the absolute URL of the API path, the path segments are escaped
+func (c *Client) endpoint(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return c.baseURL + "/" + strings.Join(escaped, "/")
+}
+
+// do sends the request and decodes the JSON response into result, when set
+func (c *Client) do(method, requestURL string, body, result interface{}) error {
+	_, err := c.doWithResponse(method, requestURL, body, result)
+	return err
+}
+
+// doWithResponse is do returning the response, for the callers reading the response headers
+func (c *Client) doWithResponse(method, requestURL string, body, result interface{}) (*resty.Response, error) {
+	request := c.restClient.R()
+	if body != nil {
+		request.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	resp, err := request.Execute(method, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode(),
+			Method:     method,
+			URL:        requestURL,
+			Body:       string(resp.Body()),
+		}
+	}
+	if result != nil && len(resp.Body()) > 0 {
+		if err := json.Unmarshal(resp.Body(), result); err != nil {
+			return nil, fmt.Errorf("unable to decode bitbucket response from %s, error: %v", requestURL, err)
+		}
+	}
+	return resp, nil
+}
+
+// page is the envelope of the paginated Bitbucket responses - Cloud follows the next link, Data Center the start
+// offset of the next page
+type page struct {
+	Values        []json.RawMessage `json:"values"`
+	Next          string            `json:"next"`
+	IsLastPage    bool              `json:"isLastPage"`
+	NextPageStart int               `json:"nextPageStart"`
+}
+
+// nextPage returns the URL of the page following the current page, empty on the last page
+func (c *Client) nextPage(requestURL string, query url.Values, current *page) string {
+	if !c.IsDataCenter() {
+		return current.Next
+	}
+	if current.IsLastPage {
+		return ""
+	}
+	query.Set("start", strconv.Itoa(current.NextPageStart))
+	return requestURL + "?" + query.Encode()
+}
+
+// paginate follows the next links of a paginated listing, calling visit for each value. The listing stops when
+// visit returns false.
+func (c *Client) paginate(requestURL string, query url.Values, visit func(value json.RawMessage) (bool, error)) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	if c.IsDataCenter() {
+		query.Set("limit", strconv.Itoa(pageLength))
+	} else {
+		query.Set("pagelen", strconv.Itoa(pageLength))
+	}
+	next := requestURL + "?" + query.Encode()
+
+	for pageCount := 0; next != "" && pageCount < maxPages; pageCount++ {
+		var current page
+		if err := c.do(http.MethodGet, next, nil, &current); err != nil {
+			return err
+		}
+		for _, value := range current.Values {
+			more, err := visit(value)
+			if err != nil {
+				return err
+			}
+			if !more {
+				return nil
+			}
+		}
+		next = c.nextPage(requestURL, query, &current)
+	}
+
+	return nil
+}
+
+// EncryptAuthInfo encrypts the oauth response into a string
+func EncryptAuthInfo(oauthResp *OauthSuccessResponse, bitbucketApp *App) (string, error) {
+	keyDecoded, err := base64.StdEncoding.DecodeString(bitbucketApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("problem decoding Bitbucket private key, error: %v", err)
+	}
+
+	b, err := json.Marshal(oauthResp)
+	if err != nil {
+		return "", fmt.Errorf("problem marshalling oauth resp json, error: %v", err)
+	}
+
+	encrypted, err := authinfo.Encrypt(keyDecoded, b)
+	if err != nil {
+		return "", fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	return hex.EncodeToString(encrypted), nil
+}
+
+// EncryptSecret encrypts the secret into a string - used for the OAuth2 client secret of the Data Center instances
+func EncryptSecret(secret string, bitbucketApp *App) (string, error) {
+	keyDecoded, err := base64.StdEncoding.DecodeString(bitbucketApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("problem decoding Bitbucket private key, error: %v", err)
+	}
+
+	encrypted, err := authinfo.Encrypt(keyDecoded, []byte(secret))
+	if err != nil {
+		return "", fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	return hex.EncodeToString(encrypted), nil
+}
+
+// DecryptSecret decrypts the secret encrypted by EncryptSecret
+func DecryptSecret(secretEncoded string, bitbucketApp *App) (string, error) {
+	ciphertext, err := hex.DecodeString(secretEncoded)
+	if err != nil {
+		return "", fmt.Errorf("decode secret : %v", err)
+	}
+
+	keyDecoded, err := base64.StdEncoding.DecodeString(bitbucketApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("decode key : %v", err)
+	}
+
+	decrypted, err := authinfo.Decrypt(keyDecoded, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt failed : %v", err)
+	}
+
+	return string(decrypted), nil
+}
+
+// DecryptAuthInfo decrypts the auth info into OauthSuccessResponse data structure
+func DecryptAuthInfo(authInfoEncoded string, bitbucketApp *App) (*OauthSuccessResponse, error) {
+	ciphertext, err := hex.DecodeString(authInfoEncoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode auth info : %v", err)
+	}
+
+	keyDecoded, err := base64.StdEncoding.DecodeString(bitbucketApp.GetAppPrivateKey())
+	if err != nil {
+		return nil, fmt.Errorf("decode key : %v", err)
+	}
+
+	decrypted, err := authinfo.Decrypt(keyDecoded, ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt failed : %v", err)
+	}
+
+	var oauthResp OauthSuccessResponse
+	if err := json.Unmarshal(decrypted, &oauthResp); err != nil {
+		return nil, fmt.Errorf("unmarshall auth info : %v", err)
+	}
+
+	return &oauthResp, nil
+}
@@ -0,0 +1,289 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var bbClientKey = "0WqnDWHnZKo2cmQ8m93EtY9ZBpfzQW4UnnEuRmgtJKM="
+
+func TestEncryptDecryptAuthInfo(t *testing.T) {
+	app := &App{bitbucketAppID: "bb-client-id", bitbucketAppSecret: "bb-client-secret", bitbucketAppPrivateKey: bbClientKey}
+	oauthResp := &OauthSuccessResponse{AccessToken: "access", TokenType: "bearer", ExpiresIn: 7200, RefreshToken: "refresh", Scopes: "pullrequest webhook"}
+
+	encrypted, err := EncryptAuthInfo(oauthResp, app)
+	assert.NoError(t, err)
+
+	decrypted, err := DecryptAuthInfo(encrypted, app)
+	assert.NoError(t, err)
+	assert.Equal(t, oauthResp, decrypted)
+	encryptedSecret, err := EncryptSecret("client-secret", app)
+	assert.NoError(t, err)
+	assert.NotContains(t, encryptedSecret, "client-secret")
+	secret, err := DecryptSecret(encryptedSecret, app)
+	assert.NoError(t, err)
+	assert.Equal(t, "client-secret", secret)
+}
+
+func TestListPullRequestCommitsFollowsPagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/acme/%7B6a4c0bbb-5ba1-4ce2-9f37-0c5e4e1b9c11%7D/pullrequests/7/commits", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("page") == "" {
+			fmt.Fprintf(w, `{"values":[{"hash":"a1","author":{"raw":"Jane Doe <jane@example.org>","user":{"uuid":"{j}"}}}],"next":"%s%s?page=2"}`, server.URL, r.URL.Path)
+			return
+		}
+		fmt.Fprint(w, `{"values":[{"hash":"b2","author":{"raw":"Jane Doe <JANE@example.org>"}},{"hash":"c3","author":{"raw":"John Smith <john@example.org>"}}]}`)
+	}))
+	defer server.Close()
+
+	client := newClient(server.URL, "token")
+	authors, err := client.GetPullRequestAuthors("acme", "6a4c0bbb-5ba1-4ce2-9f37-0c5e4e1b9c11", 7)
+	assert.NoError(t, err)
+	if assert.Len(t, authors, 2) {
+		assert.Equal(t, "Jane Doe", authors[0].Name)
+		assert.Equal(t, "jane@example.org", authors[0].Email)
+		assert.Equal(t, []string{"a1", "b2"}, authors[0].SHAs)
+		assert.Equal(t, "{j}", authors[0].Account.UUID)
+		assert.Equal(t, "john@example.org", authors[1].Email)
+		assert.Nil(t, authors[1].Account)
+	}
+}
+
+func TestSetPullRequestCommentUpdatesExistingComment(t *testing.T) {
+	var updated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"values":[{"id":1,"content":{"raw":"LGTM"}},{"id":2,"content":{"raw":"![CLA](https://example.org/cla-not-signed.svg)"}}]}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/repositories/acme/widgets/pullrequests/7/comments/2":
+			body, _ := io.ReadAll(r.Body)
+			var payload struct {
+				Content struct {
+					Raw string `json:"raw"`
+				} `json:"content"`
+			}
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			updated = payload.Content.Raw
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := newClient(server.URL, "token")
+	err := client.SetPullRequestComment("acme", "widgets", 7, "![CLA](https://example.org/cla-signed.svg)")
+	assert.NoError(t, err)
+	assert.Equal(t, "![CLA](https://example.org/cla-signed.svg)", updated)
+}
+
+func TestAPIErrorNotFound(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := newClient(server.URL, "token").GetRepository("acme", "missing")
+	assert.True(t, IsNotFound(err))
+}
+
+func TestParseRawAuthor(t *testing.T) {
+	name, email := ParseRawAuthor("Jane Doe <jane@example.org>")
+	assert.Equal(t, "Jane Doe", name)
+	assert.Equal(t, "jane@example.org", email)
+
+	name, email = ParseRawAuthor("jane")
+	assert.Equal(t, "jane", name)
+	assert.Equal(t, "", email)
+}
+
+func TestParsePullRequestEvent(t *testing.T) {
+	event, err := ParsePullRequestEvent(EventPullRequestCreated, []byte(`{"pullrequest":{"id":7,"source":{"commit":{"hash":"a1"}}},"repository":{"uuid":"{r}","workspace":{"slug":"acme"}}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 7, event.PullRequest.ID)
+	assert.Equal(t, "a1", event.PullRequest.Source.Commit.Hash)
+
+	_, err = ParsePullRequestEvent(EventPullRequestCreated, []byte(`{"repository":{"uuid":"{r}","workspace":{"slug":"acme"}}}`))
+	assert.Error(t, err)
+}
+
+func TestSetWebHookRegistersTheSecret(t *testing.T) {
+	var registered Webhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `{"values":[{"uuid":"{h}","url":"https://example.org/other"}]}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/workspaces/acme/hooks":
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &registered))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := newClient(server.URL, "token")
+	assert.NoError(t, client.SetWebHook("acme", "https://example.org/bitbucket/activity", "secret"))
+	assert.Equal(t, "https://example.org/bitbucket/activity", registered.URL)
+	assert.Equal(t, "secret", registered.Secret)
+
+	assert.Error(t, client.SetWebHook("acme", "https://example.org/bitbucket/activity", ""))
+}
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"pullrequest":{"id":7}}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+
+	assert.NoError(t, ValidateSignature(payload, signature, "secret"))
+	assert.Error(t, ValidateSignature(payload, signature, "other"))
+	assert.Error(t, ValidateSignature(payload, strings.TrimPrefix(signature, "sha256="), "secret"))
+	assert.Error(t, ValidateSignature(payload, "", "secret"))
+	assert.Error(t, ValidateSignature(payload, signature, ""))
+}
+
+func TestParseDataCenterPullRequestEvent(t *testing.T) {
+	event, err := ParsePullRequestEvent(EventDataCenterPullRequestCommentAdded, []byte(`{"eventKey":"pr:comment:added","pullRequest":{"id":7,"state":"OPEN","fromRef":{"displayId":"feature","latestCommit":"a1"},"toRef":{"displayId":"main","repository":{"id":3,"slug":"widgets","project":{"id":1,"key":"ACME"}}},"links":{"self":[{"href":"https://git.example.org/bitbucket/projects/ACME/repos/widgets/pull-requests/7"}]}},"comment":{"id":9,"text":"/easycla"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "https://git.example.org/bitbucket", event.ServerURL)
+	assert.Equal(t, 7, event.PullRequest.ID)
+	assert.Equal(t, "a1", event.PullRequest.Source.Commit.Hash)
+	assert.Equal(t, "ACME", event.Repository.Workspace.Slug)
+	assert.Equal(t, "widgets", event.Repository.ExternalID())
+	assert.Equal(t, "/easycla", event.Comment.Content.Raw)
+
+	_, err = ParsePullRequestEvent(EventDataCenterPullRequestOpened, []byte(`{"pullRequest":{"id":7,"toRef":{"repository":{"slug":"widgets"}}}}`))
+	assert.Error(t, err)
+}
+
+func TestDataCenterListPullRequestCommitsFollowsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/1.0/projects/ACME/repos/widgets/pull-requests/7/commits", r.URL.Path)
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("start") == "" {
+			fmt.Fprint(w, `{"values":[{"id":"a1","author":{"name":"jdoe","emailAddress":"jane@example.org","displayName":"Jane Doe","slug":"jdoe","id":4}}],"isLastPage":false,"nextPageStart":1}`)
+			return
+		}
+		assert.Equal(t, "1", r.URL.Query().Get("start"))
+		fmt.Fprint(w, `{"values":[{"id":"b2","author":{"name":"John Smith","emailAddress":"john@example.org"}}],"isLastPage":true}`)
+	}))
+	defer server.Close()
+
+	client := NewBitbucketClientFromAccessToken(server.URL+"/", "token")
+	assert.True(t, client.IsDataCenter())
+	authors, err := client.GetPullRequestAuthors("ACME", "widgets", 7)
+	assert.NoError(t, err)
+	if assert.Len(t, authors, 2) {
+		assert.Equal(t, "Jane Doe", authors[0].Name)
+		assert.Equal(t, "jane@example.org", authors[0].Email)
+		assert.Equal(t, "jdoe", authors[0].Account.Nickname)
+		assert.Equal(t, "John Smith", authors[1].Name)
+		assert.Nil(t, authors[1].Account)
+	}
+}
+
+func TestDataCenterSetCommitStatus(t *testing.T) {
+	var status CommitStatus
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/rest/build-status/1.0/commits/a1", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &status))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewBitbucketClientFromAccessToken(server.URL, "token")
+	assert.NoError(t, client.SetCommitStatus("ACME", "widgets", "a1", CommitStatusFailed, "Missing CLA Authorization", "https://example.org/sign"))
+	assert.Equal(t, CommitStatusFailed, status.State)
+	assert.Equal(t, commitStatusKey, status.Key)
+}
+
+func TestDataCenterSetPullRequestCommentUpdatesExistingComment(t *testing.T) {
+	var updated map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/ACME/repos/widgets/pull-requests/7/activities":
+			fmt.Fprint(w, `{"values":[{"action":"OPENED"},{"action":"COMMENTED","comment":{"id":2,"version":3,"text":"![CLA](https://example.org/cla-not-signed.svg)"}}],"isLastPage":true}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/1.0/projects/ACME/repos/widgets/pull-requests/7/comments/2":
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &updated))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewBitbucketClientFromAccessToken(server.URL, "token")
+	assert.NoError(t, client.SetPullRequestComment("ACME", "widgets", 7, "![CLA](https://example.org/cla-signed.svg)"))
+	assert.Equal(t, "![CLA](https://example.org/cla-signed.svg)", updated["text"])
+	// the update is rejected by Data Center without the current comment version
+	assert.Equal(t, float64(3), updated["version"])
+}
+
+func TestDataCenterSetWebHookRegistersTheSecret(t *testing.T) {
+	var registered dataCenterWebhook
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/rest/api/1.0/projects/ACME/webhooks":
+			fmt.Fprint(w, `{"values":[{"id":4,"url":"https://example.org/bitbucket/activity"}],"isLastPage":true}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/rest/api/1.0/projects/ACME/webhooks/4":
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &registered))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewBitbucketClientFromAccessToken(server.URL, "token")
+	assert.NoError(t, client.SetWebHook("ACME", "https://example.org/bitbucket/activity", "secret"))
+	assert.Equal(t, "secret", registered.Configuration["secret"])
+	assert.Equal(t, dataCenterWebhookEvents(), registered.Events)
+}
+
+func TestDataCenterGetCurrentUser(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/1.0/application-properties":
+			w.Header().Set(userHeader, "jdoe")
+			fmt.Fprint(w, `{"version":"8.9.0"}`)
+		case "/rest/api/1.0/users":
+			assert.Equal(t, "jdoe", r.URL.Query().Get("filter"))
+			fmt.Fprint(w, `{"values":[{"id":5,"name":"jdoe2","slug":"jdoe2"},{"id":4,"name":"jdoe","slug":"jdoe","displayName":"Jane Doe","emailAddress":"jane@example.org"}],"isLastPage":true}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewBitbucketClientFromAccessToken(server.URL, "token")
+	account, err := client.GetCurrentUser()
+	assert.NoError(t, err)
+	assert.Equal(t, "jdoe", account.Nickname)
+	assert.Equal(t, "Jane Doe", account.DisplayName)
+
+	emails, err := client.GetCurrentUserEmails()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jane@example.org"}, emails)
+}
@@ -0,0 +1,510 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// The Bitbucket Data Center REST API differs from the Cloud one in the paths and in the resource representations -
+// the Data Center resources are decoded here and converted to the Cloud models used by the callers.
+
+const (
+	// buildStatusAPIPath is the Data Center build status API path
+	buildStatusAPIPath = "/rest/build-status/1.0"
+	// requiredBuildsAPIPath is the Data Center required builds merge check API path
+	requiredBuildsAPIPath = "/rest/required-builds/latest"
+
+	// userHeader is the response header holding the username of the Data Center access token owner
+	userHeader = "X-AUSERNAME"
+
+	// commentedAction is the pull request activity of the comments
+	commentedAction = "COMMENTED"
+)
+
+// dataCenterLinks are the Data Center resource links, a list per relation
+type dataCenterLinks struct {
+	Self []Link `json:"self"`
+}
+
+func (l dataCenterLinks) toLinks() Links {
+	if len(l.Self) == 0 {
+		return Links{}
+	}
+	return Links{HTML: l.Self[0]}
+}
+
+type dataCenterUser struct {
+	ID           int             `json:"id"`
+	Name         string          `json:"name"`
+	Slug         string          `json:"slug"`
+	EmailAddress string          `json:"emailAddress"`
+	DisplayName  string          `json:"displayName"`
+	Links        dataCenterLinks `json:"links"`
+}
+
+func (u *dataCenterUser) toAccount() *Account {
+	return &Account{
+		UUID:        strconv.Itoa(u.ID),
+		Nickname:    u.Slug,
+		DisplayName: u.DisplayName,
+		Links:       u.Links.toLinks(),
+	}
+}
+
+type dataCenterProject struct {
+	ID    int             `json:"id"`
+	Key   string          `json:"key"`
+	Name  string          `json:"name"`
+	Links dataCenterLinks `json:"links"`
+}
+
+func (p *dataCenterProject) toWorkspace() *Workspace {
+	return &Workspace{
+		UUID:  strconv.Itoa(p.ID),
+		Slug:  p.Key,
+		Name:  p.Name,
+		Links: p.Links.toLinks(),
+	}
+}
+
+type dataCenterRepository struct {
+	ID      int               `json:"id"`
+	Slug    string            `json:"slug"`
+	Name    string            `json:"name"`
+	Public  bool              `json:"public"`
+	Project dataCenterProject `json:"project"`
+	Links   dataCenterLinks   `json:"links"`
+}
+
+func (r *dataCenterRepository) toRepository() *Repository {
+	return &Repository{
+		Name:      r.Name,
+		Slug:      r.Slug,
+		FullName:  r.Project.Key + "/" + r.Slug,
+		IsPrivate: !r.Public,
+		Project: &Project{
+			UUID: strconv.Itoa(r.Project.ID),
+			Key:  r.Project.Key,
+			Name: r.Project.Name,
+		},
+		Workspace: r.Project.toWorkspace(),
+		Links:     r.Links.toLinks(),
+	}
+}
+
+type dataCenterRef struct {
+	ID           string                `json:"id"`
+	DisplayID    string                `json:"displayId"`
+	LatestCommit string                `json:"latestCommit"`
+	Repository   *dataCenterRepository `json:"repository,omitempty"`
+}
+
+func (r *dataCenterRef) toEndpoint() PullRequestEndpoint {
+	endpoint := PullRequestEndpoint{
+		Branch: Branch{Name: r.DisplayID},
+		Commit: Commit{Hash: r.LatestCommit},
+	}
+	if r.Repository != nil {
+		endpoint.Repository = r.Repository.toRepository()
+	}
+	return endpoint
+}
+
+type dataCenterPullRequest struct {
+	ID     int    `json:"id"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Author *struct {
+		User dataCenterUser `json:"user"`
+	} `json:"author,omitempty"`
+	FromRef dataCenterRef   `json:"fromRef"`
+	ToRef   dataCenterRef   `json:"toRef"`
+	Links   dataCenterLinks `json:"links"`
+}
+
+func (pr *dataCenterPullRequest) toPullRequest() *PullRequest {
+	pullRequest := &PullRequest{
+		ID:          pr.ID,
+		Title:       pr.Title,
+		State:       pr.State,
+		Source:      pr.FromRef.toEndpoint(),
+		Destination: pr.ToRef.toEndpoint(),
+		Links:       pr.Links.toLinks(),
+	}
+	if pr.Author != nil {
+		pullRequest.Author = pr.Author.User.toAccount()
+	}
+	return pullRequest
+}
+
+// dataCenterCommit is a Data Center commit, the author is a Data Center user when the commit email is linked to one -
+// the name is then the username and the display name the user name
+type dataCenterCommit struct {
+	ID     string         `json:"id"`
+	Author dataCenterUser `json:"author"`
+}
+
+func (c *dataCenterCommit) toCommit() *Commit {
+	name := c.Author.Name
+	if c.Author.DisplayName != "" {
+		name = c.Author.DisplayName
+	}
+	author := &CommitAuthor{Raw: name}
+	if c.Author.EmailAddress != "" {
+		author.Raw = fmt.Sprintf("%s <%s>", name, c.Author.EmailAddress)
+	}
+	if c.Author.Slug != "" {
+		author.User = c.Author.toAccount()
+	}
+	return &Commit{Hash: c.ID, Author: author}
+}
+
+type dataCenterComment struct {
+	ID      int            `json:"id"`
+	Version int            `json:"version"`
+	Text    string         `json:"text"`
+	Author  dataCenterUser `json:"author"`
+}
+
+func (c *dataCenterComment) toComment() *Comment {
+	comment := &Comment{ID: c.ID, User: c.Author.toAccount()}
+	comment.Content.Raw = c.Text
+	return comment
+}
+
+// dataCenterWebhook is a Data Center project webhook
+type dataCenterWebhook struct {
+	ID            int               `json:"id,omitempty"`
+	Name          string            `json:"name"`
+	URL           string            `json:"url"`
+	Active        bool              `json:"active"`
+	Events        []string          `json:"events"`
+	Configuration map[string]string `json:"configuration,omitempty"`
+}
+
+// dataCenterRequiredBuild is a Data Center required builds merge check
+type dataCenterRequiredBuild struct {
+	ID              int      `json:"id,omitempty"`
+	BuildParentKeys []string `json:"buildParentKeys"`
+	RefMatcher      struct {
+		ID   string `json:"id"`
+		Type struct {
+			ID string `json:"id"`
+		} `json:"type"`
+	} `json:"refMatcher"`
+}
+
+// dataCenterPullRequestEvent is the webhook payload of the Data Center pull request events
+type dataCenterPullRequestEvent struct {
+	Actor       *dataCenterUser        `json:"actor,omitempty"`
+	PullRequest *dataCenterPullRequest `json:"pullRequest"`
+	Comment     *dataCenterComment     `json:"comment,omitempty"`
+}
+
+// serverEndpoint returns the absolute URL of the path of a Data Center API other than the REST API
+func (c *Client) serverEndpoint(apiPath string, segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return c.serverURL + apiPath + "/" + strings.Join(escaped, "/")
+}
+
+func (c *Client) dataCenterGetProject(projectKey string) (*Workspace, error) {
+	var result dataCenterProject
+	if err := c.do(http.MethodGet, c.endpoint("projects", projectKey), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.toWorkspace(), nil
+}
+
+func (c *Client) dataCenterGetRepository(projectKey, repositorySlug string) (*Repository, error) {
+	var result dataCenterRepository
+	if err := c.do(http.MethodGet, c.endpoint("projects", projectKey, "repos", repositorySlug), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.toRepository(), nil
+}
+
+// dataCenterListRepositories returns the repositories of the project with their default branch - the empty
+// repositories have no default branch yet
+func (c *Client) dataCenterListRepositories(projectKey string) ([]*Repository, error) {
+	var repositories []*Repository
+	err := c.paginate(c.endpoint("projects", projectKey, "repos"), nil, func(value json.RawMessage) (bool, error) {
+		var repository dataCenterRepository
+		if err := json.Unmarshal(value, &repository); err != nil {
+			return false, err
+		}
+		repositories = append(repositories, repository.toRepository())
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, repository := range repositories {
+		var defaultBranch dataCenterRef
+		err := c.do(http.MethodGet, c.endpoint("projects", projectKey, "repos", repository.Slug, "default-branch"), nil, &defaultBranch)
+		if err != nil && !IsNotFound(err) {
+			return nil, err
+		}
+		if defaultBranch.DisplayID != "" {
+			repository.MainBranch = &Branch{Name: defaultBranch.DisplayID}
+		}
+	}
+	return repositories, nil
+}
+
+func (c *Client) dataCenterGetPullRequest(projectKey, repositorySlug string, pullRequestID int) (*PullRequest, error) {
+	var result dataCenterPullRequest
+	if err := c.do(http.MethodGet, c.endpoint("projects", projectKey, "repos", repositorySlug, "pull-requests", strconv.Itoa(pullRequestID)), nil, &result); err != nil {
+		return nil, err
+	}
+	return result.toPullRequest(), nil
+}
+
+func (c *Client) dataCenterListOpenPullRequests(projectKey, repositorySlug string) ([]*PullRequest, error) {
+	query := url.Values{}
+	query.Set("state", PullRequestStateOpen)
+
+	var pullRequests []*PullRequest
+	err := c.paginate(c.endpoint("projects", projectKey, "repos", repositorySlug, "pull-requests"), query, func(value json.RawMessage) (bool, error) {
+		var pullRequest dataCenterPullRequest
+		if err := json.Unmarshal(value, &pullRequest); err != nil {
+			return false, err
+		}
+		pullRequests = append(pullRequests, pullRequest.toPullRequest())
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+func (c *Client) dataCenterListPullRequestCommits(projectKey, repositorySlug string, pullRequestID int) ([]*Commit, error) {
+	var commits []*Commit
+	err := c.paginate(c.endpoint("projects", projectKey, "repos", repositorySlug, "pull-requests", strconv.Itoa(pullRequestID), "commits"), nil, func(value json.RawMessage) (bool, error) {
+		var commit dataCenterCommit
+		if err := json.Unmarshal(value, &commit); err != nil {
+			return false, err
+		}
+		commits = append(commits, commit.toCommit())
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// dataCenterSetCommitStatus reports the build status of the commit - the Data Center build statuses are attached
+// to the commit, not to the repository
+func (c *Client) dataCenterSetCommitStatus(sha string, status *CommitStatus) error {
+	return c.do(http.MethodPost, c.serverEndpoint(buildStatusAPIPath, "commits", sha), status, nil)
+}
+
+// dataCenterSetPullRequestComment creates or updates the EasyCLA comment, the comments are listed from the pull
+// request activities and updated with their current version
+func (c *Client) dataCenterSetPullRequestComment(projectKey, repositorySlug string, pullRequestID int, body string) error {
+	pullRequestURL := c.endpoint("projects", projectKey, "repos", repositorySlug, "pull-requests", strconv.Itoa(pullRequestID))
+
+	var existing *dataCenterComment
+	err := c.paginate(pullRequestURL+"/activities", nil, func(value json.RawMessage) (bool, error) {
+		var activity struct {
+			Action  string             `json:"action"`
+			Comment *dataCenterComment `json:"comment,omitempty"`
+		}
+		if err := json.Unmarshal(value, &activity); err != nil {
+			return false, err
+		}
+		if activity.Action == commentedAction && activity.Comment != nil && IsCLAComment(activity.Comment.Text) {
+			existing = activity.Comment
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		return c.do(http.MethodPut, pullRequestURL+"/comments/"+strconv.Itoa(existing.ID), map[string]interface{}{
+			"text":    body,
+			"version": existing.Version,
+		}, nil)
+	}
+	return c.do(http.MethodPost, pullRequestURL+"/comments", map[string]interface{}{"text": body}, nil)
+}
+
+// dataCenterGetCurrentUser returns the access token owner - the Data Center API has no current user resource, the
+// username is returned in a response header of the authenticated requests
+func (c *Client) dataCenterGetCurrentUser() (*dataCenterUser, error) {
+	resp, err := c.doWithResponse(http.MethodGet, c.endpoint("application-properties"), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	username := resp.Header().Get(userHeader)
+	if username == "" {
+		return nil, errors.New("bitbucket data center didn't return the username of the access token owner")
+	}
+
+	query := url.Values{}
+	query.Set("filter", username)
+	var found *dataCenterUser
+	err = c.paginate(c.endpoint("users"), query, func(value json.RawMessage) (bool, error) {
+		var user dataCenterUser
+		if err := json.Unmarshal(value, &user); err != nil {
+			return false, err
+		}
+		if strings.EqualFold(user.Name, username) {
+			found = &user
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if found == nil {
+		return nil, fmt.Errorf("bitbucket data center user: %s not found", username)
+	}
+	return found, nil
+}
+
+func (c *Client) dataCenterFindWebHook(projectKey, webhookURL string) (*dataCenterWebhook, error) {
+	var found *dataCenterWebhook
+	err := c.paginate(c.endpoint("projects", projectKey, "webhooks"), nil, func(value json.RawMessage) (bool, error) {
+		var hook dataCenterWebhook
+		if err := json.Unmarshal(value, &hook); err != nil {
+			return false, err
+		}
+		if hook.URL == webhookURL {
+			found = &hook
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// dataCenterSetWebHook registers the EasyCLA webhook on the project, or updates the existing registration
+func (c *Client) dataCenterSetWebHook(projectKey, webhookURL, secret string) error {
+	existing, err := c.dataCenterFindWebHook(projectKey, webhookURL)
+	if err != nil {
+		return err
+	}
+
+	hook := &dataCenterWebhook{
+		Name:          webhookDescription,
+		URL:           webhookURL,
+		Active:        true,
+		Events:        dataCenterWebhookEvents(),
+		Configuration: map[string]string{"secret": secret},
+	}
+	if existing != nil {
+		return c.do(http.MethodPut, c.endpoint("projects", projectKey, "webhooks", strconv.Itoa(existing.ID)), hook, nil)
+	}
+	return c.do(http.MethodPost, c.endpoint("projects", projectKey, "webhooks"), hook, nil)
+}
+
+func (c *Client) dataCenterRemoveWebHook(projectKey, webhookURL string) error {
+	existing, err := c.dataCenterFindWebHook(projectKey, webhookURL)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	err = c.do(http.MethodDelete, c.endpoint("projects", projectKey, "webhooks", strconv.Itoa(existing.ID)), nil, nil)
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// dataCenterSetRequiredBuild requires the EasyCLA build status before merging into the branch, with the required
+// builds merge check. An existing check of the branch requiring our build is left in place.
+func (c *Client) dataCenterSetRequiredBuild(projectKey, repositorySlug, branch string) error {
+	conditionsURL := c.serverEndpoint(requiredBuildsAPIPath, "projects", projectKey, "repos", repositorySlug, "conditions")
+	refID := "refs/heads/" + branch
+
+	var found bool
+	err := c.paginate(conditionsURL, nil, func(value json.RawMessage) (bool, error) {
+		var condition dataCenterRequiredBuild
+		if err := json.Unmarshal(value, &condition); err != nil {
+			return false, err
+		}
+		if condition.RefMatcher.ID != refID {
+			return true, nil
+		}
+		for _, key := range condition.BuildParentKeys {
+			if key == commitStatusKey {
+				found = true
+				return false, nil
+			}
+		}
+		return true, nil
+	})
+	if err != nil || found {
+		return err
+	}
+
+	condition := &dataCenterRequiredBuild{BuildParentKeys: []string{commitStatusKey}}
+	condition.RefMatcher.ID = refID
+	condition.RefMatcher.Type.ID = "BRANCH"
+	return c.do(http.MethodPost, conditionsURL, condition, nil)
+}
+
+// parseDataCenterPullRequestEvent converts the Data Center pull request event payload, the repository of the event
+// is the target repository of the pull request
+func parseDataCenterPullRequestEvent(payload []byte) (*PullRequestEvent, error) {
+	var event dataCenterPullRequestEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.PullRequest == nil || event.PullRequest.ID == 0 {
+		return nil, errors.New("bitbucket event payload is missing the pull request")
+	}
+	if event.PullRequest.ToRef.Repository == nil || event.PullRequest.ToRef.Repository.Slug == "" || event.PullRequest.ToRef.Repository.Project.Key == "" {
+		return nil, errors.New("bitbucket event payload is missing the repository project")
+	}
+
+	pullRequest := event.PullRequest.toPullRequest()
+	out := &PullRequestEvent{
+		PullRequest: pullRequest,
+		Repository:  pullRequest.Destination.Repository,
+		ServerURL:   serverURLFromLink(pullRequest.Links.HTML.Href),
+	}
+	if event.Actor != nil {
+		out.Actor = event.Actor.toAccount()
+	}
+	if event.Comment != nil {
+		out.Comment = event.Comment.toComment()
+	}
+	if out.ServerURL == "" {
+		return nil, errors.New("bitbucket event payload is missing the pull request link")
+	}
+	return out, nil
+}
+
+// serverURLFromLink returns the base URL of the Data Center instance from the link of a project resource, the
+// instances may be served under a context path
+func serverURLFromLink(link string) string {
+	index := strings.Index(link, "/projects/")
+	if index < 0 {
+		return ""
+	}
+	return NormalizeServerURL(link[:index])
+}
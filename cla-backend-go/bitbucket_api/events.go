@@ -0,0 +1,112 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	// EventKeyHeader is the request header holding the webhook event key
+	EventKeyHeader = "X-Event-Key"
+	// SignatureHeader is the request header holding the HMAC-SHA256 signature of the payload: sha256=<hex digest>
+	SignatureHeader = "X-Hub-Signature"
+	// signaturePrefix is the prefix of the signature header value
+	signaturePrefix = "sha256="
+
+	// EventPullRequestCreated is sent when a pull request is opened
+	EventPullRequestCreated = "pullrequest:created"
+	// EventPullRequestUpdated is sent when new commits are pushed to the pull request
+	EventPullRequestUpdated = "pullrequest:updated"
+	// EventPullRequestCommentCreated is sent when a pull request comment is posted - used to re-run the check
+	EventPullRequestCommentCreated = "pullrequest:comment_created"
+
+	// EventDataCenterPullRequestOpened is sent by Data Center when a pull request is opened
+	EventDataCenterPullRequestOpened = "pr:opened"
+	// EventDataCenterPullRequestSourceUpdated is sent by Data Center when new commits are pushed to the pull request
+	EventDataCenterPullRequestSourceUpdated = "pr:from_ref_updated"
+	// EventDataCenterPullRequestCommentAdded is sent by Data Center when a pull request comment is posted
+	EventDataCenterPullRequestCommentAdded = "pr:comment:added"
+)
+
+// PullRequestEvent is the webhook payload of the pull request events
+type PullRequestEvent struct {
+	Actor       *Account     `json:"actor,omitempty"`
+	PullRequest *PullRequest `json:"pullrequest"`
+	Repository  *Repository  `json:"repository"`
+	// Comment is only set on the comment events
+	Comment *Comment `json:"comment,omitempty"`
+	// ServerURL is the base URL of the Data Center instance sending the event, empty for Bitbucket Cloud
+	ServerURL string `json:"-"`
+}
+
+// ValidateSignature checks the payload signature against the webhook secret
+func ValidateSignature(payload []byte, signature, secret string) error {
+	if secret == "" {
+		return errors.New("bitbucket webhook secret is not configured")
+	}
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return errors.New("missing bitbucket webhook signature")
+	}
+	received, err := hex.DecodeString(strings.TrimPrefix(signature, signaturePrefix))
+	if err != nil {
+		return errors.New("invalid bitbucket webhook signature")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload) // nolint
+	if !hmac.Equal(received, mac.Sum(nil)) {
+		return errors.New("bitbucket webhook signature mismatch")
+	}
+	return nil
+}
+
+// IsPullRequestEvent returns true if the event key is one of the pull request events EasyCLA handles
+func IsPullRequestEvent(eventKey string) bool {
+	for _, event := range append(webhookEvents(), dataCenterWebhookEvents()...) {
+		if event == eventKey {
+			return true
+		}
+	}
+	return false
+}
+
+// IsCommentEvent returns true if the event key is a pull request comment event
+func IsCommentEvent(eventKey string) bool {
+	return eventKey == EventPullRequestCommentCreated || eventKey == EventDataCenterPullRequestCommentAdded
+}
+
+// isDataCenterEvent returns true if the event key is one of the Data Center event keys
+func isDataCenterEvent(eventKey string) bool {
+	for _, event := range dataCenterWebhookEvents() {
+		if event == eventKey {
+			return true
+		}
+	}
+	return false
+}
+
+// ParsePullRequestEvent decodes the pull request event payload, the payload must identify the workspace, the
+// repository and the pull request. The Data Center payloads are converted to the Cloud model.
+func ParsePullRequestEvent(eventKey string, payload []byte) (*PullRequestEvent, error) {
+	if isDataCenterEvent(eventKey) {
+		return parseDataCenterPullRequestEvent(payload)
+	}
+
+	var event PullRequestEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.PullRequest == nil || event.PullRequest.ID == 0 {
+		return nil, errors.New("bitbucket event payload is missing the pull request")
+	}
+	if event.Repository == nil || event.Repository.UUID == "" || event.Repository.Workspace == nil || event.Repository.Workspace.Slug == "" {
+		return nil, errors.New("bitbucket event payload is missing the repository workspace")
+	}
+	return &event, nil
+}
@@ -0,0 +1,59 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"sync"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+)
+
+// App is a wrapper for the Bitbucket OAuth consumer configuration items
+type App struct {
+	bitbucketAppID         string
+	bitbucketAppSecret     string
+	bitbucketAppPrivateKey string
+	bitbucketWebhookSecret string
+}
+
+var bitbucketAppSingleton *App
+
+var once sync.Once
+
+// Init initializes the required bitbucket variables
+func Init(bbAppID, bbAppSecret, bbAppPrivateKey, bbWebhookSecret string) *App {
+	if bitbucketAppSingleton == nil {
+		once.Do(
+			func() {
+				log.Debug("Creating object single instance...")
+				bitbucketAppSingleton = &App{
+					bitbucketAppID:         bbAppID,
+					bitbucketAppSecret:     bbAppSecret,
+					bitbucketAppPrivateKey: bbAppPrivateKey,
+					bitbucketWebhookSecret: bbWebhookSecret,
+				}
+			})
+	}
+	return bitbucketAppSingleton
+}
+
+// GetAppID returns the Bitbucket OAuth consumer key
+func (app *App) GetAppID() string {
+	return app.bitbucketAppID
+}
+
+// GetAppSecret returns the Bitbucket OAuth consumer secret
+func (app *App) GetAppSecret() string {
+	return app.bitbucketAppSecret
+}
+
+// GetAppPrivateKey returns the key used to encrypt the stored Bitbucket credentials
+func (app *App) GetAppPrivateKey() string {
+	return app.bitbucketAppPrivateKey
+}
+
+// GetAppWebhookSecret returns the secret used to sign the Bitbucket webhook payloads
+func (app *App) GetAppWebhookSecret() string {
+	return app.bitbucketWebhookSecret
+}
@@ -0,0 +1,307 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CommitStatusSuccessful is the build status state of a passing check
+	CommitStatusSuccessful = "SUCCESSFUL"
+	// CommitStatusFailed is the build status state of a failing check
+	CommitStatusFailed = "FAILED"
+
+	// commitStatusKey identifies our build status on the commit - reporting again with the same key replaces it
+	commitStatusKey = "easycla"
+	// commitStatusName is the build status name displayed in the pull request
+	commitStatusName = "EasyCLA"
+
+	// PullRequestStateOpen is the state of the open pull requests
+	PullRequestStateOpen = "OPEN"
+)
+
+// PullRequestEndpoint is the source or destination of a pull request
+type PullRequestEndpoint struct {
+	Branch     Branch      `json:"branch"`
+	Commit     Commit      `json:"commit"`
+	Repository *Repository `json:"repository,omitempty"`
+}
+
+// PullRequest is a Bitbucket pull request
+type PullRequest struct {
+	ID          int                 `json:"id"`
+	Title       string              `json:"title"`
+	State       string              `json:"state"`
+	Author      *Account            `json:"author,omitempty"`
+	Source      PullRequestEndpoint `json:"source"`
+	Destination PullRequestEndpoint `json:"destination"`
+	Links       Links               `json:"links"`
+}
+
+// CommitAuthor is the author of a commit - the user account is only set if the commit email is linked to a
+// Bitbucket account
+type CommitAuthor struct {
+	Raw  string   `json:"raw"`
+	User *Account `json:"user,omitempty"`
+}
+
+// Commit is a Bitbucket commit
+type Commit struct {
+	Hash   string        `json:"hash"`
+	Author *CommitAuthor `json:"author,omitempty"`
+}
+
+// PullRequestAuthor is a commit author of a pull request with the commits of the author
+type PullRequestAuthor struct {
+	Name    string
+	Email   string
+	Account *Account
+	SHAs    []string
+}
+
+// Comment is a pull request comment
+type Comment struct {
+	ID      int `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	User *Account `json:"user,omitempty"`
+}
+
+// CommitStatus is a commit build status
+type CommitStatus struct {
+	Key         string `json:"key"`
+	State       string `json:"state"`
+	Name        string `json:"name"`
+	URL         string `json:"url"`
+	Description string `json:"description"`
+}
+
+// GetPullRequest returns the pull request
+func (c *Client) GetPullRequest(workspace, repository string, pullRequestID int) (*PullRequest, error) {
+	if c.IsDataCenter() {
+		return c.dataCenterGetPullRequest(workspace, repository, pullRequestID)
+	}
+	var result PullRequest
+	if err := c.do(http.MethodGet, c.endpoint("repositories", workspace, RepositoryRef(repository), "pullrequests", strconv.Itoa(pullRequestID)), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListOpenPullRequests returns the open pull requests of the repository
+func (c *Client) ListOpenPullRequests(workspace, repository string) ([]*PullRequest, error) {
+	if c.IsDataCenter() {
+		return c.dataCenterListOpenPullRequests(workspace, repository)
+	}
+	query := url.Values{}
+	query.Set("state", PullRequestStateOpen)
+
+	var pullRequests []*PullRequest
+	err := c.paginate(c.endpoint("repositories", workspace, RepositoryRef(repository), "pullrequests"), query, func(value json.RawMessage) (bool, error) {
+		var pullRequest PullRequest
+		if err := json.Unmarshal(value, &pullRequest); err != nil {
+			return false, err
+		}
+		pullRequests = append(pullRequests, &pullRequest)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pullRequests, nil
+}
+
+// ListPullRequestCommits returns all the commits of the pull request, following the pagination
+func (c *Client) ListPullRequestCommits(workspace, repository string, pullRequestID int) ([]*Commit, error) {
+	if c.IsDataCenter() {
+		return c.dataCenterListPullRequestCommits(workspace, repository, pullRequestID)
+	}
+	var commits []*Commit
+	err := c.paginate(c.endpoint("repositories", workspace, RepositoryRef(repository), "pullrequests", strconv.Itoa(pullRequestID), "commits"), nil, func(value json.RawMessage) (bool, error) {
+		var commit Commit
+		if err := json.Unmarshal(value, &commit); err != nil {
+			return false, err
+		}
+		commits = append(commits, &commit)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commits, nil
+}
+
+// GetPullRequestAuthors returns the distinct commit authors of the pull request
+func (c *Client) GetPullRequestAuthors(workspace, repository string, pullRequestID int) ([]*PullRequestAuthor, error) {
+	commits, err := c.ListPullRequestCommits(workspace, repository, pullRequestID)
+	if err != nil {
+		return nil, err
+	}
+	return GroupCommitAuthors(commits), nil
+}
+
+// GroupCommitAuthors groups the commits by author, the authors are identified by their commit email or, without an
+// email, by their Bitbucket account
+func GroupCommitAuthors(commits []*Commit) []*PullRequestAuthor {
+	var authors []*PullRequestAuthor
+	authorsByKey := make(map[string]*PullRequestAuthor)
+	for _, commit := range commits {
+		author := &PullRequestAuthor{}
+		if commit.Author != nil {
+			author.Name, author.Email = ParseRawAuthor(commit.Author.Raw)
+			author.Account = commit.Author.User
+		}
+
+		key := strings.ToLower(author.Email)
+		if key == "" && author.Account != nil {
+			key = author.Account.UUID
+		}
+		if key == "" {
+			key = author.Name
+		}
+
+		if existing, ok := authorsByKey[key]; ok {
+			existing.SHAs = append(existing.SHAs, commit.Hash)
+			if existing.Account == nil {
+				existing.Account = author.Account
+			}
+			continue
+		}
+		author.SHAs = []string{commit.Hash}
+		authorsByKey[key] = author
+		authors = append(authors, author)
+	}
+	return authors
+}
+
+// ParseRawAuthor splits the raw commit author value: "Jane Doe <jane@example.org>"
+func ParseRawAuthor(raw string) (string, string) {
+	start := strings.LastIndex(raw, "<")
+	end := strings.LastIndex(raw, ">")
+	if start < 0 || end < start {
+		return strings.TrimSpace(raw), ""
+	}
+	return strings.TrimSpace(raw[:start]), strings.TrimSpace(raw[start+1 : end])
+}
+
+// SetCommitStatus reports the EasyCLA build status of the commit
+func (c *Client) SetCommitStatus(workspace, repository, sha, state, description, targetURL string) error {
+	status := &CommitStatus{
+		Key:         commitStatusKey,
+		State:       state,
+		Name:        commitStatusName,
+		URL:         targetURL,
+		Description: description,
+	}
+	if c.IsDataCenter() {
+		return c.dataCenterSetCommitStatus(sha, status)
+	}
+	return c.do(http.MethodPost, c.endpoint("repositories", workspace, RepositoryRef(repository), "commit", sha, "statuses", "build"), status, nil)
+}
+
+// SetPullRequestComment creates the EasyCLA comment of the pull request, or updates the existing one. The existing
+// comment is recognized by the CLA badge it contains.
+func (c *Client) SetPullRequestComment(workspace, repository string, pullRequestID int, body string) error {
+	if c.IsDataCenter() {
+		return c.dataCenterSetPullRequestComment(workspace, repository, pullRequestID, body)
+	}
+	commentsURL := c.endpoint("repositories", workspace, RepositoryRef(repository), "pullrequests", strconv.Itoa(pullRequestID), "comments")
+
+	var existing *Comment
+	err := c.paginate(commentsURL, nil, func(value json.RawMessage) (bool, error) {
+		var comment Comment
+		if err := json.Unmarshal(value, &comment); err != nil {
+			return false, err
+		}
+		if IsCLAComment(comment.Content.Raw) {
+			existing = &comment
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	payload := map[string]interface{}{
+		"content": map[string]string{"raw": body},
+	}
+	if existing != nil {
+		return c.do(http.MethodPut, commentsURL+"/"+strconv.Itoa(existing.ID), payload, nil)
+	}
+	return c.do(http.MethodPost, commentsURL, payload, nil)
+}
+
+// IsCLAComment returns true if the comment was posted by EasyCLA
+func IsCLAComment(body string) bool {
+	for _, badge := range []string{"cla-signed.svg", "cla-not-signed.svg", "cla-missing-id.svg", "cla-confirmation-needed.svg"} {
+		if strings.Contains(body, badge) {
+			return true
+		}
+	}
+	return false
+}
+
+// GetCurrentUser returns the account of the access token owner
+func (c *Client) GetCurrentUser() (*Account, error) {
+	if c.IsDataCenter() {
+		user, err := c.dataCenterGetCurrentUser()
+		if err != nil {
+			return nil, err
+		}
+		return user.toAccount(), nil
+	}
+	var result Account
+	if err := c.do(http.MethodGet, c.endpoint("user"), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetCurrentUserEmails returns the confirmed emails of the access token owner, the primary email first - the Data
+// Center users have a single email address
+func (c *Client) GetCurrentUserEmails() ([]string, error) {
+	if c.IsDataCenter() {
+		user, err := c.dataCenterGetCurrentUser()
+		if err != nil {
+			return nil, err
+		}
+		if user.EmailAddress == "" {
+			return nil, nil
+		}
+		return []string{user.EmailAddress}, nil
+	}
+	type userEmail struct {
+		Email       string `json:"email"`
+		IsPrimary   bool   `json:"is_primary"`
+		IsConfirmed bool   `json:"is_confirmed"`
+	}
+
+	var emails []string
+	err := c.paginate(c.endpoint("user", "emails"), nil, func(value json.RawMessage) (bool, error) {
+		var email userEmail
+		if err := json.Unmarshal(value, &email); err != nil {
+			return false, err
+		}
+		if !email.IsConfirmed {
+			return true, nil
+		}
+		if email.IsPrimary {
+			emails = append([]string{email.Email}, emails...)
+		} else {
+			emails = append(emails, email.Email)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return emails, nil
+}
@@ -0,0 +1,141 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Link is a Bitbucket hypermedia link
+type Link struct {
+	Href string `json:"href"`
+}
+
+// Links contains the Bitbucket resource links
+type Links struct {
+	HTML Link `json:"html"`
+}
+
+// Account is a Bitbucket user account
+type Account struct {
+	UUID        string `json:"uuid"`
+	AccountID   string `json:"account_id"`
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+	Links       Links  `json:"links"`
+}
+
+// Workspace is a Bitbucket workspace - the owner of the repositories and projects
+type Workspace struct {
+	UUID  string `json:"uuid"`
+	Slug  string `json:"slug"`
+	Name  string `json:"name"`
+	Links Links  `json:"links"`
+}
+
+// Project is a Bitbucket project - a group of repositories within a workspace
+type Project struct {
+	UUID string `json:"uuid"`
+	Key  string `json:"key"`
+	Name string `json:"name"`
+}
+
+// Branch is a Bitbucket branch reference
+type Branch struct {
+	Name string `json:"name"`
+}
+
+// Repository is a Bitbucket repository
+type Repository struct {
+	UUID       string     `json:"uuid"`
+	Name       string     `json:"name"`
+	Slug       string     `json:"slug"`
+	FullName   string     `json:"full_name"`
+	IsPrivate  bool       `json:"is_private"`
+	MainBranch *Branch    `json:"mainbranch,omitempty"`
+	Project    *Project   `json:"project,omitempty"`
+	Workspace  *Workspace `json:"workspace,omitempty"`
+	Links      Links      `json:"links"`
+}
+
+// ExternalID returns the repository UUID without the curly braces - used as the repository identifier in our URLs.
+// The Data Center repositories are identified by their slug within the project.
+func (r *Repository) ExternalID() string {
+	if r.UUID == "" {
+		return r.Slug
+	}
+	return TrimUUID(r.UUID)
+}
+
+// TrimUUID removes the curly braces around the Bitbucket UUID values
+func TrimUUID(uuid string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(uuid, "{"), "}")
+}
+
+// RepositoryRef returns the repository path segment for the repository slug or UUID, the API accepts the UUID
+// surrounded by curly braces in place of the slug
+func RepositoryRef(slugOrUUID string) string {
+	if strings.HasPrefix(slugOrUUID, "{") || !looksLikeUUID(slugOrUUID) {
+		return slugOrUUID
+	}
+	return "{" + slugOrUUID + "}"
+}
+
+func looksLikeUUID(value string) bool {
+	return len(value) == 36 && strings.Count(value, "-") == 4
+}
+
+// GetWorkspace returns the workspace by slug, or the Data Center project by key
+func (c *Client) GetWorkspace(workspace string) (*Workspace, error) {
+	if c.IsDataCenter() {
+		return c.dataCenterGetProject(workspace)
+	}
+	var result Workspace
+	if err := c.do(http.MethodGet, c.endpoint("workspaces", workspace), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetRepository returns the repository by workspace and repository slug or UUID
+func (c *Client) GetRepository(workspace, repository string) (*Repository, error) {
+	if c.IsDataCenter() {
+		return c.dataCenterGetRepository(workspace, repository)
+	}
+	var result Repository
+	if err := c.do(http.MethodGet, c.endpoint("repositories", workspace, RepositoryRef(repository)), nil, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListWorkspaceRepositories returns the repositories of the workspace, restricted to the Bitbucket project when a
+// project key is provided - the Data Center workspace is the project itself
+func (c *Client) ListWorkspaceRepositories(workspace, projectKey string) ([]*Repository, error) {
+	if c.IsDataCenter() {
+		return c.dataCenterListRepositories(workspace)
+	}
+	query := url.Values{}
+	if projectKey != "" {
+		query.Set("q", fmt.Sprintf(`project.key="%s"`, projectKey))
+	}
+
+	var repositories []*Repository
+	err := c.paginate(c.endpoint("repositories", workspace), query, func(value json.RawMessage) (bool, error) {
+		var repository Repository
+		if err := json.Unmarshal(value, &repository); err != nil {
+			return false, err
+		}
+		repositories = append(repositories, &repository)
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return repositories, nil
+}
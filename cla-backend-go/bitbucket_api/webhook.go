@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+)
+
+// webhookDescription is the description of the EasyCLA webhook
+const webhookDescription = "EasyCLA"
+
+// Webhook is a Bitbucket webhook subscription
+type Webhook struct {
+	UUID        string   `json:"uuid,omitempty"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Active      bool     `json:"active"`
+	Events      []string `json:"events"`
+	// Secret signs the payloads with HMAC-SHA256 - write only, Bitbucket never returns it
+	Secret string `json:"secret,omitempty"`
+}
+
+// webhookEvents are the events EasyCLA subscribes to
+func webhookEvents() []string {
+	return []string{EventPullRequestCreated, EventPullRequestUpdated, EventPullRequestCommentCreated}
+}
+
+// dataCenterWebhookEvents are the Data Center events EasyCLA subscribes to
+func dataCenterWebhookEvents() []string {
+	return []string{EventDataCenterPullRequestOpened, EventDataCenterPullRequestSourceUpdated, EventDataCenterPullRequestCommentAdded}
+}
+
+// findWebHook returns the workspace webhook registered for the URL, nil if none
+func (c *Client) findWebHook(workspace, webhookURL string) (*Webhook, error) {
+	var found *Webhook
+	err := c.paginate(c.endpoint("workspaces", workspace, "hooks"), nil, func(value json.RawMessage) (bool, error) {
+		var hook Webhook
+		if err := json.Unmarshal(value, &hook); err != nil {
+			return false, err
+		}
+		if hook.URL == webhookURL {
+			found = &hook
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+// SetWebHook registers the EasyCLA webhook on the workspace - or the Data Center project, or updates the existing registration - updating also
+// (re)sets the secret of the webhooks registered before the payloads were signed
+func (c *Client) SetWebHook(workspace, webhookURL, secret string) error {
+	if secret == "" {
+		return errors.New("bitbucket webhook secret is not configured")
+	}
+	if c.IsDataCenter() {
+		return c.dataCenterSetWebHook(workspace, webhookURL, secret)
+	}
+
+	existing, err := c.findWebHook(workspace, webhookURL)
+	if err != nil {
+		return err
+	}
+
+	hook := &Webhook{
+		Description: webhookDescription,
+		URL:         webhookURL,
+		Active:      true,
+		Events:      webhookEvents(),
+		Secret:      secret,
+	}
+	if existing != nil {
+		return c.do(http.MethodPut, c.endpoint("workspaces", workspace, "hooks", existing.UUID), hook, nil)
+	}
+	return c.do(http.MethodPost, c.endpoint("workspaces", workspace, "hooks"), hook, nil)
+}
+
+// RemoveWebHook removes the EasyCLA webhook from the workspace, a missing webhook is not an error
+func (c *Client) RemoveWebHook(workspace, webhookURL string) error {
+	if c.IsDataCenter() {
+		return c.dataCenterRemoveWebHook(workspace, webhookURL)
+	}
+	existing, err := c.findWebHook(workspace, webhookURL)
+	if err != nil {
+		return err
+	}
+	if existing == nil {
+		return nil
+	}
+	err = c.do(http.MethodDelete, c.endpoint("workspaces", workspace, "hooks", existing.UUID), nil, nil)
+	if IsNotFound(err) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,141 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	claevents "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	gitlab "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/branch_protection_audit"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
+	v2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var branchProtectionAuditService branch_protection_audit.Service
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+	configFile, err := config.LoadConfig("", awsSession, stage)
+	if err != nil {
+		log.Panicf("Unable to load config - Error: %v", err)
+	}
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	v2Repository := v2Repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
+	gitlabOrganizationRepo := gitlab_organizations.NewRepository(awsSession, stage)
+	storeRepo := store.NewRepository(awsSession, stage)
+	approvalsTableName := fmt.Sprintf("cla-%s-approvals", stage)
+	approvalRepo := approvals.NewRepository(stage, awsSession, approvalsTableName)
+	gerritService := gerrits.NewService(gerritRepo)
+
+	github.Init(configFile.GitHub.AppID, configFile.GitHub.AppPrivateKey, configFile.GitHub.AccessToken)
+	gitlabApp := gitlab.Init(configFile.Gitlab.AppClientID, configFile.Gitlab.AppClientSecret, configFile.Gitlab.AppPrivateKey)
+
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		repository.ProjectRepository
+		projects_cla_groups.Repository
+	}
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+		projectClaGroupRepo,
+	})
+
+	usersService := users.NewService(usersRepo, eventsService)
+	signaturesRepo := signatures.NewRepository(awsSession, stage, companyRepo, usersRepo, eventsService, repositoriesRepo, githubOrganizationsRepo, gerritService, approvalRepo)
+	v2RepositoryService := v2Repositories.NewService(repositoriesRepo, v2Repository, projectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, eventsService)
+	gitlabOrgService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoryService, projectClaGroupRepo, storeRepo, usersService, signaturesRepo, companyRepo)
+
+	branchProtectionAuditService = branch_protection_audit.NewService(githubOrganizationsRepo, gitlabOrganizationRepo, gitlabOrgService, v2Repository, eventsService, gitlabApp)
+}
+
+func handler(ctx context.Context, event events.CloudWatchEvent) {
+	defer github.LogRateLimitMetrics(ctx)
+
+	repair := os.Getenv("REPAIR") == "true"
+	report, err := branchProtectionAuditService.AuditBranchProtection(github.WithRateLimitWait(utils.NewContextFromParent(ctx)), repair)
+	if err != nil {
+		log.WithError(err).Warn("unable to audit the branch protection of the repositories")
+		return
+	}
+
+	for _, drift := range report.Drifted {
+		log.Infof("%s repository %s/%s branch %s drifted: %v - repaired: %t %s", drift.Provider, drift.OrganizationName,
+			drift.RepositoryName, drift.BranchName, drift.Drift, drift.Repaired, drift.Error)
+	}
+	for _, failed := range report.Failed {
+		log.Warnf("%s repository %s/%s couldn't be audited: %s", failed.Provider, failed.OrganizationName, failed.RepositoryName, failed.Error)
+	}
+	log.Infof("audited %d repositories - drifted: %d, failed: %d, repair: %t", report.RepositoriesChecked, len(report.Drifted), len(report.Failed), repair)
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		handler(utils.NewContext(), events.CloudWatchEvent{})
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
@@ -15,6 +15,7 @@ import (
 	v2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
 
+	gitlab_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab-activity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
 
 	gitlab "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
@@ -37,7 +38,9 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/dynamo_events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/token"
 
@@ -142,6 +145,21 @@ func init() {
 	v2CompanyService := v2Company.NewService(companyService, signaturesRepo, projectRepo, usersRepo, companyRepo, projectClaGroupRepo, eventsService)
 	organization_service.InitClient(configFile.APIGatewayURL, eventsService)
 	acs_service.InitClient(configFile.APIGatewayURL, configFile.AcsAPIKey)
+	claCheckService := cla_check.NewService(usersRepo, signaturesRepo, companyRepo, projectService)
+	signaturesService := signatures.NewService(signaturesRepo, companyService, usersService, eventsService, true, repositoriesService, githubOrganizationsService, projectService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL, claCheckService)
+	gitlabActivityService := gitlab_activity.NewService(repositoriesRepo, v2Repository, usersRepo, signaturesRepo, projectClaGroupRepo, companyRepo, signaturesRepo, gitlabOrgService, projectService, eventsService)
+	var webhookQueue webhooks.DeliveryQueue
+	if webhookQueueURL := os.Getenv("WEBHOOK_DELIVERY_QUEUE_URL"); webhookQueueURL != "" {
+		webhookQueue = webhooks.NewSQSDeliveryQueue(awsSession, webhookQueueURL)
+	}
+	webhookService := webhooks.NewService(webhooks.NewRepository(awsSession, stage), webhooks.NewKMSSecretCipher(awsSession, stage), webhookQueue)
+	lfGroup := &gerrits.LFGroup{
+		LfBaseURL:     configFile.LFGroup.ClientURL,
+		ClientID:      configFile.LFGroup.ClientID,
+		ClientSecret:  configFile.LFGroup.ClientSecret,
+		RefreshToken:  configFile.LFGroup.RefreshToken,
+		EventsService: eventsService,
+	}
 	dynamoEventsService = dynamo_events.NewService(
 		stage,
 		signaturesRepo,
@@ -156,10 +174,15 @@ func init() {
 		githubOrganizationsService,
 		repositoriesService,
 		gerritService,
+		lfGroup,
+		usersService,
 		claManagerRequestsRepo,
 		approvalListRequestsRepo,
 		gitlabApp,
 		gitlabOrgService,
+		signaturesService,
+		gitlabActivityService,
+		webhookService,
 	)
 }
 
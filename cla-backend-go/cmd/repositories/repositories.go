@@ -196,10 +196,11 @@ func (t *TestBehaviour) RunUpdateProtectionBranch(msg string, param *models.Gith
 		})
 
 	t.RunGetProtectedBranch(&models.GithubRepositoryBranchProtection{
-		BranchName:        swag.String("master"),
-		EnforceAdmin:      *param.EnforceAdmin,
-		ProtectionEnabled: true,
-		StatusChecks:      param.StatusChecks,
+		BranchName:          swag.String("master"),
+		EnforceAdmin:        *param.EnforceAdmin,
+		ProtectionEnabled:   true,
+		ProtectionMechanism: "classic",
+		StatusChecks:        param.StatusChecks,
 	})
 }
 
@@ -57,7 +57,7 @@ func init() {
 	if err != nil {
 		log.Fatal(err)
 	}
-	signService = sign.NewService("", "", companyRepo, nil, nil, nil, nil, configFile.DocuSignPrivateKey, nil, nil, nil, nil, githubOrgService, nil, "", "", nil, nil, nil, nil, nil)
+	signService = sign.NewService("", "", companyRepo, nil, nil, nil, nil, configFile.DocuSignPrivateKey, nil, nil, nil, nil, githubOrgService, nil, "", "", nil, nil, nil, nil, nil, sign.ProviderDocuSign, nil, nil, nil)
 	// projectRepo = repository.NewRepository(awsSession, stage, nil, nil, nil)
 	utils.SetS3Storage(awsSession, configFile.SignatureFilesBucket)
 }
@@ -297,7 +297,7 @@ func main() { // nolint
 			}
 
 			// get the document
-			document, docErr := signService.GetSignedDocument(ctx, envelopeID, documentID)
+			document, docErr := signService.GetSignedDocument(ctx, sig.SignatureProvider, envelopeID, documentID)
 			if docErr != nil {
 				log.WithFields(f).WithError(docErr).Debugf("unable to get document for signature: %s", sig.SignatureID)
 				reportData.Comment = docErr.Error()
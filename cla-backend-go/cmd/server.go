@@ -19,6 +19,11 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
 	"github.com/linuxfoundation/easycla/cla-backend-go/project/service"
 
+	bitbucket_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/bitbucket-activity"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/bitbucket_organizations"
+	gerrit_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gerrit-activity"
+	gitea_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gitea-activity"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitea_organizations"
 	gitlab_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab-activity"
 
 	"github.com/go-openapi/strfmt"
@@ -37,6 +42,7 @@ import (
 
 	openapi_runtime "github.com/go-openapi/runtime"
 	"github.com/linuxfoundation/easycla/cla-backend-go/approval_list"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_groups"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
@@ -56,6 +62,7 @@ import (
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
 	v2Gerrits "github.com/linuxfoundation/easycla/cla-backend-go/v2/gerrits"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/aws/aws-sdk-go/service/dynamodb"
 
@@ -86,7 +93,6 @@ import (
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/auth"
 	v1Company "github.com/linuxfoundation/easycla/cla-backend-go/company"
-	"github.com/linuxfoundation/easycla/cla-backend-go/docraptor"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations"
@@ -174,6 +180,15 @@ func server(localMode bool) http.Handler {
 		}
 	}
 
+	var signatureProvider = sign.ProviderDocuSign // default is DocuSign, used by the CLA Groups without a signature provider
+	signatureProviderString := viper.GetString("SIGNATURE_PROVIDER")
+	if signatureProviderString != "" {
+		if signatureProviderString != sign.ProviderDocuSign && signatureProviderString != sign.ProviderClickThrough {
+			log.WithFields(f).Fatalf("SIGNATURE_PROVIDER value must be one of: %s, %s", sign.ProviderDocuSign, sign.ProviderClickThrough)
+		}
+		signatureProvider = signatureProviderString
+	}
+
 	stage := viper.GetString("STAGE")
 	dynamodbRegion := ini.GetProperty("DYNAMODB_AWS_REGION")
 
@@ -191,6 +206,7 @@ func server(localMode bool) http.Handler {
 		log.Infof("DYANAMODB_AWS_REGION    : %s", dynamodbRegion)
 		log.Infof("GH_ORG_VALIDATION       : %t", githubOrgValidation)
 		log.Infof("COMPANY_USER_VALIDATION : %t", companyUserValidation)
+		log.Infof("SIGNATURE_PROVIDER      : %s", signatureProvider)
 		log.Infof("STAGE                   : %s", stage)
 		log.Infof("Service Host            : %s", host)
 		log.Infof("Service Port            : %d", *portFlag)
@@ -205,6 +221,7 @@ func server(localMode bool) http.Handler {
 		f["dynamoDBRegion"] = dynamodbRegion
 		f["githubOrgValidation"] = githubOrgValidation
 		f["companyUserValidation"] = companyUserValidation
+		f["signatureProvider"] = signatureProvider
 		f["stage"] = stage
 		f["serviceHost"] = host
 		log.WithFields(f).Info("config")
@@ -230,9 +247,13 @@ func server(localMode bool) http.Handler {
 	api := operations.NewClaAPI(swaggerSpec)
 	v2API := v2Ops.NewEasyclaAPI(v2SwaggerSpec)
 
-	docraptorClient, err := docraptor.NewDocraptorClient(configFile.Docraptor.APIKey, configFile.Docraptor.TestMode)
+	pdfRenderer, err := template.NewPDFRenderer(configFile)
+	if err != nil {
+		log.WithFields(f).WithError(err).Panicf("unable to setup the %s pdf renderer", configFile.PDFRenderer)
+	}
+	previewRenderer, err := template.NewPreviewPDFRenderer(configFile)
 	if err != nil {
-		log.WithFields(f).WithError(err).Panic("unable to setup docraptor client")
+		log.WithFields(f).WithError(err).Panicf("unable to setup the %s preview pdf renderer", configFile.PreviewPDFRenderer)
 	}
 
 	// LG: to test with manual tokens
@@ -257,6 +278,7 @@ func server(localMode bool) http.Handler {
 	gitV2Repository := v2Repositories.NewRepository(awsSession, stage)
 	gerritRepo := gerrits.NewRepository(awsSession, stage)
 	templateRepo := template.NewRepository(awsSession, stage)
+	customTemplateRepo := template.NewCustomTemplateRepository(awsSession, stage)
 	approvalListRepo := approval_list.NewRepository(awsSession, stage)
 	v1CompanyRepo := v1Company.NewRepository(awsSession, stage)
 	eventsRepo := events.NewRepository(awsSession, stage)
@@ -265,9 +287,12 @@ func server(localMode bool) http.Handler {
 	metricsRepo := metrics.NewRepository(awsSession, stage, configFile.APIGatewayURL, v1ProjectClaGroupRepo)
 	githubOrganizationsRepo := github_organizations.NewRepository(awsSession, stage)
 	gitlabOrganizationRepo := gitlab_organizations.NewRepository(awsSession, stage)
+	bitbucketOrganizationRepo := bitbucket_organizations.NewRepository(awsSession, stage)
+	giteaOrganizationRepo := gitea_organizations.NewRepository(awsSession, stage)
 	claManagerReqRepo := cla_manager.NewRepository(awsSession, stage)
 	storeRepository := store.NewRepository(awsSession, stage)
 	approvalsRepo := approvals.NewRepository(stage, awsSession, fmt.Sprintf("cla-%s-approvals", stage))
+	webhooksRepo := webhooks.NewRepository(awsSession, stage)
 
 	// Our service layer handlers
 	eventsService := events.NewService(eventsRepo, combinedRepo{
@@ -278,6 +303,7 @@ func server(localMode bool) http.Handler {
 	})
 
 	gerritService := gerrits.NewService(gerritRepo)
+	webhooksService := webhooks.NewService(webhooksRepo, webhooks.NewKMSSecretCipher(awsSession, stage), nil)
 
 	// Signature repository handler
 	signaturesRepo := signatures.NewRepository(awsSession, stage, v1CompanyRepo, usersRepo, eventsService, gitV1Repository, githubOrganizationsRepo, gerritService, approvalsRepo)
@@ -293,7 +319,7 @@ func server(localMode bool) http.Handler {
 	v1ProjectClaGroupService := projects_cla_groups.NewService(v1ProjectClaGroupRepo)
 	usersService := users.NewService(usersRepo, eventsService)
 	healthService := health.New(Version, Commit, Branch, BuildDate)
-	templateService := template.NewService(stage, templateRepo, docraptorClient, awsSession)
+	templateService := template.NewService(stage, templateRepo, customTemplateRepo, pdfRenderer, previewRenderer, awsSession)
 	v1ProjectService := service.NewService(v1CLAGroupRepo, gitV1Repository, gerritRepo, v1ProjectClaGroupRepo, usersRepo)
 	emailTemplateService := emails.NewEmailTemplateService(v1CLAGroupRepo, v1ProjectClaGroupRepo, v1ProjectService, configFile.CorporateConsoleV1URL, configFile.CorporateConsoleV2URL)
 	emailService := emails.NewService(emailTemplateService, v1ProjectService)
@@ -306,21 +332,28 @@ func server(localMode bool) http.Handler {
 	v2RepositoriesService := v2Repositories.NewService(gitV1Repository, gitV2Repository, v1ProjectClaGroupRepo, githubOrganizationsRepo, gitlabOrganizationRepo, eventsService)
 	githubOrganizationsService := github_organizations.NewService(githubOrganizationsRepo, gitV1Repository, v1ProjectClaGroupRepo)
 	gitlabOrganizationsService := gitlab_organizations.NewService(gitlabOrganizationRepo, v2RepositoriesService, v1ProjectClaGroupRepo, storeRepository, usersService, signaturesRepo, v1CompanyRepo)
-	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation, v1RepositoriesService, githubOrganizationsService, v1ProjectService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL)
-	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, v1ProjectService, v1CompanyService, v1SignaturesService, v1ProjectClaGroupRepo, signaturesRepo, usersService, approvalsRepo)
+	claCheckService := cla_check.NewService(usersRepo, signaturesRepo, v1CompanyRepo, v1ProjectService)
+	v1SignaturesService := signatures.NewService(signaturesRepo, v1CompanyService, usersService, eventsService, githubOrgValidation, v1RepositoriesService, githubOrganizationsService, v1ProjectService, gitlabApp, configFile.ClaV1ApiURL, configFile.CLALandingPage, configFile.CLALogoURL, claCheckService)
 	v1ClaManagerService := cla_manager.NewService(claManagerReqRepo, v1ProjectClaGroupRepo, v1CompanyService, v1ProjectService, usersService, v1SignaturesService, eventsService, emailTemplateService, configFile.CorporateConsoleV1URL)
 	v2ClaManagerService := v2ClaManager.NewService(emailTemplateService, v1CompanyService, v1ProjectService, v1ClaManagerService, usersService, v1RepositoriesService, v2CompanyService, eventsService, v1ProjectClaGroupRepo)
 	v1ApprovalListService := approval_list.NewService(approvalListRepo, v1ProjectClaGroupRepo, v1ProjectService, usersRepo, v1CompanyRepo, v1CLAGroupRepo, signaturesRepo, emailTemplateService, configFile.CorporateConsoleV2URL, http.DefaultClient)
 	authorizer := auth.NewAuthorizer(authValidator, userRepo)
 	v2MetricsService := metrics.NewService(metricsRepo, v1ProjectClaGroupRepo)
-	gitlabActivityService := gitlab_activity.NewService(gitV1Repository, gitV2Repository, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, signaturesRepo, gitlabOrganizationsService)
+	gitlabActivityService := gitlab_activity.NewService(gitV1Repository, gitV2Repository, usersRepo, signaturesRepo, v1ProjectClaGroupRepo, v1CompanyRepo, signaturesRepo, gitlabOrganizationsService, v1ProjectService, eventsService)
 	gitlabSignService := gitlab_sign.NewService(v2RepositoriesService, usersService, storeRepository, gitlabApp, gitlabOrganizationsService)
+	bitbucketOrganizationsService := bitbucket_organizations.NewService(bitbucketOrganizationRepo, v1ProjectClaGroupRepo, storeRepository, usersService)
+	bitbucketActivityService := bitbucket_activity.NewService(usersRepo, signaturesRepo, v1CompanyRepo, v1ProjectService, bitbucketOrganizationsService)
+	giteaOrganizationsService := gitea_organizations.NewService(giteaOrganizationRepo, v2RepositoriesService, v1ProjectClaGroupRepo, storeRepository, usersService)
+	giteaActivityService := gitea_activity.NewService(usersRepo, signaturesRepo, v1CompanyRepo, v1ProjectService, giteaOrganizationsService, v2RepositoriesService)
+	gerritActivityService := gerrit_activity.NewService(usersRepo, signaturesRepo, v1CompanyRepo, v1ProjectService, gerritService)
 	v2GithubOrganizationsService := v2GithubOrganizations.NewService(githubOrganizationsRepo, gitV1Repository, v1ProjectClaGroupRepo, githubOrganizationsService)
 	autoEnableService := dynamo_events.NewAutoEnableService(v1RepositoriesService, gitV1Repository, githubOrganizationsRepo, v1ProjectClaGroupRepo, v1ProjectService)
-	v2GithubActivityService := v2GithubActivity.NewService(gitV1Repository, githubOrganizationsRepo, eventsService, autoEnableService, emailService)
+	v2GithubActivityService := v2GithubActivity.NewService(gitV1Repository, githubOrganizationsRepo, eventsService, autoEnableService, emailService, v1SignaturesService)
 
 	v2ClaGroupService := cla_groups.NewService(v1ProjectService, templateService, v1ProjectClaGroupRepo, v1ClaManagerService, v1SignaturesService, metricsRepo, gerritService, v1RepositoriesService, eventsService)
-	v2SignService := sign.NewService(configFile.ClaAPIV4Base, configFile.ClaV1ApiURL, v1CompanyRepo, v1CLAGroupRepo, v1ProjectClaGroupRepo, v1CompanyService, v2ClaGroupService, configFile.DocuSignPrivateKey, usersService, v1SignaturesService, storeRepository, v1RepositoriesService, githubOrganizationsService, gitlabOrganizationsService, configFile.CLALandingPage, configFile.CLALogoURL, emailService, eventsService, gitlabActivityService, gitlabApp, gerritService)
+	v2SignService := sign.NewService(configFile.ClaAPIV4Base, configFile.ClaV1ApiURL, v1CompanyRepo, v1CLAGroupRepo, v1ProjectClaGroupRepo, v1CompanyService, v2ClaGroupService, configFile.DocuSignPrivateKey, usersService, v1SignaturesService, storeRepository, v1RepositoriesService, githubOrganizationsService, gitlabOrganizationsService, configFile.CLALandingPage, configFile.CLALogoURL, emailService, eventsService, gitlabActivityService, gitlabApp, gerritService, signatureProvider, bitbucketActivityService, giteaActivityService, claCheckService)
+	v2SignatureService := v2Signatures.NewService(awsSession, configFile.SignatureFilesBucket, v1ProjectService, v1CompanyService, v1SignaturesService, v1ProjectClaGroupRepo, signaturesRepo, usersService, approvalsRepo, v2SignService)
+	v2SignatureExportService := v2Signatures.NewExportService(awsSession, stage, configFile.SignatureFilesBucket, v2Signatures.NewExportRepository(awsSession, stage), v1CompanyRepo, usersRepo, eventsService)
 
 	sessionStore, err := dynastore.New(dynastore.Path("/"), dynastore.HTTPOnly(), dynastore.TableName(configFile.SessionStoreTableName), dynastore.DynamoDB(dynamodb.New(awsSession)))
 	if err != nil {
@@ -343,7 +376,7 @@ func server(localMode bool) http.Handler {
 	v2Template.Configure(v2API, templateService, v1ProjectClaGroupService, eventsService)
 	github.Configure(api, configFile.GitHub.ClientID, configFile.GitHub.ClientSecret, configFile.GitHub.AccessToken, sessionStore)
 	signatures.Configure(api, v1SignaturesService, sessionStore, eventsService)
-	v2Signatures.Configure(v2API, v1ProjectService, v1CLAGroupRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, v1ProjectClaGroupRepo)
+	v2Signatures.Configure(v2API, v1ProjectService, v1CLAGroupRepo, v1CompanyService, v1SignaturesService, sessionStore, eventsService, v2SignatureService, v1ProjectClaGroupRepo, v2SignatureExportService)
 	approval_list.Configure(api, v1ApprovalListService, sessionStore, v1SignaturesService, eventsService)
 	v1Company.Configure(api, v1CompanyService, usersService, companyUserValidation, eventsService)
 	docs.Configure(api)
@@ -358,6 +391,11 @@ func server(localMode bool) http.Handler {
 	gitlab_organizations.Configure(v2API, gitlabOrganizationsService, eventsService, sessionStore, configFile.CLAContributorv2Base)
 	gitlab_sign.Configure(v2API, gitlabSignService, eventsService, configFile.CLAContributorv2Base, sessionStore)
 	gitlab_activity.Configure(v2API, gitlabActivityService, gitlabOrganizationsService, eventsService, gitlabApp, gitlabSignService, configFile.CLAContributorv2Base, sessionStore)
+	bitbucket_organizations.Configure(v2API, bitbucketOrganizationsService, eventsService, sessionStore, configFile.CLAContributorv2Base)
+	bitbucket_activity.Configure(v2API, bitbucketActivityService)
+	gitea_organizations.Configure(v2API, giteaOrganizationsService, eventsService, sessionStore, configFile.CLAContributorv2Base)
+	gitea_activity.Configure(v2API, giteaActivityService)
+	gerrit_activity.Configure(v2API, gerritActivityService)
 	v1Repositories.Configure(api, v1RepositoriesService, eventsService)
 	v2Repositories.Configure(v2API, v2RepositoriesService, eventsService)
 	gerrits.Configure(api, gerritService, v1ProjectService, eventsService)
@@ -369,10 +407,13 @@ func server(localMode bool) http.Handler {
 	cla_groups.Configure(v2API, v2ClaGroupService, v1ProjectService, v1ProjectClaGroupRepo, eventsService)
 	sign.Configure(v2API, v2SignService, usersService)
 	v2GithubActivity.Configure(v2API, v2GithubActivityService)
+	webhooks.Configure(v2API, webhooksService, eventsService, v1ProjectClaGroupRepo)
 
 	v2API.AddMiddlewareFor("POST", "/signed/individual/{installation_id}/{github_repository_id}/{change_request_id}", sign.DocusignMiddleware)
 	v2API.AddMiddlewareFor("POST", "/signed/corporate/{project_id}/{company_id}", sign.CCLADocusignMiddleware)
 	v2API.AddMiddlewareFor("POST", "/signed/gitlab/individual/{user_id}/{organization_id}/{gitlab_repository_id}/{merge_request_id}", sign.DocusignMiddleware)
+	v2API.AddMiddlewareFor("POST", "/signed/bitbucket/individual/{user_id}/{organization_id}/{bitbucket_repository_id}/{pull_request_id}", sign.DocusignMiddleware)
+	v2API.AddMiddlewareFor("POST", "/signed/gitea/individual/{user_id}/{organization_id}/{gitea_repository_id}/{pull_request_id}", sign.DocusignMiddleware)
 	v2API.AddMiddlewareFor("POST", "/signed/gerrit/individual/{user_id}", sign.DocusignMiddleware)
 
 	userCreaterMiddleware := func(next http.Handler) http.Handler {
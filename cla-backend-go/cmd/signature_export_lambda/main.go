@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"os"
+
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	claevents "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/signatures"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var exportService signatures.ExportServiceInterface
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE : %s", stage)
+	signaturesFileBucket := os.Getenv("CLA_SIGNATURE_FILES_BUCKET")
+	if signaturesFileBucket == "" {
+		log.Fatal("CLA_SIGNATURE_FILES_BUCKET is not set in environment")
+	}
+	log.Infof("CLA_SIGNATURE_FILES_BUCKET : %s", signaturesFileBucket)
+
+	usersRepo := users.NewRepository(awsSession, stage)
+	companyRepo := company.NewRepository(awsSession, stage)
+	projectClaGroupRepo := projects_cla_groups.NewRepository(awsSession, stage)
+	repositoriesRepo := repositories.NewRepository(awsSession, stage)
+	gerritRepo := gerrits.NewRepository(awsSession, stage)
+	projectRepo := repository.NewRepository(awsSession, stage, repositoriesRepo, gerritRepo, projectClaGroupRepo)
+	eventsRepo := claevents.NewRepository(awsSession, stage)
+
+	type combinedRepo struct {
+		users.UserRepository
+		company.IRepository
+		repository.ProjectRepository
+		projects_cla_groups.Repository
+	}
+
+	eventsService := claevents.NewService(eventsRepo, combinedRepo{
+		usersRepo,
+		companyRepo,
+		projectRepo,
+		projectClaGroupRepo,
+	})
+
+	exportService = signatures.NewExportService(awsSession, stage, signaturesFileBucket, signatures.NewExportRepository(awsSession, stage), companyRepo, usersRepo, eventsService)
+}
+
+func handler(ctx context.Context, event signatures.ExportJobEvent) error {
+	log.WithField("event", event).Debug("signature export called")
+	err := exportService.RunExportJob(utils.NewContextFromParent(ctx), event.JobID)
+	if err != nil {
+		log.WithField("event", event).WithError(err).Warn("unable to run the signature export job")
+	}
+	return err
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	if os.Getenv("LOCAL_MODE") == "true" {
+		if len(os.Args) != 2 {
+			log.Fatal("invalid number of args. the first arg should be the signature export job ID")
+		}
+		err := handler(utils.NewContext(), signatures.ExportJobEvent{JobID: os.Args[1]})
+		if err != nil {
+			log.Fatal(err)
+		}
+	} else {
+		lambda.Start(handler)
+	}
+	log.Infof("Lambda shutting down...")
+}
@@ -0,0 +1,84 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
+)
+
+var (
+	// version the application version
+	version string
+
+	// build/Commit the application build number
+	commit string
+
+	// branch the build branch
+	branch string
+
+	// build date
+	buildDate string
+)
+
+var webhookService webhooks.ServiceInterface
+
+func init() {
+	var awsSession = session.Must(session.NewSession(&aws.Config{}))
+	stage := os.Getenv("STAGE")
+	if stage == "" {
+		log.Fatal("stage not set")
+	}
+	log.Infof("STAGE set to %s\n", stage)
+
+	webhookService = webhooks.NewService(webhooks.NewRepository(awsSession, stage), webhooks.NewKMSSecretCipher(awsSession, stage), nil)
+}
+
+// handler processes the queued webhook deliveries - the queue is configured with a batch size of one so that a
+// failed delivery only returns its own message to the queue
+func handler(ctx context.Context, event events.SQSEvent) error {
+	for _, record := range event.Records {
+		var message webhooks.DeliveryMessage
+		if err := json.Unmarshal([]byte(record.Body), &message); err != nil {
+			// a malformed message can't be delivered, retrying it would not help
+			log.WithField("messageID", record.MessageId).WithError(err).Warn("unable to unmarshal the webhook delivery message - dropping it")
+			continue
+		}
+
+		receiveCount, err := strconv.Atoi(record.Attributes["ApproximateReceiveCount"])
+		if err != nil {
+			receiveCount = 1
+		}
+
+		if err = webhookService.ProcessDelivery(utils.NewContextFromParent(ctx), &message, receiveCount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func printBuildInfo() {
+	log.Infof("Version                 : %s", version)
+	log.Infof("Git commit hash         : %s", commit)
+	log.Infof("Branch                  : %s", branch)
+	log.Infof("Build date              : %s", buildDate)
+}
+
+func main() {
+	log.Info("Lambda server starting...")
+	printBuildInfo()
+	lambda.Start(handler)
+	log.Infof("Lambda shutting down...")
+}
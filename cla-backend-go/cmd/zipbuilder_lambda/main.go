@@ -62,7 +62,7 @@ func handler(ctx context.Context, event BuildZipEvent) error {
 	switch event.SignatureType {
 	case utils.ClaTypeICLA:
 		if event.FileType == utils.FileTypePDF {
-			err = zipBuilder.BuildICLAPDFZip(event.ClaGroupID)
+			err = zipBuilder.BuildICLAPDFZip(ctx, event.ClaGroupID)
 		} else if event.FileType == utils.FileTypeCSV {
 			err = zipBuilder.BuildICLACSVZip(event.ClaGroupID)
 		} else {
@@ -70,7 +70,7 @@ func handler(ctx context.Context, event BuildZipEvent) error {
 		}
 	case utils.ClaTypeCCLA:
 		if event.FileType == utils.FileTypePDF {
-			err = zipBuilder.BuildCCLAPDFZip(event.ClaGroupID)
+			err = zipBuilder.BuildCCLAPDFZip(ctx, event.ClaGroupID)
 		} else if event.FileType == utils.FileTypeCSV {
 			err = zipBuilder.BuildCCLACSVZip(event.ClaGroupID)
 		} else {
@@ -43,6 +43,14 @@ type Config struct {
 	// Docraptor
 	Docraptor Docraptor `json:"docraptor"`
 
+	// PDFRenderer selects the backend rendering the CLA Group documents - docraptor (default), the local renderer only
+	// renders the template previews
+	PDFRenderer string `json:"pdfRenderer"`
+
+	// PreviewPDFRenderer selects the backend rendering the template previews - docraptor or local, defaults to the
+	// PDFRenderer
+	PreviewPDFRenderer string `json:"previewPdfRenderer"`
+
 	// LF Identity
 
 	// AWS
@@ -54,6 +62,15 @@ type Config struct {
 	// Gitlab Application
 	Gitlab Gitlab `json:"gitlab"`
 
+	// Bitbucket OAuth Consumer
+	Bitbucket Bitbucket `json:"bitbucket"`
+
+	// Gitea / Forgejo self-hosted instances
+	Gitea Gitea `json:"gitea"`
+
+	// Gerrit self-hosted instances enforcing the CLA through the Gerrit REST API
+	Gerrit Gerrit `json:"gerrit"`
+
 	// Dynamo Session Store
 	SessionStoreTableName string `json:"sessionStoreTableName"`
 
@@ -158,6 +175,31 @@ type Gitlab struct {
 	WebHookURI      string `json:"app_web_hook_uri"`
 }
 
+// Bitbucket config data model
+type Bitbucket struct {
+	AppClientID     string `json:"app_client_id"`
+	AppClientSecret string `json:"app_client_secret"`
+	AppPrivateKey   string `json:"app_client_private_key"`
+	WebhookSecret   string `json:"app_web_hook_secret"`
+	RedirectURI     string `json:"app_redirect_uri"`
+	WebHookURI      string `json:"app_web_hook_uri"`
+}
+
+// Gitea config data model - the instance specific OAuth applications are stored with the Gitea organizations
+type Gitea struct {
+	AppPrivateKey string `json:"app_client_private_key"`
+	WebhookSecret string `json:"app_web_hook_secret"`
+	RedirectURI   string `json:"app_redirect_uri"`
+	WebHookURI    string `json:"app_web_hook_uri"`
+}
+
+// Gerrit config data model - the instance specific service account credentials are stored with the Gerrit instances
+type Gerrit struct {
+	AppPrivateKey string `json:"app_client_private_key"`
+	WebhookSecret string `json:"app_web_hook_secret"`
+	WebHookURI    string `json:"app_web_hook_uri"`
+}
+
 // MetricsReport keeps the config needed to send the metrics data report
 type MetricsReport struct {
 	AwsSQSRegion   string `json:"aws_sqs_region"`
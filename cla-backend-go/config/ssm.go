@@ -13,6 +13,7 @@ import (
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/ssm"
 )
@@ -37,6 +38,14 @@ func getSSMString(ssmClient *ssm.SSM, key string) (string, error) {
 	return strings.TrimSpace(*value.Parameter.Value), nil
 }
 
+// isParameterNotFound returns true if the SSM lookup error indicates the parameter does not exist
+func isParameterNotFound(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		return aerr.Code() == ssm.ErrCodeParameterNotFound
+	}
+	return false
+}
+
 // loadSSMConfig fetches all the configuration values and populates the response Config model
 func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 	f := logrus.Fields{
@@ -75,11 +84,26 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-gitlab-app-private-key-%s", stage),
 		fmt.Sprintf("cla-gitlab-app-redirect-uri-%s", stage),
 		fmt.Sprintf("cla-gitlab-app-web-hook-uri-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-id-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-secret-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-private-key-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-web-hook-secret-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-redirect-uri-%s", stage),
+		fmt.Sprintf("cla-bitbucket-app-web-hook-uri-%s", stage),
+		fmt.Sprintf("cla-gitea-app-private-key-%s", stage),
+		fmt.Sprintf("cla-gitea-app-web-hook-secret-%s", stage),
+		fmt.Sprintf("cla-gitea-app-redirect-uri-%s", stage),
+		fmt.Sprintf("cla-gitea-app-web-hook-uri-%s", stage),
+		fmt.Sprintf("cla-gerrit-app-private-key-%s", stage),
+		fmt.Sprintf("cla-gerrit-app-web-hook-secret-%s", stage),
+		fmt.Sprintf("cla-gerrit-app-web-hook-uri-%s", stage),
 		fmt.Sprintf("cla-corporate-base-%s", stage),
 		fmt.Sprintf("cla-corporate-v1-base-%s", stage),
 		fmt.Sprintf("cla-corporate-v2-base-%s", stage),
 		fmt.Sprintf("cla-contributor-v2-base-%s", stage),
 		fmt.Sprintf("cla-doc-raptor-api-key-%s", stage),
+		fmt.Sprintf("cla-pdf-renderer-%s", stage),
+		fmt.Sprintf("cla-preview-pdf-renderer-%s", stage),
 		fmt.Sprintf("cla-session-store-table-%s", stage),
 		fmt.Sprintf("cla-ses-sender-email-address-%s", stage),
 		fmt.Sprintf("cla-allowed-origins-%s", stage),
@@ -109,13 +133,36 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 		fmt.Sprintf("cla-docusign-private-key-%s", stage),
 	}
 
+	// Keys for the optional integrations - these may not be provisioned in every stage, a missing key leaves the
+	// integration unconfigured instead of failing the startup
+	optionalSSMKeys := map[string]bool{
+		fmt.Sprintf("cla-bitbucket-app-id-%s", stage):              true,
+		fmt.Sprintf("cla-bitbucket-app-secret-%s", stage):          true,
+		fmt.Sprintf("cla-bitbucket-app-private-key-%s", stage):     true,
+		fmt.Sprintf("cla-bitbucket-app-web-hook-secret-%s", stage): true,
+		fmt.Sprintf("cla-bitbucket-app-redirect-uri-%s", stage):    true,
+		fmt.Sprintf("cla-bitbucket-app-web-hook-uri-%s", stage):    true,
+		fmt.Sprintf("cla-gitea-app-private-key-%s", stage):         true,
+		fmt.Sprintf("cla-gitea-app-web-hook-secret-%s", stage):     true,
+		fmt.Sprintf("cla-gitea-app-redirect-uri-%s", stage):        true,
+		fmt.Sprintf("cla-gitea-app-web-hook-uri-%s", stage):        true,
+		fmt.Sprintf("cla-gerrit-app-private-key-%s", stage):        true,
+		fmt.Sprintf("cla-gerrit-app-web-hook-secret-%s", stage):    true,
+		fmt.Sprintf("cla-gerrit-app-web-hook-uri-%s", stage):       true,
+		fmt.Sprintf("cla-pdf-renderer-%s", stage):                  true,
+		fmt.Sprintf("cla-preview-pdf-renderer-%s", stage):          true,
+	}
+
 	// For each key to lookup
 	for _, key := range ssmKeys {
 		// Create a go routine to this concurrently
 		go func(theKey string) {
 			theValue, err := getSSMString(ssmClient, theKey)
 			if err != nil {
-				log.WithFields(f).WithError(err).Fatalf("error looking up key: %s", theKey)
+				if !optionalSSMKeys[theKey] || !isParameterNotFound(err) {
+					log.WithFields(f).WithError(err).Fatalf("error looking up key: %s", theKey)
+				}
+				log.WithFields(f).Infof("optional key: %s is not configured - using the default", theKey)
 			}
 			// Send the response back through the channel
 			responseChannel <- configLookupResponse{
@@ -177,6 +224,38 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			config.Gitlab.RedirectURI = resp.value
 		case fmt.Sprintf("cla-gitlab-app-web-hook-uri-%s", stage):
 			config.Gitlab.WebHookURI = resp.value
+
+		//	bitbucket ssm
+		case fmt.Sprintf("cla-bitbucket-app-id-%s", stage):
+			config.Bitbucket.AppClientID = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-secret-%s", stage):
+			config.Bitbucket.AppClientSecret = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-private-key-%s", stage):
+			config.Bitbucket.AppPrivateKey = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-web-hook-secret-%s", stage):
+			config.Bitbucket.WebhookSecret = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-redirect-uri-%s", stage):
+			config.Bitbucket.RedirectURI = resp.value
+		case fmt.Sprintf("cla-bitbucket-app-web-hook-uri-%s", stage):
+			config.Bitbucket.WebHookURI = resp.value
+
+		//	gitea ssm
+		case fmt.Sprintf("cla-gitea-app-private-key-%s", stage):
+			config.Gitea.AppPrivateKey = resp.value
+		case fmt.Sprintf("cla-gitea-app-web-hook-secret-%s", stage):
+			config.Gitea.WebhookSecret = resp.value
+		case fmt.Sprintf("cla-gitea-app-redirect-uri-%s", stage):
+			config.Gitea.RedirectURI = resp.value
+		case fmt.Sprintf("cla-gitea-app-web-hook-uri-%s", stage):
+			config.Gitea.WebHookURI = resp.value
+
+		//	gerrit ssm
+		case fmt.Sprintf("cla-gerrit-app-private-key-%s", stage):
+			config.Gerrit.AppPrivateKey = resp.value
+		case fmt.Sprintf("cla-gerrit-app-web-hook-secret-%s", stage):
+			config.Gerrit.WebhookSecret = resp.value
+		case fmt.Sprintf("cla-gerrit-app-web-hook-uri-%s", stage):
+			config.Gerrit.WebHookURI = resp.value
 		case fmt.Sprintf("cla-contributor-v2-base-%s", stage):
 			config.CLAContributorv2Base = resp.value
 		case fmt.Sprintf("cla-api-v4-base-%s", stage):
@@ -200,6 +279,13 @@ func loadSSMConfig(awsSession *session.Session, stage string) Config { //nolint
 			// watermark.  Restore this to just staging and prod after the testing phase is done.
 			config.Docraptor.TestMode = stage == "dev"
 			//config.Docraptor.TestMode = false // disable test mode while we evaluate various templates
+		case fmt.Sprintf("cla-pdf-renderer-%s", stage):
+			config.PDFRenderer = resp.value
+			if config.PDFRenderer == "" {
+				config.PDFRenderer = "docraptor"
+			}
+		case fmt.Sprintf("cla-preview-pdf-renderer-%s", stage):
+			config.PreviewPDFRenderer = resp.value
 		case fmt.Sprintf("cla-session-store-table-%s", stage):
 			config.SessionStoreTableName = resp.value
 		case fmt.Sprintf("cla-ses-sender-email-address-%s", stage):
@@ -5,6 +5,7 @@ package events
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
@@ -135,6 +136,15 @@ type SignatureInvalidatedApprovalRejectionEventData struct {
 	CLAGroupID  string
 }
 
+// SignatureRevokedEventData data model
+type SignatureRevokedEventData struct {
+	SignatureID   string
+	SignatureType string
+	Reason        string
+	EffectiveDate string
+	RevokedBy     string
+}
+
 // UserCreatedEventData data model
 type UserCreatedEventData struct{}
 
@@ -213,6 +223,35 @@ type GitLabOrganizationDeletedEventData struct {
 	GitLabOrganizationName string
 }
 
+// BitbucketOrganizationAddedEventData data model
+type BitbucketOrganizationAddedEventData struct {
+	BitbucketServerURL      string
+	BitbucketWorkspace      string
+	BitbucketProjectKey     string
+	BranchProtectionEnabled bool
+}
+
+// BitbucketOrganizationDeletedEventData data model
+type BitbucketOrganizationDeletedEventData struct {
+	BitbucketServerURL  string
+	BitbucketWorkspace  string
+	BitbucketProjectKey string
+}
+
+// GiteaOrganizationAddedEventData data model
+type GiteaOrganizationAddedEventData struct {
+	GiteaInstanceURL      string
+	GiteaOrganizationName string
+	AutoEnabled           bool
+	AutoEnabledClaGroupID string
+}
+
+// GiteaOrganizationDeletedEventData data model
+type GiteaOrganizationDeletedEventData struct {
+	GiteaInstanceURL      string
+	GiteaOrganizationName string
+}
+
 // GitLabOrganizationUpdatedEventData data model
 type GitLabOrganizationUpdatedEventData struct {
 	GitLabOrganizationName string
@@ -297,6 +336,99 @@ type CLAManagerRequestDeletedEventData struct {
 	ManagerEmail string
 }
 
+// ApprovalListImportedEventData data model
+type ApprovalListImportedEventData struct {
+	Format       string
+	Replace      bool
+	AddedCount   int
+	RemovedCount int
+}
+
+// ApprovalListEntryExpiredEventData data model
+type ApprovalListEntryExpiredEventData struct {
+	Criteria  string
+	Name      string
+	ExpiresAt string
+}
+
+// WebhookSubscriptionCreatedEventData data model
+type WebhookSubscriptionCreatedEventData struct {
+	SubscriptionID string
+	ScopeType      string
+	ScopeID        string
+	URL            string
+}
+
+// WebhookSubscriptionUpdatedEventData data model
+type WebhookSubscriptionUpdatedEventData struct {
+	SubscriptionID string
+	ScopeType      string
+	ScopeID        string
+	URL            string
+	SecretRotated  bool
+}
+
+// WebhookSubscriptionDeletedEventData data model
+type WebhookSubscriptionDeletedEventData struct {
+	SubscriptionID string
+	ScopeType      string
+	ScopeID        string
+	URL            string
+}
+
+// CLACustomTemplateCreatedEventData data model
+type CLACustomTemplateCreatedEventData struct {
+	TemplateID   string
+	TemplateName string
+}
+
+// CLACustomTemplateVersionCreatedEventData data model
+type CLACustomTemplateVersionCreatedEventData struct {
+	TemplateID   string
+	TemplateName string
+	Version      int64
+}
+
+// CLACustomTemplateArchivedEventData data model
+type CLACustomTemplateArchivedEventData struct {
+	TemplateID   string
+	TemplateName string
+}
+
+// CLAGroupDocumentLocaleUpdatedEventData data model
+type CLAGroupDocumentLocaleUpdatedEventData struct {
+	ClaType    string
+	Locale     string
+	ReviewedBy string
+}
+
+// CLAGroupDocumentLocaleDeletedEventData data model
+type CLAGroupDocumentLocaleDeletedEventData struct {
+	ClaType string
+	Locale  string
+}
+
+// SignatureExportRequestedEventData data model
+type SignatureExportRequestedEventData struct {
+	JobID   string
+	Format  string
+	Filters string
+}
+
+// SignatureExportCompletedEventData data model
+type SignatureExportCompletedEventData struct {
+	JobID    string
+	Format   string
+	RowCount int64
+}
+
+// SignatureExportFailedEventData data model
+type SignatureExportFailedEventData struct {
+	JobID  string
+	Format string
+	Reason string
+}
+
 // CLAApprovalListAddEmailData data model
 type CLAApprovalListAddEmailData struct {
 	ApprovalListEmail string
@@ -830,6 +962,61 @@ func (ed *GitLabOrganizationDeletedEventData) GetEventDetailsString(args *LogEve
 	return data, true
 }
 
+// GetEventDetailsString returns the details string for this event
+func (ed *BitbucketOrganizationAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Bitbucket Workspace: %s was added with branch protection enabled: %t", ed.BitbucketWorkspace, ed.BranchProtectionEnabled)
+	if ed.BitbucketProjectKey != "" {
+		data = data + fmt.Sprintf(" restricted to the Bitbucket project: %s", ed.BitbucketProjectKey)
+	}
+	if ed.BitbucketServerURL != "" {
+		data = data + fmt.Sprintf(" on the Bitbucket Data Center instance: %s", ed.BitbucketServerURL)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *BitbucketOrganizationDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Bitbucket Workspace: %s was deleted", ed.BitbucketWorkspace)
+	if ed.BitbucketProjectKey != "" {
+		data = data + fmt.Sprintf(" for the Bitbucket project: %s", ed.BitbucketProjectKey)
+	}
+	if ed.BitbucketServerURL != "" {
+		data = data + fmt.Sprintf(" on the Bitbucket Data Center instance: %s", ed.BitbucketServerURL)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GiteaOrganizationAddedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Gitea Organization: %s of the instance: %s was added with auto-enabled: %t", ed.GiteaOrganizationName, ed.GiteaInstanceURL, ed.AutoEnabled)
+	if ed.AutoEnabledClaGroupID != "" {
+		data = data + fmt.Sprintf(" with auto-enabled CLA Group ID: %s", ed.AutoEnabledClaGroupID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *GiteaOrganizationDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("Gitea Organization: %s of the instance: %s was deleted", ed.GiteaOrganizationName, ed.GiteaInstanceURL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *GitLabOrganizationUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := "GitLab Group" // nolint
@@ -962,6 +1149,196 @@ func (ed *CLAManagerRequestDeletedEventData) GetEventDetailsString(args *LogEven
 	return data, true
 }
 
+// GetEventDetailsString returns the details string for this event
+func (ed *ApprovalListImportedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The approval list was imported from a %s document with %d entries added and %d entries removed", ed.Format, ed.AddedCount, ed.RemovedCount)
+	if ed.Replace {
+		data = data + " replacing the existing entries"
+	}
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectSFID != "" {
+		data = data + fmt.Sprintf(" with project SFID %s", args.ProjectSFID)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the CLA Manager %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *ApprovalListEntryExpiredEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s approval list entry %s expired on %s and was removed from the approval list", ed.Criteria, ed.Name, ed.ExpiresAt)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectSFID != "" {
+		data = data + fmt.Sprintf(" with project SFID %s", args.ProjectSFID)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *WebhookSubscriptionCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription %s for the %s %s was created with the URL %s", ed.SubscriptionID, ed.ScopeType, ed.ScopeID, ed.URL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *WebhookSubscriptionUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription %s for the %s %s was updated with the URL %s", ed.SubscriptionID, ed.ScopeType, ed.ScopeID, ed.URL)
+	if ed.SecretRotated {
+		data = data + " and a new signing secret"
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *WebhookSubscriptionDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription %s for the %s %s with the URL %s was deleted", ed.SubscriptionID, ed.ScopeType, ed.ScopeID, ed.URL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLACustomTemplateCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s with ID %s was created", ed.TemplateName, ed.TemplateID)
+	if args.ProjectSFID != "" {
+		data = data + fmt.Sprintf(" for the foundation %s", args.ProjectSFID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLACustomTemplateVersionCreatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The version %d of the custom CLA template %s with ID %s was created", ed.Version, ed.TemplateName, ed.TemplateID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLACustomTemplateArchivedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s with ID %s was archived", ed.TemplateName, ed.TemplateID)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLAGroupDocumentLocaleUpdatedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s translation of the %s document reviewed by %s was added", ed.Locale, strings.ToUpper(ed.ClaType), ed.ReviewedBy)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" to the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectID != "" {
+		data = data + fmt.Sprintf(" with ID %s", args.ProjectID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLAGroupDocumentLocaleDeletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s translation of the %s document was deleted", ed.Locale, strings.ToUpper(ed.ClaType))
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" from the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectID != "" {
+		data = data + fmt.Sprintf(" with ID %s", args.ProjectID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *SignatureExportRequestedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature export %s was requested", ed.Format, ed.JobID)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectID != "" {
+		data = data + fmt.Sprintf(" with ID %s", args.ProjectID)
+	}
+	if ed.Filters != "" {
+		data = data + fmt.Sprintf(" with the filters %s", ed.Filters)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *SignatureExportCompletedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature export %s completed with %d signatures", ed.Format, ed.JobID, ed.RowCount)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectID != "" {
+		data = data + fmt.Sprintf(" with ID %s", args.ProjectID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" requested by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *SignatureExportFailedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature export %s failed", ed.Format, ed.JobID)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectID != "" {
+		data = data + fmt.Sprintf(" with ID %s", args.ProjectID)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" requested by the user %s", args.UserName)
+	}
+	if ed.Reason != "" {
+		data = data + fmt.Sprintf(": %s", ed.Reason)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *CLAApprovalListAddEmailData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The email address %s was added to the approval list", ed.ApprovalListEmail)
@@ -1431,6 +1808,23 @@ func (ed *SignatureInvalidatedApprovalRejectionEventData) GetEventDetailsString(
 	return data, true
 }
 
+// GetEventDetailsString returns the details string for this event
+func (ed *SignatureRevokedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature %s was revoked by %s with reason: %s effective %s",
+		ed.SignatureType, ed.SignatureID, ed.RevokedBy, ed.Reason, ed.EffectiveDate)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" for the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventDetailsString returns the details string for this event
 func (ed *ContributorNotifyCompanyAdminData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("User: %s notified Company Admin: %s by Email: %s for Company ID: %s, Name: %s.",
@@ -1931,6 +2325,70 @@ func (ed *GitLabOrganizationDeletedEventData) GetEventSummaryString(args *LogEve
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *BitbucketOrganizationAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Bitbucket workspace %s was added with branch protection enabled set to %t", ed.BitbucketWorkspace, ed.BranchProtectionEnabled)
+	if ed.BitbucketProjectKey != "" {
+		data = data + fmt.Sprintf(" restricted to the Bitbucket project %s", ed.BitbucketProjectKey)
+	}
+	if ed.BitbucketServerURL != "" {
+		data = data + fmt.Sprintf(" on the Bitbucket Data Center instance %s", ed.BitbucketServerURL)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *BitbucketOrganizationDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Bitbucket workspace %s was deleted", ed.BitbucketWorkspace)
+	if ed.BitbucketProjectKey != "" {
+		data = data + fmt.Sprintf(" for the Bitbucket project %s", ed.BitbucketProjectKey)
+	}
+	if ed.BitbucketServerURL != "" {
+		data = data + fmt.Sprintf(" on the Bitbucket Data Center instance %s", ed.BitbucketServerURL)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GiteaOrganizationAddedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Gitea organization %s of the instance %s was added with auto-enabled set to %t", ed.GiteaOrganizationName, ed.GiteaInstanceURL, ed.AutoEnabled)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *GiteaOrganizationDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The Gitea organization %s of the instance %s was deleted", ed.GiteaOrganizationName, ed.GiteaInstanceURL)
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for project %s", args.ProjectName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *GitLabOrganizationUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := "The GitLab group" // nolint
@@ -2098,6 +2556,154 @@ func (ed *CLAManagerRequestDeletedEventData) GetEventSummaryString(args *LogEven
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *ApprovalListImportedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The approval list was imported with %d entries added and %d entries removed", ed.AddedCount, ed.RemovedCount)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the CLA Manager %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *ApprovalListEntryExpiredEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s approval list entry %s expired and was removed from the approval list", ed.Criteria, ed.Name)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *WebhookSubscriptionCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("A webhook subscription was created for the URL %s", ed.URL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *WebhookSubscriptionUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription for the URL %s was updated", ed.URL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *WebhookSubscriptionDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The webhook subscription for the URL %s was deleted", ed.URL)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLACustomTemplateCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s was created", ed.TemplateName)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLACustomTemplateVersionCreatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s was updated to version %d", ed.TemplateName, ed.Version)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLACustomTemplateArchivedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The custom CLA template %s was archived", ed.TemplateName)
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLAGroupDocumentLocaleUpdatedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s translation of the %s document was added", ed.Locale, strings.ToUpper(ed.ClaType))
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" to the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLAGroupDocumentLocaleDeletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s translation of the %s document was deleted", ed.Locale, strings.ToUpper(ed.ClaType))
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" from the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *SignatureExportRequestedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("A %s signature export was requested", ed.Format)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" by the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *SignatureExportCompletedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature export completed with %d signatures", ed.Format, ed.RowCount)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *SignatureExportFailedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature export failed", ed.Format)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *CLAApprovalListAddEmailData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The email address %s was added to the approval list", ed.ApprovalListEmail)
@@ -2606,6 +3212,25 @@ func (ed *SignatureInvalidatedApprovalRejectionEventData) GetEventSummaryString(
 	return data, true
 }
 
+// GetEventSummaryString returns the summary string for this event
+func (ed *SignatureRevokedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s signature was revoked by %s with reason: %s", ed.SignatureType, ed.RevokedBy, ed.Reason)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	if args.ProjectName != "" {
+		data = data + fmt.Sprintf(" for the project %s", args.ProjectName)
+	}
+	if args.CompanyName != "" {
+		data = data + fmt.Sprintf(" for the company %s", args.CompanyName)
+	}
+	if args.UserName != "" {
+		data = data + fmt.Sprintf(" for the user %s", args.UserName)
+	}
+	data = data + "."
+	return data, true
+}
+
 // GetEventSummaryString returns the summary string for this event
 func (ed *ContributorNotifyCompanyAdminData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
 	data := fmt.Sprintf("The user %s notified the company admin %s by the email address %s",
@@ -2769,3 +3394,74 @@ func (ed *IndividualSignatureSignedEventData) GetEventDetailsString(args *LogEve
 		args.LfUsername, ed.ProjectName)
 	return data, false
 }
+
+// CLACheckBotExemptedEventData data model - a bot commit author exempt from the CLA check by the bot allowlist
+type CLACheckBotExemptedEventData struct {
+	Provider        string
+	RepositoryName  string
+	ChangeRequestID string
+	Login           string
+	Email           string
+	AllowlistEntry  string
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *CLACheckBotExemptedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The %s bot author", ed.Provider)
+	if ed.Login != "" {
+		data = data + fmt.Sprintf(" with login %s", ed.Login)
+	}
+	if ed.Email != "" {
+		data = data + fmt.Sprintf(" with email %s", ed.Email)
+	}
+	data = data + fmt.Sprintf(" was exempt from the CLA check of the change request %s of the repository %s, matching the bot allowlist entry '%s'",
+		ed.ChangeRequestID, ed.RepositoryName, ed.AllowlistEntry)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, ed.Email != ""
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *CLACheckBotExemptedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("A bot author was exempt from the CLA check of the change request %s of the repository %s by the bot allowlist entry '%s'",
+		ed.ChangeRequestID, ed.RepositoryName, ed.AllowlistEntry)
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, false
+}
+
+// RepositoryBranchProtectionRepairedEventData data model - the branch protection of a repository drifted from the
+// EasyCLA expected rules and was repaired by the branch protection audit
+type RepositoryBranchProtectionRepairedEventData struct {
+	Provider         string
+	OrganizationName string
+	RepositoryName   string
+	BranchName       string
+	Drift            []string
+}
+
+// GetEventDetailsString returns the details string for this event
+func (ed *RepositoryBranchProtectionRepairedEventData) GetEventDetailsString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The branch protection of the branch %s of the %s repository %s of the organization %s was repaired by the branch protection audit, drift: %s",
+		ed.BranchName, ed.Provider, ed.RepositoryName, ed.OrganizationName, strings.Join(ed.Drift, ", "))
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, false
+}
+
+// GetEventSummaryString returns the summary string for this event
+func (ed *RepositoryBranchProtectionRepairedEventData) GetEventSummaryString(args *LogEventArgs) (string, bool) {
+	data := fmt.Sprintf("The branch protection of the %s repository %s was repaired, drift: %s",
+		ed.Provider, ed.RepositoryName, strings.Join(ed.Drift, ", "))
+	if args.CLAGroupName != "" {
+		data = data + fmt.Sprintf(" for the CLA Group %s", args.CLAGroupName)
+	}
+	data = data + "."
+	return data, false
+}
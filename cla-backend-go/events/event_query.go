@@ -0,0 +1,329 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// event query sort orders
+const (
+	SortOrderAscending  = "asc"
+	SortOrderDescending = "desc"
+)
+
+// ErrEventQueryScopeRequired is returned when an event query has neither a foundation nor a CLA Group scope
+var ErrEventQueryScopeRequired = errors.New("event query requires a foundationSFID or a claGroupID")
+
+// EventQuery contains the combinable filters of an event query. A foundation or a CLA Group scope is required, the
+// other filters are optional and combined with AND - the event types are combined with OR.
+type EventQuery struct {
+	FoundationSFID string
+	CLAGroupID     string
+	CompanySFID    string
+	CompanyID      string
+	EventTypes     []string
+	// Actor matches the user ID, the LF username or the user name of the event
+	Actor string
+	// After and Before are the inclusive event time epoch range
+	After      *int64
+	Before     *int64
+	SearchTerm string
+	SortOrder  string
+	PageSize   int64
+	NextKey    *string
+}
+
+// EventTypeCount is the number of events of an event type
+type EventTypeCount struct {
+	EventType string
+	Count     int64
+}
+
+// QueryEvents returns a page of the events matching the query, sorted by event time
+func (repo *repository) QueryEvents(ctx context.Context, query *EventQuery) (*models.EventList, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.events.repository.QueryEvents",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"query":          fmt.Sprintf("%+v", *query),
+	}
+
+	queryInput, indexName, err := repo.buildEventQueryInput(query)
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := query.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > HugePageSize {
+		pageSize = HugePageSize
+	}
+	queryInput.Limit = aws.Int64(pageSize)
+
+	if query.NextKey != nil && *query.NextKey != "" {
+		queryInput.ExclusiveStartKey, err = decodeNextKey(*query.NextKey)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding next key value")
+			return nil, err
+		}
+	}
+
+	events := make([]*models.Event, 0)
+	hasMore := false
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).WithError(errQuery).Warn("error querying events")
+			return nil, errQuery
+		}
+
+		eventsList, modelErr := buildEventListModels(results)
+		if modelErr != nil {
+			log.WithFields(f).WithError(modelErr).Warn("error converting event list models")
+			return nil, modelErr
+		}
+		events = append(events, eventsList...)
+
+		// More events remain when the page is trimmed or when the query stopped before the end of the index
+		if int64(len(events)) > pageSize {
+			events = events[:pageSize]
+			hasMore = true
+			break
+		}
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+		if int64(len(events)) == pageSize {
+			hasMore = true
+			break
+		}
+	}
+
+	response := &models.EventList{
+		Events:      events,
+		ResultCount: int64(len(events)),
+	}
+	if hasMore && len(events) > 0 {
+		response.NextKey, err = buildNextKey(indexName, events[len(events)-1])
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to build nextKey")
+		}
+	}
+
+	log.WithFields(f).Debugf("returning %d events", len(events))
+	return response, nil
+}
+
+// CountEventsByType returns the number of events matching the query for each event type, sorted by descending count
+func (repo *repository) CountEventsByType(ctx context.Context, query *EventQuery) ([]*EventTypeCount, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.events.repository.CountEventsByType",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"query":          fmt.Sprintf("%+v", *query),
+	}
+
+	queryInput, _, err := repo.buildEventQueryInput(query)
+	if err != nil {
+		return nil, err
+	}
+	// Only the event type is needed to aggregate
+	queryInput.ProjectionExpression = aws.String("#event_type")
+	if queryInput.ExpressionAttributeNames == nil {
+		queryInput.ExpressionAttributeNames = make(map[string]*string)
+	}
+	queryInput.ExpressionAttributeNames["#event_type"] = aws.String("event_type")
+	queryInput.Limit = aws.Int64(HugePageSize)
+
+	counts := make(map[string]int64)
+	for {
+		results, errQuery := repo.dynamoDBClient.Query(queryInput)
+		if errQuery != nil {
+			log.WithFields(f).WithError(errQuery).Warn("error querying events")
+			return nil, errQuery
+		}
+
+		var items []struct {
+			EventType string `dynamodbav:"event_type"`
+		}
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &items); err != nil {
+			log.WithFields(f).WithError(err).Warn("error unmarshalling events from database")
+			return nil, err
+		}
+		for _, item := range items {
+			counts[item.EventType]++
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	eventTypeCounts := make([]*EventTypeCount, 0, len(counts))
+	for eventType, count := range counts {
+		eventTypeCounts = append(eventTypeCounts, &EventTypeCount{EventType: eventType, Count: count})
+	}
+	sort.Slice(eventTypeCounts, func(i, j int) bool {
+		if eventTypeCounts[i].Count != eventTypeCounts[j].Count {
+			return eventTypeCounts[i].Count > eventTypeCounts[j].Count
+		}
+		return eventTypeCounts[i].EventType < eventTypeCounts[j].EventType
+	})
+	return eventTypeCounts, nil
+}
+
+// buildEventQueryInput selects the index of the query scope and builds the query input with the key condition and
+// the filters - returns the query input and the index name
+func (repo *repository) buildEventQueryInput(query *EventQuery) (*dynamodb.QueryInput, string, error) {
+	indexName, keyCondition, keyAttributes, err := eventQueryKeyCondition(query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	builder := expression.NewBuilder().WithKeyCondition(keyCondition)
+	filter := eventQueryFilter(query, keyAttributes)
+	if filter != nil {
+		builder = builder.WithFilter(*filter)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.eventsTable),
+		IndexName:                 aws.String(indexName),
+		ScanIndexForward:          aws.Bool(query.SortOrder == SortOrderAscending),
+	}
+	if filter != nil {
+		queryInput.FilterExpression = expr.Filter()
+	}
+	return queryInput, indexName, nil
+}
+
+// eventQueryKeyCondition returns the most selective index for the query scope, its key condition including the event
+// time range, and the attributes covered by the key condition
+func eventQueryKeyCondition(query *EventQuery) (string, expression.KeyConditionBuilder, map[string]bool, error) {
+	var indexName string
+	var keyCondition expression.KeyConditionBuilder
+	keyAttributes := make(map[string]bool)
+
+	switch {
+	case query.CLAGroupID != "" && query.CompanySFID != "":
+		indexName = CompanySFIDClaGroupIDEpochIndex
+		keyCondition = expression.Key("company_sfid_cla_group_id").Equal(expression.Value(fmt.Sprintf("%s#%s", query.CompanySFID, query.CLAGroupID)))
+		keyAttributes["event_cla_group_id"] = true
+		keyAttributes["event_company_sfid"] = true
+	case query.CLAGroupID != "":
+		indexName = EventCLAGroupIDEpochIndex
+		keyCondition = expression.Key("event_cla_group_id").Equal(expression.Value(query.CLAGroupID))
+		keyAttributes["event_cla_group_id"] = true
+	case query.FoundationSFID != "" && query.CompanySFID != "":
+		indexName = CompanySFIDFoundationSFIDEpochIndex
+		keyCondition = expression.Key("company_sfid_foundation_sfid").Equal(expression.Value(fmt.Sprintf("%s#%s", query.CompanySFID, query.FoundationSFID)))
+		keyAttributes["event_parent_project_sfid"] = true
+		keyAttributes["event_company_sfid"] = true
+	case query.FoundationSFID != "":
+		indexName = EventFoundationSFIDEpochIndex
+		keyCondition = expression.Key("event_parent_project_sfid").Equal(expression.Value(query.FoundationSFID))
+		keyAttributes["event_parent_project_sfid"] = true
+	default:
+		return "", keyCondition, nil, ErrEventQueryScopeRequired
+	}
+
+	switch {
+	case query.After != nil && query.Before != nil:
+		keyCondition = keyCondition.And(expression.Key("event_time_epoch").Between(expression.Value(*query.After), expression.Value(*query.Before)))
+	case query.After != nil:
+		keyCondition = keyCondition.And(expression.Key("event_time_epoch").GreaterThanEqual(expression.Value(*query.After)))
+	case query.Before != nil:
+		keyCondition = keyCondition.And(expression.Key("event_time_epoch").LessThanEqual(expression.Value(*query.Before)))
+	}
+
+	return indexName, keyCondition, keyAttributes, nil
+}
+
+// eventQueryFilter returns the filter of the query conditions not covered by the key condition, or nil if there are
+// none
+func eventQueryFilter(query *EventQuery, keyAttributes map[string]bool) *expression.ConditionBuilder {
+	var filter expression.ConditionBuilder
+	var filterAdded bool
+
+	if query.CLAGroupID != "" && !keyAttributes["event_cla_group_id"] {
+		filter = addConditionToFilter(filter, expression.Name("event_cla_group_id").Equal(expression.Value(query.CLAGroupID)), &filterAdded)
+	}
+	if query.FoundationSFID != "" && !keyAttributes["event_parent_project_sfid"] {
+		filter = addConditionToFilter(filter, expression.Name("event_parent_project_sfid").Equal(expression.Value(query.FoundationSFID)), &filterAdded)
+	}
+	if query.CompanySFID != "" && !keyAttributes["event_company_sfid"] {
+		filter = addConditionToFilter(filter, expression.Name("event_company_sfid").Equal(expression.Value(query.CompanySFID)), &filterAdded)
+	}
+	if query.CompanyID != "" {
+		filter = addConditionToFilter(filter, expression.Name("event_company_id").Equal(expression.Value(query.CompanyID)), &filterAdded)
+	}
+
+	eventTypes := uniqueNonEmpty(query.EventTypes)
+	switch len(eventTypes) {
+	case 0:
+	case 1:
+		filter = addConditionToFilter(filter, expression.Name("event_type").Equal(expression.Value(eventTypes[0])), &filterAdded)
+	default:
+		var others []expression.OperandBuilder
+		for _, eventType := range eventTypes[1:] {
+			others = append(others, expression.Value(eventType))
+		}
+		filter = addConditionToFilter(filter, expression.Name("event_type").In(expression.Value(eventTypes[0]), others...), &filterAdded)
+	}
+
+	if actor := strings.TrimSpace(query.Actor); actor != "" {
+		actorCondition := expression.Or(
+			expression.Name("event_user_id").Equal(expression.Value(actor)),
+			expression.Name("event_lf_username").Equal(expression.Value(actor)),
+			expression.Name("event_user_name_lower").Equal(expression.Value(strings.ToLower(actor))),
+		)
+		filter = addConditionToFilter(filter, actorCondition, &filterAdded)
+	}
+	if searchTerm := strings.TrimSpace(query.SearchTerm); searchTerm != "" {
+		filter = addConditionToFilter(filter, expression.Name("event_data_lower").Contains(strings.ToLower(searchTerm)), &filterAdded)
+	}
+
+	if filterAdded {
+		return &filter
+	}
+	return nil
+}
+
+// uniqueNonEmpty returns the trimmed non-empty values without duplicates, in their original order
+func uniqueNonEmpty(values []string) []string {
+	var result []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if value == "" || seen[value] {
+			continue
+		}
+		seen[value] = true
+		result = append(result, value)
+	}
+	return result
+}
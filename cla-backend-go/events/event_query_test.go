@@ -0,0 +1,126 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventQueryKeyCondition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     *EventQuery
+		indexName string
+	}{
+		{
+			name:      "cla group and company",
+			query:     &EventQuery{FoundationSFID: "foundation", CLAGroupID: "cla-group", CompanySFID: "company"},
+			indexName: CompanySFIDClaGroupIDEpochIndex,
+		},
+		{
+			name:      "cla group",
+			query:     &EventQuery{FoundationSFID: "foundation", CLAGroupID: "cla-group"},
+			indexName: EventCLAGroupIDEpochIndex,
+		},
+		{
+			name:      "foundation and company",
+			query:     &EventQuery{FoundationSFID: "foundation", CompanySFID: "company"},
+			indexName: CompanySFIDFoundationSFIDEpochIndex,
+		},
+		{
+			name:      "foundation",
+			query:     &EventQuery{FoundationSFID: "foundation"},
+			indexName: EventFoundationSFIDEpochIndex,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			indexName, _, _, err := eventQueryKeyCondition(tc.query)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.indexName, indexName)
+		})
+	}
+
+	_, _, _, err := eventQueryKeyCondition(&EventQuery{CompanySFID: "company", EventTypes: []string{"signature.signed"}})
+	assert.Equal(t, ErrEventQueryScopeRequired, err)
+}
+
+func TestEventQueryKeyConditionDateRange(t *testing.T) {
+	_, keyCondition, _, err := eventQueryKeyCondition(&EventQuery{CLAGroupID: "cla-group", After: aws.Int64(100), Before: aws.Int64(200)})
+	assert.Nil(t, err)
+
+	expr, err := expression.NewBuilder().WithKeyCondition(keyCondition).Build()
+	assert.Nil(t, err)
+	assert.Contains(t, aws.StringValue(expr.KeyCondition()), "BETWEEN")
+	assert.Contains(t, attributeNames(expr), "event_time_epoch")
+}
+
+func TestEventQueryFilter(t *testing.T) {
+	query := &EventQuery{FoundationSFID: "foundation", CLAGroupID: "cla-group"}
+	_, _, keyAttributes, err := eventQueryKeyCondition(query)
+	assert.Nil(t, err)
+	filter := eventQueryFilter(query, keyAttributes)
+	if assert.NotNil(t, filter) {
+		expr, buildErr := expression.NewBuilder().WithFilter(*filter).Build()
+		assert.Nil(t, buildErr)
+		names := attributeNames(expr)
+		// the CLA Group is covered by the key condition, only the foundation is filtered
+		assert.Contains(t, names, "event_parent_project_sfid")
+		assert.NotContains(t, names, "event_cla_group_id")
+	}
+
+	query = &EventQuery{
+		CLAGroupID:  "cla-group",
+		CompanySFID: "company",
+		CompanyID:   "company-id",
+		EventTypes:  []string{"signature.signed", " ", "signature.signed", "approval_list.imported"},
+		Actor:       "JDoe",
+		SearchTerm:  "Acme",
+	}
+	_, _, keyAttributes, err = eventQueryKeyCondition(query)
+	assert.Nil(t, err)
+	filter = eventQueryFilter(query, keyAttributes)
+	if assert.NotNil(t, filter) {
+		expr, buildErr := expression.NewBuilder().WithFilter(*filter).Build()
+		assert.Nil(t, buildErr)
+		names := attributeNames(expr)
+		assert.ElementsMatch(t, []string{"event_company_id", "event_type", "event_user_id", "event_lf_username", "event_user_name_lower", "event_data_lower"}, names)
+		assert.Contains(t, aws.StringValue(expr.Filter()), " IN ")
+		assert.Contains(t, attributeStringValues(expr), "jdoe")
+		assert.Contains(t, attributeStringValues(expr), "acme")
+	}
+
+	query = &EventQuery{CLAGroupID: "cla-group"}
+	_, _, keyAttributes, err = eventQueryKeyCondition(query)
+	assert.Nil(t, err)
+	assert.Nil(t, eventQueryFilter(query, keyAttributes))
+}
+
+func TestUniqueNonEmpty(t *testing.T) {
+	assert.Equal(t, []string{"a", "b"}, uniqueNonEmpty([]string{" a", "", "b", "a"}))
+	assert.Nil(t, uniqueNonEmpty(nil))
+}
+
+func attributeNames(expr expression.Expression) []string {
+	var names []string
+	for _, name := range expr.Names() {
+		names = append(names, aws.StringValue(name))
+	}
+	return names
+}
+
+func attributeStringValues(expr expression.Expression) []string {
+	var values []string
+	for _, value := range expr.Values() {
+		if value.S != nil {
+			values = append(values, *value.S)
+		}
+	}
+	return values
+}
@@ -40,6 +40,7 @@ const (
 	RepositoryBranchProtectionAdded    = "repository.branchprotection.updated"
 	RepositoryBranchProtectionDisabled = "repository.branchprotection.updated"
 	RepositoryBranchProtectionUpdated  = "repository.branchprotection.updated"
+	RepositoryBranchProtectionRepaired = "repository.branchprotection.repaired"
 
 	GerritRepositoryAdded   = "gerrit_repository.added"
 	GerritRepositoryDeleted = "gerrit_repository.deleted"
@@ -54,6 +55,12 @@ const (
 	GitlabOrganizationDeleted = "gitlab_organization.deleted"
 	GitlabOrganizationUpdated = "gitlab_organization.updated"
 
+	BitbucketOrganizationAdded   = "bitbucket_organization.added"
+	BitbucketOrganizationDeleted = "bitbucket_organization.deleted"
+
+	GiteaOrganizationAdded   = "gitea_organization.added"
+	GiteaOrganizationDeleted = "gitea_organization.deleted"
+
 	CompanyACLUserAdded       = "company_acl.user_added"
 	CompanyACLRequestAdded    = "company_acl.request_added"
 	CompanyACLRequestApproved = "company_acl.request_approved"
@@ -65,6 +72,23 @@ const (
 
 	ApprovalListGitHubOrganizationAdded   = "approval_list.github_organization_added"
 	ApprovalListGitHubOrganizationDeleted = "approval_list.github_organization_deleted"
+	ApprovalListImported                  = "approval_list.imported"
+	ApprovalListEntryExpired              = "approval_list.entry_expired"
+
+	WebhookSubscriptionCreated = "webhook_subscription.created"
+	WebhookSubscriptionUpdated = "webhook_subscription.updated"
+	WebhookSubscriptionDeleted = "webhook_subscription.deleted"
+
+	CLACustomTemplateCreated        = "cla_custom_template.created"
+	CLACustomTemplateVersionCreated = "cla_custom_template.version_created"
+	CLACustomTemplateArchived       = "cla_custom_template.archived"
+
+	CLAGroupDocumentLocaleUpdated = "cla_group_document_locale.updated"
+	CLAGroupDocumentLocaleDeleted = "cla_group_document_locale.deleted"
+
+	SignatureExportRequested = "signature_export.requested"
+	SignatureExportCompleted = "signature_export.completed"
+	SignatureExportFailed    = "signature_export.failed"
 
 	ClaManagerAccessRequestCreated  = "cla_manager.access_request_created"
 	ClaManagerAccessRequestApproved = "cla_manager.access_request_approved"
@@ -85,6 +109,7 @@ const (
 	CLAGroupUnenrolledProject = "cla_group.unenrolled.project"
 
 	InvalidatedSignature = "signature.invalidated"
+	SignatureRevoked     = "signature.revoked"
 
 	ContributorNotifyCompanyAdminType = "contributor.notify_company_admin"
 	ContributorNotifyCLADesigneeType  = "contributor.notify_cla_designee"
@@ -99,4 +124,6 @@ const (
 
 	IndividualSignatureSigned = "individual.signature.signed"
 	CorporateSignatureSigned  = "corporate.signature.signed"
+
+	CLACheckBotExempted = "cla_check.bot_exempted"
 )
@@ -9,9 +9,11 @@
 package mock
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
+	events0 "github.com/linuxfoundation/easycla/cla-backend-go/events"
 	models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	events "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/events"
 )
@@ -53,6 +55,21 @@ func (mr *MockRepositoryMockRecorder) AddDataToEvent(eventID, parentProjectSFID,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddDataToEvent", reflect.TypeOf((*MockRepository)(nil).AddDataToEvent), eventID, parentProjectSFID, projectSFID, projectSFName, companySFID, projectID, claGroupID)
 }
 
+// CountEventsByType mocks base method.
+func (m *MockRepository) CountEventsByType(ctx context.Context, query *events0.EventQuery) ([]*events0.EventTypeCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEventsByType", ctx, query)
+	ret0, _ := ret[0].([]*events0.EventTypeCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEventsByType indicates an expected call of CountEventsByType.
+func (mr *MockRepositoryMockRecorder) CountEventsByType(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEventsByType", reflect.TypeOf((*MockRepository)(nil).CountEventsByType), ctx, query)
+}
+
 // CreateEvent mocks base method.
 func (m *MockRepository) CreateEvent(event *models.Event) error {
 	m.ctrl.T.Helper()
@@ -157,6 +174,21 @@ func (mr *MockRepositoryMockRecorder) GetRecentEvents(pageSize interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRecentEvents", reflect.TypeOf((*MockRepository)(nil).GetRecentEvents), pageSize)
 }
 
+// QueryEvents mocks base method.
+func (m *MockRepository) QueryEvents(ctx context.Context, query *events0.EventQuery) (*models.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryEvents", ctx, query)
+	ret0, _ := ret[0].(*models.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryEvents indicates an expected call of QueryEvents.
+func (mr *MockRepositoryMockRecorder) QueryEvents(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryEvents", reflect.TypeOf((*MockRepository)(nil).QueryEvents), ctx, query)
+}
+
 // SearchEvents mocks base method.
 func (m *MockRepository) SearchEvents(params *events.SearchEventsParams, pageSize int64) (*models.EventList, error) {
 	m.ctrl.T.Helper()
@@ -42,6 +42,21 @@ func (m *MockService) EXPECT() *MockServiceMockRecorder {
 	return m.recorder
 }
 
+// CountEventsByType mocks base method.
+func (m *MockService) CountEventsByType(ctx context.Context, query *events.EventQuery) ([]*events.EventTypeCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountEventsByType", ctx, query)
+	ret0, _ := ret[0].([]*events.EventTypeCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountEventsByType indicates an expected call of CountEventsByType.
+func (mr *MockServiceMockRecorder) CountEventsByType(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountEventsByType", reflect.TypeOf((*MockService)(nil).CountEventsByType), ctx, query)
+}
+
 // GetClaGroupEvents mocks base method.
 func (m *MockService) GetClaGroupEvents(claGroupID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +171,21 @@ func (mr *MockServiceMockRecorder) LogEventWithContext(ctx, args interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogEventWithContext", reflect.TypeOf((*MockService)(nil).LogEventWithContext), ctx, args)
 }
 
+// QueryEvents mocks base method.
+func (m *MockService) QueryEvents(ctx context.Context, query *events.EventQuery) (*models.EventList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "QueryEvents", ctx, query)
+	ret0, _ := ret[0].(*models.EventList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// QueryEvents indicates an expected call of QueryEvents.
+func (mr *MockServiceMockRecorder) QueryEvents(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "QueryEvents", reflect.TypeOf((*MockService)(nil).QueryEvents), ctx, query)
+}
+
 // SearchEvents mocks base method.
 func (m *MockService) SearchEvents(params *events0.SearchEventsParams) (*models.EventList, error) {
 	m.ctrl.T.Helper()
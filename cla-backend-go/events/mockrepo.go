@@ -54,6 +54,14 @@ func (repo *mockRepository) GetClaGroupEvents(claGroupID string, nextKey *string
 	panic("implement me")
 }
 
+func (repo *mockRepository) QueryEvents(ctx context.Context, query *EventQuery) (*models.EventList, error) {
+	panic("implement me")
+}
+
+func (repo *mockRepository) CountEventsByType(ctx context.Context, query *EventQuery) ([]*EventTypeCount, error) {
+	panic("implement me")
+}
+
 func (repo *mockRepository) GetClaGroupIDForProject(ctx context.Context, projectSFID string) (*projects_cla_groups.ProjectClaGroup, error) {
 	return nil, nil
 }
@@ -4,6 +4,7 @@
 package events
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/json"
@@ -74,6 +75,9 @@ type Repository interface {
 	GetCompanyEvents(companyID, eventType string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error)
 	GetFoundationEvents(foundationSFID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error)
 	GetClaGroupEvents(claGroupID string, nextKey *string, paramPageSize *int64, all bool, searchTerm *string) (*models.EventList, error)
+
+	QueryEvents(ctx context.Context, query *EventQuery) (*models.EventList, error)
+	CountEventsByType(ctx context.Context, query *EventQuery) ([]*EventTypeCount, error)
 }
 
 // repository data model
@@ -42,6 +42,9 @@ type Service interface {
 	GetCompanyFoundationEvents(companySFID, companyID, foundationSFID string, nextKey *string, paramPageSize *int64, searchTerm *string, all bool) (*models.EventList, error)
 	GetCompanyClaGroupEvents(claGroupID string, companySFID string, nextKey *string, paramPageSize *int64, searchTerm *string, all bool) (*models.EventList, error)
 	GetCompanyEvents(companyID, eventType string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error)
+
+	QueryEvents(ctx context.Context, query *EventQuery) (*models.EventList, error)
+	CountEventsByType(ctx context.Context, query *EventQuery) ([]*EventTypeCount, error)
 }
 
 // CombinedRepo contains the various methods of other repositories
@@ -110,6 +113,16 @@ func (s *service) GetCompanyClaGroupEvents(claGroupID string, companySFID string
 	return s.repo.GetCompanyClaGroupEvents(claGroupID, companySFID, nextKey, paramPageSize, searchTerm, all)
 }
 
+// QueryEvents returns a page of the events matching the combined filters of the query
+func (s *service) QueryEvents(ctx context.Context, query *EventQuery) (*models.EventList, error) {
+	return s.repo.QueryEvents(ctx, query)
+}
+
+// CountEventsByType returns the number of events matching the query filters for each event type
+func (s *service) CountEventsByType(ctx context.Context, query *EventQuery) ([]*EventTypeCount, error) {
+	return s.repo.CountEventsByType(ctx, query)
+}
+
 func (s *service) GetCompanyEvents(companyID, eventType string, nextKey *string, paramPageSize *int64, all bool) (*models.EventList, error) {
 	return s.repo.GetCompanyEvents(companyID, eventType, nextKey, paramPageSize, all)
 }
@@ -0,0 +1,76 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+const (
+	// ChangeStatusNew is the status of the open changes
+	ChangeStatusNew = "NEW"
+
+	// ReviewTag marks the EasyCLA review messages as autogenerated, Gerrit only shows the latest autogenerated message
+	// of a tag by default
+	ReviewTag = "autogenerated:easycla"
+)
+
+// ChangeInfo is a Gerrit change, the revisions are only returned with the CURRENT_REVISION or ALL_REVISIONS options
+type ChangeInfo struct {
+	ID              string                   `json:"id"`
+	Project         string                   `json:"project"`
+	Branch          string                   `json:"branch"`
+	ChangeID        string                   `json:"change_id"`
+	Number          int64                    `json:"_number"`
+	Subject         string                   `json:"subject"`
+	Status          string                   `json:"status"`
+	Owner           *AccountInfo             `json:"owner"`
+	CurrentRevision string                   `json:"current_revision"`
+	Revisions       map[string]*RevisionInfo `json:"revisions"`
+}
+
+// RevisionInfo is a patch set of a Gerrit change
+type RevisionInfo struct {
+	Number   int64        `json:"_number"`
+	Ref      string       `json:"ref"`
+	Uploader *AccountInfo `json:"uploader"`
+}
+
+// ReviewInput is the review posted on a revision, Labels maps the label names to the vote
+type ReviewInput struct {
+	Message string         `json:"message,omitempty"`
+	Labels  map[string]int `json:"labels,omitempty"`
+	Tag     string         `json:"tag,omitempty"`
+}
+
+// CurrentUploader returns the uploader of the current patch set, nil if the revisions weren't requested
+func (c *ChangeInfo) CurrentUploader() *AccountInfo {
+	if revision, ok := c.Revisions[c.CurrentRevision]; ok && revision != nil {
+		return revision.Uploader
+	}
+	return nil
+}
+
+// GetChange returns the change with the owner and the uploader of the current patch set, changeID can be the
+// change number or the project~number identifier
+func (c *Client) GetChange(changeID string) (*ChangeInfo, error) {
+	query := url.Values{}
+	query["o"] = []string{"CURRENT_REVISION", "DETAILED_ACCOUNTS"}
+
+	var change ChangeInfo
+	if err := c.do(http.MethodGet, c.endpoint("changes", changeID)+"?"+query.Encode(), nil, &change); err != nil {
+		return nil, fmt.Errorf("fetching change : %s failed : %w", changeID, err)
+	}
+	return &change, nil
+}
+
+// SetReview posts the review message and the label votes on the revision of the change
+func (c *Client) SetReview(changeID, revisionID string, review *ReviewInput) error {
+	if err := c.do(http.MethodPost, c.endpoint("changes", changeID, "revisions", revisionID, "review"), review, nil); err != nil {
+		return fmt.Errorf("setting review on change : %s revision : %s failed : %w", changeID, revisionID, err)
+	}
+	return nil
+}
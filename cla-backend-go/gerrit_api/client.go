@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/linuxfoundation/easycla/cla-backend-go/authinfo"
+)
+
+const (
+	// authenticatedPath is the prefix of the Gerrit REST API endpoints requiring the HTTP credentials
+	authenticatedPath = "/a"
+)
+
+// magicPrefix is prepended by Gerrit to the JSON responses to prevent XSSI, it has to be stripped before decoding
+var magicPrefix = []byte(")]}'")
+
+// Client is a minimal Gerrit REST API client authenticated with the HTTP credentials of a service account
+type Client struct {
+	instanceURL string
+	restClient  *resty.Client
+}
+
+// APIError is returned when Gerrit responds with a non 2xx status code
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gerrit %s %s failed with status code: %d, response: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if the error is a Gerrit 404 response
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsConflict returns true if the error is a Gerrit 409 response, such as creating a group that already exists
+func IsConflict(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict
+}
+
+// NewGerritClient creates a new gerrit client for the instance from the given auth info, authInfo is the encrypted
+// HTTP password of the service account
+func NewGerritClient(instanceURL, username, authInfo string, gerritApp *App) (*Client, error) {
+	if authInfo == "" {
+		return nil, errors.New("unable to decrypt auth info - authentication info input is nil")
+	}
+	if gerritApp == nil || gerritApp.gerritAppPrivateKey == "" {
+		return nil, errors.New("unable to decrypt auth info - Gerrit app structure is nil or empty")
+	}
+
+	password, err := DecryptAuthInfo(authInfo, gerritApp)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGerritClientFromPassword(instanceURL, username, password), nil
+}
+
+// NewGerritClientFromPassword creates a new gerrit client for the instance from the given HTTP credentials
+func NewGerritClientFromPassword(instanceURL, username, password string) *Client {
+	return &Client{
+		instanceURL: NormalizeInstanceURL(instanceURL),
+		restClient: resty.New().
+			SetBasicAuth(username, password).
+			SetHeader("Accept", "application/json"),
+	}
+}
+
+// NormalizeInstanceURL returns the instance URL without the trailing slash, the URL keeps the context path of the
+// instance, such as https://gerrit.example.org/r
+func NormalizeInstanceURL(instanceURL string) string {
+	return strings.TrimSuffix(strings.TrimSpace(instanceURL), "/")
+}
+
+// InstanceURL returns the base URL of the Gerrit instance
+func (c *Client) InstanceURL() string {
+	return c.instanceURL
+}
+
+// endpoint returns the absolute URL of the authenticated API path, the path segments are escaped
+func (c *Client) endpoint(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return c.instanceURL + authenticatedPath + "/" + strings.Join(escaped, "/")
+}
+
+// do sends the request and decodes the JSON response into result, when set
+func (c *Client) do(method, requestURL string, body, result interface{}) error {
+	request := c.restClient.R()
+	if body != nil {
+		request.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	resp, err := request.Execute(method, requestURL)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		return &APIError{
+			StatusCode: resp.StatusCode(),
+			Method:     method,
+			URL:        requestURL,
+			Body:       string(resp.Body()),
+		}
+	}
+	payload := bytes.TrimSpace(bytes.TrimPrefix(resp.Body(), magicPrefix))
+	if result != nil && len(payload) > 0 {
+		if err := json.Unmarshal(payload, result); err != nil {
+			return fmt.Errorf("unable to decode gerrit response from %s, error: %v", requestURL, err)
+		}
+	}
+	return nil
+}
+
+// EncryptAuthInfo encrypts the HTTP password into a string
+func EncryptAuthInfo(password string, gerritApp *App) (string, error) {
+	keyDecoded, err := base64.StdEncoding.DecodeString(gerritApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("problem decoding Gerrit private key, error: %v", err)
+	}
+
+	encrypted, err := authinfo.Encrypt(keyDecoded, []byte(password))
+	if err != nil {
+		return "", fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	return hex.EncodeToString(encrypted), nil
+}
+
+// DecryptAuthInfo decrypts the auth info into the HTTP password
+func DecryptAuthInfo(authInfoEncoded string, gerritApp *App) (string, error) {
+	ciphertext, err := hex.DecodeString(authInfoEncoded)
+	if err != nil {
+		return "", fmt.Errorf("decode auth info : %v", err)
+	}
+
+	keyDecoded, err := base64.StdEncoding.DecodeString(gerritApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("decode key : %v", err)
+	}
+
+	decrypted, err := authinfo.Decrypt(keyDecoded, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt failed : %v", err)
+	}
+
+	return string(decrypted), nil
+}
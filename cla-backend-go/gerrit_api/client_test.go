@@ -0,0 +1,131 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var gerritClientKey = "0WqnDWHnZKo2cmQ8m93EtY9ZBpfzQW4UnnEuRmgtJKM="
+
+func TestEncryptDecryptAuthInfo(t *testing.T) {
+	app := &App{gerritAppPrivateKey: gerritClientKey, gerritAppWebhookSecret: "secret"}
+
+	encrypted, err := EncryptAuthInfo("http-password", app)
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "http-password")
+
+	decrypted, err := DecryptAuthInfo(encrypted, app)
+	assert.NoError(t, err)
+	assert.Equal(t, "http-password", decrypted)
+}
+
+func TestGetChangeStripsMagicPrefix(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/r/a/changes/infra/tools~42", r.URL.Path)
+		assert.Equal(t, []string{"CURRENT_REVISION", "DETAILED_ACCOUNTS"}, r.URL.Query()["o"])
+		username, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "easycla", username)
+		assert.Equal(t, "http-password", password)
+		fmt.Fprint(w, ")]}'\n"+`{"id":"infra%2Ftools~master~I1","project":"infra/tools","_number":42,"status":"NEW",
+"owner":{"_account_id":1000,"name":"Jane Doe","email":"jane@example.org","username":"jane"},
+"current_revision":"abc","revisions":{"abc":{"_number":2,"uploader":{"_account_id":1001,"email":"john@example.org"}}}}`)
+	}))
+	defer server.Close()
+
+	client := NewGerritClientFromPassword(server.URL+"/r/", "easycla", "http-password")
+	change, err := client.GetChange("infra/tools~42")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(42), change.Number)
+	assert.Equal(t, ChangeStatusNew, change.Status)
+	assert.Equal(t, int64(1000), change.Owner.AccountID)
+	if assert.NotNil(t, change.CurrentUploader()) {
+		assert.Equal(t, "john@example.org", change.CurrentUploader().Email)
+	}
+}
+
+func TestEnsureGroupCreatesMissingGroup(t *testing.T) {
+	created := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/a/groups/EasyCLA Contributors":
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, "Not found: EasyCLA Contributors")
+		case r.Method == http.MethodPut && r.URL.Path == "/a/groups/EasyCLA Contributors":
+			body, _ := io.ReadAll(r.Body)
+			var payload map[string]interface{}
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			assert.Equal(t, false, payload["visible_to_all"])
+			created = true
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, ")]}'\n"+`{"id":"6a1e70e1a88782771a91808c8af9bbb7a9871389","name":"EasyCLA Contributors","group_id":7}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGerritClientFromPassword(server.URL, "easycla", "http-password")
+	group, err := client.EnsureGroup("EasyCLA Contributors", "Accounts authorized under a signed CLA")
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, "6a1e70e1a88782771a91808c8af9bbb7a9871389", group.ID)
+}
+
+func TestSetReview(t *testing.T) {
+	var review ReviewInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/a/changes/tools~42/revisions/abc/review", r.URL.Path)
+		body, _ := io.ReadAll(r.Body)
+		assert.NoError(t, json.Unmarshal(body, &review))
+		fmt.Fprint(w, ")]}'\n"+`{"labels":{"Verified":-1}}`)
+	}))
+	defer server.Close()
+
+	client := NewGerritClientFromPassword(server.URL, "easycla", "http-password")
+	err := client.SetReview("tools~42", "abc", &ReviewInput{Message: "CLA missing", Labels: map[string]int{"Verified": -1}, Tag: ReviewTag})
+	assert.NoError(t, err)
+	assert.Equal(t, -1, review.Labels["Verified"])
+	assert.Equal(t, ReviewTag, review.Tag)
+}
+
+func TestRemoveGroupMemberIgnoresMissingMember(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, "/a/groups/abc/members/1000", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewGerritClientFromPassword(server.URL, "easycla", "http-password")
+	assert.NoError(t, client.RemoveGroupMember("abc", 1000))
+}
+
+func TestParseEvent(t *testing.T) {
+	event, err := ParseEvent([]byte(`{"type":"patchset-created","change":{"project":"infra/tools","number":42,"owner":{"username":"jane"}},"patchSet":{"number":2,"revision":"abc"}}`))
+	assert.NoError(t, err)
+	assert.Equal(t, EventPatchsetCreated, event.Type)
+	assert.Equal(t, "infra/tools~42", event.ChangeID())
+
+	_, err = ParseEvent([]byte(`{"type":"ref-updated","refUpdate":{"project":"infra/tools"}}`))
+	assert.Error(t, err)
+}
+
+func TestValidateWebhookToken(t *testing.T) {
+	token := WebhookToken("gerrit-1", "secret")
+	assert.NoError(t, ValidateWebhookToken("gerrit-1", token, "secret"))
+	assert.Error(t, ValidateWebhookToken("gerrit-2", token, "secret"))
+	assert.Error(t, ValidateWebhookToken("gerrit-1", "", "secret"))
+	assert.Error(t, ValidateWebhookToken("gerrit-1", token, ""))
+}
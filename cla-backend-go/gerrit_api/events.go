@@ -0,0 +1,96 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	// EventPatchsetCreated is sent when a change is created or a new patch set is uploaded
+	EventPatchsetCreated = "patchset-created"
+	// EventCommentAdded is sent when a review comment is posted - used to re-run the check
+	EventCommentAdded = "comment-added"
+)
+
+// EventAccount is the account attribute of the stream events
+type EventAccount struct {
+	Name     string `json:"name"`
+	Email    string `json:"email"`
+	Username string `json:"username"`
+}
+
+// EventChange is the change attribute of the stream events
+type EventChange struct {
+	Project string        `json:"project"`
+	Branch  string        `json:"branch"`
+	ID      string        `json:"id"`
+	Number  int64         `json:"number"`
+	Owner   *EventAccount `json:"owner"`
+	URL     string        `json:"url"`
+	Status  string        `json:"status"`
+}
+
+// EventPatchSet is the patch set attribute of the stream events
+type EventPatchSet struct {
+	Number   int64         `json:"number"`
+	Revision string        `json:"revision"`
+	Ref      string        `json:"ref"`
+	Uploader *EventAccount `json:"uploader"`
+}
+
+// Event is the stream event delivered by the Gerrit webhooks plugin, Comment is only set on the comment-added events
+type Event struct {
+	Type     string         `json:"type"`
+	Change   *EventChange   `json:"change"`
+	PatchSet *EventPatchSet `json:"patchSet"`
+	Comment  string         `json:"comment,omitempty"`
+}
+
+// ChangeID returns the project~number identifier of the change of the event
+func (e *Event) ChangeID() string {
+	return fmt.Sprintf("%s~%d", e.Change.Project, e.Change.Number)
+}
+
+// ParseEvent decodes the stream event payload, the payload must identify the change
+func ParseEvent(payload []byte) (*Event, error) {
+	var event Event
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("unable to decode gerrit event : %v", err)
+	}
+	if event.Type == "" {
+		return nil, errors.New("gerrit event type is missing")
+	}
+	if event.Change == nil || event.Change.Project == "" || event.Change.Number == 0 {
+		return nil, fmt.Errorf("gerrit %s event doesn't identify the change", event.Type)
+	}
+	return &event, nil
+}
+
+// WebhookToken returns the token authenticating the webhook deliveries of the Gerrit instance, the webhooks plugin
+// doesn't sign the payloads so the token is part of the configured webhook URL
+func WebhookToken(gerritID, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(gerritID)) // nolint
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateWebhookToken checks the token of the webhook delivery against the token of the Gerrit instance
+func ValidateWebhookToken(gerritID, token, secret string) error {
+	if secret == "" {
+		return errors.New("gerrit webhook secret is not configured")
+	}
+	if token == "" {
+		return errors.New("missing gerrit webhook token")
+	}
+	if !hmac.Equal([]byte(token), []byte(WebhookToken(gerritID, secret))) {
+		return errors.New("gerrit webhook token mismatch")
+	}
+	return nil
+}
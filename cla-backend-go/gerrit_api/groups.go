@@ -0,0 +1,108 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Group is the Gerrit internal group holding the accounts authorized under a signed CLA - the ID is the group UUID
+type Group struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	GroupID     int64  `json:"group_id"`
+	Description string `json:"description,omitempty"`
+	Owner       string `json:"owner,omitempty"`
+	OwnerID     string `json:"owner_id,omitempty"`
+}
+
+// AccountInfo is a Gerrit account, the detailed fields are only returned with the DETAILED_ACCOUNTS option
+type AccountInfo struct {
+	AccountID       int64    `json:"_account_id"`
+	Name            string   `json:"name,omitempty"`
+	Email           string   `json:"email,omitempty"`
+	SecondaryEmails []string `json:"secondary_emails,omitempty"`
+	Username        string   `json:"username,omitempty"`
+	DisplayName     string   `json:"display_name,omitempty"`
+}
+
+// GetGroup returns the group identified by its UUID or its name
+func (c *Client) GetGroup(groupID string) (*Group, error) {
+	var group Group
+	if err := c.do(http.MethodGet, c.endpoint("groups", groupID), nil, &group); err != nil {
+		return nil, err
+	}
+	return &group, nil
+}
+
+// CreateGroup creates the group with the given name, the group isn't visible to all the users
+func (c *Client) CreateGroup(name, description string) (*Group, error) {
+	body := map[string]interface{}{
+		"description":    description,
+		"visible_to_all": false,
+	}
+	var group Group
+	if err := c.do(http.MethodPut, c.endpoint("groups", name), body, &group); err != nil {
+		return nil, fmt.Errorf("creating group : %s failed : %w", name, err)
+	}
+	return &group, nil
+}
+
+// EnsureGroup returns the group with the given name, the group is created when missing - should be idempotent operation
+func (c *Client) EnsureGroup(name, description string) (*Group, error) {
+	group, err := c.GetGroup(name)
+	if err == nil {
+		return group, nil
+	}
+	if !IsNotFound(err) {
+		return nil, fmt.Errorf("loading group : %s failed : %w", name, err)
+	}
+
+	group, err = c.CreateGroup(name, description)
+	if err != nil && IsConflict(err) {
+		// created in the meantime
+		return c.GetGroup(name)
+	}
+	return group, err
+}
+
+// ListGroupMembers returns the direct members of the group
+func (c *Client) ListGroupMembers(groupID string) ([]*AccountInfo, error) {
+	var members []*AccountInfo
+	if err := c.do(http.MethodGet, c.endpoint("groups", groupID, "members")+"/", nil, &members); err != nil {
+		return nil, err
+	}
+	return members, nil
+}
+
+// IsGroupMember returns true if the account is a direct member of the group
+func (c *Client) IsGroupMember(groupID string, accountID int64) (bool, error) {
+	err := c.do(http.MethodGet, c.endpoint("groups", groupID, "members", strconv.FormatInt(accountID, 10)), nil, nil)
+	if err != nil {
+		if IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// AddGroupMember adds the account to the group, adding an existing member is a no-op
+func (c *Client) AddGroupMember(groupID string, accountID int64) error {
+	if err := c.do(http.MethodPut, c.endpoint("groups", groupID, "members", strconv.FormatInt(accountID, 10)), nil, nil); err != nil {
+		return fmt.Errorf("adding account : %d to group : %s failed : %w", accountID, groupID, err)
+	}
+	return nil
+}
+
+// RemoveGroupMember removes the account from the group, removing an account which isn't a member is a no-op
+func (c *Client) RemoveGroupMember(groupID string, accountID int64) error {
+	err := c.do(http.MethodDelete, c.endpoint("groups", groupID, "members", strconv.FormatInt(accountID, 10)), nil, nil)
+	if err != nil && !IsNotFound(err) {
+		return fmt.Errorf("removing account : %d from group : %s failed : %w", accountID, groupID, err)
+	}
+	return nil
+}
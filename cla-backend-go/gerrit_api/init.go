@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit
+
+import (
+	"sync"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+)
+
+// App is a wrapper for the Gerrit configuration items shared by all the self-hosted Gerrit instances
+type App struct {
+	gerritAppPrivateKey    string
+	gerritAppWebhookSecret string
+}
+
+var gerritAppSingleton *App
+
+var once sync.Once
+
+// Init initializes the required gerrit variables
+func Init(gerritAppPrivateKey, gerritAppWebhookSecret string) *App {
+	if gerritAppSingleton == nil {
+		once.Do(
+			func() {
+				log.Debug("Creating object single instance...")
+				gerritAppSingleton = &App{
+					gerritAppPrivateKey:    gerritAppPrivateKey,
+					gerritAppWebhookSecret: gerritAppWebhookSecret,
+				}
+			})
+	}
+	return gerritAppSingleton
+}
+
+// GetAppPrivateKey returns the key used to encrypt the stored Gerrit credentials
+func (app *App) GetAppPrivateKey() string {
+	return app.gerritAppPrivateKey
+}
+
+// GetAppWebhookSecret returns the secret the per instance webhook tokens are derived from
+func (app *App) GetAppWebhookSecret() string {
+	return app.gerritAppWebhookSecret
+}
@@ -53,6 +53,21 @@ func (mr *MockRepositoryMockRecorder) AddGerrit(ctx, input interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddGerrit", reflect.TypeOf((*MockRepository)(nil).AddGerrit), ctx, input)
 }
 
+// AddRESTGerrit mocks base method.
+func (m *MockRepository) AddRESTGerrit(ctx context.Context, input *models.Gerrit, restAuthInfo string) (*models.Gerrit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddRESTGerrit", ctx, input, restAuthInfo)
+	ret0, _ := ret[0].(*models.Gerrit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddRESTGerrit indicates an expected call of AddRESTGerrit.
+func (mr *MockRepositoryMockRecorder) AddRESTGerrit(ctx, input, restAuthInfo interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddRESTGerrit", reflect.TypeOf((*MockRepository)(nil).AddRESTGerrit), ctx, input, restAuthInfo)
+}
+
 // DeleteGerrit mocks base method.
 func (m *MockRepository) DeleteGerrit(ctx context.Context, gerritID string) error {
 	m.ctrl.T.Helper()
@@ -112,6 +127,21 @@ func (mr *MockRepositoryMockRecorder) GetGerrit(ctx, gerritID interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGerrit", reflect.TypeOf((*MockRepository)(nil).GetGerrit), ctx, gerritID)
 }
 
+// GetGerritRESTAuthInfo mocks base method.
+func (m *MockRepository) GetGerritRESTAuthInfo(ctx context.Context, gerritID string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGerritRESTAuthInfo", ctx, gerritID)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGerritRESTAuthInfo indicates an expected call of GetGerritRESTAuthInfo.
+func (mr *MockRepositoryMockRecorder) GetGerritRESTAuthInfo(ctx, gerritID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGerritRESTAuthInfo", reflect.TypeOf((*MockRepository)(nil).GetGerritRESTAuthInfo), ctx, gerritID)
+}
+
 // GetGerritsByID mocks base method.
 func (m *MockRepository) GetGerritsByID(ctx context.Context, ID, IDType string) (*models.GerritList, error) {
 	m.ctrl.T.Helper()
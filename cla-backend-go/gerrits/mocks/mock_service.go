@@ -13,6 +13,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	gerrit "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
 )
 
 // MockService is a mock of Service interface.
@@ -141,3 +142,19 @@ func (mr *MockServiceMockRecorder) GetGerritsByProjectSFID(ctx, projectSFID inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGerritsByProjectSFID", reflect.TypeOf((*MockService)(nil).GetGerritsByProjectSFID), ctx, projectSFID)
 }
+
+// NewGerritRESTClient mocks base method.
+func (m *MockService) NewGerritRESTClient(ctx context.Context, gerritID string) (*models.Gerrit, *gerrit.Client, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NewGerritRESTClient", ctx, gerritID)
+	ret0, _ := ret[0].(*models.Gerrit)
+	ret1, _ := ret[1].(*gerrit.Client)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// NewGerritRESTClient indicates an expected call of NewGerritRESTClient.
+func (mr *MockServiceMockRecorder) NewGerritRESTClient(ctx, gerritID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NewGerritRESTClient", reflect.TypeOf((*MockService)(nil).NewGerritRESTClient), ctx, gerritID)
+}
@@ -4,39 +4,90 @@
 package gerrits
 
 import (
+	"fmt"
+	"net/url"
+
 	"github.com/go-openapi/strfmt"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	gerritApi "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
+)
+
+const (
+	// ProviderLFLDAP is the default provider - the CLA is enforced with the LF LDAP groups of the LF hosted instances
+	ProviderLFLDAP = "lf_ldap"
+	// ProviderGerritREST enforces the CLA through the REST API of a self-hosted Gerrit instance
+	ProviderGerritREST = "gerrit_rest"
+
+	// DefaultReviewLabel is the label voted on the changes when the instance doesn't configure one
+	DefaultReviewLabel = "Verified"
+	// DefaultContributorGroupName is the Gerrit group holding the authorized accounts when the instance doesn't
+	// configure one
+	DefaultContributorGroupName = "EasyCLA Contributors"
 )
 
 // Gerrit represent gerrit instances table
 type Gerrit struct {
-	DateCreated   string `json:"date_created,omitempty"`
-	DateModified  string `json:"date_modified,omitempty"`
-	GerritID      string `json:"gerrit_id,omitempty"`
-	GerritName    string `json:"gerrit_name,omitempty"`
-	GerritURL     string `json:"gerrit_url,omitempty"`
-	GroupIDCcla   string `json:"group_id_ccla,omitempty"`
-	GroupIDIcla   string `json:"group_id_icla,omitempty"`
-	GroupNameCcla string `json:"group_name_ccla,omitempty"`
-	GroupNameIcla string `json:"group_name_icla,omitempty"`
-	ProjectSFID   string `json:"project_sfid,omitempty"`
-	ProjectID     string `json:"project_id,omitempty"`
-	Version       string `json:"version,omitempty"`
-}
-
-// toModel converts the gerrit structure into a response model
+	DateCreated          string `json:"date_created,omitempty"`
+	DateModified         string `json:"date_modified,omitempty"`
+	GerritID             string `json:"gerrit_id,omitempty"`
+	GerritName           string `json:"gerrit_name,omitempty"`
+	GerritURL            string `json:"gerrit_url,omitempty"`
+	GroupIDCcla          string `json:"group_id_ccla,omitempty"`
+	GroupIDIcla          string `json:"group_id_icla,omitempty"`
+	GroupNameCcla        string `json:"group_name_ccla,omitempty"`
+	GroupNameIcla        string `json:"group_name_icla,omitempty"`
+	ProjectSFID          string `json:"project_sfid,omitempty"`
+	ProjectID            string `json:"project_id,omitempty"`
+	Version              string `json:"version,omitempty"`
+	Provider             string `json:"provider,omitempty"`
+	RESTUsername         string `json:"rest_username,omitempty"`
+	RESTAuthInfo         string `json:"rest_auth_info,omitempty"`
+	ReviewLabel          string `json:"review_label,omitempty"`
+	ContributorGroupID   string `json:"contributor_group_id,omitempty"`
+	ContributorGroupName string `json:"contributor_group_name,omitempty"`
+}
+
+// IsRESTProvider returns true if the CLA is enforced through the REST API of the Gerrit instance
+func (g *Gerrit) IsRESTProvider() bool {
+	return g.Provider == ProviderGerritREST
+}
+
+// toModel converts the gerrit structure into a response model, the REST credentials are never returned
 func (g *Gerrit) toModel() *models.Gerrit {
+	provider := g.Provider
+	if provider == "" {
+		provider = ProviderLFLDAP
+	}
 	return &models.Gerrit{
-		DateCreated:  g.DateCreated,
-		DateModified: g.DateModified,
-		GerritID:     strfmt.UUID4(g.GerritID),
-		GerritName:   g.GerritName,
-		GerritURL:    strfmt.URI(g.GerritURL),
-		GroupIDCcla:  g.GroupIDCcla,
-		ProjectID:    g.ProjectID,
-		Version:      g.Version,
-		ProjectSFID:  g.ProjectSFID,
+		DateCreated:          g.DateCreated,
+		DateModified:         g.DateModified,
+		GerritID:             strfmt.UUID4(g.GerritID),
+		GerritName:           g.GerritName,
+		GerritURL:            strfmt.URI(g.GerritURL),
+		GroupIDCcla:          g.GroupIDCcla,
+		GroupIDIcla:          g.GroupIDIcla,
+		ProjectID:            g.ProjectID,
+		Version:              g.Version,
+		ProjectSFID:          g.ProjectSFID,
+		Provider:             provider,
+		RestUsername:         g.RESTUsername,
+		ReviewLabel:          g.ReviewLabel,
+		ContributorGroupID:   g.ContributorGroupID,
+		ContributorGroupName: g.ContributorGroupName,
+		WebhookURL:           g.webhookURL(),
+	}
+}
+
+// webhookURL returns the URL to configure in the webhooks plugin of the Gerrit instance, the URL carries the token
+// authenticating the deliveries
+func (g *Gerrit) webhookURL() string {
+	gerritConfig := config.GetConfig().Gerrit
+	if !g.IsRESTProvider() || gerritConfig.WebHookURI == "" || gerritConfig.WebhookSecret == "" {
+		return ""
 	}
+	return fmt.Sprintf("%s/%s?token=%s", gerritConfig.WebHookURI, url.PathEscape(g.GerritID),
+		gerritApi.WebhookToken(g.GerritID, gerritConfig.WebhookSecret))
 }
 
 // WebLink contains the name and url
@@ -36,7 +36,9 @@ var (
 // Repository defines functions of V3Repositories
 type Repository interface {
 	AddGerrit(ctx context.Context, input *models.Gerrit) (*models.Gerrit, error)
+	AddRESTGerrit(ctx context.Context, input *models.Gerrit, restAuthInfo string) (*models.Gerrit, error)
 	GetGerrit(ctx context.Context, gerritID string) (*models.Gerrit, error)
+	GetGerritRESTAuthInfo(ctx context.Context, gerritID string) (string, error)
 	GetGerritsByID(ctx context.Context, ID string, IDType string) (*models.GerritList, error)
 	GetGerritsByProjectSFID(ctx context.Context, projectSFID string) (*models.GerritList, error)
 	GetClaGroupGerrits(ctx context.Context, claGroupID string) (*models.GerritList, error)
@@ -61,8 +63,37 @@ type repo struct {
 
 // AddGerrit creates a new gerrit instance
 func (repo *repo) AddGerrit(ctx context.Context, input *models.Gerrit) (*models.Gerrit, error) {
+	return repo.addGerrit(ctx, &Gerrit{
+		GerritName:  input.GerritName,
+		GerritURL:   input.GerritURL.String(),
+		GroupIDCcla: input.GroupIDCcla,
+		ProjectID:   input.ProjectID,
+		ProjectSFID: input.ProjectSFID,
+		Version:     input.Version,
+	})
+}
+
+// AddRESTGerrit creates a new gerrit instance enforcing the CLA through the Gerrit REST API, restAuthInfo is the
+// encrypted HTTP password of the service account
+func (repo *repo) AddRESTGerrit(ctx context.Context, input *models.Gerrit, restAuthInfo string) (*models.Gerrit, error) {
+	return repo.addGerrit(ctx, &Gerrit{
+		GerritName:           input.GerritName,
+		GerritURL:            input.GerritURL.String(),
+		ProjectID:            input.ProjectID,
+		ProjectSFID:          input.ProjectSFID,
+		Version:              input.Version,
+		Provider:             ProviderGerritREST,
+		RESTUsername:         input.RestUsername,
+		RESTAuthInfo:         restAuthInfo,
+		ReviewLabel:          input.ReviewLabel,
+		ContributorGroupID:   input.ContributorGroupID,
+		ContributorGroupName: input.ContributorGroupName,
+	})
+}
+
+func (repo *repo) addGerrit(ctx context.Context, gerrit *Gerrit) (*models.Gerrit, error) {
 	f := logrus.Fields{
-		"functionName":   "v1.gerrits.repository.AddGerrit",
+		"functionName":   "v1.gerrits.repository.addGerrit",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 	}
 	gerritID, err := uuid.NewV4()
@@ -70,17 +101,9 @@ func (repo *repo) AddGerrit(ctx context.Context, input *models.Gerrit) (*models.
 		return nil, err
 	}
 	_, currentTime := utils.CurrentTime()
-	gerrit := &Gerrit{
-		DateCreated:  currentTime,
-		DateModified: currentTime,
-		GerritID:     gerritID.String(),
-		GerritName:   input.GerritName,
-		GerritURL:    input.GerritURL.String(),
-		GroupIDCcla:  input.GroupIDCcla,
-		ProjectID:    input.ProjectID,
-		ProjectSFID:  input.ProjectSFID,
-		Version:      input.Version,
-	}
+	gerrit.DateCreated = currentTime
+	gerrit.DateModified = currentTime
+	gerrit.GerritID = gerritID.String()
 	av, err := dynamodbattribute.MarshalMap(gerrit)
 	if err != nil {
 		return nil, err
@@ -100,8 +123,26 @@ func (repo *repo) AddGerrit(ctx context.Context, input *models.Gerrit) (*models.
 
 // GetGerrit returns the gerrit instances based on the ID
 func (repo *repo) GetGerrit(ctx context.Context, gerritID string) (*models.Gerrit, error) {
+	gerrit, err := repo.getGerrit(ctx, gerritID)
+	if err != nil {
+		return nil, err
+	}
+	return gerrit.toModel(), nil
+}
+
+// GetGerritRESTAuthInfo returns the encrypted HTTP password of the gerrit instance, the credentials aren't part of
+// the response model
+func (repo *repo) GetGerritRESTAuthInfo(ctx context.Context, gerritID string) (string, error) {
+	gerrit, err := repo.getGerrit(ctx, gerritID)
+	if err != nil {
+		return "", err
+	}
+	return gerrit.RESTAuthInfo, nil
+}
+
+func (repo *repo) getGerrit(ctx context.Context, gerritID string) (*Gerrit, error) {
 	f := logrus.Fields{
-		"functionName":   "v1.gerrits.repository.GetGerrit",
+		"functionName":   "v1.gerrits.repository.getGerrit",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 		"gerritID":       gerritID,
 	}
@@ -130,7 +171,7 @@ func (repo *repo) GetGerrit(ctx context.Context, gerritID string) (*models.Gerri
 		return nil, err
 	}
 
-	return gerrit.toModel(), nil
+	return &gerrit, nil
 }
 
 func (repo repo) GetGerritsByID(ctx context.Context, ID string, IDType string) (*models.GerritList, error) {
@@ -505,5 +546,10 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("group_name_icla"),
 		expression.Name("project_id"),
 		expression.Name("project_sfid"),
+		expression.Name("provider"),
+		expression.Name("rest_username"),
+		expression.Name("review_label"),
+		expression.Name("contributor_group_id"),
+		expression.Name("contributor_group_name"),
 	)
 }
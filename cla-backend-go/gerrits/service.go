@@ -18,9 +18,11 @@ import (
 	"github.com/go-resty/resty/v2"
 	"github.com/sirupsen/logrus"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	gerritApi "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
 	// v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
@@ -35,16 +37,19 @@ type Service interface {
 	GetGerritRepos(ctx context.Context, gerritName string) (*models.GerritRepoList, error)
 	DeleteClaGroupGerrits(ctx context.Context, claGroupID string) (int, error)
 	DeleteGerrit(ctx context.Context, gerritID string) error
+	NewGerritRESTClient(ctx context.Context, gerritID string) (*models.Gerrit, *gerritApi.Client, error)
 }
 
 type service struct {
-	repo Repository
+	repo      Repository
+	gerritApp *gerritApi.App
 }
 
 // NewService creates a new gerrit service
 func NewService(repo Repository) Service {
 	return service{
-		repo: repo,
+		repo:      repo,
+		gerritApp: gerritApi.Init(config.GetConfig().Gerrit.AppPrivateKey, config.GetConfig().Gerrit.WebhookSecret),
 	}
 }
 
@@ -84,9 +89,74 @@ func (s service) AddGerrit(ctx context.Context, claGroupID string, projectSFID s
 		ProjectSFID: projectSFID,
 		Version:     params.Version,
 	}
+	if params.Provider == ProviderGerritREST {
+		return s.addRESTGerrit(ctx, input, params)
+	}
 	return s.repo.AddGerrit(ctx, input)
 }
 
+// addRESTGerrit checks the service account credentials against the Gerrit instance and sets up the group holding
+// the accounts authorized under a signed CLA before storing the instance
+func (s service) addRESTGerrit(ctx context.Context, input *models.Gerrit, params *models.AddGerritInput) (*models.Gerrit, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.gerrits.service.addRESTGerrit",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritURL":      input.GerritURL,
+		"restUsername":   params.RestUsername,
+	}
+
+	if params.RestUsername == "" || params.RestPassword == "" {
+		return nil, errors.New("rest_username and rest_password required for the gerrit_rest provider")
+	}
+
+	input.RestUsername = params.RestUsername
+	input.ReviewLabel = params.ReviewLabel
+	if input.ReviewLabel == "" {
+		input.ReviewLabel = DefaultReviewLabel
+	}
+	input.ContributorGroupName = params.ContributorGroupName
+	if input.ContributorGroupName == "" {
+		input.ContributorGroupName = DefaultContributorGroupName
+	}
+
+	gerritClient := gerritApi.NewGerritClientFromPassword(input.GerritURL.String(), params.RestUsername, params.RestPassword)
+	group, err := gerritClient.EnsureGroup(input.ContributorGroupName, "Accounts authorized to contribute under a signed EasyCLA agreement")
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to set up the contributor group: %s", input.ContributorGroupName)
+		return nil, fmt.Errorf("unable to set up the contributor group on the gerrit instance : %w", err)
+	}
+	input.ContributorGroupID = group.ID
+
+	authInfo, err := gerritApi.EncryptAuthInfo(params.RestPassword, s.gerritApp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to encrypt the gerrit credentials")
+		return nil, err
+	}
+
+	return s.repo.AddRESTGerrit(ctx, input, authInfo)
+}
+
+// NewGerritRESTClient returns the gerrit instance and the REST client authenticated as its service account
+func (s service) NewGerritRESTClient(ctx context.Context, gerritID string) (*models.Gerrit, *gerritApi.Client, error) {
+	gerrit, err := s.repo.GetGerrit(ctx, gerritID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if gerrit.Provider != ProviderGerritREST {
+		return nil, nil, fmt.Errorf("gerrit instance: %s doesn't use the %s provider", gerritID, ProviderGerritREST)
+	}
+
+	authInfo, err := s.repo.GetGerritRESTAuthInfo(ctx, gerritID)
+	if err != nil {
+		return nil, nil, err
+	}
+	gerritClient, err := gerritApi.NewGerritClient(gerrit.GerritURL.String(), gerrit.RestUsername, authInfo, s.gerritApp)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initializing gerrit client : %v", err)
+	}
+	return gerrit, gerritClient, nil
+}
+
 func (s service) GetGerrit(ctx context.Context, gerritID string) (*models.Gerrit, error) {
 	return s.repo.GetGerrit(ctx, gerritID)
 }
@@ -5,14 +5,25 @@ package gerrits
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	gerritApi "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
 	gerritsMock "github.com/linuxfoundation/easycla/cla-backend-go/gerrits/mocks"
 	"github.com/stretchr/testify/assert"
 )
 
+func TestMain(m *testing.M) {
+	// the gerrit app is a singleton, set the key encrypting the REST credentials before the services are created
+	gerritApi.Init("0WqnDWHnZKo2cmQ8m93EtY9ZBpfzQW4UnnEuRmgtJKM=", "secret")
+	os.Exit(m.Run())
+}
+
 func TestService_AddGerrit(t *testing.T) {
 	// AddGerrit test case
 
@@ -45,3 +56,52 @@ func TestService_AddGerrit(t *testing.T) {
 	assert.NoError(t, err)
 
 }
+
+func TestService_AddRESTGerrit(t *testing.T) {
+	gerritName := "Example"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/r/a/groups/EasyCLA Contributors":
+			fmt.Fprint(w, ")]}'\n"+`{"id":"6a1e70e1a88782771a91808c8af9bbb7a9871389","name":"EasyCLA Contributors","group_id":7}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	gerritURL := server.URL + "/r"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := gerritsMock.NewMockRepository(ctrl)
+	mockRepo.EXPECT().ExistsByName(gomock.Any(), gerritName).Return(nil, nil).Times(2)
+	mockRepo.EXPECT().AddRESTGerrit(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, input *models.Gerrit, restAuthInfo string) (*models.Gerrit, error) {
+		assert.Equal(t, "easycla", input.RestUsername)
+		assert.Equal(t, DefaultReviewLabel, input.ReviewLabel)
+		assert.Equal(t, "6a1e70e1a88782771a91808c8af9bbb7a9871389", input.ContributorGroupID)
+		password, err := gerritApi.DecryptAuthInfo(restAuthInfo, gerritApi.Init("", ""))
+		assert.NoError(t, err)
+		assert.Equal(t, "http-password", password)
+		return input, nil
+	})
+
+	service := NewService(mockRepo)
+	_, err := service.AddGerrit(context.TODO(), "projectID", "projectSFID", &models.AddGerritInput{
+		GerritName: &gerritName,
+		GerritURL:  &gerritURL,
+		Provider:   ProviderGerritREST,
+	}, &models.ClaGroup{ProjectID: "projectID"})
+	assert.Error(t, err)
+
+	gerrit, err := service.AddGerrit(context.TODO(), "projectID", "projectSFID", &models.AddGerritInput{
+		GerritName:   &gerritName,
+		GerritURL:    &gerritURL,
+		Provider:     ProviderGerritREST,
+		RestUsername: "easycla",
+		RestPassword: "http-password",
+	}, &models.ClaGroup{ProjectID: "projectID"})
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultContributorGroupName, gerrit.ContributorGroupName)
+}
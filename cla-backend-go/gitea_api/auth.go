@@ -0,0 +1,31 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"context"
+
+	"golang.org/x/oauth2"
+)
+
+// OAuthConfig returns the OAuth2 configuration of the application registered on the Gitea instance, Gitea acts as
+// the OAuth2 provider identifying the contributors signing from a pull request
+func OAuthConfig(instanceURL, clientID, clientSecret, redirectURL string) *oauth2.Config {
+	instanceURL = NormalizeInstanceURL(instanceURL)
+	return &oauth2.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  instanceURL + "/login/oauth/authorize",
+			TokenURL: instanceURL + "/login/oauth/access_token",
+		},
+		RedirectURL: redirectURL,
+		Scopes:      []string{"read:user"},
+	}
+}
+
+// FetchOauthCredentials exchanges the authorization code for the access token of the contributor
+func FetchOauthCredentials(ctx context.Context, oauthConfig *oauth2.Config, code string) (*oauth2.Token, error) {
+	return oauthConfig.Exchange(ctx, code)
+}
@@ -0,0 +1,211 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/linuxfoundation/easycla/cla-backend-go/authinfo"
+)
+
+const (
+	// apiPath is the REST API path of the Gitea and Forgejo instances
+	apiPath = "/api/v1"
+
+	// maxPages guards the paginated listings against an endless listing
+	maxPages = 100
+	// pageLimit is the page size requested for the paginated listings - 50 is the default Gitea maximum
+	pageLimit = 50
+)
+
+// Client is a minimal Gitea REST API client authenticated with an access token, Forgejo exposes the same API
+type Client struct {
+	instanceURL string
+	restClient  *resty.Client
+}
+
+// APIError is returned when Gitea responds with a non 2xx status code
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	Body       string
+}
+
+// Error returns the error message
+func (e *APIError) Error() string {
+	return fmt.Sprintf("gitea %s %s failed with status code: %d, response: %s", e.Method, e.URL, e.StatusCode, e.Body)
+}
+
+// IsNotFound returns true if the error is a Gitea 404 response
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// NewGiteaClient creates a new gitea client for the instance from the given auth info, authInfo is encrypted
+func NewGiteaClient(instanceURL, authInfo string, giteaApp *App) (*Client, error) {
+	if authInfo == "" {
+		return nil, errors.New("unable to decrypt auth info - authentication info input is nil")
+	}
+	if giteaApp == nil || giteaApp.giteaAppPrivateKey == "" {
+		return nil, errors.New("unable to decrypt auth info - Gitea app structure is nil or empty")
+	}
+
+	accessToken, err := DecryptAuthInfo(authInfo, giteaApp)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGiteaClientFromAccessToken(instanceURL, accessToken), nil
+}
+
+// NewGiteaClientFromAccessToken creates a new gitea client for the instance from the given access token
+func NewGiteaClientFromAccessToken(instanceURL, accessToken string) *Client {
+	return &Client{
+		instanceURL: NormalizeInstanceURL(instanceURL),
+		restClient: resty.New().
+			SetHeader("Authorization", "token "+accessToken).
+			SetHeader("Accept", "application/json"),
+	}
+}
+
+// NormalizeInstanceURL returns the instance URL without the trailing slash, the instance URL is part of the
+// identity of the Gitea organizations and repositories
+func NormalizeInstanceURL(instanceURL string) string {
+	return strings.TrimSuffix(strings.TrimSpace(instanceURL), "/")
+}
+
+// InstanceURL returns the base URL of the Gitea instance
+func (c *Client) InstanceURL() string {
+	return c.instanceURL
+}
+
+// endpoint returns the absolute URL of the API path, the path segments are escaped
+func (c *Client) endpoint(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return c.instanceURL + apiPath + "/" + strings.Join(escaped, "/")
+}
+
+// do sends the request and decodes the JSON response into result, when set
+func (c *Client) do(method, requestURL string, body, result interface{}) error {
+	_, err := c.doWithResponse(method, requestURL, body, result)
+	return err
+}
+
+func (c *Client) doWithResponse(method, requestURL string, body, result interface{}) (*resty.Response, error) {
+	request := c.restClient.R()
+	if body != nil {
+		request.SetHeader("Content-Type", "application/json").SetBody(body)
+	}
+	resp, err := request.Execute(method, requestURL)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() < 200 || resp.StatusCode() > 299 {
+		return nil, &APIError{
+			StatusCode: resp.StatusCode(),
+			Method:     method,
+			URL:        requestURL,
+			Body:       string(resp.Body()),
+		}
+	}
+	if result != nil && len(resp.Body()) > 0 {
+		if err := json.Unmarshal(resp.Body(), result); err != nil {
+			return nil, fmt.Errorf("unable to decode gitea response from %s, error: %v", requestURL, err)
+		}
+	}
+	return resp, nil
+}
+
+// paginate walks the pages of a listing, calling visit for each value. The listing stops on the last page, or when
+// visit returns false.
+func (c *Client) paginate(requestURL string, query url.Values, visit func(value json.RawMessage) (bool, error)) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("limit", strconv.Itoa(pageLimit))
+
+	for page := 1; page <= maxPages; page++ {
+		query.Set("page", strconv.Itoa(page))
+		var values []json.RawMessage
+		resp, err := c.doWithResponse(http.MethodGet, requestURL+"?"+query.Encode(), nil, &values)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			more, err := visit(value)
+			if err != nil {
+				return err
+			}
+			if !more {
+				return nil
+			}
+		}
+		if !hasNextPage(resp.Header(), len(values)) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// hasNextPage uses the Link header when Gitea sends it, older instances only return full pages until the end of
+// the listing
+func hasNextPage(header http.Header, count int) bool {
+	if count == 0 {
+		return false
+	}
+	if link := header.Get("Link"); link != "" {
+		return strings.Contains(link, `rel="next"`)
+	}
+	return count >= pageLimit
+}
+
+// EncryptAuthInfo encrypts the access token into a string
+func EncryptAuthInfo(accessToken string, giteaApp *App) (string, error) {
+	keyDecoded, err := base64.StdEncoding.DecodeString(giteaApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("problem decoding Gitea private key, error: %v", err)
+	}
+
+	encrypted, err := authinfo.Encrypt(keyDecoded, []byte(accessToken))
+	if err != nil {
+		return "", fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	return hex.EncodeToString(encrypted), nil
+}
+
+// DecryptAuthInfo decrypts the auth info into the access token
+func DecryptAuthInfo(authInfoEncoded string, giteaApp *App) (string, error) {
+	ciphertext, err := hex.DecodeString(authInfoEncoded)
+	if err != nil {
+		return "", fmt.Errorf("decode auth info : %v", err)
+	}
+
+	keyDecoded, err := base64.StdEncoding.DecodeString(giteaApp.GetAppPrivateKey())
+	if err != nil {
+		return "", fmt.Errorf("decode key : %v", err)
+	}
+
+	decrypted, err := authinfo.Decrypt(keyDecoded, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt failed : %v", err)
+	}
+
+	return string(decrypted), nil
+}
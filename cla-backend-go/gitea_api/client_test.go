@@ -0,0 +1,120 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var giteaClientKey = "0WqnDWHnZKo2cmQ8m93EtY9ZBpfzQW4UnnEuRmgtJKM="
+
+func TestEncryptDecryptAuthInfo(t *testing.T) {
+	app := &App{giteaAppPrivateKey: giteaClientKey, giteaAppWebhookSecret: "secret"}
+
+	encrypted, err := EncryptAuthInfo("gitea-token", app)
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "gitea-token")
+
+	decrypted, err := DecryptAuthInfo(encrypted, app)
+	assert.NoError(t, err)
+	assert.Equal(t, "gitea-token", decrypted)
+}
+
+func TestGetPullRequestAuthorsFollowsPagination(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/acme/widgets/pulls/7/commits", r.URL.Path)
+		assert.Equal(t, "token gitea-token", r.Header.Get("Authorization"))
+		if r.URL.Query().Get("page") == "1" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s?page=2>; rel="next"`, r.URL.Path))
+			fmt.Fprint(w, `[{"sha":"a1","commit":{"author":{"name":"Jane Doe","email":"jane@example.org"}},"author":{"id":3,"login":"jane"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"sha":"b2","commit":{"author":{"name":"Jane Doe","email":"JANE@example.org"}}},{"sha":"c3","commit":{"author":{"name":"John Smith","email":"john@example.org"}}}]`)
+	}))
+	defer server.Close()
+
+	client := NewGiteaClientFromAccessToken(server.URL+"/", "gitea-token")
+	authors, err := client.GetPullRequestAuthors("acme", "widgets", 7)
+	assert.NoError(t, err)
+	if assert.Len(t, authors, 2) {
+		assert.Equal(t, "jane@example.org", authors[0].Email)
+		assert.Equal(t, []string{"a1", "b2"}, authors[0].SHAs)
+		assert.Equal(t, "jane", authors[0].User.Login)
+		assert.Equal(t, "john@example.org", authors[1].Email)
+		assert.Nil(t, authors[1].User)
+	}
+}
+
+func TestSetPullRequestCommentUpdatesExistingComment(t *testing.T) {
+	var updated string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/v1/repos/acme/widgets/issues/7/comments":
+			fmt.Fprint(w, `[{"id":1,"body":"LGTM"},{"id":2,"body":"![CLA](https://example.org/cla-not-signed.svg)"}]`)
+		case r.Method == http.MethodPatch && r.URL.Path == "/api/v1/repos/acme/widgets/issues/comments/2":
+			body, _ := io.ReadAll(r.Body)
+			var payload map[string]string
+			assert.NoError(t, json.Unmarshal(body, &payload))
+			updated = payload["body"]
+			fmt.Fprint(w, `{"id":2}`)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := NewGiteaClientFromAccessToken(server.URL, "gitea-token")
+	err := client.SetPullRequestComment("acme", "widgets", 7, "![CLA](https://example.org/cla-signed.svg)")
+	assert.NoError(t, err)
+	assert.Equal(t, "![CLA](https://example.org/cla-signed.svg)", updated)
+}
+
+func TestNotFoundResponse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message":"not found"}`)
+	}))
+	defer server.Close()
+
+	client := NewGiteaClientFromAccessToken(server.URL, "gitea-token")
+	_, err := client.GetRepositoryByID(42)
+	assert.Error(t, err)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestValidateSignature(t *testing.T) {
+	payload := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	assert.NoError(t, ValidateSignature(payload, signature, "secret"))
+	assert.Error(t, ValidateSignature(payload, signature, "other"))
+	assert.Error(t, ValidateSignature(payload, "", "secret"))
+	assert.Error(t, ValidateSignature(payload, signature, ""))
+}
+
+func TestParsePullRequestEvent(t *testing.T) {
+	event, err := ParsePullRequestEvent([]byte(`{"action":"synchronized","number":7,"pull_request":{"id":70,"number":7,"state":"open","head":{"sha":"a1"}},"repository":{"id":5,"name":"widgets","full_name":"acme/widgets","html_url":"https://git.example.org/forge/acme/widgets","owner":{"id":1,"login":"acme"}}}`))
+	assert.NoError(t, err)
+	assert.True(t, IsCheckedPullRequestAction(event.Action))
+	assert.Equal(t, int64(7), event.PullRequest.Number)
+	assert.Equal(t, "a1", event.PullRequest.HeadSha())
+	assert.Equal(t, "acme", event.Repository.OwnerLogin())
+	assert.Equal(t, "https://git.example.org/forge", event.Repository.InstanceURL())
+
+	_, err = ParsePullRequestEvent([]byte(`{"action":"opened","repository":{"id":5}}`))
+	assert.Error(t, err)
+}
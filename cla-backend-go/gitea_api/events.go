@@ -0,0 +1,109 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+)
+
+const (
+	// EventHeader is the request header holding the webhook event type
+	EventHeader = "X-Gitea-Event"
+	// SignatureHeader is the request header holding the hex encoded HMAC-SHA256 signature of the payload
+	SignatureHeader = "X-Gitea-Signature"
+
+	// EventPullRequest is sent when a pull request is opened, synchronized, reopened or closed
+	EventPullRequest = "pull_request"
+	// EventIssueComment is sent when an issue or pull request comment is posted - used to re-run the check
+	EventIssueComment = "issue_comment"
+
+	// PullRequestActionOpened is the action of a newly opened pull request
+	PullRequestActionOpened = "opened"
+	// PullRequestActionReopened is the action of a reopened pull request
+	PullRequestActionReopened = "reopened"
+	// PullRequestActionSynchronized is the action sent when commits are pushed to the pull request
+	PullRequestActionSynchronized = "synchronized"
+	// CommentActionCreated is the action of a new comment
+	CommentActionCreated = "created"
+)
+
+// PullRequestEvent is the webhook payload of the pull request events
+type PullRequestEvent struct {
+	Action      string       `json:"action"`
+	Number      int64        `json:"number"`
+	PullRequest *PullRequest `json:"pull_request"`
+	Repository  *Repository  `json:"repository"`
+	Sender      *User        `json:"sender,omitempty"`
+}
+
+// IssueCommentEvent is the webhook payload of the issue comment events, IsPull is set for pull request comments
+type IssueCommentEvent struct {
+	Action string `json:"action"`
+	Issue  *struct {
+		Number int64 `json:"number"`
+	} `json:"issue"`
+	Comment    *Comment    `json:"comment"`
+	Repository *Repository `json:"repository"`
+	IsPull     bool        `json:"is_pull"`
+}
+
+// ValidateSignature checks the payload signature sent by Gitea against the webhook secret
+func ValidateSignature(payload []byte, signature, secret string) error {
+	if secret == "" {
+		return errors.New("gitea webhook secret is not configured")
+	}
+	if signature == "" {
+		return errors.New("missing gitea webhook signature")
+	}
+	received, err := hex.DecodeString(signature)
+	if err != nil {
+		return errors.New("invalid gitea webhook signature")
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload) // nolint
+	if !hmac.Equal(received, mac.Sum(nil)) {
+		return errors.New("gitea webhook signature mismatch")
+	}
+	return nil
+}
+
+// IsCheckedPullRequestAction returns true if the pull request action requires a new CLA check
+func IsCheckedPullRequestAction(action string) bool {
+	return action == PullRequestActionOpened || action == PullRequestActionReopened || action == PullRequestActionSynchronized
+}
+
+// ParsePullRequestEvent decodes the pull request event payload, the payload must identify the repository and the
+// pull request
+func ParsePullRequestEvent(payload []byte) (*PullRequestEvent, error) {
+	var event PullRequestEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.PullRequest == nil || event.PullRequest.Number == 0 {
+		return nil, errors.New("gitea event payload is missing the pull request")
+	}
+	if event.Repository == nil || event.Repository.ID == 0 || event.Repository.OwnerLogin() == "" {
+		return nil, errors.New("gitea event payload is missing the repository")
+	}
+	return &event, nil
+}
+
+// ParseIssueCommentEvent decodes the issue comment event payload
+func ParseIssueCommentEvent(payload []byte) (*IssueCommentEvent, error) {
+	var event IssueCommentEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.Issue == nil || event.Issue.Number == 0 || event.Comment == nil {
+		return nil, errors.New("gitea event payload is missing the comment")
+	}
+	if event.Repository == nil || event.Repository.ID == 0 || event.Repository.OwnerLogin() == "" {
+		return nil, errors.New("gitea event payload is missing the repository")
+	}
+	return &event, nil
+}
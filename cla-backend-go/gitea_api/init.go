@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"sync"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+)
+
+// App is a wrapper for the Gitea configuration items shared by all the Gitea instances
+type App struct {
+	giteaAppPrivateKey    string
+	giteaAppWebhookSecret string
+}
+
+var giteaAppSingleton *App
+
+var once sync.Once
+
+// Init initializes the required gitea variables
+func Init(giteaAppPrivateKey, giteaAppWebhookSecret string) *App {
+	if giteaAppSingleton == nil {
+		once.Do(
+			func() {
+				log.Debug("Creating object single instance...")
+				giteaAppSingleton = &App{
+					giteaAppPrivateKey:    giteaAppPrivateKey,
+					giteaAppWebhookSecret: giteaAppWebhookSecret,
+				}
+			})
+	}
+	return giteaAppSingleton
+}
+
+// GetAppPrivateKey returns the key used to encrypt the stored Gitea credentials
+func (app *App) GetAppPrivateKey() string {
+	return app.giteaAppPrivateKey
+}
+
+// GetAppWebhookSecret returns the secret used to sign the Gitea webhook payloads
+func (app *App) GetAppWebhookSecret() string {
+	return app.giteaAppWebhookSecret
+}
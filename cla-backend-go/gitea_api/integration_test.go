@@ -0,0 +1,143 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+//go:build gitea_integration
+
+package gitea
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The integration tests run against the local Gitea instance started by testdata/setup.sh:
+//
+//	eval "$(./gitea_api/testdata/setup.sh)"
+//	go test -tags gitea_integration ./gitea_api/...
+func integrationClient(t *testing.T) *Client {
+	instanceURL := os.Getenv("GITEA_URL")
+	accessToken := os.Getenv("GITEA_TOKEN")
+	if instanceURL == "" || accessToken == "" {
+		t.Skip("GITEA_URL and GITEA_TOKEN are not set - run testdata/setup.sh first")
+	}
+	return NewGiteaClientFromAccessToken(instanceURL, accessToken)
+}
+
+// createPullRequestFixture creates an organization with a repository holding an open pull request
+func createPullRequestFixture(t *testing.T, c *Client) (string, string, *PullRequest) {
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	organizationName := "easycla-" + suffix
+	repositoryName := "project-" + suffix
+
+	require.NoError(t, c.do(http.MethodPost, c.endpoint("orgs"), map[string]interface{}{
+		"username":   organizationName,
+		"visibility": "public",
+	}, nil))
+	t.Cleanup(func() {
+		_ = c.do(http.MethodDelete, c.endpoint("repos", organizationName, repositoryName), nil, nil)
+		_ = c.do(http.MethodDelete, c.endpoint("orgs", organizationName), nil, nil)
+	})
+
+	require.NoError(t, c.do(http.MethodPost, c.endpoint("orgs", organizationName, "repos"), map[string]interface{}{
+		"name":           repositoryName,
+		"auto_init":      true,
+		"default_branch": "main",
+	}, nil))
+
+	require.NoError(t, c.do(http.MethodPost, c.endpoint("repos", organizationName, repositoryName, "contents", "CONTRIBUTING.md"), map[string]interface{}{
+		"content":    base64.StdEncoding.EncodeToString([]byte("contributions welcome\n")),
+		"message":    "Add contributing guide",
+		"branch":     "main",
+		"new_branch": "contributing",
+		"author": map[string]string{
+			"name":  "Contributor",
+			"email": "contributor@example.org",
+		},
+	}, nil))
+
+	var pullRequest PullRequest
+	require.NoError(t, c.do(http.MethodPost, c.endpoint("repos", organizationName, repositoryName, "pulls"), map[string]interface{}{
+		"title": "Add contributing guide",
+		"head":  "contributing",
+		"base":  "main",
+	}, &pullRequest))
+
+	return organizationName, repositoryName, &pullRequest
+}
+
+func TestIntegrationRepositories(t *testing.T) {
+	c := integrationClient(t)
+	organizationName, repositoryName, _ := createPullRequestFixture(t, c)
+
+	organization, err := c.GetOrganization(organizationName)
+	require.NoError(t, err)
+	assert.Equal(t, organizationName, organization.Name)
+
+	repositories, err := c.ListOrganizationRepositories(organizationName)
+	require.NoError(t, err)
+	require.Len(t, repositories, 1)
+	assert.Equal(t, repositoryName, repositories[0].Name)
+	assert.Equal(t, organizationName, repositories[0].OwnerLogin())
+
+	repository, err := c.GetRepositoryByID(repositories[0].ID)
+	require.NoError(t, err)
+	assert.Equal(t, repositories[0].FullName, repository.FullName)
+
+	_, err = c.GetRepository(organizationName, "missing-"+repositoryName)
+	assert.True(t, IsNotFound(err))
+}
+
+func TestIntegrationWebHook(t *testing.T) {
+	c := integrationClient(t)
+	organizationName, repositoryName, _ := createPullRequestFixture(t, c)
+	hookURL := "http://localhost:8080/v4/gitea/activity"
+
+	// idempotent - the second call updates the existing hook
+	require.NoError(t, c.SetWebHook(organizationName, repositoryName, hookURL, "secret"))
+	require.NoError(t, c.SetWebHook(organizationName, repositoryName, hookURL, "rotated-secret"))
+
+	hook, err := c.findExistingWebHook(organizationName, repositoryName, hookURL)
+	require.NoError(t, err)
+	require.NotNil(t, hook)
+	assert.True(t, hook.Active)
+	assert.ElementsMatch(t, webhookEvents(), hook.Events)
+
+	require.NoError(t, c.RemoveWebHook(organizationName, repositoryName, hookURL))
+	hook, err = c.findExistingWebHook(organizationName, repositoryName, hookURL)
+	require.NoError(t, err)
+	assert.Nil(t, hook)
+}
+
+func TestIntegrationPullRequest(t *testing.T) {
+	c := integrationClient(t)
+	organizationName, repositoryName, created := createPullRequestFixture(t, c)
+
+	pullRequest, err := c.GetPullRequest(organizationName, repositoryName, created.Number)
+	require.NoError(t, err)
+	assert.Equal(t, PullRequestStateOpen, pullRequest.State)
+
+	authors, err := c.GetPullRequestAuthors(organizationName, repositoryName, pullRequest.Number)
+	require.NoError(t, err)
+	require.Len(t, authors, 1)
+	assert.Equal(t, "contributor@example.org", authors[0].Email)
+
+	require.NoError(t, c.SetCommitStatus(organizationName, repositoryName, pullRequest.HeadSha(), CommitStatusFailure, "missing CLA", "http://localhost/sign"))
+
+	// the CLA comment is updated in place
+	for i := 0; i < 2; i++ {
+		message := fmt.Sprintf("![CLA Not Signed](https://s3.amazonaws.com/cla-project-logo-prod/cla-not-signed.svg) please sign the CLA (%d)", i)
+		require.NoError(t, c.SetPullRequestComment(organizationName, repositoryName, pullRequest.Number, message))
+	}
+	var comments []*Comment
+	require.NoError(t, c.do(http.MethodGet, c.endpoint("repos", organizationName, repositoryName, "issues", strconv.FormatInt(pullRequest.Number, 10), "comments"), nil, &comments))
+	require.Len(t, comments, 1)
+	assert.Contains(t, comments[0].Body, "(1)")
+}
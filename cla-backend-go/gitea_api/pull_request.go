@@ -0,0 +1,216 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// CommitStatusSuccess is the commit status state of a passing check
+	CommitStatusSuccess = "success"
+	// CommitStatusFailure is the commit status state of a failing check
+	CommitStatusFailure = "failure"
+
+	// CommitStatusContext is the context identifying the EasyCLA commit status
+	CommitStatusContext = "EasyCLA"
+
+	// PullRequestStateOpen is the state of the open pull requests
+	PullRequestStateOpen = "open"
+)
+
+// PullRequestBranch is the head or base branch of a pull request
+type PullRequestBranch struct {
+	Ref  string      `json:"ref"`
+	Sha  string      `json:"sha"`
+	Repo *Repository `json:"repo,omitempty"`
+}
+
+// PullRequest is a Gitea pull request
+type PullRequest struct {
+	ID      int64              `json:"id"`
+	Number  int64              `json:"number"`
+	State   string             `json:"state"`
+	Title   string             `json:"title,omitempty"`
+	HTMLURL string             `json:"html_url"`
+	User    *User              `json:"user,omitempty"`
+	Head    *PullRequestBranch `json:"head,omitempty"`
+	Base    *PullRequestBranch `json:"base,omitempty"`
+}
+
+// HeadSha returns the sha of the last commit of the pull request
+func (pr *PullRequest) HeadSha() string {
+	if pr.Head == nil {
+		return ""
+	}
+	return pr.Head.Sha
+}
+
+// CommitUser is the git author or committer of a commit
+type CommitUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Commit is a commit of a pull request, Author is the Gitea account matching the git author when known
+type Commit struct {
+	SHA    string `json:"sha"`
+	Commit struct {
+		Author  CommitUser `json:"author"`
+		Message string     `json:"message"`
+	} `json:"commit"`
+	Author *User `json:"author,omitempty"`
+}
+
+// PullRequestAuthor is a commit author of the pull request along with the commits authored
+type PullRequestAuthor struct {
+	Name  string
+	Email string
+	// User is the Gitea account of the author, nil when the commit email isn't linked to an account
+	User *User
+	SHAs []string
+}
+
+// Comment is a pull request comment, Gitea stores pull request comments as issue comments
+type Comment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+	User *User  `json:"user,omitempty"`
+}
+
+// GetPullRequest returns the pull request of the repository
+func (c *Client) GetPullRequest(owner, repositoryName string, index int64) (*PullRequest, error) {
+	var pullRequest PullRequest
+	if err := c.do(http.MethodGet, c.endpoint("repos", owner, repositoryName, "pulls", strconv.FormatInt(index, 10)), nil, &pullRequest); err != nil {
+		return nil, fmt.Errorf("fetching pull request : %d for repository : %s/%s failed : %w", index, owner, repositoryName, err)
+	}
+	return &pullRequest, nil
+}
+
+// ListOpenPullRequests returns the open pull requests of the repository
+func (c *Client) ListOpenPullRequests(owner, repositoryName string) ([]*PullRequest, error) {
+	var results []*PullRequest
+	query := url.Values{}
+	query.Set("state", PullRequestStateOpen)
+	err := c.paginate(c.endpoint("repos", owner, repositoryName, "pulls"), query, func(value json.RawMessage) (bool, error) {
+		var pullRequest PullRequest
+		if err := json.Unmarshal(value, &pullRequest); err != nil {
+			return false, err
+		}
+		results = append(results, &pullRequest)
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching open pull requests for repository : %s/%s failed : %w", owner, repositoryName, err)
+	}
+	return results, nil
+}
+
+// ListPullRequestCommits returns the commits of the pull request
+func (c *Client) ListPullRequestCommits(owner, repositoryName string, index int64) ([]*Commit, error) {
+	var results []*Commit
+	err := c.paginate(c.endpoint("repos", owner, repositoryName, "pulls", strconv.FormatInt(index, 10), "commits"), nil, func(value json.RawMessage) (bool, error) {
+		var commit Commit
+		if err := json.Unmarshal(value, &commit); err != nil {
+			return false, err
+		}
+		results = append(results, &commit)
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching commits of pull request : %d for repository : %s/%s failed : %w", index, owner, repositoryName, err)
+	}
+	return results, nil
+}
+
+// GetPullRequestAuthors returns the unique commit authors of the pull request
+func (c *Client) GetPullRequestAuthors(owner, repositoryName string, index int64) ([]*PullRequestAuthor, error) {
+	commits, err := c.ListPullRequestCommits(owner, repositoryName, index)
+	if err != nil {
+		return nil, err
+	}
+	return GroupCommitAuthors(commits), nil
+}
+
+// GroupCommitAuthors groups the commits by author email, in the order the authors are first seen
+func GroupCommitAuthors(commits []*Commit) []*PullRequestAuthor {
+	var authors []*PullRequestAuthor
+	byKey := make(map[string]*PullRequestAuthor)
+	for _, commit := range commits {
+		key := strings.ToLower(strings.TrimSpace(commit.Commit.Author.Email))
+		if key == "" {
+			key = "name:" + commit.Commit.Author.Name
+		}
+		author, ok := byKey[key]
+		if !ok {
+			author = &PullRequestAuthor{
+				Name:  commit.Commit.Author.Name,
+				Email: strings.ToLower(strings.TrimSpace(commit.Commit.Author.Email)),
+			}
+			byKey[key] = author
+			authors = append(authors, author)
+		}
+		if author.User == nil && commit.Author != nil && commit.Author.ID != 0 {
+			author.User = commit.Author
+		}
+		author.SHAs = append(author.SHAs, commit.SHA)
+	}
+	return authors
+}
+
+// SetCommitStatus sets the EasyCLA commit status of the sha
+func (c *Client) SetCommitStatus(owner, repositoryName, sha, state, description, targetURL string) error {
+	body := map[string]string{
+		"state":       state,
+		"context":     CommitStatusContext,
+		"description": description,
+	}
+	if targetURL != "" {
+		body["target_url"] = targetURL
+	}
+	if err := c.do(http.MethodPost, c.endpoint("repos", owner, repositoryName, "statuses", sha), body, nil); err != nil {
+		return fmt.Errorf("setting commit status for the sha : %s and repository : %s/%s failed : %w", sha, owner, repositoryName, err)
+	}
+	return nil
+}
+
+// SetPullRequestComment creates the EasyCLA comment of the pull request, or updates the previous one
+func (c *Client) SetPullRequestComment(owner, repositoryName string, index int64, message string) error {
+	var previous *Comment
+	err := c.paginate(c.endpoint("repos", owner, repositoryName, "issues", strconv.FormatInt(index, 10), "comments"), nil, func(value json.RawMessage) (bool, error) {
+		var comment Comment
+		if err := json.Unmarshal(value, &comment); err != nil {
+			return false, err
+		}
+		if IsCLAComment(comment.Body) {
+			previous = &comment
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return fmt.Errorf("fetching comments for pull request : %d of repository : %s/%s failed : %w", index, owner, repositoryName, err)
+	}
+
+	body := map[string]string{"body": message}
+	if previous == nil {
+		err = c.do(http.MethodPost, c.endpoint("repos", owner, repositoryName, "issues", strconv.FormatInt(index, 10), "comments"), body, nil)
+	} else {
+		err = c.do(http.MethodPatch, c.endpoint("repos", owner, repositoryName, "issues", "comments", strconv.FormatInt(previous.ID, 10)), body, nil)
+	}
+	if err != nil {
+		return fmt.Errorf("setting comment for pull request : %d of repository : %s/%s failed : %w", index, owner, repositoryName, err)
+	}
+	return nil
+}
+
+// IsCLAComment returns true if the comment is the EasyCLA status comment
+func IsCLAComment(body string) bool {
+	return strings.Contains(body, "cla-signed.svg") || strings.Contains(body, "cla-not-signed.svg") || strings.Contains(body, "cla-missing-id.svg") || strings.Contains(body, "cla-confirmation-needed.svg")
+}
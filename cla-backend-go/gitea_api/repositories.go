@@ -0,0 +1,109 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// User is a Gitea user account
+type User struct {
+	ID       int64  `json:"id"`
+	Login    string `json:"login"`
+	FullName string `json:"full_name,omitempty"`
+	Email    string `json:"email,omitempty"`
+	HTMLURL  string `json:"html_url,omitempty"`
+}
+
+// Organization is a Gitea organization, Gitea returns the organization name as the user name
+type Organization struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"username"`
+	FullName string `json:"full_name,omitempty"`
+	Website  string `json:"website,omitempty"`
+}
+
+// Repository is a Gitea repository
+type Repository struct {
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Owner         *User  `json:"owner,omitempty"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch,omitempty"`
+	Private       bool   `json:"private"`
+	Archived      bool   `json:"archived"`
+}
+
+// OwnerLogin returns the login of the repository owner
+func (r *Repository) OwnerLogin() string {
+	if r.Owner == nil {
+		return ""
+	}
+	return r.Owner.Login
+}
+
+// InstanceURL returns the base URL of the Gitea instance hosting the repository, derived from the repository web URL
+func (r *Repository) InstanceURL() string {
+	return NormalizeInstanceURL(strings.TrimSuffix(r.HTMLURL, "/"+r.FullName))
+}
+
+// GetOrganization returns the organization by name
+func (c *Client) GetOrganization(organizationName string) (*Organization, error) {
+	var organization Organization
+	if err := c.do(http.MethodGet, c.endpoint("orgs", organizationName), nil, &organization); err != nil {
+		return nil, fmt.Errorf("fetching gitea organization : %s failed : %w", organizationName, err)
+	}
+	return &organization, nil
+}
+
+// ListOrganizationRepositories returns the repositories of the organization, the archived repositories are skipped
+func (c *Client) ListOrganizationRepositories(organizationName string) ([]*Repository, error) {
+	var results []*Repository
+	err := c.paginate(c.endpoint("orgs", organizationName, "repos"), nil, func(value json.RawMessage) (bool, error) {
+		var repository Repository
+		if err := json.Unmarshal(value, &repository); err != nil {
+			return false, err
+		}
+		if !repository.Archived {
+			results = append(results, &repository)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching repositories of the gitea organization : %s failed : %w", organizationName, err)
+	}
+	return results, nil
+}
+
+// GetRepository returns the repository of the owner by name
+func (c *Client) GetRepository(owner, repositoryName string) (*Repository, error) {
+	var repository Repository
+	if err := c.do(http.MethodGet, c.endpoint("repos", owner, repositoryName), nil, &repository); err != nil {
+		return nil, fmt.Errorf("fetching gitea repository : %s/%s failed : %w", owner, repositoryName, err)
+	}
+	return &repository, nil
+}
+
+// GetRepositoryByID returns the repository by its Gitea ID
+func (c *Client) GetRepositoryByID(repositoryID int64) (*Repository, error) {
+	var repository Repository
+	if err := c.do(http.MethodGet, c.endpoint("repositories", strconv.FormatInt(repositoryID, 10)), nil, &repository); err != nil {
+		return nil, fmt.Errorf("fetching gitea repository : %d failed : %w", repositoryID, err)
+	}
+	return &repository, nil
+}
+
+// GetCurrentUser returns the user owning the access token
+func (c *Client) GetCurrentUser() (*User, error) {
+	var user User
+	if err := c.do(http.MethodGet, c.endpoint("user"), nil, &user); err != nil {
+		return nil, fmt.Errorf("fetching gitea current user failed : %w", err)
+	}
+	return &user, nil
+}
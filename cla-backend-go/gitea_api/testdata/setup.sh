@@ -0,0 +1,44 @@
+#!/usr/bin/env bash
+# Copyright The Linux Foundation and each contributor to CommunityBridge.
+# SPDX-License-Identifier: MIT
+
+# Starts a throwaway Gitea instance and prints the environment used by the Gitea integration tests:
+#
+#   eval "$(./gitea_api/testdata/setup.sh)"
+#   go test -tags gitea_integration ./gitea_api/...
+#
+# Stop the instance with: docker compose -f gitea_api/testdata/docker-compose.yml down
+set -euo pipefail
+
+SCRIPT_DIR="$(cd "$(dirname "${BASH_SOURCE[0]}")" && pwd)"
+COMPOSE_FILE="${SCRIPT_DIR}/docker-compose.yml"
+GITEA_URL="${GITEA_URL:-http://localhost:3000}"
+ADMIN_USER="${GITEA_ADMIN_USER:-easycla}"
+ADMIN_PASSWORD="${GITEA_ADMIN_PASSWORD:-easycla-password}"
+
+docker compose -f "${COMPOSE_FILE}" up -d >&2
+
+echo "waiting for gitea at ${GITEA_URL}..." >&2
+for _ in $(seq 1 60); do
+  if curl -sf "${GITEA_URL}/api/healthz" >/dev/null; then
+    break
+  fi
+  sleep 2
+done
+curl -sf "${GITEA_URL}/api/healthz" >/dev/null
+
+docker compose -f "${COMPOSE_FILE}" exec -T -u git gitea \
+  gitea admin user create --admin --username "${ADMIN_USER}" --password "${ADMIN_PASSWORD}" \
+  --email "${ADMIN_USER}@example.org" --must-change-password=false >&2 || true
+
+TOKEN=$(curl -sf -u "${ADMIN_USER}:${ADMIN_PASSWORD}" -H "Content-Type: application/json" \
+  -d "{\"name\":\"easycla-$(date +%s)\",\"scopes\":[\"all\"]}" \
+  "${GITEA_URL}/api/v1/users/${ADMIN_USER}/tokens" | sed -n 's/.*"sha1":"\([^"]*\)".*/\1/p')
+
+if [ -z "${TOKEN}" ]; then
+  echo "unable to create the gitea access token" >&2
+  exit 1
+fi
+
+echo "export GITEA_URL=${GITEA_URL}"
+echo "export GITEA_TOKEN=${TOKEN}"
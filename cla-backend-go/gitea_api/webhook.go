@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Hook is a Gitea repository webhook
+type Hook struct {
+	ID     int64             `json:"id"`
+	Type   string            `json:"type"`
+	Config map[string]string `json:"config"`
+	Events []string          `json:"events"`
+	Active bool              `json:"active"`
+}
+
+// webhookEvents are the events EasyCLA subscribes to - the issue comment events carry the pull request comments
+func webhookEvents() []string {
+	return []string{EventPullRequest, EventIssueComment}
+}
+
+// SetWebHook is responsible for adding the webhook for given repository, if webhook is there already
+// tries to activate it, should be idempotent operation
+func (c *Client) SetWebHook(owner, repositoryName, hookURL, secret string) error {
+	existingWebHook, err := c.findExistingWebHook(owner, repositoryName, hookURL)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]interface{}{
+		"type": "gitea",
+		"config": map[string]string{
+			"url":          hookURL,
+			"content_type": "json",
+			"secret":       secret,
+		},
+		"events": webhookEvents(),
+		"active": true,
+	}
+
+	if existingWebHook == nil {
+		if err := c.do(http.MethodPost, c.endpoint("repos", owner, repositoryName, "hooks"), body, nil); err != nil {
+			return fmt.Errorf("adding web hook for repository : %s/%s, failed : %w", owner, repositoryName, err)
+		}
+		return nil
+	}
+
+	// The secret isn't returned by Gitea, the hook is always updated so that a rotated secret is applied
+	delete(body, "type")
+	if err := c.do(http.MethodPatch, c.endpoint("repos", owner, repositoryName, "hooks", strconv.FormatInt(existingWebHook.ID, 10)), body, nil); err != nil {
+		return fmt.Errorf("editing web hook for repository : %s/%s, failed : %w", owner, repositoryName, err)
+	}
+	return nil
+}
+
+// RemoveWebHook removes existing webhook from the given repository
+func (c *Client) RemoveWebHook(owner, repositoryName, hookURL string) error {
+	existingWebHook, err := c.findExistingWebHook(owner, repositoryName, hookURL)
+	if err != nil {
+		return err
+	}
+	if existingWebHook == nil {
+		return nil
+	}
+
+	return c.do(http.MethodDelete, c.endpoint("repos", owner, repositoryName, "hooks", strconv.FormatInt(existingWebHook.ID, 10)), nil, nil)
+}
+
+func (c *Client) findExistingWebHook(owner, repositoryName, hookURL string) (*Hook, error) {
+	var existingWebHook *Hook
+	err := c.paginate(c.endpoint("repos", owner, repositoryName, "hooks"), nil, func(value json.RawMessage) (bool, error) {
+		var hook Hook
+		if err := json.Unmarshal(value, &hook); err != nil {
+			return false, err
+		}
+		if hook.Config["url"] == hookURL {
+			existingWebHook = &hook
+			return false, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching hooks for repository : %s/%s, failed : %w", owner, repositoryName, err)
+	}
+	return existingWebHook, nil
+}
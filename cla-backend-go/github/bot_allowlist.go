@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
+// SplitExemptBotAuthors splits the commit authors into the authors to check and the bot authors exempt from the CLA
+// check by the allowlist - the matched allowlist entry is set on the exempt summaries
+func SplitExemptBotAuthors(authors []*UserCommitSummary, allowlist *utils.BotAllowlist) ([]*UserCommitSummary, []*UserCommitSummary) {
+	if allowlist.IsEmpty() {
+		return authors, nil
+	}
+
+	checked := make([]*UserCommitSummary, 0, len(authors))
+	var exempt []*UserCommitSummary
+	for _, summary := range authors {
+		if entry, ok := summary.matchBotAllowlist(allowlist); ok {
+			summary.ExemptBy = entry
+			exempt = append(exempt, summary)
+			continue
+		}
+		checked = append(checked, summary)
+	}
+	return checked, exempt
+}
+
+// matchBotAllowlist matches the verified identity of the commit author against the allowlist: the login of the GitHub
+// account linked to the commit, or the git commit email when no account is linked and GitHub verified the commit
+// signature for that email. The git commit email and the Co-authored-by trailers are set by the committer, so they
+// never exempt an author on their own.
+func (u UserCommitSummary) matchBotAllowlist(allowlist *utils.BotAllowlist) (string, bool) {
+	if u.CoAuthor != nil {
+		return "", false
+	}
+	if u.CommitAuthor != nil {
+		return allowlist.Match(u.CommitAuthor.GetLogin(), "")
+	}
+	if u.GitEmailVerified {
+		return allowlist.Match("", u.GitEmail)
+	}
+	return "", false
+}
@@ -0,0 +1,56 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitExemptBotAuthors(t *testing.T) {
+	dependabot := &UserCommitSummary{SHA: "sha1", CommitAuthor: &github.User{ID: github.Int64(49699333), Login: github.String("dependabot[bot]")}}
+	releaseBot := &UserCommitSummary{SHA: "sha2", GitEmail: "release-bot@example.org", GitEmailVerified: true}
+	alice := &UserCommitSummary{SHA: "sha3", CommitAuthor: &github.User{ID: github.Int64(1), Login: github.String("alice")}, GitEmail: "alice@example.org"}
+	// the git commit email and the co-author trailers are set by the committer and never exempt an author
+	unverified := &UserCommitSummary{SHA: "sha4", GitEmail: "release-unverified@example.org"}
+	linked := &UserCommitSummary{SHA: "sha5", CommitAuthor: &github.User{ID: github.Int64(2), Login: github.String("mallory")}, GitEmail: "release-mallory@example.org", GitEmailVerified: true}
+	coAuthor := &UserCommitSummary{SHA: "sha6", CoAuthor: &utils.CoAuthor{Name: "bot", Email: "release-co-author@example.org"}}
+	authors := []*UserCommitSummary{dependabot, releaseBot, alice, unverified, linked, coAuthor}
+
+	checked, exempt := SplitExemptBotAuthors(authors, nil)
+	assert.Equal(t, authors, checked)
+	assert.Empty(t, exempt)
+
+	checked, exempt = SplitExemptBotAuthors(authors, &utils.BotAllowlist{
+		Logins:        []string{"Dependabot[bot]"},
+		EmailPatterns: []string{"release-*@example.org"},
+	})
+	assert.Equal(t, []*UserCommitSummary{alice, unverified, linked, coAuthor}, checked)
+	assert.Equal(t, []*UserCommitSummary{dependabot, releaseBot}, exempt)
+	assert.Equal(t, "Dependabot[bot]", dependabot.ExemptBy)
+	assert.Equal(t, "release-*@example.org", releaseBot.ExemptBy)
+	assert.Empty(t, alice.ExemptBy)
+}
+
+func TestExemptBotAuthorsRendering(t *testing.T) {
+	signed := []*UserCommitSummary{
+		{SHA: "sha1", CommitAuthor: &github.User{ID: github.Int64(1), Login: github.String("alice")}, Authorized: true},
+	}
+	exempt := []*UserCommitSummary{
+		{SHA: "sha2", CommitAuthor: &github.User{ID: github.Int64(2), Login: github.String("renovate[bot]")}, ExemptBy: "renovate[bot]"},
+	}
+
+	body := getCommentBody("github", "https://example.org/sign", signed, nil, exempt)
+	assert.Contains(t, body, ":robot: login: renovate[bot]")
+	assert.Contains(t, body, "matches the bot allowlist entry 'renovate[bot]'")
+	assert.Contains(t, body, "authorized under a signed CLA or exempt from the CLA check")
+
+	text := assembleCLACheckRunText(signed, nil, exempt)
+	assert.Contains(t, text, "### Exempt Bots")
+	assert.Contains(t, text, "- :robot: login: renovate[bot]")
+	assert.Contains(t, assembleCLACheckRunSummary(nil, nil, exempt, "https://example.org"), "All 1 commit author(s) are bots exempt")
+}
@@ -25,8 +25,18 @@ type V4BranchProtectionRepository interface {
 	GetRepositoryIDFromName(ctx context.Context, repositoryOwner, repositoryName string) (string, error)
 }
 
-// CombinedRepository is combination of V3Repositories and V4BranchProtectionRepository
+// V3Rulesets has the v3 rulesets functionality, rulesets may protect the branches instead of the classic branch
+// protection rules
+type V3Rulesets interface {
+	GetRulesForBranch(ctx context.Context, owner, repoName, branchName string) ([]*BranchRule, error)
+	GetRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64) (*Ruleset, error)
+	UpdateRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64, update *RulesetUpdate) (*Ruleset, error)
+	CreateRepositoryRuleset(ctx context.Context, owner, repoName string, ruleset *Ruleset) (*Ruleset, error)
+}
+
+// CombinedRepository is combination of V3Repositories, V3Rulesets and V4BranchProtectionRepository
 type CombinedRepository interface {
 	V3Repositories
+	V3Rulesets
 	V4BranchProtectionRepository
 }
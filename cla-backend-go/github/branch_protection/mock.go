@@ -55,6 +55,21 @@ func (mr *MockCombinedRepositoryMockRecorder) CreateBranchProtection(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBranchProtection", reflect.TypeOf((*MockCombinedRepository)(nil).CreateBranchProtection), arg0, arg1)
 }
 
+// CreateRepositoryRuleset mocks base method
+func (m *MockCombinedRepository) CreateRepositoryRuleset(arg0 context.Context, arg1, arg2 string, arg3 *Ruleset) (*Ruleset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRepositoryRuleset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*Ruleset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRepositoryRuleset indicates an expected call of CreateRepositoryRuleset
+func (mr *MockCombinedRepositoryMockRecorder) CreateRepositoryRuleset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRepositoryRuleset", reflect.TypeOf((*MockCombinedRepository)(nil).CreateRepositoryRuleset), arg0, arg1, arg2, arg3)
+}
+
 // Get mocks base method
 func (m *MockCombinedRepository) Get(arg0 context.Context, arg1, arg2 string) (*github.Repository, *github.Response, error) {
 	m.ctrl.T.Helper()
@@ -101,6 +116,36 @@ func (mr *MockCombinedRepositoryMockRecorder) GetRepositoryIDFromName(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryIDFromName", reflect.TypeOf((*MockCombinedRepository)(nil).GetRepositoryIDFromName), arg0, arg1, arg2)
 }
 
+// GetRepositoryRuleset mocks base method
+func (m *MockCombinedRepository) GetRepositoryRuleset(arg0 context.Context, arg1, arg2 string, arg3 int64) (*Ruleset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRepositoryRuleset", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*Ruleset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRepositoryRuleset indicates an expected call of GetRepositoryRuleset
+func (mr *MockCombinedRepositoryMockRecorder) GetRepositoryRuleset(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRepositoryRuleset", reflect.TypeOf((*MockCombinedRepository)(nil).GetRepositoryRuleset), arg0, arg1, arg2, arg3)
+}
+
+// GetRulesForBranch mocks base method
+func (m *MockCombinedRepository) GetRulesForBranch(arg0 context.Context, arg1, arg2, arg3 string) ([]*BranchRule, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRulesForBranch", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*BranchRule)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRulesForBranch indicates an expected call of GetRulesForBranch
+func (mr *MockCombinedRepositoryMockRecorder) GetRulesForBranch(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRulesForBranch", reflect.TypeOf((*MockCombinedRepository)(nil).GetRulesForBranch), arg0, arg1, arg2, arg3)
+}
+
 // ListByOrg mocks base method
 func (m *MockCombinedRepository) ListByOrg(arg0 context.Context, arg1 string, arg2 *github.RepositoryListByOrgOptions) ([]*github.Repository, *github.Response, error) {
 	m.ctrl.T.Helper()
@@ -131,3 +176,18 @@ func (mr *MockCombinedRepositoryMockRecorder) UpdateBranchProtection(arg0, arg1
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBranchProtection", reflect.TypeOf((*MockCombinedRepository)(nil).UpdateBranchProtection), arg0, arg1)
 }
+
+// UpdateRepositoryRuleset mocks base method
+func (m *MockCombinedRepository) UpdateRepositoryRuleset(arg0 context.Context, arg1, arg2 string, arg3 int64, arg4 *RulesetUpdate) (*Ruleset, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRepositoryRuleset", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*Ruleset)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateRepositoryRuleset indicates an expected call of UpdateRepositoryRuleset
+func (mr *MockCombinedRepositoryMockRecorder) UpdateRepositoryRuleset(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRepositoryRuleset", reflect.TypeOf((*MockCombinedRepository)(nil).UpdateRepositoryRuleset), arg0, arg1, arg2, arg3, arg4)
+}
@@ -29,6 +29,7 @@ var (
 
 type combinedRepositoryProvider struct {
 	V3Repositories
+	V3Rulesets
 	V4BranchProtectionRepository
 }
 
@@ -73,6 +74,7 @@ func NewBranchProtectionRepository(installationID int64, opts ...BranchProtectio
 
 	combinedRepo := combinedRepositoryProvider{
 		V3Repositories:               v3Client.Repositories,
+		V3Rulesets:                   NewRulesetsV3(v3Client),
 		V4BranchProtectionRepository: v4BranchProtectionRepo,
 	}
 
@@ -182,8 +184,117 @@ func (bp *BranchProtectionRepository) GetProtectedBranch(ctx context.Context, ow
 	return nil, ErrBranchNotProtected
 }
 
+// GetBranchProtection fetches the protection of the branch, the classic branch protection rule of the branch pattern
+// when it exists, otherwise the rulesets of the repository and of the organization applying to the branch
+func (bp *BranchProtectionRepository) GetBranchProtection(ctx context.Context, owner, repoName, branchName string) (*BranchProtection, error) {
+	repoName = CleanGithubRepoName(repoName)
+	rule, err := bp.GetProtectedBranch(ctx, owner, repoName, branchName)
+	if err == nil {
+		return &BranchProtection{Mechanism: ProtectionMechanismClassic, Rule: rule}, nil
+	}
+	if !errors.Is(err, ErrBranchNotProtected) {
+		return nil, err
+	}
+
+	rules, err := bp.getRulesForBranch(ctx, owner, repoName, branchName)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) == 0 {
+		return nil, ErrBranchNotProtected
+	}
+
+	var rulesets []*Ruleset
+	seen := map[int64]bool{}
+	for _, branchRule := range rules {
+		if seen[branchRule.RulesetID] {
+			continue
+		}
+		seen[branchRule.RulesetID] = true
+		ruleset, rulesetErr := bp.combinedRepo.GetRepositoryRuleset(ctx, owner, repoName, branchRule.RulesetID)
+		if rulesetErr != nil {
+			return nil, fmt.Errorf("fetching ruleset %d for owner : %s and repoName : %s failed : %w", branchRule.RulesetID, owner, repoName, rulesetErr)
+		}
+		rulesets = append(rulesets, ruleset)
+	}
+
+	rule, err = rulesetBranchProtectionRule(branchName, rules, rulesets)
+	if err != nil {
+		return nil, err
+	}
+
+	// without a repository ruleset the branch is only protected by the organization (or enterprise) rulesets
+	mechanism := ProtectionMechanismOrganizationRuleset
+	rulesetID := rules[0].RulesetID
+	if managedID, ok := managedRulesetID(rules); ok {
+		mechanism = ProtectionMechanismRepositoryRuleset
+		rulesetID = managedID
+	}
+	rule.ID = fmt.Sprintf("%d", rulesetID)
+	return &BranchProtection{Mechanism: mechanism, Rule: rule, Rulesets: rulesets}, nil
+}
+
+// getRulesForBranch returns the ruleset rules applying to the branch, the rules of the default branch are returned
+// when the branch name is a pattern
+func (bp *BranchProtectionRepository) getRulesForBranch(ctx context.Context, owner, repoName, branchName string) ([]*BranchRule, error) {
+	if strings.Contains(branchName, "*") {
+		defaultBranch, err := bp.GetDefaultBranchForRepo(ctx, owner, repoName)
+		if err != nil {
+			return nil, err
+		}
+		branchName = defaultBranch
+	}
+	return bp.combinedRepo.GetRulesForBranch(ctx, owner, repoName, branchName)
+}
+
+// enableRulesetStatusChecks applies the status checks to the repository ruleset EasyCLA manages among the rulesets
+// applying to the branch, returns false when no ruleset protects the branch. The organization and enterprise rulesets
+// are never updated - when only those protect the branch a repository ruleset requiring the status checks is created.
+func (bp *BranchProtectionRepository) enableRulesetStatusChecks(ctx context.Context, owner, repoName, branchName string, enforceAdmin bool, enableStatusChecks, disableStatusChecks []string) (bool, error) {
+	rules, err := bp.getRulesForBranch(ctx, owner, repoName, branchName)
+	if err != nil {
+		return false, err
+	}
+	if len(rules) == 0 {
+		return false, nil
+	}
+
+	rulesetID, ok := managedRulesetID(rules)
+	if !ok {
+		ruleset, rulesetErr := newEasyCLARuleset(branchName, enforceAdmin, enableStatusChecks)
+		if rulesetErr != nil {
+			return false, rulesetErr
+		}
+		if _, err = bp.combinedRepo.CreateRepositoryRuleset(ctx, owner, repoName, ruleset); err != nil {
+			return false, fmt.Errorf("creating the %s ruleset for owner : %s and repo : %s failed : %w", EasyCLARulesetName, owner, repoName, err)
+		}
+		return true, nil
+	}
+
+	ruleset, err := bp.combinedRepo.GetRepositoryRuleset(ctx, owner, repoName, rulesetID)
+	if err != nil {
+		return false, fmt.Errorf("fetching ruleset %d for owner : %s and repo : %s failed : %w", rulesetID, owner, repoName, err)
+	}
+	mergedRules, err := mergeRulesetStatusChecks(ruleset.Rules, enableStatusChecks, disableStatusChecks)
+	if err != nil {
+		return false, err
+	}
+	update := &RulesetUpdate{Rules: mergedRules}
+	if enforceAdmin {
+		if bypassActors, removed := withoutAdminBypassActors(ruleset.BypassActors); removed {
+			update.BypassActors = &bypassActors
+		}
+	}
+
+	if _, err = bp.combinedRepo.UpdateRepositoryRuleset(ctx, owner, repoName, rulesetID, update); err != nil {
+		return false, fmt.Errorf("updating ruleset %d for owner : %s and repo : %s failed : %w", rulesetID, owner, repoName, err)
+	}
+	return true, nil
+}
+
 // EnableBranchProtection enables branch protection if not enabled and makes sure passed arguments such as enforceAdmin
-// statusChecks are applied. The operation makes sure it doesn't override the existing checks.
+// statusChecks are applied. The operation makes sure it doesn't override the existing checks. When no classic rule
+// exists for the branch pattern and rulesets protect the branch, the status checks are applied to a ruleset instead.
 func (bp *BranchProtectionRepository) EnableBranchProtection(ctx context.Context, owner, repoName, branchName string, enforceAdmin bool, enableStatusChecks, disableStatusChecks []string) error {
 	repoName = CleanGithubRepoName(repoName)
 
@@ -196,6 +307,16 @@ func (bp *BranchProtectionRepository) EnableBranchProtection(ctx context.Context
 	currentProtections := queryResult.RepositoryOwner.Repository.BranchProtectionRules.Nodes
 	repoID := queryResult.RepositoryOwner.Repository.ID
 
+	if !hasBranchProtectionRule(currentProtections, branchName) {
+		updated, rulesetErr := bp.enableRulesetStatusChecks(ctx, owner, repoName, branchName, enforceAdmin, enableStatusChecks, disableStatusChecks)
+		if rulesetErr != nil {
+			return rulesetErr
+		}
+		if updated {
+			return nil
+		}
+	}
+
 	createInput, updateInput := prepareBranchProtectionMutation(repoID, currentProtections, &BranchProtectionRule{
 		Pattern:                     branchName,
 		RequiredStatusCheckContexts: enableStatusChecks,
@@ -220,6 +341,16 @@ func (bp *BranchProtectionRepository) EnableBranchProtection(ctx context.Context
 	return nil
 }
 
+// hasBranchProtectionRule checks if a classic branch protection rule exists for the pattern
+func hasBranchProtectionRule(protections []BranchProtectionRule, pattern string) bool {
+	for _, protection := range protections {
+		if protection.Pattern == pattern {
+			return true
+		}
+	}
+	return false
+}
+
 // mergeStatusChecks merges the current checks with the new ones and disable the ones that are specified
 func mergeStatusChecks(currentChecks []string, enableContexts, disableContexts []string) []string {
 
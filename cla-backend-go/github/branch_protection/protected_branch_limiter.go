@@ -28,7 +28,8 @@ type blockingRateLimitRepositories struct {
 }
 
 // NewBlockLimiterRepositories returns a new instance of V3Repositories interface with blocking rate limiting
-// where when the limit is reached the next call blocks till the bucket is ready again
+// where when the limit is reached the next call blocks till the bucket is ready again, the requests also wait for the
+// installation GitHub rate limit reset
 func NewBlockLimiterRepositories(repo CombinedRepository) CombinedRepository {
 	return blockingRateLimitRepositories{
 		CombinedRepository: repo,
@@ -37,36 +38,54 @@ func NewBlockLimiterRepositories(repo CombinedRepository) CombinedRepository {
 
 func (b blockingRateLimitRepositories) ListByOrg(ctx context.Context, org string, opt *githubpkg.RepositoryListByOrgOptions) ([]*githubpkg.Repository, *githubpkg.Response, error) {
 	blockingRateLimit.Take()
-	return b.CombinedRepository.ListByOrg(ctx, org, opt)
+	return b.CombinedRepository.ListByOrg(github.WithRateLimitWait(ctx), org, opt)
 }
 
 func (b blockingRateLimitRepositories) Get(ctx context.Context, owner, repo string) (*githubpkg.Repository, *githubpkg.Response, error) {
 	blockingRateLimit.Take()
-	return b.CombinedRepository.Get(ctx, owner, repo)
+	return b.CombinedRepository.Get(github.WithRateLimitWait(ctx), owner, repo)
 }
 
 func (b blockingRateLimitRepositories) GetRepositoryBranchProtections(ctx context.Context, repositoryOwner, repositoryName string) (*RepoBranchProtectionQueryResult, error) {
 	blockingRateLimit.Take()
-	return b.CombinedRepository.GetRepositoryBranchProtections(ctx, repositoryOwner, repositoryName)
+	return b.CombinedRepository.GetRepositoryBranchProtections(github.WithRateLimitWait(ctx), repositoryOwner, repositoryName)
 }
 func (b blockingRateLimitRepositories) CreateBranchProtection(ctx context.Context, input *githubv4.CreateBranchProtectionRuleInput) (*CreateRepoBranchProtectionMutation, error) {
 	blockingRateLimit.Take()
-	return b.CombinedRepository.CreateBranchProtection(ctx, input)
+	return b.CombinedRepository.CreateBranchProtection(github.WithRateLimitWait(ctx), input)
 }
 func (b blockingRateLimitRepositories) UpdateBranchProtection(ctx context.Context, input *githubv4.UpdateBranchProtectionRuleInput) (*UpdateRepoBranchProtectionMutation, error) {
 	blockingRateLimit.Take()
-	return b.CombinedRepository.UpdateBranchProtection(ctx, input)
+	return b.CombinedRepository.UpdateBranchProtection(github.WithRateLimitWait(ctx), input)
 }
 func (b blockingRateLimitRepositories) GetRepositoryIDFromName(ctx context.Context, repositoryOwner, repositoryName string) (string, error) {
 	blockingRateLimit.Take()
-	return b.CombinedRepository.GetRepositoryIDFromName(ctx, repositoryOwner, repositoryName)
+	return b.CombinedRepository.GetRepositoryIDFromName(github.WithRateLimitWait(ctx), repositoryOwner, repositoryName)
+}
+
+func (b blockingRateLimitRepositories) GetRulesForBranch(ctx context.Context, owner, repoName, branchName string) ([]*BranchRule, error) {
+	blockingRateLimit.Take()
+	return b.CombinedRepository.GetRulesForBranch(github.WithRateLimitWait(ctx), owner, repoName, branchName)
+}
+func (b blockingRateLimitRepositories) GetRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64) (*Ruleset, error) {
+	blockingRateLimit.Take()
+	return b.CombinedRepository.GetRepositoryRuleset(github.WithRateLimitWait(ctx), owner, repoName, rulesetID)
+}
+func (b blockingRateLimitRepositories) UpdateRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64, update *RulesetUpdate) (*Ruleset, error) {
+	blockingRateLimit.Take()
+	return b.CombinedRepository.UpdateRepositoryRuleset(github.WithRateLimitWait(ctx), owner, repoName, rulesetID, update)
+}
+func (b blockingRateLimitRepositories) CreateRepositoryRuleset(ctx context.Context, owner, repoName string, ruleset *Ruleset) (*Ruleset, error) {
+	blockingRateLimit.Take()
+	return b.CombinedRepository.CreateRepositoryRuleset(github.WithRateLimitWait(ctx), owner, repoName, ruleset)
 }
 
 type nonBlockingRateLimitRepositories struct {
 	CombinedRepository
 }
 
-// NewNonBlockLimiterRepositories returns a new instance of V3Repositories interface with non blocking rate limiting
+// NewNonBlockLimiterRepositories returns a new instance of V3Repositories interface with non blocking rate limiting, the
+// requests also fail instead of waiting when the installation GitHub rate limit is spent
 func NewNonBlockLimiterRepositories(repo CombinedRepository) CombinedRepository {
 	return nonBlockingRateLimitRepositories{CombinedRepository: repo}
 }
@@ -112,3 +131,31 @@ func (nb nonBlockingRateLimitRepositories) GetRepositoryIDFromName(ctx context.C
 	}
 	return "", fmt.Errorf("too many requests : %w", github.ErrRateLimited)
 }
+
+func (nb nonBlockingRateLimitRepositories) GetRulesForBranch(ctx context.Context, owner, repoName, branchName string) ([]*BranchRule, error) {
+	if nonBlockingRateLimit.Allow() {
+		return nb.CombinedRepository.GetRulesForBranch(ctx, owner, repoName, branchName)
+	}
+	return nil, fmt.Errorf("too many requests : %w", github.ErrRateLimited)
+}
+
+func (nb nonBlockingRateLimitRepositories) GetRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64) (*Ruleset, error) {
+	if nonBlockingRateLimit.Allow() {
+		return nb.CombinedRepository.GetRepositoryRuleset(ctx, owner, repoName, rulesetID)
+	}
+	return nil, fmt.Errorf("too many requests : %w", github.ErrRateLimited)
+}
+
+func (nb nonBlockingRateLimitRepositories) UpdateRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64, update *RulesetUpdate) (*Ruleset, error) {
+	if nonBlockingRateLimit.Allow() {
+		return nb.CombinedRepository.UpdateRepositoryRuleset(ctx, owner, repoName, rulesetID, update)
+	}
+	return nil, fmt.Errorf("too many requests : %w", github.ErrRateLimited)
+}
+
+func (nb nonBlockingRateLimitRepositories) CreateRepositoryRuleset(ctx context.Context, owner, repoName string, ruleset *Ruleset) (*Ruleset, error) {
+	if nonBlockingRateLimit.Allow() {
+		return nb.CombinedRepository.CreateRepositoryRuleset(ctx, owner, repoName, ruleset)
+	}
+	return nil, fmt.Errorf("too many requests : %w", github.ErrRateLimited)
+}
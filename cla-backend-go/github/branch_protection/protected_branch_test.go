@@ -154,6 +154,10 @@ func TestEnableBranchProtection(t *testing.T) {
 				Return(tc.CurrentProtections, nil)
 
 			if tc.CreateProtectionRequest != nil {
+				m.
+					EXPECT().
+					GetRulesForBranch(gomock.Any(), owner, repo, branchName).
+					Return(nil, nil)
 				m.
 					EXPECT().
 					CreateBranchProtection(gomock.Any(), tc.CreateProtectionRequest).
@@ -0,0 +1,369 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	githubpkg "github.com/google/go-github/v37/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+)
+
+// the mechanisms protecting a branch
+const (
+	// ProtectionMechanismClassic is a classic branch protection rule
+	ProtectionMechanismClassic = "classic"
+	// ProtectionMechanismRepositoryRuleset is a ruleset of the repository
+	ProtectionMechanismRepositoryRuleset = "repository_ruleset"
+	// ProtectionMechanismOrganizationRuleset is a ruleset of the organization applying to the repository
+	ProtectionMechanismOrganizationRuleset = "organization_ruleset"
+)
+
+// the ruleset values used by the GitHub API
+const (
+	RulesetSourceTypeRepository   = "Repository"
+	RulesetSourceTypeOrganization = "Organization"
+
+	RulesetRuleTypeRequiredStatusChecks = "required_status_checks"
+	RulesetRuleTypeDeletion             = "deletion"
+	RulesetRuleTypeNonFastForward       = "non_fast_forward"
+
+	// rulesetAdminRoleActorID is the actor ID of the repository admin role in the bypass list of a ruleset
+	rulesetAdminRoleActorID      = 5
+	rulesetActorTypeRepoRole     = "RepositoryRole"
+	rulesetActorTypeOrgAdmin     = "OrganizationAdmin"
+	rulesetRulesForBranchPerPage = 100
+
+	// EasyCLARulesetName is the name of the repository ruleset created by EasyCLA when the branch is only protected
+	// by organization or enterprise rulesets, which EasyCLA never updates
+	EasyCLARulesetName         = "EasyCLA"
+	rulesetTargetBranch        = "branch"
+	rulesetEnforcementActive   = "active"
+	rulesetRefNameAllBranches  = "~ALL"
+	rulesetRefNameBranchPrefix = "refs/heads/"
+)
+
+// BranchRule is a rule of an active ruleset which applies to a branch
+type BranchRule struct {
+	Type              string          `json:"type"`
+	Parameters        json.RawMessage `json:"parameters,omitempty"`
+	RulesetSourceType string          `json:"ruleset_source_type"`
+	RulesetSource     string          `json:"ruleset_source"`
+	RulesetID         int64           `json:"ruleset_id"`
+}
+
+// Ruleset is a repository or an organization ruleset
+type Ruleset struct {
+	ID           int64                 `json:"id"`
+	Name         string                `json:"name"`
+	Target       string                `json:"target,omitempty"`
+	SourceType   string                `json:"source_type,omitempty"`
+	Source       string                `json:"source,omitempty"`
+	Enforcement  string                `json:"enforcement,omitempty"`
+	BypassActors []*RulesetBypassActor `json:"bypass_actors,omitempty"`
+	Conditions   *RulesetConditions    `json:"conditions,omitempty"`
+	Rules        []*RulesetRule        `json:"rules,omitempty"`
+}
+
+// RulesetConditions are the conditions of the refs a repository ruleset applies to
+type RulesetConditions struct {
+	RefName *RulesetRefNameCondition `json:"ref_name,omitempty"`
+}
+
+// RulesetRefNameCondition lists the ref name patterns a ruleset includes and excludes
+type RulesetRefNameCondition struct {
+	Include []string `json:"include"`
+	Exclude []string `json:"exclude"`
+}
+
+// RulesetBypassActor is an actor allowed to bypass a ruleset
+type RulesetBypassActor struct {
+	ActorID    *int64 `json:"actor_id,omitempty"`
+	ActorType  string `json:"actor_type"`
+	BypassMode string `json:"bypass_mode,omitempty"`
+}
+
+// RulesetRule is a rule of a ruleset, the parameters depend on the rule type
+type RulesetRule struct {
+	Type       string          `json:"type"`
+	Parameters json.RawMessage `json:"parameters,omitempty"`
+}
+
+// RequiredStatusCheck is a status check required by the required_status_checks rule
+type RequiredStatusCheck struct {
+	Context       string `json:"context"`
+	IntegrationID *int64 `json:"integration_id,omitempty"`
+}
+
+// RulesetUpdate is the payload updating a ruleset, the bypass actors are left untouched when nil
+type RulesetUpdate struct {
+	Rules        []*RulesetRule         `json:"rules"`
+	BypassActors *[]*RulesetBypassActor `json:"bypass_actors,omitempty"`
+}
+
+// BranchProtection is the protection of a branch, either by a classic branch protection rule or by the rulesets applying
+// to the branch, for the rulesets the Rule is the combination of all the rules applying to the branch
+type BranchProtection struct {
+	Mechanism string
+	Rule      *BranchProtectionRule
+	Rulesets  []*Ruleset
+}
+
+// RulesetsV3 wraps the v3 github client for the ruleset endpoints which aren't supported by the github library yet
+type RulesetsV3 struct {
+	client *githubpkg.Client
+}
+
+// NewRulesetsV3 creates a new RulesetsV3
+func NewRulesetsV3(client *githubpkg.Client) *RulesetsV3 {
+	return &RulesetsV3{client: client}
+}
+
+// GetRulesForBranch returns the rules of the active rulesets applying to the branch, rulesets of the organization included
+func (r *RulesetsV3) GetRulesForBranch(ctx context.Context, owner, repoName, branchName string) ([]*BranchRule, error) {
+	var rules []*BranchRule
+	for page := 1; page != 0; {
+		u := fmt.Sprintf("repos/%s/%s/rules/branches/%s?per_page=%d&page=%d", owner, repoName, url.PathEscape(branchName), rulesetRulesForBranchPerPage, page)
+		req, err := r.client.NewRequest(http.MethodGet, u, nil)
+		if err != nil {
+			return nil, err
+		}
+		var pageRules []*BranchRule
+		resp, err := r.client.Do(ctx, req, &pageRules)
+		if err != nil {
+			if ok, wErr := github.CheckAndWrapForKnownErrors(resp, err); ok {
+				return nil, wErr
+			}
+			return nil, fmt.Errorf("fetching the rules of owner : %s, repo : %s and branch : %s failed : %w", owner, repoName, branchName, err)
+		}
+		rules = append(rules, pageRules...)
+		page = resp.NextPage
+	}
+	return rules, nil
+}
+
+// GetRepositoryRuleset returns the ruleset applying to the repository, the ruleset may belong to the organization
+func (r *RulesetsV3) GetRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64) (*Ruleset, error) {
+	u := fmt.Sprintf("repos/%s/%s/rulesets/%d?includes_parents=true", owner, repoName, rulesetID)
+	return r.doRuleset(ctx, http.MethodGet, u, nil)
+}
+
+// UpdateRepositoryRuleset updates the ruleset of the repository
+func (r *RulesetsV3) UpdateRepositoryRuleset(ctx context.Context, owner, repoName string, rulesetID int64, update *RulesetUpdate) (*Ruleset, error) {
+	u := fmt.Sprintf("repos/%s/%s/rulesets/%d", owner, repoName, rulesetID)
+	return r.doRuleset(ctx, http.MethodPut, u, update)
+}
+
+// CreateRepositoryRuleset creates a ruleset of the repository
+func (r *RulesetsV3) CreateRepositoryRuleset(ctx context.Context, owner, repoName string, ruleset *Ruleset) (*Ruleset, error) {
+	u := fmt.Sprintf("repos/%s/%s/rulesets", owner, repoName)
+	return r.doRuleset(ctx, http.MethodPost, u, ruleset)
+}
+
+func (r *RulesetsV3) doRuleset(ctx context.Context, method, u string, body interface{}) (*Ruleset, error) {
+	req, err := r.client.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	var ruleset Ruleset
+	resp, err := r.client.Do(ctx, req, &ruleset)
+	if err != nil {
+		if ok, wErr := github.CheckAndWrapForKnownErrors(resp, err); ok {
+			return nil, wErr
+		}
+		return nil, fmt.Errorf("%s ruleset %s failed : %w", method, u, err)
+	}
+	return &ruleset, nil
+}
+
+// requiredStatusCheckContexts returns the status check contexts of a required_status_checks rule parameters
+func requiredStatusCheckContexts(parameters json.RawMessage) ([]string, error) {
+	checks, _, err := decodeRequiredStatusChecks(parameters)
+	if err != nil {
+		return nil, err
+	}
+	contexts := make([]string, 0, len(checks))
+	for _, check := range checks {
+		contexts = append(contexts, check.Context)
+	}
+	return contexts, nil
+}
+
+// decodeRequiredStatusChecks decodes the required_status_checks rule parameters, the other parameters are returned as
+// they are so they're preserved on update
+func decodeRequiredStatusChecks(parameters json.RawMessage) ([]*RequiredStatusCheck, map[string]json.RawMessage, error) {
+	params := map[string]json.RawMessage{}
+	if len(parameters) > 0 {
+		if err := json.Unmarshal(parameters, &params); err != nil {
+			return nil, nil, fmt.Errorf("decoding the required status checks rule parameters failed : %w", err)
+		}
+	}
+	var checks []*RequiredStatusCheck
+	if raw, ok := params["required_status_checks"]; ok {
+		if err := json.Unmarshal(raw, &checks); err != nil {
+			return nil, nil, fmt.Errorf("decoding the required status checks failed : %w", err)
+		}
+	}
+	return checks, params, nil
+}
+
+// mergeRulesetStatusChecks returns the rules with the status checks enabled and disabled in the required_status_checks
+// rule, the rule is added when missing, the integration of the existing checks is preserved
+func mergeRulesetStatusChecks(rules []*RulesetRule, enableContexts, disableContexts []string) ([]*RulesetRule, error) {
+	result := make([]*RulesetRule, 0, len(rules)+1)
+	var statusChecksRule *RulesetRule
+	for _, rule := range rules {
+		if rule.Type == RulesetRuleTypeRequiredStatusChecks && statusChecksRule == nil {
+			statusChecksRule = &RulesetRule{Type: rule.Type, Parameters: rule.Parameters}
+			result = append(result, statusChecksRule)
+			continue
+		}
+		result = append(result, rule)
+	}
+	if statusChecksRule == nil {
+		statusChecksRule = &RulesetRule{Type: RulesetRuleTypeRequiredStatusChecks}
+		result = append(result, statusChecksRule)
+	}
+
+	checks, params, err := decodeRequiredStatusChecks(statusChecksRule.Parameters)
+	if err != nil {
+		return nil, err
+	}
+	currentContexts := make([]string, 0, len(checks))
+	integrations := map[string]*int64{}
+	for _, check := range checks {
+		currentContexts = append(currentContexts, check.Context)
+		integrations[check.Context] = check.IntegrationID
+	}
+
+	mergedChecks := make([]*RequiredStatusCheck, 0)
+	for _, c := range mergeStatusChecks(currentContexts, enableContexts, disableContexts) {
+		mergedChecks = append(mergedChecks, &RequiredStatusCheck{Context: c, IntegrationID: integrations[c]})
+	}
+	params["required_status_checks"], err = json.Marshal(mergedChecks)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := params["strict_required_status_checks_policy"]; !ok {
+		params["strict_required_status_checks_policy"] = json.RawMessage("false")
+	}
+	statusChecksRule.Parameters, err = json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// isAdminBypassActor tells if the actor lets the repository or organization admins bypass the ruleset
+func isAdminBypassActor(actor *RulesetBypassActor) bool {
+	switch actor.ActorType {
+	case rulesetActorTypeOrgAdmin:
+		return true
+	case rulesetActorTypeRepoRole:
+		return actor.ActorID != nil && *actor.ActorID == rulesetAdminRoleActorID
+	default:
+		return false
+	}
+}
+
+// withoutAdminBypassActors returns the bypass actors without the admins and true if any was removed
+func withoutAdminBypassActors(actors []*RulesetBypassActor) ([]*RulesetBypassActor, bool) {
+	result := make([]*RulesetBypassActor, 0, len(actors))
+	for _, actor := range actors {
+		if !isAdminBypassActor(actor) {
+			result = append(result, actor)
+		}
+	}
+	return result, len(result) != len(actors)
+}
+
+// managedRulesetID returns the repository ruleset which EasyCLA updates among the rulesets applying to the branch,
+// the rulesets which already require status checks are preferred. The organization and enterprise rulesets are never
+// returned, they're owned by the organization and EasyCLA only reports their drift.
+func managedRulesetID(rules []*BranchRule) (int64, bool) {
+	var best *BranchRule
+	for _, rule := range rules {
+		if rule.RulesetSourceType != RulesetSourceTypeRepository {
+			continue
+		}
+		if best == nil || (best.Type != RulesetRuleTypeRequiredStatusChecks && rule.Type == RulesetRuleTypeRequiredStatusChecks) {
+			best = rule
+		}
+	}
+	if best == nil {
+		return 0, false
+	}
+	return best.RulesetID, true
+}
+
+// newEasyCLARuleset returns the repository ruleset requiring the status checks on the branch, a branch pattern applies
+// the ruleset to all the branches like the classic branch protection rule would
+func newEasyCLARuleset(branchName string, enforceAdmin bool, enableStatusChecks []string) (*Ruleset, error) {
+	rules, err := mergeRulesetStatusChecks([]*RulesetRule{
+		{Type: RulesetRuleTypeDeletion},
+		{Type: RulesetRuleTypeNonFastForward},
+	}, enableStatusChecks, nil)
+	if err != nil {
+		return nil, err
+	}
+	include := rulesetRefNameBranchPrefix + branchName
+	if strings.Contains(branchName, "*") {
+		include = rulesetRefNameAllBranches
+	}
+	ruleset := &Ruleset{
+		Name:        EasyCLARulesetName,
+		Target:      rulesetTargetBranch,
+		Enforcement: rulesetEnforcementActive,
+		Conditions: &RulesetConditions{
+			RefName: &RulesetRefNameCondition{Include: []string{include}, Exclude: []string{}},
+		},
+		Rules:        rules,
+		BypassActors: []*RulesetBypassActor{},
+	}
+	if !enforceAdmin {
+		adminRole := int64(rulesetAdminRoleActorID)
+		ruleset.BypassActors = append(ruleset.BypassActors, &RulesetBypassActor{ActorID: &adminRole, ActorType: rulesetActorTypeRepoRole, BypassMode: "always"})
+	}
+	return ruleset, nil
+}
+
+// rulesetBranchProtectionRule combines the rules applying to the branch into a BranchProtectionRule so the protection
+// can be compared with the classic protection rules
+func rulesetBranchProtectionRule(branchName string, rules []*BranchRule, rulesets []*Ruleset) (*BranchProtectionRule, error) {
+	rule := &BranchProtectionRule{
+		Pattern:                     branchName,
+		RequiredStatusCheckContexts: []string{},
+		IsAdminEnforced:             true,
+		AllowsDeletions:             true,
+		AllowsForcePushes:           true,
+	}
+	for _, branchRule := range rules {
+		switch branchRule.Type {
+		case RulesetRuleTypeRequiredStatusChecks:
+			contexts, err := requiredStatusCheckContexts(branchRule.Parameters)
+			if err != nil {
+				return nil, err
+			}
+			rule.RequiresStatusChecks = true
+			rule.RequiredStatusCheckContexts = mergeStatusChecks(rule.RequiredStatusCheckContexts, contexts, nil)
+		case RulesetRuleTypeDeletion:
+			rule.AllowsDeletions = false
+		case RulesetRuleTypeNonFastForward:
+			rule.AllowsForcePushes = false
+		}
+	}
+	for _, ruleset := range rulesets {
+		for _, actor := range ruleset.BypassActors {
+			if isAdminBypassActor(actor) {
+				rule.IsAdminEnforced = false
+			}
+		}
+	}
+	return rule, nil
+}
@@ -0,0 +1,249 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/bmizerany/assert"
+	"github.com/golang/mock/gomock"
+	githubpkg "github.com/google/go-github/v37/github"
+)
+
+func statusChecksRule(t *testing.T, contexts ...string) *RulesetRule {
+	checks := make([]*RequiredStatusCheck, 0, len(contexts))
+	for _, c := range contexts {
+		checks = append(checks, &RequiredStatusCheck{Context: c})
+	}
+	params, err := json.Marshal(map[string]interface{}{
+		"required_status_checks":               checks,
+		"strict_required_status_checks_policy": true,
+	})
+	if err != nil {
+		t.Fatalf("marshalling the rule parameters failed : %v", err)
+	}
+	return &RulesetRule{Type: RulesetRuleTypeRequiredStatusChecks, Parameters: params}
+}
+
+func ruleContexts(t *testing.T, rules []*RulesetRule) []string {
+	for _, rule := range rules {
+		if rule.Type == RulesetRuleTypeRequiredStatusChecks {
+			contexts, err := requiredStatusCheckContexts(rule.Parameters)
+			if err != nil {
+				t.Fatalf("decoding the rule parameters failed : %v", err)
+			}
+			return contexts
+		}
+	}
+	return nil
+}
+
+func TestMergeRulesetStatusChecks(t *testing.T) {
+	t.Run("add rule", func(tt *testing.T) {
+		rules, err := mergeRulesetStatusChecks([]*RulesetRule{{Type: RulesetRuleTypeDeletion}}, []string{"EasyCLA"}, nil)
+		if err != nil {
+			tt.Fatalf("no error expected : %v", err)
+		}
+		assert.Equal(tt, 2, len(rules))
+		assert.Equal(tt, RulesetRuleTypeDeletion, rules[0].Type)
+		assert.Equal(tt, []string{"EasyCLA"}, ruleContexts(tt, rules))
+	})
+
+	t.Run("preserve existing checks and parameters", func(tt *testing.T) {
+		integrationID := int64(15368)
+		current := statusChecksRule(tt, "circle/ci", "DCO")
+		checks, params, err := decodeRequiredStatusChecks(current.Parameters)
+		if err != nil {
+			tt.Fatalf("no error expected : %v", err)
+		}
+		checks[0].IntegrationID = &integrationID
+		params["required_status_checks"], _ = json.Marshal(checks)
+		current.Parameters, _ = json.Marshal(params)
+
+		rules, err := mergeRulesetStatusChecks([]*RulesetRule{current}, []string{"EasyCLA"}, []string{"DCO"})
+		if err != nil {
+			tt.Fatalf("no error expected : %v", err)
+		}
+		assert.Equal(tt, []string{"circle/ci", "EasyCLA"}, ruleContexts(tt, rules))
+
+		checks, params, err = decodeRequiredStatusChecks(rules[0].Parameters)
+		if err != nil {
+			tt.Fatalf("no error expected : %v", err)
+		}
+		assert.Equal(tt, integrationID, *checks[0].IntegrationID)
+		assert.Equal(tt, "true", string(params["strict_required_status_checks_policy"]))
+		// the current rule is left untouched
+		assert.Equal(tt, []string{"circle/ci", "DCO"}, ruleContexts(tt, []*RulesetRule{current}))
+	})
+}
+
+func TestManagedRulesetID(t *testing.T) {
+	rules := []*BranchRule{
+		{Type: RulesetRuleTypeDeletion, RulesetSourceType: RulesetSourceTypeRepository, RulesetID: 1},
+		{Type: RulesetRuleTypeRequiredStatusChecks, RulesetSourceType: RulesetSourceTypeOrganization, RulesetID: 2},
+		{Type: RulesetRuleTypeRequiredStatusChecks, RulesetSourceType: "Enterprise", RulesetID: 3},
+		{Type: RulesetRuleTypeRequiredStatusChecks, RulesetSourceType: RulesetSourceTypeRepository, RulesetID: 4},
+	}
+	id, ok := managedRulesetID(rules)
+	assert.Equal(t, true, ok)
+	assert.Equal(t, int64(4), id)
+
+	id, ok = managedRulesetID(rules[:3])
+	assert.Equal(t, true, ok)
+	assert.Equal(t, int64(1), id)
+
+	// the organization and enterprise rulesets are never managed
+	_, ok = managedRulesetID(rules[1:3])
+	assert.Equal(t, false, ok)
+}
+
+func TestEnableBranchProtectionRuleset(t *testing.T) {
+	owner := "johnruleset"
+	repo := "johnsreporuleset"
+	adminRole := int64(rulesetAdminRoleActorID)
+	teamID := int64(42)
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockCombinedRepository(ctrl)
+	m.
+		EXPECT().
+		GetRepositoryBranchProtections(gomock.Any(), owner, repo).
+		Return(&RepoBranchProtectionQueryResult{}, nil)
+	m.
+		EXPECT().
+		Get(gomock.Any(), owner, repo).
+		Return(&githubpkg.Repository{DefaultBranch: githubpkg.String("trunk")}, nil, nil)
+	m.
+		EXPECT().
+		GetRulesForBranch(gomock.Any(), owner, repo, "trunk").
+		Return([]*BranchRule{
+			{Type: RulesetRuleTypeNonFastForward, RulesetSourceType: RulesetSourceTypeRepository, RulesetID: 7},
+		}, nil)
+	m.
+		EXPECT().
+		GetRepositoryRuleset(gomock.Any(), owner, repo, int64(7)).
+		Return(&Ruleset{
+			ID:         7,
+			Name:       "main protection",
+			SourceType: RulesetSourceTypeRepository,
+			BypassActors: []*RulesetBypassActor{
+				{ActorID: &adminRole, ActorType: rulesetActorTypeRepoRole},
+				{ActorID: &teamID, ActorType: "Team"},
+			},
+			Rules: []*RulesetRule{{Type: RulesetRuleTypeNonFastForward}},
+		}, nil)
+	m.
+		EXPECT().
+		UpdateRepositoryRuleset(gomock.Any(), owner, repo, int64(7), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, owner, repoName string, rulesetID int64, update *RulesetUpdate) (*Ruleset, error) {
+			assert.Equal(t, 2, len(update.Rules))
+			assert.Equal(t, []string{"EasyCLA"}, ruleContexts(t, update.Rules))
+			assert.Equal(t, 1, len(*update.BypassActors))
+			assert.Equal(t, "Team", (*update.BypassActors)[0].ActorType)
+			return &Ruleset{ID: rulesetID}, nil
+		})
+
+	branchProtectionRepo := newBranchProtectionRepository(m)
+	err := branchProtectionRepo.EnableBranchProtection(context.Background(), owner, repo, "**/**", true, []string{"EasyCLA"}, nil)
+	if err != nil {
+		t.Errorf("enable branch protection failed : %v", err)
+	}
+}
+
+func TestGetBranchProtectionOrganizationRuleset(t *testing.T) {
+	owner := "johnorgruleset"
+	repo := "johnsrepoorgruleset"
+	branchName := DefaultBranchName
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := NewMockCombinedRepository(ctrl)
+	m.
+		EXPECT().
+		GetRepositoryBranchProtections(gomock.Any(), owner, repo).
+		Return(&RepoBranchProtectionQueryResult{}, nil)
+	m.
+		EXPECT().
+		GetRulesForBranch(gomock.Any(), owner, repo, branchName).
+		Return([]*BranchRule{
+			{Type: RulesetRuleTypeRequiredStatusChecks, Parameters: statusChecksRule(t, "EasyCLA").Parameters, RulesetSourceType: RulesetSourceTypeOrganization, RulesetSource: owner, RulesetID: 9},
+			{Type: RulesetRuleTypeDeletion, RulesetSourceType: RulesetSourceTypeOrganization, RulesetSource: owner, RulesetID: 9},
+		}, nil)
+	m.
+		EXPECT().
+		GetRepositoryRuleset(gomock.Any(), owner, repo, int64(9)).
+		Return(&Ruleset{ID: 9, Name: "org protection", SourceType: RulesetSourceTypeOrganization, Source: owner}, nil)
+
+	branchProtectionRepo := newBranchProtectionRepository(m)
+	protection, err := branchProtectionRepo.GetBranchProtection(context.Background(), owner, repo, branchName)
+	if err != nil {
+		t.Fatalf("no error expected : %v", err)
+	}
+	assert.Equal(t, ProtectionMechanismOrganizationRuleset, protection.Mechanism)
+	assert.Equal(t, 1, len(protection.Rulesets))
+	assert.Equal(t, []string{"EasyCLA"}, protection.Rule.RequiredStatusCheckContexts)
+	assert.Equal(t, true, protection.Rule.RequiresStatusChecks)
+	assert.Equal(t, true, protection.Rule.IsAdminEnforced)
+	assert.Equal(t, false, protection.Rule.AllowsDeletions)
+	assert.Equal(t, true, protection.Rule.AllowsForcePushes)
+}
+
+func TestEnableBranchProtectionOrganizationRuleset(t *testing.T) {
+	owner := "johnorgruleset"
+	repo := "johnsrepoorgruleset"
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// the organization ruleset is left untouched, UpdateRepositoryRuleset isn't expected either
+	m := NewMockCombinedRepository(ctrl)
+	m.
+		EXPECT().
+		GetRepositoryBranchProtections(gomock.Any(), owner, repo).
+		Return(&RepoBranchProtectionQueryResult{}, nil)
+	m.
+		EXPECT().
+		Get(gomock.Any(), owner, repo).
+		Return(&githubpkg.Repository{DefaultBranch: githubpkg.String("main")}, nil, nil)
+	m.
+		EXPECT().
+		GetRulesForBranch(gomock.Any(), owner, repo, "main").
+		Return([]*BranchRule{
+			{Type: RulesetRuleTypeRequiredStatusChecks, Parameters: statusChecksRule(t, "ci").Parameters, RulesetSourceType: RulesetSourceTypeOrganization, RulesetSource: owner, RulesetID: 9},
+		}, nil)
+	m.
+		EXPECT().
+		CreateRepositoryRuleset(gomock.Any(), owner, repo, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, owner, repoName string, ruleset *Ruleset) (*Ruleset, error) {
+			assert.Equal(t, EasyCLARulesetName, ruleset.Name)
+			assert.Equal(t, "branch", ruleset.Target)
+			assert.Equal(t, "active", ruleset.Enforcement)
+			assert.Equal(t, []string{"~ALL"}, ruleset.Conditions.RefName.Include)
+			assert.Equal(t, 0, len(ruleset.BypassActors))
+			assert.Equal(t, []string{"EasyCLA"}, ruleContexts(t, ruleset.Rules))
+			assert.Equal(t, 3, len(ruleset.Rules))
+			return &Ruleset{ID: 10, Name: ruleset.Name}, nil
+		})
+
+	branchProtectionRepo := newBranchProtectionRepository(m)
+	err := branchProtectionRepo.EnableBranchProtection(context.Background(), owner, repo, "**/**", true, []string{"EasyCLA"}, nil)
+	if err != nil {
+		t.Errorf("enable branch protection failed : %v", err)
+	}
+}
+
+func TestNewEasyCLARuleset(t *testing.T) {
+	ruleset, err := newEasyCLARuleset("main", false, []string{"EasyCLA"})
+	if err != nil {
+		t.Fatalf("no error expected : %v", err)
+	}
+	assert.Equal(t, []string{"refs/heads/main"}, ruleset.Conditions.RefName.Include)
+	assert.Equal(t, 1, len(ruleset.BypassActors))
+	assert.Equal(t, true, isAdminBypassActor(ruleset.BypassActors[0]))
+}
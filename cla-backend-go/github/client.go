@@ -78,21 +78,31 @@ func NewGithubAppClient(installationID int64) (*github.Client, error) {
 	if err != nil {
 		return nil, err
 	}
-	return github.NewClient(&http.Client{Transport: itr}), nil
+	return github.NewClient(&http.Client{Transport: NewRateLimitTransport(installationID, itr)}), nil
 }
 
 // NewGithubV4AppClient creates a new github v4 client from the supplied installationID
 func NewGithubV4AppClient(installationID int64) (*githubv4.Client, error) {
-	authTransport, err := ghinstallation.New(http.DefaultTransport, int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
+	// the timeout applies to each GitHub response, not to the whole request which may wait for the rate limit reset
+	baseTransport := http.DefaultTransport.(*http.Transport).Clone()
+	baseTransport.ResponseHeaderTimeout = 5 * time.Second
+	authTransport, err := ghinstallation.New(baseTransport, int64(getGithubAppID()), installationID, []byte(getGithubAppPrivateKey()))
 	if err != nil {
 		return nil, err
 	}
-	return githubv4.NewClient(&http.Client{Transport: authTransport, Timeout: 5 * time.Second}), nil
+	return githubv4.NewClient(&http.Client{Transport: NewRateLimitTransport(installationID, authTransport)}), nil
 }
 
-// NewGithubOauthClient creates github client from global accessToken
+// NewGithubOauthClient creates github client from global accessToken, the requests of all the global accessToken clients
+// share the same rate limiter
 func NewGithubOauthClient() *github.Client {
-	return NewGithubOauthClientWithAccessToken(getSecretAccessToken())
+	ctx := context.TODO()
+	ts := oauth2.StaticTokenSource(
+		&oauth2.Token{AccessToken: getSecretAccessToken()},
+	)
+	tc := oauth2.NewClient(ctx, ts)
+	tc.Transport = NewRateLimitTransport(oauthRateLimiterID, tc.Transport)
+	return github.NewClient(tc)
 }
 
 // NewGithubOauthClientWithAccessToken creates github client from specified accessToken
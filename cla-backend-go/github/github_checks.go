@@ -0,0 +1,157 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v37/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// claCheckRunName is the name of the check run - the same as the commit status context so that a single required
+	// check covers both the pull requests and the merge groups
+	claCheckRunName         = "EasyCLA"
+	checkRunStatusCompleted = "completed"
+)
+
+// CreateCLACheckRun reports the CLA status of the commit authors as a completed check run on the head commit, with a
+// summary listing the signed, missing and exempt bot authors. It is used for the merge groups of the merge queues, which only
+// accept the checks reported on the merge group head commit.
+//
+// GitHub API docs: https://docs.github.com/en/rest/checks/runs#create-a-check-run
+func CreateCLACheckRun(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID int64, headSHA string, signed, missing, exempt []*UserCommitSummary, CLABaseAPIURL, CLALandingPage string) error {
+	f := logrus.Fields{
+		"functionName":   "github.github_checks.CreateCLACheckRun",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+		"SHA":            headSHA,
+		"pullRequestID":  pullRequestID,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil || client == nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return err
+	}
+
+	allSigned := len(requiredMissing(missing)) == 0 && (len(signed) > 0 || len(exempt) > 0)
+	conclusion := failureState
+	detailsURL := getFullSignURL("github", strconv.FormatInt(installationID, 10), strconv.FormatInt(repoID, 10), strconv.Itoa(pullRequestID), CLABaseAPIURL)
+	if allSigned {
+		conclusion = successState
+		detailsURL = fmt.Sprintf("%s/#/?version=2", CLALandingPage)
+	}
+	_, title := assembleCLAStatus(claCheckRunName, allSigned)
+
+	log.WithFields(f).Debugf("creating CLA check run %s - %d passed, %d missing, %d exempt", conclusion, len(signed), len(missing), len(exempt))
+	_, _, err = client.Checks.CreateCheckRun(ctx, owner, repo, github.CreateCheckRunOptions{
+		Name:        claCheckRunName,
+		HeadSHA:     headSHA,
+		DetailsURL:  &detailsURL,
+		Status:      github.String(checkRunStatusCompleted),
+		Conclusion:  &conclusion,
+		CompletedAt: &github.Timestamp{Time: time.Now()},
+		Output: &github.CheckRunOutput{
+			Title:   &title,
+			Summary: github.String(assembleCLACheckRunSummary(signed, missing, exempt, detailsURL)),
+			Text:    github.String(assembleCLACheckRunText(signed, missing, exempt)),
+		},
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the CLA check run")
+		return err
+	}
+
+	return nil
+}
+
+// assembleCLACheckRunSummary returns the markdown summary of the check run
+func assembleCLACheckRunSummary(signed, missing, exempt []*UserCommitSummary, detailsURL string) string {
+	missing = requiredMissing(missing)
+	if len(missing) == 0 && len(signed) > 0 && len(exempt) > 0 {
+		return fmt.Sprintf("All %d commit author(s) are authorized under a signed CLA and %d bot author(s) are exempt from the CLA check.", len(signed), len(exempt))
+	}
+	if len(missing) == 0 && len(signed) > 0 {
+		return fmt.Sprintf("All %d commit author(s) are authorized under a signed CLA.", len(signed))
+	}
+	if len(missing) == 0 && len(exempt) > 0 {
+		return fmt.Sprintf("All %d commit author(s) are bots exempt from the CLA check.", len(exempt))
+	}
+	if len(missing) == 0 {
+		return "No commit authors were found - unable to check the CLA authorization."
+	}
+	return fmt.Sprintf("%d commit author(s) are authorized under a signed CLA and %d are missing a CLA authorization. [Please click here to be authorized](%s).",
+		len(signed), len(missing), detailsURL)
+}
+
+// assembleCLACheckRunText returns the markdown lists of the signed, missing and exempt commit authors with their commits
+func assembleCLACheckRunText(signed, missing, exempt []*UserCommitSummary) string {
+	var reported []*UserCommitSummary
+	for _, summary := range missing {
+		if summary.Optional {
+			reported = append(reported, summary)
+		}
+	}
+	missing = requiredMissing(missing)
+
+	var sb strings.Builder
+	if len(signed) > 0 {
+		sb.WriteString("### Signed\n")
+		writeCheckRunAuthors(&sb, ":white_check_mark:", signed)
+	}
+	if len(missing) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("### Missing CLA Authorization\n")
+		writeCheckRunAuthors(&sb, ":x:", missing)
+	}
+	if len(reported) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("### Reported Co-Authors\n")
+		writeCheckRunAuthors(&sb, ":warning:", reported)
+	}
+	if len(exempt) > 0 {
+		if sb.Len() > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString("### Exempt Bots\n")
+		writeCheckRunAuthors(&sb, ":robot:", exempt)
+	}
+	return sb.String()
+}
+
+// writeCheckRunAuthors writes one list item per author, sorted by author, with the author commits
+func writeCheckRunAuthors(sb *strings.Builder, icon string, summaries []*UserCommitSummary) {
+	committers := getAuthorInfoCommits(summaries, false)
+	authors := make([]string, 0, len(committers))
+	for author := range committers {
+		authors = append(authors, author)
+	}
+	sort.Strings(authors)
+
+	for _, author := range authors {
+		var shas []string
+		for _, summary := range committers[author] {
+			shas = append(shas, summary.SHA)
+		}
+		if author == "" || author == unknown {
+			author = "Unknown author - the commit is not linked to a GitHub user"
+		}
+		sb.WriteString(fmt.Sprintf("- %s %s (%s)\n", icon, strings.TrimSuffix(strings.TrimSpace(author), " /"), strings.Join(shas, ", ")))
+	}
+}
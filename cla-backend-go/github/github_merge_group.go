@@ -0,0 +1,70 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/google/go-github/v37/github"
+)
+
+const (
+	// MergeGroupEventType is the X-GitHub-Event value of the merge queue events
+	MergeGroupEventType = "merge_group"
+	// MergeGroupChecksRequested is the merge group action requesting the checks of the merge group head commit
+	MergeGroupChecksRequested = "checks_requested"
+)
+
+// mergeGroupHeadRefRegex matches the merge queue branches: refs/heads/gh-readonly-queue/{base}/pr-{number}-{sha}
+var mergeGroupHeadRefRegex = regexp.MustCompile(`gh-readonly-queue/.+/pr-(\d+)-[0-9a-fA-F]+$`)
+
+// MergeGroupEvent is sent when a merge queue requests the checks of a merge group. The go-github release we use
+// does not parse merge_group events, so the payload is decoded into this model.
+//
+// GitHub API docs: https://docs.github.com/en/webhooks/webhook-events-and-payloads#merge_group
+type MergeGroupEvent struct {
+	Action       *string              `json:"action,omitempty"`
+	MergeGroup   *MergeGroup          `json:"merge_group,omitempty"`
+	Repo         *github.Repository   `json:"repository,omitempty"`
+	Org          *github.Organization `json:"organization,omitempty"`
+	Installation *github.Installation `json:"installation,omitempty"`
+	Sender       *github.User         `json:"sender,omitempty"`
+}
+
+// MergeGroup is the group of pull requests merged together by a merge queue
+type MergeGroup struct {
+	HeadSHA *string `json:"head_sha,omitempty"`
+	HeadRef *string `json:"head_ref,omitempty"`
+	BaseSHA *string `json:"base_sha,omitempty"`
+	BaseRef *string `json:"base_ref,omitempty"`
+}
+
+// ParseMergeGroupEvent decodes the merge_group event payload
+func ParseMergeGroupEvent(payload []byte) (*MergeGroupEvent, error) {
+	event := &MergeGroupEvent{}
+	if err := json.Unmarshal(payload, event); err != nil {
+		return nil, err
+	}
+	if event.MergeGroup == nil || event.MergeGroup.HeadSHA == nil || event.Repo == nil || event.Installation == nil {
+		return nil, fmt.Errorf("incomplete merge_group event payload")
+	}
+	return event, nil
+}
+
+// GetPullRequestNumber returns the number of the pull request added to the merge queue, parsed from the head ref of
+// the merge group. The pull requests ahead of it in the queue are checked by their own merge groups.
+func (m *MergeGroup) GetPullRequestNumber() (int, error) {
+	headRef := ""
+	if m.HeadRef != nil {
+		headRef = *m.HeadRef
+	}
+	matches := mergeGroupHeadRefRegex.FindStringSubmatch(headRef)
+	if len(matches) != 2 {
+		return 0, fmt.Errorf("unable to parse the pull request number from the merge group head ref: %s", headRef)
+	}
+	return strconv.Atoi(matches[1])
+}
@@ -0,0 +1,83 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMergeGroupEvent(t *testing.T) {
+	payload := []byte(`{
+		"action": "checks_requested",
+		"merge_group": {
+			"head_sha": "ec26c3e57ca3a959ca5aad62de7213c562f8c821",
+			"head_ref": "refs/heads/gh-readonly-queue/main/pr-104-d7b8a76ad2d2ee64ac30f6553e0cf0c6e3a6c0d2",
+			"base_sha": "380387fd5a2ebd1a00c3a1e1d8e8a3c1a2d5e5f9",
+			"base_ref": "refs/heads/main"
+		},
+		"repository": {"id": 1296269, "name": "hello-world", "full_name": "octocat/hello-world", "owner": {"login": "octocat"}},
+		"installation": {"id": 2311213}
+	}`)
+
+	event, err := ParseMergeGroupEvent(payload)
+	assert.Nil(t, err)
+	assert.Equal(t, MergeGroupChecksRequested, github.StringValue(event.Action))
+	assert.Equal(t, "ec26c3e57ca3a959ca5aad62de7213c562f8c821", github.StringValue(event.MergeGroup.HeadSHA))
+	assert.Equal(t, int64(1296269), event.Repo.GetID())
+	assert.Equal(t, "octocat", event.Repo.GetOwner().GetLogin())
+	assert.Equal(t, int64(2311213), event.Installation.GetID())
+
+	pullRequestID, err := event.MergeGroup.GetPullRequestNumber()
+	assert.Nil(t, err)
+	assert.Equal(t, 104, pullRequestID)
+
+	_, err = ParseMergeGroupEvent([]byte(`{"action": "checks_requested"}`))
+	assert.NotNil(t, err)
+}
+
+func TestMergeGroupGetPullRequestNumber(t *testing.T) {
+	testCases := []struct {
+		headRef string
+		number  int
+		valid   bool
+	}{
+		{headRef: "refs/heads/gh-readonly-queue/main/pr-1-abc123", number: 1, valid: true},
+		{headRef: "refs/heads/gh-readonly-queue/release/v1.2/pr-2048-0123456789abcdef", number: 2048, valid: true},
+		{headRef: "refs/heads/main", valid: false},
+		{headRef: "", valid: false},
+	}
+
+	for _, tc := range testCases {
+		number, err := (&MergeGroup{HeadRef: github.String(tc.headRef)}).GetPullRequestNumber()
+		if tc.valid {
+			assert.Nil(t, err, tc.headRef)
+			assert.Equal(t, tc.number, number, tc.headRef)
+		} else {
+			assert.NotNil(t, err, tc.headRef)
+		}
+	}
+}
+
+func TestAssembleCLACheckRunText(t *testing.T) {
+	signed := []*UserCommitSummary{
+		{SHA: "sha1", CommitAuthor: &github.User{ID: github.Int64(1), Login: github.String("alice")}, Authorized: true},
+	}
+	missing := []*UserCommitSummary{
+		{SHA: "sha2", CommitAuthor: &github.User{ID: github.Int64(2), Login: github.String("bob")}},
+		{SHA: "sha3"},
+	}
+
+	text := assembleCLACheckRunText(signed, missing, nil)
+	assert.True(t, strings.Index(text, "### Signed") < strings.Index(text, "### Missing CLA Authorization"))
+	assert.Contains(t, text, "- :white_check_mark: login: alice (sha1)")
+	assert.Contains(t, text, "- :x: login: bob (sha2)")
+	assert.Contains(t, text, "Unknown author - the commit is not linked to a GitHub user (sha3)")
+
+	assert.Contains(t, assembleCLACheckRunSummary(signed, nil, nil, "https://example.org"), "All 1 commit author(s)")
+	assert.Contains(t, assembleCLACheckRunSummary(signed, missing, nil, "https://example.org/sign"), "(https://example.org/sign)")
+}
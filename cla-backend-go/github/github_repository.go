@@ -17,6 +17,7 @@ import (
 
 	"github.com/google/go-github/v37/github"
 	"github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/shurcooL/githubv4"
 )
 
 var (
@@ -76,12 +77,74 @@ func GetPullRequest(ctx context.Context, pullRequestID int, owner, repo string,
 	return pullRequest, nil
 }
 
+// GetOpenPullRequests returns the open pull requests of the specified repository
+func GetOpenPullRequests(ctx context.Context, installationID int64, owner, repo string) ([]*github.PullRequest, error) {
+	f := logrus.Fields{
+		"functionName":   "github.github_repository.GetOpenPullRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"owner":          owner,
+		"repo":           repo,
+	}
+
+	client, err := NewGithubAppClient(installationID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create Github client")
+		return nil, err
+	}
+
+	var pullRequests []*github.PullRequest
+	var nextPage = 1
+	for {
+		pullRequestList, resp, listErr := client.PullRequests.List(ctx, owner, repo, &github.PullRequestListOptions{
+			State: "open",
+			ListOptions: github.ListOptions{
+				Page:    nextPage,
+				PerPage: 100,
+			},
+		})
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warnf("problem listing open pull requests for repo: %s/%s", owner, repo)
+			return nil, listErr
+		}
+		if resp.StatusCode != http.StatusOK {
+			msg := fmt.Sprintf("unexpected status code: %d - expected: %d", resp.StatusCode, http.StatusOK)
+			log.WithFields(f).Warn(msg)
+			return nil, errors.New(msg)
+		}
+
+		pullRequests = append(pullRequests, pullRequestList...)
+		if resp.NextPage == 0 {
+			break
+		}
+		nextPage = resp.NextPage
+	}
+
+	log.WithFields(f).Debugf("found %d open pull requests for repo: %s/%s", len(pullRequests), owner, repo)
+	return pullRequests, nil
+}
+
 // UserCommitSummary data model
 type UserCommitSummary struct {
 	SHA          string
 	CommitAuthor *github.User
-	Affiliated   bool
-	Authorized   bool
+	// CoAuthor is set instead of the CommitAuthor for the co-authors declared by a Co-authored-by trailer
+	CoAuthor   *utils.CoAuthor
+	Affiliated bool
+	Authorized bool
+	// Optional is set for the co-authors which don't fail the check when not authorized
+	Optional bool
+	// GitEmail is the author email of the git commit, matched against the bot allowlist when GitEmailVerified is set
+	GitEmail string
+	// GitEmailVerified is set when GitHub verified the commit signature and the author email is the committer email
+	GitEmailVerified bool
+	// ExemptBy is the bot allowlist entry matching the author exempt from the CLA check
+	ExemptBy string
+}
+
+// IsCoAuthor returns true if the summary is a co-author declared by a Co-authored-by trailer
+func (u UserCommitSummary) IsCoAuthor() bool {
+	return u.CoAuthor != nil
 }
 
 // GetCommitAuthorID commit author username ID (numeric value as a string) if available, otherwise returns empty string
@@ -109,6 +172,9 @@ func (u UserCommitSummary) GetCommitAuthorUsername() string {
 
 // GetCommitAuthorEmail returns commit author email if available, otherwise returns empty string
 func (u UserCommitSummary) GetCommitAuthorEmail() string {
+	if u.CoAuthor != nil {
+		return u.CoAuthor.Email
+	}
 	if u.CommitAuthor != nil && u.CommitAuthor.Email != nil {
 		return *u.CommitAuthor.Email
 	}
@@ -118,6 +184,9 @@ func (u UserCommitSummary) GetCommitAuthorEmail() string {
 
 // IsValid returns true if the commit author information is available
 func (u UserCommitSummary) IsValid() bool {
+	if u.CoAuthor != nil {
+		return u.CoAuthor.Email != ""
+	}
 	valid := false
 	if u.CommitAuthor != nil {
 		valid = u.CommitAuthor.ID != nil && (u.CommitAuthor.Login != nil || u.CommitAuthor.Name != nil)
@@ -157,6 +226,13 @@ func (u UserCommitSummary) getUserInfo(tagUser bool) string {
 	if tagUser {
 		tagValue = "@"
 	}
+	if u.CoAuthor != nil {
+		if u.CoAuthor.Name != "" {
+			sb.WriteString(fmt.Sprintf("co-author: %s <%s> / ", u.CoAuthor.Name, u.CoAuthor.Email))
+		} else {
+			sb.WriteString(fmt.Sprintf("co-author: %s / ", u.CoAuthor.Email))
+		}
+	}
 	if u.CommitAuthor != nil {
 		if *u.CommitAuthor.Login != "" {
 			sb.WriteString(fmt.Sprintf("login: %s%s / ", tagValue, *u.CommitAuthor.Login))
@@ -166,6 +242,10 @@ func (u UserCommitSummary) getUserInfo(tagUser bool) string {
 			sb.WriteString(fmt.Sprintf("%sname: %s / ", userInfo, utils.StringValue(u.CommitAuthor.Name)))
 		}
 	}
+	if u.CommitAuthor == nil && u.CoAuthor == nil && u.ExemptBy != "" && u.GitEmail != "" {
+		// bots exempt by their commit email are not always linked to a GitHub user
+		sb.WriteString(fmt.Sprintf("email: %s / ", u.GitEmail))
+	}
 
 	return strings.Replace(sb.String(), "/ $", "", -1)
 }
@@ -183,16 +263,14 @@ func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pull
 		return nil, nil, err
 	}
 
-	commits, resp, comErr := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, &github.ListOptions{})
+	newV4Client := func() (*githubv4.Client, error) {
+		return NewGithubV4AppClient(installationID)
+	}
+	commits, comErr := listPullRequestCommits(ctx, client, newV4Client, owner, repo, pullRequestID)
 	if comErr != nil {
 		log.WithFields(f).WithError(comErr).Warnf("problem listing commits for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
 		return nil, nil, comErr
 	}
-	if resp.StatusCode != http.StatusOK {
-		msg := fmt.Sprintf("unexpected status code: %d - expected: %d", resp.StatusCode, http.StatusOK)
-		log.WithFields(f).Warn(msg)
-		return nil, nil, errors.New(msg)
-	}
 
 	log.WithFields(f).Debugf("found %d commits for pull request: %d", len(commits), pullRequestID)
 	for _, commit := range commits {
@@ -211,15 +289,55 @@ func GetPullRequestCommitAuthors(ctx context.Context, installationID int64, pull
 			CommitAuthor: commit.Author,
 			Affiliated:   false,
 			Authorized:   false,
+			GitEmail:     commit.GetCommit().GetAuthor().GetEmail(),
+			GitEmailVerified: commit.GetCommit().GetVerification().GetVerified() &&
+				strings.EqualFold(commit.GetCommit().GetAuthor().GetEmail(), commit.GetCommit().GetCommitter().GetEmail()),
 		})
+		userCommitSummary = append(userCommitSummary, getCommitCoAuthors(commit)...)
 	}
 
-	// get latest commit SHA
+	// get latest commit SHA - the commits are listed oldest first and listPullRequestCommits never returns an empty list
 	latestCommitSHA := commits[len(commits)-1].SHA
 	return userCommitSummary, latestCommitSHA, nil
 }
 
-func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID *int64, latestSHA string, signed []*UserCommitSummary, missing []*UserCommitSummary, CLABaseAPIURL, CLALandingPage, CLALogoURL string) error {
+// getCommitCoAuthors returns a summary for each co-author of the commit declared by a Co-authored-by trailer, the
+// commit author is skipped when listed as a co-author
+func getCommitCoAuthors(commit *github.RepositoryCommit) []*UserCommitSummary {
+	if commit.Commit == nil {
+		return nil
+	}
+	authorEmail := ""
+	if commit.Commit.Author != nil {
+		authorEmail = commit.Commit.Author.GetEmail()
+	}
+
+	var summaries []*UserCommitSummary
+	for _, coAuthor := range utils.ParseCoAuthors(commit.Commit.GetMessage()) {
+		if strings.EqualFold(coAuthor.Email, authorEmail) {
+			continue
+		}
+		coAuthor := coAuthor
+		summaries = append(summaries, &UserCommitSummary{
+			SHA:      commit.GetSHA(),
+			CoAuthor: &coAuthor,
+		})
+	}
+	return summaries
+}
+
+// requiredMissing returns the missing authors failing the check, the optional co-authors are only reported
+func requiredMissing(missing []*UserCommitSummary) []*UserCommitSummary {
+	required := make([]*UserCommitSummary, 0, len(missing))
+	for _, summary := range missing {
+		if !summary.Optional {
+			required = append(required, summary)
+		}
+	}
+	return required
+}
+
+func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID int, owner, repo string, repoID *int64, latestSHA string, signed []*UserCommitSummary, missing []*UserCommitSummary, exempt []*UserCommitSummary, CLABaseAPIURL, CLALandingPage, CLALogoURL string) error {
 	f := logrus.Fields{
 		"functionName":   "github.github_repository.UpdatePullRequest",
 		"installationID": installationID,
@@ -250,10 +368,10 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 		return failedErr
 	}
 
-	body := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, CLABaseAPIURL, CLALogoURL, CLALandingPage)
+	body := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, exempt, CLABaseAPIURL, CLALogoURL, CLALandingPage)
 
-	if len(missing) == 0 {
-		// All contributors are passing
+	if len(requiredMissing(missing)) == 0 {
+		// All contributors are passing - the optional co-authors are only reported
 
 		// If we have previously failed, we need to update the comment
 		if previouslyFailed {
@@ -281,7 +399,7 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 			// If we have previously succeeded, then we also need to update the comment (pass => fail)
 			log.WithFields(f).Debugf("Found previously succeeeded checks - updating the CLA comment in the PR : %d", pullRequestID)
 			// Generate a new comment with all the failed CLA info
-			failedComment := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, CLABaseAPIURL, CLALogoURL, CLALandingPage)
+			failedComment := assembleCLAComment(ctx, int(installationID), pullRequestID, repoID, signed, missing, exempt, CLABaseAPIURL, CLALogoURL, CLALandingPage)
 			previousSucceededComment.Body = &failedComment
 			_, _, err = client.Issues.EditComment(ctx, owner, repo, *previousSucceededComment.ID, previousSucceededComment)
 			if err != nil {
@@ -306,16 +424,16 @@ func UpdatePullRequest(ctx context.Context, installationID int64, pullRequestID
 	var state string
 	var signURL string
 
-	if len(missing) > 0 {
+	if len(requiredMissing(missing)) > 0 {
 		state = failureState
 		context, statusBody = assembleCLAStatus(context, false)
 		signURL = getFullSignURL("github", strconv.Itoa(int(installationID)), strconv.Itoa(int(*repoID)), strconv.Itoa(pullRequestID), CLABaseAPIURL)
 		log.WithFields(f).Debugf("Creating new CLA %s status - %d passed, %d missing, signing url %s", state, len(signed), len(missing), signURL)
-	} else if len(signed) > 0 {
+	} else if len(signed) > 0 || len(exempt) > 0 {
 		state = successState
 		context, statusBody = assembleCLAStatus(context, true)
 		signURL = fmt.Sprintf("%s/#/?version=2", CLALandingPage)
-		log.WithFields(f).Debugf("Creating new CLA %s status - %d passed, %d missing, signing url %s", state, len(signed), len(missing), signURL)
+		log.WithFields(f).Debugf("Creating new CLA %s status - %d passed, %d missing, %d exempt, signing url %s", state, len(signed), len(missing), len(exempt), signURL)
 
 	} else {
 		state = failureState
@@ -398,7 +516,7 @@ func assembleCLAStatus(authorName string, signed bool) (string, string) {
 	return authorName, "Missing CLA Authorization."
 }
 
-func assembleCLAComment(ctx context.Context, installationID, pullRequestID int, repositoryID *int64, signed, missing []*UserCommitSummary, apiBaseURL, CLALogoURL, CLALandingPage string) string {
+func assembleCLAComment(ctx context.Context, installationID, pullRequestID int, repositoryID *int64, signed, missing, exempt []*UserCommitSummary, apiBaseURL, CLALogoURL, CLALandingPage string) string {
 	f := logrus.Fields{
 		"functionName":   "github.github_repository.assembleCLAComment",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -411,20 +529,20 @@ func assembleCLAComment(ctx context.Context, installationID, pullRequestID int,
 	repositoryType := "github"
 	missingID := false
 	for _, userSummary := range missing {
-		if userSummary.GetCommitAuthorID() == "" {
+		if !userSummary.IsCoAuthor() && userSummary.GetCommitAuthorID() == "" {
 			missingID = true
 		}
 	}
 
 	log.WithFields(f).Debug("Building CLAComment body ")
 	signURL := getFullSignURL(repositoryType, strconv.Itoa(installationID), strconv.Itoa(int(*repositoryID)), strconv.Itoa(pullRequestID), apiBaseURL)
-	commentBody := getCommentBody(repositoryType, signURL, signed, missing)
-	allSigned := len(missing) == 0
+	commentBody := getCommentBody(repositoryType, signURL, signed, missing, exempt)
+	allSigned := len(requiredMissing(missing)) == 0
 	badge := getCommentBadge(allSigned, signURL, missingID, false, CLALandingPage, CLALogoURL)
 	return fmt.Sprintf("%s<br >%s", badge, commentBody)
 }
 
-func getCommentBody(repositoryType, signURL string, signed, missing []*UserCommitSummary) string {
+func getCommentBody(repositoryType, signURL string, signed, missing, exempt []*UserCommitSummary) string {
 	f := logrus.Fields{
 		"functionName":   "github.github_repository:getCommentBody",
 		"repositoryType": repositoryType,
@@ -436,7 +554,16 @@ func getCommentBody(repositoryType, signURL string, signed, missing []*UserCommi
 	committersComment := strings.Builder{}
 	text := ""
 
-	if len(missing) > 0 || len(signed) > 0 {
+	// The optional co-authors are listed apart as they don't fail the check
+	var reported []*UserCommitSummary
+	for _, summary := range missing {
+		if summary.Optional {
+			reported = append(reported, summary)
+		}
+	}
+	missing = requiredMissing(missing)
+
+	if len(missing) > 0 || len(signed) > 0 || len(reported) > 0 || len(exempt) > 0 {
 		committersComment.WriteString("<ul>")
 	}
 
@@ -488,12 +615,44 @@ func getCommentBody(repositoryType, signURL string, signed, missing []*UserCommi
 		}
 	}
 
-	if len(signed) > 0 || len(missing) > 0 {
+	if len(reported) > 0 {
+		log.WithFields(f).Debugf("processing %d reported co-authors", len(reported))
+		committers := getAuthorInfoCommits(reported, false)
+		for k, v := range committers {
+			var shas []string
+			for _, summary := range v {
+				shas = append(shas, summary.SHA)
+			}
+			committersComment.WriteString(
+				fmt.Sprintf(`<li>:warning: %s The commit (%s) is co-authored by an author who is not authorized under a signed CLA. Co-authors are reported without failing the check. <a href='%s' target='_blank'>Please click here to be authorized</a>.</li>`,
+					k, strings.Join(shas, ", "), signURL))
+		}
+	}
+
+	if len(exempt) > 0 {
+		log.WithFields(f).Debugf("processing %d exempt bot authors", len(exempt))
+		committers := getAuthorInfoCommits(exempt, false)
+		for k, v := range committers {
+			var shas []string
+			for _, summary := range v {
+				shas = append(shas, summary.SHA)
+			}
+			committersComment.WriteString(
+				fmt.Sprintf(`<li>:robot: %s The commit (%s) is exempt from the CLA check - the author matches the bot allowlist entry '%s'.</li>`,
+					k, strings.Join(shas, ", "), v[0].ExemptBy))
+		}
+	}
+
+	if len(signed) > 0 || len(missing) > 0 || len(reported) > 0 || len(exempt) > 0 {
 		committersComment.WriteString("</ul>")
 	}
 
-	if len(signed) > 0 && len(missing) == 0 {
+	if len(signed) > 0 && len(exempt) > 0 && len(missing) == 0 {
+		text = "<br>The committers listed above are authorized under a signed CLA or exempt from the CLA check."
+	} else if len(signed) > 0 && len(missing) == 0 {
 		text = "<br>The committers listed above are authorized under a signed CLA."
+	} else if len(exempt) > 0 && len(missing) == 0 {
+		text = "<br>The committers listed above are exempt from the CLA check."
 	}
 
 	return fmt.Sprintf("%s%s", committersComment.String(), text)
@@ -0,0 +1,186 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/go-github/v37/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/shurcooL/githubv4"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// pullRequestCommitsPerPage is the maximum page size of the GitHub pull request commits endpoints
+	pullRequestCommitsPerPage = 100
+	// pullRequestRESTCommitsLimit is the maximum number of commits the GitHub REST API lists for a pull request, the
+	// commits of larger pull requests are only available through the GraphQL API
+	pullRequestRESTCommitsLimit = 250
+)
+
+// ErrNoPullRequestCommits is returned when the pull request has no commits
+var ErrNoPullRequestCommits = errors.New("no commits found for pull request")
+
+// pullRequestCommit is a pull request commit of the GraphQL API, the signature is null for the unsigned commits
+type pullRequestCommit struct {
+	Oid     githubv4.GitObjectID
+	Message githubv4.String
+	Author  struct {
+		Name  githubv4.String
+		Email githubv4.String
+		User  struct {
+			Login      githubv4.String
+			DatabaseID githubv4.Int
+		}
+	}
+	Committer struct {
+		Email githubv4.String
+	}
+	Signature *struct {
+		IsValid githubv4.Boolean
+	}
+}
+
+// pullRequestCommitsQuery is the GraphQL query listing a page of the pull request commits
+type pullRequestCommitsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			Commits struct {
+				TotalCount githubv4.Int
+				Nodes      []struct {
+					Commit pullRequestCommit
+				}
+				PageInfo struct {
+					EndCursor   githubv4.String
+					HasNextPage githubv4.Boolean
+				}
+			} `graphql:"commits(first: $first, after: $cursor)"`
+		} `graphql:"pullRequest(number: $number)"`
+	} `graphql:"repository(owner: $owner, name: $name)"`
+}
+
+// listPullRequestCommits returns all the commits of the pull request, the REST API pages are walked until the REST
+// limit is reached - larger pull requests are listed through the GraphQL API with the v4 client returned by newV4Client
+func listPullRequestCommits(ctx context.Context, client *github.Client, newV4Client func() (*githubv4.Client, error), owner, repo string, pullRequestID int) ([]*github.RepositoryCommit, error) {
+	f := logrus.Fields{
+		"functionName":  "github.pull_request_commits.listPullRequestCommits",
+		"owner":         owner,
+		"repo":          repo,
+		"pullRequestID": pullRequestID,
+	}
+
+	var commits []*github.RepositoryCommit
+	opts := &github.ListOptions{PerPage: pullRequestCommitsPerPage}
+	for {
+		page, resp, err := client.PullRequests.ListCommits(ctx, owner, repo, pullRequestID, opts)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem listing commits for repo: %s/%s pull request: %d, page: %d", owner, repo, pullRequestID, opts.Page)
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			msg := fmt.Sprintf("unexpected status code: %d - expected: %d", resp.StatusCode, http.StatusOK)
+			log.WithFields(f).Warn(msg)
+			return nil, errors.New(msg)
+		}
+
+		commits = append(commits, page...)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	if len(commits) >= pullRequestRESTCommitsLimit {
+		log.WithFields(f).Debugf("found %d commits, the REST API limit - listing the commits through the GraphQL API", len(commits))
+		v4Client, err := newV4Client()
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to create Github v4 client")
+			return nil, err
+		}
+		commits, err = listPullRequestCommitsGraphQL(ctx, v4Client, owner, repo, pullRequestID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(commits) == 0 {
+		log.WithFields(f).Warnf("no commits found for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
+		return nil, ErrNoPullRequestCommits
+	}
+
+	log.WithFields(f).Debugf("found %d commits for pull request: %d", len(commits), pullRequestID)
+	return commits, nil
+}
+
+// listPullRequestCommitsGraphQL returns all the commits of the pull request using the GraphQL API, which is not bound
+// by the REST API limit of 250 commits
+func listPullRequestCommitsGraphQL(ctx context.Context, client *githubv4.Client, owner, repo string, pullRequestID int) ([]*github.RepositoryCommit, error) {
+	f := logrus.Fields{
+		"functionName":  "github.pull_request_commits.listPullRequestCommitsGraphQL",
+		"owner":         owner,
+		"repo":          repo,
+		"pullRequestID": pullRequestID,
+	}
+
+	var commits []*github.RepositoryCommit
+	variables := map[string]interface{}{
+		"owner":  githubv4.String(owner),
+		"name":   githubv4.String(repo),
+		"number": githubv4.Int(pullRequestID),
+		"first":  githubv4.Int(pullRequestCommitsPerPage),
+		"cursor": (*githubv4.String)(nil),
+	}
+	for {
+		var query pullRequestCommitsQuery
+		if err := client.Query(ctx, &query, variables); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem querying commits for repo: %s/%s pull request: %d", owner, repo, pullRequestID)
+			return nil, err
+		}
+
+		for _, node := range query.Repository.PullRequest.Commits.Nodes {
+			commits = append(commits, toRepositoryCommit(node.Commit))
+		}
+
+		pageInfo := query.Repository.PullRequest.Commits.PageInfo
+		if !bool(pageInfo.HasNextPage) {
+			break
+		}
+		variables["cursor"] = githubv4.NewString(pageInfo.EndCursor)
+	}
+
+	log.WithFields(f).Debugf("found %d commits for pull request: %d", len(commits), pullRequestID)
+	return commits, nil
+}
+
+// toRepositoryCommit converts a GraphQL pull request commit into the REST API model, the GitHub user is only set when
+// the commit author email is linked to a GitHub account
+func toRepositoryCommit(node pullRequestCommit) *github.RepositoryCommit {
+	commit := &github.RepositoryCommit{
+		SHA: github.String(string(node.Oid)),
+		Commit: &github.Commit{
+			Message: github.String(string(node.Message)),
+			Author: &github.CommitAuthor{
+				Name:  github.String(string(node.Author.Name)),
+				Email: github.String(string(node.Author.Email)),
+			},
+			Committer: &github.CommitAuthor{
+				Email: github.String(string(node.Committer.Email)),
+			},
+			Verification: &github.SignatureVerification{
+				Verified: github.Bool(node.Signature != nil && bool(node.Signature.IsValid)),
+			},
+		},
+	}
+	if node.Author.User.Login != "" {
+		commit.Author = &github.User{
+			Login: github.String(string(node.Author.User.Login)),
+			ID:    github.Int64(int64(node.Author.User.DatabaseID)),
+		}
+	}
+	return commit
+}
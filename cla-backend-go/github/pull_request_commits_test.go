@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-github/v37/github"
+	"github.com/shurcooL/githubv4"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPullRequestCommitsServer serves the REST pull request commits endpoint with the specified number of commits and
+// the GraphQL endpoint with the specified number of commits, both paginated by 100
+func newPullRequestCommitsServer(t *testing.T, restCommits, graphQLCommits int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/octocat/hello-world/pulls/1/commits", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		assert.Equal(t, pullRequestCommitsPerPage, perPage)
+
+		start := (page - 1) * perPage
+		end := start + perPage
+		if end >= restCommits {
+			end = restCommits
+		} else {
+			next := url.URL{Scheme: "http", Host: r.Host, Path: r.URL.Path, RawQuery: fmt.Sprintf("page=%d&per_page=%d", page+1, perPage)}
+			w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+		}
+		commits := make([]*github.RepositoryCommit, 0)
+		for i := start; i < end; i++ {
+			commits = append(commits, &github.RepositoryCommit{
+				SHA:    github.String(fmt.Sprintf("sha-%d", i)),
+				Author: &github.User{Login: github.String(fmt.Sprintf("user-%d", i))},
+			})
+		}
+		assert.Nil(t, json.NewEncoder(w).Encode(commits))
+	})
+	mux.HandleFunc("/graphql", func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Variables struct {
+				Cursor *string `json:"cursor"`
+			} `json:"variables"`
+		}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		start := 0
+		if body.Variables.Cursor != nil {
+			start, _ = strconv.Atoi(*body.Variables.Cursor)
+		}
+		end := start + pullRequestCommitsPerPage
+		if end > graphQLCommits {
+			end = graphQLCommits
+		}
+		nodes := make([]map[string]interface{}, 0)
+		for i := start; i < end; i++ {
+			// the even commits are signed
+			var signature interface{}
+			if i%2 == 0 {
+				signature = map[string]interface{}{"isValid": true}
+			}
+			nodes = append(nodes, map[string]interface{}{
+				"commit": map[string]interface{}{
+					"oid":     fmt.Sprintf("sha-%d", i),
+					"message": "update",
+					"author": map[string]interface{}{
+						"name":  fmt.Sprintf("User %d", i),
+						"email": fmt.Sprintf("user-%d@example.com", i),
+						"user":  map[string]interface{}{"login": fmt.Sprintf("user-%d", i), "databaseId": i},
+					},
+					"committer": map[string]interface{}{"email": fmt.Sprintf("user-%d@example.com", i)},
+					"signature": signature,
+				},
+			})
+		}
+		response := map[string]interface{}{
+			"data": map[string]interface{}{
+				"repository": map[string]interface{}{
+					"pullRequest": map[string]interface{}{
+						"commits": map[string]interface{}{
+							"totalCount": graphQLCommits,
+							"nodes":      nodes,
+							"pageInfo":   map[string]interface{}{"endCursor": strconv.Itoa(end), "hasNextPage": end < graphQLCommits},
+						},
+					},
+				},
+			},
+		}
+		assert.Nil(t, json.NewEncoder(w).Encode(response))
+	})
+	return httptest.NewServer(mux)
+}
+
+func newTestGitHubClients(t *testing.T, server *httptest.Server) (*github.Client, func() (*githubv4.Client, error)) {
+	client := github.NewClient(nil)
+	baseURL, err := url.Parse(server.URL + "/")
+	assert.Nil(t, err)
+	client.BaseURL = baseURL
+	return client, func() (*githubv4.Client, error) {
+		return githubv4.NewEnterpriseClient(server.URL+"/graphql", server.Client()), nil
+	}
+}
+
+func TestListPullRequestCommitsPaginated(t *testing.T) {
+	server := newPullRequestCommitsServer(t, 230, 0)
+	defer server.Close()
+	client, newV4Client := newTestGitHubClients(t, server)
+
+	commits, err := listPullRequestCommits(context.Background(), client, newV4Client, "octocat", "hello-world", 1)
+	assert.Nil(t, err)
+	assert.Len(t, commits, 230)
+	assert.Equal(t, "sha-0", commits[0].GetSHA())
+	assert.Equal(t, "sha-229", commits[len(commits)-1].GetSHA())
+	assert.Equal(t, "user-150", commits[150].GetAuthor().GetLogin())
+}
+
+func TestListPullRequestCommitsGraphQLOverRESTLimit(t *testing.T) {
+	server := newPullRequestCommitsServer(t, pullRequestRESTCommitsLimit, 320)
+	defer server.Close()
+	client, newV4Client := newTestGitHubClients(t, server)
+
+	commits, err := listPullRequestCommits(context.Background(), client, newV4Client, "octocat", "hello-world", 1)
+	assert.Nil(t, err)
+	assert.Len(t, commits, 320)
+	assert.Equal(t, "sha-319", commits[len(commits)-1].GetSHA())
+	assert.Equal(t, "user-300", commits[300].GetAuthor().GetLogin())
+	assert.Equal(t, int64(300), commits[300].GetAuthor().GetID())
+	assert.Equal(t, "user-300@example.com", commits[300].GetCommit().GetAuthor().GetEmail())
+	assert.Equal(t, "user-300@example.com", commits[300].GetCommit().GetCommitter().GetEmail())
+	assert.True(t, commits[300].GetCommit().GetVerification().GetVerified())
+	assert.False(t, commits[301].GetCommit().GetVerification().GetVerified())
+}
+
+func TestListPullRequestCommitsEmpty(t *testing.T) {
+	server := newPullRequestCommitsServer(t, 0, 0)
+	defer server.Close()
+	client, newV4Client := newTestGitHubClients(t, server)
+
+	commits, err := listPullRequestCommits(context.Background(), client, newV4Client, "octocat", "hello-world", 1)
+	assert.ErrorIs(t, err, ErrNoPullRequestCommits)
+	assert.Nil(t, commits)
+}
+
+func TestToRepositoryCommitWithoutGitHubUser(t *testing.T) {
+	var node pullRequestCommit
+	node.Oid = "abc123"
+	node.Message = "fix"
+	node.Author.Name = "Bot"
+	node.Author.Email = "bot@example.com"
+	node.Committer.Email = "bot@example.com"
+
+	commit := toRepositoryCommit(node)
+	assert.Equal(t, "abc123", commit.GetSHA())
+	assert.Nil(t, commit.Author)
+	assert.Equal(t, "bot@example.com", commit.GetCommit().GetAuthor().GetEmail())
+	assert.Equal(t, "bot@example.com", commit.GetCommit().GetCommitter().GetEmail())
+	assert.False(t, commit.GetCommit().GetVerification().GetVerified())
+}
@@ -0,0 +1,402 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// oauthRateLimiterID is the rate limiter key of the clients using the EasyCLA access token instead of an installation
+	oauthRateLimiterID int64 = 0
+	// rateLimitMaxConcurrent is the maximum number of in flight requests per installation, the other requests are queued
+	rateLimitMaxConcurrent = 10
+	// rateLimitReserve is the number of remaining requests under which the requests wait for the rate limit reset
+	rateLimitReserve = 10
+	// rateLimitMaxWait is the longest a request waits for the rate limit reset or a retry before giving up
+	rateLimitMaxWait = 5 * time.Minute
+	// rateLimitMaxRetries is the maximum number of retries of a request rejected by the rate limit
+	rateLimitMaxRetries = 3
+	// rateLimitDefaultBackoff is the first retry backoff when GitHub doesn't tell when to retry, doubled on each retry
+	rateLimitDefaultBackoff = 2 * time.Second
+)
+
+type rateLimitWaitKey struct{}
+
+// WithRateLimitWait returns a context for which the GitHub requests wait for a free slot and for the installation rate
+// limit reset, and retry the requests rejected by the rate limit. Only the background jobs should wait - by default the
+// requests only wait briefly for a free slot and fail with ErrRateLimited when the rate limit is spent, so an API caller
+// or a webhook delivery waiting for the response isn't held for minutes.
+func WithRateLimitWait(ctx context.Context) context.Context {
+	return context.WithValue(ctx, rateLimitWaitKey{}, true)
+}
+
+func rateLimitWaitEnabled(ctx context.Context) bool {
+	wait, ok := ctx.Value(rateLimitWaitKey{}).(bool)
+	return ok && wait
+}
+
+// RateLimitMetrics is a snapshot of the rate limit state and the request counters of an installation
+type RateLimitMetrics struct {
+	InstallationID int64
+	// Requests is the number of requests sent to GitHub, retries included
+	Requests int64
+	// Queued is the number of requests which waited for a free slot or for the rate limit reset
+	Queued int64
+	// RateLimited is the number of responses rejected by the primary or the secondary rate limit
+	RateLimited int64
+	// Retries is the number of requests retried after being rate limited
+	Retries int64
+	// Rejected is the number of requests which failed with ErrRateLimited without being sent
+	Rejected int64
+	// Resources holds the last known rate limit of each GitHub API resource, e.g. core or graphql
+	Resources map[string]RateLimitResource
+}
+
+// RateLimitResource is the last known rate limit of a GitHub API resource
+type RateLimitResource struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// installationRateLimiter tracks the rate limit of an installation, all the clients of the installation share it
+type installationRateLimiter struct {
+	installationID int64
+	slots          chan struct{}
+
+	lock      sync.Mutex
+	resources map[string]*RateLimitResource
+	metrics   RateLimitMetrics
+}
+
+var (
+	rateLimitersLock sync.Mutex
+	rateLimiters     = make(map[int64]*installationRateLimiter)
+	// rateLimitNow and rateLimitSleep are replaced by the tests
+	rateLimitNow   = time.Now
+	rateLimitSleep = sleepWithContext
+	// rateLimitMaxQueueWait is the longest a request without WithRateLimitWait waits for a free slot, replaced by the tests
+	rateLimitMaxQueueWait = 2 * time.Second
+)
+
+// getInstallationRateLimiter returns the rate limiter of the installation, creating it on first use
+func getInstallationRateLimiter(installationID int64) *installationRateLimiter {
+	rateLimitersLock.Lock()
+	defer rateLimitersLock.Unlock()
+	limiter, ok := rateLimiters[installationID]
+	if !ok {
+		limiter = &installationRateLimiter{
+			installationID: installationID,
+			slots:          make(chan struct{}, rateLimitMaxConcurrent),
+			resources:      make(map[string]*RateLimitResource),
+			metrics:        RateLimitMetrics{InstallationID: installationID},
+		}
+		rateLimiters[installationID] = limiter
+	}
+	return limiter
+}
+
+// GetRateLimitMetrics returns the rate limit metrics of every installation used by this process, ordered by
+// installation ID - see LogRateLimitMetrics
+func GetRateLimitMetrics() []RateLimitMetrics {
+	rateLimitersLock.Lock()
+	limiters := make([]*installationRateLimiter, 0, len(rateLimiters))
+	for _, limiter := range rateLimiters {
+		limiters = append(limiters, limiter)
+	}
+	rateLimitersLock.Unlock()
+
+	metrics := make([]RateLimitMetrics, 0, len(limiters))
+	for _, limiter := range limiters {
+		metrics = append(metrics, limiter.snapshot())
+	}
+	sort.Slice(metrics, func(i, j int) bool { return metrics[i].InstallationID < metrics[j].InstallationID })
+	return metrics
+}
+
+// GetInstallationRateLimitMetrics returns the rate limit metrics of the installation
+func GetInstallationRateLimitMetrics(installationID int64) RateLimitMetrics {
+	return getInstallationRateLimiter(installationID).snapshot()
+}
+
+// LogRateLimitMetrics logs the rate limit metrics of every installation used by this process, one structured entry per
+// installation and GitHub API resource so the CloudWatch metric filters can chart them. The counters are cumulative
+// since the process started.
+func LogRateLimitMetrics(ctx context.Context) {
+	for _, metrics := range GetRateLimitMetrics() {
+		f := logrus.Fields{
+			"functionName":         "github.rate_limit.LogRateLimitMetrics",
+			utils.XREQUESTID:       ctx.Value(utils.XREQUESTID),
+			"installationID":       metrics.InstallationID,
+			"rateLimitRequests":    metrics.Requests,
+			"rateLimitQueued":      metrics.Queued,
+			"rateLimitRateLimited": metrics.RateLimited,
+			"rateLimitRetries":     metrics.Retries,
+			"rateLimitRejected":    metrics.Rejected,
+		}
+		if len(metrics.Resources) == 0 {
+			log.WithFields(f).Info("GitHub rate limit metrics")
+			continue
+		}
+
+		names := make([]string, 0, len(metrics.Resources))
+		for name := range metrics.Resources {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			resource := metrics.Resources[name]
+			f["rateLimitResourceName"] = name
+			f["rateLimitLimit"] = resource.Limit
+			f["rateLimitRemaining"] = resource.Remaining
+			f["rateLimitReset"] = resource.Reset.UTC().Format(time.RFC3339)
+			log.WithFields(f).Info("GitHub rate limit metrics")
+		}
+	}
+}
+
+func (l *installationRateLimiter) snapshot() RateLimitMetrics {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	metrics := l.metrics
+	metrics.Resources = make(map[string]RateLimitResource, len(l.resources))
+	for name, resource := range l.resources {
+		metrics.Resources[name] = *resource
+	}
+	return metrics
+}
+
+// reserve takes one request of the resource budget, returning how long to wait for the rate limit reset when the budget
+// is spent
+func (l *installationRateLimiter) reserve(resource string) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	state, ok := l.resources[resource]
+	if !ok {
+		return 0
+	}
+	now := rateLimitNow()
+	if !now.Before(state.Reset) {
+		// the window was reset since the last response, the next response tells the new budget
+		delete(l.resources, resource)
+		return 0
+	}
+	if state.Remaining > rateLimitReserve {
+		state.Remaining--
+		return 0
+	}
+	return state.Reset.Sub(now) + time.Second
+}
+
+// update records the rate limit headers of the response
+func (l *installationRateLimiter) update(resource string, header http.Header) {
+	limit, limitErr := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	remaining, remainingErr := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, resetErr := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+	if limitErr != nil || remainingErr != nil || resetErr != nil {
+		return
+	}
+	if headerResource := header.Get("X-RateLimit-Resource"); headerResource != "" {
+		resource = headerResource
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.resources[resource] = &RateLimitResource{
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+func (l *installationRateLimiter) count(counter *int64) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	*counter++
+}
+
+// rateLimitTransport is a http.RoundTripper tracking the rate limit of an installation. The requests fail with
+// ErrRateLimited when the budget is spent, unless the context opts in with WithRateLimitWait - then the requests are
+// queued, wait for the rate limit reset and are retried when rejected by the rate limit.
+type rateLimitTransport struct {
+	limiter *installationRateLimiter
+	next    http.RoundTripper
+}
+
+// NewRateLimitTransport wraps the transport with the rate limiter shared by all the clients of the installation
+func NewRateLimitTransport(installationID int64, next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &rateLimitTransport{
+		limiter: getInstallationRateLimiter(installationID),
+		next:    next,
+	}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	f := logrus.Fields{
+		"functionName":   "github.rate_limit.RoundTrip",
+		"installationID": t.limiter.installationID,
+		"method":         req.Method,
+		"path":           req.URL.Path,
+	}
+	resource := rateLimitResource(req)
+
+	for attempt := 0; ; attempt++ {
+		if err := t.acquire(ctx, f, resource); err != nil {
+			return nil, err
+		}
+		t.limiter.count(&t.limiter.metrics.Requests)
+		resp, err := t.next.RoundTrip(req)
+		<-t.limiter.slots
+		if err != nil {
+			return nil, err
+		}
+		t.limiter.update(resource, resp.Header)
+
+		backoff, limited := rateLimitBackoff(resp, attempt)
+		if !limited {
+			return resp, nil
+		}
+		t.limiter.count(&t.limiter.metrics.RateLimited)
+		if attempt >= rateLimitMaxRetries || backoff > rateLimitMaxWait || !rateLimitWaitEnabled(ctx) || (req.Body != nil && req.GetBody == nil) {
+			log.WithFields(f).Warnf("request rejected by the GitHub rate limit, status: %d - not retrying", resp.StatusCode)
+			return resp, nil
+		}
+
+		retryReq := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, bodyErr := req.GetBody()
+			if bodyErr != nil {
+				return resp, nil
+			}
+			retryReq.Body = body
+		}
+		drainAndClose(resp.Body)
+
+		log.WithFields(f).Infof("request rejected by the GitHub rate limit, status: %d - retrying in %s", resp.StatusCode, backoff)
+		t.limiter.count(&t.limiter.metrics.Retries)
+		if err := rateLimitSleep(ctx, backoff); err != nil {
+			return nil, err
+		}
+		req = retryReq
+	}
+}
+
+// acquire waits for a free slot and for the rate limit budget of the resource
+func (t *rateLimitTransport) acquire(ctx context.Context, f logrus.Fields, resource string) error {
+	select {
+	case t.limiter.slots <- struct{}{}:
+	default:
+		t.limiter.count(&t.limiter.metrics.Queued)
+		// The in flight requests free their slot quickly, only the background jobs wait without a limit
+		var timeout <-chan time.Time
+		if !rateLimitWaitEnabled(ctx) {
+			timer := time.NewTimer(rateLimitMaxQueueWait)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		select {
+		case t.limiter.slots <- struct{}{}:
+		case <-timeout:
+			t.limiter.count(&t.limiter.metrics.Rejected)
+			log.WithFields(f).Warnf("too many concurrent GitHub requests for %s - rejecting the request", rateLimitMaxQueueWait)
+			return fmt.Errorf("too many concurrent requests : %w", ErrRateLimited)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	wait := t.limiter.reserve(resource)
+	if wait <= 0 {
+		return nil
+	}
+	if !rateLimitWaitEnabled(ctx) || wait > rateLimitMaxWait {
+		<-t.limiter.slots
+		t.limiter.count(&t.limiter.metrics.Rejected)
+		log.WithFields(f).Warnf("%s rate limit spent, resets in %s - rejecting the request", resource, wait)
+		return fmt.Errorf("%s rate limit spent, resets in %s : %w", resource, wait, ErrRateLimited)
+	}
+
+	log.WithFields(f).Infof("%s rate limit spent - waiting %s for the reset", resource, wait)
+	t.limiter.count(&t.limiter.metrics.Queued)
+	if err := rateLimitSleep(ctx, wait); err != nil {
+		<-t.limiter.slots
+		return err
+	}
+	return nil
+}
+
+// rateLimitResource returns the GitHub API resource of the request, the response tells the actual resource
+func rateLimitResource(req *http.Request) string {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return "graphql"
+	case strings.HasPrefix(strings.TrimPrefix(req.URL.Path, "/api/v3"), "/search/"):
+		return "search"
+	default:
+		return "core"
+	}
+}
+
+// rateLimitBackoff returns how long to wait before retrying the response and true if the response was rejected by the
+// primary or the secondary rate limit
+func rateLimitBackoff(resp *http.Response, attempt int) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter != "" {
+		seconds, err := strconv.Atoi(retryAfter)
+		if err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err == nil {
+			return time.Unix(reset, 0).Sub(rateLimitNow()) + time.Second, true
+		}
+	}
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return rateLimitDefaultBackoff << uint(attempt), true
+	}
+	// a 403 without rate limit headers is a permission error
+	return 0, false
+}
+
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func drainAndClose(body io.ReadCloser) {
+	if body == nil {
+		return
+	}
+	_, _ = io.Copy(io.Discard, body)
+	_ = body.Close()
+}
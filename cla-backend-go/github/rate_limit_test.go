@@ -0,0 +1,235 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeRateLimitClock replaces the rate limiter clock and sleep, the sleeps advance the clock and are recorded
+func fakeRateLimitClock(t *testing.T) *[]time.Duration {
+	now := time.Unix(1700000000, 0)
+	var sleeps []time.Duration
+	previousNow, previousSleep := rateLimitNow, rateLimitSleep
+	rateLimitNow = func() time.Time { return now }
+	rateLimitSleep = func(ctx context.Context, d time.Duration) error {
+		sleeps = append(sleeps, d)
+		now = now.Add(d)
+		return nil
+	}
+	t.Cleanup(func() {
+		rateLimitNow, rateLimitSleep = previousNow, previousSleep
+	})
+	return &sleeps
+}
+
+func setRateLimitHeaders(w http.ResponseWriter, remaining int, reset time.Time) {
+	w.Header().Set("X-RateLimit-Limit", "5000")
+	w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	w.Header().Set("X-RateLimit-Resource", "core")
+}
+
+func TestRateLimitTransportTracksHeaders(t *testing.T) {
+	fakeRateLimitClock(t)
+	reset := rateLimitNow().Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		setRateLimitHeaders(w, 4321, reset)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(1001, http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/repos/octocat/hello-world")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	metrics := GetInstallationRateLimitMetrics(1001)
+	assert.Equal(t, int64(1), metrics.Requests)
+	assert.Equal(t, 4321, metrics.Resources["core"].Remaining)
+	assert.Equal(t, reset.Unix(), metrics.Resources["core"].Reset.Unix())
+}
+
+func TestRateLimitTransportWaitsForReset(t *testing.T) {
+	sleeps := fakeRateLimitClock(t)
+	reset := rateLimitNow().Add(30 * time.Second)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		setRateLimitHeaders(w, rateLimitReserve, reset)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(1002, http.DefaultTransport)}
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequestWithContext(WithRateLimitWait(context.Background()), http.MethodGet, server.URL+"/repos/octocat/hello-world", nil)
+		assert.Nil(t, err)
+		resp, err := client.Do(req)
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, []time.Duration{31 * time.Second}, *sleeps)
+	assert.Equal(t, int64(1), GetInstallationRateLimitMetrics(1002).Queued)
+}
+
+func TestRateLimitTransportRetriesSecondaryRateLimit(t *testing.T) {
+	sleeps := fakeRateLimitClock(t)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, `{"state":"success"}`, string(body))
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(1003, http.DefaultTransport)}
+	req, err := http.NewRequestWithContext(WithRateLimitWait(context.Background()), http.MethodPost, server.URL+"/repos/octocat/hello-world/statuses/abc", strings.NewReader(`{"state":"success"}`))
+	assert.Nil(t, err)
+	resp, err := client.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	assert.Equal(t, []time.Duration{3 * time.Second}, *sleeps)
+
+	metrics := GetInstallationRateLimitMetrics(1003)
+	assert.Equal(t, int64(2), metrics.Requests)
+	assert.Equal(t, int64(1), metrics.RateLimited)
+	assert.Equal(t, int64(1), metrics.Retries)
+}
+
+func TestRateLimitTransportDoesNotRetryForbidden(t *testing.T) {
+	sleeps := fakeRateLimitClock(t)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(1004, http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/repos/octocat/private")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	assert.Empty(t, *sleeps)
+}
+
+func TestRateLimitTransportRejectsByDefault(t *testing.T) {
+	sleeps := fakeRateLimitClock(t)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		setRateLimitHeaders(w, 0, rateLimitNow().Add(time.Minute))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(1005, http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/repos/octocat/hello-world")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = client.Get(server.URL + "/repos/octocat/hello-world")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	assert.Empty(t, *sleeps)
+	assert.Equal(t, int64(1), GetInstallationRateLimitMetrics(1005).Rejected)
+}
+
+// fillRateLimitSlots takes every slot of the installation, the returned function frees one
+func fillRateLimitSlots(t *testing.T, installationID int64) func() {
+	previousMaxQueueWait := rateLimitMaxQueueWait
+	rateLimitMaxQueueWait = 100 * time.Millisecond
+	t.Cleanup(func() { rateLimitMaxQueueWait = previousMaxQueueWait })
+
+	limiter := getInstallationRateLimiter(installationID)
+	for i := 0; i < rateLimitMaxConcurrent; i++ {
+		limiter.slots <- struct{}{}
+	}
+	return func() { <-limiter.slots }
+}
+
+func TestRateLimitTransportQueuesForAFreeSlot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	freeSlot := fillRateLimitSlots(t, 1009)
+	time.AfterFunc(20*time.Millisecond, freeSlot)
+
+	client := &http.Client{Transport: NewRateLimitTransport(1009, http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/repos/octocat/hello-world")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	metrics := GetInstallationRateLimitMetrics(1009)
+	assert.Equal(t, int64(1), metrics.Queued)
+	assert.Equal(t, int64(0), metrics.Rejected)
+}
+
+func TestRateLimitTransportRejectsWhenNoSlotIsFreed(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+	}))
+	defer server.Close()
+
+	fillRateLimitSlots(t, 1010)
+
+	client := &http.Client{Transport: NewRateLimitTransport(1010, http.DefaultTransport)}
+	_, err := client.Get(server.URL + "/repos/octocat/hello-world")
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	assert.Equal(t, int64(1), GetInstallationRateLimitMetrics(1010).Rejected)
+}
+
+func TestRateLimitTransportDoesNotRetrySecondaryRateLimitByDefault(t *testing.T) {
+	sleeps := fakeRateLimitClock(t)
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewRateLimitTransport(1006, http.DefaultTransport)}
+	resp, err := client.Get(server.URL + "/repos/octocat/hello-world")
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	assert.Empty(t, *sleeps)
+
+	metrics := GetInstallationRateLimitMetrics(1006)
+	assert.Equal(t, int64(1), metrics.RateLimited)
+	assert.Equal(t, int64(0), metrics.Retries)
+}
+
+func TestGetRateLimitMetricsOrdersInstallations(t *testing.T) {
+	GetInstallationRateLimitMetrics(1008)
+	GetInstallationRateLimitMetrics(1007)
+
+	var installationIDs []int64
+	for _, metrics := range GetRateLimitMetrics() {
+		if metrics.InstallationID == 1007 || metrics.InstallationID == 1008 {
+			installationIDs = append(installationIDs, metrics.InstallationID)
+		}
+	}
+	assert.Equal(t, []int64{1007, 1008}, installationIDs)
+}
@@ -127,6 +127,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetGitHubOrganizations(ctx, proje
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGitHubOrganizations", reflect.TypeOf((*MockRepositoryInterface)(nil).GetGitHubOrganizations), ctx, projectSFID)
 }
 
+// GetBranchProtectionEnabledGitHubOrganizations mocks base method.
+func (m *MockRepositoryInterface) GetBranchProtectionEnabledGitHubOrganizations(ctx context.Context) (*models.GithubOrganizations, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBranchProtectionEnabledGitHubOrganizations", ctx)
+	ret0, _ := ret[0].(*models.GithubOrganizations)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBranchProtectionEnabledGitHubOrganizations indicates an expected call of GetBranchProtectionEnabledGitHubOrganizations.
+func (mr *MockRepositoryInterfaceMockRecorder) GetBranchProtectionEnabledGitHubOrganizations(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBranchProtectionEnabledGitHubOrganizations", reflect.TypeOf((*MockRepositoryInterface)(nil).GetBranchProtectionEnabledGitHubOrganizations), ctx)
+}
+
 // GetGitHubOrganizationsByParent mocks base method.
 func (m *MockRepositoryInterface) GetGitHubOrganizationsByParent(ctx context.Context, parentProjectSFID string) (*models.GithubOrganizations, error) {
 	m.ctrl.T.Helper()
@@ -155,3 +170,17 @@ func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganization(ctx, pro
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganization", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganization), ctx, projectSFID, organizationName, autoEnabled, autoEnabledClaGroupID, branchProtectionEnabled, enabled)
 }
+
+// UpdateGitHubOrganizationBotAllowlist mocks base method.
+func (m *MockRepositoryInterface) UpdateGitHubOrganizationBotAllowlist(ctx context.Context, organizationName string, allowlist *models.BotAllowlist) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateGitHubOrganizationBotAllowlist", ctx, organizationName, allowlist)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateGitHubOrganizationBotAllowlist indicates an expected call of UpdateGitHubOrganizationBotAllowlist.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateGitHubOrganizationBotAllowlist(ctx, organizationName, allowlist interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateGitHubOrganizationBotAllowlist", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateGitHubOrganizationBotAllowlist), ctx, organizationName, allowlist)
+}
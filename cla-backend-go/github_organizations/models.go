@@ -7,18 +7,20 @@ import "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 
 // GithubOrganization is data model for github organizations
 type GithubOrganization struct {
-	DateCreated                string `json:"date_created,omitempty"`
-	DateModified               string `json:"date_modified,omitempty"`
-	OrganizationInstallationID int64  `json:"organization_installation_id,omitempty"`
-	OrganizationName           string `json:"organization_name,omitempty"`
-	OrganizationNameLower      string `json:"organization_name_lower,omitempty"`
-	OrganizationSFID           string `json:"organization_sfid,omitempty"`
-	ProjectSFID                string `json:"project_sfid"`
-	Enabled                    bool   `json:"enabled"`
-	AutoEnabled                bool   `json:"auto_enabled"`
-	BranchProtectionEnabled    bool   `json:"branch_protection_enabled"`
-	AutoEnabledClaGroupID      string `json:"auto_enabled_cla_group_id,omitempty"`
-	Version                    string `json:"version,omitempty"`
+	DateCreated                string   `json:"date_created,omitempty"`
+	DateModified               string   `json:"date_modified,omitempty"`
+	OrganizationInstallationID int64    `json:"organization_installation_id,omitempty"`
+	OrganizationName           string   `json:"organization_name,omitempty"`
+	OrganizationNameLower      string   `json:"organization_name_lower,omitempty"`
+	OrganizationSFID           string   `json:"organization_sfid,omitempty"`
+	ProjectSFID                string   `json:"project_sfid"`
+	Enabled                    bool     `json:"enabled"`
+	AutoEnabled                bool     `json:"auto_enabled"`
+	BranchProtectionEnabled    bool     `json:"branch_protection_enabled"`
+	AutoEnabledClaGroupID      string   `json:"auto_enabled_cla_group_id,omitempty"`
+	BotLoginAllowlist          []string `json:"bot_login_allowlist,omitempty"`
+	BotEmailAllowlist          []string `json:"bot_email_allowlist,omitempty"`
+	Version                    string   `json:"version,omitempty"`
 }
 
 // ToModel converts to models.GithubOrganization
@@ -34,10 +36,22 @@ func ToModel(in *GithubOrganization) *models.GithubOrganization {
 		AutoEnabled:                in.AutoEnabled,
 		AutoEnabledClaGroupID:      in.AutoEnabledClaGroupID,
 		BranchProtectionEnabled:    in.BranchProtectionEnabled,
+		BotAllowlist:               toBotAllowlistModel(in.BotLoginAllowlist, in.BotEmailAllowlist),
 		ProjectSFID:                in.ProjectSFID,
 	}
 }
 
+// toBotAllowlistModel returns the bot allowlist of the organization or nil if no bots are exempt
+func toBotAllowlistModel(logins, emailPatterns []string) *models.BotAllowlist {
+	if len(logins) == 0 && len(emailPatterns) == 0 {
+		return nil
+	}
+	return &models.BotAllowlist{
+		Logins:        logins,
+		EmailPatterns: emailPatterns,
+	}
+}
+
 func toModels(input []*GithubOrganization) []*models.GithubOrganization {
 	out := make([]*models.GithubOrganization, 0)
 	for _, in := range input {
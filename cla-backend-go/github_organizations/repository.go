@@ -42,7 +42,9 @@ type RepositoryInterface interface {
 	GetGitHubOrganizationsByParent(ctx context.Context, parentProjectSFID string) (*models.GithubOrganizations, error)
 	GetGitHubOrganization(ctx context.Context, githubOrganizationName string) (*models.GithubOrganization, error)
 	GetGitHubOrganizationByName(ctx context.Context, githubOrganizationName string) (*models.GithubOrganizations, error)
+	GetBranchProtectionEnabledGitHubOrganizations(ctx context.Context) (*models.GithubOrganizations, error)
 	UpdateGitHubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool, enabled *bool) error
+	UpdateGitHubOrganizationBotAllowlist(ctx context.Context, organizationName string, allowlist *models.BotAllowlist) error
 	DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	DeleteGitHubOrganizationByParent(ctx context.Context, parentProjectSFID string, githubOrgName string) error
 }
@@ -297,6 +299,52 @@ func (repo Repository) GetGitHubOrganizationsByParent(ctx context.Context, paren
 	return &models.GithubOrganizations{List: ghOrgList}, nil
 }
 
+// GetBranchProtectionEnabledGitHubOrganizations returns the github organizations with branch protection enabled
+func (repo Repository) GetBranchProtectionEnabledGitHubOrganizations(ctx context.Context) (*models.GithubOrganizations, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.github_organizations.repository.GetBranchProtectionEnabledGitHubOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	filter := expression.Name("branch_protection_enabled").Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		return nil, err
+	}
+
+	scanInput := &dynamodb.ScanInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.githubOrgTableName),
+	}
+
+	var resultOutput []*GithubOrganization
+	for {
+		results, err := repo.dynamoDBClient.Scan(scanInput)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("error scanning github_organizations with branch protection enabled")
+			return nil, err
+		}
+
+		var page []*GithubOrganization
+		err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding database results")
+			return nil, err
+		}
+		resultOutput = append(resultOutput, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		scanInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Debugf("found %d github organizations with branch protection enabled", len(resultOutput))
+	return &models.GithubOrganizations{List: toModels(resultOutput)}, nil
+}
+
 // GetGitHubOrganizationByName get github organization by name
 func (repo Repository) GetGitHubOrganizationByName(ctx context.Context, githubOrganizationName string) (*models.GithubOrganizations, error) {
 	f := logrus.Fields{
@@ -506,6 +554,64 @@ func (repo Repository) UpdateGitHubOrganization(ctx context.Context, projectSFID
 	return nil
 }
 
+// UpdateGitHubOrganizationBotAllowlist replaces the bot allowlist of the specified GitHub organization - an empty
+// allowlist clears the exempt bots
+func (repo Repository) UpdateGitHubOrganizationBotAllowlist(ctx context.Context, organizationName string, allowlist *models.BotAllowlist) error {
+	f := logrus.Fields{
+		"functionName":     "v1.github_organizations.repository.UpdateGitHubOrganizationBotAllowlist",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationName": organizationName,
+		"tableName":        repo.githubOrgTableName,
+	}
+
+	if allowlist == nil {
+		allowlist = &models.BotAllowlist{}
+	}
+
+	githubOrg, lookupErr := repo.GetGitHubOrganization(ctx, organizationName)
+	if lookupErr != nil {
+		log.WithFields(f).WithError(lookupErr).Warn("error looking up GitHub organization by name")
+		return lookupErr
+	}
+	if githubOrg == nil {
+		lookupErr := errors.New("unable to lookup GitHub organization by name")
+		log.WithFields(f).WithError(lookupErr).Warn("error looking up GitHub organization")
+		return lookupErr
+	}
+
+	_, currentTime := utils.CurrentTime()
+	input := &dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"organization_name": {
+				S: aws.String(githubOrg.OrganizationName),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#L": aws.String("bot_login_allowlist"),
+			"#E": aws.String("bot_email_allowlist"),
+			"#M": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":l": utils.StringListAttribute(allowlist.Logins),
+			":e": utils.StringListAttribute(allowlist.EmailPatterns),
+			":m": {
+				S: aws.String(currentTime),
+			},
+		},
+		UpdateExpression: aws.String("SET #L = :l, #E = :e, #M = :m"),
+		TableName:        aws.String(repo.githubOrgTableName),
+	}
+
+	log.WithFields(f).Debugf("updating github organization bot allowlist: %+v", input)
+	_, updateErr := repo.dynamoDBClient.UpdateItem(input)
+	if updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warn("unable to update GitHub organization bot allowlist")
+		return updateErr
+	}
+
+	return nil
+}
+
 // DeleteGitHubOrganization deletes the github organization by project SFID
 func (repo Repository) DeleteGitHubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error {
 	f := logrus.Fields{
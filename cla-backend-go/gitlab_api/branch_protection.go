@@ -51,6 +51,28 @@ func SetOrCreateBranchProtection(ctx context.Context, client *gitlab.Client, pro
 	return nil
 }
 
+// IsBranchProtectionSet returns true if the protection pattern exists with the settings applied by
+// SetOrCreateBranchProtection, false when the protection is missing or was changed
+func IsBranchProtectionSet(ctx context.Context, client *gitlab.Client, projectID int, protectionPattern string) (bool, error) {
+	f := logrus.Fields{
+		"functionName":      "gitlab_api.IsBranchProtectionSet",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"gitlabProjectID":   projectID,
+		"protectionPattern": protectionPattern,
+	}
+
+	protectedBranch, resp, err := client.ProtectedBranches.GetProtectedBranch(projectID, protectionPattern)
+	if err != nil {
+		if resp != nil && resp.StatusCode == 404 {
+			log.WithFields(f).Debug("branch protection not found")
+			return false, nil
+		}
+		return false, fmt.Errorf("fetching existing branch failed : %v", err)
+	}
+
+	return protectedBranch != nil && isProtectedBranchSet(protectedBranch), nil
+}
+
 func createBranchProtection(client *gitlab.Client, projectID int, name string) (*gitlab.ProtectedBranch, error) {
 	protectedBranch, _, err := client.ProtectedBranches.ProtectRepositoryBranches(projectID, &gitlab.ProtectRepositoryBranchesOptions{
 		Name:                      gitlab.String(name),
@@ -4,16 +4,13 @@
 package gitlab
 
 import (
-	"crypto/aes"
-	"crypto/cipher"
-	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
-	"io"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/authinfo"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 
 	goGitLab "github.com/xanzy/go-gitlab"
@@ -72,7 +69,7 @@ func EncryptAuthInfo(oauthResp *OauthSuccessResponse, gitLabApp *App) (string, e
 	authInfo := string(b)
 	//log.Infof("auth info before encrypting : %s", authInfo)
 
-	encrypted, err := encrypt(keyDecoded, []byte(authInfo))
+	encrypted, err := authinfo.Encrypt(keyDecoded, []byte(authInfo))
 	if err != nil {
 		return "", fmt.Errorf("encrypt failed : %v", err)
 	}
@@ -95,7 +92,7 @@ func DecryptAuthInfo(authInfoEncoded string, gitLabApp *App) (*OauthSuccessRespo
 	}
 
 	//log.Debugf("before decrypt : keyDecoded : %s, cipherText : %s", keyDecoded, ciphertext)
-	decrypted, err := decrypt(keyDecoded, ciphertext)
+	decrypted, err := authinfo.Decrypt(keyDecoded, ciphertext)
 	if err != nil {
 		return nil, fmt.Errorf("decrypt failed : %v", err)
 	}
@@ -108,54 +105,3 @@ func DecryptAuthInfo(authInfoEncoded string, gitLabApp *App) (*OauthSuccessRespo
 
 	return &oauthResp, nil
 }
-
-func encrypt(key, message []byte) ([]byte, error) {
-	// Initialize block cipher
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create the byte slice that will hold encrypted message
-	cipherText := make([]byte, aes.BlockSize+len(message))
-
-	// Generate the Initialization Vector (IV) nonce
-	// which is stored at the beginning of the byte slice
-	// The IV is the same length as the AES blocksize
-	iv := cipherText[:aes.BlockSize]
-	_, err = io.ReadFull(rand.Reader, iv)
-	if err != nil {
-		return nil, err
-	}
-
-	// Choose the block cipher mode of operation
-	// Using the cipher feedback (CFB) mode here.
-	// CBCEncrypter also available.
-	//nolint:staticcheck // CFB mode required for backward compatibility
-	cfb := cipher.NewCFBEncrypter(block, iv)
-	// Generate the encrypted message and store it
-	// in the remaining bytes after the IV nonce
-	cfb.XORKeyStream(cipherText[aes.BlockSize:], message)
-
-	return cipherText, nil
-}
-
-// AES decryption
-func decrypt(key, cipherText []byte) ([]byte, error) {
-	// Initialize block cipher
-	block, err := aes.NewCipher(key)
-	if err != nil {
-		return nil, err
-	}
-
-	// Separate the IV nonce from the encrypted message bytes
-	iv := cipherText[:aes.BlockSize]
-	cipherText = cipherText[aes.BlockSize:]
-
-	// Decrypt the message using the CFB block mode
-	//nolint:staticcheck // CFB mode required for backward compatibility
-	cfb := cipher.NewCFBDecrypter(block, iv)
-	cfb.XORKeyStream(cipherText, cipherText)
-
-	return cipherText, nil
-}
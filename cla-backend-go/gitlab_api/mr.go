@@ -24,6 +24,38 @@ func FetchMrInfo(client *gitlab.Client, projectID int, mergeID int) (*gitlab.Mer
 	return m, nil
 }
 
+// FetchOpenMergeRequests is responsible for fetching the open MRs for given project
+func FetchOpenMergeRequests(client *gitlab.Client, projectID int) ([]*gitlab.MergeRequest, error) {
+	f := logrus.Fields{
+		"functionName": "gitlab_api.FetchOpenMergeRequests",
+		"projectID":    projectID,
+	}
+
+	var results []*gitlab.MergeRequest
+	opts := &gitlab.ListProjectMergeRequestsOptions{
+		State: gitlab.String("opened"),
+		ListOptions: gitlab.ListOptions{
+			Page:    1,
+			PerPage: 100,
+		},
+	}
+	for {
+		mergeRequests, response, err := client.MergeRequests.ListProjectMergeRequests(projectID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching open merge requests for project : %d failed : %v", projectID, err)
+		}
+
+		results = append(results, mergeRequests...)
+		if response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
+	}
+
+	log.WithFields(f).Debugf("found %d open merge requests", len(results))
+	return results, nil
+}
+
 func GetLatestCommit(client *gitlab.Client, projectID int, mergeID int) (*gitlab.Commit, error) {
 	f := logrus.Fields{
 		"functionName": "gitlab_api.GetLatestCommit",
@@ -46,26 +78,31 @@ func GetLatestCommit(client *gitlab.Client, projectID int, mergeID int) (*gitlab
 
 // FetchMrParticipants is responsible to get unique mr participants
 func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*gitlab.User, error) {
+	participants, _, err := FetchMrCommitAuthors(client, projectID, mergeID)
+	return participants, err
+}
+
+// FetchMrCommitAuthors returns the mr participants - the commit authors - and the co-authors declared by the
+// Co-authored-by trailers of the commit messages, the co-authors only carry the trailer name and email
+func FetchMrCommitAuthors(client *gitlab.Client, projectID int, mergeID int) ([]*gitlab.User, []*gitlab.User, error) {
 	f := logrus.Fields{
-		"functionName": "gitlab_api.FetchMrParticipants",
+		"functionName": "gitlab_api.FetchMrCommitAuthors",
 		"projectID":    projectID,
 		"mergeID":      mergeID,
 	}
 	log.WithFields(f).Debug("fetching mr participants...")
-	commits, response, err := client.MergeRequests.GetMergeRequestCommits(projectID, mergeID, &gitlab.GetMergeRequestCommitsOptions{})
+	commits, err := fetchMrCommits(client, projectID, mergeID)
 	if err != nil {
-		return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed : %v", projectID, mergeID, err)
-	}
-	if response.StatusCode != 200 {
-		return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed with status code : %d", projectID, mergeID, response.StatusCode)
+		return nil, nil, err
 	}
 
 	if len(commits) == 0 {
 		log.WithFields(f).Debugf("no commits found for project : %d and merge id : %d", projectID, mergeID)
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	var results []*gitlab.User
+	authorEmails := make(map[string]bool)
 
 	for _, commit := range commits {
 		log.WithFields(f).Debugf("commit information: %v", commit)
@@ -79,12 +116,64 @@ func FetchMrParticipants(client *gitlab.Client, projectID int, mergeID int) ([]*
 		user, getUserErr := getUser(client, &authorEmail, &authorName)
 		if getUserErr != nil {
 			log.WithFields(f).Warnf("unable to find user for commit author email : %s, name : %s, error : %v", authorEmail, authorName, getUserErr)
-			return nil, getUserErr
+			return nil, nil, getUserErr
 		}
 
 		results = append(results, user)
+		authorEmails[strings.ToLower(authorEmail)] = true
+	}
+
+	// the co-authors which are also commit authors are checked as participants
+	var coAuthors []*gitlab.User
+	seen := make(map[string]bool)
+	for _, commit := range commits {
+		for _, coAuthor := range utils.ParseCoAuthors(commit.Message) {
+			key := strings.ToLower(coAuthor.Email)
+			if authorEmails[key] || seen[key] {
+				continue
+			}
+			seen[key] = true
+			log.WithFields(f).Debugf("extracted co-author email: %s, name: %s, from commit: %s", coAuthor.Email, coAuthor.Name, commit.ID)
+			coAuthors = append(coAuthors, &gitlab.User{
+				Name:  coAuthor.Name,
+				Email: coAuthor.Email,
+			})
+		}
+	}
+
+	return results, coAuthors, nil
+}
+
+// fetchMrCommits returns all the commits of the merge request, walking every page of the merge request commits
+func fetchMrCommits(client *gitlab.Client, projectID int, mergeID int) ([]*gitlab.Commit, error) {
+	f := logrus.Fields{
+		"functionName": "gitlab_api.fetchMrCommits",
+		"projectID":    projectID,
+		"mergeID":      mergeID,
+	}
+
+	var results []*gitlab.Commit
+	opts := &gitlab.GetMergeRequestCommitsOptions{
+		Page:    1,
+		PerPage: 100,
+	}
+	for {
+		commits, response, err := client.MergeRequests.GetMergeRequestCommits(projectID, mergeID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed : %v", projectID, mergeID, err)
+		}
+		if response.StatusCode != 200 {
+			return nil, fmt.Errorf("fetching gitlab participants for project : %d and merge id : %d, failed with status code : %d", projectID, mergeID, response.StatusCode)
+		}
+
+		results = append(results, commits...)
+		if response.NextPage == 0 {
+			break
+		}
+		opts.Page = response.NextPage
 	}
 
+	log.WithFields(f).Debugf("found %d commits", len(results))
 	return results, nil
 }
 
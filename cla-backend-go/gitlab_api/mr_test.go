@@ -0,0 +1,83 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitlab
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/xanzy/go-gitlab"
+)
+
+// newMrCommitsServer serves the merge request commits endpoint with the specified number of commits paginated by 100,
+// every commit of the last page declares a co-author
+func newMrCommitsServer(t *testing.T, totalCommits int) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/projects/7/merge_requests/3/commits", func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+		assert.Equal(t, 100, perPage)
+
+		start := (page - 1) * perPage
+		end := start + perPage
+		if end >= totalCommits {
+			end = totalCommits
+		} else {
+			w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+		}
+		commits := make([]*gitlab.Commit, 0)
+		for i := start; i < end; i++ {
+			message := "update"
+			if page > 1 {
+				message = fmt.Sprintf("update\n\nCo-authored-by: Pair %d <pair-%d@example.com>", i, i)
+			}
+			commits = append(commits, &gitlab.Commit{
+				ID:          fmt.Sprintf("sha-%d", i),
+				AuthorName:  fmt.Sprintf("User %d", i),
+				AuthorEmail: fmt.Sprintf("user-%d@example.com", i),
+				Message:     message,
+			})
+		}
+		assert.Nil(t, json.NewEncoder(w).Encode(commits))
+	})
+	mux.HandleFunc("/api/v4/users", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("[]"))
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestFetchMrCommitAuthorsPaginated(t *testing.T) {
+	server := newMrCommitsServer(t, 150)
+	defer server.Close()
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(server.URL))
+	assert.Nil(t, err)
+
+	participants, coAuthors, err := FetchMrCommitAuthors(client, 7, 3)
+	assert.Nil(t, err)
+	assert.Len(t, participants, 150)
+	assert.Equal(t, "user-149@example.com", participants[149].Email)
+	assert.Len(t, coAuthors, 50)
+	assert.Equal(t, "pair-100@example.com", coAuthors[0].Email)
+
+	participants, err = FetchMrParticipants(client, 7, 3)
+	assert.Nil(t, err)
+	assert.Len(t, participants, 150)
+}
+
+func TestFetchMrCommitAuthorsEmpty(t *testing.T) {
+	server := newMrCommitsServer(t, 0)
+	defer server.Close()
+	client, err := gitlab.NewClient("", gitlab.WithBaseURL(server.URL))
+	assert.Nil(t, err)
+
+	participants, coAuthors, err := FetchMrCommitAuthors(client, 7, 3)
+	assert.Nil(t, err)
+	assert.Empty(t, participants)
+	assert.Empty(t, coAuthors)
+}
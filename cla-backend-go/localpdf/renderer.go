@@ -0,0 +1,304 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package localpdf
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/net/html"
+)
+
+const (
+	pageFormat = "Letter"
+	pageMargin = 72.0
+
+	regularFont = "Helvetica"
+	boldFont    = "Helvetica-Bold"
+
+	bodyFontSize = 11
+	listIndent   = 18.0
+	// lineSpacing is applied to the line height of the font
+	lineSpacing = 1.15
+)
+
+var (
+	errEmptyDocument = errors.New("empty html document")
+)
+
+// block is a run of text rendered with a single font, i.e. a paragraph, a heading or a list item
+type block struct {
+	text     string
+	fontName string
+	fontSize int
+	indent   float64
+	bullet   bool
+}
+
+// Renderer renders HTML documents as PDF without calling an external service. Only the text of the document is
+// rendered: the headings, paragraphs and list items are laid out top down with the core Helvetica fonts, the styles,
+// images and tables layouts of the HTML are ignored and the WinAnsi encoding of the core fonts can't render non-Latin
+// text. This is good enough for template previews and tests, the CLA Group documents are rendered by DocRaptor.
+type Renderer struct {
+	mediaBox *pdfcpu.Rectangle
+}
+
+// NewRenderer creates a new local PDF renderer
+func NewRenderer() *Renderer {
+	return &Renderer{
+		mediaBox: pdfcpu.RectForFormat(pageFormat),
+	}
+}
+
+// PreviewOnly returns true, the layout of the documents differs from the DocRaptor layout the DocuSign tab positions
+// are computed for
+func (r *Renderer) PreviewOnly() bool {
+	return true
+}
+
+// CreatePDF accepts an HTML document and returns a PDF
+func (r *Renderer) CreatePDF(htmlDocument string, claType string) (io.ReadCloser, error) {
+	f := logrus.Fields{
+		"functionName": "v1.localpdf.renderer.CreatePDF",
+		"claType":      claType,
+	}
+
+	if strings.TrimSpace(htmlDocument) == "" {
+		return nil, errEmptyDocument
+	}
+
+	blocks, err := parseBlocks(htmlDocument)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to parse the html document")
+		return nil, err
+	}
+
+	log.WithFields(f).Debugf("Generating PDF locally from %d text blocks...", len(blocks))
+	pages := r.layout(blocks)
+
+	pdfBytes, err := writePages(pages)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem generating the PDF")
+		return nil, err
+	}
+	log.WithFields(f).Debugf("generated PDF with %d pages", len(pages))
+
+	return io.NopCloser(bytes.NewReader(pdfBytes)), nil
+}
+
+// layout wraps the blocks to the page width and breaks them into pages
+func (r *Renderer) layout(blocks []block) []pdfcpu.Page {
+	top := r.mediaBox.Height() - pageMargin
+	width := r.mediaBox.Width() - 2*pageMargin
+
+	page := pdfcpu.NewPage(r.mediaBox)
+	pages := []pdfcpu.Page{page}
+	y := top
+	for _, b := range blocks {
+		lineHeight := font.LineHeight(b.fontName, b.fontSize) * lineSpacing
+		for _, line := range wrapText(b.text, b.fontName, b.fontSize, width-b.indent) {
+			if y-lineHeight < pageMargin {
+				page = pdfcpu.NewPage(r.mediaBox)
+				pages = append(pages, page)
+				y = top
+			}
+			y -= lineHeight
+			pdfcpu.WriteMultiLine(page.Buf, page.MediaBox, nil, pdfcpu.TextDescriptor{
+				Text:     line,
+				FontName: b.fontName,
+				FontKey:  page.Fm.EnsureKey(b.fontName),
+				FontSize: b.fontSize,
+				X:        pageMargin + b.indent,
+				Y:        y,
+				Scale:    1,
+				ScaleAbs: true,
+				FillCol:  pdfcpu.Black,
+			})
+		}
+		// paragraph spacing
+		y -= font.LineHeight(b.fontName, b.fontSize) / 2
+	}
+
+	return pages
+}
+
+// writePages writes the pages as a single PDF document
+func writePages(pages []pdfcpu.Page) ([]byte, error) {
+	documents := make([]io.ReadSeeker, 0, len(pages))
+	for _, page := range pages {
+		xRefTable, err := pdfcpu.CreateDemoXRef(page)
+		if err != nil {
+			return nil, err
+		}
+		var buf bytes.Buffer
+		if err := api.WriteContext(pdfcpu.CreateContext(xRefTable, nil), &buf); err != nil {
+			return nil, err
+		}
+		documents = append(documents, bytes.NewReader(buf.Bytes()))
+	}
+
+	if len(documents) == 1 {
+		return io.ReadAll(documents[0])
+	}
+
+	var buf bytes.Buffer
+	if err := api.Merge(documents, &buf, nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// wrapText breaks the text into lines fitting the width, a word wider than the line gets a line of its own
+func wrapText(text, fontName string, fontSize int, width float64) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		candidate := word
+		if line != "" {
+			candidate = line + " " + word
+		}
+		if line != "" && font.TextWidth(candidate, fontName, fontSize) > width {
+			lines = append(lines, line)
+			line = word
+			continue
+		}
+		line = candidate
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// parseBlocks extracts the text blocks of the HTML document body
+func parseBlocks(htmlDocument string) ([]block, error) {
+	var blocks []block
+	var text strings.Builder
+	current := block{fontName: regularFont, fontSize: bodyFontSize}
+	skipDepth, listDepth := 0, 0
+
+	reset := func() {
+		current = block{fontName: regularFont, fontSize: bodyFontSize, indent: float64(listDepth) * listIndent}
+	}
+	// flush ends the current block, the style of an empty block is kept for the next text - i.e. for a list item
+	// wrapping a paragraph
+	flush := func() {
+		content := strings.Join(strings.Fields(text.String()), " ")
+		text.Reset()
+		if content == "" {
+			return
+		}
+		if current.bullet {
+			content = "- " + content
+		}
+		current.text = content
+		blocks = append(blocks, current)
+		reset()
+	}
+
+	tokenizer := html.NewTokenizer(strings.NewReader(htmlDocument))
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return nil, err
+			}
+			flush()
+			return blocks, nil
+		case html.TextToken:
+			if skipDepth == 0 {
+				text.Write(tokenizer.Text())
+				text.WriteString(" ")
+			}
+		case html.StartTagToken, html.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			switch {
+			case isSkippedTag(tag):
+				if tokenType == html.StartTagToken {
+					skipDepth++
+				}
+			case tag == "ul" || tag == "ol":
+				flush()
+				listDepth++
+				reset()
+			case tag == "li":
+				flush()
+				reset()
+				current.bullet = true
+			case isHeadingTag(tag):
+				flush()
+				reset()
+				current.fontName = boldFont
+				current.fontSize = headingFontSize(tag)
+			case isBlockTag(tag):
+				flush()
+			}
+		case html.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			switch {
+			case isSkippedTag(tag):
+				if skipDepth > 0 {
+					skipDepth--
+				}
+			case tag == "ul" || tag == "ol":
+				flush()
+				if listDepth > 0 {
+					listDepth--
+				}
+				reset()
+			case tag == "li" || isHeadingTag(tag):
+				flush()
+				reset()
+			case isBlockTag(tag):
+				flush()
+			}
+		}
+	}
+}
+
+func isSkippedTag(tag string) bool {
+	switch tag {
+	case "head", "title", "style", "script":
+		return true
+	}
+	return false
+}
+
+func isHeadingTag(tag string) bool {
+	switch tag {
+	case "h1", "h2", "h3", "h4", "h5", "h6":
+		return true
+	}
+	return false
+}
+
+func isBlockTag(tag string) bool {
+	switch tag {
+	case "p", "div", "br", "section", "article", "header", "footer", "table", "tr", "blockquote", "pre", "hr":
+		return true
+	}
+	return false
+}
+
+func headingFontSize(tag string) int {
+	switch tag {
+	case "h1":
+		return 18
+	case "h2":
+		return 15
+	case "h3":
+		return 13
+	}
+	return 12
+}
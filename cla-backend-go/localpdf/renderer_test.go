@@ -0,0 +1,73 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package localpdf
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const templateHTML = `<html><head><title>ICLA</title><style>p { font-size: 11pt; }</style></head>
+<body>
+<h1>Individual Contributor License Agreement</h1>
+<p>Thank you for your interest in the <b>Acme</b> project.</p>
+<ol>
+<li><p>Definitions &amp; grants.</p></li>
+<li>Representations.</li>
+</ol>
+</body></html>`
+
+func TestParseBlocks(t *testing.T) {
+	blocks, err := parseBlocks(templateHTML)
+	assert.NoError(t, err)
+	if assert.Len(t, blocks, 4) {
+		assert.Equal(t, "Individual Contributor License Agreement", blocks[0].text)
+		assert.Equal(t, boldFont, blocks[0].fontName)
+		assert.Equal(t, "Thank you for your interest in the Acme project.", blocks[1].text)
+		assert.Equal(t, regularFont, blocks[1].fontName)
+		assert.Equal(t, "- Definitions & grants.", blocks[2].text)
+		assert.Equal(t, listIndent, blocks[2].indent)
+		assert.Equal(t, "- Representations.", blocks[3].text)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("one two three four", regularFont, bodyFontSize, 30)
+	assert.Equal(t, []string{"one", "two", "three", "four"}, lines)
+
+	lines = wrapText("one two three four", regularFont, bodyFontSize, 1000)
+	assert.Equal(t, []string{"one two three four"}, lines)
+}
+
+func TestCreatePDF(t *testing.T) {
+	renderer := NewRenderer()
+
+	reader, err := renderer.CreatePDF(templateHTML, "icla")
+	assert.NoError(t, err)
+	pdf, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.NoError(t, reader.Close())
+	assert.NoError(t, api.Validate(bytes.NewReader(pdf), nil))
+	pageCount, err := api.PageCount(bytes.NewReader(pdf), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pageCount)
+
+	// long documents flow over several pages
+	longHTML := "<html><body>" + strings.Repeat("<p>The contributor grants a perpetual, worldwide, non-exclusive license.</p>", 150) + "</body></html>"
+	reader, err = renderer.CreatePDF(longHTML, "ccla")
+	assert.NoError(t, err)
+	pdf, err = io.ReadAll(reader)
+	assert.NoError(t, err)
+	pageCount, err = api.PageCount(bytes.NewReader(pdf), nil)
+	assert.NoError(t, err)
+	assert.Greater(t, pageCount, 1)
+
+	_, err = renderer.CreatePDF("  ", "icla")
+	assert.Error(t, err)
+}
@@ -0,0 +1,29 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
+const (
+	// CoAuthorPolicyReport lists the Co-authored-by co-authors in the CLA check without failing it - the default
+	CoAuthorPolicyReport = "report"
+	// CoAuthorPolicyEnforce requires the Co-authored-by co-authors to be covered by a signature, like the commit authors
+	CoAuthorPolicyEnforce = "enforce"
+)
+
+// IsValidCoAuthorPolicy returns true if the specified value is a supported co-author policy
+func IsValidCoAuthorPolicy(policy string) bool {
+	switch policy {
+	case CoAuthorPolicyReport, CoAuthorPolicyEnforce:
+		return true
+	}
+	return false
+}
+
+// IsCoAuthorPolicyEnforced returns true if the co-authors must be covered by a signature for the CLA check to pass
+func IsCoAuthorPolicyEnforced(claGroup *models.ClaGroup) bool {
+	return claGroup != nil && claGroup.CoAuthorPolicy == CoAuthorPolicyEnforce
+}
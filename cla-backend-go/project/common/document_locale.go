@@ -0,0 +1,92 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"golang.org/x/text/language"
+)
+
+// DefaultDocumentLocale is the locale of the CLA Group documents created without a locale
+const DefaultDocumentLocale = "en"
+
+// GetDocumentLocale returns the locale of the original document
+func GetDocumentLocale(document models.ClaGroupDocument) string {
+	if document.DocumentLocale == "" {
+		return DefaultDocumentLocale
+	}
+	return document.DocumentLocale
+}
+
+// MatchDocumentLocale returns the locale of the document variant that best matches the preferred locale of the user
+// or, when the user doesn't have a preferred locale or none of the variants match it, the Accept-Language header
+// value. The locale of the original document is returned when none of the variants match.
+func MatchDocumentLocale(document models.ClaGroupDocument, preferredLocale, acceptLanguage string) string {
+	documentLocale := GetDocumentLocale(document)
+	if len(document.DocumentLocales) == 0 {
+		return documentLocale
+	}
+
+	// The original document comes first, the matcher falls back to the first supported locale
+	locales := []string{documentLocale}
+	supported := []language.Tag{language.Make(documentLocale)}
+	for _, variant := range document.DocumentLocales {
+		tag, err := language.Parse(variant.Locale)
+		if err != nil {
+			continue
+		}
+		locales = append(locales, variant.Locale)
+		supported = append(supported, tag)
+	}
+	matcher := language.NewMatcher(supported)
+
+	if preferredLocale != "" {
+		if tag, err := language.Parse(preferredLocale); err == nil {
+			if _, index, confidence := matcher.Match(tag); confidence != language.No {
+				return locales[index]
+			}
+		}
+	}
+
+	if acceptLanguage != "" {
+		// ParseAcceptLanguage returns the tags ordered by their quality value
+		if tags, _, err := language.ParseAcceptLanguage(acceptLanguage); err == nil && len(tags) > 0 {
+			if _, index, confidence := matcher.Match(tags...); confidence != language.No {
+				return locales[index]
+			}
+		}
+	}
+
+	return documentLocale
+}
+
+// GetDocumentLocaleVariant returns the document with the name, the content and the signature fields of the
+// translation with the specified locale, the original document is returned when the document doesn't have the
+// translation
+func GetDocumentLocaleVariant(document models.ClaGroupDocument, locale string) models.ClaGroupDocument {
+	if locale == "" || strings.EqualFold(locale, GetDocumentLocale(document)) {
+		return document
+	}
+
+	for _, variant := range document.DocumentLocales {
+		if !strings.EqualFold(variant.Locale, locale) {
+			continue
+		}
+		localized := document
+		localized.DocumentLocale = variant.Locale
+		localized.DocumentLocales = nil
+		localized.DocumentName = variant.DocumentName
+		localized.DocumentContentType = variant.DocumentContentType
+		localized.DocumentS3URL = variant.DocumentS3URL
+		localized.DocumentContent = ""
+		if len(variant.DocumentTabs) > 0 {
+			localized.DocumentTabs = variant.DocumentTabs
+		}
+		return localized
+	}
+
+	return document
+}
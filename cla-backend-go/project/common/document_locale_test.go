@@ -0,0 +1,91 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func localizedDocument() models.ClaGroupDocument {
+	return models.ClaGroupDocument{
+		DocumentName:         "Apache Style",
+		DocumentContentType:  "storage+pdf",
+		DocumentS3URL:        "https://bucket/icla.pdf",
+		DocumentMajorVersion: "2",
+		DocumentMinorVersion: "0",
+		DocumentTabs:         []models.DocumentTab{{DocumentTabID: "sign", DocumentTabAnchorString: "Signature"}},
+		DocumentLocales: []models.ClaGroupDocumentLocale{
+			{
+				Locale:              "pt-BR",
+				DocumentName:        "Apache Style (pt-BR)",
+				DocumentContentType: "storage+pdf",
+				DocumentS3URL:       "https://bucket/icla-pt-BR.pdf",
+				DocumentTabs:        []models.DocumentTab{{DocumentTabID: "sign", DocumentTabAnchorString: "Assinatura"}},
+			},
+			{
+				Locale:              "fr",
+				DocumentName:        "Apache Style (fr)",
+				DocumentContentType: "storage+pdf",
+				DocumentS3URL:       "https://bucket/icla-fr.pdf",
+			},
+		},
+	}
+}
+
+func TestMatchDocumentLocale(t *testing.T) {
+	document := localizedDocument()
+
+	testCases := []struct {
+		name            string
+		preferredLocale string
+		acceptLanguage  string
+		expected        string
+	}{
+		{name: "no preference", expected: "en"},
+		{name: "preferred locale", preferredLocale: "fr", acceptLanguage: "pt-BR", expected: "fr"},
+		{name: "preferred region variant", preferredLocale: "fr-CA", expected: "fr"},
+		{name: "preferred language of a regional translation", preferredLocale: "pt", expected: "pt-BR"},
+		{name: "preferred locale is case insensitive", preferredLocale: "PT-br", expected: "pt-BR"},
+		{name: "unknown preferred locale falls back to the header", preferredLocale: "ja", acceptLanguage: "fr-FR,fr;q=0.9", expected: "fr"},
+		{name: "invalid preferred locale falls back to the header", preferredLocale: "not a locale", acceptLanguage: "pt-BR", expected: "pt-BR"},
+		{name: "header quality values", acceptLanguage: "de;q=0.9,fr;q=0.5,pt-BR;q=0.7", expected: "pt-BR"},
+		{name: "header without a translation", acceptLanguage: "de-DE,de;q=0.9", expected: "en"},
+		{name: "header prefers the original document", acceptLanguage: "en-US,en;q=0.9,fr;q=0.8", expected: "en"},
+		{name: "invalid header", acceptLanguage: ";;;", expected: "en"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, MatchDocumentLocale(document, tc.preferredLocale, tc.acceptLanguage))
+		})
+	}
+
+	// documents without translations are always signed in their own locale
+	document.DocumentLocales = nil
+	document.DocumentLocale = "de"
+	assert.Equal(t, "de", MatchDocumentLocale(document, "fr", "fr"))
+}
+
+func TestGetDocumentLocaleVariant(t *testing.T) {
+	document := localizedDocument()
+
+	assert.Equal(t, document, GetDocumentLocaleVariant(document, ""))
+	assert.Equal(t, document, GetDocumentLocaleVariant(document, "en"))
+	assert.Equal(t, document, GetDocumentLocaleVariant(document, "ja"))
+
+	variant := GetDocumentLocaleVariant(document, "pt-br")
+	assert.Equal(t, "pt-BR", variant.DocumentLocale)
+	assert.Equal(t, "Apache Style (pt-BR)", variant.DocumentName)
+	assert.Equal(t, "https://bucket/icla-pt-BR.pdf", variant.DocumentS3URL)
+	assert.Equal(t, "Assinatura", variant.DocumentTabs[0].DocumentTabAnchorString)
+	assert.Equal(t, "2", variant.DocumentMajorVersion)
+	assert.Empty(t, variant.DocumentLocales)
+
+	// the translation without signature fields uses the fields of the original document
+	variant = GetDocumentLocaleVariant(document, "fr")
+	assert.Equal(t, "https://bucket/icla-fr.pdf", variant.DocumentS3URL)
+	assert.Equal(t, document.DocumentTabs, variant.DocumentTabs)
+}
@@ -62,6 +62,30 @@ func BuildCLAGroupDocumentModels(dbDocumentModels []models2.DBProjectDocumentMod
 			DocumentMinorVersion:    dbDocumentModel.DocumentMinorVersion,
 			DocumentCreationDate:    dbDocumentModel.DocumentCreationDate,
 			DocumentTabs:            dbDocumentModel.DocumentTabs,
+			DocumentLocale:          dbDocumentModel.DocumentLocale,
+			DocumentLocales:         buildCLAGroupDocumentLocaleModels(dbDocumentModel.DocumentLocales),
+		})
+	}
+
+	return response
+}
+
+// buildCLAGroupDocumentLocaleModels builds the document translation response models based on the array of db models
+func buildCLAGroupDocumentLocaleModels(dbDocumentLocaleModels []models2.DBProjectDocumentLocaleModel) []models.ClaGroupDocumentLocale {
+	if len(dbDocumentLocaleModels) == 0 {
+		return nil
+	}
+
+	var response []models.ClaGroupDocumentLocale
+	for _, dbDocumentLocaleModel := range dbDocumentLocaleModels {
+		response = append(response, models.ClaGroupDocumentLocale{
+			Locale:               dbDocumentLocaleModel.Locale,
+			DocumentName:         dbDocumentLocaleModel.DocumentName,
+			DocumentContentType:  dbDocumentLocaleModel.DocumentContentType,
+			DocumentS3URL:        dbDocumentLocaleModel.DocumentS3URL,
+			DocumentReviewedBy:   dbDocumentLocaleModel.DocumentReviewedBy,
+			DocumentCreationDate: dbDocumentLocaleModel.DocumentCreationDate,
+			DocumentTabs:         dbDocumentLocaleModel.DocumentTabs,
 		})
 	}
 
@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package common
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SignatureVersionPolicyAcceptPrevious keeps honoring signatures of a previous major document version - the default
+	SignatureVersionPolicyAcceptPrevious = "accept-previous"
+	// SignatureVersionPolicyResign requires contributors to sign the latest major document version on their next contribution
+	SignatureVersionPolicyResign = "resign"
+	// SignatureVersionPolicyExpire keeps honoring signatures of a previous major document version until the CLA Group signature expiry date
+	SignatureVersionPolicyExpire = "expire"
+)
+
+// IsValidSignatureVersionPolicy returns true if the specified value is a supported signature version policy
+func IsValidSignatureVersionPolicy(policy string) bool {
+	switch policy {
+	case SignatureVersionPolicyAcceptPrevious, SignatureVersionPolicyResign, SignatureVersionPolicyExpire:
+		return true
+	}
+	return false
+}
+
+// IsSignatureDocumentOutdated returns true when the signature was made against a previous major version of the
+// CLA Group document, along with the current major document version
+func IsSignatureDocumentOutdated(ctx context.Context, claGroup *models.ClaGroup, signature *models.Signature) (bool, int) {
+	f := logrus.Fields{
+		"functionName":   "v1.project.common.IsSignatureDocumentOutdated",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+		"signatureID":    signature.SignatureID,
+	}
+
+	docs := claGroup.ProjectIndividualDocuments
+	if signature.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		docs = claGroup.ProjectCorporateDocuments
+	}
+
+	currentDoc, err := GetCurrentDocument(ctx, docs)
+	if err != nil || currentDoc.DocumentMajorVersion == "" {
+		return false, 0
+	}
+
+	currentMajor, err := strconv.Atoi(currentDoc.DocumentMajorVersion)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("invalid current document major version: %s", currentDoc.DocumentMajorVersion)
+		return false, 0
+	}
+
+	signatureMajor, err := strconv.Atoi(signature.SignatureDocumentMajorVersion)
+	if err != nil {
+		// Signatures without a recorded document version can't be compared - treat them as current
+		log.WithFields(f).Debugf("unable to parse signature document major version: '%s'", signature.SignatureDocumentMajorVersion)
+		return false, currentMajor
+	}
+
+	return signatureMajor < currentMajor, currentMajor
+}
+
+// IsSignatureVersionAccepted returns true if the signature is still honored under the CLA Group signature version policy
+func IsSignatureVersionAccepted(ctx context.Context, claGroup *models.ClaGroup, signature *models.Signature, now time.Time) bool {
+	f := logrus.Fields{
+		"functionName":           "v1.project.common.IsSignatureVersionAccepted",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"claGroupID":             claGroup.ProjectID,
+		"signatureID":            signature.SignatureID,
+		"signatureVersionPolicy": claGroup.SignatureVersionPolicy,
+		"signatureExpiryDate":    claGroup.SignatureExpiryDate,
+	}
+
+	if claGroup.SignatureVersionPolicy == "" || claGroup.SignatureVersionPolicy == SignatureVersionPolicyAcceptPrevious {
+		return true
+	}
+
+	outdated, currentMajor := IsSignatureDocumentOutdated(ctx, claGroup, signature)
+	if !outdated {
+		return true
+	}
+
+	switch claGroup.SignatureVersionPolicy {
+	case SignatureVersionPolicyResign:
+		log.WithFields(f).Debugf("signature document major version %s is older than the current major version %d - re-sign required",
+			signature.SignatureDocumentMajorVersion, currentMajor)
+		return false
+	case SignatureVersionPolicyExpire:
+		expiryDate, err := utils.ParseDateTime(claGroup.SignatureExpiryDate)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to parse the CLA Group signature expiry date - accepting signature")
+			return true
+		}
+		if now.Before(expiryDate) {
+			return true
+		}
+		log.WithFields(f).Debugf("signature document major version %s expired on %s - re-sign required",
+			signature.SignatureDocumentMajorVersion, claGroup.SignatureExpiryDate)
+		return false
+	}
+
+	return true
+}
@@ -24,6 +24,12 @@ type DBProjectModel struct {
 	ProjectCclaRequiresIclaSignature bool                     `dynamodbav:"project_ccla_requires_icla_signature"`
 	ProjectIclaEnabled               bool                     `dynamodbav:"project_icla_enabled"`
 	ProjectLive                      bool                     `dynamodbav:"project_live"`
+	SignatureVersionPolicy           string                   `dynamodbav:"signature_version_policy"`
+	SignatureExpiryDate              string                   `dynamodbav:"signature_expiry_date"`
+	SignatureProvider                string                   `dynamodbav:"signature_provider"`
+	CoAuthorPolicy                   string                   `dynamodbav:"co_author_policy"`
+	BotLoginAllowlist                []string                 `dynamodbav:"bot_login_allowlist"`
+	BotEmailAllowlist                []string                 `dynamodbav:"bot_email_allowlist"`
 	ProjectCorporateDocuments        []DBProjectDocumentModel `dynamodbav:"project_corporate_documents"`
 	ProjectIndividualDocuments       []DBProjectDocumentModel `dynamodbav:"project_individual_documents"`
 	ProjectMemberDocuments           []DBProjectDocumentModel `dynamodbav:"project_member_documents"`
@@ -32,15 +38,28 @@ type DBProjectModel struct {
 
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
 type DBProjectDocumentModel struct {
-	DocumentName            string                 `dynamodbav:"document_name"`
-	DocumentFileID          string                 `dynamodbav:"document_file_id"`
-	DocumentPreamble        string                 `dynamodbav:"document_preamble"`
-	DocumentLegalEntityName string                 `dynamodbav:"document_legal_entity_name"`
-	DocumentAuthorName      string                 `dynamodbav:"document_author_name"`
-	DocumentContentType     string                 `dynamodbav:"document_content_type"`
-	DocumentS3URL           string                 `dynamodbav:"document_s3_url"`
-	DocumentMajorVersion    string                 `dynamodbav:"document_major_version"`
-	DocumentMinorVersion    string                 `dynamodbav:"document_minor_version"`
-	DocumentCreationDate    string                 `dynamodbav:"document_creation_date"`
-	DocumentTabs            []v1Models.DocumentTab `dynamodbav:"document_tabs"`
+	DocumentName            string                         `dynamodbav:"document_name"`
+	DocumentFileID          string                         `dynamodbav:"document_file_id"`
+	DocumentPreamble        string                         `dynamodbav:"document_preamble"`
+	DocumentLegalEntityName string                         `dynamodbav:"document_legal_entity_name"`
+	DocumentAuthorName      string                         `dynamodbav:"document_author_name"`
+	DocumentContentType     string                         `dynamodbav:"document_content_type"`
+	DocumentS3URL           string                         `dynamodbav:"document_s3_url"`
+	DocumentMajorVersion    string                         `dynamodbav:"document_major_version"`
+	DocumentMinorVersion    string                         `dynamodbav:"document_minor_version"`
+	DocumentCreationDate    string                         `dynamodbav:"document_creation_date"`
+	DocumentTabs            []v1Models.DocumentTab         `dynamodbav:"document_tabs"`
+	DocumentLocale          string                         `dynamodbav:"document_locale"`
+	DocumentLocales         []DBProjectDocumentLocaleModel `dynamodbav:"document_locales"`
+}
+
+// DBProjectDocumentLocaleModel is a data model for the legally reviewed translations of a CLA Group Project document
+type DBProjectDocumentLocaleModel struct {
+	Locale               string                 `dynamodbav:"locale"`
+	DocumentName         string                 `dynamodbav:"document_name"`
+	DocumentContentType  string                 `dynamodbav:"document_content_type"`
+	DocumentS3URL        string                 `dynamodbav:"document_s3_url"`
+	DocumentReviewedBy   string                 `dynamodbav:"document_reviewed_by"`
+	DocumentCreationDate string                 `dynamodbav:"document_creation_date"`
+	DocumentTabs         []v1Models.DocumentTab `dynamodbav:"document_tabs"`
 }
@@ -21,6 +21,12 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("project_icla_enabled"),
 		expression.Name("project_ccla_requires_icla_signature"),
 		expression.Name("project_live"),
+		expression.Name("signature_version_policy"),
+		expression.Name("signature_expiry_date"),
+		expression.Name("signature_provider"),
+		expression.Name("co_author_policy"),
+		expression.Name("bot_login_allowlist"),
+		expression.Name("bot_email_allowlist"),
 		expression.Name("project_corporate_documents"),
 		expression.Name("project_individual_documents"),
 		expression.Name("project_member_documents"),
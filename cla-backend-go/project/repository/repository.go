@@ -734,6 +734,50 @@ func (repo *repo) UpdateCLAGroup(ctx context.Context, claGroupModel *models.ClaG
 		updateExpression = updateExpression + " #PL = :pl, "
 	}
 
+	// An update to the signature version policy
+	if claGroupModel.SignatureVersionPolicy != "" && claGroupModel.SignatureVersionPolicy != existingCLAGroup.SignatureVersionPolicy {
+		log.WithFields(f).Debugf("adding signature_version_policy: %s", claGroupModel.SignatureVersionPolicy)
+		expressionAttributeNames["#SVP"] = aws.String("signature_version_policy")
+		expressionAttributeValues[":svp"] = &dynamodb.AttributeValue{S: aws.String(claGroupModel.SignatureVersionPolicy)}
+		updateExpression = updateExpression + " #SVP = :svp, "
+	}
+
+	// An update to the signature expiry date - it is cleared when the CLA Group leaves the expire signature version policy
+	removeSignatureExpiryDate := claGroupModel.SignatureVersionPolicy != "" && claGroupModel.SignatureVersionPolicy != common.SignatureVersionPolicyExpire &&
+		existingCLAGroup.SignatureExpiryDate != ""
+	if !removeSignatureExpiryDate && claGroupModel.SignatureExpiryDate != "" && claGroupModel.SignatureExpiryDate != existingCLAGroup.SignatureExpiryDate {
+		log.WithFields(f).Debugf("adding signature_expiry_date: %s", claGroupModel.SignatureExpiryDate)
+		expressionAttributeNames["#SED"] = aws.String("signature_expiry_date")
+		expressionAttributeValues[":sed"] = &dynamodb.AttributeValue{S: aws.String(claGroupModel.SignatureExpiryDate)}
+		updateExpression = updateExpression + " #SED = :sed, "
+	}
+
+	// An update to the signature provider
+	if claGroupModel.SignatureProvider != "" && claGroupModel.SignatureProvider != existingCLAGroup.SignatureProvider {
+		log.WithFields(f).Debugf("adding signature_provider: %s", claGroupModel.SignatureProvider)
+		expressionAttributeNames["#SP"] = aws.String("signature_provider")
+		expressionAttributeValues[":sp"] = &dynamodb.AttributeValue{S: aws.String(claGroupModel.SignatureProvider)}
+		updateExpression = updateExpression + " #SP = :sp, "
+	}
+
+	// An update to the co-author policy
+	if claGroupModel.CoAuthorPolicy != "" && claGroupModel.CoAuthorPolicy != existingCLAGroup.CoAuthorPolicy {
+		log.WithFields(f).Debugf("adding co_author_policy: %s", claGroupModel.CoAuthorPolicy)
+		expressionAttributeNames["#CAP"] = aws.String("co_author_policy")
+		expressionAttributeValues[":cap"] = &dynamodb.AttributeValue{S: aws.String(claGroupModel.CoAuthorPolicy)}
+		updateExpression = updateExpression + " #CAP = :cap, "
+	}
+
+	// An update to the bot allowlist - an empty allowlist clears the exempt bots
+	if claGroupModel.BotAllowlist != nil {
+		log.WithFields(f).Debugf("adding bot_login_allowlist: %v and bot_email_allowlist: %v", claGroupModel.BotAllowlist.Logins, claGroupModel.BotAllowlist.EmailPatterns)
+		expressionAttributeNames["#BLA"] = aws.String("bot_login_allowlist")
+		expressionAttributeValues[":bla"] = utils.StringListAttribute(claGroupModel.BotAllowlist.Logins)
+		expressionAttributeNames["#BEA"] = aws.String("bot_email_allowlist")
+		expressionAttributeValues[":bea"] = utils.StringListAttribute(claGroupModel.BotAllowlist.EmailPatterns)
+		updateExpression = updateExpression + " #BLA = :bla, #BEA = :bea, "
+	}
+
 	// We'll update the date modified time
 	_, currentTimeString := utils.CurrentTime()
 	log.WithFields(f).Debugf("adding date_modified: %s", currentTimeString)
@@ -741,6 +785,12 @@ func (repo *repo) UpdateCLAGroup(ctx context.Context, claGroupModel *models.ClaG
 	expressionAttributeValues[":m"] = &dynamodb.AttributeValue{S: aws.String(currentTimeString)}
 	updateExpression = updateExpression + " #M = :m "
 
+	if removeSignatureExpiryDate {
+		log.WithFields(f).Debugf("removing signature_expiry_date: %s", existingCLAGroup.SignatureExpiryDate)
+		expressionAttributeNames["#SED"] = aws.String("signature_expiry_date")
+		updateExpression = updateExpression + " REMOVE #SED"
+	}
+
 	// Assemble the query input parameters
 	updateInput := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
@@ -904,6 +954,11 @@ func (repo *repo) buildCLAGroupModel(ctx context.Context, dbModel models2.DBProj
 		ProjectCCLARequiresICLA:      dbModel.ProjectCclaRequiresIclaSignature,
 		ProjectTemplateID:            dbModel.ProjectTemplateID,
 		ProjectLive:                  dbModel.ProjectLive,
+		SignatureVersionPolicy:       dbModel.SignatureVersionPolicy,
+		SignatureExpiryDate:          dbModel.SignatureExpiryDate,
+		SignatureProvider:            dbModel.SignatureProvider,
+		CoAuthorPolicy:               dbModel.CoAuthorPolicy,
+		BotAllowlist:                 buildBotAllowlistModel(dbModel),
 		ProjectCorporateDocuments:    common.BuildCLAGroupDocumentModels(dbModel.ProjectCorporateDocuments),
 		ProjectIndividualDocuments:   common.BuildCLAGroupDocumentModels(dbModel.ProjectIndividualDocuments),
 		ProjectMemberDocuments:       common.BuildCLAGroupDocumentModels(dbModel.ProjectMemberDocuments),
@@ -914,3 +969,14 @@ func (repo *repo) buildCLAGroupModel(ctx context.Context, dbModel models2.DBProj
 		Version:                      dbModel.Version,
 	}
 }
+
+// buildBotAllowlistModel returns the bot allowlist of the CLA Group or nil if no bots are exempt
+func buildBotAllowlistModel(dbModel models2.DBProjectModel) *models.BotAllowlist {
+	if len(dbModel.BotLoginAllowlist) == 0 && len(dbModel.BotEmailAllowlist) == 0 {
+		return nil
+	}
+	return &models.BotAllowlist{
+		Logins:        dbModel.BotLoginAllowlist,
+		EmailPatterns: dbModel.BotEmailAllowlist,
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/sirupsen/logrus"
+)
+
+// GetSignatureApprovalItems returns the approval list items of the signature
+func (repo repository) GetSignatureApprovalItems(ctx context.Context, signatureID string) ([]approvals.ApprovalItem, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.repository.GetSignatureApprovalItems",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	approvalItems, err := repo.approvalRepo.GetApprovalListBySignature(signatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signature approval list items")
+		return nil, err
+	}
+
+	return approvalItems, nil
+}
+
+// WithoutExpiredApprovals returns a copy of the corporate signature without the approval list entries which expired
+// at the specified time. The expired entries are removed from the signature by the approval expiry job, this covers
+// the time in between.
+func WithoutExpiredApprovals(cclaSignature *models.Signature, approvalItems []approvals.ApprovalItem, now time.Time) *models.Signature {
+	expired := make(map[string]map[string]bool)
+	for _, approvalItem := range approvalItems {
+		if !approvalItem.IsExpired(now) {
+			continue
+		}
+		criteria := approvalItem.NormalizedCriteria()
+		if expired[criteria] == nil {
+			expired[criteria] = make(map[string]bool)
+		}
+		expired[criteria][strings.ToLower(strings.TrimSpace(approvalItem.ApprovalName))] = true
+	}
+	if len(expired) == 0 {
+		return cclaSignature
+	}
+
+	filter := func(criteria string, entries []string) []string {
+		if len(expired[criteria]) == 0 {
+			return entries
+		}
+		var active []string
+		for _, entry := range entries {
+			if !expired[criteria][strings.ToLower(strings.TrimSpace(entry))] {
+				active = append(active, entry)
+			}
+		}
+		return active
+	}
+
+	activeSignature := *cclaSignature
+	activeSignature.EmailApprovalList = filter(utils.EmailApprovalCriteria, cclaSignature.EmailApprovalList)
+	activeSignature.DomainApprovalList = filter(utils.DomainApprovalCriteria, cclaSignature.DomainApprovalList)
+	activeSignature.GithubUsernameApprovalList = filter(utils.GithubUsernameApprovalCriteria, cclaSignature.GithubUsernameApprovalList)
+	activeSignature.GithubOrgApprovalList = filter(utils.GithubOrgApprovalCriteria, cclaSignature.GithubOrgApprovalList)
+	activeSignature.GitlabUsernameApprovalList = filter(utils.GitlabUsernameApprovalCriteria, cclaSignature.GitlabUsernameApprovalList)
+	activeSignature.GitlabOrgApprovalList = filter(utils.GitlabOrgApprovalCriteria, cclaSignature.GitlabOrgApprovalList)
+	return &activeSignature
+}
+
+// withoutExpiredApprovals loads the approval list items of the corporate signature and returns the signature without
+// the expired entries - the signature is returned as is if the items can't be loaded
+func (s service) withoutExpiredApprovals(ctx context.Context, cclaSignature *models.Signature) *models.Signature {
+	if s.repo == nil {
+		return cclaSignature
+	}
+	approvalItems, err := s.repo.GetSignatureApprovalItems(ctx, cclaSignature.SignatureID)
+	if err != nil {
+		return cclaSignature
+	}
+	return WithoutExpiredApprovals(cclaSignature, approvalItems, time.Now())
+}
@@ -0,0 +1,47 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+	"time"
+
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithoutExpiredApprovals(t *testing.T) {
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	cclaSignature := &v1Models.Signature{
+		SignatureID:                "sig-1",
+		EmailApprovalList:          []string{"intern@example.org", "employee@example.org"},
+		DomainApprovalList:         []string{"contractor.example.org"},
+		GithubUsernameApprovalList: []string{"Intern"},
+		GitlabUsernameApprovalList: []string{"intern"},
+	}
+	approvalItems := []approvals.ApprovalItem{
+		{ApprovalCriteria: utils.EmailApprovalCriteria, ApprovalName: "intern@example.org", Active: true, ExpiresAt: "2026-05-31T00:00:00Z"},
+		{ApprovalCriteria: utils.EmailApprovalCriteria, ApprovalName: "employee@example.org", Active: true},
+		// Legacy domain entries were recorded with the email criteria
+		{ApprovalCriteria: utils.EmailApprovalCriteria, ApprovalName: "contractor.example.org", Active: true, ExpiresAt: "2026-06-01T12:00:00Z"},
+		{ApprovalCriteria: utils.GithubUsernameApprovalCriteria, ApprovalName: "intern", Active: true, ExpiresAt: "2026-05-01T00:00:00Z"},
+		// Not expired yet
+		{ApprovalCriteria: utils.GitlabUsernameApprovalCriteria, ApprovalName: "intern", Active: true, ExpiresAt: "2026-07-01T00:00:00Z"},
+	}
+
+	activeSignature := WithoutExpiredApprovals(cclaSignature, approvalItems, now)
+	assert.Equal(t, []string{"employee@example.org"}, activeSignature.EmailApprovalList)
+	assert.Empty(t, activeSignature.DomainApprovalList)
+	assert.Empty(t, activeSignature.GithubUsernameApprovalList)
+	assert.Equal(t, []string{"intern"}, activeSignature.GitlabUsernameApprovalList)
+
+	// The original signature is left untouched
+	assert.Len(t, cclaSignature.EmailApprovalList, 2)
+	assert.Equal(t, "sig-1", activeSignature.SignatureID)
+
+	// Nothing expired - same signature
+	assert.Same(t, cclaSignature, WithoutExpiredApprovals(cclaSignature, approvalItems[1:2], now))
+}
@@ -0,0 +1,212 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// UpdateOpenPullRequests re-runs the CLA check on the open GitHub pull requests of the CLA Group repositories which
+// have at least one commit authored by one of the specified users. All open pull requests are updated when no users
+// are specified.
+func (s service) UpdateOpenPullRequests(ctx context.Context, claGroupID string, affectedUsers []*models.User) error {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.UpdateOpenPullRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"affectedUsers":  len(affectedUsers),
+	}
+	// Updating every open pull request of the CLA Group is a batch job, its requests wait for the rate limit
+	ctx = github.WithRateLimitWait(ctx)
+
+	claRepositories, err := s.repositoryService.GetRepositoriesByCLAGroup(ctx, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group repositories")
+		return err
+	}
+
+	updated := 0
+	for _, claRepository := range claRepositories {
+		if claRepository.RepositoryType != utils.GitHubType {
+			continue
+		}
+
+		githubOrg, githubOrgErr := s.githubOrgService.GetGitHubOrganizationByName(ctx, claRepository.RepositoryOrganizationName)
+		if githubOrgErr != nil || githubOrg == nil {
+			log.WithFields(f).WithError(githubOrgErr).Warnf("unable to lookup GitHub organization by name: %s - skipping repository: %s",
+				claRepository.RepositoryOrganizationName, claRepository.RepositoryName)
+			continue
+		}
+
+		githubRepository, ghErr := github.GetGitHubRepository(ctx, githubOrg.OrganizationInstallationID, claRepository.RepositoryExternalID)
+		if ghErr != nil || githubRepository == nil || githubRepository.Owner == nil {
+			log.WithFields(f).WithError(ghErr).Warnf("unable to get GitHub repository by ID: %d - skipping", claRepository.RepositoryExternalID)
+			continue
+		}
+		owner := utils.StringValue(githubRepository.Owner.Login)
+		repo := utils.StringValue(githubRepository.Name)
+
+		pullRequests, listErr := github.GetOpenPullRequests(ctx, githubOrg.OrganizationInstallationID, owner, repo)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warnf("unable to list open pull requests for %s/%s - skipping", owner, repo)
+			continue
+		}
+
+		for _, pullRequest := range pullRequests {
+			pullRequestID := pullRequest.GetNumber()
+			if len(affectedUsers) > 0 {
+				authors, _, authorsErr := github.GetPullRequestCommitAuthors(ctx, githubOrg.OrganizationInstallationID, pullRequestID, owner, repo)
+				if authorsErr != nil {
+					log.WithFields(f).WithError(authorsErr).Warnf("unable to get commit authors for %s/%s PR: %d - skipping", owner, repo, pullRequestID)
+					continue
+				}
+				if !commitAuthorsContainUsers(authors, affectedUsers) {
+					continue
+				}
+			}
+
+			log.WithFields(f).Debugf("updating CLA status for %s/%s PR: %d", owner, repo, pullRequestID)
+			if updateErr := s.updateChangeRequest(ctx, githubOrg, claRepository.RepositoryExternalID, int64(pullRequestID), claGroupID); updateErr != nil {
+				log.WithFields(f).WithError(updateErr).Warnf("unable to update %s/%s PR: %d", owner, repo, pullRequestID)
+				continue
+			}
+			updated++
+		}
+	}
+
+	log.WithFields(f).Debugf("updated %d open pull requests", updated)
+	return nil
+}
+
+// UpdateMergeGroupCheck runs the CLA check on the commit authors of the pull request added to a merge queue and
+// reports the result as a check run on the merge group head commit
+func (s service) UpdateMergeGroupCheck(ctx context.Context, installationID, repositoryID int64, owner, repo string, pullRequestID int, headSHA, claGroupID string) error {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.UpdateMergeGroupCheck",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"installationID": installationID,
+		"repositoryID":   repositoryID,
+		"owner":          owner,
+		"repo":           repo,
+		"pullRequestID":  pullRequestID,
+		"headSHA":        headSHA,
+		"claGroupID":     claGroupID,
+	}
+
+	authors, _, authorsErr := github.GetPullRequestCommitAuthors(ctx, installationID, pullRequestID, owner, repo)
+	if authorsErr != nil {
+		log.WithFields(f).WithError(authorsErr).Warnf("unable to get commit authors for %s/%s PR: %d", owner, repo, pullRequestID)
+		return authorsErr
+	}
+
+	githubOrg, githubOrgErr := s.githubOrgService.GetGitHubOrganizationByName(ctx, owner)
+	if githubOrgErr != nil {
+		log.WithFields(f).WithError(githubOrgErr).Warnf("unable to lookup GitHub organization by name: %s - skipping its bot allowlist", owner)
+	}
+	authors, exempt := github.SplitExemptBotAuthors(authors, s.gitHubBotAllowlist(ctx, claGroupID, githubOrg))
+	s.logBotExemptions(ctx, claGroupID, fmt.Sprintf("%s/%s", owner, repo), int64(pullRequestID), exempt)
+
+	signed, unsigned, triageErr := s.gitHubAuthorTriage.TriageGitHubAuthors(ctx, claGroupID, authors)
+	if triageErr != nil {
+		log.WithFields(f).WithError(triageErr).Warnf("unable to evaluate the commit authors of %s/%s PR: %d", owner, repo, pullRequestID)
+		return triageErr
+	}
+	log.WithFields(f).Debugf("merge group commit authors status => signed: %d, missing: %d and exempt: %d", len(signed), len(unsigned), len(exempt))
+
+	return github.CreateCLACheckRun(ctx, installationID, pullRequestID, owner, repo, repositoryID, headSHA, signed, unsigned, exempt, s.claBaseAPIURL, s.claLandingPage)
+}
+
+// gitHubBotAllowlist returns the union of the CLA Group and the GitHub organization bot allowlists - an allowlist which
+// can't be loaded is skipped
+func (s service) gitHubBotAllowlist(ctx context.Context, claGroupID string, githubOrg *models.GithubOrganization) *utils.BotAllowlist {
+	f := logrus.Fields{
+		"functionName":   "v1.signatures.service.gitHubBotAllowlist",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	var claGroupAllowlist, orgAllowlist *utils.BotAllowlist
+	claGroup, claGroupErr := s.claGroupService.GetCLAGroupByID(ctx, claGroupID)
+	if claGroupErr != nil || claGroup == nil {
+		log.WithFields(f).WithError(claGroupErr).Warn("unable to load the CLA Group bot allowlist")
+	} else {
+		claGroupAllowlist = utils.BotAllowlistFromV1Model(claGroup.BotAllowlist)
+	}
+	if githubOrg != nil {
+		orgAllowlist = utils.BotAllowlistFromV1Model(githubOrg.BotAllowlist)
+	}
+	return utils.MergeBotAllowlists(claGroupAllowlist, orgAllowlist)
+}
+
+// logBotExemptions logs an event for each commit author exempt from the CLA check by the bot allowlist
+func (s service) logBotExemptions(ctx context.Context, claGroupID, repositoryName string, pullRequestID int64, exempt []*github.UserCommitSummary) {
+	for _, summary := range exempt {
+		email := summary.GetCommitAuthorEmail()
+		if email == "" {
+			email = summary.GitEmail
+		}
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:  events.CLACheckBotExempted,
+			CLAGroupID: claGroupID,
+			UserID:     "easycla system",
+			UserName:   "easycla system",
+			EventData: &events.CLACheckBotExemptedEventData{
+				Provider:        utils.GitHubType,
+				RepositoryName:  repositoryName,
+				ChangeRequestID: strconv.FormatInt(pullRequestID, 10),
+				Login:           summary.GetCommitAuthorUsername(),
+				Email:           email,
+				AllowlistEntry:  summary.ExemptBy,
+			},
+		})
+	}
+}
+
+// commitAuthorsContainUsers returns true if any of the commit authors matches one of the users by GitHub ID, GitHub
+// username or email
+func commitAuthorsContainUsers(authors []*github.UserCommitSummary, users []*models.User) bool {
+	for _, author := range authors {
+		authorID := author.GetCommitAuthorID()
+		authorUsername := author.GetCommitAuthorUsername()
+		authorEmail := author.GetCommitAuthorEmail()
+		for _, user := range users {
+			if user == nil {
+				continue
+			}
+			if authorID != "" && authorID == user.GithubID {
+				return true
+			}
+			if authorUsername != "" && strings.EqualFold(authorUsername, user.GithubUsername) {
+				return true
+			}
+			if authorEmail != "" && userHasEmail(user, authorEmail) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// userHasEmail returns true if the email is one of the user emails
+func userHasEmail(user *models.User, email string) bool {
+	if strings.EqualFold(user.LfEmail.String(), email) {
+		return true
+	}
+	for _, userEmail := range user.Emails {
+		if strings.EqualFold(userEmail, email) {
+			return true
+		}
+	}
+	return false
+}
@@ -26,3 +26,15 @@ const SignatureUserGitHubUsername = "user_github_username"
 
 // SignatureUserGitlabUsername is the name of the signature column for user gitlab username
 const SignatureUserGitlabUsername = "user_gitlab_username"
+
+// SignatureRevokedReasonColumn is the name of the signature column for the revocation reason code
+const SignatureRevokedReasonColumn = "signature_revoked_reason"
+
+// SignatureRevokedDateColumn is the name of the signature column for the revocation effective date
+const SignatureRevokedDateColumn = "signature_revoked_date"
+
+// SignatureRevokedByColumn is the name of the signature column for the username who revoked the signature
+const SignatureRevokedByColumn = "signature_revoked_by"
+
+// SignatureRevocationPendingColumn is the name of the signature column flagging a revocation with a future effective date
+const SignatureRevocationPendingColumn = "signature_revocation_pending"
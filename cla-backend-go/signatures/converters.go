@@ -105,6 +105,14 @@ func (repo repository) buildProjectSignatureModels(ctx context.Context, results
 			SignatureReturnURL:            dbSignature.SignatureReturnURL,
 			SignatureReturnURLType:        dbSignature.SignatureReturnURLType,
 			SignatureEnvelopeID:           dbSignature.SignatureEnvelopeID,
+			SignatureProvider:             dbSignature.SignatureProvider,
+			SignatureLocale:               dbSignature.SignatureLocale,
+			SignatureResignVersion:        dbSignature.SignatureResignVersion,
+			SignatureAccessRemovedVersion: dbSignature.SignatureAccessRemovedVersion,
+			SignatureRevokedReason:        dbSignature.SignatureRevokedReason,
+			SignatureRevokedDate:          dbSignature.SignatureRevokedDate,
+			SignatureRevokedBy:            dbSignature.SignatureRevokedBy,
+			SignatureRevocationPending:    dbSignature.SignatureRevocationPending,
 		}
 
 		sigs = append(sigs, sig)
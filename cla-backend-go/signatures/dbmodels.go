@@ -24,6 +24,14 @@ type ItemSignature struct {
 	SignatureReferenceType        string   `json:"signature_reference_type,omitempty"`
 	SignatureType                 string   `json:"signature_type,omitempty"`
 	SignatureEnvelopeID           string   `json:"signature_envelope_id,omitempty"`
+	SignatureProvider             string   `json:"signature_provider,omitempty"`
+	SignatureLocale               string   `json:"signature_locale,omitempty"`
+	SignatureResignVersion        string   `json:"signature_resign_version,omitempty"`
+	SignatureAccessRemovedVersion string   `json:"signature_access_removed_version,omitempty"`
+	SignatureRevokedReason        string   `json:"signature_revoked_reason,omitempty"`
+	SignatureRevokedDate          string   `json:"signature_revoked_date,omitempty"`
+	SignatureRevokedBy            string   `json:"signature_revoked_by,omitempty"`
+	SignatureRevocationPending    bool     `json:"signature_revocation_pending,omitempty"`
 	SignatureUserCompanyID        string   `json:"signature_user_ccla_company_id,omitempty"`
 	EmailApprovalList             []string `json:"email_whitelist,omitempty"`
 	EmailDomainApprovalList       []string `json:"domain_whitelist,omitempty"`
@@ -17,6 +17,7 @@ import (
 	models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	signatures "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
 	signatures0 "github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	approvals "github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
 )
 
 // MockSignatureRepository is a mock of SignatureRepository interface.
@@ -395,6 +396,21 @@ func (mr *MockSignatureRepositoryMockRecorder) GetItemSignature(ctx, signatureID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetItemSignature", reflect.TypeOf((*MockSignatureRepository)(nil).GetItemSignature), ctx, signatureID)
 }
 
+// GetPendingRevocationSignatures mocks base method.
+func (m *MockSignatureRepository) GetPendingRevocationSignatures(ctx context.Context, effectiveBefore string) ([]signatures0.ItemSignature, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPendingRevocationSignatures", ctx, effectiveBefore)
+	ret0, _ := ret[0].([]signatures0.ItemSignature)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPendingRevocationSignatures indicates an expected call of GetPendingRevocationSignatures.
+func (mr *MockSignatureRepositoryMockRecorder) GetPendingRevocationSignatures(ctx, effectiveBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingRevocationSignatures", reflect.TypeOf((*MockSignatureRepository)(nil).GetPendingRevocationSignatures), ctx, effectiveBefore)
+}
+
 // GetProjectCompanyEmployeeSignature mocks base method.
 func (m *MockSignatureRepository) GetProjectCompanyEmployeeSignature(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, employeeUserModel *models.User, wg *sync.WaitGroup, resultChannel chan<- *signatures0.EmployeeModel, errorChannel chan<- error) {
 	m.ctrl.T.Helper()
@@ -482,6 +498,21 @@ func (mr *MockSignatureRepositoryMockRecorder) GetSignature(ctx, signatureID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignature", reflect.TypeOf((*MockSignatureRepository)(nil).GetSignature), ctx, signatureID)
 }
 
+// GetSignatureApprovalItems mocks base method.
+func (m *MockSignatureRepository) GetSignatureApprovalItems(ctx context.Context, signatureID string) ([]approvals.ApprovalItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSignatureApprovalItems", ctx, signatureID)
+	ret0, _ := ret[0].([]approvals.ApprovalItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSignatureApprovalItems indicates an expected call of GetSignatureApprovalItems.
+func (mr *MockSignatureRepositoryMockRecorder) GetSignatureApprovalItems(ctx, signatureID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSignatureApprovalItems", reflect.TypeOf((*MockSignatureRepository)(nil).GetSignatureApprovalItems), ctx, signatureID)
+}
+
 // GetSignatureACL mocks base method.
 func (m *MockSignatureRepository) GetSignatureACL(ctx context.Context, signatureID string) ([]string, error) {
 	m.ctrl.T.Helper()
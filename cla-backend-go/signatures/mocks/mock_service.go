@@ -490,6 +490,34 @@ func (mr *MockSignatureServiceMockRecorder) UpdateEnvelopeDetails(ctx, signature
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEnvelopeDetails", reflect.TypeOf((*MockSignatureService)(nil).UpdateEnvelopeDetails), ctx, signatureID, envelopeID, signURL)
 }
 
+// UpdateMergeGroupCheck mocks base method.
+func (m *MockSignatureService) UpdateMergeGroupCheck(ctx context.Context, installationID, repositoryID int64, owner, repo string, pullRequestID int, headSHA, claGroupID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateMergeGroupCheck", ctx, installationID, repositoryID, owner, repo, pullRequestID, headSHA, claGroupID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateMergeGroupCheck indicates an expected call of UpdateMergeGroupCheck.
+func (mr *MockSignatureServiceMockRecorder) UpdateMergeGroupCheck(ctx, installationID, repositoryID, owner, repo, pullRequestID, headSHA, claGroupID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateMergeGroupCheck", reflect.TypeOf((*MockSignatureService)(nil).UpdateMergeGroupCheck), ctx, installationID, repositoryID, owner, repo, pullRequestID, headSHA, claGroupID)
+}
+
+// UpdateOpenPullRequests mocks base method.
+func (m *MockSignatureService) UpdateOpenPullRequests(ctx context.Context, claGroupID string, affectedUsers []*models.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOpenPullRequests", ctx, claGroupID, affectedUsers)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOpenPullRequests indicates an expected call of UpdateOpenPullRequests.
+func (mr *MockSignatureServiceMockRecorder) UpdateOpenPullRequests(ctx, claGroupID, affectedUsers interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOpenPullRequests", reflect.TypeOf((*MockSignatureService)(nil).UpdateOpenPullRequests), ctx, claGroupID, affectedUsers)
+}
+
 // UpdateSignature mocks base method.
 func (m *MockSignatureService) UpdateSignature(ctx context.Context, signatureID string, updates map[string]interface{}) error {
 	m.ctrl.T.Helper()
@@ -40,6 +40,15 @@ func buildProjection() expression.ProjectionBuilder {
 		expression.Name("user_docusign_date_signed"),
 		expression.Name("user_docusign_name"),
 		expression.Name("auto_create_ecla"),
+		expression.Name("signature_resign_version"),
+		expression.Name("signature_access_removed_version"),
+		expression.Name("signature_envelope_id"),
+		expression.Name("signature_provider"),
+		expression.Name("signature_locale"),
+		expression.Name(SignatureRevokedReasonColumn),
+		expression.Name(SignatureRevokedDateColumn),
+		expression.Name(SignatureRevokedByColumn),
+		expression.Name(SignatureRevocationPendingColumn),
 	)
 }
 
@@ -76,6 +76,7 @@ type SignatureRepository interface {
 	SaveOrUpdateSignature(ctx context.Context, signature *ItemSignature) error
 
 	GetSignature(ctx context.Context, signatureID string) (*models.Signature, error)
+	GetSignatureApprovalItems(ctx context.Context, signatureID string) ([]approvals.ApprovalItem, error)
 	GetItemSignature(ctx context.Context, signatureID string) (*ItemSignature, error)
 	GetActivePullRequestMetadata(ctx context.Context, gitHubAuthorUsername, gitHubAuthorEmail string) (*ActivePullRequest, error)
 	GetIndividualSignature(ctx context.Context, claGroupID, userID string, approved, signed *bool) (*models.Signature, error)
@@ -106,6 +107,7 @@ type SignatureRepository interface {
 	EclaAutoCreate(ctx context.Context, signatureID string, autoCreateECLA bool) error
 	ActivateSignature(ctx context.Context, signatureID string) error
 	GetICLAByDate(ctx context.Context, startDate string) ([]ItemSignature, error)
+	GetPendingRevocationSignatures(ctx context.Context, effectiveBefore string) ([]ItemSignature, error)
 }
 
 type iclaSignatureWithDetails struct {
@@ -3262,12 +3264,12 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		log.WithFields(f).Debugf("updating approval list table")
 
 		if params.AddEmailApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddEmailApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTable(ctx, params.AddEmailApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true, params.ExpiresAt)
 		}
 
 		// if email removal update signature approvals
 		if params.RemoveEmailApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddEmailApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTable(ctx, params.AddEmailApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false, params.ExpiresAt)
 			log.WithFields(f).Debugf("removing email: %+v the approval list", params.RemoveDomainApprovalList)
 			var wg sync.WaitGroup
 			wg.Add(len(params.RemoveEmailApprovalList))
@@ -3386,8 +3388,9 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		log.WithFields(f).Debugf("updating approval list table")
+		// Domain entries are recorded with the email criteria, as the existing records are - the readers normalize them
 		if params.AddDomainApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddDomainApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTable(ctx, params.AddDomainApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true, params.ExpiresAt)
 		}
 
 		if params.RemoveDomainApprovalList != nil {
@@ -3427,7 +3430,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			}
 
 			repo.invalidateSignatures(ctx, &approvalList, claManager, eventArgs)
-			repo.updateApprovalTable(ctx, params.AddDomainApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTable(ctx, params.AddDomainApprovalList, utils.EmailApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false, params.ExpiresAt)
 		}
 	}
 
@@ -3452,11 +3455,11 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		if params.AddGithubUsernameApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGithubUsernameApprovalList, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTable(ctx, params.AddGithubUsernameApprovalList, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true, params.ExpiresAt)
 		}
 		if params.RemoveGithubUsernameApprovalList != nil {
 
-			repo.updateApprovalTable(ctx, params.AddGithubUsernameApprovalList, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTable(ctx, params.AddGithubUsernameApprovalList, utils.GithubUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false, params.ExpiresAt)
 			// if email removal update signature approvals
 			if params.RemoveGithubUsernameApprovalList != nil {
 				var wg sync.WaitGroup
@@ -3541,7 +3544,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		if params.AddGithubOrgApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGithubOrgApprovalList, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTable(ctx, params.AddGithubOrgApprovalList, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true, params.ExpiresAt)
 		}
 
 		if params.RemoveGithubOrgApprovalList != nil {
@@ -3588,7 +3591,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			approvalList.GitHubUsernames = utils.RemoveDuplicates(ghUsernames)
 
 			repo.invalidateSignatures(ctx, &approvalList, claManager, eventArgs)
-			repo.updateApprovalTable(ctx, params.AddGithubOrgApprovalList, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTable(ctx, params.AddGithubOrgApprovalList, utils.GithubOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false, params.ExpiresAt)
 		}
 	}
 
@@ -3612,10 +3615,10 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 			updateExpression = updateExpression + " #GLU = :glu, "
 		}
 		if params.AddGitlabUsernameApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabUsernameApprovalList, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTable(ctx, params.AddGitlabUsernameApprovalList, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true, params.ExpiresAt)
 		}
 		if params.RemoveGitlabUsernameApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabUsernameApprovalList, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTable(ctx, params.AddGitlabUsernameApprovalList, utils.GitlabUsernameApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false, params.ExpiresAt)
 			// if email removal update signature approvals
 			if params.RemoveGitlabUsernameApprovalList != nil {
 				approvalList.Criteria = utils.GitlabUsernameCriteria
@@ -3702,11 +3705,11 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 		}
 
 		if params.AddGitlabOrgApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabOrgApprovalList, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true)
+			repo.updateApprovalTable(ctx, params.AddGitlabOrgApprovalList, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, true, params.ExpiresAt)
 		}
 
 		if params.RemoveGitlabOrgApprovalList != nil {
-			repo.updateApprovalTable(ctx, params.AddGitlabOrgApprovalList, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false)
+			repo.updateApprovalTable(ctx, params.AddGitlabOrgApprovalList, utils.GitlabOrgApprovalCriteria, signatureID, projectID, companyID, cclaSignature.SignatureReferenceName, false, params.ExpiresAt)
 			approvalList.Criteria = utils.GitlabOrgCriteria
 			approvalList.ApprovalList = params.RemoveGitlabOrgApprovalList
 			approvalList.Action = utils.RemoveApprovals
@@ -3794,7 +3797,7 @@ func (repo repository) UpdateApprovalList(ctx context.Context, claManager *model
 	return updatedSig, nil
 }
 
-func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []string, criteria, signatureID, projectID, companyID, companyName string, add bool) {
+func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []string, criteria, signatureID, projectID, companyID, companyName string, add bool, expiresAt string) {
 	f := logrus.Fields{
 		"functionName":   "v1.signatures.repository.addApprovalList",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -3824,6 +3827,8 @@ func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []
 				approvalItem.DateModified = currentTime
 				approvalItem.DateAdded = currentTime
 				approvalItem.Active = true
+				approvalItem.ExpiresAt = expiresAt
+				approvalItem.ExpiryNotified = false
 			} else {
 				log.WithFields(f).Debugf("approval request for item: %s with criteria: %s already exists", item, criteria)
 				approvalItem.DateModified = currentTime
@@ -3856,6 +3861,7 @@ func (repo *repository) updateApprovalTable(ctx context.Context, approvalList []
 		if add {
 			approvalItem.Active = true
 			approvalItem.DateAdded = currentTime
+			approvalItem.ExpiresAt = expiresAt
 			approvalItem.Note = "Auto-Added"
 		} else {
 			approvalItem.Active = false
@@ -4066,8 +4072,7 @@ func (repo repository) verifyUserApprovals(ctx context.Context, userID, signatur
 	if approvalList.Criteria == utils.EmailDomainCriteria {
 		// Handle Domains
 		log.WithFields(f).Debugf("Handling domain for user email: %s  with approval list: %+v ", email, approvalList.ApprovalList)
-		domain := strings.Split(email, "@")[1]
-		if utils.StringInSlice(domain, approvalList.ApprovalList) {
+		if _, matched := utils.EmailsMatchDomainPatterns([]string{email}, approvalList.ApprovalList); matched {
 			if (!utils.StringInSlice(user.GithubUsername, approvalList.GitHubUsernameApprovals) || utils.StringInSlice(user.LfUsername, approvalList.GerritICLAECLAs)) && !utils.StringInSlice(email, approvalList.EmailApprovals) {
 				//Invalidate record
 				note := fmt.Sprintf("Signature invalidated (approved set to false) by %s due to %s  removal", utils.GetBestUsername(claManager), utils.EmailDomainCriteria)
@@ -4518,6 +4523,62 @@ func (repo repository) GetICLAByDate(ctx context.Context, startDate string) ([]I
 	return signatures, nil
 }
 
+// GetPendingRevocationSignatures returns the signatures with a pending revocation effective on or before the specified
+// date and time
+func (repo repository) GetPendingRevocationSignatures(ctx context.Context, effectiveBefore string) ([]ItemSignature, error) {
+	f := logrus.Fields{
+		"functionName":    "v1.signatures.repository.GetPendingRevocationSignatures",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"effectiveBefore": effectiveBefore,
+	}
+
+	log.WithFields(f).Debug("querying for signatures with a pending revocation...")
+
+	// The revocation dates are stored in the RFC3339 UTC format which sorts chronologically
+	filter := expression.Name(SignatureRevocationPendingColumn).Equal(expression.Value(true)).
+		And(expression.Name(SignatureRevokedDateColumn).LessThanEqual(expression.Value(effectiveBefore)))
+
+	expr, err := expression.NewBuilder().WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression for the pending revocations query: %v", err)
+		return nil, err
+	}
+
+	var signatures []ItemSignature
+	var lastEvaluatedKey map[string]*dynamodb.AttributeValue
+	for {
+		scanInput := &dynamodb.ScanInput{
+			ExpressionAttributeNames:  expr.Names(),
+			ExpressionAttributeValues: expr.Values(),
+			FilterExpression:          expr.Filter(),
+			TableName:                 aws.String(repo.signatureTableName),
+			ExclusiveStartKey:         lastEvaluatedKey,
+		}
+
+		result, scanErr := repo.dynamoDBClient.Scan(scanInput)
+		if scanErr != nil {
+			log.WithFields(f).Warnf("error retrieving the signatures with a pending revocation: %v", scanErr)
+			return nil, scanErr
+		}
+
+		var dbSignatures []ItemSignature
+		unmarshallError := dynamodbattribute.UnmarshalListOfMaps(result.Items, &dbSignatures)
+		if unmarshallError != nil {
+			log.WithFields(f).Warnf("error unmarshalling the signatures with a pending revocation: %v", unmarshallError)
+			return nil, unmarshallError
+		}
+		signatures = append(signatures, dbSignatures...)
+
+		if result.LastEvaluatedKey == nil {
+			break
+		}
+		lastEvaluatedKey = result.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Debugf("retrieved %d signatures with a pending revocation", len(signatures))
+	return signatures, nil
+}
+
 func (repo repository) getIntermediateICLAResponse(f logrus.Fields, dbSignatures []ItemSignature) []*iclaSignatureWithDetails {
 	var intermediateResponse []*iclaSignatureWithDetails
 
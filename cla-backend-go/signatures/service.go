@@ -7,13 +7,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"regexp"
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	gitlab_api "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
 	service2 "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/github"
@@ -59,6 +60,8 @@ type SignatureService interface {
 	UpdateSignature(ctx context.Context, signatureID string, updates map[string]interface{}) error
 	SaveOrUpdateSignature(ctx context.Context, signature *ItemSignature) error
 	HasUserSigned(ctx context.Context, user *models.User, projectID string) (*bool, *bool, error)
+	UpdateOpenPullRequests(ctx context.Context, claGroupID string, affectedUsers []*models.User) error
+	UpdateMergeGroupCheck(ctx context.Context, installationID, repositoryID int64, owner, repo string, pullRequestID int, headSHA, claGroupID string) error
 
 	GetGithubOrganizationsFromApprovalList(ctx context.Context, signatureID string, githubAccessToken string) ([]models.GithubOrg, error)
 	AddGithubOrganizationToApprovalList(ctx context.Context, signatureID string, approvalListParams models.GhOrgWhitelist, githubAccessToken string) ([]models.GithubOrg, error)
@@ -80,6 +83,12 @@ type SignatureService interface {
 	UserIsApproved(ctx context.Context, user *models.User, cclaSignature *models.Signature) (bool, error)
 }
 
+// GitHubAuthorTriage splits the GitHub commit authors of a pull request into the authors covered by the CLA Group and
+// the authors missing a CLA - implemented by the CLA check shared by the repository providers
+type GitHubAuthorTriage interface {
+	TriageGitHubAuthors(ctx context.Context, claGroupID string, authors []*github.UserCommitSummary) ([]*github.UserCommitSummary, []*github.UserCommitSummary, error)
+}
+
 type service struct {
 	repo                SignatureRepository
 	companyService      company.IService
@@ -93,10 +102,11 @@ type service struct {
 	claBaseAPIURL       string
 	claLandingPage      string
 	claLogoURL          string
+	gitHubAuthorTriage  GitHubAuthorTriage
 }
 
 // NewService creates a new signature service
-func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, repositoryService repositories.Service, githubOrgService github_organizations.ServiceInterface, claGroupService service2.Service, gitLabApp *gitlab_api.App, CLABaseAPIURL, CLALandingPage, CLALogoURL string) SignatureService {
+func NewService(repo SignatureRepository, companyService company.IService, usersService users.Service, eventsService events.Service, githubOrgValidation bool, repositoryService repositories.Service, githubOrgService github_organizations.ServiceInterface, claGroupService service2.Service, gitLabApp *gitlab_api.App, CLABaseAPIURL, CLALandingPage, CLALogoURL string, gitHubAuthorTriage GitHubAuthorTriage) SignatureService {
 	return service{
 		repo,
 		companyService,
@@ -110,6 +120,7 @@ func NewService(repo SignatureRepository, companyService company.IService, users
 		CLABaseAPIURL,
 		CLALandingPage,
 		CLALogoURL,
+		gitHubAuthorTriage,
 	}
 }
 
@@ -501,6 +512,17 @@ func (s service) UpdateApprovalList(ctx context.Context, authUser *auth.User, cl
 		return nil, NewForbiddenError(msg)
 	}
 
+	// Time-boxed entries must expire in the future - normalize to UTC so expiry lookups can compare the strings
+	if params.ExpiresAt != "" {
+		expiresAt, parseErr := time.Parse(time.RFC3339, params.ExpiresAt)
+		if parseErr != nil || !expiresAt.After(time.Now()) {
+			msg := fmt.Sprintf("invalid approval list expiry date time: %s - expecting a RFC3339 date time in the future", params.ExpiresAt)
+			log.WithFields(f).Warn(msg)
+			return nil, NewBadRequestError(msg)
+		}
+		params.ExpiresAt = utils.TimeToString(expiresAt)
+	}
+
 	// Lookup the user making the request - should be the CLA Manager
 	userModel, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
 	if userErr != nil {
@@ -1029,94 +1051,23 @@ func (s service) updateChangeRequest(ctx context.Context, ghOrg *models.GithubOr
 	}
 	log.WithFields(f).Debugf("found %d commit authors for %s/%s for PR: %d", len(authors), gitHubOrgName, gitHubRepoName, pullRequestID)
 
-	signed := make([]*github.UserCommitSummary, 0)
-	unsigned := make([]*github.UserCommitSummary, 0)
+	// bots of the CLA Group and GitHub organization allowlists are exempt from the CLA check
+	authors, exempt := github.SplitExemptBotAuthors(authors, s.gitHubBotAllowlist(ctx, projectID, ghOrg))
+	s.logBotExemptions(ctx, projectID, fmt.Sprintf("%s/%s", gitHubOrgName, gitHubRepoName), pullRequestID, exempt)
 
 	// triage signed and unsigned users
 	log.WithFields(f).Debugf("triaging %d commit authors for PR: %d using repository %s/%s",
 		len(authors), pullRequestID, gitHubOrgName, gitHubRepoName)
-	for _, userSummary := range authors {
-
-		if !userSummary.IsValid() {
-			log.WithFields(f).Debugf("invalid user summary: %+v", *userSummary)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		commitAuthorID := userSummary.GetCommitAuthorID()
-		commitAuthorUsername := userSummary.GetCommitAuthorUsername()
-		commitAuthorEmail := userSummary.GetCommitAuthorEmail()
-
-		log.WithFields(f).Debugf("checking user - sha: %s, user ID: %s, username: %s, email: %s",
-			userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
-
-		var user *models.User
-		var userErr error
-
-		if commitAuthorID != "" {
-			log.WithFields(f).Debugf("looking up user by ID: %s", commitAuthorID)
-			user, userErr = s.usersService.GetUserByGitHubID(commitAuthorID)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github id: %s", commitAuthorID)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by ID: %s", commitAuthorID)
-			}
-		}
-		if user == nil && commitAuthorUsername != "" {
-			log.WithFields(f).Debugf("looking up user by username: %s", commitAuthorUsername)
-			user, userErr = s.usersService.GetUserByGitHubUsername(commitAuthorUsername)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github username: %s", commitAuthorUsername)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by username: %s", commitAuthorUsername)
-			}
-		}
-		if user == nil && commitAuthorEmail != "" {
-			log.WithFields(f).Debugf("looking up user by email: %s", commitAuthorEmail)
-			user, userErr = s.usersService.GetUserByEmail(commitAuthorEmail)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by user email: %s", commitAuthorEmail)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by email: %s", commitAuthorEmail)
-			}
-		}
-
-		if user == nil {
-			log.WithFields(f).Debugf("unable to find user for commit author - sha: %s, user ID: %s, username: %s, email: %s",
-				userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		log.WithFields(f).Debugf("checking to see if user has signed an ICLA or ECLA for project: %s", projectID)
-		userSigned, companyAffiliation, signedErr := s.HasUserSigned(ctx, user, projectID)
-		if signedErr != nil {
-			log.WithFields(f).WithError(signedErr).Warnf("has user signed error - user: %+v, project: %s", user, projectID)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		if companyAffiliation != nil {
-			userSummary.Affiliated = *companyAffiliation
-		}
-
-		if userSigned != nil {
-			userSummary.Authorized = *userSigned
-			if userSummary.Authorized {
-				signed = append(signed, userSummary)
-			} else {
-				unsigned = append(unsigned, userSummary)
-			}
-		}
+	signed, unsigned, triageErr := s.gitHubAuthorTriage.TriageGitHubAuthors(ctx, projectID, authors)
+	if triageErr != nil {
+		log.WithFields(f).WithError(triageErr).Warnf("unable to evaluate the commit authors of PR: %d", pullRequestID)
+		return triageErr
 	}
 
 	log.WithFields(f).Debugf("commit authors status => signed: %+v and missing: %+v", signed, unsigned)
 
 	// update pull request
-	updateErr := github.UpdatePullRequest(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.claBaseAPIURL, s.claLandingPage, s.claLogoURL)
+	updateErr := github.UpdatePullRequest(ctx, ghOrg.OrganizationInstallationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, exempt, s.claBaseAPIURL, s.claLandingPage, s.claLogoURL)
 	if updateErr != nil {
 		log.WithFields(f).Debugf("unable to update PR: %d", pullRequestID)
 		return updateErr
@@ -1150,9 +1101,19 @@ func (s service) HasUserSigned(ctx context.Context, user *models.User, projectID
 		return &hasSigned, &companyAffiliation, sigErr
 	}
 	if signature != nil {
-		hasSigned = true
-		log.WithFields(f).Debugf("ICLA signature check passed for user: %+v on project : %s", user, projectID)
-		return &hasSigned, &companyAffiliation, nil // ICLA passes, no company affiliation
+		// Make sure the ICLA document version is still honored by the CLA Group signature version policy
+		claGroupModel, claGroupModelErr := s.claGroupService.GetCLAGroupByID(ctx, projectID)
+		if claGroupModelErr != nil {
+			log.WithFields(f).WithError(claGroupModelErr).Warnf("problem looking up project: %s", projectID)
+			return &hasSigned, &companyAffiliation, claGroupModelErr
+		}
+		if common.IsSignatureVersionAccepted(ctx, claGroupModel, signature, time.Now()) {
+			hasSigned = true
+			log.WithFields(f).Debugf("ICLA signature check passed for user: %+v on project : %s", user, projectID)
+			return &hasSigned, &companyAffiliation, nil // ICLA passes, no company affiliation
+		}
+		log.WithFields(f).Debugf("ICLA signature check failed for user: %+v on project: %s - ICLA document version %s requires a re-sign",
+			user, projectID, signature.SignatureDocumentMajorVersion)
 	} else {
 		log.WithFields(f).Debugf("ICLA signature check failed for user: %+v on project: %s - ICLA not signed", user, projectID)
 	}
@@ -1238,6 +1199,12 @@ func (s service) ProcessEmployeeSignature(ctx context.Context, companyModel *mod
 					return &hasSigned, cclaErr
 				}
 
+				if cclaSignature != nil && !common.IsSignatureVersionAccepted(ctx, claGroupModel, cclaSignature, time.Now()) {
+					log.WithFields(f).Debugf("CCLA signature: %s document version %s requires a re-sign - ignoring signature",
+						cclaSignature.SignatureID, cclaSignature.SignatureDocumentMajorVersion)
+					cclaSignature = nil
+				}
+
 				if cclaSignature != nil {
 					log.WithFields(f).Debug("found ccla signature")
 					userApproved, approvedErr := s.UserIsApproved(ctx, user, cclaSignature)
@@ -1277,6 +1244,9 @@ func (s service) UserIsApproved(ctx context.Context, user *models.User, cclaSign
 
 	emails := user.Emails
 
+	// Time-boxed entries no longer count once expired
+	cclaSignature = s.withoutExpiredApprovals(ctx, cclaSignature)
+
 	if user.LfEmail != "" {
 		log.WithFields(f).Debugf("adding lf email: %s to emails", user.LfEmail)
 		emails = append(emails, string(user.LfEmail))
@@ -1330,11 +1300,8 @@ func (s service) UserIsApproved(ctx context.Context, user *models.User, cclaSign
 	// check domain email approval list
 	domainApprovalList := cclaSignature.DomainApprovalList
 	if len(domainApprovalList) > 0 {
-		matched, err := s.processPattern(emails, domainApprovalList)
-		if err != nil {
-			return false, err
-		}
-		if matched != nil && *matched {
+		if pattern, matched := utils.EmailsMatchDomainPatterns(emails, domainApprovalList); matched {
+			log.WithFields(f).Debugf("found matching domain approval pattern: %s", pattern)
 			return true, nil
 		}
 	}
@@ -1363,35 +1330,6 @@ func (s service) UserIsApproved(ctx context.Context, user *models.User, cclaSign
 	return false, nil
 }
 
-func (s service) processPattern(emails []string, patterns []string) (*bool, error) {
-	matched := false
-
-	for _, pattern := range patterns {
-		if strings.HasPrefix(pattern, "*.") {
-			pattern = strings.Replace(pattern, "*.", ".*", -1)
-		} else if strings.HasPrefix(pattern, "*") {
-			pattern = strings.Replace(pattern, "*", ".*", -1)
-		} else if strings.HasPrefix(pattern, ".") {
-			pattern = strings.Replace(pattern, ".", ".*", -1)
-		}
-
-		preProcessedPattern := fmt.Sprintf("^.*@%s$", pattern)
-		compiled, err := regexp.Compile(preProcessedPattern)
-		if err != nil {
-			return nil, err
-		}
-
-		for _, email := range emails {
-			if compiled.MatchString(email) {
-				matched = true
-				break
-			}
-		}
-	}
-
-	return &matched, nil
-}
-
 func (s service) handleGitHubStatusUpdate(ctx context.Context, employeeUserModel *models.User) error {
 	if employeeUserModel == nil {
 		return fmt.Errorf("employee user model is nil")
@@ -84,7 +84,7 @@ func TestUserIsApproved(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			service := NewService(nil, nil, nil, nil, false, nil, nil, nil, nil, "", "", "")
+			service := NewService(nil, nil, nil, nil, false, nil, nil, nil, nil, "", "", "", nil)
 
 			isApproved, err := service.UserIsApproved(ctx, tc.user, tc.cclaSignature)
 
@@ -0,0 +1,209 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
+const (
+	// diffContextLines is the number of unchanged lines shown around the changes
+	diffContextLines = 3
+	// maxDiffMatrixSize bounds the memory used by the line diff, larger bodies are shown as fully replaced
+	maxDiffMatrixSize = 4 * 1024 * 1024
+)
+
+// diffLine is a line of the edit script, kind is ' ' for an unchanged line, '-' for a removed line or '+' for an
+// added line
+type diffLine struct {
+	kind byte
+	text string
+}
+
+// diffTemplateVersions compares the two versions of the custom template
+func diffTemplateVersions(from, to *CustomTemplateVersion) *TemplateVersionDiff {
+	diff := &TemplateVersionDiff{
+		TemplateID:        to.TemplateID,
+		FromVersion:       from.Version,
+		ToVersion:         to.Version,
+		IclaDiff:          unifiedDiff(fmt.Sprintf("icla v%d", from.Version), fmt.Sprintf("icla v%d", to.Version), from.IclaHTMLBody, to.IclaHTMLBody),
+		CclaDiff:          unifiedDiff(fmt.Sprintf("ccla v%d", from.Version), fmt.Sprintf("ccla v%d", to.Version), from.CclaHTMLBody, to.CclaHTMLBody),
+		IclaFieldsChanged: !reflect.DeepEqual(from.IclaFields, to.IclaFields),
+		CclaFieldsChanged: !reflect.DeepEqual(from.CclaFields, to.CclaFields),
+	}
+
+	fromVariables := metaFieldVariables(from.MetaFields)
+	toVariables := metaFieldVariables(to.MetaFields)
+	for _, metaField := range to.MetaFields {
+		if !fromVariables[metaField.TemplateVariable] {
+			diff.MetaFieldsAdded = append(diff.MetaFieldsAdded, metaField.TemplateVariable)
+		}
+	}
+	for _, metaField := range from.MetaFields {
+		if !toVariables[metaField.TemplateVariable] {
+			diff.MetaFieldsRemoved = append(diff.MetaFieldsRemoved, metaField.TemplateVariable)
+		}
+	}
+
+	return diff
+}
+
+func metaFieldVariables(metaFields []*models.MetaField) map[string]bool {
+	variables := make(map[string]bool, len(metaFields))
+	for _, metaField := range metaFields {
+		variables[metaField.TemplateVariable] = true
+	}
+	return variables
+}
+
+// unifiedDiff returns the unified diff of the two texts, or an empty string if they are identical
+func unifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+
+	lines := diffLines(splitLines(from), splitLines(to))
+
+	// number of lines of each text before each line of the edit script, used for the hunk ranges
+	fromPos := make([]int, len(lines)+1)
+	toPos := make([]int, len(lines)+1)
+	for i, line := range lines {
+		fromPos[i+1], toPos[i+1] = fromPos[i], toPos[i]
+		if line.kind != '+' {
+			fromPos[i+1]++
+		}
+		if line.kind != '-' {
+			toPos[i+1]++
+		}
+	}
+
+	var out strings.Builder
+	fmt.Fprintf(&out, "--- %s\n+++ %s\n", fromName, toName)
+
+	previousEnd := 0
+	for i := 0; i < len(lines); {
+		for i < len(lines) && lines[i].kind == ' ' {
+			i++
+		}
+		if i == len(lines) {
+			break
+		}
+
+		start := i - diffContextLines
+		if start < previousEnd {
+			start = previousEnd
+		}
+
+		// extend the hunk while the next change is close enough to share the context lines
+		end := i
+		for {
+			changeEnd := end
+			for changeEnd < len(lines) && lines[changeEnd].kind != ' ' {
+				changeEnd++
+			}
+			nextChange := changeEnd
+			for nextChange < len(lines) && lines[nextChange].kind == ' ' {
+				nextChange++
+			}
+			if nextChange < len(lines) && nextChange-changeEnd <= 2*diffContextLines {
+				end = nextChange
+				continue
+			}
+			end = changeEnd + diffContextLines
+			if end > len(lines) {
+				end = len(lines)
+			}
+			break
+		}
+
+		writeHunk(&out, lines[start:end], fromPos[start], fromPos[end], toPos[start], toPos[end])
+		previousEnd = end
+		i = end
+	}
+
+	return out.String()
+}
+
+func writeHunk(out *strings.Builder, lines []diffLine, fromStart, fromEnd, toStart, toEnd int) {
+	fmt.Fprintf(out, "@@ -%s +%s @@\n", hunkRange(fromStart, fromEnd-fromStart), hunkRange(toStart, toEnd-toStart))
+	for _, line := range lines {
+		out.WriteByte(line.kind)
+		out.WriteString(line.text)
+		out.WriteByte('\n')
+	}
+}
+
+// hunkRange formats the hunk range, the start of an empty range is the line before the range
+func hunkRange(start, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", start)
+	}
+	return fmt.Sprintf("%d,%d", start+1, count)
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines returns the edit script transforming from into to, based on the longest common subsequence of lines
+func diffLines(from, to []string) []diffLine {
+	if len(from)*len(to) > maxDiffMatrixSize {
+		lines := make([]diffLine, 0, len(from)+len(to))
+		for _, line := range from {
+			lines = append(lines, diffLine{kind: '-', text: line})
+		}
+		for _, line := range to {
+			lines = append(lines, diffLine{kind: '+', text: line})
+		}
+		return lines
+	}
+
+	// lcs[i][j] is the length of the longest common subsequence of from[i:] and to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if from[i] == to[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(from)+len(to))
+	i, j := 0, 0
+	for i < len(from) && j < len(to) {
+		switch {
+		case from[i] == to[j]:
+			lines = append(lines, diffLine{kind: ' ', text: from[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			lines = append(lines, diffLine{kind: '-', text: from[i]})
+			i++
+		default:
+			lines = append(lines, diffLine{kind: '+', text: to[j]})
+			j++
+		}
+	}
+	for ; i < len(from); i++ {
+		lines = append(lines, diffLine{kind: '-', text: from[i]})
+	}
+	for ; j < len(to); j++ {
+		lines = append(lines, diffLine{kind: '+', text: to[j]})
+	}
+	return lines
+}
@@ -0,0 +1,120 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
+// custom template status values
+const (
+	CustomTemplateStatusActive   = "active"
+	CustomTemplateStatusArchived = "archived"
+)
+
+// CustomTemplate is a CLA template authored by a foundation, the legal text of the template is kept in its versions
+type CustomTemplate struct {
+	TemplateID     string `dynamodbav:"template_id"`
+	FoundationSFID string `dynamodbav:"foundation_sfid"`
+	Name           string `dynamodbav:"template_name"`
+	Description    string `dynamodbav:"template_description"`
+	LatestVersion  int64  `dynamodbav:"latest_version"`
+	Status         string `dynamodbav:"status"`
+	CreatedBy      string `dynamodbav:"created_by"`
+	DateCreated    string `dynamodbav:"date_created"`
+	DateModified   string `dynamodbav:"date_modified"`
+}
+
+// CustomTemplateVersion is an immutable revision of a custom template
+type CustomTemplateVersion struct {
+	TemplateID   string              `dynamodbav:"template_id"`
+	Version      int64               `dynamodbav:"version"`
+	MetaFields   []*models.MetaField `dynamodbav:"meta_fields"`
+	IclaHTMLBody string              `dynamodbav:"icla_html_body"`
+	CclaHTMLBody string              `dynamodbav:"ccla_html_body"`
+	IclaFields   []*models.Field     `dynamodbav:"icla_fields"`
+	CclaFields   []*models.Field     `dynamodbav:"ccla_fields"`
+	Comment      string              `dynamodbav:"comment"`
+	CreatedBy    string              `dynamodbav:"created_by"`
+	DateCreated  string              `dynamodbav:"date_created"`
+}
+
+// CustomTemplateInput is the content of a new custom template or of a new version of a custom template. The name and
+// the description are optional for a new version, the foundation can't be changed once the template is created. The
+// base version of a new version is the version it was edited from, the latest version is used when it's not set.
+type CustomTemplateInput struct {
+	FoundationSFID string
+	Name           string
+	Description    string
+	BaseVersion    int64
+	MetaFields     []*models.MetaField
+	IclaHTMLBody   string
+	CclaHTMLBody   string
+	IclaFields     []*models.Field
+	CclaFields     []*models.Field
+	Comment        string
+}
+
+// TemplateValidation is the result of the custom template validation, the template can only be saved without errors
+type TemplateValidation struct {
+	Errors   []string
+	Warnings []string
+}
+
+// Valid returns true if the template has no validation error
+func (v *TemplateValidation) Valid() bool {
+	return len(v.Errors) == 0
+}
+
+// TemplateValidationError is returned when a custom template is saved with validation errors
+type TemplateValidationError struct {
+	Validation *TemplateValidation
+}
+
+// Error implements the error interface
+func (e *TemplateValidationError) Error() string {
+	return "invalid template: " + strings.Join(e.Validation.Errors, "; ")
+}
+
+// TemplateVersionDiff describes the changes between two versions of a custom template, the HTML bodies are compared
+// line by line and rendered as unified diffs
+type TemplateVersionDiff struct {
+	TemplateID        string
+	FromVersion       int64
+	ToVersion         int64
+	IclaDiff          string
+	CclaDiff          string
+	MetaFieldsAdded   []string
+	MetaFieldsRemoved []string
+	IclaFieldsChanged bool
+	CclaFieldsChanged bool
+}
+
+// TemplatePin is the template and template version the documents of a CLA Group were last generated from, the
+// version is zero for the built-in templates
+type TemplatePin struct {
+	CLAGroupID      string
+	TemplateID      string
+	TemplateVersion int64
+	DatePinned      string
+}
+
+// toTemplate maps the custom template version to the template model used to render the CLA Group documents, the
+// template version is used as the major version of the documents
+func (t *CustomTemplate) toTemplate(version *CustomTemplateVersion) models.Template {
+	return models.Template{
+		ID:                   t.TemplateID,
+		Name:                 t.Name,
+		Description:          t.Description,
+		TemplateMajorVersion: version.Version,
+		TemplateMinorVersion: 0,
+		MetaFields:           version.MetaFields,
+		IclaHTMLBody:         version.IclaHTMLBody,
+		CclaHTMLBody:         version.CclaHTMLBody,
+		IclaFields:           version.IclaFields,
+		CclaFields:           version.CclaFields,
+	}
+}
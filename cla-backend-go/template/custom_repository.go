@@ -0,0 +1,324 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrTemplateVersionNotFound error
+	ErrTemplateVersionNotFound = errors.New("template version not found")
+	// ErrTemplateVersionConflict is returned when the template was updated concurrently
+	ErrTemplateVersionConflict = errors.New("template was updated concurrently, reload the latest version and retry")
+)
+
+// index names
+const (
+	// FoundationSFIDIndex is the custom templates index on the owner foundation
+	FoundationSFIDIndex = "foundation-sfid-index"
+)
+
+// CustomTemplateRepository defines the custom template and template version database functions
+type CustomTemplateRepository interface {
+	CreateCustomTemplate(ctx context.Context, template *CustomTemplate, version *CustomTemplateVersion) error
+	AddCustomTemplateVersion(ctx context.Context, template *CustomTemplate, version *CustomTemplateVersion, previousVersion int64) error
+	GetCustomTemplate(ctx context.Context, templateID string) (*CustomTemplate, error)
+	GetCustomTemplatesByFoundation(ctx context.Context, foundationSFID string) ([]*CustomTemplate, error)
+	UpdateCustomTemplateStatus(ctx context.Context, templateID, status string) error
+	GetCustomTemplateVersion(ctx context.Context, templateID string, version int64) (*CustomTemplateVersion, error)
+	GetCustomTemplateVersions(ctx context.Context, templateID string) ([]*CustomTemplateVersion, error)
+}
+
+type customTemplateRepo struct {
+	dynamoDBClient    *dynamodb.DynamoDB
+	templateTableName string
+	versionTableName  string
+}
+
+// NewCustomTemplateRepository creates a new custom template repository
+func NewCustomTemplateRepository(awsSession *session.Session, stage string) CustomTemplateRepository {
+	return &customTemplateRepo{
+		dynamoDBClient:    dynamodb.New(awsSession),
+		templateTableName: fmt.Sprintf("cla-%s-templates", stage),
+		versionTableName:  fmt.Sprintf("cla-%s-template-versions", stage),
+	}
+}
+
+// CreateCustomTemplate stores the new custom template with its first version
+func (repo *customTemplateRepo) CreateCustomTemplate(ctx context.Context, template *CustomTemplate, version *CustomTemplateVersion) error {
+	return repo.AddCustomTemplateVersion(ctx, template, version, 0)
+}
+
+// AddCustomTemplateVersion stores the new template version and the updated template in a single transaction, the
+// transaction is rejected with ErrTemplateVersionConflict if the latest version of the template is no longer the
+// previous version
+func (repo *customTemplateRepo) AddCustomTemplateVersion(ctx context.Context, template *CustomTemplate, version *CustomTemplateVersion, previousVersion int64) error {
+	f := logrus.Fields{
+		"functionName":    "v1.template.custom_repository.AddCustomTemplateVersion",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"templateID":      template.TemplateID,
+		"version":         version.Version,
+		"previousVersion": previousVersion,
+	}
+
+	templateItem, err := dynamodbattribute.MarshalMap(template)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the custom template")
+		return err
+	}
+	versionItem, err := dynamodbattribute.MarshalMap(version)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the custom template version")
+		return err
+	}
+
+	templateCondition := aws.String("attribute_not_exists(template_id)")
+	var templateConditionValues map[string]*dynamodb.AttributeValue
+	if previousVersion > 0 {
+		templateCondition = aws.String("latest_version = :previous_version")
+		templateConditionValues = map[string]*dynamodb.AttributeValue{
+			":previous_version": {N: aws.String(strconv.FormatInt(previousVersion, 10))},
+		}
+	}
+
+	_, err = repo.dynamoDBClient.TransactWriteItems(&dynamodb.TransactWriteItemsInput{
+		TransactItems: []*dynamodb.TransactWriteItem{
+			{
+				Put: &dynamodb.Put{
+					TableName:           aws.String(repo.versionTableName),
+					Item:                versionItem,
+					ConditionExpression: aws.String("attribute_not_exists(template_id)"),
+				},
+			},
+			{
+				Put: &dynamodb.Put{
+					TableName:                 aws.String(repo.templateTableName),
+					Item:                      templateItem,
+					ConditionExpression:       templateCondition,
+					ExpressionAttributeValues: templateConditionValues,
+				},
+			},
+		},
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeTransactionCanceledException {
+			log.WithFields(f).WithError(err).Warn("custom template version conflict")
+			return ErrTemplateVersionConflict
+		}
+		log.WithFields(f).WithError(err).Warn("unable to store the custom template version")
+		return err
+	}
+
+	return nil
+}
+
+// GetCustomTemplate returns the custom template, or ErrTemplateNotFound
+func (repo *customTemplateRepo) GetCustomTemplate(ctx context.Context, templateID string) (*CustomTemplate, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_repository.GetCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"template_id": {S: aws.String(templateID)},
+		},
+		TableName: aws.String(repo.templateTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the custom template")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+
+	var template CustomTemplate
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &template); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the custom template")
+		return nil, err
+	}
+	return &template, nil
+}
+
+// GetCustomTemplatesByFoundation returns the custom templates of the foundation, sorted by name
+func (repo *customTemplateRepo) GetCustomTemplatesByFoundation(ctx context.Context, foundationSFID string) ([]*CustomTemplate, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_repository.GetCustomTemplatesByFoundation",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
+	}
+
+	condition := expression.Key("foundation_sfid").Equal(expression.Value(foundationSFID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the custom templates query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.templateTableName),
+		IndexName:                 aws.String(FoundationSFIDIndex),
+	}
+
+	var templates []*CustomTemplate
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query the custom templates")
+			return nil, queryErr
+		}
+
+		var page []*CustomTemplate
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the custom templates")
+			return nil, err
+		}
+		templates = append(templates, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		return templates[i].Name < templates[j].Name
+	})
+	return templates, nil
+}
+
+// UpdateCustomTemplateStatus updates the status of the custom template
+func (repo *customTemplateRepo) UpdateCustomTemplateStatus(ctx context.Context, templateID, status string) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_repository.UpdateCustomTemplateStatus",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+		"status":         status,
+	}
+
+	_, now := utils.CurrentTime()
+	_, err := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"template_id": {S: aws.String(templateID)},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#status":        aws.String("status"),
+			"#date_modified": aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":status":        {S: aws.String(status)},
+			":date_modified": {S: aws.String(now)},
+		},
+		ConditionExpression: aws.String("attribute_exists(template_id)"),
+		UpdateExpression:    aws.String("SET #status = :status, #date_modified = :date_modified"),
+		TableName:           aws.String(repo.templateTableName),
+	})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && aerr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			return ErrTemplateNotFound
+		}
+		log.WithFields(f).WithError(err).Warn("unable to update the custom template status")
+		return err
+	}
+	return nil
+}
+
+// GetCustomTemplateVersion returns the version of the custom template, or ErrTemplateVersionNotFound
+func (repo *customTemplateRepo) GetCustomTemplateVersion(ctx context.Context, templateID string, version int64) (*CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_repository.GetCustomTemplateVersion",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+		"version":        version,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"template_id": {S: aws.String(templateID)},
+			"version":     {N: aws.String(strconv.FormatInt(version, 10))},
+		},
+		TableName: aws.String(repo.versionTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the custom template version")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrTemplateVersionNotFound
+	}
+
+	var templateVersion CustomTemplateVersion
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &templateVersion); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the custom template version")
+		return nil, err
+	}
+	return &templateVersion, nil
+}
+
+// GetCustomTemplateVersions returns the versions of the custom template, oldest first
+func (repo *customTemplateRepo) GetCustomTemplateVersions(ctx context.Context, templateID string) ([]*CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_repository.GetCustomTemplateVersions",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+	}
+
+	condition := expression.Key("template_id").Equal(expression.Value(templateID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the custom template versions query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.versionTableName),
+		ScanIndexForward:          aws.Bool(true),
+	}
+
+	var versions []*CustomTemplateVersion
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query the custom template versions")
+			return nil, queryErr
+		}
+
+		var page []*CustomTemplateVersion
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the custom template versions")
+			return nil, err
+		}
+		versions = append(versions, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return versions, nil
+}
@@ -0,0 +1,316 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrTemplateArchived is returned when an archived template is updated or used for new CLA Group documents
+	ErrTemplateArchived = errors.New("template is archived")
+)
+
+// ValidateCustomTemplate validates the content of the custom template without saving it
+func (s Service) ValidateCustomTemplate(ctx context.Context, input *CustomTemplateInput) *TemplateValidation {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.ValidateCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+	content := *input
+	applyDefaultFields(&content)
+	validation := validateCustomTemplateContent(&content)
+	log.WithFields(f).Debugf("template validation completed with %d errors and %d warnings", len(validation.Errors), len(validation.Warnings))
+	return validation
+}
+
+// CreateCustomTemplate validates and saves the new custom template with its first version
+func (s Service) CreateCustomTemplate(ctx context.Context, input *CustomTemplateInput, createdBy string) (*CustomTemplate, *CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.CreateCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": input.FoundationSFID,
+		"templateName":   input.Name,
+	}
+
+	applyDefaultFields(input)
+	validation := validateCustomTemplateContent(input)
+	if input.FoundationSFID == "" {
+		validation.Errors = append([]string{"foundation SFID is required"}, validation.Errors...)
+	}
+	if strings.TrimSpace(input.Name) == "" {
+		validation.Errors = append([]string{"template name is required"}, validation.Errors...)
+	} else if len(input.Name) > maxTemplateNameLength {
+		validation.Errors = append([]string{"template name is too long"}, validation.Errors...)
+	}
+	if !validation.Valid() {
+		log.WithFields(f).Debugf("template validation failed: %s", strings.Join(validation.Errors, "; "))
+		return nil, nil, &TemplateValidationError{Validation: validation}
+	}
+
+	templateID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the template ID")
+		return nil, nil, err
+	}
+
+	_, now := utils.CurrentTime()
+	template := &CustomTemplate{
+		TemplateID:     templateID.String(),
+		FoundationSFID: input.FoundationSFID,
+		Name:           strings.TrimSpace(input.Name),
+		Description:    input.Description,
+		LatestVersion:  1,
+		Status:         CustomTemplateStatusActive,
+		CreatedBy:      createdBy,
+		DateCreated:    now,
+		DateModified:   now,
+	}
+	version := newCustomTemplateVersion(template.TemplateID, 1, input, createdBy, now)
+
+	log.WithFields(f).Debugf("creating custom template %s", template.TemplateID)
+	if err = s.customTemplateRepo.CreateCustomTemplate(ctx, template, version); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the custom template")
+		return nil, nil, err
+	}
+
+	return template, version, nil
+}
+
+// UpdateCustomTemplate validates and saves the content as a new version of the custom template
+func (s Service) UpdateCustomTemplate(ctx context.Context, templateID string, input *CustomTemplateInput, createdBy string) (*CustomTemplate, *CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.UpdateCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+		"baseVersion":    input.BaseVersion,
+	}
+
+	template, err := s.customTemplateRepo.GetCustomTemplate(ctx, templateID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the custom template")
+		return nil, nil, err
+	}
+	if template.Status == CustomTemplateStatusArchived {
+		return nil, nil, ErrTemplateArchived
+	}
+
+	previousVersion := template.LatestVersion
+	if input.BaseVersion > 0 && input.BaseVersion != previousVersion {
+		log.WithFields(f).Debugf("template was updated since version %d, latest version is %d", input.BaseVersion, previousVersion)
+		return nil, nil, ErrTemplateVersionConflict
+	}
+
+	applyDefaultFields(input)
+	validation := validateCustomTemplateContent(input)
+	if len(input.Name) > maxTemplateNameLength {
+		validation.Errors = append([]string{"template name is too long"}, validation.Errors...)
+	}
+	if !validation.Valid() {
+		log.WithFields(f).Debugf("template validation failed: %s", strings.Join(validation.Errors, "; "))
+		return nil, nil, &TemplateValidationError{Validation: validation}
+	}
+
+	_, now := utils.CurrentTime()
+	if strings.TrimSpace(input.Name) != "" {
+		template.Name = strings.TrimSpace(input.Name)
+	}
+	if input.Description != "" {
+		template.Description = input.Description
+	}
+	template.LatestVersion = previousVersion + 1
+	template.DateModified = now
+	version := newCustomTemplateVersion(template.TemplateID, template.LatestVersion, input, createdBy, now)
+
+	log.WithFields(f).Debugf("adding version %d to the custom template", version.Version)
+	if err = s.customTemplateRepo.AddCustomTemplateVersion(ctx, template, version, previousVersion); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add the custom template version")
+		return nil, nil, err
+	}
+
+	return template, version, nil
+}
+
+// GetCustomTemplate returns the custom template with its latest version
+func (s Service) GetCustomTemplate(ctx context.Context, templateID string) (*CustomTemplate, *CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.GetCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+	}
+
+	template, err := s.customTemplateRepo.GetCustomTemplate(ctx, templateID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the custom template")
+		return nil, nil, err
+	}
+
+	version, err := s.customTemplateRepo.GetCustomTemplateVersion(ctx, templateID, template.LatestVersion)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the latest version %d of the custom template", template.LatestVersion)
+		return nil, nil, err
+	}
+
+	return template, version, nil
+}
+
+// ListCustomTemplates returns the custom templates of the foundation
+func (s Service) ListCustomTemplates(ctx context.Context, foundationSFID string) ([]*CustomTemplate, error) {
+	return s.customTemplateRepo.GetCustomTemplatesByFoundation(ctx, foundationSFID)
+}
+
+// ArchiveCustomTemplate archives the custom template, the CLA Groups using the template keep their documents
+func (s Service) ArchiveCustomTemplate(ctx context.Context, templateID string) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.ArchiveCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+	}
+
+	if err := s.customTemplateRepo.UpdateCustomTemplateStatus(ctx, templateID, CustomTemplateStatusArchived); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to archive the custom template")
+		return err
+	}
+	return nil
+}
+
+// GetCustomTemplateVersion returns the version of the custom template
+func (s Service) GetCustomTemplateVersion(ctx context.Context, templateID string, version int64) (*CustomTemplateVersion, error) {
+	return s.customTemplateRepo.GetCustomTemplateVersion(ctx, templateID, version)
+}
+
+// ListCustomTemplateVersions returns the versions of the custom template, oldest first
+func (s Service) ListCustomTemplateVersions(ctx context.Context, templateID string) ([]*CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.ListCustomTemplateVersions",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+	}
+
+	versions, err := s.customTemplateRepo.GetCustomTemplateVersions(ctx, templateID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the custom template versions")
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, ErrTemplateNotFound
+	}
+	return versions, nil
+}
+
+// DiffCustomTemplateVersions compares two versions of the custom template
+func (s Service) DiffCustomTemplateVersions(ctx context.Context, templateID string, fromVersion, toVersion int64) (*TemplateVersionDiff, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.DiffCustomTemplateVersions",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     templateID,
+		"fromVersion":    fromVersion,
+		"toVersion":      toVersion,
+	}
+
+	from, err := s.customTemplateRepo.GetCustomTemplateVersion(ctx, templateID, fromVersion)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the base version")
+		return nil, err
+	}
+	to, err := s.customTemplateRepo.GetCustomTemplateVersion(ctx, templateID, toVersion)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the compared version")
+		return nil, err
+	}
+
+	return diffTemplateVersions(from, to), nil
+}
+
+// GetCLAGroupTemplatePin returns the template and template version the documents of the CLA Group were generated from
+func (s Service) GetCLAGroupTemplatePin(ctx context.Context, claGroupID string) (*TemplatePin, error) {
+	return s.templateRepo.GetCLAGroupTemplatePin(ctx, claGroupID)
+}
+
+// resolveTemplate returns the built-in template or the version of the custom template, the latest version of the
+// custom template is used when the version is zero. The custom template is nil for the built-in templates. The custom
+// templates of other foundations are reported as not found.
+func (s Service) resolveTemplate(ctx context.Context, foundationSFID, templateID string, version int64) (models.Template, *CustomTemplateVersion, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.custom_service.resolveTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
+		"templateID":     templateID,
+		"version":        version,
+	}
+
+	template, err := s.templateRepo.GetTemplate(templateID)
+	if err != ErrTemplateNotFound || s.customTemplateRepo == nil {
+		return template, nil, err
+	}
+
+	customTemplate, err := s.customTemplateRepo.GetCustomTemplate(ctx, templateID)
+	if err != nil {
+		return models.Template{}, nil, err
+	}
+	if customTemplate.FoundationSFID != foundationSFID {
+		log.WithFields(f).Warnf("the custom template belongs to the foundation: %s", customTemplate.FoundationSFID)
+		return models.Template{}, nil, ErrTemplateNotFound
+	}
+	if customTemplate.Status == CustomTemplateStatusArchived {
+		return models.Template{}, nil, ErrTemplateArchived
+	}
+	if version == 0 {
+		version = customTemplate.LatestVersion
+	}
+
+	customVersion, err := s.customTemplateRepo.GetCustomTemplateVersion(ctx, templateID, version)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the custom template version")
+		return models.Template{}, nil, err
+	}
+
+	return customTemplate.toTemplate(customVersion), customVersion, nil
+}
+
+// nextDocumentVersion returns the document version of the template, bumped to the next major version when the
+// template version is lower than the latest document of the CLA Group - the documents of a CLA Group never go back
+// to a previous version when the template is changed
+func nextDocumentVersion(documents []models.ClaGroupDocument, major, minor int64) (int64, int64) {
+	var latestMajor, latestMinor int64
+	for _, document := range documents {
+		documentMajor, majorErr := strconv.ParseInt(document.DocumentMajorVersion, 10, 64)
+		documentMinor, minorErr := strconv.ParseInt(document.DocumentMinorVersion, 10, 64)
+		if majorErr != nil || minorErr != nil {
+			continue
+		}
+		if documentMajor > latestMajor || (documentMajor == latestMajor && documentMinor > latestMinor) {
+			latestMajor, latestMinor = documentMajor, documentMinor
+		}
+	}
+
+	if major < latestMajor || (major == latestMajor && minor < latestMinor) {
+		return latestMajor + 1, 0
+	}
+	return major, minor
+}
+
+func newCustomTemplateVersion(templateID string, version int64, input *CustomTemplateInput, createdBy, now string) *CustomTemplateVersion {
+	return &CustomTemplateVersion{
+		TemplateID:   templateID,
+		Version:      version,
+		MetaFields:   input.MetaFields,
+		IclaHTMLBody: input.IclaHTMLBody,
+		CclaHTMLBody: input.CclaHTMLBody,
+		IclaFields:   input.IclaFields,
+		CclaFields:   input.CclaFields,
+		Comment:      input.Comment,
+		CreatedBy:    createdBy,
+		DateCreated:  now,
+	}
+}
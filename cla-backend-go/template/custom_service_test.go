@@ -0,0 +1,285 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCustomTemplateRepository struct {
+	templates map[string]*CustomTemplate
+	versions  map[string]*CustomTemplateVersion
+}
+
+func newFakeCustomTemplateRepository() *fakeCustomTemplateRepository {
+	return &fakeCustomTemplateRepository{
+		templates: map[string]*CustomTemplate{},
+		versions:  map[string]*CustomTemplateVersion{},
+	}
+}
+
+func versionKey(templateID string, version int64) string {
+	return fmt.Sprintf("%s:%d", templateID, version)
+}
+
+func (r *fakeCustomTemplateRepository) CreateCustomTemplate(ctx context.Context, template *CustomTemplate, version *CustomTemplateVersion) error {
+	return r.AddCustomTemplateVersion(ctx, template, version, 0)
+}
+
+func (r *fakeCustomTemplateRepository) AddCustomTemplateVersion(ctx context.Context, template *CustomTemplate, version *CustomTemplateVersion, previousVersion int64) error {
+	existing, ok := r.templates[template.TemplateID]
+	if (ok && existing.LatestVersion != previousVersion) || (!ok && previousVersion != 0) {
+		return ErrTemplateVersionConflict
+	}
+	stored := *template
+	r.templates[template.TemplateID] = &stored
+	r.versions[versionKey(version.TemplateID, version.Version)] = version
+	return nil
+}
+
+func (r *fakeCustomTemplateRepository) GetCustomTemplate(ctx context.Context, templateID string) (*CustomTemplate, error) {
+	template, ok := r.templates[templateID]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	loaded := *template
+	return &loaded, nil
+}
+
+func (r *fakeCustomTemplateRepository) GetCustomTemplatesByFoundation(ctx context.Context, foundationSFID string) ([]*CustomTemplate, error) {
+	var templates []*CustomTemplate
+	for _, template := range r.templates {
+		if template.FoundationSFID == foundationSFID {
+			templates = append(templates, template)
+		}
+	}
+	return templates, nil
+}
+
+func (r *fakeCustomTemplateRepository) UpdateCustomTemplateStatus(ctx context.Context, templateID, status string) error {
+	template, ok := r.templates[templateID]
+	if !ok {
+		return ErrTemplateNotFound
+	}
+	template.Status = status
+	return nil
+}
+
+func (r *fakeCustomTemplateRepository) GetCustomTemplateVersion(ctx context.Context, templateID string, version int64) (*CustomTemplateVersion, error) {
+	templateVersion, ok := r.versions[versionKey(templateID, version)]
+	if !ok {
+		return nil, ErrTemplateVersionNotFound
+	}
+	return templateVersion, nil
+}
+
+func (r *fakeCustomTemplateRepository) GetCustomTemplateVersions(ctx context.Context, templateID string) ([]*CustomTemplateVersion, error) {
+	var versions []*CustomTemplateVersion
+	for version := int64(1); ; version++ {
+		templateVersion, ok := r.versions[versionKey(templateID, version)]
+		if !ok {
+			return versions, nil
+		}
+		versions = append(versions, templateVersion)
+	}
+}
+
+func apacheStyleInput() *CustomTemplateInput {
+	apacheStyleTemplate := templateMap[ApacheStyleTemplateID]
+	return &CustomTemplateInput{
+		FoundationSFID: "foundation-sfid",
+		Name:           "Acme CLA",
+		MetaFields:     apacheStyleTemplate.MetaFields,
+		IclaHTMLBody:   apacheStyleTemplate.IclaHTMLBody,
+		CclaHTMLBody:   apacheStyleTemplate.CclaHTMLBody,
+	}
+}
+
+func TestValidateCustomTemplate(t *testing.T) {
+	s := Service{}
+
+	// The built-in template with its default fields is a valid custom template
+	validation := s.ValidateCustomTemplate(context.Background(), apacheStyleInput())
+	assert.Empty(t, validation.Errors)
+	assert.True(t, validation.Valid())
+
+	validation = s.ValidateCustomTemplate(context.Background(), &CustomTemplateInput{})
+	assert.Equal(t, []string{"at least one of the ICLA or CCLA HTML bodies is required"}, validation.Errors)
+
+	validation = s.ValidateCustomTemplate(context.Background(), &CustomTemplateInput{
+		MetaFields: []*models.MetaField{
+			{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+			{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+			{Name: "Contact", TemplateVariable: "CONTACT_EMAIL"},
+		},
+		IclaHTMLBody: "<p>{{ PROJECT_NAME }} - {{{ PROJECT_ENTITY_NAME }}}</p><p>Please sign: ____ Date: ____</p>",
+		IclaFields: []*models.Field{
+			{ID: "sign", AnchorString: "Please Sign:", FieldType: "sign"},
+			{ID: "date", AnchorString: "Date:", FieldType: "date"},
+			{ID: "date", AnchorString: "Country:", FieldType: "text"},
+		},
+	})
+	assert.Equal(t, []string{
+		"meta field 2: template variable PROJECT_NAME is declared more than once",
+		"icla HTML body: template variable PROJECT_ENTITY_NAME is not declared as a meta field",
+		"icla field 3: id date is used more than once",
+		"icla field date: anchor string 'Country:' not found in the icla HTML body",
+	}, validation.Errors)
+	assert.Equal(t, []string{"meta field CONTACT_EMAIL is not used by the HTML bodies"}, validation.Warnings)
+
+	validation = s.ValidateCustomTemplate(context.Background(), &CustomTemplateInput{
+		CclaHTMLBody: "<p>{{#if PROJECT_NAME}}Acme</p>",
+		CclaFields:   []*models.Field{{ID: "date", AnchorString: "Date:", FieldType: "date"}},
+	})
+	assert.Len(t, validation.Errors, 1)
+	assert.Contains(t, validation.Errors[0], "ccla HTML body is not a valid template")
+
+	validation = s.ValidateCustomTemplate(context.Background(), &CustomTemplateInput{
+		CclaHTMLBody: "<p>Date: ____</p>",
+		CclaFields:   []*models.Field{{ID: "date", AnchorString: "Date:", FieldType: "date"}},
+	})
+	assert.Equal(t, []string{"ccla fields: a sign field is required"}, validation.Errors)
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	assert.Equal(t, "", unifiedDiff("a", "b", "one\ntwo\n", "one\ntwo\n"))
+
+	from := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n11\n12\n"
+	to := "1\n2\nthree\n4\n5\n6\n7\n8\n9\n10\n11\n12\n13\n"
+	assert.Equal(t, `--- icla v1
++++ icla v2
+@@ -1,6 +1,6 @@
+ 1
+ 2
+-3
++three
+ 4
+ 5
+ 6
+@@ -10,3 +10,4 @@
+ 10
+ 11
+ 12
++13
+`, unifiedDiff("icla v1", "icla v2", from, to))
+
+	// changes separated by less than twice the context lines share a hunk
+	assert.Equal(t, `--- a
++++ b
+@@ -1,7 +1,7 @@
+-1
++one
+ 2
+ 3
+ 4
+ 5
+ 6
+-7
++seven
+`, unifiedDiff("a", "b", "1\n2\n3\n4\n5\n6\n7\n", "one\n2\n3\n4\n5\n6\nseven\n"))
+
+	assert.Equal(t, "--- a\n+++ b\n@@ -0,0 +1,1 @@\n+new\n", unifiedDiff("a", "b", "", "new"))
+}
+
+func TestNextDocumentVersion(t *testing.T) {
+	documents := []models.ClaGroupDocument{
+		{DocumentMajorVersion: "2", DocumentMinorVersion: "0"},
+		{DocumentMajorVersion: "2", DocumentMinorVersion: "1"},
+	}
+
+	major, minor := nextDocumentVersion(nil, 1, 0)
+	assert.Equal(t, []int64{1, 0}, []int64{major, minor})
+
+	// the built-in template regenerated with its own version
+	major, minor = nextDocumentVersion(documents[:1], 2, 0)
+	assert.Equal(t, []int64{2, 0}, []int64{major, minor})
+
+	major, minor = nextDocumentVersion(documents, 2, 0)
+	assert.Equal(t, []int64{3, 0}, []int64{major, minor})
+
+	major, minor = nextDocumentVersion(documents, 4, 0)
+	assert.Equal(t, []int64{4, 0}, []int64{major, minor})
+}
+
+func TestCustomTemplateVersions(t *testing.T) {
+	ctx := context.Background()
+	repo := newFakeCustomTemplateRepository()
+	s := Service{
+		templateRepo:       &Repository{},
+		customTemplateRepo: repo,
+	}
+
+	_, _, err := s.CreateCustomTemplate(ctx, &CustomTemplateInput{IclaHTMLBody: "<p>Please sign:</p>"}, "jdoe")
+	var validationErr *TemplateValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, validationErr.Validation.Errors, "template name is required")
+	assert.Contains(t, validationErr.Validation.Errors, "foundation SFID is required")
+
+	template, version, err := s.CreateCustomTemplate(ctx, apacheStyleInput(), "jdoe")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), template.LatestVersion)
+	assert.Equal(t, CustomTemplateStatusActive, template.Status)
+	assert.Equal(t, int64(1), version.Version)
+	assert.NotEmpty(t, version.IclaFields)
+
+	name, err := s.GetTemplateName(ctx, template.TemplateID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Acme CLA", name)
+	assert.True(t, s.CLAGroupTemplateExists(ctx, template.TemplateID))
+
+	update := apacheStyleInput()
+	update.BaseVersion = 1
+	update.IclaHTMLBody = "<p>{{ PROJECT_NAME }}</p>\n<p>Full name: Country: E-Mail: Please sign: Date:</p>\n"
+	update.Comment = "shorter agreement"
+	template, version, err = s.UpdateCustomTemplate(ctx, template.TemplateID, update, "jdoe")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), template.LatestVersion)
+	assert.Equal(t, int64(2), version.Version)
+	assert.Equal(t, "shorter agreement", version.Comment)
+
+	// the update is based on a stale version
+	stale := apacheStyleInput()
+	stale.BaseVersion = 1
+	_, _, err = s.UpdateCustomTemplate(ctx, template.TemplateID, stale, "jdoe")
+	assert.Equal(t, ErrTemplateVersionConflict, err)
+
+	versions, err := s.ListCustomTemplateVersions(ctx, template.TemplateID)
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+
+	diff, err := s.DiffCustomTemplateVersions(ctx, template.TemplateID, 1, 2)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.IclaDiff, "+<p>{{ PROJECT_NAME }}</p>")
+	assert.Empty(t, diff.CclaDiff)
+	assert.False(t, diff.IclaFieldsChanged)
+
+	resolved, resolvedVersion, err := s.resolveTemplate(ctx, "foundation-sfid", template.TemplateID, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), resolvedVersion.Version)
+	assert.Equal(t, int64(2), resolved.TemplateMajorVersion)
+	assert.Equal(t, update.IclaHTMLBody, resolved.IclaHTMLBody)
+
+	resolved, _, err = s.resolveTemplate(ctx, "foundation-sfid", template.TemplateID, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, templateMap[ApacheStyleTemplateID].IclaHTMLBody, resolved.IclaHTMLBody)
+
+	// the custom templates of other foundations can't be used
+	_, _, err = s.resolveTemplate(ctx, "other-foundation-sfid", template.TemplateID, 0)
+	assert.Equal(t, ErrTemplateNotFound, err)
+
+	assert.NoError(t, s.ArchiveCustomTemplate(ctx, template.TemplateID))
+	_, _, err = s.resolveTemplate(ctx, "foundation-sfid", template.TemplateID, 0)
+	assert.Equal(t, ErrTemplateArchived, err)
+	_, _, err = s.UpdateCustomTemplate(ctx, template.TemplateID, apacheStyleInput(), "jdoe")
+	assert.Equal(t, ErrTemplateArchived, err)
+	assert.False(t, s.CLAGroupTemplateExists(ctx, template.TemplateID))
+
+	_, _, err = s.GetCustomTemplate(ctx, "unknown")
+	assert.Equal(t, ErrTemplateNotFound, err)
+}
@@ -0,0 +1,198 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/aymerick/raymond"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"golang.org/x/net/html"
+)
+
+const (
+	// maxTemplateBodySize is the maximum size of each HTML body, both bodies are stored in the version item which
+	// is limited to 400KB by DynamoDB
+	maxTemplateBodySize = 128 * 1024
+	// maxTemplateNameLength is the maximum length of the template name
+	maxTemplateNameLength = 255
+
+	// signFieldType is the DocuSign tab type of the signature field
+	signFieldType = "sign"
+)
+
+var (
+	// templateVariableRegex matches the {{ VARIABLE }} and {{{ VARIABLE }}} handlebars expressions, the helpers and
+	// the block expressions such as {{#if VARIABLE}} are not matched
+	templateVariableRegex = regexp.MustCompile(`{{{?\s*([A-Za-z_][A-Za-z0-9_]*)\s*}?}}`)
+	// templateVariableNameRegex is the format of the declared template variables
+	templateVariableNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// handlebarsKeywords are matched by templateVariableRegex but aren't template variables
+var handlebarsKeywords = map[string]bool{
+	"else": true,
+	"this": true,
+}
+
+// validateCustomTemplateContent validates the meta fields, the HTML bodies and the signature fields of the custom
+// template, the default signature fields must already be applied
+func validateCustomTemplateContent(input *CustomTemplateInput) *TemplateValidation {
+	validation := &TemplateValidation{}
+
+	if strings.TrimSpace(input.IclaHTMLBody) == "" && strings.TrimSpace(input.CclaHTMLBody) == "" {
+		validation.Errors = append(validation.Errors, "at least one of the ICLA or CCLA HTML bodies is required")
+		return validation
+	}
+
+	declared := validateMetaFields(validation, input.MetaFields)
+	used := map[string]bool{}
+	validateTemplateBody(validation, claTypeICLA, input.IclaHTMLBody, input.IclaFields, declared, used)
+	validateTemplateBody(validation, claTypeCCLA, input.CclaHTMLBody, input.CclaFields, declared, used)
+
+	for _, metaField := range input.MetaFields {
+		if metaField != nil && declared[metaField.TemplateVariable] && !used[metaField.TemplateVariable] {
+			validation.Warnings = append(validation.Warnings,
+				fmt.Sprintf("meta field %s is not used by the HTML bodies", metaField.TemplateVariable))
+		}
+	}
+
+	return validation
+}
+
+// validateMetaFields validates the declared meta fields and returns the set of declared template variables
+func validateMetaFields(validation *TemplateValidation, metaFields []*models.MetaField) map[string]bool {
+	declared := map[string]bool{}
+	for i, metaField := range metaFields {
+		if metaField == nil {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("meta field %d: is empty", i+1))
+			continue
+		}
+		if strings.TrimSpace(metaField.Name) == "" {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("meta field %d: name is required", i+1))
+		}
+		if !templateVariableNameRegex.MatchString(metaField.TemplateVariable) {
+			validation.Errors = append(validation.Errors,
+				fmt.Sprintf("meta field %d: invalid template variable '%s' - letters, digits and underscores are allowed", i+1, metaField.TemplateVariable))
+			continue
+		}
+		if declared[metaField.TemplateVariable] {
+			validation.Errors = append(validation.Errors,
+				fmt.Sprintf("meta field %d: template variable %s is declared more than once", i+1, metaField.TemplateVariable))
+			continue
+		}
+		declared[metaField.TemplateVariable] = true
+	}
+	return declared
+}
+
+// validateTemplateBody validates the handlebars HTML body of the CLA type and its signature fields, the template
+// variables used by the body are added to used
+func validateTemplateBody(validation *TemplateValidation, claType, body string, fields []*models.Field, declared, used map[string]bool) {
+	if strings.TrimSpace(body) == "" {
+		if len(fields) > 0 {
+			validation.Warnings = append(validation.Warnings,
+				fmt.Sprintf("%s fields are ignored without a %s HTML body", claType, claType))
+		}
+		return
+	}
+
+	if len(body) > maxTemplateBodySize {
+		validation.Errors = append(validation.Errors,
+			fmt.Sprintf("%s HTML body exceeds the maximum size of %d bytes", claType, maxTemplateBodySize))
+		return
+	}
+
+	if _, err := raymond.Parse(body); err != nil {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("%s HTML body is not a valid template: %v", claType, err))
+		return
+	}
+
+	for _, match := range templateVariableRegex.FindAllStringSubmatch(body, -1) {
+		variable := match[1]
+		if handlebarsKeywords[variable] || used[claType+":"+variable] {
+			continue
+		}
+		used[claType+":"+variable] = true
+		used[variable] = true
+		if !declared[variable] {
+			validation.Errors = append(validation.Errors,
+				fmt.Sprintf("%s HTML body: template variable %s is not declared as a meta field", claType, variable))
+		}
+	}
+
+	text, err := bodyText(body)
+	if err != nil {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("%s HTML body is not valid HTML: %v", claType, err))
+		return
+	}
+	text = strings.ToLower(text)
+
+	fieldIDs := map[string]bool{}
+	hasSignField := false
+	for i, field := range fields {
+		if field == nil {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("%s field %d: is empty", claType, i+1))
+			continue
+		}
+		if field.ID == "" {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("%s field %d: id is required", claType, i+1))
+		} else if fieldIDs[field.ID] {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("%s field %d: id %s is used more than once", claType, i+1, field.ID))
+		}
+		fieldIDs[field.ID] = true
+
+		if field.FieldType == "" {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("%s field %d: type is required", claType, i+1))
+		}
+		if field.FieldType == signFieldType {
+			hasSignField = true
+		}
+
+		// DocuSign anchors are matched case insensitively
+		anchor := strings.ToLower(strings.Join(strings.Fields(field.AnchorString), " "))
+		if anchor == "" {
+			validation.Errors = append(validation.Errors, fmt.Sprintf("%s field %d: anchor string is required", claType, i+1))
+		} else if !strings.Contains(text, anchor) {
+			validation.Errors = append(validation.Errors,
+				fmt.Sprintf("%s field %s: anchor string '%s' not found in the %s HTML body", claType, field.ID, field.AnchorString, claType))
+		}
+	}
+
+	if !hasSignField {
+		validation.Errors = append(validation.Errors, fmt.Sprintf("%s fields: a %s field is required", claType, signFieldType))
+	}
+}
+
+// bodyText returns the text of the HTML body with the whitespaces collapsed
+func bodyText(body string) (string, error) {
+	var text strings.Builder
+	tokenizer := html.NewTokenizer(strings.NewReader(body))
+	for {
+		switch tokenizer.Next() {
+		case html.ErrorToken:
+			if err := tokenizer.Err(); err != io.EOF {
+				return "", err
+			}
+			return strings.Join(strings.Fields(text.String()), " "), nil
+		case html.TextToken:
+			text.Write(tokenizer.Text())
+			text.WriteString(" ")
+		}
+	}
+}
+
+// applyDefaultFields uses the Apache Style signature fields for the bodies without fields
+func applyDefaultFields(input *CustomTemplateInput) {
+	apacheStyleTemplate := templateMap[ApacheStyleTemplateID]
+	if len(input.IclaFields) == 0 && strings.TrimSpace(input.IclaHTMLBody) != "" {
+		input.IclaFields = apacheStyleTemplate.IclaFields
+	}
+	if len(input.CclaFields) == 0 && strings.TrimSpace(input.CclaHTMLBody) != "" {
+		input.CclaFields = apacheStyleTemplate.CclaFields
+	}
+}
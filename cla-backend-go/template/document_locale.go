@@ -0,0 +1,289 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/text/language"
+)
+
+const (
+	// defaultDocumentLocale is the locale of the CLA Group documents created without a locale
+	defaultDocumentLocale = "en"
+	// maxDocumentLocaleSize is the maximum size of a translated document, the base64 encoded request body must fit
+	// in the Lambda request payload
+	maxDocumentLocaleSize = 4 * 1024 * 1024
+)
+
+var (
+	// ErrInvalidDocumentLocale is returned when the translation of a CLA Group document is not valid
+	ErrInvalidDocumentLocale = errors.New("invalid cla group document translation")
+	// ErrCLADocumentNotFound is returned when the CLA Group doesn't have a document of the CLA type
+	ErrCLADocumentNotFound = errors.New("cla group document not found")
+	// ErrDocumentLocaleNotFound is returned when the current CLA Group document doesn't have the translation
+	ErrDocumentLocaleNotFound = errors.New("cla group document translation not found")
+)
+
+// DocumentLocaleInput is a legally reviewed translation of the current CLA Group document of the CLA type
+type DocumentLocaleInput struct {
+	ClaType            string
+	Locale             string
+	DocumentName       string
+	DocumentReviewedBy string
+	DocumentContent    []byte
+	DocumentTabs       []models.DocumentTab
+}
+
+// PutCLAGroupDocumentLocale stores the translated PDF and adds the translation to the current CLA Group document of
+// the CLA type, an existing translation with the same locale is replaced
+func (s Service) PutCLAGroupDocumentLocale(ctx context.Context, claGroupID string, input *DocumentLocaleInput) (*models.ClaGroupDocumentLocale, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.document_locale.PutCLAGroupDocumentLocale",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        input.ClaType,
+		"locale":         input.Locale,
+	}
+
+	locale, err := validateDocumentLocaleInput(input)
+	if err != nil {
+		log.WithFields(f).WithError(err).Debug("invalid CLA Group document translation")
+		return nil, err
+	}
+
+	documents, err := s.templateRepo.GetCLADocumentModels(ctx, claGroupID, input.ClaType)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group documents")
+		return nil, err
+	}
+	index := currentDocumentIndex(ctx, documents)
+	if index < 0 {
+		return nil, ErrCLADocumentNotFound
+	}
+	document := documents[index]
+
+	if strings.EqualFold(locale, documentLocale(document)) {
+		return nil, fmt.Errorf("%w: the original document is in the %s locale", ErrInvalidDocumentLocale, documentLocale(document))
+	}
+
+	documentName := strings.TrimSpace(input.DocumentName)
+	if documentName == "" {
+		documentName = fmt.Sprintf("%s (%s)", document.DocumentName, locale)
+	}
+
+	bucket := fmt.Sprintf("cla-signature-files-%s", s.stage)
+	fileName := s.generateDocumentLocaleS3FilePath(claGroupID, input.ClaType, locale)
+	log.WithFields(f).Debugf("uploading the translated document to %s", fileName)
+	fileURL, err := s.SaveTemplateToS3(bucket, fileName, io.NopCloser(bytes.NewReader(input.DocumentContent)))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem uploading the translated document: %s to s3", fileName)
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	variant := DBProjectDocumentLocaleModel{
+		Locale:               locale,
+		DocumentName:         documentName,
+		DocumentContentType:  "storage+pdf",
+		DocumentS3URL:        fileURL,
+		DocumentReviewedBy:   strings.TrimSpace(input.DocumentReviewedBy),
+		DocumentCreationDate: currentTime,
+		DocumentTabs:         input.DocumentTabs,
+	}
+
+	locales := putDocumentLocale(document.DocumentLocales, variant)
+	err = s.templateRepo.UpdateCLADocumentLocales(ctx, claGroupID, input.ClaType, index, document.DocumentCreationDate, locales)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the CLA Group document translations")
+		return nil, err
+	}
+
+	return toDocumentLocaleModel(variant), nil
+}
+
+// DeleteCLAGroupDocumentLocale removes the translation from the current CLA Group document of the CLA type, the
+// translated PDF is kept as the previous signers may have signed it
+func (s Service) DeleteCLAGroupDocumentLocale(ctx context.Context, claGroupID, claType, locale string) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.document_locale.DeleteCLAGroupDocumentLocale",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+		"locale":         locale,
+	}
+
+	documents, err := s.templateRepo.GetCLADocumentModels(ctx, claGroupID, claType)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group documents")
+		return err
+	}
+	index := currentDocumentIndex(ctx, documents)
+	if index < 0 {
+		return ErrCLADocumentNotFound
+	}
+	document := documents[index]
+
+	locales, removed := removeDocumentLocale(document.DocumentLocales, locale)
+	if !removed {
+		return ErrDocumentLocaleNotFound
+	}
+
+	return s.templateRepo.UpdateCLADocumentLocales(ctx, claGroupID, claType, index, document.DocumentCreationDate, locales)
+}
+
+// validateDocumentLocaleInput validates the translation and returns the canonical form of its locale
+func validateDocumentLocaleInput(input *DocumentLocaleInput) (string, error) {
+	if input.ClaType != claTypeICLA && input.ClaType != claTypeCCLA {
+		return "", fmt.Errorf("%w: not supported cla type supplied: %s", ErrInvalidDocumentLocale, input.ClaType)
+	}
+
+	tag, err := language.Parse(input.Locale)
+	if err != nil || tag == language.Und {
+		return "", fmt.Errorf("%w: '%s' is not a valid BCP 47 language tag", ErrInvalidDocumentLocale, input.Locale)
+	}
+
+	if strings.TrimSpace(input.DocumentReviewedBy) == "" {
+		return "", fmt.Errorf("%w: the legal reviewer of the translation is required", ErrInvalidDocumentLocale)
+	}
+
+	if len(input.DocumentContent) == 0 {
+		return "", fmt.Errorf("%w: the translated document is required", ErrInvalidDocumentLocale)
+	}
+	if len(input.DocumentContent) > maxDocumentLocaleSize {
+		return "", fmt.Errorf("%w: the translated document exceeds the maximum size of %d bytes", ErrInvalidDocumentLocale, maxDocumentLocaleSize)
+	}
+	if !bytes.HasPrefix(input.DocumentContent, []byte("%PDF-")) {
+		return "", fmt.Errorf("%w: the translated document is not a PDF document", ErrInvalidDocumentLocale)
+	}
+
+	// The fields of the original document are used when the translation doesn't have fields
+	if len(input.DocumentTabs) > 0 {
+		hasSignTab := false
+		for i, tab := range input.DocumentTabs {
+			if tab.DocumentTabID == "" || tab.DocumentTabType == "" {
+				return "", fmt.Errorf("%w: document tab %d: id and type are required", ErrInvalidDocumentLocale, i+1)
+			}
+			if tab.DocumentTabType == signFieldType {
+				hasSignTab = true
+			}
+		}
+		if !hasSignTab {
+			return "", fmt.Errorf("%w: document tabs: a %s tab is required", ErrInvalidDocumentLocale, signFieldType)
+		}
+	}
+
+	return tag.String(), nil
+}
+
+// documentLocale returns the locale of the original document
+func documentLocale(document DBProjectDocumentModel) string {
+	if document.DocumentLocale == "" {
+		return defaultDocumentLocale
+	}
+	return document.DocumentLocale
+}
+
+// currentDocumentIndex returns the index of the current document, the document selection matches the selection of the
+// document to sign: the highest version wins and the most recent document wins when several documents have the same
+// version, -1 is returned when there are no valid documents
+func currentDocumentIndex(ctx context.Context, documents []DBProjectDocumentModel) int {
+	f := logrus.Fields{
+		"functionName":   "v1.template.document_locale.currentDocumentIndex",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	current := -1
+	var lastDateTime time.Time
+	lastMajor, lastMinor := 0, -1
+	for i, document := range documents {
+		major, err := strconv.Atoi(document.DocumentMajorVersion)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("invalid major number in cla group: %s", document.DocumentMajorVersion)
+			continue
+		}
+		minor, err := strconv.Atoi(document.DocumentMinorVersion)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("invalid minor number in cla group: %s", document.DocumentMinorVersion)
+			continue
+		}
+		dateTime, err := utils.ParseDateTime(document.DocumentCreationDate)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("invalid date time in cla group: %s", document.DocumentCreationDate)
+			continue
+		}
+
+		if major > lastMajor || (major == lastMajor && minor > lastMinor) || (major == lastMajor && minor == lastMinor && dateTime.After(lastDateTime)) {
+			current = i
+			lastMajor, lastMinor = major, minor
+			lastDateTime = dateTime
+		}
+	}
+
+	return current
+}
+
+// putDocumentLocale returns the translations with the variant, the existing translation with the same locale is
+// replaced
+func putDocumentLocale(locales []DBProjectDocumentLocaleModel, variant DBProjectDocumentLocaleModel) []DBProjectDocumentLocaleModel {
+	updated := make([]DBProjectDocumentLocaleModel, 0, len(locales)+1)
+	replaced := false
+	for _, existing := range locales {
+		if strings.EqualFold(existing.Locale, variant.Locale) {
+			updated = append(updated, variant)
+			replaced = true
+			continue
+		}
+		updated = append(updated, existing)
+	}
+	if !replaced {
+		updated = append(updated, variant)
+	}
+	return updated
+}
+
+// removeDocumentLocale returns the translations without the translation with the locale and whether it was found
+func removeDocumentLocale(locales []DBProjectDocumentLocaleModel, locale string) ([]DBProjectDocumentLocaleModel, bool) {
+	updated := make([]DBProjectDocumentLocaleModel, 0, len(locales))
+	removed := false
+	for _, existing := range locales {
+		if strings.EqualFold(existing.Locale, locale) {
+			removed = true
+			continue
+		}
+		updated = append(updated, existing)
+	}
+	return updated, removed
+}
+
+// generateDocumentLocaleS3FilePath returns the S3 key of a translated document, for example:
+// contract-group/<cla_group_id>/template/icla-pt-BR-2020-09-25T22-32-59Z.pdf
+func (s Service) generateDocumentLocaleS3FilePath(claGroupID, claType, locale string) string {
+	return fmt.Sprintf("contract-group/%s/template/%s-%s-%s.pdf", claGroupID, claType, locale,
+		strings.ReplaceAll(utils.CurrentSimpleDateTimeString(), ":", "-"))
+}
+
+// toDocumentLocaleModel maps the translation database model to the API model
+func toDocumentLocaleModel(variant DBProjectDocumentLocaleModel) *models.ClaGroupDocumentLocale {
+	return &models.ClaGroupDocumentLocale{
+		Locale:               variant.Locale,
+		DocumentName:         variant.DocumentName,
+		DocumentContentType:  variant.DocumentContentType,
+		DocumentS3URL:        variant.DocumentS3URL,
+		DocumentReviewedBy:   variant.DocumentReviewedBy,
+		DocumentCreationDate: variant.DocumentCreationDate,
+		DocumentTabs:         variant.DocumentTabs,
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDocumentRepository struct {
+	RepositoryInterface
+	documents []DBProjectDocumentModel
+}
+
+func (r *fakeDocumentRepository) GetCLADocumentModels(ctx context.Context, claGroupID, claType string) ([]DBProjectDocumentModel, error) {
+	return r.documents, nil
+}
+
+func (r *fakeDocumentRepository) UpdateCLADocumentLocales(ctx context.Context, claGroupID, claType string, documentIndex int, documentCreationDate string, locales []DBProjectDocumentLocaleModel) error {
+	if r.documents[documentIndex].DocumentCreationDate != documentCreationDate {
+		return ErrCLADocumentChanged
+	}
+	r.documents[documentIndex].DocumentLocales = locales
+	return nil
+}
+
+func TestValidateDocumentLocaleInput(t *testing.T) {
+	input := func() *DocumentLocaleInput {
+		return &DocumentLocaleInput{
+			ClaType:            claTypeICLA,
+			Locale:             "pt-br",
+			DocumentReviewedBy: "Jane Doe",
+			DocumentContent:    []byte("%PDF-1.4 ..."),
+		}
+	}
+
+	locale, err := validateDocumentLocaleInput(input())
+	assert.NoError(t, err)
+	assert.Equal(t, "pt-BR", locale)
+
+	testCases := []struct {
+		name   string
+		update func(input *DocumentLocaleInput)
+		err    string
+	}{
+		{name: "cla type", update: func(input *DocumentLocaleInput) { input.ClaType = "ecla" }, err: "not supported cla type supplied: ecla"},
+		{name: "locale", update: func(input *DocumentLocaleInput) { input.Locale = "not a locale" }, err: "'not a locale' is not a valid BCP 47 language tag"},
+		{name: "reviewer", update: func(input *DocumentLocaleInput) { input.DocumentReviewedBy = " " }, err: "the legal reviewer of the translation is required"},
+		{name: "content", update: func(input *DocumentLocaleInput) { input.DocumentContent = nil }, err: "the translated document is required"},
+		{name: "pdf", update: func(input *DocumentLocaleInput) { input.DocumentContent = []byte("<html>") }, err: "the translated document is not a PDF document"},
+		{name: "tab", update: func(input *DocumentLocaleInput) {
+			input.DocumentTabs = []models.DocumentTab{{DocumentTabID: "sign"}}
+		}, err: "document tab 1: id and type are required"},
+		{name: "sign tab", update: func(input *DocumentLocaleInput) {
+			input.DocumentTabs = []models.DocumentTab{{DocumentTabID: "date", DocumentTabType: "date"}}
+		}, err: "document tabs: a sign tab is required"},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			invalid := input()
+			tc.update(invalid)
+			_, err := validateDocumentLocaleInput(invalid)
+			assert.ErrorIs(t, err, ErrInvalidDocumentLocale)
+			assert.EqualError(t, err, "invalid cla group document translation: "+tc.err)
+		})
+	}
+}
+
+func TestCurrentDocumentIndex(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, -1, currentDocumentIndex(ctx, nil))
+
+	documents := []DBProjectDocumentModel{
+		{DocumentMajorVersion: "2", DocumentMinorVersion: "0", DocumentCreationDate: "2020-01-01T00:00:00Z"},
+		{DocumentMajorVersion: "2", DocumentMinorVersion: "1", DocumentCreationDate: "2020-02-01T00:00:00Z"},
+		{DocumentMajorVersion: "2", DocumentMinorVersion: "1", DocumentCreationDate: "2020-03-01T00:00:00Z"},
+		{DocumentMajorVersion: "1", DocumentMinorVersion: "9", DocumentCreationDate: "2020-04-01T00:00:00Z"},
+		{DocumentMajorVersion: "3", DocumentMinorVersion: "0", DocumentCreationDate: "invalid"},
+	}
+	assert.Equal(t, 2, currentDocumentIndex(ctx, documents))
+}
+
+func TestDocumentLocales(t *testing.T) {
+	ctx := context.Background()
+	repo := &fakeDocumentRepository{
+		documents: []DBProjectDocumentModel{
+			{DocumentMajorVersion: "1", DocumentMinorVersion: "0", DocumentCreationDate: "2020-01-01T00:00:00Z"},
+			{
+				DocumentMajorVersion: "2",
+				DocumentMinorVersion: "0",
+				DocumentCreationDate: "2020-02-01T00:00:00Z",
+				DocumentLocales: []DBProjectDocumentLocaleModel{
+					{Locale: "fr", DocumentS3URL: "https://bucket/icla-fr.pdf"},
+					{Locale: "pt-BR", DocumentS3URL: "https://bucket/icla-pt-BR.pdf"},
+				},
+			},
+		},
+	}
+	s := Service{templateRepo: repo}
+
+	// translations are replaced in place
+	locales := putDocumentLocale(repo.documents[1].DocumentLocales, DBProjectDocumentLocaleModel{Locale: "fr", DocumentS3URL: "https://bucket/icla-fr-2.pdf"})
+	assert.Equal(t, []DBProjectDocumentLocaleModel{
+		{Locale: "fr", DocumentS3URL: "https://bucket/icla-fr-2.pdf"},
+		{Locale: "pt-BR", DocumentS3URL: "https://bucket/icla-pt-BR.pdf"},
+	}, locales)
+	locales = putDocumentLocale(nil, DBProjectDocumentLocaleModel{Locale: "de"})
+	assert.Equal(t, []DBProjectDocumentLocaleModel{{Locale: "de"}}, locales)
+
+	assert.Equal(t, ErrDocumentLocaleNotFound, s.DeleteCLAGroupDocumentLocale(ctx, "cla-group", claTypeICLA, "de"))
+	assert.NoError(t, s.DeleteCLAGroupDocumentLocale(ctx, "cla-group", claTypeICLA, "FR"))
+	assert.Equal(t, []DBProjectDocumentLocaleModel{{Locale: "pt-BR", DocumentS3URL: "https://bucket/icla-pt-BR.pdf"}}, repo.documents[1].DocumentLocales)
+	assert.Empty(t, repo.documents[0].DocumentLocales)
+
+	// the original document locale can't be added as a translation
+	_, err := s.PutCLAGroupDocumentLocale(ctx, "cla-group", &DocumentLocaleInput{
+		ClaType:            claTypeICLA,
+		Locale:             "EN",
+		DocumentReviewedBy: "Jane Doe",
+		DocumentContent:    []byte("%PDF-1.4"),
+	})
+	assert.ErrorIs(t, err, ErrInvalidDocumentLocale)
+
+	repo.documents = nil
+	assert.Equal(t, ErrCLADocumentNotFound, s.DeleteCLAGroupDocumentLocale(ctx, "cla-group", claTypeICLA, "pt-BR"))
+}
@@ -3,6 +3,10 @@
 
 package template
 
+import (
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
 // DBProjectModel data model
 type DBProjectModel struct {
 	DateCreated                      string                   `dynamodbav:"date_created"`
@@ -18,18 +22,34 @@ type DBProjectModel struct {
 	ProjectIndividualDocuments       []DBProjectDocumentModel `dynamodbav:"project_individual_documents"`
 	ProjectMemberDocuments           []DBProjectDocumentModel `dynamodbav:"project_member_documents"`
 	ProjectACL                       []string                 `dynamodbav:"project_acl"`
+	ProjectTemplateID                string                   `dynamodbav:"project_template_id"`
+	ProjectTemplateVersion           int64                    `dynamodbav:"project_template_version"`
+	ProjectTemplateDatePinned        string                   `dynamodbav:"project_template_date_pinned"`
 }
 
 // DBProjectDocumentModel is a data model for the CLA Group Project documents
 type DBProjectDocumentModel struct {
-	DocumentName            string `dynamodbav:"document_name"`
-	DocumentFileID          string `dynamodbav:"document_file_id"`
-	DocumentPreamble        string `dynamodbav:"document_preamble"`
-	DocumentLegalEntityName string `dynamodbav:"document_legal_entity_name"`
-	DocumentAuthorName      string `dynamodbav:"document_author_name"`
-	DocumentContentType     string `dynamodbav:"document_content_type"`
-	DocumentS3URL           string `dynamodbav:"document_s3_url"`
-	DocumentMajorVersion    string `dynamodbav:"document_major_version"`
-	DocumentMinorVersion    string `dynamodbav:"document_minor_version"`
-	DocumentCreationDate    string `dynamodbav:"document_creation_date"`
+	DocumentName            string                         `dynamodbav:"document_name"`
+	DocumentFileID          string                         `dynamodbav:"document_file_id"`
+	DocumentPreamble        string                         `dynamodbav:"document_preamble"`
+	DocumentLegalEntityName string                         `dynamodbav:"document_legal_entity_name"`
+	DocumentAuthorName      string                         `dynamodbav:"document_author_name"`
+	DocumentContentType     string                         `dynamodbav:"document_content_type"`
+	DocumentS3URL           string                         `dynamodbav:"document_s3_url"`
+	DocumentMajorVersion    string                         `dynamodbav:"document_major_version"`
+	DocumentMinorVersion    string                         `dynamodbav:"document_minor_version"`
+	DocumentCreationDate    string                         `dynamodbav:"document_creation_date"`
+	DocumentLocale          string                         `dynamodbav:"document_locale"`
+	DocumentLocales         []DBProjectDocumentLocaleModel `dynamodbav:"document_locales"`
+}
+
+// DBProjectDocumentLocaleModel is a data model for the legally reviewed translations of a CLA Group Project document
+type DBProjectDocumentLocaleModel struct {
+	Locale               string               `dynamodbav:"locale"`
+	DocumentName         string               `dynamodbav:"document_name"`
+	DocumentContentType  string               `dynamodbav:"document_content_type"`
+	DocumentS3URL        string               `dynamodbav:"document_s3_url"`
+	DocumentReviewedBy   string               `dynamodbav:"document_reviewed_by"`
+	DocumentCreationDate string               `dynamodbav:"document_creation_date"`
+	DocumentTabs         []models.DocumentTab `dynamodbav:"document_tabs"`
 }
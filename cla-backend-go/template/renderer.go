@@ -0,0 +1,71 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/docraptor"
+	"github.com/linuxfoundation/easycla/cla-backend-go/localpdf"
+)
+
+const (
+	// PDFRendererDocraptor renders the templates with the DocRaptor API
+	PDFRendererDocraptor = "docraptor"
+	// PDFRendererLocal renders the template previews in process, without calling an external service
+	PDFRendererLocal = "local"
+)
+
+var (
+	// ErrPreviewOnlyRenderer is returned when the CLA Group documents are created with a renderer limited to previews
+	ErrPreviewOnlyRenderer = errors.New("the pdf renderer only renders template previews, the cla group documents require the docraptor renderer")
+)
+
+// PDFRenderer is a backend rendering the HTML templates as PDF
+type PDFRenderer interface {
+	CreatePDF(html string, claType string) (io.ReadCloser, error)
+}
+
+// previewOnlyRenderer is implemented by the renderers whose output can't be used for the CLA Group documents
+type previewOnlyRenderer interface {
+	PreviewOnly() bool
+}
+
+// isPreviewOnly returns true if the renderer output is only suitable for the template previews
+func isPreviewOnly(renderer PDFRenderer) bool {
+	previewRenderer, ok := renderer.(previewOnlyRenderer)
+	return ok && previewRenderer.PreviewOnly()
+}
+
+// NewPDFRenderer returns the backend rendering the CLA Group documents, DocRaptor remains the default
+func NewPDFRenderer(configFile config.Config) (PDFRenderer, error) {
+	return newPDFRenderer(configFile.PDFRenderer, configFile)
+}
+
+// NewPreviewPDFRenderer returns the backend rendering the template previews, the previews are rendered by the document
+// renderer unless a preview renderer is configured
+func NewPreviewPDFRenderer(configFile config.Config) (PDFRenderer, error) {
+	if configFile.PreviewPDFRenderer == "" {
+		return NewPDFRenderer(configFile)
+	}
+	return newPDFRenderer(configFile.PreviewPDFRenderer, configFile)
+}
+
+func newPDFRenderer(renderer string, configFile config.Config) (PDFRenderer, error) {
+	switch renderer {
+	case "", PDFRendererDocraptor:
+		docraptorClient, err := docraptor.NewDocraptorClient(configFile.Docraptor.APIKey, configFile.Docraptor.TestMode)
+		if err != nil {
+			return nil, err
+		}
+		return docraptorClient, nil
+	case PDFRendererLocal:
+		return localpdf.NewRenderer(), nil
+	default:
+		return nil, fmt.Errorf("unsupported pdf renderer: %s", renderer)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -26,6 +27,10 @@ import (
 var (
 	// ErrTemplateNotFound error
 	ErrTemplateNotFound = errors.New("template not found")
+	// ErrCLAGroupNotFound error
+	ErrCLAGroupNotFound = errors.New("cla group not found")
+	// ErrCLADocumentChanged is returned when the CLA Group documents were updated while updating a document
+	ErrCLADocumentChanged = errors.New("cla group document was modified concurrently")
 )
 
 var (
@@ -44,6 +49,10 @@ type RepositoryInterface interface {
 	GetCLAGroup(claGroupID string) (*models.ClaGroup, error)
 	GetCLADocuments(claGroupID string, claType string) ([]models.ClaGroupDocument, error)
 	UpdateDynamoContractGroupTemplates(ctx context.Context, ContractGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error
+	UpdateCLAGroupTemplatePin(ctx context.Context, claGroupID, templateID string, templateVersion int64) error
+	GetCLAGroupTemplatePin(ctx context.Context, claGroupID string) (*TemplatePin, error)
+	GetCLADocumentModels(ctx context.Context, claGroupID, claType string) ([]DBProjectDocumentModel, error)
+	UpdateCLADocumentLocales(ctx context.Context, claGroupID, claType string, documentIndex int, documentCreationDate string, locales []DBProjectDocumentLocaleModel) error
 }
 
 // Repository object/struct
@@ -267,6 +276,193 @@ func (r Repository) buildProjectModel(dbModel DBProjectModel) *models.ClaGroup {
 	}
 }
 
+// UpdateCLAGroupTemplatePin records the template and the template version the documents of the CLA Group were
+// generated from, the version is zero for the built-in templates
+func (r Repository) UpdateCLAGroupTemplatePin(ctx context.Context, claGroupID, templateID string, templateVersion int64) error {
+	f := logrus.Fields{
+		"functionName":    "v1.template.repository.UpdateCLAGroupTemplatePin",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"claGroupID":      claGroupID,
+		"templateID":      templateID,
+		"templateVersion": templateVersion,
+	}
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	_, now := utils.CurrentTime()
+	_, err := r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#project_template_id":          aws.String("project_template_id"),
+			"#project_template_version":     aws.String("project_template_version"),
+			"#project_template_date_pinned": aws.String("project_template_date_pinned"),
+			"#date_modified":                aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":project_template_id": {
+				S: aws.String(templateID),
+			},
+			":project_template_version": {
+				N: aws.String(strconv.FormatInt(templateVersion, 10)),
+			},
+			":project_template_date_pinned": {
+				S: aws.String(now),
+			},
+			":date_modified": {
+				S: aws.String(now),
+			},
+		},
+		TableName:        aws.String(tableName),
+		UpdateExpression: aws.String("SET #project_template_id = :project_template_id, #project_template_version = :project_template_version, #project_template_date_pinned = :project_template_date_pinned, #date_modified = :date_modified"),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the CLA Group template pin")
+		return err
+	}
+
+	return nil
+}
+
+// GetCLAGroupTemplatePin returns the template and the template version the documents of the CLA Group were generated
+// from
+func (r Repository) GetCLAGroupTemplatePin(ctx context.Context, claGroupID string) (*TemplatePin, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.repository.GetCLAGroupTemplatePin",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	dbModel, err := r.fetchCLAGroup(claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group")
+		return nil, err
+	}
+	if dbModel.ProjectID == "" {
+		return nil, ErrCLAGroupNotFound
+	}
+
+	return &TemplatePin{
+		CLAGroupID:      dbModel.ProjectID,
+		TemplateID:      dbModel.ProjectTemplateID,
+		TemplateVersion: dbModel.ProjectTemplateVersion,
+		DatePinned:      dbModel.ProjectTemplateDatePinned,
+	}, nil
+}
+
+// claDocumentsAttribute returns the CLA Group documents attribute name of the CLA type
+func claDocumentsAttribute(claType string) (string, error) {
+	switch claType {
+	case claTypeICLA:
+		return "project_individual_documents", nil
+	case claTypeCCLA:
+		return "project_corporate_documents", nil
+	default:
+		return "", fmt.Errorf("not supported cla type supplied: %s", claType)
+	}
+}
+
+// GetCLADocumentModels returns the database models of the CLA Group documents of the CLA type
+func (r Repository) GetCLADocumentModels(ctx context.Context, claGroupID, claType string) ([]DBProjectDocumentModel, error) {
+	f := logrus.Fields{
+		"functionName":   "v1.template.repository.GetCLADocumentModels",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+
+	dbModel, err := r.fetchCLAGroup(claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group")
+		return nil, err
+	}
+	if dbModel.ProjectID == "" {
+		return nil, ErrCLAGroupNotFound
+	}
+
+	switch claType {
+	case claTypeICLA:
+		return dbModel.ProjectIndividualDocuments, nil
+	case claTypeCCLA:
+		return dbModel.ProjectCorporateDocuments, nil
+	default:
+		return nil, fmt.Errorf("not supported cla type supplied: %s", claType)
+	}
+}
+
+// UpdateCLADocumentLocales replaces the translations of the CLA Group document at the specified index of the
+// document list, the update fails with ErrCLADocumentChanged when the document at the index is no longer the
+// document with the specified creation date
+func (r Repository) UpdateCLADocumentLocales(ctx context.Context, claGroupID, claType string, documentIndex int, documentCreationDate string, locales []DBProjectDocumentLocaleModel) error {
+	f := logrus.Fields{
+		"functionName":   "v1.template.repository.UpdateCLADocumentLocales",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+		"documentIndex":  documentIndex,
+		"locales":        len(locales),
+	}
+	tableName := fmt.Sprintf("cla-%s-projects", r.stage)
+
+	documentsAttribute, err := claDocumentsAttribute(claType)
+	if err != nil {
+		return err
+	}
+
+	// An empty list is stored instead of removing the attribute
+	if locales == nil {
+		locales = []DBProjectDocumentLocaleModel{}
+	}
+	localesAttribute, err := dynamodbattribute.MarshalList(locales)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the CLA Group document locales")
+		return err
+	}
+
+	_, now := utils.CurrentTime()
+	document := fmt.Sprintf("#documents[%d]", documentIndex)
+	_, err = r.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"project_id": {
+				S: aws.String(claGroupID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#documents":              aws.String(documentsAttribute),
+			"#document_locales":       aws.String("document_locales"),
+			"#document_creation_date": aws.String("document_creation_date"),
+			"#date_modified":          aws.String("date_modified"),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":document_locales": {
+				L: localesAttribute,
+			},
+			":document_creation_date": {
+				S: aws.String(documentCreationDate),
+			},
+			":date_modified": {
+				S: aws.String(now),
+			},
+		},
+		ConditionExpression: aws.String(fmt.Sprintf("%s.#document_creation_date = :document_creation_date", document)),
+		TableName:           aws.String(tableName),
+		UpdateExpression:    aws.String(fmt.Sprintf("SET %s.#document_locales = :document_locales, #date_modified = :date_modified", document)),
+	})
+	if err != nil {
+		var conditionErr *dynamodb.ConditionalCheckFailedException
+		if errors.As(err, &conditionErr) {
+			log.WithFields(f).WithError(err).Warn("the CLA Group document was modified concurrently")
+			return ErrCLADocumentChanged
+		}
+		log.WithFields(f).WithError(err).Warn("unable to update the CLA Group document locales")
+		return err
+	}
+
+	return nil
+}
+
 // UpdateDynamoContractGroupTemplates updates the templates in the data store
 func (r Repository) UpdateDynamoContractGroupTemplates(ctx context.Context, claGroupID string, template models.Template, pdfUrls models.TemplatePdfs, projectCCLAEnabled, projectICLAEnabled bool) error {
 	f := logrus.Fields{
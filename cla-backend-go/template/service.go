@@ -18,7 +18,6 @@ import (
 
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 
-	"github.com/linuxfoundation/easycla/cla-backend-go/docraptor"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -37,26 +36,43 @@ type ServiceInterface interface {
 	GetTemplates(ctx context.Context) ([]models.Template, error)
 	GetTemplateName(ctx context.Context, templateID string) (string, error)
 	CreateCLAGroupTemplate(ctx context.Context, claGroupID string, claGroupFields *models.CreateClaGroupTemplate) (models.TemplatePdfs, error)
-	CreateTemplatePreview(ctx context.Context, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
+	CreateTemplatePreview(ctx context.Context, foundationSFID string, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error)
 	GetCLATemplatePreview(ctx context.Context, claGroupID, claType string, watermark bool) ([]byte, error)
 	CLAGroupTemplateExists(ctx context.Context, templateID string) bool
+	GetCLAGroupTemplatePin(ctx context.Context, claGroupID string) (*TemplatePin, error)
+	PutCLAGroupDocumentLocale(ctx context.Context, claGroupID string, input *DocumentLocaleInput) (*models.ClaGroupDocumentLocale, error)
+	DeleteCLAGroupDocumentLocale(ctx context.Context, claGroupID, claType, locale string) error
+
+	ValidateCustomTemplate(ctx context.Context, input *CustomTemplateInput) *TemplateValidation
+	CreateCustomTemplate(ctx context.Context, input *CustomTemplateInput, createdBy string) (*CustomTemplate, *CustomTemplateVersion, error)
+	UpdateCustomTemplate(ctx context.Context, templateID string, input *CustomTemplateInput, createdBy string) (*CustomTemplate, *CustomTemplateVersion, error)
+	GetCustomTemplate(ctx context.Context, templateID string) (*CustomTemplate, *CustomTemplateVersion, error)
+	ListCustomTemplates(ctx context.Context, foundationSFID string) ([]*CustomTemplate, error)
+	ArchiveCustomTemplate(ctx context.Context, templateID string) error
+	GetCustomTemplateVersion(ctx context.Context, templateID string, version int64) (*CustomTemplateVersion, error)
+	ListCustomTemplateVersions(ctx context.Context, templateID string) ([]*CustomTemplateVersion, error)
+	DiffCustomTemplateVersions(ctx context.Context, templateID string, fromVersion, toVersion int64) (*TemplateVersionDiff, error)
 }
 
 // Service object/struct
 type Service struct {
-	stage           string // The AWS stage (dev, staging, prod)
-	templateRepo    RepositoryInterface
-	docRaptorClient docraptor.Client
-	s3Client        *s3manager.Uploader
+	stage              string // The AWS stage (dev, staging, prod)
+	templateRepo       RepositoryInterface
+	customTemplateRepo CustomTemplateRepository
+	pdfRenderer        PDFRenderer
+	previewRenderer    PDFRenderer
+	s3Client           *s3manager.Uploader
 }
 
 // NewService API call
-func NewService(stage string, templateRepo RepositoryInterface, docRaptorClient docraptor.Client, awsSession *session.Session) Service {
+func NewService(stage string, templateRepo RepositoryInterface, customTemplateRepo CustomTemplateRepository, pdfRenderer, previewRenderer PDFRenderer, awsSession *session.Session) Service {
 	return Service{
-		stage:           stage,
-		templateRepo:    templateRepo,
-		docRaptorClient: docRaptorClient,
-		s3Client:        s3manager.NewUploader(awsSession),
+		stage:              stage,
+		templateRepo:       templateRepo,
+		customTemplateRepo: customTemplateRepo,
+		pdfRenderer:        pdfRenderer,
+		previewRenderer:    previewRenderer,
+		s3Client:           s3manager.NewUploader(awsSession),
 	}
 }
 
@@ -96,14 +112,25 @@ func (s Service) GetTemplateName(ctx context.Context, templateID string) (string
 		return "", err
 	}
 
+	if templateName == "" && s.customTemplateRepo != nil {
+		customTemplate, customErr := s.customTemplateRepo.GetCustomTemplate(ctx, templateID)
+		if customErr != nil {
+			log.WithFields(f).WithError(customErr).Warnf("problem loading custom template by ID: %s", templateID)
+			return "", customErr
+		}
+		templateName = customTemplate.Name
+	}
+
 	return templateName, nil
 }
 
-// CreateTemplatePreview returns a PDF using the specified CLA Group field values and template identifier
-func (s Service) CreateTemplatePreview(ctx context.Context, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error) {
+// CreateTemplatePreview returns a PDF using the specified CLA Group field values and template identifier, only the
+// built-in templates and the custom templates of the foundation can be previewed
+func (s Service) CreateTemplatePreview(ctx context.Context, foundationSFID string, claGroupFields *models.CreateClaGroupTemplate, templateFor string) ([]byte, error) {
 	f := logrus.Fields{
 		"functionName":   "v1.template.service.CreateTemplatePreview",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": foundationSFID,
 		"templateID":     claGroupFields.TemplateID,
 		"templateFor":    templateFor,
 	}
@@ -117,7 +144,7 @@ func (s Service) CreateTemplatePreview(ctx context.Context, claGroupFields *mode
 	log.WithFields(f).Debugf("using template ID: %s", templateID)
 
 	// Get Template
-	template, err = s.templateRepo.GetTemplate(templateID)
+	template, _, err = s.resolveTemplate(ctx, foundationSFID, templateID, claGroupFields.TemplateVersion)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("unable to fetch template fields : %s",
 			claGroupFields.TemplateID)
@@ -141,9 +168,9 @@ func (s Service) CreateTemplatePreview(ctx context.Context, claGroupFields *mode
 		return nil, errors.New("invalid value of template_for")
 	}
 
-	ioReader, err := s.docRaptorClient.CreatePDF(templateHTML, templateFor)
+	ioReader, err := s.previewRenderer.CreatePDF(templateHTML, templateFor)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("problem with API call to docraptor service")
+		log.WithFields(f).WithError(err).Warn("problem rendering the template PDF")
 		return nil, err
 	}
 	defer func() {
@@ -171,6 +198,12 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		"claGroupFields": claGroupFields,
 	}
 
+	// The DocuSign tab positions of the documents assume the DocRaptor layout
+	if isPreviewOnly(s.pdfRenderer) {
+		log.WithFields(f).Warn("the configured pdf renderer only renders template previews, configure it as the preview pdf renderer - returning empty template PDFs")
+		return models.TemplatePdfs{}, ErrPreviewOnlyRenderer
+	}
+
 	// Verify claGroupID matches an existing CLA Group
 	claGroup, err := s.templateRepo.GetCLAGroup(claGroupID)
 	if err != nil {
@@ -178,13 +211,29 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		return models.TemplatePdfs{}, err
 	}
 
-	// Get Template
-	template, err := s.templateRepo.GetTemplate(claGroupFields.TemplateID)
+	// Get Template - the custom templates must belong to the foundation of the CLA Group
+	template, customVersion, err := s.resolveTemplate(ctx, claGroup.ProjectExternalID, claGroupFields.TemplateID, claGroupFields.TemplateVersion)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("Unable to fetch template id: %s - returning empty template PDFs",
 			claGroupFields.TemplateID)
 		return models.TemplatePdfs{}, err
 	}
+	var templateVersion int64
+	if customVersion != nil {
+		templateVersion = customVersion.Version
+	}
+
+	// The new documents must be the latest documents of the CLA Group
+	var claGroupDocuments []models.ClaGroupDocument
+	for _, claType := range []string{claTypeICLA, claTypeCCLA} {
+		documents, docErr := s.templateRepo.GetCLADocuments(claGroupID, claType)
+		if docErr != nil {
+			log.WithFields(f).WithError(docErr).Warnf("Unable to fetch the %s documents of the CLA group - returning empty template PDFs", claType)
+			return models.TemplatePdfs{}, docErr
+		}
+		claGroupDocuments = append(claGroupDocuments, documents...)
+	}
+	template.TemplateMajorVersion, template.TemplateMinorVersion = nextDocumentVersion(claGroupDocuments, template.TemplateMajorVersion, template.TemplateMinorVersion)
 
 	// Apply template fields
 	iclaTemplateHTML, cclaTemplateHTML, err := s.InjectProjectInformationIntoTemplate(template, claGroupFields.MetaFields)
@@ -208,9 +257,9 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		// Invoke the go routine - any errors will be handled below
 		eg.Go(func() error {
 			log.WithFields(f).Debugf("Creating PDF for %s", claTypeICLA)
-			ioReader, iclaErr := s.docRaptorClient.CreatePDF(iclaTemplateHTML, claTypeICLA)
+			ioReader, iclaErr := s.pdfRenderer.CreatePDF(iclaTemplateHTML, claTypeICLA)
 			if iclaErr != nil {
-				log.WithFields(f).WithError(iclaErr).Warn("Problem generating ICLA template via docraptor client - returning empty template PDFs")
+				log.WithFields(f).WithError(iclaErr).Warn("Problem generating ICLA template PDF - returning empty template PDFs")
 				return err
 			}
 			defer func() {
@@ -235,9 +284,9 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		// Invoke the go routine - any errors will be handled below
 		eg.Go(func() error {
 			log.WithFields(f).Debugf("Creating PDF for %s", claTypeCCLA)
-			ioReader, cclaErr := s.docRaptorClient.CreatePDF(cclaTemplateHTML, claTypeCCLA)
+			ioReader, cclaErr := s.pdfRenderer.CreatePDF(cclaTemplateHTML, claTypeCCLA)
 			if cclaErr != nil {
-				log.WithFields(f).WithError(cclaErr).Warn("Problem generating CCLA template via docraptor client - returning empty template PDFs")
+				log.WithFields(f).WithError(cclaErr).Warn("Problem generating CCLA template PDF - returning empty template PDFs")
 				return err
 			}
 			defer func() {
@@ -289,6 +338,12 @@ func (s Service) CreateCLAGroupTemplate(ctx context.Context, claGroupID string,
 		return models.TemplatePdfs{}, err
 	}
 
+	// The documents are created, a pin failure is not reported to the caller
+	err = s.templateRepo.UpdateCLAGroupTemplatePin(ctx, claGroupID, template.ID, templateVersion)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("Problem pinning the CLA group to the template version %d", templateVersion)
+	}
+
 	return pdfUrls, nil
 }
 
@@ -554,5 +609,12 @@ func (s Service) SaveTemplateToS3(bucket, filepath string, template io.ReadClose
 
 // CLAGroupTemplateExists return true if the specified template ID exists, false otherwise
 func (s Service) CLAGroupTemplateExists(ctx context.Context, templateID string) bool {
-	return s.templateRepo.CLAGroupTemplateExists(ctx, templateID)
+	if s.templateRepo.CLAGroupTemplateExists(ctx, templateID) {
+		return true
+	}
+	if s.customTemplateRepo == nil {
+		return false
+	}
+	customTemplate, err := s.customTemplateRepo.GetCustomTemplate(ctx, templateID)
+	return err == nil && customTemplate.Status == CustomTemplateStatusActive
 }
@@ -0,0 +1,89 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/localpdf"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepository struct {
+	RepositoryInterface
+	template models.Template
+}
+
+func (r *fakeRepository) GetTemplate(templateID string) (models.Template, error) {
+	return r.template, nil
+}
+
+func TestNewPDFRenderer(t *testing.T) {
+	renderer, err := NewPDFRenderer(config.Config{PDFRenderer: PDFRendererLocal})
+	assert.NoError(t, err)
+	assert.IsType(t, &localpdf.Renderer{}, renderer)
+
+	// DocRaptor is the default backend and requires its API key
+	_, err = NewPDFRenderer(config.Config{})
+	assert.Error(t, err)
+
+	_, err = NewPDFRenderer(config.Config{PDFRenderer: "wkhtmltopdf"})
+	assert.Error(t, err)
+}
+
+func TestNewPreviewPDFRenderer(t *testing.T) {
+	renderer, err := NewPreviewPDFRenderer(config.Config{PDFRenderer: PDFRendererDocraptor, PreviewPDFRenderer: PDFRendererLocal})
+	assert.NoError(t, err)
+	assert.IsType(t, &localpdf.Renderer{}, renderer)
+
+	// The previews fall back to the document renderer
+	renderer, err = NewPreviewPDFRenderer(config.Config{PDFRenderer: PDFRendererLocal})
+	assert.NoError(t, err)
+	assert.IsType(t, &localpdf.Renderer{}, renderer)
+
+	_, err = NewPreviewPDFRenderer(config.Config{PreviewPDFRenderer: "wkhtmltopdf"})
+	assert.Error(t, err)
+}
+
+func TestCreateTemplatePreviewWithLocalRenderer(t *testing.T) {
+	s := Service{
+		templateRepo: &fakeRepository{template: models.Template{
+			ID:   ApacheStyleTemplateID,
+			Name: "Apache Style",
+			MetaFields: []*models.MetaField{
+				{Name: "Project Name", TemplateVariable: "PROJECT_NAME"},
+			},
+			IclaHTMLBody: "<html><body><h1>{{PROJECT_NAME}} Individual Contributor License Agreement</h1><p>Thank you.</p></body></html>",
+			CclaHTMLBody: "<html><body><h1>{{PROJECT_NAME}} Corporate Contributor License Agreement</h1><p>Thank you.</p></body></html>",
+		}},
+		previewRenderer: localpdf.NewRenderer(),
+	}
+
+	pdf, err := s.CreateTemplatePreview(context.Background(), "foundation-sfid", &models.CreateClaGroupTemplate{
+		MetaFields: []*models.MetaField{
+			{Name: "Project Name", TemplateVariable: "PROJECT_NAME", Value: "Acme"},
+		},
+	}, utils.ClaTypeCCLA)
+	assert.NoError(t, err)
+	pageCount, err := api.PageCount(bytes.NewReader(pdf), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pageCount)
+}
+
+func TestCreateCLAGroupTemplateRefusesPreviewOnlyRenderer(t *testing.T) {
+	s := Service{
+		templateRepo: &fakeRepository{},
+		pdfRenderer:  localpdf.NewRenderer(),
+	}
+
+	pdfUrls, err := s.CreateCLAGroupTemplate(context.Background(), "cla-group-id", &models.CreateClaGroupTemplate{TemplateID: ApacheStyleTemplateID})
+	assert.ErrorIs(t, err, ErrPreviewOnlyRenderer)
+	assert.Equal(t, models.TemplatePdfs{}, pdfUrls)
+}
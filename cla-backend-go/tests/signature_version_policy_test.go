@@ -0,0 +1,75 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func versionPolicyCLAGroup(policy, expiryDate string) *models.ClaGroup {
+	return &models.ClaGroup{
+		ProjectID:              "cla-group-id",
+		SignatureVersionPolicy: policy,
+		SignatureExpiryDate:    expiryDate,
+		ProjectIndividualDocuments: []models.ClaGroupDocument{
+			{DocumentMajorVersion: "1", DocumentMinorVersion: "0", DocumentCreationDate: "2020-01-01T00:00:00Z"},
+			{DocumentMajorVersion: "2", DocumentMinorVersion: "0", DocumentCreationDate: "2026-01-01T00:00:00Z"},
+		},
+		ProjectCorporateDocuments: []models.ClaGroupDocument{
+			{DocumentMajorVersion: "1", DocumentMinorVersion: "0", DocumentCreationDate: "2020-01-01T00:00:00Z"},
+		},
+	}
+}
+
+func TestIsValidSignatureVersionPolicy(t *testing.T) {
+	assert.True(t, common.IsValidSignatureVersionPolicy(common.SignatureVersionPolicyAcceptPrevious))
+	assert.True(t, common.IsValidSignatureVersionPolicy(common.SignatureVersionPolicyResign))
+	assert.True(t, common.IsValidSignatureVersionPolicy(common.SignatureVersionPolicyExpire))
+	assert.False(t, common.IsValidSignatureVersionPolicy("never"))
+}
+
+func TestIsSignatureDocumentOutdated(t *testing.T) {
+	ctx := context.Background()
+	claGroup := versionPolicyCLAGroup(common.SignatureVersionPolicyResign, "")
+
+	outdated, currentMajor := common.IsSignatureDocumentOutdated(ctx, claGroup, &models.Signature{SignatureDocumentMajorVersion: "1"})
+	assert.True(t, outdated, "ICLA signed against version 1 is outdated")
+	assert.Equal(t, 2, currentMajor)
+
+	outdated, _ = common.IsSignatureDocumentOutdated(ctx, claGroup, &models.Signature{SignatureDocumentMajorVersion: "2"})
+	assert.False(t, outdated, "ICLA signed against version 2 is current")
+
+	outdated, _ = common.IsSignatureDocumentOutdated(ctx, claGroup, &models.Signature{
+		SignatureDocumentMajorVersion: "1",
+		SignatureReferenceType:        utils.SignatureReferenceTypeCompany,
+	})
+	assert.False(t, outdated, "CCLA signed against version 1 is current")
+
+	outdated, _ = common.IsSignatureDocumentOutdated(ctx, claGroup, &models.Signature{})
+	assert.False(t, outdated, "signature without a document version is treated as current")
+}
+
+func TestIsSignatureVersionAccepted(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	oldSignature := &models.Signature{SignatureDocumentMajorVersion: "1"}
+	currentSignature := &models.Signature{SignatureDocumentMajorVersion: "2"}
+
+	assert.True(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup("", ""), oldSignature, now), "no policy")
+	assert.True(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup(common.SignatureVersionPolicyAcceptPrevious, ""), oldSignature, now), "accept previous")
+
+	assert.False(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup(common.SignatureVersionPolicyResign, ""), oldSignature, now), "resign - old version")
+	assert.True(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup(common.SignatureVersionPolicyResign, ""), currentSignature, now), "resign - current version")
+
+	assert.True(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup(common.SignatureVersionPolicyExpire, "2026-12-31T00:00:00Z"), oldSignature, now), "expire - before expiry date")
+	assert.False(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup(common.SignatureVersionPolicyExpire, "2026-01-31T00:00:00Z"), oldSignature, now), "expire - after expiry date")
+	assert.True(t, common.IsSignatureVersionAccepted(ctx, versionPolicyCLAGroup(common.SignatureVersionPolicyExpire, "2026-01-31T00:00:00Z"), currentSignature, now), "expire - current version")
+}
@@ -0,0 +1,63 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestBotAllowlistMatch tests the bot allowlist login and email pattern matching
+func TestBotAllowlistMatch(t *testing.T) {
+	allowlist := &utils.BotAllowlist{
+		Logins:        []string{"dependabot[bot]", "Renovate-Bot"},
+		EmailPatterns: []string{"*[bot]@users.noreply.github.com", "release-*@example.org", "ci@example.com"},
+	}
+
+	testCases := []struct {
+		name          string
+		login         string
+		email         string
+		expectedEntry string
+		expected      bool
+	}{
+		{name: "login", login: "dependabot[bot]", expectedEntry: "dependabot[bot]", expected: true},
+		{name: "login case-insensitive", login: "renovate-bot", expectedEntry: "Renovate-Bot", expected: true},
+		{name: "login not listed", login: "octocat", email: "octocat@example.com", expected: false},
+		{name: "email wildcard prefix", email: "49699333+dependabot[bot]@users.noreply.github.com", expectedEntry: "*[bot]@users.noreply.github.com", expected: true},
+		{name: "email wildcard middle", email: "Release-Train@example.org", expectedEntry: "release-*@example.org", expected: true},
+		{name: "email exact", email: "ci@example.com", expectedEntry: "ci@example.com", expected: true},
+		{name: "email exact no suffix match", email: "xci@example.com", expected: false},
+		{name: "email wildcard other domain", email: "release-train@example.org.evil.com", expected: false},
+		{name: "no identity", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			entry, matched := allowlist.Match(tc.login, tc.email)
+			assert.Equal(t, tc.expected, matched)
+			assert.Equal(t, tc.expectedEntry, entry)
+		})
+	}
+}
+
+// TestMergeBotAllowlists tests the CLA Group and organization allowlists merge
+func TestMergeBotAllowlists(t *testing.T) {
+	var nilAllowlist *utils.BotAllowlist
+	assert.True(t, nilAllowlist.IsEmpty())
+	assert.True(t, utils.MergeBotAllowlists(nil, nil).IsEmpty())
+
+	merged := utils.MergeBotAllowlists(
+		&utils.BotAllowlist{Logins: []string{"dependabot[bot]"}},
+		nil,
+		&utils.BotAllowlist{EmailPatterns: []string{"*@bots.example.org"}},
+	)
+	assert.False(t, merged.IsEmpty())
+	_, matched := merged.Match("dependabot[bot]", "")
+	assert.True(t, matched)
+	_, matched = merged.Match("", "release@bots.example.org")
+	assert.True(t, matched)
+}
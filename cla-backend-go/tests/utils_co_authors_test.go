@@ -0,0 +1,62 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseCoAuthors is a collection of unit tests for the ParseCoAuthors utility function
+func TestParseCoAuthors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		message  string
+		expected []utils.CoAuthor
+	}{
+		{
+			name:    "no trailers",
+			message: "Fix the build\n\nSigned-off-by: Jane Doe <jane@example.com>",
+		},
+		{
+			name:     "single trailer",
+			message:  "Fix the build\n\nCo-authored-by: Jane Doe <jane@example.com>",
+			expected: []utils.CoAuthor{{Name: "Jane Doe", Email: "jane@example.com"}},
+		},
+		{
+			name:    "multiple trailers with mixed case keys and CRLF line endings",
+			message: "Fix the build\r\n\r\nco-authored-by: Jane Doe <jane@example.com>\r\nCO-AUTHORED-BY:John Roe   <john@example.org>  \r\n",
+			expected: []utils.CoAuthor{
+				{Name: "Jane Doe", Email: "jane@example.com"},
+				{Name: "John Roe", Email: "john@example.org"},
+			},
+		},
+		{
+			name:     "duplicate emails are returned once",
+			message:  "Fix\n\nCo-authored-by: Jane Doe <jane@example.com>\nCo-authored-by: Jane <JANE@example.com>",
+			expected: []utils.CoAuthor{{Name: "Jane Doe", Email: "jane@example.com"}},
+		},
+		{
+			name:     "trailer without a name",
+			message:  "Fix\n\nCo-authored-by: <jane@example.com>",
+			expected: []utils.CoAuthor{{Name: "", Email: "jane@example.com"}},
+		},
+		{
+			name:    "trailer without an email is ignored",
+			message: "Fix\n\nCo-authored-by: Jane Doe\nCo-authored-by: John <not-an-email>",
+		},
+		{
+			name:    "trailer mentioned inside a sentence is ignored",
+			message: "Document the Co-authored-by: Jane <jane@example.com> trailer usage",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(tt *testing.T) {
+			assert.Equal(tt, tc.expected, utils.ParseCoAuthors(tc.message))
+		})
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEmailDomainMatchesPattern tests the domain approval list pattern matching
+func TestEmailDomainMatchesPattern(t *testing.T) {
+	type testCase struct {
+		email    string
+		pattern  string
+		expected bool
+	}
+
+	cases := []testCase{
+		// exact domains
+		{email: "harold@bar.com", pattern: "bar.com", expected: true},
+		{email: "Harold@Bar.COM", pattern: "bar.com", expected: true},
+		{email: "harold@help.bar.com", pattern: "bar.com", expected: false},
+		{email: "harold@barxcom", pattern: "bar.com", expected: false},
+		{email: "harold@foobar.com", pattern: "bar.com", expected: false},
+		// wildcard domains
+		{email: "harold@bar.com", pattern: "*.bar.com", expected: true},
+		{email: "harold@eu.bar.com", pattern: "*.bar.com", expected: true},
+		{email: "harold@dev.us.bar.com", pattern: "*.bar.com", expected: true},
+		{email: "harold@EU.bar.com", pattern: "*.Bar.com", expected: true},
+		{email: "harold@foobar.com", pattern: "*.bar.com", expected: false},
+		{email: "harold@bar.com.evil.org", pattern: "*.bar.com", expected: false},
+		{email: "harold@eu.foo.com", pattern: "*.bar.com", expected: false},
+		// older wildcard prefixes
+		{email: "harold@help.bar.com", pattern: "*bar.com", expected: true},
+		{email: "harold@help.bar.com", pattern: ".bar.com", expected: true},
+		{email: "harold@foobar.com", pattern: "*bar.com", expected: false},
+		// invalid input
+		{email: "harold", pattern: "bar.com", expected: false},
+		{email: "harold@", pattern: "*.bar.com", expected: false},
+		{email: "harold@bar.com", pattern: "", expected: false},
+		{email: "harold@bar.com", pattern: "*.", expected: false},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.expected, utils.EmailDomainMatchesPattern(tc.email, tc.pattern), fmt.Sprintf("email: %s, pattern: %s", tc.email, tc.pattern))
+	}
+}
+
+// TestEmailsMatchDomainPatterns tests matching a list of emails against a list of domain approval list patterns
+func TestEmailsMatchDomainPatterns(t *testing.T) {
+	pattern, matched := utils.EmailsMatchDomainPatterns([]string{"harold@gmail.com", "harold@eu.bar.com"}, []string{"foo.com", "*.bar.com"})
+	assert.True(t, matched)
+	assert.Equal(t, "*.bar.com", pattern)
+
+	pattern, matched = utils.EmailsMatchDomainPatterns([]string{"harold@gmail.com"}, []string{"foo.com", "*.bar.com"})
+	assert.False(t, matched)
+	assert.Equal(t, "", pattern)
+
+	_, matched = utils.EmailsMatchDomainPatterns(nil, []string{"*.bar.com"})
+	assert.False(t, matched)
+}
@@ -0,0 +1,59 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package tests
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// singlePagePDF builds a minimal single page PDF document
+func singlePagePDF(text string) []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	content := fmt.Sprintf("BT /F1 12 Tf 72 720 Td (%s) Tj ET", text)
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+	var offsets []int
+	for i, object := range objects {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+// TestAppendSignaturePage tests that the signature page is appended to the end of the document
+func TestAppendSignaturePage(t *testing.T) {
+	signed, err := utils.AppendSignaturePage(singlePagePDF("Contributor License Agreement"), []string{
+		"Electronic Signature Record",
+		"Accepted by: Jane Doe",
+	})
+	assert.Nil(t, err, "AppendSignaturePage - no error")
+
+	pageCount, err := api.PageCount(bytes.NewReader(signed), nil)
+	assert.Nil(t, err, "AppendSignaturePage - signed document is valid")
+	assert.Equal(t, 2, pageCount, "AppendSignaturePage - page count")
+}
+
+// TestAppendSignaturePageInvalidDocument tests that an invalid document is rejected
+func TestAppendSignaturePageInvalidDocument(t *testing.T) {
+	_, err := utils.AppendSignaturePage([]byte("not a pdf"), []string{"Electronic Signature Record"})
+	assert.NotNil(t, err, "AppendSignaturePage - invalid document")
+}
@@ -70,6 +70,10 @@ func TestValidDomain(t *testing.T) {
 		"*.linuxfoundation+fun.org",
 		"user_linuxfoundation.org",
 		"*.user_linuxfoundation.org",
+		"*.com",
+		"*.*.google.com",
+		"us.*.google.com",
+		"google.*",
 	}
 
 	for _, domain := range validDomains {
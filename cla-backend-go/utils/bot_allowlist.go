@@ -0,0 +1,85 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+)
+
+// BotAllowlist lists the bot and service account commit authors which are exempt from the CLA check
+type BotAllowlist struct {
+	// Logins are the GitHub or GitLab logins, e.g. dependabot[bot], compared case-insensitively
+	Logins []string
+	// EmailPatterns are the email addresses, a '*' matches any sequence of characters, e.g. *[bot]@users.noreply.github.com
+	// - the callers only match verified emails
+	EmailPatterns []string
+}
+
+// MergeBotAllowlists returns the union of the allowlists, e.g. the CLA Group and the GitHub organization ones - nil
+// allowlists are skipped
+func MergeBotAllowlists(allowlists ...*BotAllowlist) *BotAllowlist {
+	merged := &BotAllowlist{}
+	for _, allowlist := range allowlists {
+		if allowlist == nil {
+			continue
+		}
+		merged.Logins = append(merged.Logins, allowlist.Logins...)
+		merged.EmailPatterns = append(merged.EmailPatterns, allowlist.EmailPatterns...)
+	}
+	return merged
+}
+
+// IsEmpty returns true if the allowlist doesn't exempt any author
+func (b *BotAllowlist) IsEmpty() bool {
+	return b == nil || (len(b.Logins) == 0 && len(b.EmailPatterns) == 0)
+}
+
+// Match returns the allowlist entry matching the login or the email of the commit author and true, or an empty string
+// and false if the author is not exempt
+func (b *BotAllowlist) Match(login, email string) (string, bool) {
+	if b.IsEmpty() {
+		return "", false
+	}
+	login = strings.TrimSpace(login)
+	if login != "" {
+		for _, entry := range b.Logins {
+			if strings.EqualFold(strings.TrimSpace(entry), login) {
+				return entry, true
+			}
+		}
+	}
+	email = strings.ToLower(strings.TrimSpace(email))
+	if email != "" {
+		for _, pattern := range b.EmailPatterns {
+			if emailMatchesBotPattern(email, strings.ToLower(strings.TrimSpace(pattern))) {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+// emailMatchesBotPattern returns true if the lower case email matches the lower case pattern, a '*' in the pattern
+// matches any sequence of characters
+func emailMatchesBotPattern(email, pattern string) bool {
+	if pattern == "" {
+		return false
+	}
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return email == pattern
+	}
+	if !strings.HasPrefix(email, parts[0]) {
+		return false
+	}
+	remaining := email[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(remaining, part)
+		if idx < 0 {
+			return false
+		}
+		remaining = remaining[idx+len(part):]
+	}
+	return strings.HasSuffix(remaining, parts[len(parts)-1])
+}
@@ -0,0 +1,25 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+)
+
+// BotAllowlistFromV1Model converts the CLA Group or GitHub organization bot allowlist, nil if not set
+func BotAllowlistFromV1Model(in *v1Models.BotAllowlist) *BotAllowlist {
+	if in == nil {
+		return nil
+	}
+	return &BotAllowlist{Logins: in.Logins, EmailPatterns: in.EmailPatterns}
+}
+
+// BotAllowlistFromV2Model converts the GitLab organization bot allowlist, nil if not set
+func BotAllowlistFromV2Model(in *models.BotAllowlist) *BotAllowlist {
+	if in == nil {
+		return nil
+	}
+	return &BotAllowlist{Logins: in.Logins, EmailPatterns: in.EmailPatterns}
+}
@@ -0,0 +1,36 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+// coAuthorTrailerRegex matches the Co-authored-by commit message trailers, the key is case insensitive
+var coAuthorTrailerRegex = regexp.MustCompile(`(?im)^[ \t]*co-authored-by:[ \t]*([^<\r\n]*?)[ \t]*<([^<>\s]+@[^<>\s]+)>[ \t\r]*$`)
+
+// CoAuthor is a commit co-author declared by a Co-authored-by trailer of the commit message
+type CoAuthor struct {
+	Name  string
+	Email string
+}
+
+// ParseCoAuthors returns the co-authors declared in the commit message, each email is only returned once
+func ParseCoAuthors(message string) []CoAuthor {
+	var coAuthors []CoAuthor
+	seen := make(map[string]bool)
+	for _, match := range coAuthorTrailerRegex.FindAllStringSubmatch(message, -1) {
+		email := strings.TrimSpace(match[2])
+		if seen[strings.ToLower(email)] {
+			continue
+		}
+		seen[strings.ToLower(email)] = true
+		coAuthors = append(coAuthors, CoAuthor{
+			Name:  strings.TrimSpace(match[1]),
+			Email: email,
+		})
+	}
+	return coAuthors
+}
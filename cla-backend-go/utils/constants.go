@@ -159,6 +159,24 @@ const GitLab = "GitLab"
 // GitLabLower is the GitLab spelled out in lower case
 const GitLabLower = "gitlab"
 
+// Bitbucket is the Bitbucket spelled out with the proper case
+const Bitbucket = "Bitbucket"
+
+// BitbucketLower is the Bitbucket spelled out in lower case
+const BitbucketLower = "bitbucket"
+
+// Gitea is the Gitea spelled out with the proper case - Forgejo instances share the Gitea API
+const Gitea = "Gitea"
+
+// GiteaLower is the Gitea spelled out in lower case, also used as the repository type
+const GiteaLower = "gitea"
+
+// Gerrit is the Gerrit spelled out with the proper case
+const Gerrit = "Gerrit"
+
+// GerritLower is the Gerrit spelled out in lower case
+const GerritLower = "gerrit"
+
 // GitLabRepoNotFound is a string that indicates the GitLab repository is not found
 const GitLabRepoNotFound = "GitLab repository not found"
 
@@ -0,0 +1,65 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"strings"
+)
+
+// DomainPatternWildcardPrefix is the prefix of a domain approval list entry matching a domain and all its subdomains
+const DomainPatternWildcardPrefix = "*."
+
+// EmailDomainMatchesPattern returns true if the domain of the email matches the domain approval list pattern.
+//
+// A plain domain such as example.com only matches that exact domain. A wildcard pattern such as *.example.com matches
+// example.com and any of its subdomains at any depth, e.g. eu.example.com or dev.us.example.com. Older approval list
+// entries using the '*' or '.' prefix, e.g. *example.com or .example.com, are handled as wildcard patterns.
+// Comparisons are case-insensitive.
+func EmailDomainMatchesPattern(email, pattern string) bool {
+	email = strings.ToLower(strings.TrimSpace(email))
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	at := strings.LastIndex(email, "@")
+	if at < 0 || at == len(email)-1 || pattern == "" {
+		return false
+	}
+	emailDomain := email[at+1:]
+
+	baseDomain, wildcard := parseDomainPattern(pattern)
+	if baseDomain == "" {
+		return false
+	}
+	if emailDomain == baseDomain {
+		return true
+	}
+
+	return wildcard && strings.HasSuffix(emailDomain, "."+baseDomain)
+}
+
+// EmailsMatchDomainPatterns returns the first domain approval list pattern matching one of the emails and true, or an
+// empty string and false if none of the emails match
+func EmailsMatchDomainPatterns(emails, patterns []string) (string, bool) {
+	for _, pattern := range patterns {
+		for _, email := range emails {
+			if EmailDomainMatchesPattern(email, pattern) {
+				return pattern, true
+			}
+		}
+	}
+	return "", false
+}
+
+// parseDomainPattern returns the base domain of the domain approval list pattern and whether the pattern also matches
+// the subdomains of the base domain
+func parseDomainPattern(pattern string) (string, bool) {
+	switch {
+	case strings.HasPrefix(pattern, DomainPatternWildcardPrefix):
+		return strings.TrimPrefix(pattern, DomainPatternWildcardPrefix), true
+	case strings.HasPrefix(pattern, "*"):
+		return strings.TrimPrefix(pattern, "*"), true
+	case strings.HasPrefix(pattern, "."):
+		return strings.TrimPrefix(pattern, "."), true
+	}
+	return pattern, false
+}
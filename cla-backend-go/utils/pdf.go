@@ -7,6 +7,8 @@ import (
 	"bufio"
 	"bytes"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
@@ -32,3 +34,33 @@ func WatermarkPdf(pdf []byte, text string) ([]byte, error) {
 
 	return b.Bytes(), nil
 }
+
+// AppendSignaturePage appends a new page to the end of the given pdf blob with the provided lines
+// of text stamped on it, this is used to record the signature details of a click-through signature
+func AppendSignaturePage(pdf []byte, lines []string) ([]byte, error) {
+	pageCount, err := api.PageCount(bytes.NewReader(pdf), nil)
+	if err != nil {
+		return nil, fmt.Errorf("reading page count failed : %w", err)
+	}
+
+	var withPage bytes.Buffer
+	err = api.InsertPages(bytes.NewReader(pdf), &withPage, []string{strconv.Itoa(pageCount)}, false, nil)
+	if err != nil {
+		return nil, fmt.Errorf("inserting signature page failed : %w", err)
+	}
+
+	// this means it's a stamp
+	onTop := true
+	wm, err := pdfcpu.ParseTextWatermarkDetails(strings.Join(lines, "\n"), "fontname:Helvetica, points:10, scalefactor:1 abs, position:tl, offset:40 -40, rotation:0, opacity:1, aligntext:l, fillcolor:0 0 0", onTop)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	err = api.AddWatermarks(bytes.NewReader(withPage.Bytes()), &b, []string{strconv.Itoa(pageCount + 1)}, wm, nil)
+	if err != nil {
+		return nil, fmt.Errorf("stamping signature page failed : %w", err)
+	}
+
+	return b.Bytes(), nil
+}
@@ -27,6 +27,7 @@ const PresignedURLValidity = 15 * time.Minute
 type S3Storage interface {
 	Upload(fileContent []byte, projectID string, claType string, identifier string, signatureID string) error
 	UploadFile(file *os.File, projectID string, claType string, identifier string, signatureID string) error
+	UploadObject(key string, fileContent []byte) error
 	Download(filename string) ([]byte, error)
 	Delete(filename string) error
 	GetPresignedURL(filename string) (string, error)
@@ -72,6 +73,16 @@ func (s3c *S3Client) UploadFile(file *os.File, projectID string, claType string,
 	return err
 }
 
+// UploadObject uploads the content to s3 storage at the specified key
+func (s3c *S3Client) UploadObject(key string, fileContent []byte) error {
+	_, err := s3c.s3.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s3c.BucketName),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(fileContent),
+	})
+	return err
+}
+
 // Download file from s3
 func (s3c *S3Client) Download(filename string) ([]byte, error) {
 	ou, err := s3c.s3.GetObject(&s3.GetObjectInput{
@@ -137,6 +148,14 @@ func UploadFileToS3(file *os.File, projectID string, claType string, identifier
 	return s3Storage.UploadFile(file, projectID, claType, identifier, signatureID)
 }
 
+// UploadObjectToS3 uploads the content to s3 storage at the specified key
+func UploadObjectToS3(key string, body []byte) error {
+	if s3Storage == nil {
+		return errors.New("s3Storage not set")
+	}
+	return s3Storage.UploadObject(key, body)
+}
+
 func DocumentExists(key string) (bool, error) {
 	if s3Storage == nil {
 		return false, errors.New("s3 storage not set")
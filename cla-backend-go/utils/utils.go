@@ -113,6 +113,15 @@ func AddNumberAttribute(item map[string]*dynamodb.AttributeValue, key string, va
 	item[key] = &dynamodb.AttributeValue{N: aws.String(numString)}
 }
 
+// StringListAttribute returns a dynamodb list attribute of the string values - unlike a string set, the list may be empty
+func StringListAttribute(values []string) *dynamodb.AttributeValue {
+	list := make([]*dynamodb.AttributeValue, 0, len(values))
+	for _, value := range values {
+		list = append(list, &dynamodb.AttributeValue{S: aws.String(value)})
+	}
+	return &dynamodb.AttributeValue{L: list}
+}
+
 // StringInSlice returns true if the specified string value exists in the slice, otherwise returns false
 func StringInSlice(a string, list []string) bool {
 	if list == nil {
@@ -18,10 +18,20 @@ func ValidEmail(email string) bool {
 	return emailRegexp.MatchString(strings.TrimSpace(email))
 }
 
-// ValidDomain tests the specified domain string, returns true if domain is valid, returns false otherwise
+// ValidDomain tests the specified domain string, returns true if domain is valid, returns false otherwise. When
+// allowWildcard is set, a leading wildcard label is accepted, e.g. *.linuxfoundation.org, as long as it is followed by
+// at least a second level domain.
 func ValidDomain(domain string, allowWildcard bool) (string, bool) { // nolint
 	domain = strings.TrimSpace(domain)
 
+	if allowWildcard && strings.HasPrefix(domain, DomainPatternWildcardPrefix) {
+		baseDomain := strings.TrimPrefix(domain, DomainPatternWildcardPrefix)
+		if !strings.Contains(baseDomain, ".") {
+			return fmt.Sprintf("wildcard domain '%s' must include at least a second level domain, e.g. *.example.com", domain), false
+		}
+		return ValidDomain(baseDomain, false)
+	}
+
 	switch {
 	case len(domain) == 0:
 		return "domain is empty", false
@@ -47,27 +57,14 @@ func ValidDomain(domain string, allowWildcard bool) (string, bool) { // nolint
 			continue
 		}
 
-		// If wildcard domains are allowed, e.g. *.linuxfoundation.org
-		if allowWildcard {
-			// test label character validity, note: tests are ordered by decreasing validity frequency
-			if !(b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b == '*' || b >= 'A' && b <= 'Z') {
-				// show the printable unicode character starting at byte offset i
-				c, _ := utf8.DecodeRuneInString(domain[i:])
-				if c == utf8.RuneError {
-					return fmt.Sprintf("invalid character at offset %d", i), false
-				}
-				return fmt.Sprintf("invalid character '%c' at offset %d", c, i), false
-			}
-		} else {
-			// test label character validity, note: tests are ordered by decreasing validity frequency
-			if !(b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b >= 'A' && b <= 'Z') {
-				// show the printable unicode character starting at byte offset i
-				c, _ := utf8.DecodeRuneInString(domain[i:])
-				if c == utf8.RuneError {
-					return fmt.Sprintf("invalid character at offset %d", i), false
-				}
-				return fmt.Sprintf("invalid character '%c' at offset %d", c, i), false
+		// test label character validity, note: tests are ordered by decreasing validity frequency
+		if !(b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || b == '-' || b >= 'A' && b <= 'Z') {
+			// show the printable unicode character starting at byte offset i
+			c, _ := utf8.DecodeRuneInString(domain[i:])
+			if c == utf8.RuneError {
+				return fmt.Sprintf("invalid character at offset %d", i), false
 			}
+			return fmt.Sprintf("invalid character '%c' at offset %d", c, i), false
 		}
 	}
 
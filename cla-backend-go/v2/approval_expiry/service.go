@@ -0,0 +1,260 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_expiry
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// NotificationPeriod is how long before the expiry the CLA Managers are notified
+	NotificationPeriod = 7 * 24 * time.Hour
+	// expiryActorName is the name recorded on the employee signatures invalidated by an expiry
+	expiryActorName = "EasyCLA (approval list expiry)"
+)
+
+// Service contains the approval list expiry methods
+type Service interface {
+	ProcessExpiringApprovals(ctx context.Context) error
+}
+
+type service struct {
+	approvalsRepo approvals.IRepository
+	signatureRepo signatures.SignatureRepository
+	claGroupRepo  repository.ProjectRepository
+	companyRepo   company.IRepository
+	eventsService events.Service
+}
+
+// NewService creates a new approval list expiry service
+func NewService(approvalsRepo approvals.IRepository, signatureRepo signatures.SignatureRepository, claGroupRepo repository.ProjectRepository, companyRepo company.IRepository, eventsService events.Service) Service {
+	return &service{
+		approvalsRepo: approvalsRepo,
+		signatureRepo: signatureRepo,
+		claGroupRepo:  claGroupRepo,
+		companyRepo:   companyRepo,
+		eventsService: eventsService,
+	}
+}
+
+// ProcessExpiringApprovals removes the expired approval list entries from the corporate signatures and notifies the
+// CLA Managers of the entries expiring within the notification period. Each entry is notified once.
+func (s *service) ProcessExpiringApprovals(ctx context.Context) error {
+	f := logrus.Fields{
+		"functionName":   "v2.approval_expiry.service.ProcessExpiringApprovals",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	now := time.Now().UTC()
+	approvalItems, err := s.approvalsRepo.GetExpiringApprovalItems(utils.TimeToString(now.Add(NotificationPeriod)))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the expiring approval list items")
+		return err
+	}
+
+	bySignature := make(map[string][]approvals.ApprovalItem)
+	for _, approvalItem := range approvalItems {
+		bySignature[approvalItem.SignatureID] = append(bySignature[approvalItem.SignatureID], approvalItem)
+	}
+	log.WithFields(f).Debugf("processing %d expiring approval list items of %d signatures", len(approvalItems), len(bySignature))
+
+	for signatureID, signatureItems := range bySignature {
+		s.processSignature(ctx, signatureID, signatureItems, now)
+	}
+
+	return nil
+}
+
+// processSignature removes the expired entries and notifies the upcoming expiries of a corporate signature
+func (s *service) processSignature(ctx context.Context, signatureID string, approvalItems []approvals.ApprovalItem, now time.Time) {
+	f := logrus.Fields{
+		"functionName":   "v2.approval_expiry.service.processSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signatureID,
+	}
+
+	sig, err := s.signatureRepo.GetSignature(ctx, signatureID)
+	if err != nil || sig == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the corporate signature - skipping")
+		return
+	}
+	claGroup, err := s.claGroupRepo.GetCLAGroupByID(ctx, sig.ProjectID, repository.DontLoadRepoDetails)
+	if err != nil || claGroup == nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the CLA Group by ID: %s - skipping", sig.ProjectID)
+		return
+	}
+	companyModel, err := s.companyRepo.GetCompany(ctx, sig.SignatureReferenceID)
+	if err != nil || companyModel == nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load the company by ID: %s - skipping", sig.SignatureReferenceID)
+		return
+	}
+
+	var expired, upcoming []approvals.ApprovalItem
+	for _, approvalItem := range approvalItems {
+		switch {
+		case approvalItem.IsExpired(now):
+			expired = append(expired, approvalItem)
+		case !approvalItem.ExpiryNotified:
+			upcoming = append(upcoming, approvalItem)
+		}
+	}
+
+	if len(expired) > 0 {
+		s.removeExpired(ctx, sig, claGroup, companyModel, expired)
+	}
+	if len(upcoming) > 0 {
+		s.notifyUpcoming(ctx, sig, claGroup, companyModel, upcoming)
+	}
+}
+
+// removeExpired removes the expired entries from the approval list, which invalidates the employee signatures no
+// longer covered, and records the removal in the approvals table and in the events
+func (s *service) removeExpired(ctx context.Context, sig *models.Signature, claGroup *models.ClaGroup, companyModel *models.Company, expired []approvals.ApprovalItem) {
+	f := logrus.Fields{
+		"functionName":   "v2.approval_expiry.service.removeExpired",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     claGroup.ProjectID,
+		"companyID":      companyModel.CompanyID,
+	}
+
+	removals := &models.ApprovalList{}
+	for _, approvalItem := range expired {
+		switch approvalItem.NormalizedCriteria() {
+		case utils.EmailApprovalCriteria:
+			removals.RemoveEmailApprovalList = append(removals.RemoveEmailApprovalList, approvalItem.ApprovalName)
+		case utils.DomainApprovalCriteria:
+			removals.RemoveDomainApprovalList = append(removals.RemoveDomainApprovalList, approvalItem.ApprovalName)
+		case utils.GithubUsernameApprovalCriteria:
+			removals.RemoveGithubUsernameApprovalList = append(removals.RemoveGithubUsernameApprovalList, approvalItem.ApprovalName)
+		case utils.GithubOrgApprovalCriteria:
+			removals.RemoveGithubOrgApprovalList = append(removals.RemoveGithubOrgApprovalList, approvalItem.ApprovalName)
+		case utils.GitlabUsernameApprovalCriteria:
+			removals.RemoveGitlabUsernameApprovalList = append(removals.RemoveGitlabUsernameApprovalList, approvalItem.ApprovalName)
+		case utils.GitlabOrgApprovalCriteria:
+			removals.RemoveGitlabOrgApprovalList = append(removals.RemoveGitlabOrgApprovalList, approvalItem.ApprovalName)
+		}
+	}
+
+	expiryActor := &models.User{Username: expiryActorName}
+	_, err := s.signatureRepo.UpdateApprovalList(ctx, expiryActor, claGroup, companyModel.CompanyID, removals, &events.LogEventArgs{
+		EventType:     events.InvalidatedSignature,
+		ProjectID:     claGroup.ProjectExternalID,
+		ClaGroupModel: claGroup,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		UserModel:     expiryActor,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to remove the expired approval list entries")
+		return
+	}
+
+	_, currentTime := utils.CurrentTime()
+	for _, approvalItem := range expired {
+		approvalItem.Active = false
+		approvalItem.DateRemoved = currentTime
+		approvalItem.DateModified = currentTime
+		approvalItem.Note = "Expired"
+		if updateErr := s.approvalsRepo.UpdateApprovalItem(approvalItem); updateErr != nil {
+			log.WithFields(f).WithError(updateErr).Warnf("unable to deactivate the expired approval list item: %s", approvalItem.ApprovalID)
+		}
+
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:     events.ApprovalListEntryExpired,
+			ProjectID:     claGroup.ProjectExternalID,
+			ClaGroupModel: claGroup,
+			CompanyID:     companyModel.CompanyID,
+			CompanyModel:  companyModel,
+			EventData: &events.ApprovalListEntryExpiredEventData{
+				Criteria:  approvalItem.NormalizedCriteria(),
+				Name:      approvalItem.ApprovalName,
+				ExpiresAt: approvalItem.ExpiresAt,
+			},
+		})
+	}
+
+	log.WithFields(f).Infof("removed %d expired approval list entries", len(expired))
+}
+
+// notifyUpcoming emails the CLA Managers of the corporate signature the list of entries about to expire and flags
+// the entries as notified
+func (s *service) notifyUpcoming(ctx context.Context, sig *models.Signature, claGroup *models.ClaGroup, companyModel *models.Company, upcoming []approvals.ApprovalItem) {
+	f := logrus.Fields{
+		"functionName":   "v2.approval_expiry.service.notifyUpcoming",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     claGroup.ProjectID,
+		"companyID":      companyModel.CompanyID,
+	}
+
+	subject := fmt.Sprintf("EasyCLA: Approval List Entries Expiring for %s", claGroup.ProjectName)
+	sent := false
+	for i := range sig.SignatureACL {
+		claManager := &sig.SignatureACL[i]
+		email := utils.GetBestEmail(claManager)
+		if email == "" {
+			continue
+		}
+		body := expiryNotificationEmailBody(claGroup, companyModel, utils.GetBestUsername(claManager), upcoming)
+		if err := utils.SendEmail(subject, body, []string{email}); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipient: %s", subject, email)
+			continue
+		}
+		sent = true
+		log.WithFields(f).Debugf("sent email with subject: %s to recipient: %s", subject, email)
+	}
+	if !sent {
+		log.WithFields(f).Warn("unable to notify any CLA Manager of the expiring approval list entries")
+		return
+	}
+
+	_, currentTime := utils.CurrentTime()
+	for _, approvalItem := range upcoming {
+		approvalItem.ExpiryNotified = true
+		approvalItem.DateModified = currentTime
+		if err := s.approvalsRepo.UpdateApprovalItem(approvalItem); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to flag the approval list item: %s as notified", approvalItem.ApprovalID)
+		}
+	}
+}
+
+// expiryNotificationEmailBody builds the expiry notification email body for the CLA Manager
+func expiryNotificationEmailBody(claGroup *models.ClaGroup, companyModel *models.Company, recipientName string, upcoming []approvals.ApprovalItem) string {
+	sort.Slice(upcoming, func(i, j int) bool {
+		return upcoming[i].ExpiresAt < upcoming[j].ExpiresAt
+	})
+	var entries strings.Builder
+	for _, approvalItem := range upcoming {
+		entries.WriteString(fmt.Sprintf("<li>%s %s - expires on %s</li>\n", approvalItem.NormalizedCriteria(), approvalItem.ApprovalName, approvalItem.ExpiresAt))
+	}
+
+	return fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the CLA Group %s.</p>
+<p>The following approval list entries of %s will expire within a week:</p>
+<ul>
+%s</ul>
+<p>Once expired, the entries are removed from the approval list and the contributors they cover will need to be
+approved again. To keep them approved, add the entries again with a later expiry date from the
+<a href="%s" target="_blank">EasyCLA Corporate Console</a>.</p>
+%s
+%s`,
+		recipientName, claGroup.ProjectName, companyModel.CompanyName, entries.String(),
+		utils.GetCorporateURL(claGroup.Version == utils.V2), utils.GetEmailHelpContent(claGroup.Version == utils.V2), utils.GetEmailSignOffContent())
+}
@@ -0,0 +1,185 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approval_expiry
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	signatureID = "ccla-123"
+	claGroupID  = "cla-group-123"
+	companyID   = "company-123"
+)
+
+type fakeApprovalsRepo struct {
+	approvals.IRepository
+	items   []approvals.ApprovalItem
+	updated []approvals.ApprovalItem
+}
+
+func (r *fakeApprovalsRepo) GetExpiringApprovalItems(expiresBefore string) ([]approvals.ApprovalItem, error) {
+	var items []approvals.ApprovalItem
+	for _, item := range r.items {
+		if item.ExpiresAt <= expiresBefore {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func (r *fakeApprovalsRepo) UpdateApprovalItem(approvalItem approvals.ApprovalItem) error {
+	r.updated = append(r.updated, approvalItem)
+	return nil
+}
+
+type fakeSignatureRepo struct {
+	signatures.SignatureRepository
+	signature *models.Signature
+	removals  *models.ApprovalList
+}
+
+func (r *fakeSignatureRepo) GetSignature(ctx context.Context, signatureID string) (*models.Signature, error) {
+	return r.signature, nil
+}
+
+func (r *fakeSignatureRepo) UpdateApprovalList(ctx context.Context, claManager *models.User, claGroupModel *models.ClaGroup, companyID string, params *models.ApprovalList, eventArgs *events.LogEventArgs) (*models.Signature, error) {
+	r.removals = params
+	return r.signature, nil
+}
+
+type fakeCLAGroupRepo struct {
+	repository.ProjectRepository
+}
+
+func (r *fakeCLAGroupRepo) GetCLAGroupByID(ctx context.Context, claGroupID string, loadRepoDetails bool) (*models.ClaGroup, error) {
+	return &models.ClaGroup{ProjectID: claGroupID, ProjectName: "Test CLA Group"}, nil
+}
+
+type fakeCompanyRepo struct {
+	company.IRepository
+}
+
+func (r *fakeCompanyRepo) GetCompany(ctx context.Context, companyID string) (*models.Company, error) {
+	return &models.Company{CompanyID: companyID, CompanyName: "Acme"}, nil
+}
+
+type fakeEvents struct {
+	events.Service
+	logged []*events.LogEventArgs
+}
+
+func (e *fakeEvents) LogEventWithContext(ctx context.Context, args *events.LogEventArgs) {
+	e.logged = append(e.logged, args)
+}
+
+type fakeEmailSender struct {
+	recipients []string
+}
+
+func (e *fakeEmailSender) SendEmail(subject string, body string, recipients []string) error {
+	e.recipients = append(e.recipients, recipients...)
+	return nil
+}
+
+func newTestService(t *testing.T, items []approvals.ApprovalItem, claManagers []models.User) (Service, *fakeApprovalsRepo, *fakeSignatureRepo, *fakeEvents, *fakeEmailSender) {
+	emailSender := &fakeEmailSender{}
+	previousSender := utils.GetEmailSender()
+	utils.SetEmailSender(emailSender)
+	t.Cleanup(func() { utils.SetEmailSender(previousSender) })
+
+	approvalsRepo := &fakeApprovalsRepo{items: items}
+	signatureRepo := &fakeSignatureRepo{signature: &models.Signature{
+		SignatureID:          signatureID,
+		ProjectID:            claGroupID,
+		SignatureReferenceID: companyID,
+		SignatureACL:         claManagers,
+	}}
+	eventsService := &fakeEvents{}
+	return NewService(approvalsRepo, signatureRepo, &fakeCLAGroupRepo{}, &fakeCompanyRepo{}, eventsService), approvalsRepo, signatureRepo, eventsService, emailSender
+}
+
+func approvalItem(approvalID, criteria, name string, expiresAt time.Time, notified bool) approvals.ApprovalItem {
+	return approvals.ApprovalItem{
+		ApprovalID:       approvalID,
+		SignatureID:      signatureID,
+		ApprovalCriteria: criteria,
+		ApprovalName:     name,
+		Active:           true,
+		ExpiresAt:        expiresAt.UTC().Format(time.RFC3339),
+		ExpiryNotified:   notified,
+	}
+}
+
+func TestProcessExpiringApprovalsRemovesExpired(t *testing.T) {
+	past := time.Now().Add(-time.Hour)
+	s, approvalsRepo, signatureRepo, eventsService, emailSender := newTestService(t, []approvals.ApprovalItem{
+		approvalItem("approval-1", utils.EmailApprovalCriteria, "intern@example.org", past, true),
+		// domain entries are recorded with the email criteria
+		approvalItem("approval-2", utils.EmailApprovalCriteria, "contractor.example.org", past, true),
+		approvalItem("approval-3", utils.GithubUsernameApprovalCriteria, "octocat", past, false),
+	}, nil)
+
+	assert.NoError(t, s.ProcessExpiringApprovals(context.Background()))
+
+	if assert.NotNil(t, signatureRepo.removals) {
+		assert.Equal(t, []string{"intern@example.org"}, signatureRepo.removals.RemoveEmailApprovalList)
+		assert.Equal(t, []string{"contractor.example.org"}, signatureRepo.removals.RemoveDomainApprovalList)
+		assert.Equal(t, []string{"octocat"}, signatureRepo.removals.RemoveGithubUsernameApprovalList)
+	}
+	if assert.Len(t, approvalsRepo.updated, 3) {
+		for _, updated := range approvalsRepo.updated {
+			assert.False(t, updated.Active)
+			assert.Equal(t, "Expired", updated.Note)
+		}
+	}
+	if assert.Len(t, eventsService.logged, 3) {
+		assert.Equal(t, events.ApprovalListEntryExpired, eventsService.logged[0].EventType)
+	}
+	// the expired entries are not notified
+	assert.Empty(t, emailSender.recipients)
+}
+
+func TestProcessExpiringApprovalsNotifiesUpcoming(t *testing.T) {
+	soon := time.Now().Add(48 * time.Hour)
+	s, approvalsRepo, signatureRepo, _, emailSender := newTestService(t, []approvals.ApprovalItem{
+		approvalItem("approval-1", utils.EmailApprovalCriteria, "intern@example.org", soon, false),
+		approvalItem("approval-2", utils.EmailApprovalCriteria, "notified@example.org", soon, true),
+		// outside of the notification period
+		approvalItem("approval-3", utils.EmailApprovalCriteria, "later@example.org", time.Now().Add(30*24*time.Hour), false),
+	}, []models.User{{UserID: "manager-1", LfEmail: "manager@example.org"}})
+
+	assert.NoError(t, s.ProcessExpiringApprovals(context.Background()))
+
+	assert.Nil(t, signatureRepo.removals)
+	assert.Equal(t, []string{"manager@example.org"}, emailSender.recipients)
+	if assert.Len(t, approvalsRepo.updated, 1) {
+		assert.Equal(t, "approval-1", approvalsRepo.updated[0].ApprovalID)
+		assert.True(t, approvalsRepo.updated[0].ExpiryNotified)
+		assert.True(t, approvalsRepo.updated[0].Active)
+	}
+}
+
+func TestProcessExpiringApprovalsNoCLAManagerToNotify(t *testing.T) {
+	s, approvalsRepo, _, _, emailSender := newTestService(t, []approvals.ApprovalItem{
+		approvalItem("approval-1", utils.EmailApprovalCriteria, "intern@example.org", time.Now().Add(48*time.Hour), false),
+	}, []models.User{{UserID: "manager-1"}})
+
+	assert.NoError(t, s.ProcessExpiringApprovals(context.Background()))
+
+	// the entry stays unnotified so the next run tries again
+	assert.Empty(t, emailSender.recipients)
+	assert.Empty(t, approvalsRepo.updated)
+}
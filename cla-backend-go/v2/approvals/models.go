@@ -3,6 +3,13 @@
 
 package approvals
 
+import (
+	"strings"
+	"time"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+)
+
 type ApprovalItem struct {
 	ApprovalID          string `dynamodbav:"approval_id"`
 	SignatureID         string `dynamodbav:"signature_id"`
@@ -17,4 +24,48 @@ type ApprovalItem struct {
 	ApprovalCompanyName string `dynamodbav:"approval_company_name"`
 	Note                string `dynamodbav:"note"`
 	Active              bool   `dynamodbav:"active"`
+	ExpiresAt           string `dynamodbav:"expires_at,omitempty"`
+	ExpiryNotified      bool   `dynamodbav:"expiry_notified"`
+	// ExpiryIndexKey is only set on the active entries with an expiry date time - the partition key of the sparse
+	// expires-at-index, so the expiry lambda queries the expiring entries instead of scanning the table
+	ExpiryIndexKey string `dynamodbav:"expiry_index_key,omitempty"`
+}
+
+// ExpiryIndexKeyValue is the expiry_index_key value of the entries in the expires-at-index
+const ExpiryIndexKeyValue = "expiring"
+
+// withExpiryIndexKey returns the entry with the expiry_index_key set when it belongs to the expires-at-index
+func (a ApprovalItem) withExpiryIndexKey() ApprovalItem {
+	a.ExpiryIndexKey = ""
+	if a.Active && a.ExpiresAt != "" {
+		a.ExpiryIndexKey = ExpiryIndexKeyValue
+	}
+	return a
+}
+
+// ExpiryTime returns the expiry time of the approval list entry and true, or false if the entry doesn't expire
+func (a ApprovalItem) ExpiryTime() (time.Time, bool) {
+	if a.ExpiresAt == "" {
+		return time.Time{}, false
+	}
+	expiresAt, err := time.Parse(time.RFC3339, a.ExpiresAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return expiresAt, true
+}
+
+// IsExpired returns true if the approval list entry is active and its expiry time has passed
+func (a ApprovalItem) IsExpired(now time.Time) bool {
+	expiresAt, ok := a.ExpiryTime()
+	return a.Active && ok && !expiresAt.After(now)
+}
+
+// NormalizedCriteria returns the approval criteria of the entry - older domain entries were recorded with the email
+// criteria
+func (a ApprovalItem) NormalizedCriteria() string {
+	if a.ApprovalCriteria == utils.EmailApprovalCriteria && !strings.Contains(a.ApprovalName, "@") {
+		return utils.DomainApprovalCriteria
+	}
+	return a.ApprovalCriteria
 }
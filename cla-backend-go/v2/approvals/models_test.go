@@ -0,0 +1,28 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package approvals
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApprovalItemWithExpiryIndexKey(t *testing.T) {
+	expiring := ApprovalItem{Active: true, ExpiresAt: "2026-06-01T00:00:00Z"}
+	assert.Equal(t, ExpiryIndexKeyValue, expiring.withExpiryIndexKey().ExpiryIndexKey)
+
+	// the deactivated and the permanent entries are left out of the expires-at-index
+	removed := ApprovalItem{Active: false, ExpiresAt: "2026-06-01T00:00:00Z", ExpiryIndexKey: ExpiryIndexKeyValue}
+	assert.Empty(t, removed.withExpiryIndexKey().ExpiryIndexKey)
+	permanent := ApprovalItem{Active: true}
+	assert.Empty(t, permanent.withExpiryIndexKey().ExpiryIndexKey)
+}
+
+func TestApprovalItemNormalizedCriteria(t *testing.T) {
+	assert.Equal(t, utils.EmailApprovalCriteria, ApprovalItem{ApprovalCriteria: utils.EmailApprovalCriteria, ApprovalName: "dev@example.org"}.NormalizedCriteria())
+	assert.Equal(t, utils.DomainApprovalCriteria, ApprovalItem{ApprovalCriteria: utils.EmailApprovalCriteria, ApprovalName: "example.org"}.NormalizedCriteria())
+	assert.Equal(t, utils.GithubUsernameApprovalCriteria, ApprovalItem{ApprovalCriteria: utils.GithubUsernameApprovalCriteria, ApprovalName: "octocat"}.NormalizedCriteria())
+}
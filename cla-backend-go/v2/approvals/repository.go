@@ -32,6 +32,7 @@ type IRepository interface {
 	SearchApprovalList(criteria, approvalListName, claGroupID, companyID, signatureID string) ([]ApprovalItem, error)
 	BatchAddApprovalList(approvalItems []ApprovalItem) error
 	BatchDeleteApprovalList() error
+	GetExpiringApprovalItems(expiresBefore string) ([]ApprovalItem, error)
 }
 
 type repository struct {
@@ -144,7 +145,7 @@ func (repo *repository) UpdateApprovalItem(approvalItem ApprovalItem) error {
 
 	log.WithFields(f).Debugf("updating approval item: %+v", approvalItem)
 
-	av, err := dynamodbattribute.MarshalMap(approvalItem)
+	av, err := dynamodbattribute.MarshalMap(approvalItem.withExpiryIndexKey())
 	if err != nil {
 		log.WithFields(f).Warnf("unable to marshal data, error: %+v", err)
 		return err
@@ -394,7 +395,7 @@ func (repo *repository) BatchAddApprovalList(approvalItems []ApprovalItem) error
 			defer wg.Done()
 			var batchWriteItems []*dynamodb.WriteRequest
 			for _, approvalItem := range approvalItems[s:e] {
-				av, err := dynamodbattribute.MarshalMap(approvalItem)
+				av, err := dynamodbattribute.MarshalMap(approvalItem.withExpiryIndexKey())
 				if err != nil {
 					log.WithFields(f).Warnf("repository.BatchAddApprovalList - unable to marshal data, error: %+v", err)
 					return
@@ -458,7 +459,7 @@ func (repo *repository) AddApprovalList(approvalItem ApprovalItem) error {
 
 	log.WithFields(f).Debugf("repository.AddApprovalList - adding approval list: %+v", approvalItem)
 
-	av, err := dynamodbattribute.MarshalMap(approvalItem)
+	av, err := dynamodbattribute.MarshalMap(approvalItem.withExpiryIndexKey())
 	if err != nil {
 		log.WithFields(f).Warnf("repository.AddApprovalList - unable to marshal data, error: %+v", err)
 		return err
@@ -610,3 +611,59 @@ func (repo *repository) SearchApprovalList(criteria, approvalListName, claGroupI
 	return results, nil
 
 }
+
+// GetExpiringApprovalItems returns the active approval list items with an expiry date time before or equal to the
+// specified RFC3339 date time
+func (repo *repository) GetExpiringApprovalItems(expiresBefore string) ([]ApprovalItem, error) {
+	f := logrus.Fields{
+		"functionName":  "v2.approvals.repository.GetExpiringApprovalItems",
+		"tableName":     repo.tableName,
+		"expiresBefore": expiresBefore,
+	}
+
+	condition := expression.Key("expiry_index_key").Equal(expression.Value(ExpiryIndexKeyValue)).
+		And(expression.Key("expires_at").LessThanEqual(expression.Value(expiresBefore)))
+	// the deactivated entries drop out of the index, the filter only guards the entries updated by other writers
+	filter := expression.Name("active").Equal(expression.Value(true))
+
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).Warnf("error building expression, error: %+v", err)
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		TableName:                 aws.String(repo.tableName),
+		IndexName:                 aws.String("expires-at-index"),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		Limit:                     aws.Int64(100),
+	}
+
+	var results []ApprovalItem
+	for {
+		output, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).Warnf("error querying expiring approval list items, error: %+v", queryErr)
+			return nil, queryErr
+		}
+
+		var items []ApprovalItem
+		err = dynamodbattribute.UnmarshalListOfMaps(output.Items, &items)
+		if err != nil {
+			log.WithFields(f).Warnf("error unmarshalling data, error: %+v", err)
+			return nil, err
+		}
+		results = append(results, items...)
+
+		if output.LastEvaluatedKey == nil {
+			break
+		}
+		queryInput.ExclusiveStartKey = output.LastEvaluatedKey
+	}
+
+	log.WithFields(f).Debugf("found %d expiring approval list items", len(results))
+	return results, nil
+}
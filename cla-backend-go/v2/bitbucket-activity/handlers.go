@@ -0,0 +1,78 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_activity
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	bitbucketApi "github.com/linuxfoundation/easycla/cla-backend-go/bitbucket_api"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_activity"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// signatureCheckMiddleware is used to get access to raw http request so can do the
+// signature validation properly
+func signatureCheckMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "unable to read the request body", http.StatusBadRequest)
+			return
+		}
+		if err := bitbucketApi.ValidateSignature(payload, r.Header.Get(bitbucketApi.SignatureHeader), config.GetConfig().Bitbucket.WebhookSecret); err != nil {
+			log.Warnf("bitbucket webhook signature check failed : %v", err)
+			http.Error(w, "signature check failure", http.StatusUnauthorized)
+			return
+		}
+		defer r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+		// call the next middleware
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+
+	api.BitbucketActivityBitbucketActivityHandler = bitbucket_activity.BitbucketActivityHandlerFunc(func(params bitbucket_activity.BitbucketActivityParams) middleware.Responder {
+		requestID, _ := uuid.NewV4()
+		reqID := requestID.String()
+		f := logrus.Fields{
+			"functionName":   "v2.bitbucket-activity.handlers.BitbucketActivityBitbucketActivityHandler",
+			utils.XREQUESTID: reqID,
+			"eventKey":       params.XEventKey,
+		}
+		log.WithFields(f).Debugf("handling bitbucket activity callback")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+
+		// The event is acknowledged once the signature is verified, the processing failures are only logged - the CLA
+		// check runs again on the next pull request event
+
+		jsonData, err := params.BitbucketActivityInput.MarshalJSON()
+		if err != nil {
+			msg := fmt.Sprintf("unmarshall event data failed : %v", err)
+			log.WithFields(f).Debugf("%s", msg)
+			return bitbucket_activity.NewBitbucketActivityOK()
+		}
+
+		if err := service.ProcessPullRequestEvent(ctx, params.XEventKey, jsonData); err != nil {
+			msg := fmt.Sprintf("processing bitbucket pull request event failed : %v", err)
+			log.WithFields(f).Debugf("%s", msg)
+			return bitbucket_activity.NewBitbucketActivityOK()
+		}
+
+		return bitbucket_activity.NewBitbucketActivityOK()
+	})
+	api.AddMiddlewareFor("POST", "/bitbucket/activity", signatureCheckMiddleware)
+}
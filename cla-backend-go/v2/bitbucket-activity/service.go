@@ -0,0 +1,352 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	bitbucketApi "github.com/linuxfoundation/easycla/cla-backend-go/bitbucket_api"
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/bitbucket_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	pullRequestNotOpen = errors.New("pull request is not open")
+)
+
+const (
+	svgVersion = "?v=2"
+
+	missingCLAMsg = "Missing CLA Authorization"
+	signedCLAMsg  = "EasyCLA check passed. You are authorized to contribute."
+)
+
+// ProcessPullRequestActivityInput is used to pass the data needed to trigger a bitbucket pull request check
+type ProcessPullRequestActivityInput struct {
+	// OrganizationID is optional, the organization is resolved from the workspace when empty
+	OrganizationID string
+	// ServerURL is the base URL of the Data Center instance, empty for Bitbucket Cloud
+	ServerURL string
+	// Workspace is the workspace slug, or the project key on Data Center
+	Workspace string
+	// RepositoryID is the repository UUID without the curly braces
+	RepositoryID  string
+	PullRequestID int
+}
+
+type gatedBitbucketAuthor struct {
+	*bitbucketApi.PullRequestAuthor
+	err error
+}
+
+// Service contains the functions handling the Bitbucket pull request activity
+type Service interface {
+	ProcessPullRequestEvent(ctx context.Context, eventKey string, payload []byte) error
+	ProcessPullRequestActivity(ctx context.Context, input *ProcessPullRequestActivityInput) error
+}
+
+type service struct {
+	claCheckService     cla_check.Service
+	bitbucketOrgService bitbucket_organizations.ServiceInterface
+}
+
+// NewService creates a new bitbucket activity service
+func NewService(usersRepository users.UserRepository, signatureRepository signatures.SignatureRepository, companyRepository company.IRepository,
+	claGroupService projectService.Service, bitbucketOrgService bitbucket_organizations.ServiceInterface) Service {
+	return &service{
+		claCheckService:     cla_check.NewService(usersRepository, signatureRepository, companyRepository, claGroupService),
+		bitbucketOrgService: bitbucketOrgService,
+	}
+}
+
+// ProcessPullRequestEvent handles the Bitbucket webhook payload, comment events only re-run the check when the comment
+// asks for it
+func (s *service) ProcessPullRequestEvent(ctx context.Context, eventKey string, payload []byte) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket-activity.service.ProcessPullRequestEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventKey":       eventKey,
+	}
+
+	if !bitbucketApi.IsPullRequestEvent(eventKey) {
+		log.WithFields(f).Debugf("ignoring bitbucket event: %s", eventKey)
+		return nil
+	}
+
+	event, err := bitbucketApi.ParsePullRequestEvent(eventKey, payload)
+	if err != nil {
+		return err
+	}
+
+	if bitbucketApi.IsCommentEvent(eventKey) {
+		if event.Comment == nil || !strings.Contains(event.Comment.Content.Raw, "/easycla") {
+			log.WithFields(f).Debug("comment doesn't request a CLA check - ignoring")
+			return nil
+		}
+	}
+
+	return s.ProcessPullRequestActivity(ctx, &ProcessPullRequestActivityInput{
+		ServerURL:     event.ServerURL,
+		Workspace:     event.Repository.Workspace.Slug,
+		RepositoryID:  event.Repository.ExternalID(),
+		PullRequestID: event.PullRequest.ID,
+	})
+}
+
+// ProcessPullRequestActivity checks the commit authors of the pull request against the CLA Group signatures, then
+// reports the result as a build status and a pull request comment
+func (s *service) ProcessPullRequestActivity(ctx context.Context, input *ProcessPullRequestActivityInput) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket-activity.service.ProcessPullRequestActivity",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": input.OrganizationID,
+		"serverURL":      input.ServerURL,
+		"workspace":      input.Workspace,
+		"repositoryID":   input.RepositoryID,
+		"pullRequestID":  input.PullRequestID,
+	}
+
+	bitbucketOrg, bitbucketClient, err := s.getOrganizationClient(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	repository, err := bitbucketClient.GetRepository(bitbucketOrg.WorkspaceSlug, input.RepositoryID)
+	if err != nil {
+		return fmt.Errorf("fetching bitbucket repository: %s failed : %v", input.RepositoryID, err)
+	}
+	if bitbucketOrg.ProjectKey != "" && (repository.Project == nil || !strings.EqualFold(repository.Project.Key, bitbucketOrg.ProjectKey)) {
+		// Resolved by ID - the repository must belong to the onboarded Bitbucket project
+		log.WithFields(f).Debugf("repository: %s is not part of the onboarded Bitbucket project: %s - ignoring", repository.FullName, bitbucketOrg.ProjectKey)
+		return nil
+	}
+
+	pullRequest, err := bitbucketClient.GetPullRequest(bitbucketOrg.WorkspaceSlug, input.RepositoryID, input.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("fetching info for pull request : %d failed : %v", input.PullRequestID, err)
+	}
+	if pullRequest.State != bitbucketApi.PullRequestStateOpen {
+		log.WithFields(f).Debugf("pull request state is %s", pullRequest.State)
+		return pullRequestNotOpen
+	}
+	lastCommitSha := pullRequest.Source.Commit.Hash
+	f["lastCommitSha"] = lastCommitSha
+
+	authors, err := bitbucketClient.GetPullRequestAuthors(bitbucketOrg.WorkspaceSlug, input.RepositoryID, input.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("problem loading commit authors for pull request: %d - error: %+v", input.PullRequestID, err)
+	}
+	if len(authors) == 0 {
+		return fmt.Errorf("no commit authors found in bitbucket pull request : %d", input.PullRequestID)
+	}
+
+	log.WithFields(f).Debugf("found %d commit authors for the pull request", len(authors))
+	identities := make([]*cla_check.CommitIdentity, 0, len(authors))
+	for _, author := range authors {
+		identities = append(identities, toCommitIdentity(author))
+	}
+	evaluation, err := s.claCheckService.Evaluate(ctx, &cla_check.EvaluateInput{
+		CLAGroupID: bitbucketOrg.ClaGroupID,
+		Authors:    identities,
+	})
+	if err != nil {
+		return fmt.Errorf("evaluating the commit authors of pull request: %d failed : %v", input.PullRequestID, err)
+	}
+
+	var missingUsers []*gatedBitbucketAuthor
+	var signedUsers []*bitbucketApi.PullRequestAuthor
+	for i, verdict := range evaluation.Verdicts {
+		author := authors[i]
+		if !verdict.Covered {
+			log.WithFields(f).WithError(verdict.Err).Infof("bitbucket commit author: %s <%s> has NOT signed - %s", author.Name, author.Email, verdict.Reason.Message())
+			missingUsers = append(missingUsers, &gatedBitbucketAuthor{PullRequestAuthor: author, err: verdict.Err})
+			continue
+		}
+		log.WithFields(f).Infof("bitbucket commit author: %s <%s> has signed - %s", author.Name, author.Email, verdict.Reason.Message())
+		signedUsers = append(signedUsers, author)
+	}
+
+	signURL := GetFullSignURL(bitbucketOrg.OrganizationID, input.RepositoryID, strconv.Itoa(input.PullRequestID))
+	commentContent := PreparePullRequestCommentContent(missingUsers, signedUsers, signURL)
+
+	state, description, targetURL := bitbucketApi.CommitStatusSuccessful, signedCLAMsg, config.GetConfig().CLALandingPage+"/#/?version=2"
+	if len(missingUsers) > 0 {
+		log.WithFields(f).Warnf("pull request failed with %d commit authors not passing authorization", len(missingUsers))
+		state, description, targetURL = bitbucketApi.CommitStatusFailed, missingCLAMsg, signURL
+	}
+
+	if statusErr := bitbucketClient.SetCommitStatus(bitbucketOrg.WorkspaceSlug, input.RepositoryID, lastCommitSha, state, description, targetURL); statusErr != nil {
+		log.WithFields(f).WithError(statusErr).Warnf("problem setting the commit status for pull request ID: %d, sha: %s", input.PullRequestID, lastCommitSha)
+		return fmt.Errorf("setting commit status failed : %v", statusErr)
+	}
+
+	if commentErr := bitbucketClient.SetPullRequestComment(bitbucketOrg.WorkspaceSlug, input.RepositoryID, input.PullRequestID, commentContent); commentErr != nil {
+		log.WithFields(f).WithError(commentErr).Warnf("problem setting the pull request comment for pull request ID: %d", input.PullRequestID)
+		return fmt.Errorf("setting comment failed : %v", commentErr)
+	}
+
+	return nil
+}
+
+// getOrganizationClient resolves the onboarded Bitbucket organization of the pull request and returns an API client
+// authorized by the organization credentials
+func (s *service) getOrganizationClient(ctx context.Context, input *ProcessPullRequestActivityInput) (*bitbucket_organizations.BitbucketOrganization, *bitbucketApi.Client, error) {
+	var bitbucketOrg *bitbucket_organizations.BitbucketOrganization
+	var err error
+	if input.OrganizationID != "" {
+		bitbucketOrg, err = s.bitbucketOrgService.GetBitbucketOrganizationByID(ctx, input.OrganizationID)
+	} else {
+		bitbucketOrg, err = s.getOrganizationFromWorkspace(ctx, input.ServerURL, input.Workspace, input.RepositoryID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if bitbucketOrg == nil || !bitbucketOrg.Enabled || !bitbucketOrg.IsConnected() {
+		return nil, nil, fmt.Errorf("bitbucket organization for workspace: %s is not onboarded", input.Workspace)
+	}
+
+	bitbucketClient, err := s.bitbucketOrgService.NewBitbucketClient(ctx, bitbucketOrg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("initializing bitbucket client : %v", err)
+	}
+
+	return bitbucketOrg, bitbucketClient, nil
+}
+
+// getOrganizationFromWorkspace returns the organization onboarding the Bitbucket project of the repository, or the
+// one covering the whole workspace - the workspaces of different Bitbucket instances may share the slug
+func (s *service) getOrganizationFromWorkspace(ctx context.Context, serverURL, workspace, repositoryID string) (*bitbucket_organizations.BitbucketOrganization, error) {
+	bitbucketOrgs, err := s.bitbucketOrgService.GetBitbucketOrganizationsByWorkspace(ctx, workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	var workspaceOrg *bitbucket_organizations.BitbucketOrganization
+	var projectOrgs []*bitbucket_organizations.BitbucketOrganization
+	for _, bitbucketOrg := range bitbucketOrgs {
+		if !bitbucketOrg.IsConnected() || !bitbucketOrg.IsOnInstance(serverURL) {
+			continue
+		}
+		if bitbucketOrg.ProjectKey == "" {
+			workspaceOrg = bitbucketOrg
+		} else {
+			projectOrgs = append(projectOrgs, bitbucketOrg)
+		}
+	}
+
+	if len(projectOrgs) > 0 {
+		// Any project organization can read the repository to find its project
+		bitbucketClient, err := s.bitbucketOrgService.NewBitbucketClient(ctx, projectOrgs[0])
+		if err != nil {
+			return nil, err
+		}
+		repository, err := bitbucketClient.GetRepository(workspace, repositoryID)
+		if err != nil {
+			return nil, err
+		}
+		if repository.Project != nil {
+			for _, projectOrg := range projectOrgs {
+				if strings.EqualFold(projectOrg.ProjectKey, repository.Project.Key) {
+					return projectOrg, nil
+				}
+			}
+		}
+	}
+
+	if workspaceOrg == nil {
+		return nil, fmt.Errorf("bitbucket workspace : %s is not onboarded", workspace)
+	}
+	return workspaceOrg, nil
+}
+
+// PreparePullRequestCommentContent renders the markdown comment listing the commit authors and their CLA status
+func PreparePullRequestCommentContent(missingUsers []*gatedBitbucketAuthor, signedUsers []*bitbucketApi.PullRequestAuthor, signURL string) string {
+	landingPage := config.GetConfig().CLALandingPage
+	landingPage += "/#/?version=2"
+
+	badgeHyperlink := landingPage
+	if len(missingUsers) > 0 {
+		badgeHyperlink = signURL
+	}
+
+	coveredBadge := fmt.Sprintf("[![CLA Signed](https://s3.amazonaws.com/cla-project-logo-dev/cla-signed.svg%s)](%s)", svgVersion, badgeHyperlink)
+	failedBadge := fmt.Sprintf("[![CLA Not Signed](https://s3.amazonaws.com/cla-project-logo-dev/cla-not-signed.svg%s)](%s)", svgVersion, badgeHyperlink)
+	easyCLASupportURL := "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+
+	body := coveredBadge
+	var lines []string
+	for _, signed := range signedUsers {
+		lines = append(lines, fmt.Sprintf("* :white_check_mark: %s", getAuthorInfo(signed)))
+	}
+	if len(missingUsers) > 0 {
+		body = failedBadge
+		for _, missingUser := range missingUsers {
+			lines = append(lines, fmt.Sprintf("* :x: %s - the commit (%s) is not authorized under a signed CLA. [Please click here to be authorized](%s). For further assistance with EasyCLA, [please submit a support request ticket](%s).",
+				getAuthorInfo(missingUser.PullRequestAuthor), strings.Join(shortSHAs(missingUser.SHAs), ", "), signURL, easyCLASupportURL))
+		}
+	}
+
+	if len(lines) > 0 {
+		body += "\n\n" + strings.Join(lines, "\n")
+	}
+	return body
+}
+
+// GetFullSignURL returns the URL starting the signing flow of the Bitbucket pull request
+func GetFullSignURL(bitbucketOrganizationID string, bitbucketRepositoryID string, pullRequestID string) string {
+	return fmt.Sprintf("%s/v4/repository-provider/%s/sign/%s/%s/%s/#/",
+		config.GetConfig().ClaAPIV4Base,
+		utils.BitbucketLower,
+		bitbucketOrganizationID,
+		bitbucketRepositoryID,
+		pullRequestID,
+	)
+}
+
+// toCommitIdentity maps the commit author to the identity checked by the CLA engine, Bitbucket accounts aren't linked
+// to EasyCLA users so the author is matched by email
+func toCommitIdentity(author *bitbucketApi.PullRequestAuthor) *cla_check.CommitIdentity {
+	identity := &cla_check.CommitIdentity{
+		Provider: utils.BitbucketLower,
+		Name:     author.Name,
+		Email:    author.Email,
+	}
+	if author.Account != nil {
+		identity.ProviderID = author.Account.UUID
+		identity.Login = author.Account.Nickname
+	}
+	return identity
+}
+
+func getAuthorInfo(author *bitbucketApi.PullRequestAuthor) string {
+	if author.Account != nil && author.Account.Nickname != "" {
+		return fmt.Sprintf("login:@%s/name:%s", author.Account.Nickname, author.Name)
+	} else if author.Email != "" {
+		return fmt.Sprintf("email:%s/name:%s", author.Email, author.Name)
+	}
+	return fmt.Sprintf("name:%s", author.Name)
+}
+
+func shortSHAs(shas []string) []string {
+	out := make([]string, 0, len(shas))
+	for _, sha := range shas {
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		out = append(out, sha)
+	}
+	return out
+}
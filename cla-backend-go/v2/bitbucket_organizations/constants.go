@@ -0,0 +1,29 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+const (
+	// BitbucketOrganizationsOrganizationIDColumn constant
+	BitbucketOrganizationsOrganizationIDColumn = "organization_id"
+	// BitbucketOrganizationsProjectSFIDColumn constant
+	BitbucketOrganizationsProjectSFIDColumn = "project_sfid"
+	// BitbucketOrganizationsWorkspaceSlugColumn constant
+	BitbucketOrganizationsWorkspaceSlugColumn = "workspace_slug"
+	// BitbucketOrganizationsWorkspaceUUIDColumn constant
+	BitbucketOrganizationsWorkspaceUUIDColumn = "workspace_uuid"
+	// BitbucketOrganizationsOrganizationNameColumn constant
+	BitbucketOrganizationsOrganizationNameColumn = "organization_name"
+	// BitbucketOrganizationsOrganizationURLColumn constant
+	BitbucketOrganizationsOrganizationURLColumn = "organization_url"
+	// BitbucketOrganizationsEnabledColumn constant
+	BitbucketOrganizationsEnabledColumn = "enabled"
+	// BitbucketOrganizationsAuthInfoColumn constant
+	BitbucketOrganizationsAuthInfoColumn = "auth_info"
+	// BitbucketOrganizationsAuthExpiryTimeColumn constant
+	BitbucketOrganizationsAuthExpiryTimeColumn = "auth_expiry_time"
+	// BitbucketOrganizationsNoteColumn constant
+	BitbucketOrganizationsNoteColumn = "note"
+	// BitbucketOrganizationsDateModifiedColumn constant
+	BitbucketOrganizationsDateModifiedColumn = "date_modified"
+)
@@ -0,0 +1,559 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	bitbucketApi "github.com/linuxfoundation/easycla/cla-backend-go/bitbucket_api"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_activity"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/bitbucket_sign"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
+	"github.com/savaki/dynastore"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SessionStoreKey for cla-bitbucket
+	SessionStoreKey = "cla-bitbucket"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventService events.Service, sessionStore *dynastore.Store, contributorConsoleV2Base string) {
+
+	api.BitbucketOrganizationsGetProjectBitbucketOrganizationsHandler = bitbucket_organizations.GetProjectBitbucketOrganizationsHandlerFunc(
+		func(params bitbucket_organizations.GetProjectBitbucketOrganizationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_organizations.handlers.BitbucketOrganizationsGetProjectBitbucketOrganizationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			// Load the project
+			psc := projectService.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return bitbucket_organizations.NewGetProjectBitbucketOrganizationsNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project Bitbucket Organizations for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return bitbucket_organizations.NewGetProjectBitbucketOrganizationsForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetBitbucketOrganizationsByProjectSFID(ctx, params.ProjectSFID)
+			if err != nil {
+				msg := fmt.Sprintf("failed to locate Bitbucket organizations by project SFID: %s, error: %+v", params.ProjectSFID, err)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewGetProjectBitbucketOrganizationsBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return bitbucket_organizations.NewGetProjectBitbucketOrganizationsOK().WithPayload(result)
+		})
+
+	api.BitbucketOrganizationsAddProjectBitbucketOrganizationHandler = bitbucket_organizations.AddProjectBitbucketOrganizationHandlerFunc(
+		func(params bitbucket_organizations.AddProjectBitbucketOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_organizations.handlers.BitbucketOrganizationsAddProjectBitbucketOrganizationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			// Quick check of the parameters
+			if params.Body == nil || params.Body.Workspace == nil || strings.TrimSpace(*params.Body.Workspace) == "" {
+				msg := "missing Bitbucket workspace in the body"
+				log.WithFields(f).Warn(msg)
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			if params.Body.ClaGroupID == nil || *params.Body.ClaGroupID == "" {
+				msg := "missing CLA Group ID in the body"
+				log.WithFields(f).Warn(msg)
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			serverURL := bitbucketApi.NormalizeServerURL(params.Body.ServerURL)
+			if serverURL != "" {
+				if !strings.HasPrefix(serverURL, "https://") && !strings.HasPrefix(serverURL, "http://") {
+					msg := fmt.Sprintf("invalid Bitbucket Data Center server URL: %s", serverURL)
+					log.WithFields(f).Warn(msg)
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+						utils.ErrorResponseBadRequest(reqID, msg))
+				}
+				if params.Body.AccessToken == "" {
+					msg := "missing Bitbucket Data Center access token in the body"
+					log.WithFields(f).Warn(msg)
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+						utils.ErrorResponseBadRequest(reqID, msg))
+				}
+				if params.Body.OauthClientID == "" || params.Body.OauthClientSecret == "" {
+					msg := "missing Bitbucket Data Center OAuth2 application link client ID or secret in the body"
+					log.WithFields(f).Warn(msg)
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+						utils.ErrorResponseBadRequest(reqID, msg))
+				}
+				if strings.TrimSpace(params.Body.ProjectKey) != "" {
+					msg := "the project key is not supported for Bitbucket Data Center - the workspace holds the project key"
+					log.WithFields(f).Warn(msg)
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+						utils.ErrorResponseBadRequest(reqID, msg))
+				}
+			}
+			f["serverURL"] = serverURL
+			f["workspace"] = *params.Body.Workspace
+			f["projectKey"] = params.Body.ProjectKey
+			f["claGroupID"] = *params.Body.ClaGroupID
+
+			// Load the project
+			psc := projectService.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			// Load the project parent
+			parentProjectModel, err := psc.GetParentProjectModel(params.ProjectSFID)
+			if err != nil || (parentProjectModel == nil && !utils.IsProjectHasRootParent(projectModel)) {
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate parent project from project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Add Project Bitbucket Organizations for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			// If the parent is TLF, then use the same project SFID value for the parent SFID value
+			parentProjectSFID := ""
+			if parentProjectModel != nil {
+				parentProjectSFID = parentProjectModel.ID
+			}
+			if utils.IsProjectHasRootParent(projectModel) {
+				parentProjectSFID = params.ProjectSFID
+			}
+
+			// The Bitbucket Cloud workspace slugs are lower case, the Data Center project keys upper case
+			workspaceSlug := strings.ToLower(strings.TrimSpace(*params.Body.Workspace))
+			if serverURL != "" {
+				workspaceSlug = strings.ToUpper(strings.TrimSpace(*params.Body.Workspace))
+			}
+
+			inputModel := &BitbucketAddOrganization{
+				ServerURL:               serverURL,
+				AccessToken:             params.Body.AccessToken,
+				OAuthClientID:           params.Body.OauthClientID,
+				OAuthClientSecret:       params.Body.OauthClientSecret,
+				WorkspaceSlug:           workspaceSlug,
+				ProjectKey:              strings.TrimSpace(params.Body.ProjectKey),
+				ProjectSFID:             params.ProjectSFID,
+				ParentProjectSFID:       parentProjectSFID,
+				ClaGroupID:              *params.Body.ClaGroupID,
+				BranchProtectionEnabled: utils.BoolValue(params.Body.BranchProtectionEnabled),
+			}
+
+			result, err := service.AddBitbucketOrganization(ctx, inputModel)
+			if err != nil {
+				if _, ok := err.(*utils.ProjectConflict); ok {
+					return bitbucket_organizations.NewAddProjectBitbucketOrganizationConflict().WithPayload(
+						utils.ErrorResponseConflict(reqID, err.Error()))
+				}
+				msg := fmt.Sprintf("unable to add Bitbucket organization, error: %+v", err)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewAddProjectBitbucketOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			// Log the event
+			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+				LfUsername:  authUser.UserName,
+				EventType:   events.BitbucketOrganizationAdded,
+				ProjectSFID: params.ProjectSFID,
+				CLAGroupID:  inputModel.ClaGroupID,
+				EventData: &events.BitbucketOrganizationAddedEventData{
+					BitbucketServerURL:      inputModel.ServerURL,
+					BitbucketWorkspace:      inputModel.WorkspaceSlug,
+					BitbucketProjectKey:     inputModel.ProjectKey,
+					BranchProtectionEnabled: inputModel.BranchProtectionEnabled,
+				},
+			})
+
+			return bitbucket_organizations.NewAddProjectBitbucketOrganizationOK().WithPayload(result)
+		})
+
+	api.BitbucketOrganizationsDeleteProjectBitbucketOrganizationHandler = bitbucket_organizations.DeleteProjectBitbucketOrganizationHandlerFunc(
+		func(params bitbucket_organizations.DeleteProjectBitbucketOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_organizations.handlers.BitbucketOrganizationsDeleteProjectBitbucketOrganizationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"organizationID": params.OrganizationID,
+			}
+
+			// Load the project
+			psc := projectService.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Project Bitbucket Organizations for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			bitbucketOrg, err := service.GetBitbucketOrganizationByID(ctx, params.OrganizationID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading Bitbucket organization: %s", params.OrganizationID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			err = service.DeleteBitbucketOrganization(ctx, params.ProjectSFID, params.OrganizationID)
+			if err != nil {
+				if errors.Is(err, ErrBitbucketOrganizationNotFound) {
+					msg := fmt.Sprintf("Bitbucket organization %s not found for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+					log.WithFields(f).Debug(msg)
+					return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationNotFound().WithPayload(
+						utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem deleting Bitbucket organization: %s for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+				LfUsername:  authUser.UserName,
+				EventType:   events.BitbucketOrganizationDeleted,
+				ProjectSFID: params.ProjectSFID,
+				CLAGroupID:  bitbucketOrg.ClaGroupID,
+				EventData: &events.BitbucketOrganizationDeletedEventData{
+					BitbucketServerURL:  bitbucketOrg.ServerURL,
+					BitbucketWorkspace:  bitbucketOrg.WorkspaceSlug,
+					BitbucketProjectKey: bitbucketOrg.ProjectKey,
+				},
+			})
+
+			return bitbucket_organizations.NewDeleteProjectBitbucketOrganizationNoContent()
+		})
+
+	api.BitbucketSignSignRequestHandler = bitbucket_sign.SignRequestHandlerFunc(
+		func(params bitbucket_sign.SignRequestParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.bitbucket_organizations.handlers.BitbucketSignSignRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"organizationID": params.OrganizationID,
+				"repositoryID":   params.BitbucketRepositoryID,
+				"pullRequestID":  params.PullRequestID,
+			}
+
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				session, err := sessionStore.Get(params.HTTPRequest, SessionStoreKey)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error with session store lookup")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				pullRequestID, err := strconv.Atoi(params.PullRequestID)
+				if err != nil {
+					msg := fmt.Sprintf("invalid pull request ID: %s", params.PullRequestID)
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusBadRequest)
+					return
+				}
+
+				bitbucketOrg, err := service.GetBitbucketOrganizationByID(ctx, params.OrganizationID)
+				if err != nil || bitbucketOrg == nil {
+					msg := fmt.Sprintf("unable to locate Bitbucket organization: %s", params.OrganizationID)
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusNotFound)
+					return
+				}
+
+				originURL, err := service.GetOriginURL(ctx, bitbucketOrg, params.BitbucketRepositoryID, pullRequestID)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error getting origin URL")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				session.Values["bitbucket_organization_id"] = params.OrganizationID
+				session.Values["bitbucket_repository_id"] = params.BitbucketRepositoryID
+				session.Values["bitbucket_pull_request_id"] = params.PullRequestID
+				session.Values["bitbucket_origin_url"] = originURL
+
+				stateID, err := uuid.NewV4()
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to generate the oauth2 state")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				state := fmt.Sprintf("user:%s", stateID.String())
+				session.Values["bitbucket_oauth2_state"] = state
+				if err := session.Save(params.HTTPRequest, rw); err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to save the session")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				oauthConfig, err := service.OAuthConfig(bitbucketOrg)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to load the OAuth2 configuration")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				log.WithFields(f).Debug("redirecting the contributor to the Bitbucket authorization...")
+				http.Redirect(rw, params.HTTPRequest, oauthConfig.AuthCodeURL(state), http.StatusFound)
+			})
+		})
+
+	api.BitbucketActivityBitbucketOauthCallbackHandler = bitbucket_activity.BitbucketOauthCallbackHandlerFunc(func(params bitbucket_activity.BitbucketOauthCallbackParams) middleware.Responder {
+		ctx := utils.NewContext()
+		f := logrus.Fields{
+			"functionName":   "v2.bitbucket_organizations.handlers.BitbucketActivityBitbucketOauthCallbackHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"state":          params.State,
+		}
+		reqID, _ := ctx.Value(utils.XREQUESTID).(string)
+
+		if params.Code == nil || params.State == nil {
+			msg := "missing code or state parameter"
+			log.WithFields(f).Warn(msg)
+			return NewServerError(reqID, "", errors.New(msg))
+		}
+
+		stateParts := strings.Split(*params.State, ":")
+		if len(stateParts) != 2 {
+			msg := fmt.Sprintf("invalid state variable passed : %s", *params.State)
+			log.WithFields(f).Warn(msg)
+			return NewServerError(reqID, "", errors.New(msg))
+		}
+
+		if stateParts[0] == "user" {
+			// contributor authorization as part of the signing flow
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, p runtime.Producer) {
+				session, err := sessionStore.Get(params.HTTPRequest, SessionStoreKey)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error with session store lookup")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				values := make(map[string]string)
+				for _, key := range []string{"bitbucket_oauth2_state", "bitbucket_origin_url", "bitbucket_organization_id", "bitbucket_repository_id", "bitbucket_pull_request_id"} {
+					value, ok := session.Values[key].(string)
+					if !ok {
+						msg := fmt.Sprintf("Error getting %s - missing from session object", key)
+						log.WithFields(f).Warn(msg)
+						http.Error(rw, msg, http.StatusInternalServerError)
+						return
+					}
+					values[key] = value
+				}
+
+				if *params.State != values["bitbucket_oauth2_state"] {
+					msg := fmt.Sprintf("mismatch state, received: %s from callback, but loaded our state as: %s", *params.State, values["bitbucket_oauth2_state"])
+					log.WithFields(f).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				bitbucketOrg, err := service.GetBitbucketOrganizationByID(ctx, values["bitbucket_organization_id"])
+				if err != nil || bitbucketOrg == nil {
+					msg := fmt.Sprintf("unable to locate Bitbucket organization: %s", values["bitbucket_organization_id"])
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				log.WithFields(f).Debug("Fetching access token for user...")
+				bitbucketClient, err := service.NewContributorClient(ctx, bitbucketOrg, *params.Code)
+				if err != nil {
+					msg := "unable to fetch access token for user"
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				consoleURL, err := service.InitiateSignRequest(ctx, bitbucketClient, bitbucketOrg, values["bitbucket_repository_id"], values["bitbucket_pull_request_id"], values["bitbucket_origin_url"], contributorConsoleV2Base, eventService)
+				if err != nil {
+					msg := "problem initiating the sign request"
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				log.WithFields(f).Debugf("redirecting to :%s ", *consoleURL)
+				http.Redirect(rw, params.HTTPRequest, *consoleURL, http.StatusSeeOther)
+			})
+		}
+
+		organizationID := stateParts[0]
+		bitbucketOrg, err := service.GetBitbucketOrganizationByState(ctx, organizationID, stateParts[1])
+		if err != nil {
+			msg := fmt.Sprintf("fetching bitbucket model failed : %s : %v", organizationID, err)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return NewServerError(reqID, "", errors.New(msg))
+		}
+
+		// now fetch the oauth credentials and store to db
+		oauthResp, err := bitbucketApi.FetchOauthCredentials(*params.Code)
+		if err != nil {
+			msg := fmt.Sprintf("fetching bitbucket credentials failed : %s : %v", organizationID, err)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return NewServerError(reqID, bitbucketOrg.WorkspaceSlug, errors.New(msg))
+		}
+
+		if err := service.UpdateBitbucketOrganizationAuth(ctx, organizationID, oauthResp); err != nil {
+			log.WithFields(f).WithError(err).Warn("installation of Bitbucket workspace failed")
+			return NewServerError(reqID, bitbucketOrg.WorkspaceSlug, err)
+		}
+
+		return NewSuccessResponse(reqID, bitbucketOrg.ProjectSFID, bitbucketOrg.WorkspaceSlug)
+	})
+}
+
+// SuccessResponse Success
+type SuccessResponse struct {
+	ReqID         string
+	ProjectSFID   string
+	WorkspaceSlug string
+}
+
+// NewSuccessResponse creates a new redirect handler
+func NewSuccessResponse(reqID, projectSFID, workspaceSlug string) *SuccessResponse {
+	return &SuccessResponse{reqID, projectSFID, workspaceSlug}
+}
+
+// WriteResponse to the client
+func (o *SuccessResponse) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+	configPage := fmt.Sprintf("https://bitbucket.org/%s/workspace/settings/hooks", o.WorkspaceSlug)
+
+	html := fmt.Sprintf(`<!DOCTYPE html>
+    <html lang="en">
+	  <head>
+			<title>LFX EasyCLA Service Bitbucket App Installation Status</title>
+			<!-- Required meta tags -->
+			<meta charset="utf-8">
+			<meta name="viewport" content="width=device-width, initial-scale=1, shrink-to-fit=no">
+			<link rel="shortcut icon" href="https://www.linuxfoundation.org/wp-content/uploads/2017/08/favicon.png">
+			<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.0.0/css/bootstrap.min.css" integrity="sha384-Gn5384xqQ1aoWXA+058RXPxPg6fy4IWvTNh0E263XmFcJlSAwiGgFAW/dAiS6JXm" crossorigin="anonymous"/>
+			<style>h1 { text-align:center;}</style>
+		</head>
+		<body style='margin-top:20;margin-left:0;margin-right:0;'>
+			<div class="text-center">
+				<img width=300px" src="https://cla-project-logo-prod.s3.amazonaws.com/lf-horizontal-color.svg" alt="lf logo"/>
+			</div>
+			<h2 class="text-center">LFx EasyCLA Service Bitbucket App - Installation Successful</h2>
+			<p class="text-center">Thank you for connecting the LFX EasyCLA Bitbucket Application.  Your Bitbucket workspace %s is now onboarded.</p>
+			<p class="text-center">To review the EasyCLA webhook, navigate to <a href="%s" target="_blank">the Bitbucket workspace webhook settings.</a></p>
+			<p class="text-center">You may now close this window and return to the LFX Project Control Center.</p>
+		</body>
+	</html>`, o.WorkspaceSlug, configPage)
+
+	rw.Header().Set("Content-Type", "text/html")
+	rw.Header().Set(utils.XREQUESTID, o.ReqID)
+	rw.WriteHeader(http.StatusOK)
+	_, err := rw.Write([]byte(html))
+	if err != nil {
+		panic(err)
+	}
+}
+
+// ServerError Success
+type ServerError struct {
+	ReqID         string
+	WorkspaceSlug string
+	Error         error
+}
+
+// NewServerError creates a new redirect handler
+func NewServerError(reqID string, workspaceSlug string, theError error) *ServerError {
+	return &ServerError{
+		ReqID:         reqID,
+		WorkspaceSlug: workspaceSlug,
+		Error:         theError,
+	}
+}
+
+// WriteResponse to the client
+func (o *ServerError) WriteResponse(rw http.ResponseWriter, producer runtime.Producer) {
+	html := fmt.Sprintf(`<!DOCTYPE html>
+    <html lang="en">
+		<head>
+			<title>LFX EasyCLA Service Bitbucket App Installation Status</title>
+			<!-- Required meta tags -->
+			<meta charset="utf-8">
+			<meta name="viewport" content="width=device-width, initial-scale=1, shrink-to-fit=no">
+			<link rel="shortcut icon" href="https://www.linuxfoundation.org/wp-content/uploads/2017/08/favicon.png">
+			<link rel="stylesheet" href="https://maxcdn.bootstrapcdn.com/bootstrap/4.0.0/css/bootstrap.min.css" integrity="sha384-Gn5384xqQ1aoWXA+058RXPxPg6fy4IWvTNh0E263XmFcJlSAwiGgFAW/dAiS6JXm" crossorigin="anonymous"/>
+			<style>h1 { text-align:center;}</style>
+		</head>
+		<body style='margin-top:20;margin-left:0;margin-right:0;'>
+			<div class="text-center">
+				<img width=300px" src="https://cla-project-logo-prod.s3.amazonaws.com/lf-horizontal-color.svg" alt="lf logo"/>
+			</div>
+			<h2 class="text-center">LFx EasyCLA Service Bitbucket App - Installation Issue</h2>
+			<p class="text-center">Unable to connect the Bitbucket workspace %s due to the following error: %s.</p>
+		</body>
+	</html>`, o.WorkspaceSlug, o.Error.Error())
+
+	rw.Header().Set("Content-Type", "text/html")
+	rw.Header().Set(utils.XREQUESTID, o.ReqID)
+	rw.WriteHeader(http.StatusInternalServerError)
+	_, err := rw.Write([]byte(html))
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+)
+
+// BitbucketOrganization is the data model of an onboarded Bitbucket workspace. The pull requests of all the
+// repositories of the workspace - or of the Bitbucket project when a project key is set - are checked against the
+// CLA Group.
+//
+// The Bitbucket Data Center projects are onboarded with the server URL of the instance, the workspace slug then holds
+// the project key. EasyCLA acts on the project repositories with the access token of a bot account, the contributors
+// are identified with the OAuth2 incoming application link registered on the instance.
+type BitbucketOrganization struct {
+	OrganizationID          string `json:"organization_id"`
+	ServerURL               string `json:"server_url,omitempty"`
+	WorkspaceSlug           string `json:"workspace_slug"`
+	WorkspaceUUID           string `json:"workspace_uuid,omitempty"`
+	ProjectKey              string `json:"project_key,omitempty"`
+	OrganizationName        string `json:"organization_name,omitempty"`
+	OrganizationURL         string `json:"organization_url,omitempty"`
+	OrganizationSFID        string `json:"organization_sfid,omitempty"`
+	ProjectSFID             string `json:"project_sfid"`
+	ClaGroupID              string `json:"cla_group_id"`
+	Enabled                 bool   `json:"enabled"`
+	BranchProtectionEnabled bool   `json:"branch_protection_enabled"`
+	AuthInfo                string `json:"auth_info"`
+	AuthState               string `json:"auth_state"`
+	AuthExpirationTime      int64  `json:"auth_expiry_time,omitempty"`
+	OAuthClientID           string `json:"oauth_client_id,omitempty"`
+	OAuthClientSecret       string `json:"oauth_client_secret,omitempty"`
+	Note                    string `json:"note,omitempty"`
+	DateCreated             string `json:"date_created,omitempty"`
+	DateModified            string `json:"date_modified,omitempty"`
+	Version                 string `json:"version,omitempty"`
+}
+
+// BitbucketAddOrganization is data model for Bitbucket add organization requests
+type BitbucketAddOrganization struct {
+	// ServerURL is only set for the Bitbucket Data Center projects, together with the credentials
+	ServerURL               string
+	AccessToken             string
+	OAuthClientID           string
+	OAuthClientSecret       string
+	WorkspaceSlug           string
+	ProjectKey              string
+	ProjectSFID             string
+	ParentProjectSFID       string
+	ClaGroupID              string
+	BranchProtectionEnabled bool
+}
+
+// IsConnected returns true if the workspace administrator completed the OAuth authorization, the Data Center
+// projects are connected when onboarded
+func (in *BitbucketOrganization) IsConnected() bool {
+	return in.AuthInfo != ""
+}
+
+// IsDataCenter returns true if the organization is a Bitbucket Data Center project
+func (in *BitbucketOrganization) IsDataCenter() bool {
+	return in.ServerURL != ""
+}
+
+// IsOnInstance returns true if the organization is a workspace of the Bitbucket instance - an empty server URL is
+// Bitbucket Cloud
+func (in *BitbucketOrganization) IsOnInstance(serverURL string) bool {
+	return in.ServerURL == serverURL
+}
+
+// ToModel converts to models.BitbucketOrganization
+func ToModel(in *BitbucketOrganization) *v2Models.BitbucketOrganization {
+	return &v2Models.BitbucketOrganization{
+		OrganizationID:          in.OrganizationID,
+		ServerURL:               in.ServerURL,
+		WorkspaceSlug:           in.WorkspaceSlug,
+		WorkspaceUUID:           in.WorkspaceUUID,
+		ProjectKey:              in.ProjectKey,
+		OrganizationName:        in.OrganizationName,
+		OrganizationURL:         in.OrganizationURL,
+		OrganizationSfid:        in.OrganizationSFID,
+		ProjectSfid:             in.ProjectSFID,
+		ClaGroupID:              in.ClaGroupID,
+		Enabled:                 in.Enabled,
+		Connected:               in.IsConnected(),
+		BranchProtectionEnabled: in.BranchProtectionEnabled,
+		DateCreated:             in.DateCreated,
+		DateModified:            in.DateModified,
+		Version:                 in.Version,
+	}
+}
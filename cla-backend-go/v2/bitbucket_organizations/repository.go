@@ -0,0 +1,282 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// indexes
+const (
+	// BitbucketOrgProjectSFIDIndex the index for the Project SFID
+	BitbucketOrgProjectSFIDIndex = "bitbucket-project-sfid-index"
+	// BitbucketOrgWorkspaceSlugIndex the index for the workspace slug
+	BitbucketOrgWorkspaceSlugIndex = "bitbucket-workspace-slug-index"
+)
+
+// RepositoryInterface is interface for bitbucket org data model
+type RepositoryInterface interface {
+	AddBitbucketOrganization(ctx context.Context, bitbucketOrg *BitbucketOrganization) error
+	GetBitbucketOrganization(ctx context.Context, organizationID string) (*BitbucketOrganization, error)
+	GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) ([]*BitbucketOrganization, error)
+	GetBitbucketOrganizationsByWorkspace(ctx context.Context, workspaceSlug string) ([]*BitbucketOrganization, error)
+	UpdateBitbucketOrganizationAuth(ctx context.Context, organizationID string, authExpiryTime int64, authInfo, workspaceUUID, organizationName, organizationURL string) error
+	DeleteBitbucketOrganization(ctx context.Context, organizationID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage                 string
+	dynamoDBClient        *dynamodb.DynamoDB
+	bitbucketOrgTableName string
+}
+
+// NewRepository creates a new instance of the bitbucketOrganizations repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:                 stage,
+		dynamoDBClient:        dynamodb.New(awsSession),
+		bitbucketOrgTableName: fmt.Sprintf("cla-%s-bitbucket-orgs", stage),
+	}
+}
+
+// AddBitbucketOrganization adds the Bitbucket workspace record
+func (repo *Repository) AddBitbucketOrganization(ctx context.Context, bitbucketOrg *BitbucketOrganization) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.AddBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": bitbucketOrg.OrganizationID,
+		"workspaceSlug":  bitbucketOrg.WorkspaceSlug,
+		"projectKey":     bitbucketOrg.ProjectKey,
+		"projectSFID":    bitbucketOrg.ProjectSFID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(bitbucketOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall bitbucket organization record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding bitbucket organization record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.bitbucketOrgTableName),
+		ConditionExpression: aws.String("attribute_not_exists(organization_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).WithError(err).Warn("bitbucket organization already exists")
+			return fmt.Errorf("bitbucket organization already exists")
+		}
+		log.WithFields(f).WithError(err).Warn("cannot put bitbucket organization in dynamodb")
+		return err
+	}
+
+	return nil
+}
+
+// GetBitbucketOrganization returns the Bitbucket organization by ID, nil if not found
+func (repo *Repository) GetBitbucketOrganization(ctx context.Context, organizationID string) (*BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.GetBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			BitbucketOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		TableName: aws.String(repo.bitbucketOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load bitbucket organization")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debugf("Unable to find Bitbucket organization by ID: %s - no results", organizationID)
+		return nil, nil
+	}
+
+	var bitbucketOrg BitbucketOrganization
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &bitbucketOrg); err != nil {
+		log.WithFields(f).WithError(err).Warn("error unmarshalling bitbucket organization table data")
+		return nil, err
+	}
+	return &bitbucketOrg, nil
+}
+
+// GetBitbucketOrganizationsByProjectSFID returns the enabled Bitbucket organizations of the project
+func (repo *Repository) GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) ([]*BitbucketOrganization, error) {
+	condition := expression.Key(BitbucketOrganizationsProjectSFIDColumn).Equal(expression.Value(projectSFID))
+	return repo.getEnabledOrganizations(ctx, condition, BitbucketOrgProjectSFIDIndex)
+}
+
+// GetBitbucketOrganizationsByWorkspace returns the enabled Bitbucket organizations of the workspace - one per
+// onboarded Bitbucket project, or a single one covering the whole workspace
+func (repo *Repository) GetBitbucketOrganizationsByWorkspace(ctx context.Context, workspaceSlug string) ([]*BitbucketOrganization, error) {
+	condition := expression.Key(BitbucketOrganizationsWorkspaceSlugColumn).Equal(expression.Value(workspaceSlug))
+	return repo.getEnabledOrganizations(ctx, condition, BitbucketOrgWorkspaceSlugIndex)
+}
+
+// UpdateBitbucketOrganizationAuth updates the specified Bitbucket organization oauth info
+func (repo *Repository) UpdateBitbucketOrganizationAuth(ctx context.Context, organizationID string, authExpiryTime int64, authInfo, workspaceUUID, organizationName, organizationURL string) error {
+	f := logrus.Fields{
+		"functionName":    "v2.bitbucket_organizations.repository.UpdateBitbucketOrganizationAuth",
+		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
+		"organizationID":  organizationID,
+		"workspaceUUID":   workspaceUUID,
+		"organizationURL": organizationURL,
+	}
+
+	_, currentTime := utils.CurrentTime()
+	_, updateErr := repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			BitbucketOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#A": aws.String(BitbucketOrganizationsAuthInfoColumn),
+			"#E": aws.String(BitbucketOrganizationsAuthExpiryTimeColumn),
+			"#W": aws.String(BitbucketOrganizationsWorkspaceUUIDColumn),
+			"#N": aws.String(BitbucketOrganizationsOrganizationNameColumn),
+			"#U": aws.String(BitbucketOrganizationsOrganizationURLColumn),
+			"#M": aws.String(BitbucketOrganizationsDateModifiedColumn),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":a": {S: aws.String(authInfo)},
+			":e": {N: aws.String(strconv.FormatInt(authExpiryTime, 10))},
+			":w": {S: aws.String(workspaceUUID)},
+			":n": {S: aws.String(organizationName)},
+			":u": {S: aws.String(organizationURL)},
+			":m": {S: aws.String(currentTime)},
+		},
+		UpdateExpression:    aws.String("SET #A = :a, #E = :e, #W = :w, #N = :n, #U = :u, #M = :m"),
+		ConditionExpression: aws.String("attribute_exists(organization_id)"),
+		TableName:           aws.String(repo.bitbucketOrgTableName),
+	})
+	if updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warn("unable to update bitbucket organization auth")
+		return updateErr
+	}
+
+	return nil
+}
+
+// DeleteBitbucketOrganization disables the Bitbucket organization and removes the stored credentials
+func (repo *Repository) DeleteBitbucketOrganization(ctx context.Context, organizationID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.DeleteBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	org, err := repo.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	// Nothing to delete or disable
+	if org == nil || !org.Enabled {
+		return nil
+	}
+
+	_, currentTime := utils.CurrentTime()
+	note := fmt.Sprintf("Enabled set to false due to org deletion on %s by %s.", currentTime, utils.GetUserNameFromContext(ctx))
+	if org.Note != "" {
+		note = fmt.Sprintf("%s. %s", org.Note, note)
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			BitbucketOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#E":  aws.String(BitbucketOrganizationsEnabledColumn),
+			"#AI": aws.String(BitbucketOrganizationsAuthInfoColumn),
+			"#N":  aws.String(BitbucketOrganizationsNoteColumn),
+			"#D":  aws.String(BitbucketOrganizationsDateModifiedColumn),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":e":  {BOOL: aws.Bool(false)},
+			":ai": {S: aws.String("")},
+			":n":  {S: aws.String(note)},
+			":d":  {S: aws.String(currentTime)},
+		},
+		UpdateExpression: aws.String("SET #E = :e, #AI = :ai, #N = :n, #D = :d"),
+		TableName:        aws.String(repo.bitbucketOrgTableName),
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("error disabling bitbucket organization: %s - %+v", organizationID, err)
+		log.WithFields(f).WithError(err).Warn(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+// getEnabledOrganizations queries the index for the enabled Bitbucket organizations matching the key condition
+func (repo *Repository) getEnabledOrganizations(ctx context.Context, condition expression.KeyConditionBuilder, indexName string) ([]*BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.repository.getEnabledOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"indexName":      indexName,
+	}
+
+	filter := expression.Name(BitbucketOrganizationsEnabledColumn).Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.bitbucketOrgTableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var resultOutput []*BitbucketOrganization
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("problem retrieving bitbucket organizations")
+			return nil, queryErr
+		}
+
+		var page []*BitbucketOrganization
+		if err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding bitbucket organizations")
+			return nil, err
+		}
+		resultOutput = append(resultOutput, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return resultOutput, nil
+}
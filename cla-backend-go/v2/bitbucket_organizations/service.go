@@ -0,0 +1,657 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package bitbucket_organizations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/gofrs/uuid"
+	bitbucketApi "github.com/linuxfoundation/easycla/cla-backend-go/bitbucket_api"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+	oauth_bitbucket "golang.org/x/oauth2/bitbucket"
+)
+
+// ErrBitbucketOrganizationNotFound is returned when the Bitbucket organization does not exist for the project
+var ErrBitbucketOrganizationNotFound = errors.New("bitbucket organization not found")
+
+// ServiceInterface contains functions of BitbucketOrganizations service
+type ServiceInterface interface {
+	AddBitbucketOrganization(ctx context.Context, input *BitbucketAddOrganization) (*v2Models.BitbucketOrganization, error)
+	GetBitbucketOrganizationByID(ctx context.Context, organizationID string) (*BitbucketOrganization, error)
+	GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) (*v2Models.BitbucketOrganizations, error)
+	GetBitbucketOrganizationsByWorkspace(ctx context.Context, workspaceSlug string) ([]*BitbucketOrganization, error)
+	GetBitbucketOrganizationByState(ctx context.Context, organizationID, authState string) (*BitbucketOrganization, error)
+	UpdateBitbucketOrganizationAuth(ctx context.Context, organizationID string, oauthResp *bitbucketApi.OauthSuccessResponse) error
+	RefreshBitbucketOrganizationAuth(ctx context.Context, bitbucketOrg *BitbucketOrganization) (*string, error)
+	DeleteBitbucketOrganization(ctx context.Context, projectSFID, organizationID string) error
+	GetOriginURL(ctx context.Context, bitbucketOrg *BitbucketOrganization, repositoryID string, pullRequestID int) (string, error)
+	InitiateSignRequest(ctx context.Context, bitbucketClient *bitbucketApi.Client, bitbucketOrg *BitbucketOrganization, repositoryID, pullRequestID, originURL, contributorBaseURL string, eventService events.Service) (*string, error)
+	NewBitbucketClient(ctx context.Context, bitbucketOrg *BitbucketOrganization) (*bitbucketApi.Client, error)
+	OAuthConfig(bitbucketOrg *BitbucketOrganization) (*oauth2.Config, error)
+	NewContributorClient(ctx context.Context, bitbucketOrg *BitbucketOrganization, code string) (*bitbucketApi.Client, error)
+}
+
+// Service data model
+type Service struct {
+	repo               RepositoryInterface
+	claGroupRepository projects_cla_groups.Repository
+	bitbucketApp       *bitbucketApi.App
+	storeRepo          store.Repository
+	userService        users.Service
+}
+
+// NewService creates a new bitbucket organization service
+func NewService(repo RepositoryInterface, claGroupRepository projects_cla_groups.Repository, storeRepo store.Repository, userService users.Service) ServiceInterface {
+	return &Service{
+		repo:               repo,
+		claGroupRepository: claGroupRepository,
+		bitbucketApp:       bitbucketApi.Init(config.GetConfig().Bitbucket.AppClientID, config.GetConfig().Bitbucket.AppClientSecret, config.GetConfig().Bitbucket.AppPrivateKey, config.GetConfig().Bitbucket.WebhookSecret),
+		storeRepo:          storeRepo,
+		userService:        userService,
+	}
+}
+
+// AddBitbucketOrganization adds the specified Bitbucket workspace (optionally limited to one Bitbucket project) to
+// the project - the returned model holds the installation URL the workspace administrator uses to connect EasyCLA.
+// The Bitbucket Data Center projects are connected right away with the access token of the bot account.
+func (s *Service) AddBitbucketOrganization(ctx context.Context, input *BitbucketAddOrganization) (*v2Models.BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":            "v2.bitbucket_organizations.service.AddBitbucketOrganization",
+		utils.XREQUESTID:          ctx.Value(utils.XREQUESTID),
+		"serverURL":               input.ServerURL,
+		"projectSFID":             input.ProjectSFID,
+		"parentProjectSFID":       input.ParentProjectSFID,
+		"workspaceSlug":           input.WorkspaceSlug,
+		"projectKey":              input.ProjectKey,
+		"claGroupID":              input.ClaGroupID,
+		"branchProtectionEnabled": input.BranchProtectionEnabled,
+	}
+
+	// check if valid cla group id is passed
+	if _, err := s.claGroupRepository.GetCLAGroupNameByID(ctx, input.ClaGroupID); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to locate CLA Group: %s", input.ClaGroupID)
+		return nil, err
+	}
+
+	existingOrgs, err := s.repo.GetBitbucketOrganizationsByWorkspace(ctx, input.WorkspaceSlug)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem querying Bitbucket workspace: %s", input.WorkspaceSlug)
+		return nil, err
+	}
+	for _, existing := range existingOrgs {
+		if !existing.IsOnInstance(input.ServerURL) || !strings.EqualFold(existing.ProjectKey, input.ProjectKey) {
+			continue
+		}
+		// Check to make sure another project doesn't own this Bitbucket workspace
+		if existing.ProjectSFID != input.ProjectSFID {
+			return nil, s.projectConflict(f, input.ProjectSFID, existing.ProjectSFID)
+		}
+		log.WithFields(f).Debugf("Bitbucket workspace already onboarded with ID: %s", existing.OrganizationID)
+		return s.toResponseModel(existing), nil
+	}
+
+	organizationID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for bitbucket org")
+		return nil, err
+	}
+	authStateNonce, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a auth nonce UUID for bitbucket org")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	bitbucketOrg := &BitbucketOrganization{
+		OrganizationID:          organizationID.String(),
+		WorkspaceSlug:           input.WorkspaceSlug,
+		ProjectKey:              input.ProjectKey,
+		OrganizationName:        input.WorkspaceSlug,
+		OrganizationSFID:        input.ParentProjectSFID,
+		ProjectSFID:             input.ProjectSFID,
+		ClaGroupID:              input.ClaGroupID,
+		Enabled:                 true,
+		BranchProtectionEnabled: input.BranchProtectionEnabled,
+		AuthState:               authStateNonce.String(),
+		DateCreated:             currentTime,
+		DateModified:            currentTime,
+		Version:                 "v1",
+	}
+
+	var bitbucketClient *bitbucketApi.Client
+	if input.ServerURL != "" {
+		bitbucketClient, err = s.setDataCenterAuth(f, bitbucketOrg, input)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	log.WithFields(f).Debug("adding Bitbucket organization...")
+	if err := s.repo.AddBitbucketOrganization(ctx, bitbucketOrg); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem adding bitbucket organization for project")
+		return nil, err
+	}
+	log.WithFields(f).Debugf("created Bitbucket organization with ID: %s", bitbucketOrg.OrganizationID)
+
+	if bitbucketClient != nil {
+		if err := s.connect(ctx, bitbucketClient, bitbucketOrg); err != nil {
+			return nil, err
+		}
+	}
+
+	return s.toResponseModel(bitbucketOrg), nil
+}
+
+// setDataCenterAuth verifies the access token of the bot account against the Data Center project and stores the
+// encrypted credentials on the record, the returned client acts as the bot account
+func (s *Service) setDataCenterAuth(f logrus.Fields, bitbucketOrg *BitbucketOrganization, input *BitbucketAddOrganization) (*bitbucketApi.Client, error) {
+	bitbucketClient := bitbucketApi.NewBitbucketClientFromAccessToken(input.ServerURL, input.AccessToken)
+	project, err := bitbucketClient.GetWorkspace(input.WorkspaceSlug)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load Bitbucket Data Center project: %s with the provided access token", input.WorkspaceSlug)
+		return nil, err
+	}
+
+	authInfo, err := bitbucketApi.EncryptAuthInfo(&bitbucketApi.OauthSuccessResponse{AccessToken: input.AccessToken}, s.bitbucketApp)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt failed : %v", err)
+	}
+	oauthClientSecret, err := bitbucketApi.EncryptSecret(input.OAuthClientSecret, s.bitbucketApp)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	bitbucketOrg.ServerURL = input.ServerURL
+	bitbucketOrg.WorkspaceUUID = project.UUID
+	bitbucketOrg.OrganizationName = project.Name
+	bitbucketOrg.OrganizationURL = project.Links.HTML.Href
+	bitbucketOrg.AuthInfo = authInfo
+	bitbucketOrg.OAuthClientID = input.OAuthClientID
+	bitbucketOrg.OAuthClientSecret = oauthClientSecret
+	return bitbucketClient, nil
+}
+
+// GetBitbucketOrganizationByID returns the record associated with the Bitbucket Organization ID, nil if not found
+func (s *Service) GetBitbucketOrganizationByID(ctx context.Context, organizationID string) (*BitbucketOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.GetBitbucketOrganizationByID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	log.WithFields(f).Debugf("fetching bitbucket organization for bitbucket org id: %s", organizationID)
+	return s.repo.GetBitbucketOrganization(ctx, organizationID)
+}
+
+// GetBitbucketOrganizationsByProjectSFID returns the Bitbucket organizations onboarded by the project
+func (s *Service) GetBitbucketOrganizationsByProjectSFID(ctx context.Context, projectSFID string) (*v2Models.BitbucketOrganizations, error) {
+	dbModels, err := s.repo.GetBitbucketOrganizationsByProjectSFID(ctx, projectSFID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &v2Models.BitbucketOrganizations{
+		List: make([]*v2Models.BitbucketOrganization, 0, len(dbModels)),
+	}
+	for _, dbModel := range dbModels {
+		out.List = append(out.List, s.toResponseModel(dbModel))
+	}
+	return out, nil
+}
+
+// GetBitbucketOrganizationsByWorkspace returns the enabled Bitbucket organizations of the workspace
+func (s *Service) GetBitbucketOrganizationsByWorkspace(ctx context.Context, workspaceSlug string) ([]*BitbucketOrganization, error) {
+	return s.repo.GetBitbucketOrganizationsByWorkspace(ctx, workspaceSlug)
+}
+
+// GetBitbucketOrganizationByState returns the Bitbucket organization by the auth state
+func (s *Service) GetBitbucketOrganizationByState(ctx context.Context, organizationID, authState string) (*BitbucketOrganization, error) {
+	bitbucketOrg, err := s.repo.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if bitbucketOrg == nil {
+		return nil, fmt.Errorf("bitbucket organization not found: %s", organizationID)
+	}
+	if bitbucketOrg.AuthState != authState {
+		return nil, fmt.Errorf("auth state doesn't match")
+	}
+	return bitbucketOrg, nil
+}
+
+// UpdateBitbucketOrganizationAuth stores the OAuth credentials of the Bitbucket organization, registers the EasyCLA
+// webhook on the workspace and, when requested, the branch restrictions on the repositories
+func (s *Service) UpdateBitbucketOrganizationAuth(ctx context.Context, organizationID string, oauthResp *bitbucketApi.OauthSuccessResponse) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.UpdateBitbucketOrganizationAuth",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	log.WithFields(f).Debugf("updating bitbucket org auth")
+	authInfoEncrypted, err := bitbucketApi.EncryptAuthInfo(oauthResp, s.bitbucketApp)
+	if err != nil {
+		return fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	bitbucketOrg, err := s.repo.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return fmt.Errorf("bitbucket organization lookup error: %+v", err)
+	}
+	if bitbucketOrg == nil {
+		return fmt.Errorf("bitbucket organization not found: %s", organizationID)
+	}
+
+	bitbucketClient := bitbucketApi.NewBitbucketOauthClientFromAccessToken(oauthResp.AccessToken)
+	workspace, err := bitbucketClient.GetWorkspace(bitbucketOrg.WorkspaceSlug)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load Bitbucket workspace: %s", bitbucketOrg.WorkspaceSlug)
+		return err
+	}
+
+	authExpiryTime := time.Now().Add(time.Duration(oauthResp.ExpiresIn) * time.Second).Unix()
+	err = s.repo.UpdateBitbucketOrganizationAuth(ctx, organizationID, authExpiryTime, authInfoEncrypted, workspace.UUID, workspace.Name, workspace.Links.HTML.Href)
+	if err != nil {
+		return err
+	}
+
+	return s.connect(ctx, bitbucketClient, bitbucketOrg)
+}
+
+// connect registers the EasyCLA webhook on the workspace and, when requested, the branch restrictions on the
+// repositories
+func (s *Service) connect(ctx context.Context, bitbucketClient *bitbucketApi.Client, bitbucketOrg *BitbucketOrganization) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.connect",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": bitbucketOrg.OrganizationID,
+		"serverURL":      bitbucketOrg.ServerURL,
+		"workspaceSlug":  bitbucketOrg.WorkspaceSlug,
+	}
+
+	log.WithFields(f).Debugf("registering EasyCLA webhook on Bitbucket workspace: %s", bitbucketOrg.WorkspaceSlug)
+	if err := bitbucketClient.SetWebHook(bitbucketOrg.WorkspaceSlug, config.GetConfig().Bitbucket.WebHookURI, s.bitbucketApp.GetAppWebhookSecret()); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to register webhook on Bitbucket workspace: %s", bitbucketOrg.WorkspaceSlug)
+		return err
+	}
+
+	if bitbucketOrg.BranchProtectionEnabled {
+		s.setBranchRestrictions(ctx, bitbucketClient, bitbucketOrg)
+	}
+
+	return nil
+}
+
+// setBranchRestrictions requires the EasyCLA build status on the main branch of the workspace repositories, failures
+// are logged and don't prevent the onboarding
+func (s *Service) setBranchRestrictions(ctx context.Context, bitbucketClient *bitbucketApi.Client, bitbucketOrg *BitbucketOrganization) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.setBranchRestrictions",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": bitbucketOrg.OrganizationID,
+		"workspaceSlug":  bitbucketOrg.WorkspaceSlug,
+		"projectKey":     bitbucketOrg.ProjectKey,
+	}
+
+	repos, err := bitbucketClient.ListWorkspaceRepositories(bitbucketOrg.WorkspaceSlug, bitbucketOrg.ProjectKey)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the Bitbucket repositories - skipping branch restrictions")
+		return
+	}
+
+	for _, repo := range repos {
+		if repo.MainBranch == nil || repo.MainBranch.Name == "" {
+			continue
+		}
+		if err := bitbucketClient.SetBranchRestriction(bitbucketOrg.WorkspaceSlug, repo.Slug, repo.MainBranch.Name); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to set branch restriction on repository: %s branch: %s", repo.FullName, repo.MainBranch.Name)
+		}
+	}
+}
+
+// RefreshBitbucketOrganizationAuth refreshes the Bitbucket organization auth token in case of expired token, the
+// access tokens of the Data Center bot accounts don't expire
+func (s *Service) RefreshBitbucketOrganizationAuth(ctx context.Context, bitbucketOrg *BitbucketOrganization) (*string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.RefreshBitbucketOrganizationAuth",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": bitbucketOrg.OrganizationID,
+	}
+
+	if bitbucketOrg.IsDataCenter() {
+		return &bitbucketOrg.AuthInfo, nil
+	}
+
+	expireTime := time.Unix(bitbucketOrg.AuthExpirationTime, 0)
+	timeBuffer := 30 * time.Second
+
+	// If the current time (minus a small buffer/window) is before the expiration time, keep the token
+	if bitbucketOrg.AuthExpirationTime != 0 && !time.Now().Add(timeBuffer).After(expireTime) {
+		log.WithFields(f).Debug("using existing bitbucket auth token")
+		return &bitbucketOrg.AuthInfo, nil
+	}
+
+	decryptedOauthResponse, err := bitbucketApi.DecryptAuthInfo(bitbucketOrg.AuthInfo, s.bitbucketApp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem decrypting oauthResponse")
+		return nil, err
+	}
+
+	log.WithFields(f).Debugf("refreshing bitbucket auth token - now + buffer: %v - expiration: %v", time.Now().Add(timeBuffer), expireTime)
+	refreshOauthResponse, err := bitbucketApi.RefreshOauthToken(decryptedOauthResponse.RefreshToken)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem refreshing token")
+		return nil, err
+	}
+
+	authExpiryTime := time.Now().Add(time.Duration(refreshOauthResponse.ExpiresIn) * time.Second).Unix()
+	authInfo, err := bitbucketApi.EncryptAuthInfo(refreshOauthResponse, s.bitbucketApp)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem encrypting oauthResponse")
+		return nil, err
+	}
+
+	err = s.repo.UpdateBitbucketOrganizationAuth(ctx, bitbucketOrg.OrganizationID, authExpiryTime, authInfo, bitbucketOrg.WorkspaceUUID, bitbucketOrg.OrganizationName, bitbucketOrg.OrganizationURL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem updating bitbucket organization auth")
+		return nil, err
+	}
+	bitbucketOrg.AuthInfo = authInfo
+	bitbucketOrg.AuthExpirationTime = authExpiryTime
+
+	return &authInfo, nil
+}
+
+// DeleteBitbucketOrganization disables the Bitbucket organization and removes the EasyCLA webhook from the workspace
+func (s *Service) DeleteBitbucketOrganization(ctx context.Context, projectSFID, organizationID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.DeleteBitbucketOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"organizationID": organizationID,
+	}
+
+	bitbucketOrg, err := s.repo.GetBitbucketOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	if bitbucketOrg == nil || bitbucketOrg.ProjectSFID != projectSFID {
+		return ErrBitbucketOrganizationNotFound
+	}
+
+	// Only remove the webhook when no other onboarded Bitbucket project of the workspace relies on it
+	if bitbucketOrg.IsConnected() {
+		workspaceOrgs, err := s.repo.GetBitbucketOrganizationsByWorkspace(ctx, bitbucketOrg.WorkspaceSlug)
+		if err != nil {
+			return err
+		}
+		siblings := 0
+		for _, workspaceOrg := range workspaceOrgs {
+			if workspaceOrg.IsOnInstance(bitbucketOrg.ServerURL) {
+				siblings++
+			}
+		}
+		if siblings <= 1 {
+			s.removeWebHook(ctx, bitbucketOrg)
+		} else {
+			log.WithFields(f).Debugf("workspace: %s still has other onboarded Bitbucket projects - keeping the webhook", bitbucketOrg.WorkspaceSlug)
+		}
+	}
+
+	return s.repo.DeleteBitbucketOrganization(ctx, organizationID)
+}
+
+// removeWebHook removes the EasyCLA webhook from the workspace, failures are logged only since the credentials may
+// already be revoked on the Bitbucket side
+func (s *Service) removeWebHook(ctx context.Context, bitbucketOrg *BitbucketOrganization) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.removeWebHook",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": bitbucketOrg.OrganizationID,
+		"workspaceSlug":  bitbucketOrg.WorkspaceSlug,
+	}
+
+	bitbucketClient, err := s.NewBitbucketClient(ctx, bitbucketOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the bitbucket client - skipping webhook removal")
+		return
+	}
+	if err := bitbucketClient.RemoveWebHook(bitbucketOrg.WorkspaceSlug, config.GetConfig().Bitbucket.WebHookURI); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to remove the EasyCLA webhook")
+	}
+}
+
+// GetOriginURL returns the web URL of the pull request, the contributor returns there once signed
+func (s *Service) GetOriginURL(ctx context.Context, bitbucketOrg *BitbucketOrganization, repositoryID string, pullRequestID int) (string, error) {
+	bitbucketClient, err := s.NewBitbucketClient(ctx, bitbucketOrg)
+	if err != nil {
+		return "", err
+	}
+	pullRequest, err := bitbucketClient.GetPullRequest(bitbucketOrg.WorkspaceSlug, repositoryID, pullRequestID)
+	if err != nil {
+		return "", err
+	}
+	return pullRequest.Links.HTML.Href, nil
+}
+
+// NewBitbucketClient returns the client acting on the workspace repositories, the OAuth credentials of the Bitbucket
+// Cloud workspaces are refreshed when expired
+func (s *Service) NewBitbucketClient(ctx context.Context, bitbucketOrg *BitbucketOrganization) (*bitbucketApi.Client, error) {
+	authInfo, err := s.RefreshBitbucketOrganizationAuth(ctx, bitbucketOrg)
+	if err != nil {
+		return nil, err
+	}
+	return bitbucketApi.NewBitbucketClient(bitbucketOrg.ServerURL, *authInfo, s.bitbucketApp)
+}
+
+// OAuthConfig returns the configuration of the OAuth2 application identifying the contributors of the organization -
+// the EasyCLA OAuth consumer for Bitbucket Cloud, the incoming application link of the Data Center instance otherwise
+func (s *Service) OAuthConfig(bitbucketOrg *BitbucketOrganization) (*oauth2.Config, error) {
+	if !bitbucketOrg.IsDataCenter() {
+		return &oauth2.Config{
+			ClientID:    config.GetConfig().Bitbucket.AppClientID,
+			Scopes:      []string{"account", "email"},
+			Endpoint:    oauth_bitbucket.Endpoint,
+			RedirectURL: config.GetConfig().Bitbucket.RedirectURI,
+		}, nil
+	}
+
+	if bitbucketOrg.OAuthClientID == "" || bitbucketOrg.OAuthClientSecret == "" {
+		return nil, fmt.Errorf("bitbucket organization: %s has no OAuth2 application link configured", bitbucketOrg.WorkspaceSlug)
+	}
+	clientSecret, err := bitbucketApi.DecryptSecret(bitbucketOrg.OAuthClientSecret, s.bitbucketApp)
+	if err != nil {
+		return nil, err
+	}
+	return bitbucketApi.DataCenterOAuthConfig(bitbucketOrg.ServerURL, bitbucketOrg.OAuthClientID, clientSecret, config.GetConfig().Bitbucket.RedirectURI), nil
+}
+
+// NewContributorClient exchanges the authorization code of the contributor signing from a pull request and returns
+// the client acting as the contributor
+func (s *Service) NewContributorClient(ctx context.Context, bitbucketOrg *BitbucketOrganization, code string) (*bitbucketApi.Client, error) {
+	if !bitbucketOrg.IsDataCenter() {
+		token, err := bitbucketApi.FetchOauthCredentials(code)
+		if err != nil {
+			return nil, err
+		}
+		return bitbucketApi.NewBitbucketOauthClientFromAccessToken(token.AccessToken), nil
+	}
+
+	oauthConfig, err := s.OAuthConfig(bitbucketOrg)
+	if err != nil {
+		return nil, err
+	}
+	token, err := oauthConfig.Exchange(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return bitbucketApi.NewBitbucketClientFromAccessToken(bitbucketOrg.ServerURL, token.AccessToken), nil
+}
+
+// InitiateSignRequest initiates sign request and returns easy cla redirect url
+func (s *Service) InitiateSignRequest(ctx context.Context, bitbucketClient *bitbucketApi.Client, bitbucketOrg *BitbucketOrganization, repositoryID, pullRequestID, originURL, contributorBaseURL string, eventService events.Service) (*string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.InitiateSignRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": bitbucketOrg.OrganizationID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+		"originURL":      originURL,
+	}
+
+	claUser, err := s.getOrCreateUser(ctx, bitbucketClient, eventService)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to get or create user")
+		return nil, err
+	}
+
+	type StoreValue struct {
+		UserID         string `json:"user_id"`
+		ProjectID      string `json:"project_id"`
+		OrganizationID string `json:"organization_id"`
+		RepositoryID   string `json:"repository_id"`
+		PullRequestID  string `json:"pull_request_id"`
+		ReturnURL      string `json:"return_url"`
+	}
+
+	// set active signature metadata to track the user signing process
+	key := fmt.Sprintf("active_signature:%s", claUser.UserID)
+	storeValue := StoreValue{
+		UserID:         claUser.UserID,
+		ProjectID:      bitbucketOrg.ClaGroupID,
+		OrganizationID: bitbucketOrg.OrganizationID,
+		RepositoryID:   repositoryID,
+		PullRequestID:  pullRequestID,
+		ReturnURL:      originURL,
+	}
+	log.WithFields(f).Debugf("active signature metadata: %+v", storeValue)
+
+	jsonData, err := json.Marshal(storeValue)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to marshall storeValue object: %+v", storeValue)
+		return nil, err
+	}
+	expire := time.Now().AddDate(0, 0, 1).Unix()
+	if err := s.storeRepo.SetActiveSignatureMetaData(ctx, key, expire, string(jsonData)); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save signature metadata")
+		return nil, err
+	}
+
+	params := "redirect=" + url.QueryEscape(originURL)
+	consoleURL := fmt.Sprintf("https://%s/#/cla/project/%s/user/%s?%s", contributorBaseURL, bitbucketOrg.ClaGroupID, claUser.UserID, params)
+	return &consoleURL, nil
+}
+
+// getOrCreateUser locates the CLA user by the primary email of the authenticated Bitbucket user, the user is
+// created when missing
+func (s *Service) getOrCreateUser(ctx context.Context, bitbucketClient *bitbucketApi.Client, eventsService events.Service) (*models.User, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.bitbucket_organizations.service.getOrCreateUser",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	bitbucketUser, err := bitbucketClient.GetCurrentUser()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("getting bitbucket current user failed")
+		return nil, err
+	}
+	emails, err := bitbucketClient.GetCurrentUserEmails()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("getting bitbucket current user emails failed")
+		return nil, err
+	}
+	if len(emails) == 0 {
+		return nil, errors.New("bitbucket user has no confirmed email address")
+	}
+
+	claUser, err := s.userService.GetUserByEmail(emails[0])
+	if err == nil && claUser != nil {
+		return claUser, nil
+	}
+
+	log.WithFields(f).Infof("creating user record for bitbucket user: %s", bitbucketUser.Nickname)
+	user := &models.User{
+		Emails:   emails,
+		Username: bitbucketUser.DisplayName,
+	}
+	claUser, err = s.userService.CreateUser(user, nil)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to create user with details : %+v", user)
+		return nil, err
+	}
+
+	// Log the event
+	eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.UserCreated,
+		UserID:    claUser.UserID,
+		UserModel: claUser,
+		EventData: &events.UserCreatedEventData{},
+	})
+	return claUser, nil
+}
+
+// projectConflict builds the error returned when the Bitbucket workspace is already onboarded by another project
+func (s *Service) projectConflict(f logrus.Fields, requestedProjectSFID, existingProjectSFID string) error {
+	psc := projectService.GetClient()
+	requestedProjectModel, err := psc.GetProject(requestedProjectSFID)
+	if err != nil || requestedProjectModel == nil {
+		return err
+	}
+	existingProjectModel, err := psc.GetProject(existingProjectSFID)
+	if err != nil || existingProjectModel == nil {
+		log.WithFields(f).WithError(err).Warnf("unable to lookup project with SFID: %s", existingProjectSFID)
+		return err
+	}
+
+	return &utils.ProjectConflict{
+		Message: "unable to add the Bitbucket workspace - already taken by another project",
+		ProjectA: utils.ProjectSummary{
+			Name: requestedProjectModel.Name,
+			ID:   requestedProjectSFID,
+		},
+		ProjectB: utils.ProjectSummary{
+			Name: existingProjectModel.Name,
+			ID:   existingProjectSFID,
+		},
+	}
+}
+
+// toResponseModel converts the record to the response model, not yet connected records carry the installation URL
+func (s *Service) toResponseModel(bitbucketOrg *BitbucketOrganization) *v2Models.BitbucketOrganization {
+	out := ToModel(bitbucketOrg)
+	if !bitbucketOrg.IsConnected() {
+		out.InstallationURL = buildInstallationURL(bitbucketOrg.OrganizationID, bitbucketOrg.AuthState)
+	}
+	return out
+}
+
+func buildInstallationURL(bitbucketOrgID string, authStateNonce string) strfmt.URI {
+	c := config.GetConfig()
+	state := fmt.Sprintf("%s:%s", bitbucketOrgID, authStateNonce)
+
+	params := url.Values{}
+	params.Add("client_id", c.Bitbucket.AppClientID)
+	params.Add("response_type", "code")
+	params.Add("state", state)
+
+	return strfmt.URI(bitbucketApi.AuthorizeURL + "?" + params.Encode())
+}
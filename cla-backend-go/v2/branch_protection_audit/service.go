@@ -0,0 +1,354 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection_audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github/branch_protection"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	gitlab_api "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	repoModels "github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/common"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
+	v2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+// the drift reasons reported for a repository
+const (
+	DriftProtectionMissing       = "branch protection rule missing"
+	DriftStatusChecksNotRequired = "status checks not required"
+	DriftEasyCLACheckMissing     = "EasyCLA status check not required"
+	DriftAdminNotEnforced        = "not enforced for administrators"
+	DriftForcePushesAllowed      = "force pushes allowed"
+	DriftDeletionsAllowed        = "branch deletion allowed"
+	DriftProtectionChanged       = "branch protection settings changed"
+	DriftOrganizationRuleset     = "protected by organization rulesets which EasyCLA does not update"
+)
+
+// RepositoryDrift is the branch protection drift of a repository
+type RepositoryDrift struct {
+	Provider         string
+	OrganizationName string
+	RepositoryName   string
+	BranchName       string
+	CLAGroupID       string
+	// Drift lists the differences between the actual and the expected branch protection
+	Drift []string
+	// Repaired is set when the expected branch protection was applied again
+	Repaired bool
+	// Error is set when the branch protection couldn't be checked or repaired
+	Error string
+}
+
+// Report is the result of a branch protection audit
+type Report struct {
+	RepositoriesChecked int
+	Drifted             []*RepositoryDrift
+	Failed              []*RepositoryDrift
+}
+
+// Service contains the branch protection audit methods
+type Service interface {
+	AuditBranchProtection(ctx context.Context, repair bool) (*Report, error)
+}
+
+// gitHubBranchProtection is the part of branch_protection.BranchProtectionRepository used by the audit
+type gitHubBranchProtection interface {
+	GetBranchProtection(ctx context.Context, owner, repoName, branchName string) (*branch_protection.BranchProtection, error)
+	EnableBranchProtection(ctx context.Context, owner, repoName, branchName string, enforceAdmin bool, enableStatusChecks, disableStatusChecks []string) error
+}
+
+type service struct {
+	githubOrgRepo    github_organizations.RepositoryInterface
+	gitLabOrgRepo    gitlab_organizations.RepositoryInterface
+	gitLabOrgService gitlab_organizations.ServiceInterface
+	v2Repository     v2Repositories.RepositoryInterface
+	eventsService    events.Service
+	gitLabApp        *gitlab_api.App
+
+	newGitHubBranchProtection func(installationID int64) (gitHubBranchProtection, error)
+}
+
+// NewService creates a new branch protection audit service
+func NewService(githubOrgRepo github_organizations.RepositoryInterface, gitLabOrgRepo gitlab_organizations.RepositoryInterface, gitLabOrgService gitlab_organizations.ServiceInterface,
+	v2Repository v2Repositories.RepositoryInterface, eventsService events.Service, gitLabApp *gitlab_api.App) Service {
+	return &service{
+		githubOrgRepo:    githubOrgRepo,
+		gitLabOrgRepo:    gitLabOrgRepo,
+		gitLabOrgService: gitLabOrgService,
+		v2Repository:     v2Repository,
+		eventsService:    eventsService,
+		gitLabApp:        gitLabApp,
+		newGitHubBranchProtection: func(installationID int64) (gitHubBranchProtection, error) {
+			return branch_protection.NewBranchProtectionRepository(installationID, branch_protection.EnableBlockingLimiter())
+		},
+	}
+}
+
+// AuditBranchProtection compares the branch protection of the enabled repositories of the organizations with branch
+// protection enabled against the rules applied by EasyCLA at enrollment, the drifted repositories are repaired when
+// repair is set
+func (s *service) AuditBranchProtection(ctx context.Context, repair bool) (*Report, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.branch_protection_audit.service.AuditBranchProtection",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repair":         repair,
+	}
+
+	report := &Report{}
+	if err := s.auditGitHub(ctx, report, repair); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to audit the GitHub organizations")
+		return nil, err
+	}
+	if err := s.auditGitLab(ctx, report, repair); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to audit the GitLab groups")
+		return nil, err
+	}
+
+	log.WithFields(f).Infof("branch protection audit checked %d repositories - drifted: %d, failed: %d",
+		report.RepositoriesChecked, len(report.Drifted), len(report.Failed))
+	return report, nil
+}
+
+func (s *service) auditGitHub(ctx context.Context, report *Report, repair bool) error {
+	f := logrus.Fields{
+		"functionName":   "v2.branch_protection_audit.service.auditGitHub",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	githubOrgs, err := s.githubOrgRepo.GetBranchProtectionEnabledGitHubOrganizations(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, githubOrg := range githubOrgs.List {
+		if githubOrg.OrganizationInstallationID == 0 {
+			log.WithFields(f).Debugf("GitHub organization %s has no installation - skipping", githubOrg.OrganizationName)
+			continue
+		}
+		repositories, repoErr := s.v2Repository.GitHubGetRepositoriesByOrganizationName(ctx, githubOrg.OrganizationName)
+		if repoErr != nil {
+			log.WithFields(f).WithError(repoErr).Warnf("unable to load the repositories of the GitHub organization %s - skipping", githubOrg.OrganizationName)
+			continue
+		}
+		repositories = auditedRepositories(repositories)
+		if len(repositories) == 0 {
+			continue
+		}
+
+		branchProtection, clientErr := s.newGitHubBranchProtection(githubOrg.OrganizationInstallationID)
+		if clientErr != nil {
+			log.WithFields(f).WithError(clientErr).Warnf("unable to create the GitHub client of the organization %s - skipping", githubOrg.OrganizationName)
+			for _, repository := range repositories {
+				report.Failed = append(report.Failed, newRepositoryDrift(utils.GitHubType, githubOrg.OrganizationName, repository, utils.GithubBranchProtectionPatternAll, clientErr))
+			}
+			continue
+		}
+
+		for _, repository := range repositories {
+			report.RepositoriesChecked++
+			drift := newRepositoryDrift(utils.GitHubType, githubOrg.OrganizationName, repository, utils.GithubBranchProtectionPatternAll, nil)
+			protection, protectionErr := branchProtection.GetBranchProtection(ctx, githubOrg.OrganizationName, repository.RepositoryName, utils.GithubBranchProtectionPatternAll)
+			if protectionErr != nil && !errors.Is(protectionErr, branch_protection.ErrBranchNotProtected) {
+				log.WithFields(f).WithError(protectionErr).Warnf("unable to load the branch protection of the GitHub repository %s", repository.RepositoryName)
+				drift.Error = protectionErr.Error()
+				report.Failed = append(report.Failed, drift)
+				continue
+			}
+
+			drift.Drift = gitHubBranchProtectionDrift(protection)
+			if len(drift.Drift) == 0 {
+				continue
+			}
+			log.WithFields(f).Infof("branch protection of the GitHub repository %s/%s drifted: %v", githubOrg.OrganizationName, repository.RepositoryName, drift.Drift)
+			report.Drifted = append(report.Drifted, drift)
+			if !repair {
+				continue
+			}
+
+			repairErr := branchProtection.EnableBranchProtection(ctx, githubOrg.OrganizationName, repository.RepositoryName,
+				utils.GithubBranchProtectionPatternAll, true, []string{utils.GitHubBotName}, []string{})
+			s.recordRepair(ctx, drift, repairErr)
+		}
+	}
+	return nil
+}
+
+func (s *service) auditGitLab(ctx context.Context, report *Report, repair bool) error {
+	f := logrus.Fields{
+		"functionName":   "v2.branch_protection_audit.service.auditGitLab",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	gitLabOrgs, err := s.gitLabOrgRepo.GetGitLabOrganizationsEnabled(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, gitLabOrg := range gitLabOrgs.List {
+		if !gitLabOrg.BranchProtectionEnabled || gitLabOrg.AuthInfo == "" {
+			continue
+		}
+		repositories, repoErr := s.v2Repository.GitLabGetRepositoriesByOrganizationName(ctx, gitLabOrg.OrganizationName)
+		if repoErr != nil {
+			log.WithFields(f).WithError(repoErr).Warnf("unable to load the repositories of the GitLab group %s - skipping", gitLabOrg.OrganizationName)
+			continue
+		}
+		repositories = auditedRepositories(repositories)
+		if len(repositories) == 0 {
+			continue
+		}
+
+		oauthResponse, authErr := s.gitLabOrgService.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(gitLabOrg))
+		if authErr != nil {
+			log.WithFields(f).WithError(authErr).Warnf("unable to refresh the auth of the GitLab group %s - skipping", gitLabOrg.OrganizationName)
+			continue
+		}
+		gitLabClient, clientErr := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+		if clientErr != nil {
+			log.WithFields(f).WithError(clientErr).Warnf("unable to create the GitLab client of the group %s - skipping", gitLabOrg.OrganizationName)
+			continue
+		}
+
+		for _, repository := range repositories {
+			report.RepositoriesChecked++
+			drift := newRepositoryDrift(utils.GitLabLower, gitLabOrg.OrganizationName, repository, "", nil)
+			projectID, idErr := strconv.Atoi(repository.RepositoryExternalID)
+			if idErr != nil {
+				drift.Error = fmt.Sprintf("parsing external repository id failed : %v", idErr)
+				report.Failed = append(report.Failed, drift)
+				continue
+			}
+			defaultBranch, branchErr := gitlab_api.GetDefaultBranch(gitLabClient, projectID)
+			if branchErr != nil {
+				drift.Error = branchErr.Error()
+				report.Failed = append(report.Failed, drift)
+				continue
+			}
+			drift.BranchName = defaultBranch.Name
+
+			protected, protectedErr := gitlab_api.IsBranchProtectionSet(ctx, gitLabClient, projectID, defaultBranch.Name)
+			if protectedErr != nil {
+				drift.Error = protectedErr.Error()
+				report.Failed = append(report.Failed, drift)
+				continue
+			}
+			if protected {
+				continue
+			}
+
+			drift.Drift = []string{DriftProtectionChanged}
+			log.WithFields(f).Infof("branch protection of the GitLab repository %s drifted: %v", repository.RepositoryName, drift.Drift)
+			report.Drifted = append(report.Drifted, drift)
+			if !repair {
+				continue
+			}
+
+			repairErr := gitlab_api.SetOrCreateBranchProtection(ctx, gitLabClient, projectID, defaultBranch.Name)
+			s.recordRepair(ctx, drift, repairErr)
+		}
+	}
+	return nil
+}
+
+// recordRepair marks the drift as repaired and logs the repair event, or records the repair error
+func (s *service) recordRepair(ctx context.Context, drift *RepositoryDrift, repairErr error) {
+	f := logrus.Fields{
+		"functionName":     "v2.branch_protection_audit.service.recordRepair",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"provider":         drift.Provider,
+		"organizationName": drift.OrganizationName,
+		"repositoryName":   drift.RepositoryName,
+	}
+
+	if repairErr != nil {
+		log.WithFields(f).WithError(repairErr).Warn("unable to repair the branch protection")
+		drift.Error = repairErr.Error()
+		return
+	}
+
+	log.WithFields(f).Infof("repaired the branch protection of the branch %s", drift.BranchName)
+	drift.Repaired = true
+	s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:  events.RepositoryBranchProtectionRepaired,
+		CLAGroupID: drift.CLAGroupID,
+		UserID:     "easycla system",
+		UserName:   "easycla system",
+		EventData: &events.RepositoryBranchProtectionRepairedEventData{
+			Provider:         drift.Provider,
+			OrganizationName: drift.OrganizationName,
+			RepositoryName:   drift.RepositoryName,
+			BranchName:       drift.BranchName,
+			Drift:            drift.Drift,
+		},
+	})
+}
+
+// gitHubBranchProtectionDrift returns the differences between the protection and the rule applied by EasyCLA, a nil
+// protection means the branch is not protected. The force pushes and deletions of the branches protected by rulesets
+// are left to the ruleset owners. The drift of a branch only protected by organization rulesets is reported as such,
+// the repair adds the EasyCLA repository ruleset and leaves the organization rulesets untouched.
+func gitHubBranchProtectionDrift(protection *branch_protection.BranchProtection) []string {
+	if protection == nil || protection.Rule == nil {
+		return []string{DriftProtectionMissing}
+	}
+
+	rule := protection.Rule
+	var drift []string
+	if !rule.RequiresStatusChecks {
+		drift = append(drift, DriftStatusChecksNotRequired)
+	}
+	if !utils.StringInSlice(utils.GitHubBotName, rule.RequiredStatusCheckContexts) {
+		drift = append(drift, DriftEasyCLACheckMissing)
+	}
+	if !branch_protection.IsEnforceAdminEnabled(rule) {
+		drift = append(drift, DriftAdminNotEnforced)
+	}
+	if protection.Mechanism == branch_protection.ProtectionMechanismOrganizationRuleset && len(drift) > 0 {
+		drift = append(drift, DriftOrganizationRuleset)
+	}
+	if protection.Mechanism != branch_protection.ProtectionMechanismClassic {
+		return drift
+	}
+	if rule.AllowsForcePushes {
+		drift = append(drift, DriftForcePushesAllowed)
+	}
+	if rule.AllowsDeletions {
+		drift = append(drift, DriftDeletionsAllowed)
+	}
+	return drift
+}
+
+// auditedRepositories returns the enabled repositories which still exist on the provider
+func auditedRepositories(repositories []*repoModels.RepositoryDBModel) []*repoModels.RepositoryDBModel {
+	audited := make([]*repoModels.RepositoryDBModel, 0, len(repositories))
+	for _, repository := range repositories {
+		if repository.Enabled && !repository.IsRemoteDeleted {
+			audited = append(audited, repository)
+		}
+	}
+	return audited
+}
+
+func newRepositoryDrift(provider, organizationName string, repository *repoModels.RepositoryDBModel, branchName string, err error) *RepositoryDrift {
+	drift := &RepositoryDrift{
+		Provider:         provider,
+		OrganizationName: organizationName,
+		RepositoryName:   repository.RepositoryName,
+		BranchName:       branchName,
+		CLAGroupID:       repository.RepositoryCLAGroupID,
+	}
+	if err != nil {
+		drift.Error = err.Error()
+	}
+	return drift
+}
@@ -0,0 +1,168 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package branch_protection_audit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	eventsMock "github.com/linuxfoundation/easycla/cla-backend-go/events/mock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github/branch_protection"
+	githubOrgMock "github.com/linuxfoundation/easycla/cla-backend-go/github_organizations/mock"
+	repoModels "github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
+	v2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRepositories struct {
+	v2Repositories.RepositoryInterface
+	repositories []*repoModels.RepositoryDBModel
+}
+
+func (r *fakeRepositories) GitHubGetRepositoriesByOrganizationName(ctx context.Context, orgName string) ([]*repoModels.RepositoryDBModel, error) {
+	return r.repositories, nil
+}
+
+type fakeGitLabOrganizations struct {
+	gitlab_organizations.RepositoryInterface
+}
+
+func (r *fakeGitLabOrganizations) GetGitLabOrganizationsEnabled(ctx context.Context) (*v2Models.GitlabOrganizations, error) {
+	return &v2Models.GitlabOrganizations{}, nil
+}
+
+type fakeBranchProtection struct {
+	protections map[string]*branch_protection.BranchProtection
+	repaired    []string
+}
+
+func (b *fakeBranchProtection) GetBranchProtection(ctx context.Context, owner, repoName, branchName string) (*branch_protection.BranchProtection, error) {
+	protection, ok := b.protections[repoName]
+	if !ok {
+		return nil, branch_protection.ErrBranchNotProtected
+	}
+	if protection == nil {
+		return nil, errors.New("boom")
+	}
+	return protection, nil
+}
+
+func (b *fakeBranchProtection) EnableBranchProtection(ctx context.Context, owner, repoName, branchName string, enforceAdmin bool, enableStatusChecks, disableStatusChecks []string) error {
+	b.repaired = append(b.repaired, repoName)
+	return nil
+}
+
+func expectedProtection(mechanism string) *branch_protection.BranchProtection {
+	return &branch_protection.BranchProtection{
+		Mechanism: mechanism,
+		Rule: &branch_protection.BranchProtectionRule{
+			Pattern:                     utils.GithubBranchProtectionPatternAll,
+			RequiresStatusChecks:        true,
+			RequiredStatusCheckContexts: []string{utils.GitHubBotName},
+			IsAdminEnforced:             true,
+		},
+	}
+}
+
+func TestGitHubBranchProtectionDrift(t *testing.T) {
+	assert.Empty(t, gitHubBranchProtectionDrift(expectedProtection(branch_protection.ProtectionMechanismClassic)))
+	assert.Equal(t, []string{DriftProtectionMissing}, gitHubBranchProtectionDrift(nil))
+
+	protection := expectedProtection(branch_protection.ProtectionMechanismClassic)
+	protection.Rule.RequiredStatusCheckContexts = []string{"ci"}
+	protection.Rule.IsAdminEnforced = false
+	protection.Rule.AllowsForcePushes = true
+	assert.Equal(t, []string{DriftEasyCLACheckMissing, DriftAdminNotEnforced, DriftForcePushesAllowed}, gitHubBranchProtectionDrift(protection))
+
+	protection = expectedProtection(branch_protection.ProtectionMechanismOrganizationRuleset)
+	protection.Rule.AllowsForcePushes = true
+	protection.Rule.AllowsDeletions = true
+	assert.Empty(t, gitHubBranchProtectionDrift(protection))
+
+	protection.Rule.RequiredStatusCheckContexts = []string{"ci"}
+	assert.Equal(t, []string{DriftEasyCLACheckMissing, DriftOrganizationRuleset}, gitHubBranchProtectionDrift(protection))
+}
+
+func TestAuditBranchProtection(t *testing.T) {
+	drifted := expectedProtection(branch_protection.ProtectionMechanismRepositoryRuleset)
+	drifted.Rule.RequiresStatusChecks = false
+
+	testCases := []struct {
+		name   string
+		repair bool
+	}{
+		{name: "report only", repair: false},
+		{name: "repair", repair: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			githubOrgRepo := githubOrgMock.NewMockRepositoryInterface(ctrl)
+			githubOrgRepo.EXPECT().GetBranchProtectionEnabledGitHubOrganizations(gomock.Any()).Return(&models.GithubOrganizations{
+				List: []*models.GithubOrganization{{OrganizationName: "org1", OrganizationInstallationID: 1}},
+			}, nil)
+
+			eventsService := eventsMock.NewMockService(ctrl)
+			if tc.repair {
+				eventsService.EXPECT().LogEventWithContext(gomock.Any(), &events.LogEventArgs{
+					EventType:  events.RepositoryBranchProtectionRepaired,
+					CLAGroupID: "cla-group-2",
+					UserID:     "easycla system",
+					UserName:   "easycla system",
+					EventData: &events.RepositoryBranchProtectionRepairedEventData{
+						Provider:         utils.GitHubType,
+						OrganizationName: "org1",
+						RepositoryName:   "repo2",
+						BranchName:       utils.GithubBranchProtectionPatternAll,
+						Drift:            []string{DriftStatusChecksNotRequired},
+					},
+				})
+				eventsService.EXPECT().LogEventWithContext(gomock.Any(), gomock.Any())
+			}
+
+			branchProtection := &fakeBranchProtection{protections: map[string]*branch_protection.BranchProtection{
+				"repo1": expectedProtection(branch_protection.ProtectionMechanismClassic),
+				"repo2": drifted,
+				"repo4": nil,
+			}}
+			s := NewService(githubOrgRepo, &fakeGitLabOrganizations{}, nil, &fakeRepositories{repositories: []*repoModels.RepositoryDBModel{
+				{RepositoryName: "repo1", Enabled: true, RepositoryCLAGroupID: "cla-group-1"},
+				{RepositoryName: "repo2", Enabled: true, RepositoryCLAGroupID: "cla-group-2"},
+				{RepositoryName: "repo3", Enabled: true, RepositoryCLAGroupID: "cla-group-3"},
+				{RepositoryName: "repo4", Enabled: true, RepositoryCLAGroupID: "cla-group-4"},
+				{RepositoryName: "repo5", Enabled: false, RepositoryCLAGroupID: "cla-group-5"},
+				{RepositoryName: "repo6", Enabled: true, IsRemoteDeleted: true, RepositoryCLAGroupID: "cla-group-6"},
+			}}, eventsService, nil).(*service)
+			s.newGitHubBranchProtection = func(installationID int64) (gitHubBranchProtection, error) {
+				return branchProtection, nil
+			}
+
+			report, err := s.AuditBranchProtection(context.Background(), tc.repair)
+			assert.Nil(t, err)
+			assert.Equal(t, 4, report.RepositoriesChecked)
+			assert.Len(t, report.Drifted, 2)
+			assert.Equal(t, "repo2", report.Drifted[0].RepositoryName)
+			assert.Equal(t, "repo3", report.Drifted[1].RepositoryName)
+			assert.Equal(t, []string{DriftProtectionMissing}, report.Drifted[1].Drift)
+			assert.Len(t, report.Failed, 1)
+			assert.Equal(t, "repo4", report.Failed[0].RepositoryName)
+			assert.Equal(t, tc.repair, report.Drifted[0].Repaired)
+			if tc.repair {
+				assert.Equal(t, []string{"repo2", "repo3"}, branchProtection.repaired)
+			} else {
+				assert.Empty(t, branchProtection.repaired)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_check
+
+import (
+	"context"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// TriageGitHubAuthors evaluates the GitHub commit authors of a pull request and splits them into the authors covered
+// by the CLA Group and the authors missing a CLA - invalid user summaries can't be matched to a user and are missing
+func (s *service) TriageGitHubAuthors(ctx context.Context, claGroupID string, authors []*github.UserCommitSummary) ([]*github.UserCommitSummary, []*github.UserCommitSummary, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_check.github.TriageGitHubAuthors",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	signed := make([]*github.UserCommitSummary, 0)
+	unsigned := make([]*github.UserCommitSummary, 0)
+
+	validAuthors := make([]*github.UserCommitSummary, 0, len(authors))
+	identities := make([]*CommitIdentity, 0, len(authors))
+	for _, userSummary := range authors {
+		if !userSummary.IsValid() {
+			log.WithFields(f).Debugf("invalid user summary: %+v", *userSummary)
+			unsigned = append(unsigned, userSummary)
+			continue
+		}
+		validAuthors = append(validAuthors, userSummary)
+		identities = append(identities, toGitHubCommitIdentity(userSummary))
+	}
+
+	evaluation, err := s.Evaluate(ctx, &EvaluateInput{
+		CLAGroupID:           claGroupID,
+		Authors:              identities,
+		OrganizationApproval: gitHubOrganizationApproval,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for i, verdict := range evaluation.Verdicts {
+		userSummary := validAuthors[i]
+		userSummary.Affiliated = verdict.Affiliated()
+		userSummary.Authorized = verdict.Covered
+		userSummary.Optional = !verdict.Required
+		log.WithFields(f).Debugf("commit author - sha: %s, user ID: %s, username: %s, email: %s - covered: %t - %s",
+			userSummary.SHA, verdict.Author.ProviderID, verdict.Author.Login, verdict.Author.Email, verdict.Covered, verdict.Reason.Message())
+		if verdict.Covered {
+			signed = append(signed, userSummary)
+		} else {
+			unsigned = append(unsigned, userSummary)
+		}
+	}
+
+	return signed, unsigned, nil
+}
+
+// toGitHubCommitIdentity maps the commit author - the Co-authored-by co-authors only carry a name and an email
+func toGitHubCommitIdentity(userSummary *github.UserCommitSummary) *CommitIdentity {
+	if userSummary.IsCoAuthor() {
+		return &CommitIdentity{
+			Provider: utils.GitHubType,
+			Name:     userSummary.CoAuthor.Name,
+			Email:    userSummary.CoAuthor.Email,
+			CoAuthor: true,
+		}
+	}
+	return &CommitIdentity{
+		Provider:   utils.GitHubType,
+		ProviderID: userSummary.GetCommitAuthorID(),
+		Login:      userSummary.GetCommitAuthorUsername(),
+		Email:      userSummary.GetCommitAuthorEmail(),
+	}
+}
+
+// gitHubOrganizationApproval checks the author against the GitHub organization approval list of the corporate signature
+func gitHubOrganizationApproval(ctx context.Context, author *CommitIdentity, user *models.User, corporateSignature *models.Signature) (bool, error) {
+	gitHubUsername := author.Login
+	if gitHubUsername == "" {
+		gitHubUsername = user.GithubUsername
+	}
+	if gitHubUsername == "" {
+		return false, nil
+	}
+	for _, org := range corporateSignature.GithubOrgApprovalList {
+		membership, err := github.GetMembership(ctx, gitHubUsername, org)
+		if err != nil {
+			return false, err
+		}
+		if membership != nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
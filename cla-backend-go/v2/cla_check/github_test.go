@@ -0,0 +1,51 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_check
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	gogithub "github.com/google/go-github/v37/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTriageGitHubAuthors(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID}, nil)
+	mocks.users.EXPECT().GetUsersByEmail("pair@example.com").Return(nil, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(&models.Signature{SignatureID: "icla-123"}, nil)
+
+	author := &github.UserCommitSummary{SHA: "sha-1", CommitAuthor: &gogithub.User{ID: gogithub.Int64(42), Login: gogithub.String("octocat")}}
+	coAuthor := &github.UserCommitSummary{SHA: "sha-1", CoAuthor: &utils.CoAuthor{Name: "Pair", Email: "pair@example.com"}}
+	invalid := &github.UserCommitSummary{SHA: "sha-2"}
+
+	signed, unsigned, err := s.TriageGitHubAuthors(context.Background(), claGroupID, []*github.UserCommitSummary{author, invalid, coAuthor})
+	assert.NoError(t, err)
+	assert.Equal(t, []*github.UserCommitSummary{author}, signed)
+	assert.Equal(t, []*github.UserCommitSummary{invalid, coAuthor}, unsigned)
+
+	assert.True(t, author.Authorized)
+	assert.False(t, author.Affiliated)
+	assert.False(t, author.Optional)
+	// the co-author policy of the CLA Group only reports the co-authors
+	assert.False(t, coAuthor.Authorized)
+	assert.True(t, coAuthor.Optional)
+}
+
+func TestTriageGitHubAuthorsCoAuthorEnforced(t *testing.T) {
+	s, mocks := newTestService(t, &models.ClaGroup{ProjectID: claGroupID, CoAuthorPolicy: "enforce"})
+	mocks.users.EXPECT().GetUsersByEmail("pair@example.com").Return(nil, nil)
+
+	coAuthor := &github.UserCommitSummary{SHA: "sha-1", CoAuthor: &utils.CoAuthor{Name: "Pair", Email: "pair@example.com"}}
+	signed, unsigned, err := s.TriageGitHubAuthors(context.Background(), claGroupID, []*github.UserCommitSummary{coAuthor})
+	assert.NoError(t, err)
+	assert.Empty(t, signed)
+	assert.Equal(t, []*github.UserCommitSummary{coAuthor}, unsigned)
+	assert.False(t, coAuthor.Optional)
+}
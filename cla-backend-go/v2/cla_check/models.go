@@ -0,0 +1,163 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_check
+
+import (
+	"errors"
+)
+
+var (
+	// ErrMissingID is the verdict error of the authors which can't be matched to an EasyCLA user
+	ErrMissingID = errors.New("user missing in easyCLA records")
+	// ErrMissingCompanyAffiliation is the verdict error of the approved employees which haven't acknowledged the CCLA yet
+	ErrMissingCompanyAffiliation = errors.New("must confirm affiliation with their company")
+	// ErrMissingCompanyApproval is the verdict error of the employees missing from the company approval lists
+	ErrMissingCompanyApproval = errors.New("missing in company approval lists")
+)
+
+// Reason explains the verdict of a commit author
+type Reason string
+
+const (
+	// ReasonICLASigned - the author has signed an ICLA
+	ReasonICLASigned Reason = "icla_signed"
+	// ReasonCCLAApproved - the author is approved by the CCLA of their company and acknowledged it
+	ReasonCCLAApproved Reason = "ccla_approved"
+	// ReasonMissingIdentity - the commit has no email, login or provider ID to identify the author
+	ReasonMissingIdentity Reason = "missing_identity"
+	// ReasonUserNotFound - no EasyCLA user matches the author identity
+	ReasonUserNotFound Reason = "user_not_found"
+	// ReasonNotSigned - the author has no ICLA and no company affiliation
+	ReasonNotSigned Reason = "not_signed"
+	// ReasonICLAOutdated - the ICLA of the author was signed on a document version requiring a re-sign
+	ReasonICLAOutdated Reason = "icla_outdated"
+	// ReasonCompanyNotFound - the company the author is affiliated with can't be loaded
+	ReasonCompanyNotFound Reason = "company_not_found"
+	// ReasonCCLANotSigned - the company of the author has not signed a CCLA for the CLA Group
+	ReasonCCLANotSigned Reason = "ccla_not_signed"
+	// ReasonCCLAOutdated - the CCLA of the company was signed on a document version requiring a re-sign
+	ReasonCCLAOutdated Reason = "ccla_outdated"
+	// ReasonNotApproved - the author is not in any approval list of the CCLA
+	ReasonNotApproved Reason = "not_approved"
+	// ReasonEmployeeAcknowledgementMissing - the author is approved but hasn't acknowledged the CCLA
+	ReasonEmployeeAcknowledgementMissing Reason = "employee_acknowledgement_missing"
+	// ReasonLookupFailed - a record needed to decide could not be loaded
+	ReasonLookupFailed Reason = "lookup_failed"
+)
+
+// Message returns the human readable explanation of the reason
+func (r Reason) Message() string {
+	switch r {
+	case ReasonICLASigned:
+		return "signed an individual CLA"
+	case ReasonCCLAApproved:
+		return "authorized under the corporate CLA of their company"
+	case ReasonMissingIdentity:
+		return "the commit has no email or account identifying the author"
+	case ReasonUserNotFound:
+		return "the author is not an EasyCLA user"
+	case ReasonNotSigned:
+		return "no individual CLA and no company affiliation"
+	case ReasonICLAOutdated:
+		return "the individual CLA must be signed again on the current document version"
+	case ReasonCompanyNotFound:
+		return "the company the author is affiliated with could not be found"
+	case ReasonCCLANotSigned:
+		return "the company of the author has not signed the corporate CLA"
+	case ReasonCCLAOutdated:
+		return "the corporate CLA must be signed again on the current document version"
+	case ReasonNotApproved:
+		return "the author is not in the approval lists of the corporate CLA"
+	case ReasonEmployeeAcknowledgementMissing:
+		return "the author must confirm their affiliation with their company"
+	case ReasonLookupFailed:
+		return "the CLA status could not be verified"
+	}
+	return string(r)
+}
+
+// CommitIdentity is a commit author as reported by the repository provider, the fields the provider doesn't know are
+// left empty
+type CommitIdentity struct {
+	// Provider is the repository provider type, such as github or gitlab - it decides how ProviderID and Login are matched
+	Provider   string
+	Name       string
+	Email      string
+	ProviderID string
+	Login      string
+	// CoAuthor is set for the co-authors declared by a Co-authored-by trailer, they are only matched by email
+	CoAuthor bool
+}
+
+// AuthorVerdict is the CLA coverage of a commit author
+type AuthorVerdict struct {
+	Author  *CommitIdentity
+	Covered bool
+	// Required is false for the co-authors of a CLA Group only reporting them, they don't fail the check when not covered
+	Required bool
+	Reason   Reason
+	// UserID is the EasyCLA user the author was matched to, empty when not found
+	UserID string
+	// CompanyID is set when the author is affiliated with a company
+	CompanyID string
+	// SignatureID is the ICLA or CCLA covering the author, or the CCLA the author is missing from
+	SignatureID string
+	// Err is the provider neutral error explaining a failed verdict, see ErrMissingID, ErrMissingCompanyAffiliation and
+	// ErrMissingCompanyApproval
+	Err error
+}
+
+// Affiliated returns true if the author is affiliated with a company
+func (v *AuthorVerdict) Affiliated() bool {
+	return v.CompanyID != ""
+}
+
+// Evaluation is the result of a CLA check, the verdicts are in the order of the evaluated authors
+type Evaluation struct {
+	CLAGroupID string
+	Verdicts   []*AuthorVerdict
+}
+
+// Covered returns the verdicts of the authors covered by a signature
+func (e *Evaluation) Covered() []*AuthorVerdict {
+	var covered []*AuthorVerdict
+	for _, verdict := range e.Verdicts {
+		if verdict.Covered {
+			covered = append(covered, verdict)
+		}
+	}
+	return covered
+}
+
+// Missing returns the verdicts of the authors not covered by a signature
+func (e *Evaluation) Missing() []*AuthorVerdict {
+	var missing []*AuthorVerdict
+	for _, verdict := range e.Verdicts {
+		if !verdict.Covered {
+			missing = append(missing, verdict)
+		}
+	}
+	return missing
+}
+
+// Blocking returns the verdicts of the required authors not covered by a signature
+func (e *Evaluation) Blocking() []*AuthorVerdict {
+	var blocking []*AuthorVerdict
+	for _, verdict := range e.Verdicts {
+		if !verdict.Covered && verdict.Required {
+			blocking = append(blocking, verdict)
+		}
+	}
+	return blocking
+}
+
+// AllCovered returns true if every author is covered by a signature
+func (e *Evaluation) AllCovered() bool {
+	return len(e.Missing()) == 0
+}
+
+// Passed returns true if every required author is covered by a signature
+func (e *Evaluation) Passed() bool {
+	return len(e.Blocking()) == 0
+}
@@ -0,0 +1,417 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_check
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	signatures1 "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	projectCommon "github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// OrganizationApprovalCheck returns true if the author belongs to one of the organizations or groups of the CCLA
+// approval lists - the membership lookups need the provider API, so each provider supplies its own check
+type OrganizationApprovalCheck func(ctx context.Context, author *CommitIdentity, user *models.User, corporateSignature *models.Signature) (bool, error)
+
+// EvaluateInput is used to pass the commit authors of a change request to check against the CLA Group
+type EvaluateInput struct {
+	CLAGroupID string
+	Authors    []*CommitIdentity
+	// OrganizationApproval is optional, the organization approval lists are ignored when not set
+	OrganizationApproval OrganizationApprovalCheck
+}
+
+// Service evaluates the CLA coverage of change request authors, the repository providers only map their commit
+// authors to commit identities and render the verdicts
+type Service interface {
+	Evaluate(ctx context.Context, input *EvaluateInput) (*Evaluation, error)
+	IsApproved(ctx context.Context, author *CommitIdentity, user *models.User, corporateSignature *models.Signature, organizationApproval OrganizationApprovalCheck) bool
+	TriageGitHubAuthors(ctx context.Context, claGroupID string, authors []*github.UserCommitSummary) ([]*github.UserCommitSummary, []*github.UserCommitSummary, error)
+}
+
+type service struct {
+	usersRepository     users.UserRepository
+	signatureRepository signatures.SignatureRepository
+	companyRepository   company.IRepository
+	claGroupService     projectService.Service
+}
+
+// NewService creates a new CLA check service
+func NewService(usersRepository users.UserRepository, signatureRepository signatures.SignatureRepository, companyRepository company.IRepository, claGroupService projectService.Service) Service {
+	return &service{
+		usersRepository:     usersRepository,
+		signatureRepository: signatureRepository,
+		companyRepository:   companyRepository,
+		claGroupService:     claGroupService,
+	}
+}
+
+// Evaluate returns the verdict of each commit author, an error is only returned when the CLA Group can't be loaded
+func (s *service) Evaluate(ctx context.Context, input *EvaluateInput) (*Evaluation, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_check.service.Evaluate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     input.CLAGroupID,
+		"authors":        len(input.Authors),
+	}
+
+	// Load the CLA Group - the signature version policy decides if signatures of a previous document version are honored
+	claGroupModel, err := s.claGroupService.GetCLAGroupByID(ctx, input.CLAGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load CLA Group: %s", input.CLAGroupID)
+		return nil, err
+	}
+
+	evaluation := &Evaluation{
+		CLAGroupID: input.CLAGroupID,
+		Verdicts:   make([]*AuthorVerdict, 0, len(input.Authors)),
+	}
+	coAuthorsRequired := projectCommon.IsCoAuthorPolicyEnforced(claGroupModel)
+	for _, author := range input.Authors {
+		verdict := s.evaluateAuthor(ctx, claGroupModel, author, input.OrganizationApproval)
+		verdict.Required = !author.CoAuthor || coAuthorsRequired
+		log.WithFields(f).Debugf("commit author: %s <%s> covered: %t - %s", author.Name, author.Email, verdict.Covered, verdict.Reason.Message())
+		evaluation.Verdicts = append(evaluation.Verdicts, verdict)
+	}
+
+	return evaluation, nil
+}
+
+// evaluateAuthor checks every EasyCLA user matching the author, the author is covered if any of them is
+func (s *service) evaluateAuthor(ctx context.Context, claGroupModel *models.ClaGroup, author *CommitIdentity, organizationApproval OrganizationApprovalCheck) *AuthorVerdict {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_check.service.evaluateAuthor",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"provider":       author.Provider,
+		"providerID":     author.ProviderID,
+		"login":          author.Login,
+		"authorEmail":    author.Email,
+	}
+
+	if author.Email == "" && author.Login == "" && author.ProviderID == "" {
+		return &AuthorVerdict{Author: author, Reason: ReasonMissingIdentity, Err: ErrMissingID}
+	}
+
+	userModels := s.findUsers(f, author)
+	if len(userModels) == 0 {
+		log.WithFields(f).Warn("commit author not found in easycla records")
+		return &AuthorVerdict{Author: author, Reason: ReasonUserNotFound, Err: ErrMissingID}
+	}
+
+	var verdict *AuthorVerdict
+	for _, userModel := range userModels {
+		userVerdict := s.evaluateUser(ctx, f, claGroupModel, author, userModel, organizationApproval)
+		if userVerdict.Covered {
+			return userVerdict
+		}
+		if verdict == nil {
+			verdict = userVerdict
+		}
+	}
+	return verdict
+}
+
+// findUsers locates the EasyCLA users of the author by provider ID, login, then email
+func (s *service) findUsers(f logrus.Fields, author *CommitIdentity) []*models.User {
+	if author.ProviderID != "" {
+		var userModel *models.User
+		var err error
+		switch author.Provider {
+		case utils.GitHubType:
+			userModel, err = s.usersRepository.GetUserByGitHubID(author.ProviderID)
+		case utils.GitLabLower:
+			gitlabID, convErr := strconv.Atoi(author.ProviderID)
+			if convErr == nil {
+				userModel, err = s.usersRepository.GetUserByGitlabID(gitlabID)
+			}
+		}
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem locating the user via the %s ID: %s", author.Provider, author.ProviderID)
+		} else if userModel != nil {
+			return []*models.User{userModel}
+		}
+	}
+
+	if author.Login != "" {
+		var userModel *models.User
+		var err error
+		switch author.Provider {
+		case utils.GitHubType:
+			userModel, err = s.usersRepository.GetUserByGitHubUsername(author.Login)
+		case utils.GitLabLower:
+			userModel, err = s.usersRepository.GetUserByGitLabUsername(author.Login)
+		}
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem locating the user via the %s username: %s", author.Provider, author.Login)
+		} else if userModel != nil {
+			return []*models.User{userModel}
+		}
+	}
+
+	if author.Email != "" {
+		userModels, err := s.usersRepository.GetUsersByEmail(author.Email)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem locating the user via the commit author email")
+		} else if len(userModels) > 0 {
+			return userModels
+		}
+	}
+
+	return nil
+}
+
+// evaluateUser checks the ICLA of the user, then the CCLA of the company the user is affiliated with
+func (s *service) evaluateUser(ctx context.Context, f logrus.Fields, claGroupModel *models.ClaGroup, author *CommitIdentity, userModel *models.User, organizationApproval OrganizationApprovalCheck) *AuthorVerdict {
+	claGroupID := claGroupModel.ProjectID
+	verdict := &AuthorVerdict{Author: author, UserID: userModel.UserID, CompanyID: userModel.CompanyID}
+	failed := func(reason Reason, err error) *AuthorVerdict {
+		verdict.Reason = reason
+		verdict.Err = err
+		return verdict
+	}
+
+	// First check for an ICLA signature
+	icla, err := s.signatureRepository.GetIndividualSignature(ctx, claGroupID, userModel.UserID, aws.Bool(true), aws.Bool(true))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("fetching ICLA for user: %s failed", userModel.UserID)
+		return failed(ReasonLookupFailed, err)
+	}
+	iclaOutdated := false
+	if icla != nil {
+		if projectCommon.IsSignatureVersionAccepted(ctx, claGroupModel, icla, time.Now()) {
+			log.WithFields(f).Infof("user has signed the following signature (ICLA): %s, passing", icla.SignatureID)
+			verdict.Covered = true
+			verdict.Reason = ReasonICLASigned
+			verdict.SignatureID = icla.SignatureID
+			return verdict
+		}
+		log.WithFields(f).Infof("user has signed the following signature (ICLA): %s, but document version %s requires a re-sign",
+			icla.SignatureID, icla.SignatureDocumentMajorVersion)
+		iclaOutdated = true
+		verdict.SignatureID = icla.SignatureID
+	}
+
+	if userModel.CompanyID == "" {
+		log.WithFields(f).Debugf("user does not have association with any company, can't confirm employee acknowledgement")
+		if iclaOutdated {
+			return failed(ReasonICLAOutdated, fmt.Errorf("individual signature: %s requires a re-sign", icla.SignatureID))
+		}
+		return failed(ReasonNotSigned, fmt.Errorf("user hasn't signed yet"))
+	}
+
+	companyID := userModel.CompanyID
+	companyModel, err := s.companyRepository.GetCompany(ctx, companyID)
+	if err != nil {
+		msg := fmt.Sprintf("can't load company record: %s for user: %s (%s), error: %v", companyID, userModel.Username, userModel.UserID, err)
+		log.WithFields(f).Warn(msg)
+		return failed(ReasonCompanyNotFound, fmt.Errorf("%s", msg))
+	}
+
+	corporateSignature, err := s.signatureRepository.GetCorporateSignature(ctx, claGroupID, companyID, aws.Bool(true), aws.Bool(true))
+	if err != nil {
+		msg := fmt.Sprintf("can't load company signature record for company: %s for user : %s (%s), error : %v", companyID, userModel.Username, userModel.UserID, err)
+		log.WithFields(f).Warn(msg)
+		return failed(ReasonLookupFailed, fmt.Errorf("%s", msg))
+	}
+	if corporateSignature == nil {
+		msg := fmt.Sprintf("no corporate signature (CCLA) record found for company : %s ", companyID)
+		log.WithFields(f).Debug(msg)
+		return failed(ReasonCCLANotSigned, fmt.Errorf("%s", msg))
+	}
+	verdict.SignatureID = corporateSignature.SignatureID
+
+	if !projectCommon.IsSignatureVersionAccepted(ctx, claGroupModel, corporateSignature, time.Now()) {
+		msg := fmt.Sprintf("corporate signature (CCLA) record: %s document version %s requires a re-sign", corporateSignature.SignatureID, corporateSignature.SignatureDocumentMajorVersion)
+		log.WithFields(f).Warn(msg)
+		return failed(ReasonCCLAOutdated, fmt.Errorf("%s", msg))
+	}
+
+	if !s.IsApproved(ctx, author, userModel, corporateSignature, organizationApproval) {
+		log.WithFields(f).Debugf("user is not approved in signature : %s", corporateSignature.SignatureID)
+		return failed(ReasonNotApproved, ErrMissingCompanyApproval)
+	}
+
+	acknowledged, err := s.hasEmployeeAcknowledgement(ctx, companyModel, claGroupModel, author, userModel)
+	if err != nil {
+		msg := fmt.Sprintf("can't load employee signature records : %s for user : %s association : %v", companyID, userModel.UserID, err)
+		log.WithFields(f).Warn(msg)
+		return failed(ReasonLookupFailed, fmt.Errorf("%s", msg))
+	}
+	if !acknowledged {
+		log.WithFields(f).Debugf("no employee signature records found for company : %s user : %s association", companyID, userModel.UserID)
+		return failed(ReasonEmployeeAcknowledgementMissing, ErrMissingCompanyAffiliation)
+	}
+
+	log.WithFields(f).Debugf("is in signature approval list : %s and has employee signature", corporateSignature.SignatureID)
+	verdict.Covered = true
+	verdict.Reason = ReasonCCLAApproved
+	return verdict
+}
+
+// hasEmployeeAcknowledgement looks up the employee acknowledgement referencing the user, then the one matching the
+// email or username of the user - acknowledgements signed before the user record was linked only carry those
+func (s *service) hasEmployeeAcknowledgement(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, author *CommitIdentity, userModel *models.User) (bool, error) {
+	var wg sync.WaitGroup
+	resultChannel := make(chan *signatures.EmployeeModel, 1)
+	errorChannel := make(chan error, 1)
+	wg.Add(1)
+	s.signatureRepository.GetProjectCompanyEmployeeSignature(ctx, companyModel, claGroupModel, userModel, &wg, resultChannel, errorChannel)
+	wg.Wait()
+	close(resultChannel)
+	close(errorChannel)
+	if err := <-errorChannel; err != nil {
+		return false, err
+	}
+	if result := <-resultChannel; result != nil && result.Signature != nil {
+		return true, nil
+	}
+
+	criteria := employeeApprovalCriteria(author, userModel)
+	if criteria == nil {
+		return false, nil
+	}
+	employeeSignatures, err := s.signatureRepository.GetProjectCompanyEmployeeSignatures(ctx, signatures1.GetProjectCompanyEmployeeSignaturesParams{
+		CompanyID: companyModel.CompanyID,
+		ProjectID: claGroupModel.ProjectID,
+		PageSize:  utils.Int64(100),
+	}, criteria)
+	if err != nil {
+		return false, err
+	}
+	return employeeSignatures != nil && len(employeeSignatures.Signatures) > 0, nil
+}
+
+// employeeApprovalCriteria returns the criteria locating the employee acknowledgement of the resolved user - an email
+// of the user record first, preferring the commit email when the record holds it, then the provider username. Returns
+// nil when the user can't be identified, an empty criteria would match every acknowledgement of the company.
+func employeeApprovalCriteria(author *CommitIdentity, userModel *models.User) *signatures.ApprovalCriteria {
+	for _, email := range userRecordEmails(userModel) {
+		if author.Email != "" && strings.EqualFold(email, author.Email) {
+			return &signatures.ApprovalCriteria{UserEmail: email}
+		}
+	}
+	if email := utils.GetBestEmail(userModel); email != "" {
+		return &signatures.ApprovalCriteria{UserEmail: email}
+	}
+	switch author.Provider {
+	case utils.GitHubType:
+		if username := firstNonEmpty(author.Login, userModel.GithubUsername); username != "" {
+			return &signatures.ApprovalCriteria{GitHubUsername: username}
+		}
+	case utils.GitLabLower:
+		if username := firstNonEmpty(author.Login, userModel.GitlabUsername); username != "" {
+			return &signatures.ApprovalCriteria{GitlabUsername: username}
+		}
+	}
+	return nil
+}
+
+// IsApproved checks the author and the matching user against the approval lists of the corporate signature, the
+// expired time-boxed entries are ignored
+func (s *service) IsApproved(ctx context.Context, author *CommitIdentity, user *models.User, corporateSignature *models.Signature, organizationApproval OrganizationApprovalCheck) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.cla_check.service.IsApproved",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         user.UserID,
+		"signatureID":    corporateSignature.SignatureID,
+	}
+
+	// Time-boxed entries no longer count once expired
+	if approvalItems, err := s.signatureRepository.GetSignatureApprovalItems(ctx, corporateSignature.SignatureID); err == nil {
+		corporateSignature = signatures.WithoutExpiredApprovals(corporateSignature, approvalItems, time.Now())
+	}
+
+	// Only the emails of the user record count - the commit author email is set by whoever made the commit
+	userEmails := userRecordEmails(user)
+
+	for _, email := range userEmails {
+		for _, approvalEmail := range corporateSignature.EmailApprovalList {
+			if strings.EqualFold(email, approvalEmail) {
+				log.WithFields(f).Debugf("found user email : %s in email approval list ", email)
+				return true
+			}
+		}
+	}
+
+	if domainApprovalPattern, matched := utils.EmailsMatchDomainPatterns(userEmails, corporateSignature.DomainApprovalList); matched {
+		log.WithFields(f).Debugf("found user emails : %+v in domain approval list : %s", userEmails, domainApprovalPattern)
+		return true
+	}
+
+	githubUsername, gitlabUsername := user.GithubUsername, user.GitlabUsername
+	switch author.Provider {
+	case utils.GitHubType:
+		githubUsername = firstNonEmpty(author.Login, githubUsername)
+	case utils.GitLabLower:
+		gitlabUsername = firstNonEmpty(author.Login, gitlabUsername)
+	}
+	if containsFold(corporateSignature.GithubUsernameApprovalList, githubUsername) {
+		log.WithFields(f).Debugf("found github username : %s in github approval list", githubUsername)
+		return true
+	}
+	if containsFold(corporateSignature.GitlabUsernameApprovalList, gitlabUsername) {
+		log.WithFields(f).Debugf("found gitlab username : %s in gitlab approval list", gitlabUsername)
+		return true
+	}
+
+	if organizationApproval != nil {
+		approved, err := organizationApproval(ctx, author, user, corporateSignature)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("problem checking the organization approval lists")
+		} else if approved {
+			log.WithFields(f).Debug("found user in organization approval list")
+			return true
+		}
+	}
+
+	log.WithFields(f).Debug("unable to find user in any approval list")
+	return false
+}
+
+// userRecordEmails returns the emails of the user record
+func userRecordEmails(user *models.User) []string {
+	userEmails := append([]string{}, user.Emails...)
+	if string(user.LfEmail) != "" {
+		userEmails = append(userEmails, string(user.LfEmail))
+	}
+	return utils.RemoveDuplicates(userEmails)
+}
+
+func containsFold(values []string, value string) bool {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return false
+	}
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), value) {
+			return true
+		}
+	}
+	return false
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,344 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_check
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	mock_company "github.com/linuxfoundation/easycla/cla-backend-go/company/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	mock_project "github.com/linuxfoundation/easycla/cla-backend-go/project/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/users/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	claGroupID = "cla-group-123"
+	userID     = "user-123"
+	companyID  = "company-123"
+	cclaID     = "ccla-123"
+)
+
+type testMocks struct {
+	users      *mock_users.MockUserRepository
+	signatures *mock_signatures.MockSignatureRepository
+	companies  *mock_company.MockIRepository
+	claGroups  *mock_project.MockService
+}
+
+func newTestService(t *testing.T, claGroup *models.ClaGroup) (Service, *testMocks) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+	mocks := &testMocks{
+		users:      mock_users.NewMockUserRepository(ctrl),
+		signatures: mock_signatures.NewMockSignatureRepository(ctrl),
+		companies:  mock_company.NewMockIRepository(ctrl),
+		claGroups:  mock_project.NewMockService(ctrl),
+	}
+	if claGroup == nil {
+		claGroup = &models.ClaGroup{ProjectID: claGroupID}
+	}
+	mocks.claGroups.EXPECT().GetCLAGroupByID(gomock.Any(), claGroupID).Return(claGroup, nil).AnyTimes()
+	return NewService(mocks.users, mocks.signatures, mocks.companies, mocks.claGroups), mocks
+}
+
+// expectCorporateSignature sets up an employee of the company without an ICLA
+func (m *testMocks) expectCorporateSignature(ccla *models.Signature, approvalItems []approvals.ApprovalItem) {
+	m.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(nil, nil).AnyTimes()
+	m.companies.EXPECT().GetCompany(gomock.Any(), companyID).Return(&models.Company{CompanyID: companyID}, nil).AnyTimes()
+	m.signatures.EXPECT().GetCorporateSignature(gomock.Any(), claGroupID, companyID, gomock.Any(), gomock.Any()).Return(ccla, nil).AnyTimes()
+	m.signatures.EXPECT().GetSignatureApprovalItems(gomock.Any(), cclaID).Return(approvalItems, nil).AnyTimes()
+}
+
+// expectEmployeeAcknowledgement sets up the employee acknowledgement lookups, by user reference then by criteria
+func (m *testMocks) expectEmployeeAcknowledgement(byReference bool, byCriteria *signatures.ApprovalCriteria) {
+	m.signatures.EXPECT().GetProjectCompanyEmployeeSignature(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, companyModel *models.Company, claGroupModel *models.ClaGroup, user *models.User, wg *sync.WaitGroup, resultChannel chan<- *signatures.EmployeeModel, errorChannel chan<- error) {
+			defer wg.Done()
+			if byReference {
+				resultChannel <- &signatures.EmployeeModel{Signature: &models.Signature{SignatureID: "ecla-123"}, User: user}
+				return
+			}
+			resultChannel <- nil
+		}).AnyTimes()
+	if byReference {
+		return
+	}
+	m.signatures.EXPECT().GetProjectCompanyEmployeeSignatures(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, params interface{}, criteria *signatures.ApprovalCriteria) (*models.Signatures, error) {
+			if byCriteria != nil && *criteria == *byCriteria {
+				return &models.Signatures{Signatures: []*models.Signature{{SignatureID: "ecla-123"}}}, nil
+			}
+			return &models.Signatures{}, nil
+		}).AnyTimes()
+}
+
+func evaluateOne(t *testing.T, s Service, author *CommitIdentity, organizationApproval OrganizationApprovalCheck) *AuthorVerdict {
+	evaluation, err := s.Evaluate(context.Background(), &EvaluateInput{
+		CLAGroupID:           claGroupID,
+		Authors:              []*CommitIdentity{author},
+		OrganizationApproval: organizationApproval,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, evaluation.Verdicts, 1)
+	return evaluation.Verdicts[0]
+}
+
+func TestEvaluateICLASigned(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID}, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(&models.Signature{SignatureID: "icla-123"}, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat"}, nil)
+	assert.True(t, verdict.Covered)
+	assert.Equal(t, ReasonICLASigned, verdict.Reason)
+	assert.Equal(t, "icla-123", verdict.SignatureID)
+	assert.False(t, verdict.Affiliated())
+}
+
+func TestEvaluateMissingIdentity(t *testing.T) {
+	s, _ := newTestService(t, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, Name: "anonymous"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonMissingIdentity, verdict.Reason)
+	assert.True(t, errors.Is(verdict.Err, ErrMissingID))
+}
+
+func TestEvaluateUserNotFound(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitlabID(7).Return(nil, errors.New("not found"))
+	mocks.users.EXPECT().GetUserByGitLabUsername("ghost").Return(nil, nil)
+	mocks.users.EXPECT().GetUsersByEmail("ghost@example.com").Return(nil, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitLabLower, ProviderID: "7", Login: "ghost", Email: "ghost@example.com"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonUserNotFound, verdict.Reason)
+	assert.True(t, errors.Is(verdict.Err, ErrMissingID))
+}
+
+func TestEvaluateEmailOnlyProviderSkipsAccountLookups(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUsersByEmail("dev@example.com").Return([]*models.User{{UserID: userID}}, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(&models.Signature{SignatureID: "icla-123"}, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GiteaLower, Login: "dev", Email: "dev@example.com"}, nil)
+	assert.True(t, verdict.Covered)
+	assert.Equal(t, ReasonICLASigned, verdict.Reason)
+}
+
+func TestEvaluateNotSigned(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUsersByEmail("dev@example.com").Return([]*models.User{{UserID: userID}}, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.BitbucketLower, Email: "dev@example.com"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonNotSigned, verdict.Reason)
+	assert.Equal(t, userID, verdict.UserID)
+}
+
+func TestEvaluateICLAOutdated(t *testing.T) {
+	claGroup := &models.ClaGroup{
+		ProjectID:              claGroupID,
+		SignatureVersionPolicy: "resign",
+		ProjectIndividualDocuments: []models.ClaGroupDocument{
+			{DocumentMajorVersion: "2", DocumentMinorVersion: "0", DocumentCreationDate: "2024-01-01T00:00:00Z"},
+		},
+	}
+	s, mocks := newTestService(t, claGroup)
+	mocks.users.EXPECT().GetUsersByEmail("dev@example.com").Return([]*models.User{{UserID: userID}}, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).
+		Return(&models.Signature{SignatureID: "icla-123", SignatureDocumentMajorVersion: "1"}, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GiteaLower, Email: "dev@example.com"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonICLAOutdated, verdict.Reason)
+	assert.Equal(t, "icla-123", verdict.SignatureID)
+}
+
+func TestEvaluateCCLANotSigned(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUsersByEmail("dev@example.com").Return([]*models.User{{UserID: userID, CompanyID: companyID}}, nil)
+	mocks.expectCorporateSignature(nil, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GiteaLower, Email: "dev@example.com"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonCCLANotSigned, verdict.Reason)
+	assert.True(t, verdict.Affiliated())
+}
+
+func TestEvaluateNotApproved(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID, CompanyID: companyID, Emails: []string{"dev@example.com"}}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, EmailApprovalList: []string{"someone@example.com"}}, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonNotApproved, verdict.Reason)
+	assert.Equal(t, cclaID, verdict.SignatureID)
+	assert.True(t, errors.Is(verdict.Err, ErrMissingCompanyApproval))
+}
+
+func TestEvaluateEmployeeAcknowledgementMissing(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID, CompanyID: companyID}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, GithubUsernameApprovalList: []string{"OctoCat"}}, nil)
+	mocks.expectEmployeeAcknowledgement(false, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat", Email: "dev@example.com"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonEmployeeAcknowledgementMissing, verdict.Reason)
+	assert.True(t, errors.Is(verdict.Err, ErrMissingCompanyAffiliation))
+}
+
+func TestEvaluateCCLAApproved(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID, CompanyID: companyID}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, GithubUsernameApprovalList: []string{"octocat"}}, nil)
+	mocks.expectEmployeeAcknowledgement(true, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat"}, nil)
+	assert.True(t, verdict.Covered)
+	assert.Equal(t, ReasonCCLAApproved, verdict.Reason)
+	assert.Equal(t, companyID, verdict.CompanyID)
+	assert.Equal(t, cclaID, verdict.SignatureID)
+}
+
+func TestEvaluateAcknowledgementMatchedByCommitAuthor(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitlabID(7).Return(&models.User{UserID: userID, CompanyID: companyID}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, GitlabUsernameApprovalList: []string{"dev"}}, nil)
+	mocks.expectEmployeeAcknowledgement(false, &signatures.ApprovalCriteria{GitlabUsername: "dev"})
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitLabLower, ProviderID: "7", Login: "dev"}, nil)
+	assert.True(t, verdict.Covered)
+	assert.Equal(t, ReasonCCLAApproved, verdict.Reason)
+}
+
+func TestEvaluateCommitEmailNotOnUserRecordRejected(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID, CompanyID: companyID, Emails: []string{"dev@personal.org"}}, nil)
+	mocks.expectCorporateSignature(&models.Signature{
+		SignatureID:        cclaID,
+		EmailApprovalList:  []string{"x@approved-corp.com"},
+		DomainApprovalList: []string{"approved-corp.com"},
+	}, nil)
+
+	// anyone can write an approved address in the commit, only the emails of the user record count
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat", Email: "x@approved-corp.com"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonNotApproved, verdict.Reason)
+}
+
+func TestEvaluateAcknowledgementMatchedByUserEmail(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID, CompanyID: companyID, Emails: []string{"dev@acme.org"}}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, DomainApprovalList: []string{"acme.org"}}, nil)
+	mocks.expectEmployeeAcknowledgement(false, &signatures.ApprovalCriteria{UserEmail: "dev@acme.org"})
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat", Email: "someone-else@acme.org"}, nil)
+	assert.True(t, verdict.Covered)
+	assert.Equal(t, ReasonCCLAApproved, verdict.Reason)
+}
+
+func TestEvaluateOrganizationApproval(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID, CompanyID: companyID}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, GithubOrgApprovalList: []string{"acme"}}, nil)
+	mocks.expectEmployeeAcknowledgement(true, nil)
+
+	var checked []string
+	organizationApproval := func(ctx context.Context, author *CommitIdentity, user *models.User, corporateSignature *models.Signature) (bool, error) {
+		checked = append(checked, author.Login)
+		return true, nil
+	}
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat"}, organizationApproval)
+	assert.True(t, verdict.Covered)
+	assert.Equal(t, []string{"octocat"}, checked)
+}
+
+func TestEvaluateExpiredApprovalIgnored(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUsersByEmail("intern@example.org").Return([]*models.User{{UserID: userID, CompanyID: companyID, Emails: []string{"intern@example.org"}}}, nil)
+	mocks.expectCorporateSignature(&models.Signature{SignatureID: cclaID, EmailApprovalList: []string{"intern@example.org"}}, []approvals.ApprovalItem{
+		{ApprovalCriteria: utils.EmailApprovalCriteria, ApprovalName: "intern@example.org", Active: true, ExpiresAt: "2020-01-01T00:00:00Z"},
+	})
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GiteaLower, Email: "intern@example.org"}, nil)
+	assert.False(t, verdict.Covered)
+	assert.Equal(t, ReasonNotApproved, verdict.Reason)
+}
+
+func TestEvaluateKeepsAuthorOrder(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUsersByEmail("signed@example.com").Return([]*models.User{{UserID: userID}}, nil)
+	mocks.users.EXPECT().GetUsersByEmail("unknown@example.com").Return(nil, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(&models.Signature{SignatureID: "icla-123"}, nil)
+
+	evaluation, err := s.Evaluate(context.Background(), &EvaluateInput{
+		CLAGroupID: claGroupID,
+		Authors: []*CommitIdentity{
+			{Provider: utils.GiteaLower, Email: "unknown@example.com"},
+			{Provider: utils.GiteaLower, Email: "signed@example.com"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.False(t, evaluation.AllCovered())
+	assert.Equal(t, "unknown@example.com", evaluation.Missing()[0].Author.Email)
+	assert.Equal(t, "signed@example.com", evaluation.Covered()[0].Author.Email)
+	assert.Equal(t, ReasonUserNotFound, evaluation.Verdicts[0].Reason)
+	assert.Equal(t, ReasonICLASigned, evaluation.Verdicts[1].Reason)
+}
+
+func TestEvaluateCLAGroupLookupFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	claGroups := mock_project.NewMockService(ctrl)
+	claGroups.EXPECT().GetCLAGroupByID(gomock.Any(), claGroupID).Return(nil, errors.New("boom"))
+	s := NewService(mock_users.NewMockUserRepository(ctrl), mock_signatures.NewMockSignatureRepository(ctrl), mock_company.NewMockIRepository(ctrl), claGroups)
+
+	_, err := s.Evaluate(context.Background(), &EvaluateInput{CLAGroupID: claGroupID, Authors: []*CommitIdentity{{Email: "dev@example.com"}}})
+	assert.Error(t, err)
+}
+
+func TestEvaluateCoAuthorReported(t *testing.T) {
+	s, mocks := newTestService(t, nil)
+	mocks.users.EXPECT().GetUserByGitHubID("42").Return(&models.User{UserID: userID}, nil)
+	mocks.users.EXPECT().GetUsersByEmail("pair@example.com").Return(nil, nil)
+	mocks.signatures.EXPECT().GetIndividualSignature(gomock.Any(), claGroupID, userID, gomock.Any(), gomock.Any()).Return(&models.Signature{SignatureID: "icla-123"}, nil)
+
+	evaluation, err := s.Evaluate(context.Background(), &EvaluateInput{
+		CLAGroupID: claGroupID,
+		Authors: []*CommitIdentity{
+			{Provider: utils.GitHubType, ProviderID: "42", Login: "octocat"},
+			{Provider: utils.GitHubType, Name: "Pair", Email: "pair@example.com", CoAuthor: true},
+		},
+	})
+	assert.NoError(t, err)
+	assert.True(t, evaluation.Verdicts[0].Required)
+	assert.False(t, evaluation.Verdicts[1].Required)
+	assert.False(t, evaluation.AllCovered())
+	assert.True(t, evaluation.Passed())
+	assert.Empty(t, evaluation.Blocking())
+}
+
+func TestEvaluateCoAuthorEnforced(t *testing.T) {
+	s, mocks := newTestService(t, &models.ClaGroup{ProjectID: claGroupID, CoAuthorPolicy: "enforce"})
+	mocks.users.EXPECT().GetUsersByEmail("pair@example.com").Return(nil, nil)
+
+	verdict := evaluateOne(t, s, &CommitIdentity{Provider: utils.GitHubType, Name: "Pair", Email: "pair@example.com", CoAuthor: true}, nil)
+	assert.False(t, verdict.Covered)
+	assert.True(t, verdict.Required)
+	assert.Equal(t, ReasonUserNotFound, verdict.Reason)
+}
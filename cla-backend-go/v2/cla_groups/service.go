@@ -288,12 +288,52 @@ func (s *service) UpdateCLAGroup(ctx context.Context, authUser *auth.User, claGr
 		}
 	}
 
+	signatureVersionPolicy := claGroupModel.SignatureVersionPolicy
+	if input.SignatureVersionPolicy != "" {
+		signatureVersionPolicy = input.SignatureVersionPolicy
+	}
+	signatureExpiryDate := claGroupModel.SignatureExpiryDate
+	if input.SignatureExpiryDate != "" {
+		if _, parseErr := utils.ParseDateTime(input.SignatureExpiryDate); parseErr != nil {
+			log.WithFields(f).WithError(parseErr).Warnf("invalid signature expiry date: %s", input.SignatureExpiryDate)
+			return nil, parseErr
+		}
+		signatureExpiryDate = input.SignatureExpiryDate
+	}
+	if signatureVersionPolicy == common.SignatureVersionPolicyExpire && signatureExpiryDate == "" {
+		msg := "bad request: signature_expiry_date is required with the expire signature version policy"
+		log.WithFields(f).Warn(msg)
+		return nil, errors.New(msg)
+	}
+	// The expiry date only applies to the expire policy - switching to another policy clears it
+	if signatureVersionPolicy != common.SignatureVersionPolicyExpire {
+		if input.SignatureExpiryDate != "" {
+			msg := "bad request: signature_expiry_date is only used with the expire signature version policy"
+			log.WithFields(f).Warn(msg)
+			return nil, errors.New(msg)
+		}
+		signatureExpiryDate = ""
+	}
+	signatureProvider := claGroupModel.SignatureProvider
+	if input.SignatureProvider != "" {
+		signatureProvider = input.SignatureProvider
+	}
+	coAuthorPolicy := claGroupModel.CoAuthorPolicy
+	if input.CoAuthorPolicy != "" {
+		coAuthorPolicy = input.CoAuthorPolicy
+	}
+
 	// Update the CLA Group
 	log.WithFields(f).WithField("input", input).Debugf("updating cla group...")
 	claGroup, err := s.v1ProjectService.UpdateCLAGroup(ctx, &v1Models.ClaGroup{
-		ProjectID:          claGroupModel.ProjectID,
-		ProjectName:        input.ClaGroupName,
-		ProjectDescription: input.ClaGroupDescription,
+		ProjectID:              claGroupModel.ProjectID,
+		ProjectName:            input.ClaGroupName,
+		ProjectDescription:     input.ClaGroupDescription,
+		SignatureVersionPolicy: signatureVersionPolicy,
+		SignatureExpiryDate:    signatureExpiryDate,
+		SignatureProvider:      signatureProvider,
+		CoAuthorPolicy:         coAuthorPolicy,
+		BotAllowlist:           toV1BotAllowlist(input.BotAllowlist),
 		// Copy over the existing values
 		ProjectExternalID:            claGroupModel.ProjectExternalID,
 		FoundationSFID:               claGroupModel.FoundationSFID,
@@ -350,6 +390,14 @@ func (s *service) UpdateCLAGroup(ctx context.Context, authUser *auth.User, claGr
 		FoundationName:      foundationName,
 		IclaEnabled:         claGroup.ProjectICLAEnabled,
 		ProjectList:         projectList,
+		// Signature version policy
+		SignatureVersionPolicy: claGroup.SignatureVersionPolicy,
+		SignatureExpiryDate:    claGroup.SignatureExpiryDate,
+		// Signature provider, empty for the deployment default
+		SignatureProvider: claGroup.SignatureProvider,
+		// Co-authored-by trailer policy
+		CoAuthorPolicy: claGroup.CoAuthorPolicy,
+		BotAllowlist:   toV2BotAllowlist(claGroup.BotAllowlist),
 	}
 
 	// Load and set the ICLA template - if set
@@ -568,6 +616,14 @@ func (s *service) buildClaGroupSummaryResponseModel(ctx context.Context, f logru
 			IclaEnabled:         v1ClaGroup.ProjectICLAEnabled,
 			IclaPdfURL:          currentICLADoc.DocumentS3URL,
 			CclaPdfURL:          currentCCLADoc.DocumentS3URL,
+			// Signature version policy
+			SignatureVersionPolicy: v1ClaGroup.SignatureVersionPolicy,
+			SignatureExpiryDate:    v1ClaGroup.SignatureExpiryDate,
+			// Signature provider, empty for the deployment default
+			SignatureProvider: v1ClaGroup.SignatureProvider,
+			// Co-authored-by trailer policy
+			CoAuthorPolicy: v1ClaGroup.CoAuthorPolicy,
+			BotAllowlist:   toV2BotAllowlist(v1ClaGroup.BotAllowlist),
 			// Add root_project_repositories_count to repositories_count initially
 			RepositoriesCount:            v1ClaGroup.RootProjectRepositoriesCount,
 			RootProjectRepositoriesCount: v1ClaGroup.RootProjectRepositoriesCount,
@@ -1186,3 +1242,25 @@ func Find(slice []v1Models.ClaGroup, val string) (int, bool) {
 	}
 	return -1, false
 }
+
+// toV1BotAllowlist converts the bot allowlist of the update input, nil leaves the stored allowlist unchanged
+func toV1BotAllowlist(in *models.BotAllowlist) *v1Models.BotAllowlist {
+	if in == nil {
+		return nil
+	}
+	return &v1Models.BotAllowlist{
+		Logins:        utils.RemoveDuplicates(in.Logins),
+		EmailPatterns: utils.RemoveDuplicates(in.EmailPatterns),
+	}
+}
+
+// toV2BotAllowlist converts the stored bot allowlist of the CLA Group
+func toV2BotAllowlist(in *v1Models.BotAllowlist) *models.BotAllowlist {
+	if in == nil {
+		return nil
+	}
+	return &models.BotAllowlist{
+		Logins:        in.Logins,
+		EmailPatterns: in.EmailPatterns,
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package cla_groups
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	service2 "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/stretchr/testify/assert"
+)
+
+var errUpdateRecorded = errors.New("update recorded")
+
+// fakeProjectService records the CLA Group update and stops the update flow
+type fakeProjectService struct {
+	service2.Service
+	updated *v1Models.ClaGroup
+}
+
+func (s *fakeProjectService) UpdateCLAGroup(ctx context.Context, claGroupModel *v1Models.ClaGroup) (*v1Models.ClaGroup, error) {
+	s.updated = claGroupModel
+	return nil, errUpdateRecorded
+}
+
+func updateCLAGroup(existing *v1Models.ClaGroup, input *models.UpdateClaGroupInput) (*v1Models.ClaGroup, error) {
+	projectService := &fakeProjectService{}
+	s := &service{v1ProjectService: projectService}
+	_, err := s.UpdateCLAGroup(context.Background(), &auth.User{UserName: "admin"}, existing, input)
+	if err == errUpdateRecorded {
+		return projectService.updated, nil
+	}
+	return nil, err
+}
+
+func TestUpdateCLAGroupSignatureExpiryDate(t *testing.T) {
+	expiring := &v1Models.ClaGroup{
+		ProjectID:              "cla-group-id",
+		SignatureVersionPolicy: common.SignatureVersionPolicyExpire,
+		SignatureExpiryDate:    "2026-12-31T00:00:00Z",
+	}
+
+	// the expiry date is kept while the expire policy is in place
+	updated, err := updateCLAGroup(expiring, &models.UpdateClaGroupInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, "2026-12-31T00:00:00Z", updated.SignatureExpiryDate)
+
+	updated, err = updateCLAGroup(expiring, &models.UpdateClaGroupInput{SignatureExpiryDate: "2027-06-30T00:00:00Z"})
+	assert.NoError(t, err)
+	assert.Equal(t, "2027-06-30T00:00:00Z", updated.SignatureExpiryDate)
+
+	// switching to another policy clears the expiry date
+	updated, err = updateCLAGroup(expiring, &models.UpdateClaGroupInput{SignatureVersionPolicy: common.SignatureVersionPolicyResign})
+	assert.NoError(t, err)
+	assert.Equal(t, common.SignatureVersionPolicyResign, updated.SignatureVersionPolicy)
+	assert.Empty(t, updated.SignatureExpiryDate)
+}
+
+func TestUpdateCLAGroupSignatureExpiryDateValidation(t *testing.T) {
+	claGroup := &v1Models.ClaGroup{ProjectID: "cla-group-id", SignatureVersionPolicy: common.SignatureVersionPolicyAcceptPrevious}
+
+	_, err := updateCLAGroup(claGroup, &models.UpdateClaGroupInput{SignatureVersionPolicy: common.SignatureVersionPolicyExpire})
+	assert.EqualError(t, err, "bad request: signature_expiry_date is required with the expire signature version policy")
+
+	_, err = updateCLAGroup(claGroup, &models.UpdateClaGroupInput{SignatureExpiryDate: "2026-12-31T00:00:00Z"})
+	assert.EqualError(t, err, "bad request: signature_expiry_date is only used with the expire signature version policy")
+
+	_, err = updateCLAGroup(claGroup, &models.UpdateClaGroupInput{SignatureVersionPolicy: common.SignatureVersionPolicyExpire, SignatureExpiryDate: "not a date"})
+	assert.Error(t, err)
+}
+
+func TestUpdateCLAGroupSignatureProvider(t *testing.T) {
+	claGroup := &v1Models.ClaGroup{ProjectID: "cla-group-id", SignatureProvider: "docusign"}
+
+	// the provider is kept when the input doesn't set one
+	updated, err := updateCLAGroup(claGroup, &models.UpdateClaGroupInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, "docusign", updated.SignatureProvider)
+
+	updated, err = updateCLAGroup(claGroup, &models.UpdateClaGroupInput{SignatureProvider: "click-through"})
+	assert.NoError(t, err)
+	assert.Equal(t, "click-through", updated.SignatureProvider)
+}
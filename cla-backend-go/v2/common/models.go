@@ -9,25 +9,27 @@ import (
 
 // GitLabOrganization is data model for gitlab organizations
 type GitLabOrganization struct {
-	OrganizationID          string `json:"organization_id"`
-	ExternalGroupID         int    `json:"external_gitlab_group_id"`
-	DateCreated             string `json:"date_created,omitempty"`
-	DateModified            string `json:"date_modified,omitempty"`
-	OrganizationName        string `json:"organization_name,omitempty"`
-	OrganizationNameLower   string `json:"organization_name_lower,omitempty"`
-	OrganizationFullPath    string `json:"organization_full_path,omitempty"`
-	OrganizationURL         string `json:"organization_url,omitempty"`
-	OrganizationSFID        string `json:"organization_sfid,omitempty"`
-	ProjectSFID             string `json:"project_sfid"`
-	Enabled                 bool   `json:"enabled"`
-	AutoEnabled             bool   `json:"auto_enabled"`
-	BranchProtectionEnabled bool   `json:"branch_protection_enabled"`
-	AutoEnabledClaGroupID   string `json:"auto_enabled_cla_group_id,omitempty"`
-	AuthInfo                string `json:"auth_info"`
-	AuthState               string `json:"auth_state"`
-	Note                    string `json:"note,omitempty"`
-	AuthExpirationTime      int    `json:"auth_expiry_time,omitempty"`
-	Version                 string `json:"version,omitempty"`
+	OrganizationID          string   `json:"organization_id"`
+	ExternalGroupID         int      `json:"external_gitlab_group_id"`
+	DateCreated             string   `json:"date_created,omitempty"`
+	DateModified            string   `json:"date_modified,omitempty"`
+	OrganizationName        string   `json:"organization_name,omitempty"`
+	OrganizationNameLower   string   `json:"organization_name_lower,omitempty"`
+	OrganizationFullPath    string   `json:"organization_full_path,omitempty"`
+	OrganizationURL         string   `json:"organization_url,omitempty"`
+	OrganizationSFID        string   `json:"organization_sfid,omitempty"`
+	ProjectSFID             string   `json:"project_sfid"`
+	Enabled                 bool     `json:"enabled"`
+	AutoEnabled             bool     `json:"auto_enabled"`
+	BranchProtectionEnabled bool     `json:"branch_protection_enabled"`
+	AutoEnabledClaGroupID   string   `json:"auto_enabled_cla_group_id,omitempty"`
+	AuthInfo                string   `json:"auth_info"`
+	AuthState               string   `json:"auth_state"`
+	Note                    string   `json:"note,omitempty"`
+	AuthExpirationTime      int      `json:"auth_expiry_time,omitempty"`
+	BotLoginAllowlist       []string `json:"bot_login_allowlist,omitempty"`
+	BotEmailAllowlist       []string `json:"bot_email_allowlist,omitempty"`
+	Version                 string   `json:"version,omitempty"`
 }
 
 // ToModel converts to models.GitlabOrganization
@@ -50,12 +52,24 @@ func ToModel(in *GitLabOrganization) *models2.GitlabOrganization {
 		OrganizationExternalID:  int64(in.ExternalGroupID),
 		AuthState:               in.AuthState,
 		AuthExpiryTime:          int64(in.AuthExpirationTime),
+		BotAllowlist:            toBotAllowlistModel(in.BotLoginAllowlist, in.BotEmailAllowlist),
+	}
+}
+
+// toBotAllowlistModel returns the bot allowlist of the organization or nil if no bots are exempt
+func toBotAllowlistModel(logins, emailPatterns []string) *models2.BotAllowlist {
+	if len(logins) == 0 && len(emailPatterns) == 0 {
+		return nil
+	}
+	return &models2.BotAllowlist{
+		Logins:        logins,
+		EmailPatterns: emailPatterns,
 	}
 }
 
 // ToCommonModel converts to common.GitLabOrganization
 func ToCommonModel(in *models2.GitlabOrganization) *GitLabOrganization {
-	return &GitLabOrganization{
+	out := &GitLabOrganization{
 		AuthInfo:                in.AuthInfo,
 		OrganizationID:          in.OrganizationID,
 		DateCreated:             in.DateCreated,
@@ -74,6 +88,11 @@ func ToCommonModel(in *models2.GitlabOrganization) *GitLabOrganization {
 		AuthState:               in.AuthState,
 		AuthExpirationTime:      int(in.AuthExpiryTime),
 	}
+	if in.BotAllowlist != nil {
+		out.BotLoginAllowlist = in.BotAllowlist.Logins
+		out.BotEmailAllowlist = in.BotAllowlist.EmailPatterns
+	}
+	return out
 }
 
 // ToModels converts a list of GitLab organizations to a list of external GitLab organization response models
@@ -105,6 +124,9 @@ type GitLabAddOrganization struct {
 	AuthInfo                string `json:"auth_info"`
 	AuthState               string `json:"auth_state"`
 	Version                 string `json:"version,omitempty"`
+
+	// BotAllowlist replaces the stored bot allowlist on update when set
+	BotAllowlist *models2.BotAllowlist `json:"-"`
 }
 
 // ExternalGroupIDAsInt returns the external group ID as an integer value
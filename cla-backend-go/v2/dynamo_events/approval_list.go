@@ -0,0 +1,133 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// approvalListAdditions contains the entries added to the approval lists of a CCLA signature
+type approvalListAdditions struct {
+	Emails          []string
+	Domains         []string
+	GitHubUsernames []string
+	GitHubOrgs      []string
+	GitLabUsernames []string
+	GitLabOrgs      []string
+}
+
+// IsEmpty returns true if no approval list entries were added
+func (a approvalListAdditions) IsEmpty() bool {
+	return len(a.Emails) == 0 && len(a.Domains) == 0 && len(a.GitHubUsernames) == 0 &&
+		len(a.GitHubOrgs) == 0 && len(a.GitLabUsernames) == 0 && len(a.GitLabOrgs) == 0
+}
+
+// CoversAnyUser returns true if the additions may cover contributors which can't be individually identified, such as
+// all the contributors of an email domain or of a GitHub/GitLab organization
+func (a approvalListAdditions) CoversAnyUser() bool {
+	return len(a.Domains) > 0 || len(a.GitHubOrgs) > 0 || len(a.GitLabOrgs) > 0
+}
+
+// Users returns the contributors newly covered by the email and username additions
+func (a approvalListAdditions) Users() []*models.User {
+	var users []*models.User
+	for _, email := range a.Emails {
+		users = append(users, &models.User{Emails: []string{email}})
+	}
+	for _, githubUsername := range a.GitHubUsernames {
+		users = append(users, &models.User{GithubUsername: githubUsername})
+	}
+	for _, gitlabUsername := range a.GitLabUsernames {
+		users = append(users, &models.User{GitlabUsername: gitlabUsername})
+	}
+	return users
+}
+
+// getApprovalListAdditions returns the entries present in the new signature approval lists but not in the old ones
+func getApprovalListAdditions(oldSignature, newSignature Signature) approvalListAdditions {
+	return approvalListAdditions{
+		Emails:          addedEntries(oldSignature.EmailWhitelist, newSignature.EmailWhitelist),
+		Domains:         addedEntries(oldSignature.DomainWhitelist, newSignature.DomainWhitelist),
+		GitHubUsernames: addedEntries(oldSignature.GitHubWhitelist, newSignature.GitHubWhitelist),
+		GitHubOrgs:      addedEntries(oldSignature.GitHubOrgWhitelist, newSignature.GitHubOrgWhitelist),
+		GitLabUsernames: addedEntries(oldSignature.GitlabUsernameApprovalList, newSignature.GitlabUsernameApprovalList),
+		GitLabOrgs:      addedEntries(oldSignature.GitlabOrgApprovalList, newSignature.GitlabOrgApprovalList),
+	}
+}
+
+// addedEntries returns the entries of newList which are not in oldList
+func addedEntries(oldList, newList []string) []string {
+	var added []string
+	for _, entry := range newList {
+		if !utils.StringInSlice(entry, oldList) {
+			added = append(added, entry)
+		}
+	}
+	return added
+}
+
+// SignatureApprovalListUpdatedEvent re-evaluates the open pull/merge requests of the CLA Group repositories when
+// entries are added to the approval lists of a CCLA signature, so that newly covered contributors don't have to push
+// again to get their CLA status updated
+func (s *service) SignatureApprovalListUpdatedEvent(event events.DynamoDBEventRecord) error {
+	ctx := newEventContext()
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.SignatureApprovalListUpdatedEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	// Decode the pre-update and post-update signature record details
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+
+	f["id"] = newSignature.SignatureID
+	f["type"] = newSignature.SignatureType
+	f["referenceID"] = newSignature.SignatureReferenceID
+	f["projectID"] = newSignature.SignatureProjectID
+
+	if newSignature.SignatureType != CCLASignatureType || !newSignature.SignatureSigned || !newSignature.SignatureApproved {
+		return nil
+	}
+
+	additions := getApprovalListAdditions(oldSignature, newSignature)
+	if additions.IsEmpty() {
+		return nil
+	}
+	log.WithFields(f).Debugf("approval list additions: %+v", additions)
+
+	// Domain and organization entries may cover any contributor - re-evaluate all the open pull/merge requests
+	var affectedUsers []*models.User
+	if !additions.CoversAnyUser() {
+		affectedUsers = additions.Users()
+	}
+
+	if s.signatureService != nil {
+		log.WithFields(f).Debug("updating open GitHub pull requests of newly covered contributors...")
+		if prErr := s.signatureService.UpdateOpenPullRequests(ctx, newSignature.SignatureProjectID, affectedUsers); prErr != nil {
+			log.WithFields(f).WithError(prErr).Warn("problem updating open GitHub pull requests")
+		}
+	}
+
+	if s.gitLabActivityService != nil {
+		log.WithFields(f).Debug("updating open GitLab merge requests of newly covered contributors...")
+		if mrErr := s.gitLabActivityService.UpdateOpenMergeRequests(ctx, newSignature.SignatureProjectID, affectedUsers); mrErr != nil {
+			log.WithFields(f).WithError(mrErr).Warn("problem updating open GitLab merge requests")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetApprovalListAdditions(t *testing.T) {
+	oldSignature := Signature{
+		EmailWhitelist:  []string{"alice@example.org"},
+		GitHubWhitelist: []string{"alice"},
+	}
+
+	// Removals only - nothing newly covered
+	additions := getApprovalListAdditions(oldSignature, Signature{EmailWhitelist: []string{"alice@example.org"}})
+	assert.True(t, additions.IsEmpty())
+
+	// Individual additions
+	additions = getApprovalListAdditions(oldSignature, Signature{
+		EmailWhitelist:             []string{"alice@example.org", "bob@example.org"},
+		GitHubWhitelist:            []string{"alice", "bob"},
+		GitlabUsernameApprovalList: []string{"carol"},
+	})
+	assert.False(t, additions.IsEmpty())
+	assert.False(t, additions.CoversAnyUser())
+	assert.Equal(t, []string{"bob@example.org"}, additions.Emails)
+	assert.Equal(t, []string{"bob"}, additions.GitHubUsernames)
+	users := additions.Users()
+	if assert.Len(t, users, 3) {
+		assert.Equal(t, []string{"bob@example.org"}, users[0].Emails)
+		assert.Equal(t, "bob", users[1].GithubUsername)
+		assert.Equal(t, "carol", users[2].GitlabUsername)
+	}
+
+	// Domain and organization additions
+	additions = getApprovalListAdditions(oldSignature, Signature{
+		EmailWhitelist:     []string{"alice@example.org"},
+		GitHubWhitelist:    []string{"alice"},
+		DomainWhitelist:    []string{"example.org"},
+		GitHubOrgWhitelist: []string{"example"},
+	})
+	assert.False(t, additions.IsEmpty())
+	assert.True(t, additions.CoversAnyUser())
+}
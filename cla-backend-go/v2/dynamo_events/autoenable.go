@@ -65,7 +65,7 @@ type autoEnableServiceProvider struct {
 }
 
 func (a *autoEnableServiceProvider) CreateAutoEnabledRepository(repo *github.Repository) (*models.GithubRepository, error) {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	repositoryFullName := *repo.FullName
 	repositoryExternalID := strconv.FormatInt(*repo.ID, 10)
 	f := logrus.Fields{
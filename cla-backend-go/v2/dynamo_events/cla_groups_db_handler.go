@@ -12,7 +12,7 @@ import (
 )
 
 func (s *service) ProcessCLAGroupUpdateEvents(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "ProcessCLAGroupUpdateEvents",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
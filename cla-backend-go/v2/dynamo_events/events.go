@@ -4,60 +4,109 @@
 package dynamo_events
 
 import (
+	"context"
+
 	"github.com/aws/aws-lambda-go/events"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
-	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	v2ProjectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 	"github.com/sirupsen/logrus"
 )
 
 // Event data model
 type Event struct {
-	EventID         string `json:"event_id"`
-	EventProjectID  string `json:"event_project_id"`
-	EventCompanyID  string `json:"event_company_id"`
-	EventCLAGroupID string `json:"event_cla_group_id"`
+	EventID           string `json:"event_id"`
+	EventType         string `json:"event_type"`
+	EventUserID       string `json:"event_user_id"`
+	EventUserName     string `json:"event_user_name"`
+	EventLfUsername   string `json:"event_lf_username"`
+	EventProjectID    string `json:"event_project_id"`
+	EventCompanyID    string `json:"event_company_id"`
+	EventCompanyName  string `json:"event_company_name"`
+	EventCLAGroupID   string `json:"event_cla_group_id"`
+	EventCLAGroupName string `json:"event_cla_group_name"`
+	EventData         string `json:"event_data"`
+	EventSummary      string `json:"event_summary"`
+	EventTime         string `json:"event_time"`
+	EventTimeEpoch    int64  `json:"event_time_epoch"`
+}
+
+// eventScope contains the foundation, project and company the event belongs to
+type eventScope struct {
+	foundationSFID string
+	projectSFID    string
+	projectSFName  string
+	companySFID    string
 }
 
-// should be called when we insert Event
+// should be called when we insert Event - the event scope is added to the event and the event is queued for the
+// matching outbound webhook subscriptions
 func (s *service) EventAddedEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	var newEvent Event
 	err := unmarshalStreamImage(event.Change.NewImage, &newEvent)
 	if err != nil {
 		return err
 	}
+	scope := s.loadEventScope(ctx, &newEvent)
+	err = s.eventsRepo.AddDataToEvent(newEvent.EventID, scope.foundationSFID, scope.projectSFID, scope.projectSFName, scope.companySFID, newEvent.EventProjectID, newEvent.EventCLAGroupID)
+	if err != nil {
+		return err
+	}
+	if s.webhookService == nil {
+		return nil
+	}
+	return s.webhookService.QueueEvent(ctx, &webhooks.EventPayload{
+		EventID:         newEvent.EventID,
+		EventType:       newEvent.EventType,
+		EventTime:       newEvent.EventTime,
+		EventTimeEpoch:  newEvent.EventTimeEpoch,
+		EventSummary:    newEvent.EventSummary,
+		EventData:       newEvent.EventData,
+		EventUserID:     newEvent.EventUserID,
+		EventUserName:   newEvent.EventUserName,
+		EventLFUsername: newEvent.EventLfUsername,
+		FoundationSFID:  scope.foundationSFID,
+		ProjectSFID:     scope.projectSFID,
+		ProjectName:     scope.projectSFName,
+		CLAGroupID:      newEvent.EventCLAGroupID,
+		CLAGroupName:    newEvent.EventCLAGroupName,
+		CompanyID:       newEvent.EventCompanyID,
+		CompanySFID:     scope.companySFID,
+		CompanyName:     newEvent.EventCompanyName,
+	})
+}
+
+// loadEventScope returns the foundation, project and company of the event - the values which can't be loaded are
+// left empty
+func (s *service) loadEventScope(ctx context.Context, newEvent *Event) eventScope {
 	f := logrus.Fields{"event": newEvent}
-	var foundationSFID, projectSFID, projectSFName, companySFID string
+	var scope eventScope
 	companyModel, err := s.companyRepo.GetCompany(ctx, newEvent.EventCompanyID)
 	if err != nil {
 		log.WithFields(f).Error("unable to get company detail", err)
 	} else {
-		companySFID = companyModel.CompanyExternalID
+		scope.companySFID = companyModel.CompanyExternalID
 	}
 	pmList, err := s.projectsClaGroupRepo.GetProjectsIdsForClaGroup(ctx, newEvent.EventCLAGroupID)
 	if err != nil || len(pmList) == 0 {
 		log.WithFields(f).Error("unable to get project mapping detail", err)
 	} else {
 		if len(pmList) > 1 {
-			foundationSFID = pmList[0].FoundationSFID
-			projectSFID = pmList[0].FoundationSFID
+			scope.foundationSFID = pmList[0].FoundationSFID
+			scope.projectSFID = pmList[0].FoundationSFID
 			psc := v2ProjectService.GetClient()
-			projectDetails, perr := psc.GetProject(foundationSFID)
+			projectDetails, perr := psc.GetProject(scope.foundationSFID)
 			if perr != nil {
-				log.WithFields(f).WithField("foundation_sfid", foundationSFID).Error("unable to fetch foundation details", perr)
+				log.WithFields(f).WithField("foundation_sfid", scope.foundationSFID).Error("unable to fetch foundation details", perr)
 			} else {
-				projectSFName = projectDetails.Name
+				scope.projectSFName = projectDetails.Name
 			}
 		} else {
-			foundationSFID = pmList[0].FoundationSFID
-			projectSFID = pmList[0].ProjectSFID
-			projectSFName = pmList[0].ProjectName
+			scope.foundationSFID = pmList[0].FoundationSFID
+			scope.projectSFID = pmList[0].ProjectSFID
+			scope.projectSFName = pmList[0].ProjectName
 		}
 	}
-	err = s.eventsRepo.AddDataToEvent(newEvent.EventID, foundationSFID, projectSFID, projectSFName, companySFID, newEvent.EventProjectID, newEvent.EventCLAGroupID)
-	if err != nil {
-		return err
-	}
-	return nil
+	return scope
 }
@@ -6,6 +6,7 @@ package dynamo_events
 import (
 	"context"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
 	"github.com/linuxfoundation/easycla/cla-backend-go/github/branch_protection"
 
 	"github.com/aws/aws-lambda-go/events"
@@ -18,7 +19,7 @@ import (
 
 // GitHubOrgAddedEvent github repository added event
 func (s *service) GitHubOrgAddedEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamodb_events.github_organization.GitHubOrgAddedEvent",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -52,7 +53,7 @@ func (s *service) GitHubOrgAddedEvent(event events.DynamoDBEventRecord) error {
 
 // GitHubOrgUpdatedEvent github repository updated event
 func (s *service) GitHubOrgUpdatedEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamodb_events.github_organization.GitHubOrgUpdatedEvent",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -90,7 +91,7 @@ func (s *service) GitHubOrgUpdatedEvent(event events.DynamoDBEventRecord) error
 
 // GitHubOrgDeletedEvent github repository deleted event
 func (s *service) GitHubOrgDeletedEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamodb_events.github_organization.GitHubOrgDeletedEvent",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -190,5 +191,7 @@ func (s *service) enableBranchProtectionForGithubOrg(ctx context.Context, newGit
 		branchProtectionErr = loadErr
 	}
 
+	github.LogRateLimitMetrics(ctx)
+
 	return branchProtectionErr
 }
@@ -18,7 +18,7 @@ import (
 
 // GithubRepoModifyAddEvent github repository modify add event
 func (s *service) GithubRepoModifyAddEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "GitHubRepoModifyEvent",
 		"eventID":        event.EventID,
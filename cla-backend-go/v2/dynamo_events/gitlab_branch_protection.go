@@ -19,7 +19,7 @@ import (
 
 // GitLabOrgUpdatedEvent handles branch protection functionality
 func (s *service) GitLabOrgUpdatedEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamodb_events.gitlab_organization.GitLabOrgUpdatedEvent",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
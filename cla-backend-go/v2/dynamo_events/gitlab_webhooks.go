@@ -17,7 +17,7 @@ import (
 )
 
 func (s *service) GitLabRepoAddedWebhookEventHandler(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "GitLabRepoAddedWebhookEventHandler",
 		"eventID":        event.EventID,
@@ -81,7 +81,7 @@ func (s *service) GitLabRepoAddedWebhookEventHandler(event events.DynamoDBEventR
 }
 
 func (s *service) GitlabRepoModifiedWebhookEventHandler(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "GitlabRepoModifiedWebhookEventHandler",
 		"eventID":        event.EventID,
@@ -166,7 +166,7 @@ func (s *service) GitlabRepoModifiedWebhookEventHandler(event events.DynamoDBEve
 }
 
 func (s *service) GitLabRepoRemovedWebhookEventHandler(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "GitLabRepoRemovedWebhookEventHandler",
 		"eventID":        event.EventID,
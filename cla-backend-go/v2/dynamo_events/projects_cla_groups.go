@@ -174,7 +174,7 @@ type ProjectClaGroup struct {
 //}
 
 func (s *service) ProjectUnenrolledDisableRepositoryHandler(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamo_events.projects_cla_groups.ProjectUnenrolledDisableRepositoryHandler",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -250,7 +250,7 @@ func (s *service) ProjectUnenrolledDisableRepositoryHandler(event events.DynamoD
 
 // AddCLAPermissions handles adding CLA permissions
 func (s *service) AddCLAPermissions(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamo_events.projects_cla_groups.AddCLAPermissions",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -291,7 +291,7 @@ func (s *service) AddCLAPermissions(event events.DynamoDBEventRecord) error {
 
 // RemoveCLAPermissions handles removing existing CLA permissions
 func (s *service) RemoveCLAPermissions(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "dynamo_events.projects_cla_groups.RemoveCLAPermissions",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
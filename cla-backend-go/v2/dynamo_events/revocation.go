@@ -0,0 +1,192 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v1Sigs "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// revocationPageSize is the page size used to load the employee acknowledgements of a revoked CCLA signature
+const revocationPageSize = int64(10000)
+
+// SignatureRevokedEvent propagates the revocation of a signature - the affected contributors are removed from the
+// CLA Group Gerrit LDAP groups and the CLA status of their open pull/merge requests is re-evaluated. The contributors
+// covered by the approval lists of a revoked CCLA signature are re-evaluated too. A pending revocation is propagated
+// once its effective date is reached and the revocation is applied.
+func (s *service) SignatureRevokedEvent(event events.DynamoDBEventRecord) error {
+	ctx := newEventContext()
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.SignatureRevokedEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	// Decode the pre-update and post-update signature record details
+	var newSignature, oldSignature Signature
+	err := unmarshalStreamImage(event.Change.OldImage, &oldSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding pre-update signature, error: %+v", err)
+		return err
+	}
+	err = unmarshalStreamImage(event.Change.NewImage, &newSignature)
+	if err != nil {
+		log.WithFields(f).Warnf("problem decoding post-update signature, error: %+v", err)
+		return err
+	}
+
+	if newSignature.SignatureRevokedReason == "" || newSignature.SignatureRevocationPending {
+		return nil
+	}
+	if oldSignature.SignatureRevokedReason != "" && !oldSignature.SignatureRevocationPending {
+		return nil
+	}
+
+	f["id"] = newSignature.SignatureID
+	f["type"] = newSignature.SignatureType
+	f["referenceID"] = newSignature.SignatureReferenceID
+	f["projectID"] = newSignature.SignatureProjectID
+	f["revokedBy"] = newSignature.SignatureRevokedBy
+
+	affectedUsers := s.getRevocationAffectedUsers(ctx, newSignature)
+	log.WithFields(f).Debugf("revocation affects %d identified contributors", len(affectedUsers))
+	s.removeGerritGroupMembership(ctx, newSignature, affectedUsers)
+
+	// The contributors covered by the approval lists of the CCLA lose their coverage too - domain and organization
+	// entries may cover any contributor, so all the open pull/merge requests are re-evaluated
+	reevaluateAll := false
+	if newSignature.SignatureType == CCLASignatureType {
+		approvalListEntries := getApprovalListAdditions(Signature{}, newSignature)
+		if approvalListEntries.CoversAnyUser() {
+			reevaluateAll = true
+		} else {
+			affectedUsers = append(affectedUsers, approvalListEntries.Users()...)
+		}
+	}
+
+	if reevaluateAll {
+		affectedUsers = nil
+	} else if len(affectedUsers) == 0 {
+		log.WithFields(f).Debug("no contributors affected by the revocation")
+		return nil
+	}
+
+	if s.signatureService != nil {
+		log.WithFields(f).Debug("updating open GitHub pull requests of the affected contributors...")
+		if prErr := s.signatureService.UpdateOpenPullRequests(ctx, newSignature.SignatureProjectID, affectedUsers); prErr != nil {
+			log.WithFields(f).WithError(prErr).Warn("problem updating open GitHub pull requests")
+		}
+	}
+
+	if s.gitLabActivityService != nil {
+		log.WithFields(f).Debug("updating open GitLab merge requests of the affected contributors...")
+		if mrErr := s.gitLabActivityService.UpdateOpenMergeRequests(ctx, newSignature.SignatureProjectID, affectedUsers); mrErr != nil {
+			log.WithFields(f).WithError(mrErr).Warn("problem updating open GitLab merge requests")
+		}
+	}
+
+	return nil
+}
+
+// getRevocationAffectedUsers returns the signer of an individual signature or the employees acknowledged under a
+// corporate signature
+func (s *service) getRevocationAffectedUsers(ctx context.Context, signature Signature) []*models.User {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.getRevocationAffectedUsers",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"claGroupID":     signature.SignatureProjectID,
+	}
+
+	if s.usersService == nil {
+		log.WithFields(f).Debug("no users service configured - skipping")
+		return nil
+	}
+
+	var userIDs []string
+	if signature.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		employeeSignatures, err := s.signatureRepo.GetProjectCompanyEmployeeSignatures(ctx, v1Sigs.GetProjectCompanyEmployeeSignaturesParams{
+			CompanyID: signature.SignatureReferenceID,
+			ProjectID: signature.SignatureProjectID,
+			PageSize:  aws.Int64(revocationPageSize),
+		}, nil)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load the employee signatures of the company")
+			return nil
+		}
+		for _, employeeSignature := range employeeSignatures.Signatures {
+			userIDs = append(userIDs, employeeSignature.SignatureReferenceID)
+		}
+	} else {
+		userIDs = append(userIDs, signature.SignatureReferenceID)
+	}
+
+	var affectedUsers []*models.User
+	for _, userID := range userIDs {
+		userModel, err := s.usersService.GetUser(userID)
+		if err != nil || userModel == nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load user by ID: %s - skipping", userID)
+			continue
+		}
+		affectedUsers = append(affectedUsers, userModel)
+	}
+
+	return affectedUsers
+}
+
+// removeGerritGroupMembership removes the affected contributors from the ICLA or CCLA LDAP group of each CLA Group
+// Gerrit instance
+func (s *service) removeGerritGroupMembership(ctx context.Context, signature Signature, affectedUsers []*models.User) {
+	f := logrus.Fields{
+		"functionName":   "v2.dynamo_events.removeGerritGroupMembership",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    signature.SignatureID,
+		"claGroupID":     signature.SignatureProjectID,
+	}
+
+	if s.gerritService == nil || s.lfGroup == nil || len(affectedUsers) == 0 {
+		log.WithFields(f).Debug("gerrit LDAP group access not configured or no affected contributors - skipping")
+		return
+	}
+
+	gerritList, err := s.gerritService.GetClaGroupGerrits(ctx, signature.SignatureProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group gerrit instances")
+		return
+	}
+	if gerritList == nil || len(gerritList.List) == 0 {
+		return
+	}
+
+	// The LF group API only uses the user who revoked the signature for auditing
+	revokedBy := &auth.User{UserName: signature.SignatureRevokedBy}
+	for _, gerrit := range gerritList.List {
+		groupID := gerrit.GroupIDIcla
+		// Employee acknowledgements are individual signatures with the company of the employee
+		if signature.SignatureType == CCLASignatureType || signature.SignatureUserCompanyID != "" {
+			groupID = gerrit.GroupIDCcla
+		}
+		if groupID == "" {
+			continue
+		}
+
+		for _, affectedUser := range affectedUsers {
+			if affectedUser.LfUsername == "" {
+				log.WithFields(f).Debugf("user: %s has no LF username - unable to remove from gerrit group: %s", affectedUser.UserID, groupID)
+				continue
+			}
+			log.WithFields(f).Debugf("removing user: %s from gerrit: %s group: %s", affectedUser.LfUsername, gerrit.GerritName, groupID)
+			if removeErr := s.lfGroup.RemoveUserFromGroup(ctx, revokedBy, signature.SignatureProjectID, groupID, affectedUser.LfUsername); removeErr != nil {
+				log.WithFields(f).WithError(removeErr).Warnf("unable to remove user: %s from gerrit group: %s", affectedUser.LfUsername, groupID)
+			}
+		}
+	}
+}
@@ -0,0 +1,205 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package dynamo_events
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v1Sigs "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeRevocationSignatureRepo struct {
+	signatures.SignatureRepository
+	employeeSignatures []*models.Signature
+}
+
+func (r *fakeRevocationSignatureRepo) GetProjectCompanyEmployeeSignatures(ctx context.Context, params v1Sigs.GetProjectCompanyEmployeeSignaturesParams, criteria *signatures.ApprovalCriteria) (*models.Signatures, error) {
+	return &models.Signatures{Signatures: r.employeeSignatures}, nil
+}
+
+type fakeRevocationSignatureService struct {
+	signatures.SignatureService
+	calls         int
+	affectedUsers []*models.User
+}
+
+func (s *fakeRevocationSignatureService) UpdateOpenPullRequests(ctx context.Context, claGroupID string, affectedUsers []*models.User) error {
+	s.calls++
+	s.affectedUsers = affectedUsers
+	return nil
+}
+
+type fakeRevocationUsers struct {
+	users.Service
+	users map[string]*models.User
+}
+
+func (u *fakeRevocationUsers) GetUser(userID string) (*models.User, error) {
+	return u.users[userID], nil
+}
+
+// revocationEvent returns the stream record of the signature update, the new image adds the revocation reason
+func revocationEvent(oldImage, newImage map[string]events.DynamoDBAttributeValue) events.DynamoDBEventRecord {
+	return events.DynamoDBEventRecord{
+		EventName: Modify,
+		Change: events.DynamoDBStreamRecord{
+			OldImage: oldImage,
+			NewImage: newImage,
+		},
+	}
+}
+
+func signatureImage(signatureType, referenceType, referenceID, revokedReason string, extra map[string]events.DynamoDBAttributeValue) map[string]events.DynamoDBAttributeValue {
+	image := map[string]events.DynamoDBAttributeValue{
+		"signature_id":             events.NewStringAttribute("signature-123"),
+		"signature_project_id":     events.NewStringAttribute("cla-group-123"),
+		"signature_type":           events.NewStringAttribute(signatureType),
+		"signature_reference_type": events.NewStringAttribute(referenceType),
+		"signature_reference_id":   events.NewStringAttribute(referenceID),
+		"signature_signed":         events.NewBooleanAttribute(true),
+		"signature_approved":       events.NewBooleanAttribute(revokedReason == ""),
+	}
+	if revokedReason != "" {
+		image["signature_revoked_reason"] = events.NewStringAttribute(revokedReason)
+		image["signature_revoked_by"] = events.NewStringAttribute("cla_manager")
+	}
+	for key, value := range extra {
+		image[key] = value
+	}
+	return image
+}
+
+func stringListAttribute(values ...string) events.DynamoDBAttributeValue {
+	var list []events.DynamoDBAttributeValue
+	for _, value := range values {
+		list = append(list, events.NewStringAttribute(value))
+	}
+	return events.NewListAttribute(list)
+}
+
+func newRevocationTestService(employeeSignatures []*models.Signature) (*service, *fakeRevocationSignatureService) {
+	signatureService := &fakeRevocationSignatureService{}
+	return &service{
+		signatureRepo:    &fakeRevocationSignatureRepo{employeeSignatures: employeeSignatures},
+		signatureService: signatureService,
+		usersService: &fakeRevocationUsers{users: map[string]*models.User{
+			"user-alice": {UserID: "user-alice", GithubUsername: "alice"},
+			"user-bob":   {UserID: "user-bob", GithubUsername: "bob"},
+		}},
+	}, signatureService
+}
+
+func TestSignatureRevokedEventIgnoresOtherUpdates(t *testing.T) {
+	s, signatureService := newRevocationTestService(nil)
+
+	// not revoked
+	err := s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CLASignatureType, "user", "user-alice", "", nil),
+		signatureImage(CLASignatureType, "user", "user-alice", "", nil)))
+	assert.NoError(t, err)
+
+	// already revoked before the update
+	err = s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CLASignatureType, "user", "user-alice", "duplicate", nil),
+		signatureImage(CLASignatureType, "user", "user-alice", "duplicate", nil)))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, signatureService.calls)
+}
+
+func TestSignatureRevokedEventICLA(t *testing.T) {
+	s, signatureService := newRevocationTestService(nil)
+
+	err := s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CLASignatureType, "user", "user-alice", "", nil),
+		signatureImage(CLASignatureType, "user", "user-alice", "employment-ended", nil)))
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1, signatureService.calls)
+	if assert.Len(t, signatureService.affectedUsers, 1) {
+		assert.Equal(t, "alice", signatureService.affectedUsers[0].GithubUsername)
+	}
+}
+
+func TestSignatureRevokedEventPendingRevocation(t *testing.T) {
+	s, signatureService := newRevocationTestService(nil)
+	pending := map[string]events.DynamoDBAttributeValue{
+		"signature_approved":           events.NewBooleanAttribute(true),
+		"signature_revocation_pending": events.NewBooleanAttribute(true),
+	}
+	applied := map[string]events.DynamoDBAttributeValue{
+		"signature_revocation_pending": events.NewBooleanAttribute(false),
+	}
+
+	// the revocation is scheduled - the signature remains approved until the effective date
+	err := s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CLASignatureType, "user", "user-alice", "", nil),
+		signatureImage(CLASignatureType, "user", "user-alice", "employment-ended", pending)))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, signatureService.calls)
+
+	// the scheduled job applies the revocation
+	err = s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CLASignatureType, "user", "user-alice", "employment-ended", pending),
+		signatureImage(CLASignatureType, "user", "user-alice", "employment-ended", applied)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, signatureService.calls)
+}
+
+func TestSignatureRevokedEventUnknownUser(t *testing.T) {
+	s, signatureService := newRevocationTestService(nil)
+
+	// no identified contributor - the open pull requests of all the contributors must not be re-evaluated
+	err := s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CLASignatureType, "user", "user-unknown", "", nil),
+		signatureImage(CLASignatureType, "user", "user-unknown", "employment-ended", nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, signatureService.calls)
+}
+
+func TestSignatureRevokedEventCCLAApprovalLists(t *testing.T) {
+	employeeSignatures := []*models.Signature{{SignatureReferenceID: "user-bob"}}
+	approvalLists := map[string]events.DynamoDBAttributeValue{
+		"email_whitelist":  stringListAttribute("carol@example.org"),
+		"github_whitelist": stringListAttribute("dave"),
+	}
+
+	s, signatureService := newRevocationTestService(employeeSignatures)
+	err := s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CCLASignatureType, "company", "company-123", "", approvalLists),
+		signatureImage(CCLASignatureType, "company", "company-123", "company-request", approvalLists)))
+	assert.NoError(t, err)
+
+	// the acknowledged employee and the contributors covered by the approval lists
+	assert.Equal(t, 1, signatureService.calls)
+	if assert.Len(t, signatureService.affectedUsers, 3) {
+		assert.Equal(t, "bob", signatureService.affectedUsers[0].GithubUsername)
+		assert.Equal(t, []string{"carol@example.org"}, signatureService.affectedUsers[1].Emails)
+		assert.Equal(t, "dave", signatureService.affectedUsers[2].GithubUsername)
+	}
+
+	// domain entries may cover any contributor - all the open pull requests are re-evaluated
+	approvalLists["domain_whitelist"] = stringListAttribute("example.org")
+	s, signatureService = newRevocationTestService(employeeSignatures)
+	err = s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CCLASignatureType, "company", "company-123", "", approvalLists),
+		signatureImage(CCLASignatureType, "company", "company-123", "company-request", approvalLists)))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, signatureService.calls)
+	assert.Nil(t, signatureService.affectedUsers)
+
+	// a CCLA without employees or approval lists affects nobody
+	s, signatureService = newRevocationTestService(nil)
+	err = s.SignatureRevokedEvent(revocationEvent(
+		signatureImage(CCLASignatureType, "company", "company-123", "", nil),
+		signatureImage(CCLASignatureType, "company", "company-123", "company-request", nil)))
+	assert.NoError(t, err)
+	assert.Equal(t, 0, signatureService.calls)
+}
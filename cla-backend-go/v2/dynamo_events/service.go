@@ -4,6 +4,7 @@
 package dynamo_events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"reflect"
@@ -17,12 +18,15 @@ import (
 	v2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
 
 	gitlab_api "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	gitlab_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab-activity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/webhooks"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
 
+	"github.com/linuxfoundation/easycla/cla-backend-go/github"
 	"github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/approval_list"
@@ -35,6 +39,8 @@ import (
 	v2Company "github.com/linuxfoundation/easycla/cla-backend-go/v2/company"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 
 	"github.com/sirupsen/logrus"
 
@@ -71,10 +77,15 @@ type service struct {
 	githubOrgService         github_organizations.ServiceInterface
 	repositoryService        repositories.Service
 	gerritService            gerrits.Service
+	lfGroup                  *gerrits.LFGroup
+	usersService             users.Service
 	autoEnableService        *autoEnableServiceProvider
 	claManagerRequestsRepo   cla_manager.IRepository
 	approvalListRequestsRepo approval_list.IRepository
 	gitLabApp                *gitlab_api.App
+	signatureService         signatures.SignatureService
+	gitLabActivityService    gitlab_activity.Service
+	webhookService           webhooks.ServiceInterface
 }
 
 // Service implements DynamoDB stream event handler service
@@ -96,10 +107,15 @@ func NewService(stage string,
 	githubOrgService github_organizations.ServiceInterface,
 	repositoryService repositories.Service,
 	gerritService gerrits.Service,
+	lfGroup *gerrits.LFGroup,
+	usersService users.Service,
 	claManagerRequestsRepo cla_manager.IRepository,
 	approvalListRequestsRepo approval_list.IRepository,
 	gitLabApp *gitlab_api.App,
-	gitlabOrgService gitlab_organizations.ServiceInterface) Service {
+	gitlabOrgService gitlab_organizations.ServiceInterface,
+	signatureService signatures.SignatureService,
+	gitLabActivityService gitlab_activity.Service,
+	webhookService webhooks.ServiceInterface) Service {
 
 	signaturesTable := fmt.Sprintf("cla-%s-signatures", stage)
 	eventsTable := fmt.Sprintf("cla-%s-events", stage)
@@ -124,11 +140,16 @@ func NewService(stage string,
 		githubOrgService:         githubOrgService,
 		repositoryService:        repositoryService,
 		gerritService:            gerritService,
+		lfGroup:                  lfGroup,
+		usersService:             usersService,
 		autoEnableService:        &autoEnableServiceProvider{repositoryService: repositoryService},
 		claManagerRequestsRepo:   claManagerRequestsRepo,
 		approvalListRequestsRepo: approvalListRequestsRepo,
 		gitLabApp:                gitLabApp,
 		gitLabOrgService:         gitlabOrgService,
+		signatureService:         signatureService,
+		gitLabActivityService:    gitLabActivityService,
+		webhookService:           webhookService,
 	}
 
 	s.registerCallback(signaturesTable, Modify, s.SignatureSignedEvent)
@@ -138,6 +159,10 @@ func NewService(stage string,
 	s.registerCallback(signaturesTable, Insert, s.SignatureAddUsersDetails)
 	// Add or Remove any CLA Permissions
 	s.registerCallback(signaturesTable, Modify, s.UpdateCLAPermissions)
+	// Re-evaluate the open pull/merge requests of contributors added to the approval lists
+	s.registerCallback(signaturesTable, Modify, s.SignatureApprovalListUpdatedEvent)
+	// Remove the Gerrit group membership and re-evaluate the open pull/merge requests of revoked signatures
+	s.registerCallback(signaturesTable, Modify, s.SignatureRevokedEvent)
 
 	s.registerCallback(eventsTable, Insert, s.EventAddedEvent)
 
@@ -242,6 +267,12 @@ func (s *service) ProcessEvents(dynamoDBEvents events.DynamoDBEvent) {
 	}
 }
 
+// newEventContext returns the context of a stream event handler, the GitHub requests of the handlers wait for the
+// rate limit as the handlers run in the background
+func newEventContext() context.Context {
+	return github.WithRateLimitWait(utils.NewContext())
+}
+
 // UnmarshalStreamImage converts events.DynamoDBAttributeValue to struct
 func unmarshalStreamImage(attribute map[string]events.DynamoDBAttributeValue, out interface{}) error {
 	dbAttrMap := make(map[string]*dynamodb.AttributeValue)
@@ -55,6 +55,8 @@ type Signature struct {
 	DomainWhitelist               []string `json:"domain_whitelist"`
 	GitHubWhitelist               []string `json:"github_whitelist"`
 	GitHubOrgWhitelist            []string `json:"github_org_whitelist"`
+	GitlabUsernameApprovalList    []string `json:"gitlab_username_approval_list"`
+	GitlabOrgApprovalList         []string `json:"gitlab_org_approval_list"`
 	SignatureACL                  []string `json:"signature_acl"`
 	SigtypeSignedApprovedID       string   `json:"sigtype_signed_approved_id"`
 	UserGithubUsername            string   `json:"user_github_username"`
@@ -62,11 +64,14 @@ type Signature struct {
 	UserName                      string   `json:"user_name"`
 	UserEmail                     string   `json:"user_email"`
 	SignedOn                      string   `json:"signed_on"`
+	SignatureRevokedReason        string   `json:"signature_revoked_reason"`
+	SignatureRevokedBy            string   `json:"signature_revoked_by"`
+	SignatureRevocationPending    bool     `json:"signature_revocation_pending"`
 }
 
 // Assign Contributor role upon CCLA or CCLA/ICLA signing
 func (s *service) SignatureAssignContributorEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "SignatureAssignContributorEvent",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -111,7 +116,7 @@ func (s *service) SignatureAssignContributorEvent(event events.DynamoDBEventReco
 
 // should be called when we modify signature
 func (s *service) SignatureSignedEvent(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "SignatureSignedEvent",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -263,7 +268,7 @@ func (s *service) SignatureSignedEvent(event events.DynamoDBEventRecord) error {
 
 // SignatureAddSigTypeSignedApprovedID function should be called when new icla, ecla signature added
 func (s *service) SignatureAddSigTypeSignedApprovedID(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "SignatureAddSigTypeSignedApprovedID",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -309,7 +314,7 @@ func (s *service) SignatureAddSigTypeSignedApprovedID(event events.DynamoDBEvent
 }
 
 func (s *service) SignatureAddUsersDetails(event events.DynamoDBEventRecord) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":   "SignatureAddUsersDetails",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -449,7 +454,7 @@ func (s *service) assignContributor(ctx context.Context, newSignature Signature,
 
 // helper function that assigns acl permissions(v2 specific)
 func (s *service) updateCLAManagerPermissions(signature Signature, managers []string, action string) error {
-	ctx := utils.NewContext()
+	ctx := newEventContext()
 	f := logrus.Fields{
 		"functionName":  "updateCLAManagerPermissions",
 		"signatureID":   signature.SignatureID,
@@ -333,6 +333,99 @@ func Configure(api *operations.EasyclaAPI, service v1Events.Service, v1CompanyRe
 			}
 			return events.NewGetCompanyProjectEventsOK().WithPayload(resp)
 		})
+
+	api.EventsQueryEventsHandler = events.QueryEventsHandlerFunc(
+		func(params events.QueryEventsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "EventsQueryEventsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			query := &v1Events.EventQuery{
+				CompanyID:  utils.StringValue(params.CompanyID),
+				EventTypes: params.EventType,
+				Actor:      utils.StringValue(params.Actor),
+				After:      params.After,
+				Before:     params.Before,
+				SearchTerm: utils.StringValue(params.SearchTerm),
+				SortOrder:  utils.StringValue(params.SortOrder),
+				PageSize:   utils.Int64Value(params.PageSize),
+				NextKey:    params.NextKey,
+			}
+			scope := eventQueryScope{
+				foundationSFID: utils.StringValue(params.FoundationSFID),
+				projectSFID:    utils.StringValue(params.ProjectSFID),
+				claGroupID:     utils.StringValue(params.ClaGroupID),
+				companySFID:    utils.StringValue(params.CompanySFID),
+			}
+			if errResponder := resolveEventQueryScope(ctx, reqID, authUser, projectsClaGroupsRepo, scope, query); errResponder != nil {
+				return errResponder
+			}
+
+			result, err := service.QueryEvents(ctx, query)
+			if err != nil {
+				msg := "problem querying events"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return events.NewQueryEventsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			resp, err := v2EventList(result)
+			if err != nil {
+				msg := "problem converting events to a v2 object"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return events.NewQueryEventsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+			if resp.Events == nil {
+				resp.Events = []*models.Event{}
+			}
+
+			return events.NewQueryEventsOK().WithXRequestID(reqID).WithPayload(resp)
+		})
+
+	api.EventsCountEventsByTypeHandler = events.CountEventsByTypeHandlerFunc(
+		func(params events.CountEventsByTypeParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "EventsCountEventsByTypeHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			query := &v1Events.EventQuery{
+				CompanyID:  utils.StringValue(params.CompanyID),
+				EventTypes: params.EventType,
+				Actor:      utils.StringValue(params.Actor),
+				After:      params.After,
+				Before:     params.Before,
+				SearchTerm: utils.StringValue(params.SearchTerm),
+			}
+			scope := eventQueryScope{
+				foundationSFID: utils.StringValue(params.FoundationSFID),
+				projectSFID:    utils.StringValue(params.ProjectSFID),
+				claGroupID:     utils.StringValue(params.ClaGroupID),
+				companySFID:    utils.StringValue(params.CompanySFID),
+			}
+			if errResponder := resolveEventQueryScope(ctx, reqID, authUser, projectsClaGroupsRepo, scope, query); errResponder != nil {
+				return errResponder
+			}
+
+			result, err := service.CountEventsByType(ctx, query)
+			if err != nil {
+				msg := "problem counting events by event type"
+				log.WithFields(f).WithError(err).Warn(msg)
+				return events.NewCountEventsByTypeBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return events.NewCountEventsByTypeOK().WithXRequestID(reqID).WithPayload(v2EventTypeCounts(result))
+		})
 }
 
 // WriteResponse function writes http response.
@@ -0,0 +1,115 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	v1Events "github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// eventQueryScope contains the scope parameters of an event query
+type eventQueryScope struct {
+	foundationSFID string
+	projectSFID    string
+	claGroupID     string
+	companySFID    string
+}
+
+// resolveEventQueryScope sets the foundation or CLA Group scope of the query and checks that the user is authorized
+// for it - the user is authorized when it has access to the foundation or project tree of the scope, or to the
+// company within that tree when the query is restricted to a company. Returns an error responder, or nil if the query
+// is authorized.
+func resolveEventQueryScope(ctx context.Context, reqID string, authUser *auth.User, projectsClaGroupsRepo projects_cla_groups.Repository, scope eventQueryScope, query *v1Events.EventQuery) middleware.Responder {
+	f := logrus.Fields{
+		"functionName":   "v2.events.resolveEventQueryScope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"foundationSFID": scope.foundationSFID,
+		"projectSFID":    scope.projectSFID,
+		"claGroupID":     scope.claGroupID,
+		"companySFID":    scope.companySFID,
+	}
+
+	// The SFIDs of the project trees which grant access to the query scope
+	var scopeSFIDs []string
+	switch {
+	case scope.projectSFID != "":
+		pm, err := projectsClaGroupsRepo.GetClaGroupIDForProject(ctx, scope.projectSFID)
+		if err != nil {
+			if err == projects_cla_groups.ErrProjectNotAssociatedWithClaGroup {
+				msg := fmt.Sprintf("no cla group associated with this project: %s", scope.projectSFID)
+				log.WithFields(f).Warn(msg)
+				return WriteResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+			msg := fmt.Sprintf("unable to get CLA Group for project: %s", scope.projectSFID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return WriteResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		if scope.claGroupID != "" && scope.claGroupID != pm.ClaGroupID {
+			msg := fmt.Sprintf("project: %s is not associated with the CLA Group: %s", scope.projectSFID, scope.claGroupID)
+			log.WithFields(f).Warn(msg)
+			return WriteResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseBadRequest(reqID, msg))
+		}
+		query.CLAGroupID = pm.ClaGroupID
+		scopeSFIDs = append(scopeSFIDs, scope.projectSFID)
+	case scope.claGroupID != "":
+		projects, err := projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, scope.claGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the projects of the CLA Group: %s", scope.claGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return WriteResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		if len(projects) > 0 {
+			scopeSFIDs = append(scopeSFIDs, projects[0].FoundationSFID)
+		}
+		for _, project := range projects {
+			scopeSFIDs = append(scopeSFIDs, project.ProjectSFID)
+		}
+		query.CLAGroupID = scope.claGroupID
+	case scope.foundationSFID != "":
+		scopeSFIDs = append(scopeSFIDs, scope.foundationSFID)
+	default:
+		return WriteResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseBadRequestWithError(reqID, "missing event query scope", v1Events.ErrEventQueryScopeRequired))
+	}
+	query.FoundationSFID = scope.foundationSFID
+	query.CompanySFID = scope.companySFID
+
+	for _, scopeSFID := range scopeSFIDs {
+		if utils.IsUserAuthorizedForProjectTree(ctx, authUser, scopeSFID, utils.ALLOW_ADMIN_SCOPE) {
+			return nil
+		}
+		if scope.companySFID != "" && utils.IsUserAuthorizedForProjectOrganizationTree(ctx, authUser, scopeSFID, scope.companySFID, utils.ALLOW_ADMIN_SCOPE) {
+			return nil
+		}
+	}
+
+	msg := fmt.Sprintf("user %s does not have access to query the events of the requested scope", authUser.UserName)
+	log.WithFields(f).Warn(msg)
+	return WriteResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), utils.ErrorResponseForbidden(reqID, msg))
+}
+
+// v2EventTypeCounts converts the event type counts to the response model
+func v2EventTypeCounts(eventTypeCounts []*v1Events.EventTypeCount) *models.EventTypeCounts {
+	response := &models.EventTypeCounts{
+		Counts: make([]*models.EventTypeCount, 0, len(eventTypeCounts)),
+	}
+	for _, eventTypeCount := range eventTypeCounts {
+		response.Total += eventTypeCount.Count
+		response.Counts = append(response.Counts, &models.EventTypeCount{
+			EventType: eventTypeCount.EventType,
+			Count:     eventTypeCount.Count,
+		})
+	}
+	return response
+}
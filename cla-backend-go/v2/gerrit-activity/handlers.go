@@ -0,0 +1,66 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/gerrit_activity"
+	gerritApi "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+
+	api.GerritActivityGerritActivityHandler = gerrit_activity.GerritActivityHandlerFunc(func(params gerrit_activity.GerritActivityParams) middleware.Responder {
+		requestID, _ := uuid.NewV4()
+		reqID := requestID.String()
+		f := logrus.Fields{
+			"functionName":   "v2.gerrit-activity.handlers.GerritActivityGerritActivityHandler",
+			utils.XREQUESTID: reqID,
+			"gerritID":       params.GerritID,
+		}
+		log.WithFields(f).Debugf("handling gerrit activity callback")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+
+		// The webhooks plugin doesn't sign the payloads, the deliveries are authenticated with the token of the
+		// instance webhook URL
+		if err := gerritApi.ValidateWebhookToken(params.GerritID, params.Token, config.GetConfig().Gerrit.WebhookSecret); err != nil {
+			log.WithFields(f).Warnf("gerrit webhook token check failed : %v", err)
+			return gerrit_activity.NewGerritActivityUnauthorized().WithPayload(
+				utils.ErrorResponseUnauthorized(reqID, "token check failure"))
+		}
+
+		jsonData, err := params.GerritActivityInput.MarshalJSON()
+		if err != nil {
+			msg := fmt.Sprintf("unmarshall event data failed : %v", err)
+			log.WithFields(f).Debugf("%s", msg)
+			return gerrit_activity.NewGerritActivityOK()
+		}
+
+		// The webhooks plugin retries the deliveries answered with a server error, so only the failures of the Gerrit
+		// and EasyCLA calls are reported - the events which can't be checked are acknowledged
+		if err := service.ProcessChangeEvent(ctx, params.GerritID, jsonData); err != nil {
+			msg := fmt.Sprintf("processing gerrit change event failed : %v", err)
+			if errors.Is(err, ErrInvalidChangeEvent) || errors.Is(err, changeNotOpen) {
+				log.WithFields(f).Debugf("%s", msg)
+				return gerrit_activity.NewGerritActivityOK()
+			}
+			log.WithFields(f).Warnf("%s", msg)
+			return gerrit_activity.NewGerritActivityInternalServerError().WithPayload(
+				utils.ErrorResponseInternalServerError(reqID, msg))
+		}
+
+		return gerrit_activity.NewGerritActivityOK()
+	})
+}
@@ -0,0 +1,266 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	gerritApi "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	changeNotOpen = errors.New("change is not open")
+	// ErrInvalidChangeEvent is returned when the change event can't be checked, retrying the delivery doesn't help
+	ErrInvalidChangeEvent = errors.New("invalid gerrit change event")
+)
+
+const (
+	// recheckCommand is the review comment line re-running the check, once the contributor signed
+	recheckCommand = "/easycla"
+
+	missingCLAMsg = "EasyCLA: Missing CLA Authorization."
+	signedCLAMsg  = "EasyCLA check passed. The change owner and uploader are authorized to contribute."
+)
+
+// ProcessChangeActivityInput is used to pass the data needed to trigger a gerrit change check
+type ProcessChangeActivityInput struct {
+	GerritID string
+	// ChangeID is the project~number identifier of the change
+	ChangeID string
+}
+
+// Service contains the functions handling the Gerrit change activity of the gerrit_rest instances
+type Service interface {
+	ProcessChangeEvent(ctx context.Context, gerritID string, payload []byte) error
+	ProcessChangeActivity(ctx context.Context, input *ProcessChangeActivityInput) error
+}
+
+type service struct {
+	claCheckService cla_check.Service
+	gerritService   gerrits.Service
+}
+
+// NewService creates a new gerrit activity service
+func NewService(usersRepository users.UserRepository, signatureRepository signatures.SignatureRepository, companyRepository company.IRepository,
+	claGroupService projectService.Service, gerritService gerrits.Service) Service {
+	return &service{
+		claCheckService: cla_check.NewService(usersRepository, signatureRepository, companyRepository, claGroupService),
+		gerritService:   gerritService,
+	}
+}
+
+// ProcessChangeEvent handles the stream event delivered by the webhooks plugin of the Gerrit instance, comment events
+// only re-run the check when the comment asks for it
+func (s *service) ProcessChangeEvent(ctx context.Context, gerritID string, payload []byte) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gerrit-activity.service.ProcessChangeEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       gerritID,
+	}
+
+	event, err := gerritApi.ParseEvent(payload)
+	if err != nil {
+		return fmt.Errorf("%w : %v", ErrInvalidChangeEvent, err)
+	}
+	f["eventType"] = event.Type
+
+	switch event.Type {
+	case gerritApi.EventPatchsetCreated:
+	case gerritApi.EventCommentAdded:
+		if !isRecheckComment(event.Comment) {
+			log.WithFields(f).Debug("comment doesn't request a CLA check - ignoring")
+			return nil
+		}
+	default:
+		log.WithFields(f).Debugf("ignoring gerrit event: %s", event.Type)
+		return nil
+	}
+
+	// The event payload isn't trusted, the change is loaded again from the Gerrit instance
+	return s.ProcessChangeActivity(ctx, &ProcessChangeActivityInput{
+		GerritID: gerritID,
+		ChangeID: event.ChangeID(),
+	})
+}
+
+// ProcessChangeActivity checks the owner and the uploader of the change against the CLA Group signatures, keeps the
+// contributor group of the instance in sync, then reports the result as a label vote and a review message
+func (s *service) ProcessChangeActivity(ctx context.Context, input *ProcessChangeActivityInput) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gerrit-activity.service.ProcessChangeActivity",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"gerritID":       input.GerritID,
+		"changeID":       input.ChangeID,
+	}
+
+	gerrit, gerritClient, err := s.gerritService.NewGerritRESTClient(ctx, input.GerritID)
+	if err != nil {
+		return fmt.Errorf("loading gerrit instance: %s failed : %v", input.GerritID, err)
+	}
+	claGroupID := gerrit.ProjectID
+	f["claGroupID"] = claGroupID
+
+	change, err := gerritClient.GetChange(input.ChangeID)
+	if err != nil {
+		return err
+	}
+	if change.Status != gerritApi.ChangeStatusNew {
+		log.WithFields(f).Debugf("change status is %s", change.Status)
+		return changeNotOpen
+	}
+	f["currentRevision"] = change.CurrentRevision
+
+	accounts := changeAccounts(change)
+	if len(accounts) == 0 {
+		return fmt.Errorf("%w : no owner or uploader found for gerrit change : %s", ErrInvalidChangeEvent, input.ChangeID)
+	}
+
+	identities := make([]*cla_check.CommitIdentity, 0, len(accounts))
+	for _, account := range accounts {
+		identities = append(identities, toCommitIdentity(account))
+	}
+	evaluation, err := s.claCheckService.Evaluate(ctx, &cla_check.EvaluateInput{
+		CLAGroupID: claGroupID,
+		Authors:    identities,
+	})
+	if err != nil {
+		return fmt.Errorf("evaluating the accounts of gerrit change: %s failed : %v", input.ChangeID, err)
+	}
+
+	var missingUsers, signedUsers []*gerritApi.AccountInfo
+	for i, verdict := range evaluation.Verdicts {
+		account := accounts[i]
+		if !verdict.Covered {
+			log.WithFields(f).WithError(verdict.Err).Infof("gerrit account: %s has NOT signed - %s", getAccountInfo(account), verdict.Reason.Message())
+			missingUsers = append(missingUsers, account)
+			// a lookup failure doesn't revoke the membership
+			if verdict.Err == nil {
+				if groupErr := gerritClient.RemoveGroupMember(gerrit.ContributorGroupID, account.AccountID); groupErr != nil {
+					log.WithFields(f).WithError(groupErr).Warnf("problem removing account: %d from the contributor group", account.AccountID)
+				}
+			}
+			continue
+		}
+		log.WithFields(f).Infof("gerrit account: %s has signed - %s", getAccountInfo(account), verdict.Reason.Message())
+		signedUsers = append(signedUsers, account)
+		if groupErr := gerritClient.AddGroupMember(gerrit.ContributorGroupID, account.AccountID); groupErr != nil {
+			log.WithFields(f).WithError(groupErr).Warnf("problem adding account: %d to the contributor group", account.AccountID)
+		}
+	}
+
+	reviewLabel := gerrit.ReviewLabel
+	if reviewLabel == "" {
+		reviewLabel = gerrits.DefaultReviewLabel
+	}
+	vote := 1
+	if len(missingUsers) > 0 {
+		log.WithFields(f).Warnf("gerrit change failed with %d accounts not passing authorization", len(missingUsers))
+		vote = -1
+	}
+
+	review := &gerritApi.ReviewInput{
+		Message: PrepareReviewMessage(input.GerritID, missingUsers, signedUsers),
+		Labels:  map[string]int{reviewLabel: vote},
+		Tag:     gerritApi.ReviewTag,
+	}
+	if reviewErr := gerritClient.SetReview(input.ChangeID, change.CurrentRevision, review); reviewErr != nil {
+		log.WithFields(f).WithError(reviewErr).Warn("problem setting the review of the gerrit change")
+		return reviewErr
+	}
+
+	return nil
+}
+
+// PrepareReviewMessage renders the review message listing the accounts and their CLA status, the missing accounts
+// are given the links starting the signing flow
+func PrepareReviewMessage(gerritID string, missingUsers, signedUsers []*gerritApi.AccountInfo) string {
+	easyCLASupportURL := "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+
+	lines := []string{signedCLAMsg, ""}
+	if len(missingUsers) > 0 {
+		lines = []string{missingCLAMsg, ""}
+	}
+	for _, signed := range signedUsers {
+		lines = append(lines, fmt.Sprintf("* %s - authorized", getAccountInfo(signed)))
+	}
+	for _, missingUser := range missingUsers {
+		lines = append(lines, fmt.Sprintf("* %s - not authorized under a signed CLA", getAccountInfo(missingUser)))
+	}
+	if len(missingUsers) > 0 {
+		lines = append(lines, "",
+			fmt.Sprintf("To sign an individual CLA: %s", GetFullSignURL(gerritID, utils.ClaTypeICLA)),
+			fmt.Sprintf("To be authorized under a corporate CLA: %s", GetFullSignURL(gerritID, utils.ClaTypeCCLA)),
+			fmt.Sprintf("Once authorized, reply with %s to re-run the check. For further assistance with EasyCLA, please submit a support request ticket: %s", recheckCommand, easyCLASupportURL))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// GetFullSignURL returns the URL starting the signing flow of the Gerrit instance for the contract type
+func GetFullSignURL(gerritID string, claType string) string {
+	contractType := "individual"
+	if claType == utils.ClaTypeCCLA {
+		contractType = "corporate"
+	}
+	return fmt.Sprintf("%s/v2/gerrit/%s/%s/agreementUrl.html", config.GetConfig().ClaV1ApiURL, gerritID, contractType)
+}
+
+// changeAccounts returns the owner of the change and the uploader of the current patch set, once
+func changeAccounts(change *gerritApi.ChangeInfo) []*gerritApi.AccountInfo {
+	var accounts []*gerritApi.AccountInfo
+	for _, account := range []*gerritApi.AccountInfo{change.Owner, change.CurrentUploader()} {
+		if account == nil || account.AccountID == 0 {
+			continue
+		}
+		if len(accounts) > 0 && accounts[0].AccountID == account.AccountID {
+			continue
+		}
+		accounts = append(accounts, account)
+	}
+	return accounts
+}
+
+// toCommitIdentity maps the Gerrit account to the identity checked by the CLA engine, Gerrit accounts aren't linked to
+// EasyCLA users so the account is matched by email
+func toCommitIdentity(account *gerritApi.AccountInfo) *cla_check.CommitIdentity {
+	return &cla_check.CommitIdentity{
+		Provider:   utils.GerritLower,
+		Name:       account.Name,
+		Email:      account.Email,
+		ProviderID: strconv.FormatInt(account.AccountID, 10),
+		Login:      account.Username,
+	}
+}
+
+// isRecheckComment returns true if a line of the review comment is the recheck command
+func isRecheckComment(comment string) bool {
+	for _, line := range strings.Split(comment, "\n") {
+		if strings.EqualFold(strings.TrimSpace(line), recheckCommand) {
+			return true
+		}
+	}
+	return false
+}
+
+func getAccountInfo(account *gerritApi.AccountInfo) string {
+	if account.Username != "" {
+		return fmt.Sprintf("username:%s/name:%s", account.Username, account.Name)
+	} else if account.Email != "" {
+		return fmt.Sprintf("email:%s/name:%s", account.Email, account.Name)
+	}
+	return fmt.Sprintf("account:%d/name:%s", account.AccountID, account.Name)
+}
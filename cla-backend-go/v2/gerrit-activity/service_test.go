@@ -0,0 +1,116 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gerrit_activity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	gerritApi "github.com/linuxfoundation/easycla/cla-backend-go/gerrit_api"
+	gerritsMock "github.com/linuxfoundation/easycla/cla-backend-go/gerrits/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCLACheck struct {
+	cla_check.Service
+	covered map[string]bool
+}
+
+func (c *fakeCLACheck) Evaluate(ctx context.Context, input *cla_check.EvaluateInput) (*cla_check.Evaluation, error) {
+	evaluation := &cla_check.Evaluation{CLAGroupID: input.CLAGroupID}
+	for _, author := range input.Authors {
+		evaluation.Verdicts = append(evaluation.Verdicts, &cla_check.AuthorVerdict{Covered: c.covered[author.Email]})
+	}
+	return evaluation, nil
+}
+
+func TestIsRecheckComment(t *testing.T) {
+	assert.True(t, isRecheckComment("Patch Set 2:\n\n/easycla"))
+	assert.True(t, isRecheckComment("  /EasyCLA  "))
+	assert.False(t, isRecheckComment("Patch Set 2: Code-Review+1\n\nLGTM"))
+	assert.False(t, isRecheckComment("Once authorized, reply with /easycla to re-run the check."))
+}
+
+func TestChangeAccounts(t *testing.T) {
+	owner := &gerritApi.AccountInfo{AccountID: 1000, Username: "jane"}
+	change := &gerritApi.ChangeInfo{
+		Owner:           owner,
+		CurrentRevision: "abc",
+		Revisions: map[string]*gerritApi.RevisionInfo{
+			"abc": {Number: 2, Uploader: &gerritApi.AccountInfo{AccountID: 1000, Username: "jane"}},
+		},
+	}
+	assert.Equal(t, []*gerritApi.AccountInfo{owner}, changeAccounts(change))
+
+	uploader := &gerritApi.AccountInfo{AccountID: 1001, Username: "john"}
+	change.Revisions["abc"].Uploader = uploader
+	assert.Equal(t, []*gerritApi.AccountInfo{owner, uploader}, changeAccounts(change))
+}
+
+func TestProcessChangeEventIgnoresUnrelatedEvents(t *testing.T) {
+	s := &service{}
+	payload := []byte(`{"type":"comment-added","comment":"Patch Set 1: Code-Review+2","change":{"project":"tools","number":42}}`)
+	assert.NoError(t, s.ProcessChangeEvent(context.Background(), "gerrit-1", payload))
+
+	payload = []byte(`{"type":"change-merged","change":{"project":"tools","number":42}}`)
+	assert.NoError(t, s.ProcessChangeEvent(context.Background(), "gerrit-1", payload))
+
+	assert.ErrorIs(t, s.ProcessChangeEvent(context.Background(), "gerrit-1", []byte(`{"type":"patchset-created"}`)), ErrInvalidChangeEvent)
+}
+
+func TestProcessChangeActivity(t *testing.T) {
+	var requests []string
+	var review gerritApi.ReviewInput
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/a/changes/tools~42":
+			fmt.Fprint(w, ")]}'\n"+`{"project":"tools","_number":42,"status":"NEW",
+"owner":{"_account_id":1000,"email":"jane@example.org","username":"jane"},
+"current_revision":"abc","revisions":{"abc":{"_number":2,"uploader":{"_account_id":1001,"email":"john@example.org","username":"john"}}}}`)
+		case r.Method == http.MethodPost && r.URL.Path == "/a/changes/tools~42/revisions/abc/review":
+			body, _ := io.ReadAll(r.Body)
+			assert.NoError(t, json.Unmarshal(body, &review))
+			fmt.Fprint(w, ")]}'\n{}")
+		case r.Method == http.MethodPut || r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	gerritService := gerritsMock.NewMockService(ctrl)
+	gerritService.EXPECT().NewGerritRESTClient(gomock.Any(), "gerrit-1").Return(&models.Gerrit{
+		ProjectID:          "cla-group-1",
+		ReviewLabel:        "Verified",
+		ContributorGroupID: "group-uuid",
+	}, gerritApi.NewGerritClientFromPassword(server.URL, "easycla", "http-password"), nil)
+
+	s := &service{
+		claCheckService: &fakeCLACheck{covered: map[string]bool{"jane@example.org": true}},
+		gerritService:   gerritService,
+	}
+	err := s.ProcessChangeActivity(context.Background(), &ProcessChangeActivityInput{GerritID: "gerrit-1", ChangeID: "tools~42"})
+	assert.NoError(t, err)
+	assert.Contains(t, requests, "PUT /a/groups/group-uuid/members/1000")
+	assert.Contains(t, requests, "DELETE /a/groups/group-uuid/members/1001")
+	assert.Equal(t, -1, review.Labels["Verified"])
+	assert.Equal(t, gerritApi.ReviewTag, review.Tag)
+	assert.Contains(t, review.Message, "username:john")
+	assert.Contains(t, review.Message, GetFullSignURL("gerrit-1", utils.ClaTypeICLA))
+}
@@ -129,9 +129,14 @@ func Configure(api *operations.EasyclaAPI, v1Service v1Gerrits.Service, projectS
 
 			// add the gerrit
 			addGerritInput := &v1Models.AddGerritInput{
-				GerritName: params.AddGerritInput.GerritName,
-				GerritURL:  params.AddGerritInput.GerritURL,
-				Version:    "v2",
+				GerritName:           params.AddGerritInput.GerritName,
+				GerritURL:            params.AddGerritInput.GerritURL,
+				Version:              "v2",
+				Provider:             params.AddGerritInput.Provider,
+				RestUsername:         params.AddGerritInput.RestUsername,
+				RestPassword:         params.AddGerritInput.RestPassword,
+				ReviewLabel:          params.AddGerritInput.ReviewLabel,
+				ContributorGroupName: params.AddGerritInput.ContributorGroupName,
 			}
 			result, err := v1Service.AddGerrit(ctx, params.ClaGroupID, params.ProjectSFID, addGerritInput, projectModel)
 			if err != nil {
@@ -0,0 +1,78 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_activity
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/gitea_activity"
+	giteaApi "github.com/linuxfoundation/easycla/cla-backend-go/gitea_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// signatureCheckMiddleware is used to get access to raw http request so can do the
+// signature validation properly
+func signatureCheckMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "unable to read the request body", http.StatusBadRequest)
+			return
+		}
+		if err := giteaApi.ValidateSignature(payload, r.Header.Get(giteaApi.SignatureHeader), config.GetConfig().Gitea.WebhookSecret); err != nil {
+			log.Warnf("gitea webhook signature check failed : %v", err)
+			http.Error(w, "signature check failure", http.StatusUnauthorized)
+			return
+		}
+		defer r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewBuffer(payload))
+		// call the next middleware
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service Service) {
+
+	api.GiteaActivityGiteaActivityHandler = gitea_activity.GiteaActivityHandlerFunc(func(params gitea_activity.GiteaActivityParams) middleware.Responder {
+		requestID, _ := uuid.NewV4()
+		reqID := requestID.String()
+		f := logrus.Fields{
+			"functionName":   "v2.gitea-activity.handlers.GiteaActivityGiteaActivityHandler",
+			utils.XREQUESTID: reqID,
+			"eventType":      params.XGiteaEvent,
+		}
+		log.WithFields(f).Debugf("handling gitea activity callback")
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID)
+
+		// Gitea doesn't redeliver the failed events, so the event is acknowledged once the signature is verified and
+		// the processing failures are only logged - the CLA check runs again on the next pull request event
+
+		jsonData, err := params.GiteaActivityInput.MarshalJSON()
+		if err != nil {
+			msg := fmt.Sprintf("unmarshall event data failed : %v", err)
+			log.WithFields(f).Debugf("%s", msg)
+			return gitea_activity.NewGiteaActivityOK()
+		}
+
+		if err := service.ProcessPullRequestEvent(ctx, params.XGiteaEvent, jsonData); err != nil {
+			msg := fmt.Sprintf("processing gitea pull request event failed : %v", err)
+			log.WithFields(f).Debugf("%s", msg)
+			return gitea_activity.NewGiteaActivityOK()
+		}
+
+		return gitea_activity.NewGiteaActivityOK()
+	})
+	api.AddMiddlewareFor("POST", "/gitea/activity", signatureCheckMiddleware)
+}
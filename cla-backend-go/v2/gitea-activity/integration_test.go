@@ -0,0 +1,267 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+//go:build gitea_integration
+
+package gitea_activity
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	mock_company "github.com/linuxfoundation/easycla/cla-backend-go/company/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	giteaApi "github.com/linuxfoundation/easycla/cla-backend-go/gitea_api"
+	mock_project "github.com/linuxfoundation/easycla/cla-backend-go/project/mocks"
+	mock_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
+	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/users/mocks"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitea_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// The integration tests run against the local Gitea instance started by gitea_api/testdata/setup.sh, Gitea delivers
+// the webhooks to the test listener through GITEA_WEBHOOK_HOST (host.docker.internal by default):
+//
+//	make test-gitea
+
+const (
+	integrationCLAGroupID     = "cla-group-gitea"
+	integrationOrganizationID = "gitea-org-123"
+	integrationWebhookSecret  = "integration-secret"
+	signedContributorEmail    = "signed@example.org"
+	unsignedContributorEmail  = "unsigned@example.org"
+)
+
+type integrationGiteaOrgService struct {
+	gitea_organizations.ServiceInterface
+	giteaOrg    *gitea_organizations.GiteaOrganization
+	accessToken string
+}
+
+func (s *integrationGiteaOrgService) GetGiteaOrganizationByName(ctx context.Context, instanceURL, organizationName string) (*gitea_organizations.GiteaOrganization, error) {
+	if instanceURL != s.giteaOrg.InstanceURL || organizationName != s.giteaOrg.OrganizationName {
+		return nil, nil
+	}
+	return s.giteaOrg, nil
+}
+
+func (s *integrationGiteaOrgService) NewGiteaClient(giteaOrg *gitea_organizations.GiteaOrganization) (*giteaApi.Client, error) {
+	return giteaApi.NewGiteaClientFromAccessToken(giteaOrg.InstanceURL, s.accessToken), nil
+}
+
+type integrationRepositoryService struct {
+	repositories.ServiceInterface
+	repositoryName string
+}
+
+func (s *integrationRepositoryService) GiteaGetRepositoryByExternalID(ctx context.Context, instanceURL string, repositoryExternalID int64) (*v2Models.GiteaRepository, error) {
+	return &v2Models.GiteaRepository{
+		RepositoryExternalID: repositoryExternalID,
+		RepositoryName:       s.repositoryName,
+		RepositoryClaGroupID: integrationCLAGroupID,
+		Enabled:              true,
+	}, nil
+}
+
+// giteaRequest calls the Gitea API as the administrator, the fixtures use endpoints the EasyCLA client doesn't need
+func giteaRequest(t *testing.T, method, path string, body, result interface{}) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequest(method, os.Getenv("GITEA_URL")+"/api/v1"+path, reader)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "token "+os.Getenv("GITEA_TOKEN"))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Less(t, resp.StatusCode, 300, "%s %s failed with status: %d", method, path, resp.StatusCode)
+	if result != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(result))
+	}
+}
+
+// loadIntegrationConfig points the webhook signature check and the links of the comment at the test values
+func loadIntegrationConfig(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "env.json")
+	content, err := json.Marshal(map[string]interface{}{
+		"cla_landing_page": "https://easycla.example.org",
+		"cla_api_v4_base":  "https://api.easycla.example.org",
+		"gitea": map[string]string{
+			"app_web_hook_secret": integrationWebhookSecret,
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(configFile, content, 0600))
+	_, err = config.LoadConfig(configFile, nil, "")
+	require.NoError(t, err)
+}
+
+// startWebhookListener serves the Gitea activity callback behind the signature check, the listener binds every
+// interface so the Gitea container can reach it
+func startWebhookListener(t *testing.T, service Service) string {
+	listener, err := net.Listen("tcp", "0.0.0.0:0")
+	require.NoError(t, err)
+	server := httptest.NewUnstartedServer(signatureCheckMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if err := service.ProcessPullRequestEvent(context.Background(), r.Header.Get(giteaApi.EventHeader), payload); err != nil {
+			t.Logf("processing gitea event: %s failed : %v", r.Header.Get(giteaApi.EventHeader), err)
+		}
+		w.WriteHeader(http.StatusOK)
+	})))
+	server.Listener = listener
+	server.Start()
+	t.Cleanup(server.Close)
+
+	webhookHost := os.Getenv("GITEA_WEBHOOK_HOST")
+	if webhookHost == "" {
+		webhookHost = "host.docker.internal"
+	}
+	return fmt.Sprintf("http://%s:%d/v4/gitea/activity", webhookHost, listener.Addr().(*net.TCPAddr).Port)
+}
+
+// openPullRequest commits a file authored by the contributor on a new branch and opens the pull request
+func openPullRequest(t *testing.T, organizationName, repositoryName, branch, authorEmail string) *giteaApi.PullRequest {
+	giteaRequest(t, http.MethodPost, fmt.Sprintf("/repos/%s/%s/contents/%s.md", organizationName, repositoryName, branch), map[string]interface{}{
+		"content":    base64.StdEncoding.EncodeToString([]byte("contribution from " + authorEmail + "\n")),
+		"message":    "Add " + branch,
+		"branch":     "main",
+		"new_branch": branch,
+		"author": map[string]string{
+			"name":  "Contributor",
+			"email": authorEmail,
+		},
+	}, nil)
+
+	var pullRequest giteaApi.PullRequest
+	giteaRequest(t, http.MethodPost, fmt.Sprintf("/repos/%s/%s/pulls", organizationName, repositoryName), map[string]interface{}{
+		"title": "Add " + branch,
+		"head":  branch,
+		"base":  "main",
+	}, &pullRequest)
+	return &pullRequest
+}
+
+// waitForCommitStatus waits for the webhook delivery to complete the CLA check of the commit
+func waitForCommitStatus(t *testing.T, organizationName, repositoryName, sha string) string {
+	deadline := time.Now().Add(90 * time.Second)
+	for time.Now().Before(deadline) {
+		var statuses []struct {
+			State   string `json:"status"`
+			Context string `json:"context"`
+		}
+		giteaRequest(t, http.MethodGet, fmt.Sprintf("/repos/%s/%s/statuses/%s", organizationName, repositoryName, sha), nil, &statuses)
+		for _, status := range statuses {
+			if status.Context == giteaApi.CommitStatusContext {
+				return status.State
+			}
+		}
+		time.Sleep(2 * time.Second)
+	}
+	t.Fatalf("no commit status reported on %s/%s@%s", organizationName, repositoryName, sha)
+	return ""
+}
+
+func pullRequestComments(t *testing.T, organizationName, repositoryName string, index int64) []string {
+	var comments []*giteaApi.Comment
+	giteaRequest(t, http.MethodGet, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", organizationName, repositoryName, index), nil, &comments)
+	bodies := make([]string, 0, len(comments))
+	for _, comment := range comments {
+		bodies = append(bodies, comment.Body)
+	}
+	return bodies
+}
+
+func TestIntegrationWebhookCLACheck(t *testing.T) {
+	instanceURL, accessToken := os.Getenv("GITEA_URL"), os.Getenv("GITEA_TOKEN")
+	if instanceURL == "" || accessToken == "" {
+		t.Skip("GITEA_URL and GITEA_TOKEN are not set - run gitea_api/testdata/setup.sh first")
+	}
+	instanceURL = giteaApi.NormalizeInstanceURL(instanceURL)
+	loadIntegrationConfig(t)
+
+	suffix := strconv.FormatInt(time.Now().UnixNano(), 36)
+	organizationName := "easycla-" + suffix
+	repositoryName := "project-" + suffix
+	giteaRequest(t, http.MethodPost, "/orgs", map[string]interface{}{
+		"username":   organizationName,
+		"visibility": "public",
+	}, nil)
+	t.Cleanup(func() {
+		giteaRequest(t, http.MethodDelete, fmt.Sprintf("/repos/%s/%s", organizationName, repositoryName), nil, nil)
+		giteaRequest(t, http.MethodDelete, "/orgs/"+organizationName, nil, nil)
+	})
+	giteaRequest(t, http.MethodPost, fmt.Sprintf("/orgs/%s/repos", organizationName), map[string]interface{}{
+		"name":           repositoryName,
+		"auto_init":      true,
+		"default_branch": "main",
+	}, nil)
+
+	// The signed contributor has an ICLA, the unsigned contributor is unknown to EasyCLA
+	ctrl := gomock.NewController(t)
+	usersRepo := mock_users.NewMockUserRepository(ctrl)
+	signatureRepo := mock_signatures.NewMockSignatureRepository(ctrl)
+	companyRepo := mock_company.NewMockIRepository(ctrl)
+	claGroupService := mock_project.NewMockService(ctrl)
+	claGroupService.EXPECT().GetCLAGroupByID(gomock.Any(), integrationCLAGroupID).Return(&models.ClaGroup{ProjectID: integrationCLAGroupID}, nil).AnyTimes()
+	usersRepo.EXPECT().GetUsersByEmail(signedContributorEmail).Return([]*models.User{{UserID: "signed-user"}}, nil).AnyTimes()
+	usersRepo.EXPECT().GetUsersByEmail(unsignedContributorEmail).Return(nil, nil).AnyTimes()
+	signatureRepo.EXPECT().GetIndividualSignature(gomock.Any(), integrationCLAGroupID, "signed-user", gomock.Any(), gomock.Any()).
+		Return(&models.Signature{SignatureID: "icla-123"}, nil).AnyTimes()
+
+	service := NewService(usersRepo, signatureRepo, companyRepo, claGroupService,
+		&integrationGiteaOrgService{
+			giteaOrg: &gitea_organizations.GiteaOrganization{
+				OrganizationID:   integrationOrganizationID,
+				InstanceURL:      instanceURL,
+				OrganizationName: organizationName,
+				ClaGroupID:       integrationCLAGroupID,
+				Enabled:          true,
+				AuthInfo:         "connected",
+			},
+			accessToken: accessToken,
+		},
+		&integrationRepositoryService{repositoryName: organizationName + "/" + repositoryName})
+
+	giteaClient := giteaApi.NewGiteaClientFromAccessToken(instanceURL, accessToken)
+	require.NoError(t, giteaClient.SetWebHook(organizationName, repositoryName, startWebhookListener(t, service), integrationWebhookSecret))
+
+	signed := openPullRequest(t, organizationName, repositoryName, "signed", signedContributorEmail)
+	unsigned := openPullRequest(t, organizationName, repositoryName, "unsigned", unsignedContributorEmail)
+
+	assert.Equal(t, giteaApi.CommitStatusSuccess, waitForCommitStatus(t, organizationName, repositoryName, signed.HeadSha()))
+	assert.Equal(t, giteaApi.CommitStatusFailure, waitForCommitStatus(t, organizationName, repositoryName, unsigned.HeadSha()))
+
+	signedComments := pullRequestComments(t, organizationName, repositoryName, signed.Number)
+	require.Len(t, signedComments, 1)
+	assert.Contains(t, signedComments[0], "cla-signed.svg")
+	unsignedComments := pullRequestComments(t, organizationName, repositoryName, unsigned.Number)
+	require.Len(t, unsignedComments, 1)
+	assert.Contains(t, unsignedComments[0], "cla-not-signed.svg")
+	assert.True(t, strings.Contains(unsignedComments[0], "/v4/repository-provider/gitea/sign/"+integrationOrganizationID+"/"))
+}
@@ -0,0 +1,323 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	giteaApi "github.com/linuxfoundation/easycla/cla-backend-go/gitea_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitea_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	pullRequestNotOpen = errors.New("pull request is not open")
+)
+
+const (
+	svgVersion = "?v=2"
+
+	missingCLAMsg = "Missing CLA Authorization"
+	signedCLAMsg  = "EasyCLA check passed. You are authorized to contribute."
+)
+
+// ProcessPullRequestActivityInput is used to pass the data needed to trigger a gitea pull request check
+type ProcessPullRequestActivityInput struct {
+	// OrganizationID is optional, the organization is resolved from the instance URL and the organization name when empty
+	OrganizationID   string
+	InstanceURL      string
+	OrganizationName string
+	RepositoryID     int64
+	PullRequestID    int64
+}
+
+type gatedGiteaAuthor struct {
+	*giteaApi.PullRequestAuthor
+	err error
+}
+
+// Service contains the functions handling the Gitea pull request activity
+type Service interface {
+	ProcessPullRequestEvent(ctx context.Context, eventType string, payload []byte) error
+	ProcessPullRequestActivity(ctx context.Context, input *ProcessPullRequestActivityInput) error
+}
+
+type service struct {
+	claCheckService  cla_check.Service
+	giteaOrgService  gitea_organizations.ServiceInterface
+	v2GitRepoService repositories.ServiceInterface
+}
+
+// NewService creates a new gitea activity service
+func NewService(usersRepository users.UserRepository, signatureRepository signatures.SignatureRepository, companyRepository company.IRepository,
+	claGroupService projectService.Service, giteaOrgService gitea_organizations.ServiceInterface, v2GitRepoService repositories.ServiceInterface) Service {
+	return &service{
+		claCheckService:  cla_check.NewService(usersRepository, signatureRepository, companyRepository, claGroupService),
+		giteaOrgService:  giteaOrgService,
+		v2GitRepoService: v2GitRepoService,
+	}
+}
+
+// ProcessPullRequestEvent handles the Gitea webhook payload, comment events only re-run the check when the comment
+// asks for it
+func (s *service) ProcessPullRequestEvent(ctx context.Context, eventType string, payload []byte) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea-activity.service.ProcessPullRequestEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventType":      eventType,
+	}
+
+	switch eventType {
+	case giteaApi.EventPullRequest:
+		event, err := giteaApi.ParsePullRequestEvent(payload)
+		if err != nil {
+			return err
+		}
+		if !giteaApi.IsCheckedPullRequestAction(event.Action) {
+			log.WithFields(f).Debugf("ignoring pull request action: %s", event.Action)
+			return nil
+		}
+		return s.ProcessPullRequestActivity(ctx, &ProcessPullRequestActivityInput{
+			InstanceURL:      event.Repository.InstanceURL(),
+			OrganizationName: event.Repository.OwnerLogin(),
+			RepositoryID:     event.Repository.ID,
+			PullRequestID:    event.PullRequest.Number,
+		})
+	case giteaApi.EventIssueComment:
+		event, err := giteaApi.ParseIssueCommentEvent(payload)
+		if err != nil {
+			return err
+		}
+		if !event.IsPull || event.Action != giteaApi.CommentActionCreated || !strings.Contains(event.Comment.Body, "/easycla") {
+			log.WithFields(f).Debug("comment doesn't request a CLA check - ignoring")
+			return nil
+		}
+		return s.ProcessPullRequestActivity(ctx, &ProcessPullRequestActivityInput{
+			InstanceURL:      event.Repository.InstanceURL(),
+			OrganizationName: event.Repository.OwnerLogin(),
+			RepositoryID:     event.Repository.ID,
+			PullRequestID:    event.Issue.Number,
+		})
+	default:
+		log.WithFields(f).Debugf("ignoring gitea event: %s", eventType)
+		return nil
+	}
+}
+
+// ProcessPullRequestActivity checks the commit authors of the pull request against the CLA Group signatures, then
+// reports the result as a commit status and a pull request comment
+func (s *service) ProcessPullRequestActivity(ctx context.Context, input *ProcessPullRequestActivityInput) error {
+	f := logrus.Fields{
+		"functionName":     "v2.gitea-activity.service.ProcessPullRequestActivity",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationID":   input.OrganizationID,
+		"instanceURL":      input.InstanceURL,
+		"organizationName": input.OrganizationName,
+		"repositoryID":     input.RepositoryID,
+		"pullRequestID":    input.PullRequestID,
+	}
+
+	giteaOrg, err := s.getOrganization(ctx, input)
+	if err != nil {
+		return err
+	}
+
+	giteaRepo, err := s.v2GitRepoService.GiteaGetRepositoryByExternalID(ctx, giteaOrg.InstanceURL, input.RepositoryID)
+	if err != nil {
+		return fmt.Errorf("loading gitea repository: %d failed : %v", input.RepositoryID, err)
+	}
+	if giteaRepo == nil || !giteaRepo.Enabled {
+		log.WithFields(f).Debug("repository is not enrolled in EasyCLA - ignoring")
+		return nil
+	}
+	claGroupID := giteaRepo.RepositoryClaGroupID
+	if claGroupID == "" {
+		claGroupID = giteaOrg.ClaGroupID
+	}
+	f["claGroupID"] = claGroupID
+
+	giteaClient, err := s.giteaOrgService.NewGiteaClient(giteaOrg)
+	if err != nil {
+		return fmt.Errorf("initializing gitea client : %v", err)
+	}
+
+	owner, repositoryName := giteaOrg.OrganizationName, strings.TrimPrefix(giteaRepo.RepositoryName, giteaOrg.OrganizationName+"/")
+	pullRequest, err := giteaClient.GetPullRequest(owner, repositoryName, input.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("fetching info for pull request : %d failed : %v", input.PullRequestID, err)
+	}
+	if pullRequest.State != giteaApi.PullRequestStateOpen {
+		log.WithFields(f).Debugf("pull request state is %s", pullRequest.State)
+		return pullRequestNotOpen
+	}
+	lastCommitSha := pullRequest.HeadSha()
+	f["lastCommitSha"] = lastCommitSha
+
+	authors, err := giteaClient.GetPullRequestAuthors(owner, repositoryName, input.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("problem loading commit authors for pull request: %d - error: %+v", input.PullRequestID, err)
+	}
+	if len(authors) == 0 {
+		return fmt.Errorf("no commit authors found in gitea pull request : %d", input.PullRequestID)
+	}
+
+	log.WithFields(f).Debugf("found %d commit authors for the pull request", len(authors))
+	identities := make([]*cla_check.CommitIdentity, 0, len(authors))
+	for _, author := range authors {
+		identities = append(identities, toCommitIdentity(author))
+	}
+	evaluation, err := s.claCheckService.Evaluate(ctx, &cla_check.EvaluateInput{
+		CLAGroupID: claGroupID,
+		Authors:    identities,
+	})
+	if err != nil {
+		return fmt.Errorf("evaluating the commit authors of pull request: %d failed : %v", input.PullRequestID, err)
+	}
+
+	var missingUsers []*gatedGiteaAuthor
+	var signedUsers []*giteaApi.PullRequestAuthor
+	for i, verdict := range evaluation.Verdicts {
+		author := authors[i]
+		if !verdict.Covered {
+			log.WithFields(f).WithError(verdict.Err).Infof("gitea commit author: %s <%s> has NOT signed - %s", author.Name, author.Email, verdict.Reason.Message())
+			missingUsers = append(missingUsers, &gatedGiteaAuthor{PullRequestAuthor: author, err: verdict.Err})
+			continue
+		}
+		log.WithFields(f).Infof("gitea commit author: %s <%s> has signed - %s", author.Name, author.Email, verdict.Reason.Message())
+		signedUsers = append(signedUsers, author)
+	}
+
+	signURL := GetFullSignURL(giteaOrg.OrganizationID, strconv.FormatInt(input.RepositoryID, 10), strconv.FormatInt(input.PullRequestID, 10))
+	commentContent := PreparePullRequestCommentContent(missingUsers, signedUsers, signURL)
+
+	state, description, targetURL := giteaApi.CommitStatusSuccess, signedCLAMsg, config.GetConfig().CLALandingPage+"/#/?version=2"
+	if len(missingUsers) > 0 {
+		log.WithFields(f).Warnf("pull request failed with %d commit authors not passing authorization", len(missingUsers))
+		state, description, targetURL = giteaApi.CommitStatusFailure, missingCLAMsg, signURL
+	}
+
+	if statusErr := giteaClient.SetCommitStatus(owner, repositoryName, lastCommitSha, state, description, targetURL); statusErr != nil {
+		log.WithFields(f).WithError(statusErr).Warnf("problem setting the commit status for pull request ID: %d, sha: %s", input.PullRequestID, lastCommitSha)
+		return fmt.Errorf("setting commit status failed : %v", statusErr)
+	}
+
+	if commentErr := giteaClient.SetPullRequestComment(owner, repositoryName, input.PullRequestID, commentContent); commentErr != nil {
+		log.WithFields(f).WithError(commentErr).Warnf("problem setting the pull request comment for pull request ID: %d", input.PullRequestID)
+		return fmt.Errorf("setting comment failed : %v", commentErr)
+	}
+
+	return nil
+}
+
+// getOrganization resolves the onboarded Gitea organization of the pull request
+func (s *service) getOrganization(ctx context.Context, input *ProcessPullRequestActivityInput) (*gitea_organizations.GiteaOrganization, error) {
+	var giteaOrg *gitea_organizations.GiteaOrganization
+	var err error
+	if input.OrganizationID != "" {
+		giteaOrg, err = s.giteaOrgService.GetGiteaOrganizationByID(ctx, input.OrganizationID)
+	} else {
+		giteaOrg, err = s.giteaOrgService.GetGiteaOrganizationByName(ctx, input.InstanceURL, input.OrganizationName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if giteaOrg == nil || !giteaOrg.Enabled || !giteaOrg.IsConnected() {
+		return nil, fmt.Errorf("gitea organization: %s of instance: %s is not onboarded", input.OrganizationName, input.InstanceURL)
+	}
+	return giteaOrg, nil
+}
+
+// PreparePullRequestCommentContent renders the markdown comment listing the commit authors and their CLA status
+func PreparePullRequestCommentContent(missingUsers []*gatedGiteaAuthor, signedUsers []*giteaApi.PullRequestAuthor, signURL string) string {
+	landingPage := config.GetConfig().CLALandingPage
+	landingPage += "/#/?version=2"
+
+	badgeHyperlink := landingPage
+	if len(missingUsers) > 0 {
+		badgeHyperlink = signURL
+	}
+
+	coveredBadge := fmt.Sprintf("[![CLA Signed](https://s3.amazonaws.com/cla-project-logo-dev/cla-signed.svg%s)](%s)", svgVersion, badgeHyperlink)
+	failedBadge := fmt.Sprintf("[![CLA Not Signed](https://s3.amazonaws.com/cla-project-logo-dev/cla-not-signed.svg%s)](%s)", svgVersion, badgeHyperlink)
+	easyCLASupportURL := "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
+
+	body := coveredBadge
+	var lines []string
+	for _, signed := range signedUsers {
+		lines = append(lines, fmt.Sprintf("* :white_check_mark: %s", getAuthorInfo(signed)))
+	}
+	if len(missingUsers) > 0 {
+		body = failedBadge
+		for _, missingUser := range missingUsers {
+			lines = append(lines, fmt.Sprintf("* :x: %s - the commit (%s) is not authorized under a signed CLA. [Please click here to be authorized](%s). For further assistance with EasyCLA, [please submit a support request ticket](%s).",
+				getAuthorInfo(missingUser.PullRequestAuthor), strings.Join(shortSHAs(missingUser.SHAs), ", "), signURL, easyCLASupportURL))
+		}
+	}
+
+	if len(lines) > 0 {
+		body += "\n\n" + strings.Join(lines, "\n")
+	}
+	return body
+}
+
+// GetFullSignURL returns the URL starting the signing flow of the Gitea pull request
+func GetFullSignURL(giteaOrganizationID string, giteaRepositoryID string, pullRequestID string) string {
+	return fmt.Sprintf("%s/v4/repository-provider/%s/sign/%s/%s/%s/#/",
+		config.GetConfig().ClaAPIV4Base,
+		utils.GiteaLower,
+		giteaOrganizationID,
+		giteaRepositoryID,
+		pullRequestID,
+	)
+}
+
+// toCommitIdentity maps the commit author to the identity checked by the CLA engine, Gitea accounts aren't linked to
+// EasyCLA users so the author is matched by email
+func toCommitIdentity(author *giteaApi.PullRequestAuthor) *cla_check.CommitIdentity {
+	identity := &cla_check.CommitIdentity{
+		Provider: utils.GiteaLower,
+		Name:     author.Name,
+		Email:    author.Email,
+	}
+	if author.User != nil {
+		identity.Login = author.User.Login
+		if identity.Email == "" {
+			identity.Email = author.User.Email
+		}
+	}
+	return identity
+}
+
+func getAuthorInfo(author *giteaApi.PullRequestAuthor) string {
+	if author.User != nil && author.User.Login != "" {
+		return fmt.Sprintf("login:@%s/name:%s", author.User.Login, author.Name)
+	} else if author.Email != "" {
+		return fmt.Sprintf("email:%s/name:%s", author.Email, author.Name)
+	}
+	return fmt.Sprintf("name:%s", author.Name)
+}
+
+func shortSHAs(shas []string) []string {
+	out := make([]string, 0, len(shas))
+	for _, sha := range shas {
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		out = append(out, sha)
+	}
+	return out
+}
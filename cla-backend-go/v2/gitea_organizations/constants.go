@@ -0,0 +1,25 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_organizations
+
+const (
+	// GiteaOrganizationsOrganizationIDColumn constant
+	GiteaOrganizationsOrganizationIDColumn = "organization_id"
+	// GiteaOrganizationsProjectSFIDColumn constant
+	GiteaOrganizationsProjectSFIDColumn = "project_sfid"
+	// GiteaOrganizationsInstanceURLColumn constant
+	GiteaOrganizationsInstanceURLColumn = "instance_url"
+	// GiteaOrganizationsOrganizationNameColumn constant
+	GiteaOrganizationsOrganizationNameColumn = "organization_name"
+	// GiteaOrganizationsEnabledColumn constant
+	GiteaOrganizationsEnabledColumn = "enabled"
+	// GiteaOrganizationsAuthInfoColumn constant
+	GiteaOrganizationsAuthInfoColumn = "auth_info"
+	// GiteaOrganizationsOAuthClientSecretColumn constant
+	GiteaOrganizationsOAuthClientSecretColumn = "oauth_client_secret"
+	// GiteaOrganizationsNoteColumn constant
+	GiteaOrganizationsNoteColumn = "note"
+	// GiteaOrganizationsDateModifiedColumn constant
+	GiteaOrganizationsDateModifiedColumn = "date_modified"
+)
@@ -0,0 +1,519 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/gitea_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/gitea_sign"
+	giteaApi "github.com/linuxfoundation/easycla/cla-backend-go/gitea_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
+	"github.com/savaki/dynastore"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// SessionStoreKey for cla-gitea
+	SessionStoreKey = "cla-gitea"
+)
+
+// Configure setups handlers on api with service
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventService events.Service, sessionStore *dynastore.Store, contributorConsoleV2Base string) {
+
+	api.GiteaOrganizationsGetProjectGiteaOrganizationsHandler = gitea_organizations.GetProjectGiteaOrganizationsHandlerFunc(
+		func(params gitea_organizations.GetProjectGiteaOrganizationsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaOrganizationsGetProjectGiteaOrganizationsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			// Load the project
+			psc := projectService.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return gitea_organizations.NewGetProjectGiteaOrganizationsNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project Gitea Organizations for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitea_organizations.NewGetProjectGiteaOrganizationsForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGiteaOrganizationsByProjectSFID(ctx, params.ProjectSFID)
+			if err != nil {
+				msg := fmt.Sprintf("failed to locate Gitea organizations by project SFID: %s, error: %+v", params.ProjectSFID, err)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitea_organizations.NewGetProjectGiteaOrganizationsBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitea_organizations.NewGetProjectGiteaOrganizationsOK().WithPayload(result)
+		})
+
+	api.GiteaOrganizationsAddProjectGiteaOrganizationHandler = gitea_organizations.AddProjectGiteaOrganizationHandlerFunc(
+		func(params gitea_organizations.AddProjectGiteaOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaOrganizationsAddProjectGiteaOrganizationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+			}
+
+			// Quick check of the parameters
+			if params.Body == nil || utils.StringValue(params.Body.InstanceURL) == "" || utils.StringValue(params.Body.OrganizationName) == "" {
+				msg := "missing Gitea instance URL or organization name in the body"
+				log.WithFields(f).Warn(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			if utils.StringValue(params.Body.AccessToken) == "" {
+				msg := "missing Gitea access token in the body"
+				log.WithFields(f).Warn(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			if utils.StringValue(params.Body.OauthClientID) == "" || utils.StringValue(params.Body.OauthClientSecret) == "" {
+				msg := "missing Gitea OAuth2 application client ID or secret in the body"
+				log.WithFields(f).Warn(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			if utils.StringValue(params.Body.ClaGroupID) == "" {
+				msg := "missing CLA Group ID in the body"
+				log.WithFields(f).Warn(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			instanceURL := giteaApi.NormalizeInstanceURL(*params.Body.InstanceURL)
+			if !strings.HasPrefix(instanceURL, "https://") && !strings.HasPrefix(instanceURL, "http://") {
+				msg := fmt.Sprintf("invalid Gitea instance URL: %s", instanceURL)
+				log.WithFields(f).Warn(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+			f["instanceURL"] = instanceURL
+			f["organizationName"] = *params.Body.OrganizationName
+			f["claGroupID"] = *params.Body.ClaGroupID
+
+			// Load the project
+			psc := projectService.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return gitea_organizations.NewAddProjectGiteaOrganizationNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			// Load the project parent
+			parentProjectModel, err := psc.GetParentProjectModel(params.ProjectSFID)
+			if err != nil || (parentProjectModel == nil && !utils.IsProjectHasRootParent(projectModel)) {
+				return gitea_organizations.NewAddProjectGiteaOrganizationNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate parent project from project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Add Project Gitea Organizations for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			// If the parent is TLF, then use the same project SFID value for the parent SFID value
+			parentProjectSFID := ""
+			if parentProjectModel != nil {
+				parentProjectSFID = parentProjectModel.ID
+			}
+			if utils.IsProjectHasRootParent(projectModel) {
+				parentProjectSFID = params.ProjectSFID
+			}
+
+			inputModel := &GiteaAddOrganization{
+				InstanceURL:       instanceURL,
+				OrganizationName:  strings.TrimSpace(*params.Body.OrganizationName),
+				AccessToken:       *params.Body.AccessToken,
+				OAuthClientID:     *params.Body.OauthClientID,
+				OAuthClientSecret: *params.Body.OauthClientSecret,
+				ProjectSFID:       params.ProjectSFID,
+				ParentProjectSFID: parentProjectSFID,
+				ClaGroupID:        *params.Body.ClaGroupID,
+				AutoEnabled:       utils.BoolValue(params.Body.AutoEnabled),
+			}
+
+			result, err := service.AddGiteaOrganization(ctx, inputModel)
+			if err != nil {
+				if _, ok := err.(*utils.ProjectConflict); ok {
+					return gitea_organizations.NewAddProjectGiteaOrganizationConflict().WithPayload(
+						utils.ErrorResponseConflict(reqID, err.Error()))
+				}
+				msg := fmt.Sprintf("unable to add Gitea organization, error: %+v", err)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitea_organizations.NewAddProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			// Log the event
+			autoEnabledCLAGroupID := ""
+			if inputModel.AutoEnabled {
+				autoEnabledCLAGroupID = inputModel.ClaGroupID
+			}
+			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+				LfUsername:  authUser.UserName,
+				EventType:   events.GiteaOrganizationAdded,
+				ProjectSFID: params.ProjectSFID,
+				CLAGroupID:  inputModel.ClaGroupID,
+				EventData: &events.GiteaOrganizationAddedEventData{
+					GiteaInstanceURL:      inputModel.InstanceURL,
+					GiteaOrganizationName: inputModel.OrganizationName,
+					AutoEnabled:           inputModel.AutoEnabled,
+					AutoEnabledClaGroupID: autoEnabledCLAGroupID,
+				},
+			})
+
+			return gitea_organizations.NewAddProjectGiteaOrganizationOK().WithPayload(result)
+		})
+
+	api.GiteaOrganizationsDeleteProjectGiteaOrganizationHandler = gitea_organizations.DeleteProjectGiteaOrganizationHandlerFunc(
+		func(params gitea_organizations.DeleteProjectGiteaOrganizationParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaOrganizationsDeleteProjectGiteaOrganizationHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"organizationID": params.OrganizationID,
+			}
+
+			// Load the project
+			psc := projectService.GetClient()
+			projectModel, err := psc.GetProject(params.ProjectSFID)
+			if err != nil || projectModel == nil {
+				return gitea_organizations.NewDeleteProjectGiteaOrganizationNotFound().WithPayload(
+					utils.ErrorResponseNotFound(reqID, fmt.Sprintf("unable to locate project with ID: %s", params.ProjectSFID)))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Delete Project Gitea Organizations for Project '%s' with scope of %s",
+					authUser.UserName, projectModel.Name, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitea_organizations.NewDeleteProjectGiteaOrganizationForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			giteaOrg, err := service.GetGiteaOrganizationByID(ctx, params.OrganizationID)
+			if err != nil {
+				msg := fmt.Sprintf("problem loading Gitea organization: %s", params.OrganizationID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitea_organizations.NewDeleteProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			err = service.DeleteGiteaOrganization(ctx, params.ProjectSFID, params.OrganizationID)
+			if err != nil {
+				if errors.Is(err, ErrGiteaOrganizationNotFound) {
+					msg := fmt.Sprintf("Gitea organization %s not found for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+					log.WithFields(f).Debug(msg)
+					return gitea_organizations.NewDeleteProjectGiteaOrganizationNotFound().WithPayload(
+						utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem deleting Gitea organization: %s for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitea_organizations.NewDeleteProjectGiteaOrganizationBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+				LfUsername:  authUser.UserName,
+				EventType:   events.GiteaOrganizationDeleted,
+				ProjectSFID: params.ProjectSFID,
+				CLAGroupID:  giteaOrg.ClaGroupID,
+				EventData: &events.GiteaOrganizationDeletedEventData{
+					GiteaInstanceURL:      giteaOrg.InstanceURL,
+					GiteaOrganizationName: giteaOrg.OrganizationName,
+				},
+			})
+
+			return gitea_organizations.NewDeleteProjectGiteaOrganizationNoContent()
+		})
+
+	api.GiteaOrganizationsGetProjectGiteaRepositoriesHandler = gitea_organizations.GetProjectGiteaRepositoriesHandlerFunc(
+		func(params gitea_organizations.GetProjectGiteaRepositoriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaOrganizationsGetProjectGiteaRepositoriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"organizationID": params.OrganizationID,
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Get Project Gitea Repositories with scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitea_organizations.NewGetProjectGiteaRepositoriesForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.GetGiteaOrganizationRepositories(ctx, params.ProjectSFID, params.OrganizationID)
+			if err != nil {
+				if errors.Is(err, ErrGiteaOrganizationNotFound) {
+					msg := fmt.Sprintf("Gitea organization %s not found for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+					return gitea_organizations.NewGetProjectGiteaRepositoriesNotFound().WithPayload(
+						utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem loading the repositories of the Gitea organization: %s", params.OrganizationID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitea_organizations.NewGetProjectGiteaRepositoriesBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitea_organizations.NewGetProjectGiteaRepositoriesOK().WithPayload(result)
+		})
+
+	api.GiteaOrganizationsUpdateProjectGiteaRepositoriesHandler = gitea_organizations.UpdateProjectGiteaRepositoriesHandlerFunc(
+		func(params gitea_organizations.UpdateProjectGiteaRepositoriesParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			ctx := utils.ContextWithRequestAndUser(params.HTTPRequest.Context(), reqID, authUser) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaOrganizationsUpdateProjectGiteaRepositoriesHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"authUser":       authUser.UserName,
+				"authEmail":      authUser.Email,
+				"projectSFID":    params.ProjectSFID,
+				"organizationID": params.OrganizationID,
+			}
+
+			if params.Body == nil {
+				msg := "missing the repositories enrollment in the body"
+				log.WithFields(f).Warn(msg)
+				return gitea_organizations.NewUpdateProjectGiteaRepositoriesBadRequest().WithPayload(
+					utils.ErrorResponseBadRequest(reqID, msg))
+			}
+
+			if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				msg := fmt.Sprintf("user %s does not have access to Update Project Gitea Repositories with scope of %s",
+					authUser.UserName, params.ProjectSFID)
+				log.WithFields(f).Debug(msg)
+				return gitea_organizations.NewUpdateProjectGiteaRepositoriesForbidden().WithPayload(
+					utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.UpdateGiteaRepositoriesEnrollment(ctx, params.ProjectSFID, params.OrganizationID, params.Body)
+			if err != nil {
+				if errors.Is(err, ErrGiteaOrganizationNotFound) {
+					msg := fmt.Sprintf("Gitea organization %s not found for project SFID: %s", params.OrganizationID, params.ProjectSFID)
+					return gitea_organizations.NewUpdateProjectGiteaRepositoriesNotFound().WithPayload(
+						utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				msg := fmt.Sprintf("problem updating the repositories of the Gitea organization: %s", params.OrganizationID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return gitea_organizations.NewUpdateProjectGiteaRepositoriesBadRequest().WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+
+			return gitea_organizations.NewUpdateProjectGiteaRepositoriesOK().WithPayload(result)
+		})
+
+	api.GiteaSignSignRequestHandler = gitea_sign.SignRequestHandlerFunc(
+		func(params gitea_sign.SignRequestParams) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaSignSignRequestHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"organizationID": params.OrganizationID,
+				"repositoryID":   params.GiteaRepositoryID,
+				"pullRequestID":  params.PullRequestID,
+			}
+
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				session, err := sessionStore.Get(params.HTTPRequest, SessionStoreKey)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error with session store lookup")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				repositoryID, err := strconv.ParseInt(params.GiteaRepositoryID, 10, 64)
+				if err != nil {
+					msg := fmt.Sprintf("invalid repository ID: %s", params.GiteaRepositoryID)
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusBadRequest)
+					return
+				}
+				pullRequestID, err := strconv.ParseInt(params.PullRequestID, 10, 64)
+				if err != nil {
+					msg := fmt.Sprintf("invalid pull request ID: %s", params.PullRequestID)
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusBadRequest)
+					return
+				}
+
+				giteaOrg, err := service.GetGiteaOrganizationByID(ctx, params.OrganizationID)
+				if err != nil || giteaOrg == nil || !giteaOrg.Enabled {
+					msg := fmt.Sprintf("unable to locate Gitea organization: %s", params.OrganizationID)
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusNotFound)
+					return
+				}
+
+				oauthConfig, err := service.OAuthConfig(giteaOrg)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to load the Gitea OAuth2 application")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				originURL, err := service.GetOriginURL(ctx, giteaOrg, repositoryID, pullRequestID)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error getting origin URL")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				session.Values["gitea_organization_id"] = params.OrganizationID
+				session.Values["gitea_repository_id"] = params.GiteaRepositoryID
+				session.Values["gitea_pull_request_id"] = params.PullRequestID
+				session.Values["gitea_origin_url"] = originURL
+
+				stateID, err := uuid.NewV4()
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to generate the oauth2 state")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+				state := stateID.String()
+				session.Values["gitea_oauth2_state"] = state
+				if err := session.Save(params.HTTPRequest, rw); err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to save the session")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				log.WithFields(f).Debug("redirecting the contributor to the Gitea authorization...")
+				http.Redirect(rw, params.HTTPRequest, oauthConfig.AuthCodeURL(state), http.StatusFound)
+			})
+		})
+
+	api.GiteaSignGiteaOauthCallbackHandler = gitea_sign.GiteaOauthCallbackHandlerFunc(
+		func(params gitea_sign.GiteaOauthCallbackParams) middleware.Responder {
+			ctx := utils.NewContext()
+			f := logrus.Fields{
+				"functionName":   "v2.gitea_organizations.handlers.GiteaSignGiteaOauthCallbackHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			}
+
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, p runtime.Producer) {
+				if params.Code == nil || params.State == nil {
+					msg := "missing code or state parameter"
+					log.WithFields(f).Warn(msg)
+					http.Error(rw, msg, http.StatusBadRequest)
+					return
+				}
+
+				session, err := sessionStore.Get(params.HTTPRequest, SessionStoreKey)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("error with session store lookup")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				values := make(map[string]string)
+				for _, key := range []string{"gitea_oauth2_state", "gitea_origin_url", "gitea_organization_id", "gitea_repository_id", "gitea_pull_request_id"} {
+					value, ok := session.Values[key].(string)
+					if !ok {
+						msg := fmt.Sprintf("Error getting %s - missing from session object", key)
+						log.WithFields(f).Warn(msg)
+						http.Error(rw, msg, http.StatusInternalServerError)
+						return
+					}
+					values[key] = value
+				}
+
+				if *params.State != values["gitea_oauth2_state"] {
+					msg := fmt.Sprintf("mismatch state, received: %s from callback, but loaded our state as: %s", *params.State, values["gitea_oauth2_state"])
+					log.WithFields(f).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				giteaOrg, err := service.GetGiteaOrganizationByID(ctx, values["gitea_organization_id"])
+				if err != nil || giteaOrg == nil {
+					msg := fmt.Sprintf("unable to locate Gitea organization: %s", values["gitea_organization_id"])
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				oauthConfig, err := service.OAuthConfig(giteaOrg)
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to load the Gitea OAuth2 application")
+					http.Error(rw, err.Error(), http.StatusInternalServerError)
+					return
+				}
+
+				log.WithFields(f).Debug("Fetching access token for user...")
+				token, err := giteaApi.FetchOauthCredentials(ctx, oauthConfig, *params.Code)
+				if err != nil {
+					msg := "unable to fetch access token for user"
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				giteaClient := giteaApi.NewGiteaClientFromAccessToken(giteaOrg.InstanceURL, token.AccessToken)
+				consoleURL, err := service.InitiateSignRequest(ctx, giteaClient, giteaOrg, values["gitea_repository_id"], values["gitea_pull_request_id"], values["gitea_origin_url"], contributorConsoleV2Base, eventService)
+				if err != nil {
+					msg := "problem initiating the sign request"
+					log.WithFields(f).WithError(err).Warn(msg)
+					http.Error(rw, msg, http.StatusInternalServerError)
+					return
+				}
+
+				log.WithFields(f).Debugf("redirecting to :%s ", *consoleURL)
+				http.Redirect(rw, params.HTTPRequest, *consoleURL, http.StatusSeeOther)
+			})
+		})
+}
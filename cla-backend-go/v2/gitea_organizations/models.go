@@ -0,0 +1,70 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_organizations
+
+import (
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+)
+
+// GiteaOrganization is the data model of an onboarded Gitea or Forgejo organization. EasyCLA acts on the
+// organization repositories with the access token of a bot account, the contributors are identified with the OAuth2
+// application registered on the instance.
+type GiteaOrganization struct {
+	OrganizationID       string `json:"organization_id"`
+	InstanceURL          string `json:"instance_url"`
+	OrganizationName     string `json:"organization_name"`
+	OrganizationFullName string `json:"organization_full_name,omitempty"`
+	OrganizationURL      string `json:"organization_url,omitempty"`
+	ExternalID           int64  `json:"external_id,omitempty"`
+	OrganizationSFID     string `json:"organization_sfid,omitempty"`
+	ProjectSFID          string `json:"project_sfid"`
+	ClaGroupID           string `json:"cla_group_id"`
+	AutoEnabled          bool   `json:"auto_enabled"`
+	Enabled              bool   `json:"enabled"`
+	AuthInfo             string `json:"auth_info"`
+	OAuthClientID        string `json:"oauth_client_id"`
+	OAuthClientSecret    string `json:"oauth_client_secret"`
+	Note                 string `json:"note,omitempty"`
+	DateCreated          string `json:"date_created,omitempty"`
+	DateModified         string `json:"date_modified,omitempty"`
+	Version              string `json:"version,omitempty"`
+}
+
+// GiteaAddOrganization is data model for Gitea add organization requests
+type GiteaAddOrganization struct {
+	InstanceURL       string
+	OrganizationName  string
+	AccessToken       string
+	OAuthClientID     string
+	OAuthClientSecret string
+	ProjectSFID       string
+	ParentProjectSFID string
+	ClaGroupID        string
+	AutoEnabled       bool
+}
+
+// IsConnected returns true if the organization holds the credentials of the bot account
+func (in *GiteaOrganization) IsConnected() bool {
+	return in.AuthInfo != ""
+}
+
+// ToModel converts to models.GiteaOrganization
+func ToModel(in *GiteaOrganization) *v2Models.GiteaOrganization {
+	return &v2Models.GiteaOrganization{
+		OrganizationID:       in.OrganizationID,
+		InstanceURL:          in.InstanceURL,
+		OrganizationName:     in.OrganizationName,
+		OrganizationFullName: in.OrganizationFullName,
+		OrganizationURL:      in.OrganizationURL,
+		OrganizationSfid:     in.OrganizationSFID,
+		ProjectSfid:          in.ProjectSFID,
+		ClaGroupID:           in.ClaGroupID,
+		AutoEnabled:          in.AutoEnabled,
+		Enabled:              in.Enabled,
+		Connected:            in.IsConnected(),
+		DateCreated:          in.DateCreated,
+		DateModified:         in.DateModified,
+		Version:              in.Version,
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_organizations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// indexes
+const (
+	// GiteaOrgProjectSFIDIndex the index for the Project SFID
+	GiteaOrgProjectSFIDIndex = "gitea-project-sfid-index"
+	// GiteaOrgNameIndex the index for the organization name
+	GiteaOrgNameIndex = "gitea-org-name-index"
+)
+
+// RepositoryInterface is interface for gitea org data model
+type RepositoryInterface interface {
+	AddGiteaOrganization(ctx context.Context, giteaOrg *GiteaOrganization) error
+	GetGiteaOrganization(ctx context.Context, organizationID string) (*GiteaOrganization, error)
+	GetGiteaOrganizationsByProjectSFID(ctx context.Context, projectSFID string) ([]*GiteaOrganization, error)
+	GetGiteaOrganizationByName(ctx context.Context, instanceURL, organizationName string) (*GiteaOrganization, error)
+	DeleteGiteaOrganization(ctx context.Context, organizationID string) error
+}
+
+// Repository object/struct
+type Repository struct {
+	stage             string
+	dynamoDBClient    *dynamodb.DynamoDB
+	giteaOrgTableName string
+}
+
+// NewRepository creates a new instance of the giteaOrganizations repository
+func NewRepository(awsSession *session.Session, stage string) RepositoryInterface {
+	return &Repository{
+		stage:             stage,
+		dynamoDBClient:    dynamodb.New(awsSession),
+		giteaOrgTableName: fmt.Sprintf("cla-%s-gitea-orgs", stage),
+	}
+}
+
+// AddGiteaOrganization adds the Gitea organization record
+func (repo *Repository) AddGiteaOrganization(ctx context.Context, giteaOrg *GiteaOrganization) error {
+	f := logrus.Fields{
+		"functionName":     "v2.gitea_organizations.repository.AddGiteaOrganization",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationID":   giteaOrg.OrganizationID,
+		"instanceURL":      giteaOrg.InstanceURL,
+		"organizationName": giteaOrg.OrganizationName,
+		"projectSFID":      giteaOrg.ProjectSFID,
+	}
+
+	av, err := dynamodbattribute.MarshalMap(giteaOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshall gitea organization record")
+		return err
+	}
+
+	log.WithFields(f).Debug("adding gitea organization record to the database...")
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:                av,
+		TableName:           aws.String(repo.giteaOrgTableName),
+		ConditionExpression: aws.String("attribute_not_exists(organization_id)"),
+	})
+	if err != nil {
+		if aErr, ok := err.(awserr.Error); ok && aErr.Code() == dynamodb.ErrCodeConditionalCheckFailedException {
+			log.WithFields(f).WithError(err).Warn("gitea organization already exists")
+			return fmt.Errorf("gitea organization already exists")
+		}
+		log.WithFields(f).WithError(err).Warn("cannot put gitea organization in dynamodb")
+		return err
+	}
+
+	return nil
+}
+
+// GetGiteaOrganization returns the Gitea organization by ID, nil if not found
+func (repo *Repository) GetGiteaOrganization(ctx context.Context, organizationID string) (*GiteaOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.repository.GetGiteaOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			GiteaOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		TableName: aws.String(repo.giteaOrgTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load gitea organization")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		log.WithFields(f).Debugf("Unable to find Gitea organization by ID: %s - no results", organizationID)
+		return nil, nil
+	}
+
+	var giteaOrg GiteaOrganization
+	if err := dynamodbattribute.UnmarshalMap(result.Item, &giteaOrg); err != nil {
+		log.WithFields(f).WithError(err).Warn("error unmarshalling gitea organization table data")
+		return nil, err
+	}
+	return &giteaOrg, nil
+}
+
+// GetGiteaOrganizationsByProjectSFID returns the enabled Gitea organizations of the project
+func (repo *Repository) GetGiteaOrganizationsByProjectSFID(ctx context.Context, projectSFID string) ([]*GiteaOrganization, error) {
+	condition := expression.Key(GiteaOrganizationsProjectSFIDColumn).Equal(expression.Value(projectSFID))
+	return repo.getEnabledOrganizations(ctx, condition, GiteaOrgProjectSFIDIndex)
+}
+
+// GetGiteaOrganizationByName returns the enabled Gitea organization of the instance, nil if not found - the same
+// organization name may be onboarded on several Gitea instances
+func (repo *Repository) GetGiteaOrganizationByName(ctx context.Context, instanceURL, organizationName string) (*GiteaOrganization, error) {
+	condition := expression.Key(GiteaOrganizationsOrganizationNameColumn).Equal(expression.Value(organizationName))
+	giteaOrgs, err := repo.getEnabledOrganizations(ctx, condition, GiteaOrgNameIndex)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, giteaOrg := range giteaOrgs {
+		if giteaOrg.InstanceURL == instanceURL {
+			return giteaOrg, nil
+		}
+	}
+	return nil, nil
+}
+
+// DeleteGiteaOrganization disables the Gitea organization and removes the stored credentials
+func (repo *Repository) DeleteGiteaOrganization(ctx context.Context, organizationID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.repository.DeleteGiteaOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	org, err := repo.GetGiteaOrganization(ctx, organizationID)
+	if err != nil {
+		return err
+	}
+	// Nothing to delete or disable
+	if org == nil || !org.Enabled {
+		return nil
+	}
+
+	_, currentTime := utils.CurrentTime()
+	note := fmt.Sprintf("Enabled set to false due to org deletion on %s by %s.", currentTime, utils.GetUserNameFromContext(ctx))
+	if org.Note != "" {
+		note = fmt.Sprintf("%s. %s", org.Note, note)
+	}
+
+	_, err = repo.dynamoDBClient.UpdateItem(&dynamodb.UpdateItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			GiteaOrganizationsOrganizationIDColumn: {
+				S: aws.String(organizationID),
+			},
+		},
+		ExpressionAttributeNames: map[string]*string{
+			"#E":  aws.String(GiteaOrganizationsEnabledColumn),
+			"#AI": aws.String(GiteaOrganizationsAuthInfoColumn),
+			"#OS": aws.String(GiteaOrganizationsOAuthClientSecretColumn),
+			"#N":  aws.String(GiteaOrganizationsNoteColumn),
+			"#D":  aws.String(GiteaOrganizationsDateModifiedColumn),
+		},
+		ExpressionAttributeValues: map[string]*dynamodb.AttributeValue{
+			":e":  {BOOL: aws.Bool(false)},
+			":ai": {S: aws.String("")},
+			":os": {S: aws.String("")},
+			":n":  {S: aws.String(note)},
+			":d":  {S: aws.String(currentTime)},
+		},
+		UpdateExpression: aws.String("SET #E = :e, #AI = :ai, #OS = :os, #N = :n, #D = :d"),
+		TableName:        aws.String(repo.giteaOrgTableName),
+	})
+	if err != nil {
+		errMsg := fmt.Sprintf("error disabling gitea organization: %s - %+v", organizationID, err)
+		log.WithFields(f).WithError(err).Warn(errMsg)
+		return errors.New(errMsg)
+	}
+
+	return nil
+}
+
+// getEnabledOrganizations queries the index for the enabled Gitea organizations matching the key condition
+func (repo *Repository) getEnabledOrganizations(ctx context.Context, condition expression.KeyConditionBuilder, indexName string) ([]*GiteaOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.repository.getEnabledOrganizations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"indexName":      indexName,
+	}
+
+	filter := expression.Name(GiteaOrganizationsEnabledColumn).Equal(expression.Value(true))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).WithFilter(filter).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("problem building query expression")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.giteaOrgTableName),
+		IndexName:                 aws.String(indexName),
+	}
+
+	var resultOutput []*GiteaOrganization
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("problem retrieving gitea organizations")
+			return nil, queryErr
+		}
+
+		var page []*GiteaOrganization
+		if err := dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("problem decoding gitea organizations")
+			return nil, err
+		}
+		resultOutput = append(resultOutput, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	return resultOutput, nil
+}
@@ -0,0 +1,585 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package gitea_organizations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/config"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	giteaApi "github.com/linuxfoundation/easycla/cla-backend-go/gitea_api"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/v2/project-service"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
+)
+
+// ErrGiteaOrganizationNotFound is returned when the Gitea organization does not exist for the project
+var ErrGiteaOrganizationNotFound = errors.New("gitea organization not found")
+
+// ServiceInterface contains functions of GiteaOrganizations service
+type ServiceInterface interface {
+	AddGiteaOrganization(ctx context.Context, input *GiteaAddOrganization) (*v2Models.GiteaOrganization, error)
+	GetGiteaOrganizationByID(ctx context.Context, organizationID string) (*GiteaOrganization, error)
+	GetGiteaOrganizationsByProjectSFID(ctx context.Context, projectSFID string) (*v2Models.GiteaOrganizations, error)
+	GetGiteaOrganizationByName(ctx context.Context, instanceURL, organizationName string) (*GiteaOrganization, error)
+	GetGiteaOrganizationRepositories(ctx context.Context, projectSFID, organizationID string) (*v2Models.GiteaRepositoriesList, error)
+	UpdateGiteaRepositoriesEnrollment(ctx context.Context, projectSFID, organizationID string, input *v2Models.GiteaRepositoriesEnroll) (*v2Models.GiteaRepositoriesList, error)
+	DeleteGiteaOrganization(ctx context.Context, projectSFID, organizationID string) error
+	NewGiteaClient(giteaOrg *GiteaOrganization) (*giteaApi.Client, error)
+	OAuthConfig(giteaOrg *GiteaOrganization) (*oauth2.Config, error)
+	GetOriginURL(ctx context.Context, giteaOrg *GiteaOrganization, repositoryID, pullRequestID int64) (string, error)
+	InitiateSignRequest(ctx context.Context, giteaClient *giteaApi.Client, giteaOrg *GiteaOrganization, repositoryID, pullRequestID, originURL, contributorBaseURL string, eventService events.Service) (*string, error)
+}
+
+// Service data model
+type Service struct {
+	repo               RepositoryInterface
+	v2GitRepoService   repositories.ServiceInterface
+	claGroupRepository projects_cla_groups.Repository
+	giteaApp           *giteaApi.App
+	storeRepo          store.Repository
+	userService        users.Service
+}
+
+// NewService creates a new gitea organization service
+func NewService(repo RepositoryInterface, v2GitRepoService repositories.ServiceInterface, claGroupRepository projects_cla_groups.Repository, storeRepo store.Repository, userService users.Service) ServiceInterface {
+	return &Service{
+		repo:               repo,
+		v2GitRepoService:   v2GitRepoService,
+		claGroupRepository: claGroupRepository,
+		giteaApp:           giteaApi.Init(config.GetConfig().Gitea.AppPrivateKey, config.GetConfig().Gitea.WebhookSecret),
+		storeRepo:          storeRepo,
+		userService:        userService,
+	}
+}
+
+// AddGiteaOrganization adds the specified Gitea organization to the project. The access token of the bot account is
+// verified against the instance before the organization repositories are registered.
+func (s *Service) AddGiteaOrganization(ctx context.Context, input *GiteaAddOrganization) (*v2Models.GiteaOrganization, error) {
+	f := logrus.Fields{
+		"functionName":      "v2.gitea_organizations.service.AddGiteaOrganization",
+		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
+		"projectSFID":       input.ProjectSFID,
+		"parentProjectSFID": input.ParentProjectSFID,
+		"instanceURL":       input.InstanceURL,
+		"organizationName":  input.OrganizationName,
+		"claGroupID":        input.ClaGroupID,
+		"autoEnabled":       input.AutoEnabled,
+	}
+
+	// check if valid cla group id is passed
+	if _, err := s.claGroupRepository.GetCLAGroupNameByID(ctx, input.ClaGroupID); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to locate CLA Group: %s", input.ClaGroupID)
+		return nil, err
+	}
+
+	existing, err := s.repo.GetGiteaOrganizationByName(ctx, input.InstanceURL, input.OrganizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("problem querying Gitea organization: %s", input.OrganizationName)
+		return nil, err
+	}
+	if existing != nil {
+		// Check to make sure another project doesn't own this Gitea organization
+		if existing.ProjectSFID != input.ProjectSFID {
+			return nil, s.projectConflict(f, input.ProjectSFID, existing.ProjectSFID)
+		}
+		log.WithFields(f).Debugf("Gitea organization already onboarded with ID: %s", existing.OrganizationID)
+		return ToModel(existing), nil
+	}
+
+	giteaClient := giteaApi.NewGiteaClientFromAccessToken(input.InstanceURL, input.AccessToken)
+	giteaOrganization, err := giteaClient.GetOrganization(input.OrganizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load Gitea organization: %s with the provided access token", input.OrganizationName)
+		return nil, err
+	}
+
+	authInfo, err := giteaApi.EncryptAuthInfo(input.AccessToken, s.giteaApp)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt failed : %v", err)
+	}
+	oauthClientSecret, err := giteaApi.EncryptAuthInfo(input.OAuthClientSecret, s.giteaApp)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt failed : %v", err)
+	}
+
+	organizationID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for gitea org")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	giteaOrg := &GiteaOrganization{
+		OrganizationID:       organizationID.String(),
+		InstanceURL:          input.InstanceURL,
+		OrganizationName:     input.OrganizationName,
+		OrganizationFullName: giteaOrganization.FullName,
+		OrganizationURL:      fmt.Sprintf("%s/%s", input.InstanceURL, input.OrganizationName),
+		ExternalID:           giteaOrganization.ID,
+		OrganizationSFID:     input.ParentProjectSFID,
+		ProjectSFID:          input.ProjectSFID,
+		ClaGroupID:           input.ClaGroupID,
+		AutoEnabled:          input.AutoEnabled,
+		Enabled:              true,
+		AuthInfo:             authInfo,
+		OAuthClientID:        input.OAuthClientID,
+		OAuthClientSecret:    oauthClientSecret,
+		DateCreated:          currentTime,
+		DateModified:         currentTime,
+		Version:              "v1",
+	}
+
+	log.WithFields(f).Debug("adding Gitea organization...")
+	if err := s.repo.AddGiteaOrganization(ctx, giteaOrg); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem adding gitea organization for project")
+		return nil, err
+	}
+	log.WithFields(f).Debugf("created Gitea organization with ID: %s", giteaOrg.OrganizationID)
+
+	if _, err := s.syncRepositories(ctx, giteaClient, giteaOrg); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem registering the gitea organization repositories")
+		return nil, err
+	}
+
+	return ToModel(giteaOrg), nil
+}
+
+// GetGiteaOrganizationByID returns the record associated with the Gitea Organization ID, nil if not found
+func (s *Service) GetGiteaOrganizationByID(ctx context.Context, organizationID string) (*GiteaOrganization, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.service.GetGiteaOrganizationByID",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": organizationID,
+	}
+
+	log.WithFields(f).Debugf("fetching gitea organization for gitea org id: %s", organizationID)
+	return s.repo.GetGiteaOrganization(ctx, organizationID)
+}
+
+// GetGiteaOrganizationsByProjectSFID returns the Gitea organizations onboarded by the project
+func (s *Service) GetGiteaOrganizationsByProjectSFID(ctx context.Context, projectSFID string) (*v2Models.GiteaOrganizations, error) {
+	dbModels, err := s.repo.GetGiteaOrganizationsByProjectSFID(ctx, projectSFID)
+	if err != nil {
+		return nil, err
+	}
+
+	out := &v2Models.GiteaOrganizations{
+		List: make([]*v2Models.GiteaOrganization, 0, len(dbModels)),
+	}
+	for _, dbModel := range dbModels {
+		out.List = append(out.List, ToModel(dbModel))
+	}
+	return out, nil
+}
+
+// GetGiteaOrganizationByName returns the enabled Gitea organization of the instance, nil if not found
+func (s *Service) GetGiteaOrganizationByName(ctx context.Context, instanceURL, organizationName string) (*GiteaOrganization, error) {
+	return s.repo.GetGiteaOrganizationByName(ctx, giteaApi.NormalizeInstanceURL(instanceURL), organizationName)
+}
+
+// GetGiteaOrganizationRepositories returns the repositories registered for the Gitea organization
+func (s *Service) GetGiteaOrganizationRepositories(ctx context.Context, projectSFID, organizationID string) (*v2Models.GiteaRepositoriesList, error) {
+	giteaOrg, err := s.getProjectGiteaOrganization(ctx, projectSFID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	return s.v2GitRepoService.GiteaGetRepositoriesByOrganizationName(ctx, giteaOrg.InstanceURL, giteaOrg.OrganizationName)
+}
+
+// UpdateGiteaRepositoriesEnrollment registers the repositories created since the last synchronization, then enrolls
+// and unenrolls the requested repositories. The EasyCLA webhook is only set on the enrolled repositories.
+func (s *Service) UpdateGiteaRepositoriesEnrollment(ctx context.Context, projectSFID, organizationID string, input *v2Models.GiteaRepositoriesEnroll) (*v2Models.GiteaRepositoriesList, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.service.UpdateGiteaRepositoriesEnrollment",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"organizationID": organizationID,
+	}
+
+	giteaOrg, err := s.getProjectGiteaOrganization(ctx, projectSFID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	giteaClient, err := s.NewGiteaClient(giteaOrg)
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryList, err := s.syncRepositories(ctx, giteaClient, giteaOrg)
+	if err != nil {
+		return nil, err
+	}
+
+	claGroupID := input.ClaGroupID
+	if claGroupID == "" {
+		claGroupID = giteaOrg.ClaGroupID
+	}
+	hookURL := config.GetConfig().Gitea.WebHookURI
+
+	registered := make(map[string]*v2Models.GiteaRepository, len(repositoryList.List))
+	for _, repo := range repositoryList.List {
+		registered[repo.RepositoryID] = repo
+	}
+
+	for _, repositoryID := range input.Enroll {
+		repo, ok := registered[repositoryID]
+		if !ok {
+			return nil, fmt.Errorf("repository: %s is not a repository of the gitea organization: %s", repositoryID, giteaOrg.OrganizationName)
+		}
+		if err := s.v2GitRepoService.GiteaEnrollRepository(ctx, repositoryID, claGroupID, true); err != nil {
+			return nil, err
+		}
+		owner, name := splitFullName(repo.RepositoryName)
+		if err := giteaClient.SetWebHook(owner, name, hookURL, s.giteaApp.GetAppWebhookSecret()); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to set the EasyCLA webhook on repository: %s", repo.RepositoryName)
+			return nil, err
+		}
+	}
+
+	for _, repositoryID := range input.Unenroll {
+		repo, ok := registered[repositoryID]
+		if !ok {
+			return nil, fmt.Errorf("repository: %s is not a repository of the gitea organization: %s", repositoryID, giteaOrg.OrganizationName)
+		}
+		if err := s.v2GitRepoService.GiteaEnrollRepository(ctx, repositoryID, "", false); err != nil {
+			return nil, err
+		}
+		owner, name := splitFullName(repo.RepositoryName)
+		if err := giteaClient.RemoveWebHook(owner, name, hookURL); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to remove the EasyCLA webhook from repository: %s", repo.RepositoryName)
+		}
+	}
+
+	return s.v2GitRepoService.GiteaGetRepositoriesByOrganizationName(ctx, giteaOrg.InstanceURL, giteaOrg.OrganizationName)
+}
+
+// syncRepositories registers the repositories of the Gitea organization missing from the repositories table - the
+// new repositories are enrolled, with the EasyCLA webhook, when the organization is auto enabled
+func (s *Service) syncRepositories(ctx context.Context, giteaClient *giteaApi.Client, giteaOrg *GiteaOrganization) (*v2Models.GiteaRepositoriesList, error) {
+	f := logrus.Fields{
+		"functionName":     "v2.gitea_organizations.service.syncRepositories",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"organizationID":   giteaOrg.OrganizationID,
+		"organizationName": giteaOrg.OrganizationName,
+		"autoEnabled":      giteaOrg.AutoEnabled,
+	}
+
+	giteaRepos, err := giteaClient.ListOrganizationRepositories(giteaOrg.OrganizationName)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the gitea organization repositories")
+		return nil, err
+	}
+
+	existing, err := s.v2GitRepoService.GiteaGetRepositoriesByOrganizationName(ctx, giteaOrg.InstanceURL, giteaOrg.OrganizationName)
+	if err != nil {
+		return nil, err
+	}
+	known := make(map[int64]bool, len(existing.List))
+	for _, repo := range existing.List {
+		known[repo.RepositoryExternalID] = true
+	}
+
+	var newRepos []*giteaApi.Repository
+	for _, giteaRepo := range giteaRepos {
+		if !known[giteaRepo.ID] {
+			newRepos = append(newRepos, giteaRepo)
+		}
+	}
+	if len(newRepos) == 0 {
+		return existing, nil
+	}
+
+	log.WithFields(f).Debugf("registering %d new gitea repositories", len(newRepos))
+	repositoryList, err := s.v2GitRepoService.GiteaAddRepositories(ctx, &repositories.GiteaAddRepoModel{
+		InstanceURL:      giteaOrg.InstanceURL,
+		OrganizationName: giteaOrg.OrganizationName,
+		ProjectSFID:      giteaOrg.ProjectSFID,
+		ClaGroupID:       giteaOrg.ClaGroupID,
+		Repositories:     newRepos,
+		Enabled:          giteaOrg.AutoEnabled,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if giteaOrg.AutoEnabled {
+		hookURL := config.GetConfig().Gitea.WebHookURI
+		for _, giteaRepo := range newRepos {
+			if err := giteaClient.SetWebHook(giteaRepo.OwnerLogin(), giteaRepo.Name, hookURL, s.giteaApp.GetAppWebhookSecret()); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to set the EasyCLA webhook on repository: %s", giteaRepo.FullName)
+				return nil, err
+			}
+		}
+	}
+
+	return repositoryList, nil
+}
+
+// DeleteGiteaOrganization removes the EasyCLA webhooks and the repositories of the Gitea organization, then disables
+// the organization
+func (s *Service) DeleteGiteaOrganization(ctx context.Context, projectSFID, organizationID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.service.DeleteGiteaOrganization",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"projectSFID":    projectSFID,
+		"organizationID": organizationID,
+	}
+
+	giteaOrg, err := s.getProjectGiteaOrganization(ctx, projectSFID, organizationID)
+	if err != nil {
+		return err
+	}
+
+	repositoryList, err := s.v2GitRepoService.GiteaGetRepositoriesByOrganizationName(ctx, giteaOrg.InstanceURL, giteaOrg.OrganizationName)
+	if err != nil {
+		return err
+	}
+
+	// The credentials may already be revoked on the Gitea side, failures are logged only
+	giteaClient, err := s.NewGiteaClient(giteaOrg)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the gitea client - skipping webhook removal")
+	} else {
+		for _, repo := range repositoryList.List {
+			if !repo.Enabled {
+				continue
+			}
+			owner, name := splitFullName(repo.RepositoryName)
+			if err := giteaClient.RemoveWebHook(owner, name, config.GetConfig().Gitea.WebHookURI); err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to remove the EasyCLA webhook from repository: %s", repo.RepositoryName)
+			}
+		}
+	}
+
+	if err := s.v2GitRepoService.GiteaDeleteRepositories(ctx, giteaOrg.InstanceURL, giteaOrg.OrganizationName); err != nil {
+		log.WithFields(f).WithError(err).Warn("problem deleting the gitea organization repositories")
+		return err
+	}
+
+	return s.repo.DeleteGiteaOrganization(ctx, organizationID)
+}
+
+// NewGiteaClient returns the client of the Gitea instance authenticated as the bot account of the organization
+func (s *Service) NewGiteaClient(giteaOrg *GiteaOrganization) (*giteaApi.Client, error) {
+	return giteaApi.NewGiteaClient(giteaOrg.InstanceURL, giteaOrg.AuthInfo, s.giteaApp)
+}
+
+// OAuthConfig returns the configuration of the OAuth2 application identifying the contributors of the organization
+func (s *Service) OAuthConfig(giteaOrg *GiteaOrganization) (*oauth2.Config, error) {
+	if giteaOrg.OAuthClientID == "" || giteaOrg.OAuthClientSecret == "" {
+		return nil, fmt.Errorf("gitea organization: %s has no OAuth2 application configured", giteaOrg.OrganizationName)
+	}
+	clientSecret, err := giteaApi.DecryptAuthInfo(giteaOrg.OAuthClientSecret, s.giteaApp)
+	if err != nil {
+		return nil, err
+	}
+	return giteaApi.OAuthConfig(giteaOrg.InstanceURL, giteaOrg.OAuthClientID, clientSecret, config.GetConfig().Gitea.RedirectURI), nil
+}
+
+// GetOriginURL returns the web URL of the pull request, the contributor returns there once signed
+func (s *Service) GetOriginURL(ctx context.Context, giteaOrg *GiteaOrganization, repositoryID, pullRequestID int64) (string, error) {
+	giteaClient, err := s.NewGiteaClient(giteaOrg)
+	if err != nil {
+		return "", err
+	}
+	giteaRepo, err := giteaClient.GetRepositoryByID(repositoryID)
+	if err != nil {
+		return "", err
+	}
+	pullRequest, err := giteaClient.GetPullRequest(giteaRepo.OwnerLogin(), giteaRepo.Name, pullRequestID)
+	if err != nil {
+		return "", err
+	}
+	return pullRequest.HTMLURL, nil
+}
+
+// InitiateSignRequest initiates sign request and returns easy cla redirect url
+func (s *Service) InitiateSignRequest(ctx context.Context, giteaClient *giteaApi.Client, giteaOrg *GiteaOrganization, repositoryID, pullRequestID, originURL, contributorBaseURL string, eventService events.Service) (*string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.service.InitiateSignRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"organizationID": giteaOrg.OrganizationID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+		"originURL":      originURL,
+	}
+
+	claUser, err := s.getOrCreateUser(ctx, giteaClient, eventService)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to get or create user")
+		return nil, err
+	}
+
+	claGroupID, err := s.getRepositoryCLAGroupID(ctx, giteaOrg, repositoryID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to resolve the CLA Group of the repository")
+		return nil, err
+	}
+
+	type StoreValue struct {
+		UserID         string `json:"user_id"`
+		ProjectID      string `json:"project_id"`
+		OrganizationID string `json:"organization_id"`
+		RepositoryID   string `json:"repository_id"`
+		PullRequestID  string `json:"pull_request_id"`
+		ReturnURL      string `json:"return_url"`
+	}
+
+	// set active signature metadata to track the user signing process
+	key := fmt.Sprintf("active_signature:%s", claUser.UserID)
+	storeValue := StoreValue{
+		UserID:         claUser.UserID,
+		ProjectID:      claGroupID,
+		OrganizationID: giteaOrg.OrganizationID,
+		RepositoryID:   repositoryID,
+		PullRequestID:  pullRequestID,
+		ReturnURL:      originURL,
+	}
+	log.WithFields(f).Debugf("active signature metadata: %+v", storeValue)
+
+	jsonData, err := json.Marshal(storeValue)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to marshall storeValue object: %+v", storeValue)
+		return nil, err
+	}
+	expire := time.Now().AddDate(0, 0, 1).Unix()
+	if err := s.storeRepo.SetActiveSignatureMetaData(ctx, key, expire, string(jsonData)); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to save signature metadata")
+		return nil, err
+	}
+
+	params := "redirect=" + url.QueryEscape(originURL)
+	consoleURL := fmt.Sprintf("https://%s/#/cla/project/%s/user/%s?%s", contributorBaseURL, claGroupID, claUser.UserID, params)
+	return &consoleURL, nil
+}
+
+// getRepositoryCLAGroupID returns the CLA Group the repository is enrolled in, the repositories may be enrolled in a
+// different CLA Group than the organization default
+func (s *Service) getRepositoryCLAGroupID(ctx context.Context, giteaOrg *GiteaOrganization, repositoryID string) (string, error) {
+	repositoryExternalID, err := strconv.ParseInt(repositoryID, 10, 64)
+	if err != nil {
+		return "", err
+	}
+	giteaRepo, err := s.v2GitRepoService.GiteaGetRepositoryByExternalID(ctx, giteaOrg.InstanceURL, repositoryExternalID)
+	if err != nil {
+		return "", err
+	}
+	if giteaRepo == nil || !giteaRepo.Enabled {
+		return "", fmt.Errorf("gitea repository: %s is not enrolled in EasyCLA", repositoryID)
+	}
+	if giteaRepo.RepositoryClaGroupID != "" {
+		return giteaRepo.RepositoryClaGroupID, nil
+	}
+	return giteaOrg.ClaGroupID, nil
+}
+
+// getOrCreateUser locates the CLA user by the email of the authenticated Gitea user, the user is created when missing
+func (s *Service) getOrCreateUser(ctx context.Context, giteaClient *giteaApi.Client, eventsService events.Service) (*models.User, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.gitea_organizations.service.getOrCreateUser",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	giteaUser, err := giteaClient.GetCurrentUser()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("getting gitea current user failed")
+		return nil, err
+	}
+	if giteaUser.Email == "" {
+		return nil, errors.New("gitea user has no email address")
+	}
+
+	claUser, err := s.userService.GetUserByEmail(giteaUser.Email)
+	if err == nil && claUser != nil {
+		return claUser, nil
+	}
+
+	log.WithFields(f).Infof("creating user record for gitea user: %s", giteaUser.Login)
+	userName := giteaUser.FullName
+	if userName == "" {
+		userName = giteaUser.Login
+	}
+	user := &models.User{
+		Emails:   []string{giteaUser.Email},
+		Username: userName,
+	}
+	claUser, err = s.userService.CreateUser(user, nil)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to create user with details : %+v", user)
+		return nil, err
+	}
+
+	// Log the event
+	eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.UserCreated,
+		UserID:    claUser.UserID,
+		UserModel: claUser,
+		EventData: &events.UserCreatedEventData{},
+	})
+	return claUser, nil
+}
+
+// getProjectGiteaOrganization loads the enabled Gitea organization of the project
+func (s *Service) getProjectGiteaOrganization(ctx context.Context, projectSFID, organizationID string) (*GiteaOrganization, error) {
+	giteaOrg, err := s.repo.GetGiteaOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	if giteaOrg == nil || !giteaOrg.Enabled || giteaOrg.ProjectSFID != projectSFID {
+		return nil, ErrGiteaOrganizationNotFound
+	}
+	return giteaOrg, nil
+}
+
+// projectConflict builds the error returned when the Gitea organization is already onboarded by another project
+func (s *Service) projectConflict(f logrus.Fields, requestedProjectSFID, existingProjectSFID string) error {
+	psc := projectService.GetClient()
+	requestedProjectModel, err := psc.GetProject(requestedProjectSFID)
+	if err != nil || requestedProjectModel == nil {
+		return err
+	}
+	existingProjectModel, err := psc.GetProject(existingProjectSFID)
+	if err != nil || existingProjectModel == nil {
+		log.WithFields(f).WithError(err).Warnf("unable to lookup project with SFID: %s", existingProjectSFID)
+		return err
+	}
+
+	return &utils.ProjectConflict{
+		Message: "unable to add the Gitea organization - already taken by another project",
+		ProjectA: utils.ProjectSummary{
+			Name: requestedProjectModel.Name,
+			ID:   requestedProjectSFID,
+		},
+		ProjectB: utils.ProjectSummary{
+			Name: existingProjectModel.Name,
+			ID:   existingProjectSFID,
+		},
+	}
+}
+
+// splitFullName splits the owner/name full name of a repository
+func splitFullName(fullName string) (string, string) {
+	parts := strings.SplitN(fullName, "/", 2)
+	if len(parts) != 2 {
+		return "", fullName
+	}
+	return parts[0], parts[1]
+}
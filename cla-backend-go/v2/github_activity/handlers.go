@@ -18,6 +18,7 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/github_activity"
+	v1Github "github.com/linuxfoundation/easycla/cla-backend-go/github"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 )
 
@@ -58,6 +59,21 @@ func Configure(api *operations.EasyclaAPI, service Service) {
 				})
 			}
 
+			// go-github does not parse the merge queue events
+			if githubEvent == v1Github.MergeGroupEventType {
+				mergeGroupEvent, parseErr := v1Github.ParseMergeGroupEvent(payload)
+				if parseErr != nil {
+					return github_activity.NewGithubActivityBadRequest().WithPayload(&models.ErrorResponse{
+						Code:    "400",
+						Message: fmt.Sprintf("parsing event failed : %v", parseErr),
+					})
+				}
+				if processError := service.ProcessMergeGroupEvent(mergeGroupEvent); processError != nil {
+					log.Warnf("processing event : %s failed with : %v", githubEvent, processError)
+				}
+				return github_activity.NewGithubActivityOK()
+			}
+
 			event, err := github.ParseWebHook(githubEvent, payload)
 			if err != nil {
 				return github_activity.NewGithubActivityBadRequest().WithPayload(&models.ErrorResponse{
@@ -11,7 +11,9 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/linuxfoundation/easycla/cla-backend-go/emails"
+	v1Github "github.com/linuxfoundation/easycla/cla-backend-go/github"
 	v1GithubOrg "github.com/linuxfoundation/easycla/cla-backend-go/github_organizations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
 
 	"github.com/sirupsen/logrus"
 
@@ -33,6 +35,7 @@ import (
 type Service interface {
 	ProcessInstallationRepositoriesEvent(event *github.InstallationRepositoriesEvent) error
 	ProcessRepositoryEvent(*github.RepositoryEvent) error
+	ProcessMergeGroupEvent(event *v1Github.MergeGroupEvent) error
 }
 
 type eventHandlerService struct {
@@ -41,6 +44,7 @@ type eventHandlerService struct {
 	eventService      events.Service
 	autoEnableService dynamo_events.AutoEnableService
 	emailService      emails.Service
+	signatureService  signatures.SignatureService
 	sendEmail         bool
 }
 
@@ -49,9 +53,10 @@ func NewService(gitV1Repository repositories.RepositoryInterface,
 	githubOrgRepo v1GithubOrg.RepositoryInterface,
 	eventService events.Service,
 	autoEnableService dynamo_events.AutoEnableService,
-	emailService emails.Service) Service {
+	emailService emails.Service,
+	signatureService signatures.SignatureService) Service {
 
-	return newService(gitV1Repository, githubOrgRepo, eventService, autoEnableService, emailService, true)
+	return newService(gitV1Repository, githubOrgRepo, eventService, autoEnableService, emailService, signatureService, true)
 }
 
 func newService(gitV1Repository repositories.RepositoryInterface,
@@ -59,6 +64,7 @@ func newService(gitV1Repository repositories.RepositoryInterface,
 	eventService events.Service,
 	autoEnableService dynamo_events.AutoEnableService,
 	emailService emails.Service,
+	signatureService signatures.SignatureService,
 	sendEmail bool) Service {
 	return &eventHandlerService{
 		gitV1Repository:   gitV1Repository,
@@ -66,6 +72,7 @@ func newService(gitV1Repository repositories.RepositoryInterface,
 		eventService:      eventService,
 		autoEnableService: autoEnableService,
 		emailService:      emailService,
+		signatureService:  signatureService,
 		sendEmail:         sendEmail,
 	}
 }
@@ -536,3 +543,46 @@ func (s *eventHandlerService) ProcessInstallationRepositoriesEvent(event *github
 
 	return nil
 }
+
+// ProcessMergeGroupEvent runs the CLA check of the pull request added to a merge queue when the merge queue requests
+// the checks of the merge group, and reports it as a check run on the merge group head commit
+func (s *eventHandlerService) ProcessMergeGroupEvent(event *v1Github.MergeGroupEvent) error {
+	ctx := utils.NewContext()
+	f := logrus.Fields{
+		"functionName":   "v2.github_activity.service.ProcessMergeGroupEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"action":         aws.StringValue(event.Action),
+		"repository":     event.Repo.GetFullName(),
+		"headSHA":        aws.StringValue(event.MergeGroup.HeadSHA),
+	}
+
+	if aws.StringValue(event.Action) != v1Github.MergeGroupChecksRequested {
+		log.WithFields(f).Debugf("ProcessMergeGroupEvent no handler for action : %s", aws.StringValue(event.Action))
+		return nil
+	}
+
+	if event.Repo.ID == nil || *event.Repo.ID == 0 {
+		return fmt.Errorf("missing repo id")
+	}
+	repositoryExternalID := strconv.FormatInt(*event.Repo.ID, 10)
+	repoModel, err := s.gitV1Repository.GitHubGetRepositoryByGithubID(ctx, repositoryExternalID, true)
+	if err != nil {
+		if _, ok := err.(*utils.GitHubRepositoryNotFound); ok {
+			log.WithFields(f).Warnf("merge group event for a repository without CLA enforcement : %s, nothing to do", event.Repo.GetFullName())
+			return nil
+		}
+		return fmt.Errorf("fetching the repo : %s by external id : %s failed : %v", event.Repo.GetFullName(), repositoryExternalID, err)
+	}
+
+	pullRequestID, err := event.MergeGroup.GetPullRequestNumber()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to determine the pull request of the merge group")
+		return err
+	}
+	f["pullRequestID"] = pullRequestID
+
+	owner := event.Repo.GetOwner().GetLogin()
+	log.WithFields(f).Debugf("running the CLA check of the merge group for CLA Group : %s", repoModel.RepositoryClaGroupID)
+	return s.signatureService.UpdateMergeGroupCheck(ctx, event.Installation.GetID(), *event.Repo.ID, owner, event.Repo.GetName(),
+		pullRequestID, aws.StringValue(event.MergeGroup.HeadSHA), repoModel.RepositoryClaGroupID)
+}
@@ -226,6 +226,15 @@ func Configure(api *operations.EasyclaAPI, service Service, eventService events.
 				return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
 			}
 
+			if params.Body.BotAllowlist != nil {
+				err = service.UpdateGithubOrganizationBotAllowlist(ctx, params.OrgName, params.Body.BotAllowlist)
+				if err != nil {
+					msg := fmt.Sprintf("problem updating the bot allowlist of the GitHub Organization for project SFID: %s for organization: %s", params.ProjectSFID, params.OrgName)
+					log.WithFields(f).Debug(msg)
+					return github_organizations.NewUpdateProjectGithubOrganizationConfigBadRequest().WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+			}
+
 			// Log the event
 			eventService.LogEventWithContext(ctx, &events.LogEventArgs{
 				LfUsername:  authUser.UserName,
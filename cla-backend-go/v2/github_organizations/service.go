@@ -33,15 +33,27 @@ func v2GithubOrganizationModel(in *v1Models.GithubOrganization) (*models.GithubO
 	if err != nil {
 		return nil, err
 	}
+	response.BotAllowlist = v2BotAllowlistModel(in.BotAllowlist)
 	return &response, nil
 }
 
+func v2BotAllowlistModel(in *v1Models.BotAllowlist) *models.BotAllowlist {
+	if in == nil {
+		return nil
+	}
+	return &models.BotAllowlist{
+		Logins:        in.Logins,
+		EmailPatterns: in.EmailPatterns,
+	}
+}
+
 // Service contains functions of GithubOrganizations service
 type Service interface {
 	GetGithubOrganizations(ctx context.Context, projectSFID string) (*models.ProjectGithubOrganizations, error)
 	AddGithubOrganization(ctx context.Context, projectSFID string, input *models.GithubCreateOrganization) (*models.GithubOrganization, error)
 	DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error
 	UpdateGithubOrganization(ctx context.Context, projectSFID string, organizationName string, autoEnabled bool, autoEnabledClaGroupID string, branchProtectionEnabled bool) error
+	UpdateGithubOrganizationBotAllowlist(ctx context.Context, organizationName string, allowlist *models.BotAllowlist) error
 }
 
 type service struct {
@@ -175,6 +187,7 @@ func (s service) GetGithubOrganizations(ctx context.Context, projectSFID string)
 			AutoEnableCLAGroupID:    org.AutoEnabledClaGroupID,
 			AutoEnabledCLAGroupName: autoEnabledCLAGroupName,
 			BranchProtectionEnabled: org.BranchProtectionEnabled,
+			BotAllowlist:            v2BotAllowlistModel(org.BotAllowlist),
 			ConnectionStatus:        "", // updated below
 			GithubOrganizationName:  org.OrganizationName,
 			Repositories:            make([]*models.ProjectGithubRepository, 0),
@@ -344,6 +357,16 @@ func (s service) UpdateGithubOrganization(ctx context.Context, projectSFID strin
 	return s.repo.UpdateGitHubOrganization(ctx, projectSFID, organizationName, autoEnabled, autoEnabledClaGroupID, branchProtectionEnabled, nil)
 }
 
+// UpdateGithubOrganizationBotAllowlist replaces the bots exempt from the CLA check on the organization repositories
+func (s service) UpdateGithubOrganizationBotAllowlist(ctx context.Context, organizationName string, allowlist *models.BotAllowlist) error {
+	v1Allowlist := &v1Models.BotAllowlist{}
+	if allowlist != nil {
+		v1Allowlist.Logins = utils.RemoveDuplicates(allowlist.Logins)
+		v1Allowlist.EmailPatterns = utils.RemoveDuplicates(allowlist.EmailPatterns)
+	}
+	return s.repo.UpdateGitHubOrganizationBotAllowlist(ctx, organizationName, v1Allowlist)
+}
+
 func (s service) DeleteGithubOrganization(ctx context.Context, projectSFID string, githubOrgName string) error {
 	f := logrus.Fields{
 		"functionName":   "v2.github_organizations.service.DeleteGitHubOrganization",
@@ -14,16 +14,18 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/config"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/company"
-	signatures1 "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
 
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 	gitlab_api "github.com/linuxfoundation/easycla/cla-backend-go/gitlab_api"
+	projectService "github.com/linuxfoundation/easycla/cla-backend-go/project/service"
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
 	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
 	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/common"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
 	gitV2Repositories "github.com/linuxfoundation/easycla/cla-backend-go/v2/repositories"
@@ -35,9 +37,9 @@ import (
 )
 
 var (
-	missingID                 = errors.New("user missing in easyCLA records")
-	missingCompanyAffiliation = errors.New("must confirm affiliation with their company")
-	missingCompanyApproval    = errors.New("missing in company approval lists")
+	missingID                 = cla_check.ErrMissingID
+	missingCompanyAffiliation = cla_check.ErrMissingCompanyAffiliation
+	missingCompanyApproval    = cla_check.ErrMissingCompanyApproval
 	secretTokenMismatch       = errors.New("secret token mismatch")
 )
 
@@ -59,12 +61,22 @@ type ProcessMergeActivityInput struct {
 type gatedGitlabUser struct {
 	*gitlab.User
 	err error
+	// optional is set for the co-authors which are only reported
+	optional bool
+}
+
+// exemptGitlabUser is a commit author exempt from the CLA check by the bot allowlist
+type exemptGitlabUser struct {
+	*gitlab.User
+	// allowlistEntry is the bot allowlist login or email pattern the user matches
+	allowlistEntry string
 }
 
 type Service interface {
 	ProcessMergeCommentActivity(ctx context.Context, secretToken string, commentEvent *gitlab.MergeEvent) error
 	ProcessMergeOpenedActivity(ctx context.Context, secretToken string, mergeEvent *gitlab.MergeEvent) error
 	ProcessMergeActivity(ctx context.Context, secretToken string, input *ProcessMergeActivityInput) error
+	UpdateOpenMergeRequests(ctx context.Context, claGroupID string, affectedUsers []*models.User) error
 	IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool
 }
 
@@ -77,11 +89,14 @@ type service struct {
 	projectsCLAGroupsRepository projects_cla_groups.Repository
 	companyRepository           company.IRepository
 	signatureRepository         signatures.SignatureRepository
+	claGroupService             projectService.Service
+	claCheckService             cla_check.Service
+	eventsService               events.Service
 	gitLabApp                   *gitlab_api.App
 }
 
 func NewService(gitRepository repositories.RepositoryInterface, gitV2Repository gitV2Repositories.RepositoryInterface, usersRepository users.UserRepository, signaturesRepository signatures.SignatureRepository, projectsCLAGroupsRepository projects_cla_groups.Repository,
-	companyRepository company.IRepository, signatureRepository signatures.SignatureRepository, gitlabOrgService gitlab_organizations.ServiceInterface) Service {
+	companyRepository company.IRepository, signatureRepository signatures.SignatureRepository, gitlabOrgService gitlab_organizations.ServiceInterface, claGroupService projectService.Service, eventsService events.Service) Service {
 	return &service{
 		gitRepository:               gitRepository,
 		gitV2Repository:             gitV2Repository,
@@ -90,6 +105,9 @@ func NewService(gitRepository repositories.RepositoryInterface, gitV2Repository
 		projectsCLAGroupsRepository: projectsCLAGroupsRepository,
 		companyRepository:           companyRepository,
 		signatureRepository:         signatureRepository,
+		claGroupService:             claGroupService,
+		claCheckService:             cla_check.NewService(usersRepository, signatureRepository, companyRepository, claGroupService),
+		eventsService:               eventsService,
 		gitLabApp:                   gitlab_api.Init(config.GetConfig().Gitlab.AppClientID, config.GetConfig().Gitlab.AppClientSecret, config.GetConfig().Gitlab.AppPrivateKey),
 		gitlabOrgService:            gitlabOrgService,
 	}
@@ -231,7 +249,7 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	}
 
 	log.WithFields(f).Debugf("loading GitLab merge request participatants for merge request: %d", mergeID)
-	participants, err := gitlab_api.FetchMrParticipants(gitlabClient, projectID, mergeID)
+	participants, coAuthors, err := gitlab_api.FetchMrCommitAuthors(gitlabClient, projectID, mergeID)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem loading GitLab merge request participants for merge request: %d", mergeID)
 		return fmt.Errorf("problem loading GitLab merge request participants for merge request: %d - error: %+v", mergeID, err)
@@ -248,39 +266,56 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	claGroupID := claGroup.ClaGroupID
 	log.WithFields(f).Debugf("gitlabOrg : %s is associated with cla group id : %s", gitlabOrg.OrganizationName, claGroupID)
 
-	log.WithFields(f).Debugf("found %d participants for the MR ", len(participants))
+	// bots of the CLA Group and GitLab group allowlists are exempt from the CLA check
+	allowlist := s.gitLabBotAllowlist(ctx, claGroupID, gitlabOrg)
+	participants, exemptParticipants := splitExemptGitlabUsers(participants, allowlist)
+	coAuthors, exemptCoAuthors := splitExemptGitlabUsers(coAuthors, allowlist)
+	exemptUsers := append(exemptParticipants, exemptCoAuthors...)
+	s.logBotExemptions(ctx, claGroupID, repositoryPath, mergeID, exemptUsers)
+
+	log.WithFields(f).Debugf("found %d participants, %d co-authors and %d exempt bots for the MR ", len(participants), len(coAuthors), len(exemptUsers))
 	missingCLAMsg := "Missing CLA Authorization"
 	signedCLAMsg := "EasyCLA check passed. You are authorized to contribute."
 
+	authors := make([]*cla_check.CommitIdentity, 0, len(participants)+len(coAuthors))
+	for _, gitlabUser := range participants {
+		authors = append(authors, toCommitIdentity(gitlabUser))
+	}
+	for _, coAuthor := range coAuthors {
+		identity := toCommitIdentity(coAuthor)
+		identity.CoAuthor = true
+		authors = append(authors, identity)
+	}
+	checkedUsers := append(participants, coAuthors...)
+	evaluation, err := s.claCheckService.Evaluate(ctx, &cla_check.EvaluateInput{
+		CLAGroupID:           claGroupID,
+		Authors:              authors,
+		OrganizationApproval: s.gitLabGroupApproval,
+	})
+	if err != nil {
+		return fmt.Errorf("evaluating the participants of merge request: %d failed : %v", mergeID, err)
+	}
+
 	var missingUsers []*gatedGitlabUser
 	var signedUsers []*gitlab.User
-	for _, gitlabUser := range participants {
-		log.WithFields(f).Debugf("checking if GitLab user: %s (%d) with email: %s has signed", gitlabUser.Username, gitlabUser.ID, gitlabUser.Email)
-		userSigned, signedCheckErr := s.hasUserSigned(ctx, claGroupID, gitlabUser)
-		if signedCheckErr != nil {
-			log.WithFields(f).WithError(signedCheckErr).Warnf("problem checking if user : %s (%d) has signed - assuming not signed", gitlabUser.Username, gitlabUser.ID)
+	for i, verdict := range evaluation.Verdicts {
+		gitlabUser := checkedUsers[i]
+		if !verdict.Covered {
+			log.WithFields(f).WithError(verdict.Err).Infof("gitlabUser: %s (%d) has NOT signed - %s", gitlabUser.Username, gitlabUser.ID, verdict.Reason.Message())
 			missingUsers = append(missingUsers, &gatedGitlabUser{
-				User: gitlabUser,
-				err:  err,
+				User:     gitlabUser,
+				err:      verdict.Err,
+				optional: !verdict.Required,
 			})
 			continue
 		}
-
-		if userSigned {
-			log.WithFields(f).Infof("gitlabUser: %s (%d) has signed", gitlabUser.Username, gitlabUser.ID)
-			signedUsers = append(signedUsers, gitlabUser)
-		} else {
-			log.WithFields(f).Infof("gitlabUser: %s (%d) has NOT signed", gitlabUser.Username, gitlabUser.ID)
-			missingUsers = append(missingUsers, &gatedGitlabUser{
-				User: gitlabUser,
-				err:  err,
-			})
-		}
+		log.WithFields(f).Infof("gitlabUser: %s (%d) has signed - %s", gitlabUser.Username, gitlabUser.ID, verdict.Reason.Message())
+		signedUsers = append(signedUsers, gitlabUser)
 	}
 
 	signURL := GetFullSignURL(gitlabOrg.OrganizationID, strconv.Itoa(int(gitlabRepo.RepositoryExternalID)), strconv.Itoa(mergeID))
-	mrCommentContent := PrepareMrCommentContent(missingUsers, signedUsers, signURL)
-	if len(missingUsers) > 0 {
+	mrCommentContent := PrepareMrCommentContent(missingUsers, signedUsers, exemptUsers, signURL)
+	if len(blockingUsers(missingUsers)) > 0 {
 		log.WithFields(f).Errorf("merge request faild with 1 or more users not passing authorization - failed users : %+v", missingUsers)
 		if statusErr := gitlab_api.SetCommitStatus(gitlabClient, projectID, lastCommitSha, gitlab.Failed, missingCLAMsg, signURL); statusErr != nil {
 			log.WithFields(f).WithError(statusErr).Warnf("problem setting the commit status for merge request ID: %d, sha: %s", mergeID, lastCommitSha)
@@ -309,12 +344,130 @@ func (s *service) ProcessMergeActivity(ctx context.Context, secretToken string,
 	return nil
 }
 
-func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*gitlab.User, signURL string) string {
+// UpdateOpenMergeRequests re-runs the CLA check on the open merge requests of the CLA Group GitLab repositories which
+// have at least one commit authored by one of the specified users. All open merge requests are updated when no users
+// are specified.
+func (s *service) UpdateOpenMergeRequests(ctx context.Context, claGroupID string, affectedUsers []*models.User) error {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab-activity.service.UpdateOpenMergeRequests",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"affectedUsers":  len(affectedUsers),
+	}
+
+	// Note: despite the name, this returns the enabled GitLab repositories of the CLA Group
+	gitlabRepos, err := s.gitV2Repository.GitHubGetRepositoriesByCLAGroupEnabled(ctx, claGroupID)
+	if err != nil {
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); ok {
+			log.WithFields(f).Debug("no GitLab repositories enabled for the CLA Group")
+			return nil
+		}
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group GitLab repositories")
+		return err
+	}
+
+	updated := 0
+	for _, gitlabRepo := range gitlabRepos {
+		projectPath := gitlabRepo.RepositoryName
+		projectNamespace := gitlabRepo.RepositoryOrganizationName
+		if idx := strings.LastIndex(projectPath, "/"); idx > 0 {
+			projectNamespace = projectPath[:idx]
+		}
+		projectID, convErr := strconv.Atoi(gitlabRepo.RepositoryExternalID)
+		if convErr != nil {
+			log.WithFields(f).WithError(convErr).Warnf("invalid external ID: %s for GitLab repository: %s - skipping", gitlabRepo.RepositoryExternalID, projectPath)
+			continue
+		}
+
+		gitlabOrg, orgErr := s.getGitlabOrganizationFromProjectPath(ctx, projectPath, projectNamespace)
+		if orgErr != nil {
+			log.WithFields(f).WithError(orgErr).Warnf("unable to locate the GitLab organization for repository: %s - skipping", projectPath)
+			continue
+		}
+
+		oauthResponse, authErr := s.gitlabOrgService.RefreshGitLabOrganizationAuth(ctx, common.ToCommonModel(gitlabOrg))
+		if authErr != nil {
+			log.WithFields(f).WithError(authErr).Warnf("refreshing gitlab org : %s auth info failed - skipping", gitlabOrg.OrganizationName)
+			continue
+		}
+
+		gitlabClient, clientErr := gitlab_api.NewGitlabOauthClient(*oauthResponse, s.gitLabApp)
+		if clientErr != nil {
+			log.WithFields(f).WithError(clientErr).Warnf("initializing gitlab client for org : %s failed - skipping", gitlabOrg.OrganizationName)
+			continue
+		}
+
+		mergeRequests, listErr := gitlab_api.FetchOpenMergeRequests(gitlabClient, projectID)
+		if listErr != nil {
+			log.WithFields(f).WithError(listErr).Warnf("unable to list open merge requests for repository: %s - skipping", projectPath)
+			continue
+		}
+
+		for _, mergeRequest := range mergeRequests {
+			if len(affectedUsers) > 0 {
+				participants, coAuthors, participantsErr := gitlab_api.FetchMrCommitAuthors(gitlabClient, projectID, mergeRequest.IID)
+				if participantsErr != nil {
+					log.WithFields(f).WithError(participantsErr).Warnf("unable to load participants for merge request: %d of repository: %s - skipping", mergeRequest.IID, projectPath)
+					continue
+				}
+				if !participantsContainUsers(append(participants, coAuthors...), affectedUsers) {
+					continue
+				}
+			}
+
+			input := &ProcessMergeActivityInput{
+				ProjectName:      projectPath[strings.LastIndex(projectPath, "/")+1:],
+				ProjectPath:      projectPath,
+				ProjectNamespace: projectNamespace,
+				ProjectID:        projectID,
+				MergeID:          mergeRequest.IID,
+				RepositoryPath:   projectPath,
+				LastCommitSha:    mergeRequest.SHA,
+			}
+			log.WithFields(f).Debugf("updating CLA status for merge request: %d of repository: %s", mergeRequest.IID, projectPath)
+			if processErr := s.ProcessMergeActivity(ctx, "", input); processErr != nil {
+				log.WithFields(f).WithError(processErr).Warnf("unable to update merge request: %d of repository: %s", mergeRequest.IID, projectPath)
+				continue
+			}
+			updated++
+		}
+	}
+
+	log.WithFields(f).Debugf("updated %d open merge requests", updated)
+	return nil
+}
+
+// participantsContainUsers returns true if any of the merge request participants matches one of the users by GitLab ID,
+// GitLab username or email
+func participantsContainUsers(participants []*gitlab.User, users []*models.User) bool {
+	for _, participant := range participants {
+		if participant == nil {
+			continue
+		}
+		for _, user := range users {
+			if user == nil {
+				continue
+			}
+			if participant.ID != 0 && strconv.Itoa(participant.ID) == user.GitlabID {
+				return true
+			}
+			if participant.Username != "" && strings.EqualFold(participant.Username, user.GitlabUsername) {
+				return true
+			}
+			if participant.Email != "" && (strings.EqualFold(participant.Email, user.LfEmail.String()) || utils.StringInSlice(participant.Email, user.Emails)) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*gitlab.User, exemptUsers []*exemptGitlabUser, signURL string) string {
 	landingPage := config.GetConfig().CLALandingPage
 	landingPage += "/#/?version=2"
 
 	var badgeHyperlink string
-	if len(missingUsers) > 0 {
+	if len(blockingUsers(missingUsers)) > 0 {
 		badgeHyperlink = signURL
 	} else {
 		badgeHyperlink = landingPage
@@ -345,6 +498,16 @@ func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*git
 		body = coveredBadge
 	}
 
+	if len(exemptUsers) > 0 {
+		result += "<ul>"
+		for _, exempt := range exemptUsers {
+			authorInfo := getAuthorInfo(exempt.User)
+			result += fmt.Sprintf("<li>:robot: %s. This commit author is exempt from the CLA check - it matches the bot allowlist entry '%s'.</li>", authorInfo, exempt.allowlistEntry)
+		}
+		result += "</ul>"
+		body = coveredBadge
+	}
+
 	// gitlabSupportURL := "https://about.gitlab.com/support"
 	easyCLASupportURL := "https://jira.linuxfoundation.org/servicedesk/customer/portal/4"
 	// faq := "https://docs.linuxfoundation.org/lfx/easycla/v2-current/getting-started/easycla-troubleshooting#github-unable-to-contribute-to-easycla-enforced-repositories"
@@ -353,6 +516,13 @@ func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*git
 		result += "<ul>"
 		for _, missingUser := range missingUsers {
 			authorInfo := getAuthorInfo(missingUser.User)
+			if missingUser.optional {
+				msg := fmt.Sprintf(`<li>:warning: %s. This co-author is not authorized under a signed CLA, co-authors are reported without failing the check.
+									<a href='%s' target='_blank'>Please click here to be authorized</a>.
+									</li>`, authorInfo, signURL)
+				result += msg
+				continue
+			}
 			if errors.Is(missingUser.err, missingCompanyAffiliation) {
 				msg := fmt.Sprintf(`<li> %s %s. This user is authorized, but they must confirm their affiliation with their company. 
 								  Start the authorization process <a href='%s'> by clicking here</a>, click "Corporate", 
@@ -381,6 +551,82 @@ func PrepareMrCommentContent(missingUsers []*gatedGitlabUser, signedUsers []*git
 	return body
 }
 
+// splitExemptGitlabUsers splits the users into the users to check and the users exempt by the bot allowlist. Only the
+// username of a resolved GitLab account is matched, the commit and Co-authored-by trailer emails are set by the
+// committer and never exempt a user.
+func splitExemptGitlabUsers(users []*gitlab.User, allowlist *utils.BotAllowlist) ([]*gitlab.User, []*exemptGitlabUser) {
+	if allowlist.IsEmpty() {
+		return users, nil
+	}
+	checked := make([]*gitlab.User, 0, len(users))
+	var exempt []*exemptGitlabUser
+	for _, user := range users {
+		if user == nil {
+			continue
+		}
+		if user.ID == 0 {
+			checked = append(checked, user)
+			continue
+		}
+		if entry, ok := allowlist.Match(user.Username, ""); ok {
+			exempt = append(exempt, &exemptGitlabUser{User: user, allowlistEntry: entry})
+			continue
+		}
+		checked = append(checked, user)
+	}
+	return checked, exempt
+}
+
+// gitLabBotAllowlist returns the union of the CLA Group and the GitLab group bot allowlists - an allowlist which can't
+// be loaded is skipped
+func (s *service) gitLabBotAllowlist(ctx context.Context, claGroupID string, gitlabOrg *v2Models.GitlabOrganization) *utils.BotAllowlist {
+	f := logrus.Fields{
+		"functionName":   "v2.gitlab-activity.service.gitLabBotAllowlist",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	var claGroupAllowlist *utils.BotAllowlist
+	claGroupModel, err := s.claGroupService.GetCLAGroupByID(ctx, claGroupID)
+	if err != nil || claGroupModel == nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group bot allowlist")
+	} else {
+		claGroupAllowlist = utils.BotAllowlistFromV1Model(claGroupModel.BotAllowlist)
+	}
+	return utils.MergeBotAllowlists(claGroupAllowlist, utils.BotAllowlistFromV2Model(gitlabOrg.BotAllowlist))
+}
+
+// logBotExemptions logs an event for each commit author exempt from the CLA check by the bot allowlist
+func (s *service) logBotExemptions(ctx context.Context, claGroupID, repositoryPath string, mergeID int, exemptUsers []*exemptGitlabUser) {
+	for _, exempt := range exemptUsers {
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:  events.CLACheckBotExempted,
+			CLAGroupID: claGroupID,
+			UserID:     "easycla system",
+			UserName:   "easycla system",
+			EventData: &events.CLACheckBotExemptedEventData{
+				Provider:        utils.GitLabLower,
+				RepositoryName:  repositoryPath,
+				ChangeRequestID: strconv.Itoa(mergeID),
+				Login:           exempt.Username,
+				Email:           exempt.Email,
+				AllowlistEntry:  exempt.allowlistEntry,
+			},
+		})
+	}
+}
+
+// blockingUsers returns the missing users failing the check, the optional co-authors are only reported
+func blockingUsers(missingUsers []*gatedGitlabUser) []*gatedGitlabUser {
+	var blocking []*gatedGitlabUser
+	for _, missingUser := range missingUsers {
+		if !missingUser.optional {
+			blocking = append(blocking, missingUser)
+		}
+	}
+	return blocking
+}
+
 func GetFullSignURL(gitlabOrganizationID string, gitlabRepositoryID string, mrID string) string {
 	return fmt.Sprintf("%s/v4/repository-provider/%s/sign/%s/%s/%s/#/",
 		config.GetConfig().ClaAPIV4Base,
@@ -445,257 +691,41 @@ func (s *service) getGitlabRepoByName(ctx context.Context, repoNameWithPath stri
 	return gitlabRepo.ToGitHubModel(), nil
 }
 
-type UserSigned struct {
-	signed bool
-	err    error
+// IsUserApprovedForSignature checks the GitLab user against the approval lists of the corporate signature
+func (s *service) IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool {
+	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s", user.UserID, corporateSignature.SignatureID)
+	return s.claCheckService.IsApproved(ctx, toCommitIdentity(gitlabUser), user, corporateSignature, s.gitLabGroupApproval)
 }
 
-func (s *service) hasUserSigned(ctx context.Context, claGroupID string, gitlabUser *gitlab.User) (bool, error) {
-	f := logrus.Fields{
-		"functionName":    "v2.gitlab-activity.service.hasUserSigned",
-		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
-		"gitlabUserID":    gitlabUser.ID,
-		"gitlabUserName":  gitlabUser.Username,
-		"gitlabUserEmail": gitlabUser.Email,
-	}
-
-	userModels, lookUpErr := s.findUserModelForGitlabUser(f, gitlabUser)
-	if lookUpErr != nil {
-		log.WithFields(f).WithError(lookUpErr).Warnf("unable to find user model for gitlab user: %v", gitlabUser)
-		return false, lookUpErr
+// gitLabGroupApproval checks the author against the GitLab group approval list of the corporate signature
+func (s *service) gitLabGroupApproval(ctx context.Context, author *cla_check.CommitIdentity, user *models.User, corporateSignature *models.Signature) (bool, error) {
+	if author.Login == "" {
+		return false, nil
 	}
-
-	if len(userModels) == 0 {
-		log.WithFields(f).Warnf("gitlab user: %s (%d) not found in easycla records", gitlabUser.Username, gitlabUser.ID)
-		return false, missingID
-	}
-
-	for _, userModel := range userModels {
-		signed, err := s.isSigned(ctx, userModel, claGroupID, gitlabUser)
+	for _, gitlabGroupApproval := range corporateSignature.GitlabOrgApprovalList {
+		isApproved, err := s.checkGitLabGroupApproval(ctx, author.Login, gitlabGroupApproval)
 		if err != nil {
-			log.WithFields(f).Debugf("error checking if user is signed, error: %v", err)
-			continue
+			return false, err
 		}
-		if signed {
-			log.WithFields(f).Debugf("found signed user for clagroupID: %s, userID: %s", claGroupID, userModel.UserID)
+		if isApproved {
 			return true, nil
-		} else {
-			log.WithFields(f).Debugf("user is not signed for claGroupID: %s, userID: %s", claGroupID, userModel.UserID)
 		}
 	}
-
 	return false, nil
 }
 
-func (s *service) isSigned(ctx context.Context, userModel *models.User, claGroupID string, gitlabUser *gitlab.User) (bool, error) {
-	f := logrus.Fields{
-		"functionName":    "v2.gitlab-activity.service.isSigned",
-		utils.XREQUESTID:  ctx.Value(utils.XREQUESTID),
-		"gitlabUserID":    gitlabUser.ID,
-		"gitlabUserName":  gitlabUser.Username,
-		"gitlabUserEmail": gitlabUser.Email,
-	}
-
-	// First check for an ICLA signature
-	icla, err := s.signaturesRepository.GetIndividualSignature(ctx, claGroupID, userModel.UserID, aws.Bool(true), aws.Bool(true))
-	if err != nil {
-		log.WithFields(f).Warnf("fetching ICLA for gitlab user : %d:%s failed : %v", gitlabUser.ID, gitlabUser.Username, err)
-		return false, err
-	}
-
-	if icla != nil {
-		log.WithFields(f).Infof("user has signed the following signature (ICLA): %s, passing", icla.SignatureID)
-		return true, nil
+// toCommitIdentity maps the merge request participant to the identity checked by the CLA engine
+func toCommitIdentity(gitlabUser *gitlab.User) *cla_check.CommitIdentity {
+	identity := &cla_check.CommitIdentity{
+		Provider: utils.GitLabLower,
+		Name:     gitlabUser.Name,
+		Email:    gitlabUser.Email,
+		Login:    gitlabUser.Username,
 	}
-
-	if userModel.CompanyID == "" {
-		log.WithFields(f).Debugf("user does not have association with any company, can't confirm employee acknoledgement")
-		return false, fmt.Errorf("user hasn't signed yet")
-	}
-
-	companyID := userModel.CompanyID
-	_, err = s.companyRepository.GetCompany(ctx, companyID)
-	if err != nil {
-		msg := fmt.Sprintf("can't load company record: %s for user: %s (%s), error: %v", companyID, userModel.Username, userModel.UserID, err)
-		log.WithFields(f).Errorf("%s", msg)
-		return false, fmt.Errorf("%s", msg)
-	}
-
-	corporateSignature, err := s.signatureRepository.GetCorporateSignature(ctx, claGroupID, companyID, aws.Bool(true), aws.Bool(true))
-	if err != nil {
-		msg := fmt.Sprintf("can't load company signature record for company: %s for user : %s (%s), error : %v", companyID, userModel.Username, userModel.UserID, err)
-		log.WithFields(f).Errorf("%s", msg)
-		return false, fmt.Errorf("%s", msg)
-	}
-
-	if corporateSignature == nil {
-		msg := fmt.Sprintf("no corporate signature (CCLA) record found for company : %s ", companyID)
-		log.WithFields(f).Errorf("%s", msg)
-		return false, fmt.Errorf("%s", msg)
-	}
-
-	log.WithFields(f).Debugf("loaded corporate signature id: %s for claGroupID: %s and companyID: %s", corporateSignature.SignatureID, claGroupID, companyID)
-
-	approvalCriteria := &signatures.ApprovalCriteria{}
-	if gitlabUser.Email != "" {
-		approvalCriteria.UserEmail = gitlabUser.Email
-	} else if gitlabUser.Username != "" {
-		approvalCriteria.GitlabUsername = gitlabUser.Username
-	} else {
-		msg := fmt.Sprintf("gitlabUser model doesn't have enough information to fetch the employee signatures for user : %s", userModel.UserID)
-		log.WithFields(f).Errorf("%s", msg)
-		return false, fmt.Errorf("%s", msg)
-	}
-
-	if !s.IsUserApprovedForSignature(ctx, f, corporateSignature, userModel, gitlabUser) {
-		log.WithFields(f).Debugf("user is not approved in signature : %s", corporateSignature.SignatureID)
-		return false, fmt.Errorf("user is not approved in signature : %s", corporateSignature.SignatureID)
-	}
-
-	employeeSignatures, err := s.signaturesRepository.GetProjectCompanyEmployeeSignatures(ctx, signatures1.GetProjectCompanyEmployeeSignaturesParams{
-		CompanyID: companyID,
-		ProjectID: claGroupID,
-		PageSize:  utils.Int64(100),
-	}, approvalCriteria)
-
-	if err != nil {
-		msg := fmt.Sprintf("can't load employee signature records : %s for user : %s association : %v", companyID, userModel.UserID, err)
-		log.WithFields(f).Errorf("%s", msg)
-		return false, fmt.Errorf("%s", msg)
-	}
-
-	if len(employeeSignatures.Signatures) == 0 {
-		msg := fmt.Sprintf("no employee signature records found for company : %s user : %s association", companyID, userModel.UserID)
-		log.WithFields(f).Errorf("%s", msg)
-		return false, fmt.Errorf("%s", msg)
-	}
-
-	log.WithFields(f).Warnf("is in signature approval list : %s and has employee signature", corporateSignature.SignatureID)
-	return true, nil
-}
-
-// findUserModelForGitlabUser locates the user model in our users table for the given GitLab user (by GitLab ID, GitLab username, or email)
-func (s *service) findUserModelForGitlabUser(f logrus.Fields, gitlabUser *gitlab.User) ([]*models.User, error) {
-
 	if gitlabUser.ID != 0 {
-		log.WithFields(f).Debugf("Looking up GitLab user via ID: %d", gitlabUser.ID)
-		userModel, lookupErr := s.usersRepository.GetUserByGitlabID(gitlabUser.ID)
-		if lookupErr != nil {
-			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab ID : %d", gitlabUser.ID)
-		} else if userModel != nil {
-			log.WithFields(f).Debugf("located GitLab user via ID: %d", gitlabUser.ID)
-			return []*models.User{userModel}, nil
-		}
-	}
-
-	if gitlabUser.Username != "" {
-		log.WithFields(f).Debugf("Looking up GitLab user via username: %s", gitlabUser.Username)
-		userModel, lookupErr := s.usersRepository.GetUserByGitLabUsername(gitlabUser.Username)
-		if lookupErr != nil {
-			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab username : %s", gitlabUser.Username)
-		} else if userModel != nil {
-			log.WithFields(f).Debugf("located GitLab user via username: %s", gitlabUser.Username)
-			return []*models.User{userModel}, nil
-		}
-	}
-
-	if gitlabUser.Email != "" {
-		gitlabUsers := make([]*models.User, 0)
-		log.WithFields(f).Debugf("Looking up GitLab user via user email: %s", gitlabUser.Email)
-		// previously search was done by lf_email, now we are searching by email #3816
-		users, lookupErr := s.usersRepository.GetUsersByEmail(gitlabUser.Email)
-		if lookupErr != nil {
-			log.WithFields(f).WithError(lookupErr).Warnf("problem locating GitLab user via GitLab username : %s", gitlabUser.Username)
-		} else if len(users) > 0 {
-			log.WithFields(f).Debugf("located GitLab user via email: %s", gitlabUser.Email)
-			gitlabUsers = append(gitlabUsers, users...)
-			return gitlabUsers, nil
-		}
+		identity.ProviderID = strconv.Itoa(gitlabUser.ID)
 	}
-
-	// Didn't find it
-	return nil, nil
-}
-
-func (s *service) IsUserApprovedForSignature(ctx context.Context, f logrus.Fields, corporateSignature *models.Signature, user *models.User, gitlabUser *gitlab.User) bool {
-	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s", user.UserID, corporateSignature.SignatureID)
-	userEmails := user.Emails
-	if string(user.LfEmail) != "" {
-		userEmails = append(userEmails, string(user.LfEmail))
-	}
-
-	emailApprovalList := corporateSignature.EmailApprovalList
-	domainApprovalList := corporateSignature.DomainApprovalList
-	log.WithFields(f).Debugf("checking if user : %s is approved for corporate signature : %s, email approval list : %+v", user.UserID, corporateSignature.SignatureID, emailApprovalList)
-
-	if len(userEmails) > 0 && len(emailApprovalList) > 0 {
-		for _, email := range userEmails {
-			for _, approvalEmail := range emailApprovalList {
-				if email == approvalEmail {
-					log.WithFields(f).Debugf("found user email : %s in email approval list ", email)
-					return true
-				}
-			}
-		}
-	} else {
-		log.WithFields(f).Warnf("no match for user in signature email approval list")
-	}
-
-	if len(domainApprovalList) > 0 && len(userEmails) > 0 {
-		log.WithFields(f).Debugf("checking if emails : %+v are approved for corporate signature : %s, domain approval list : %+v", userEmails, corporateSignature.SignatureID, domainApprovalList)
-		for _, userEmail := range userEmails {
-			for _, domainApprovalPattern := range domainApprovalList {
-				if strings.HasPrefix(domainApprovalPattern, "*.") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, "*.", ".*", 1)
-				} else if strings.HasPrefix(domainApprovalPattern, "*") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, "*", ".*", 1)
-				} else if strings.HasPrefix(domainApprovalPattern, ".") {
-					domainApprovalPattern = strings.Replace(domainApprovalPattern, ".", ".*", 1)
-				}
-				regexpApprovalPattern := "^.*@" + domainApprovalPattern + "$"
-				if ok, err := regexp.MatchString(regexpApprovalPattern, userEmail); ok && err == nil {
-					log.WithFields(f).Debugf("found user email : %s in email approval list : %s", userEmail, domainApprovalPattern)
-					return true
-				}
-			}
-		}
-	}
-
-	gitlabUserName := gitlabUser.Username
-	gitlabUsernameApprovalList := corporateSignature.GitlabUsernameApprovalList
-	if gitlabUserName != "" && len(gitlabUsernameApprovalList) > 0 {
-		log.WithFields(f).Debugf("checking gitlab username : %s for gitlab approval list : %+v", gitlabUserName, gitlabUsernameApprovalList)
-		for _, gitlabApproval := range gitlabUsernameApprovalList {
-			if gitlabApproval == gitlabUserName {
-				log.WithFields(f).Debugf("found gitlab username : %s in gitlab approval list ", gitlabUserName)
-				return true
-			}
-		}
-
-	} else {
-		log.WithFields(f).Warnf("no match found for gitlabUser : %s in gitlab approval list : %+v", gitlabUserName, gitlabUsernameApprovalList)
-	}
-
-	gitlabGroupApprovalList := corporateSignature.GitlabOrgApprovalList
-	if gitlabUserName != "" && len(gitlabGroupApprovalList) > 0 {
-		log.WithFields(f).Debugf("checking gitlab username : %s for gitlab org approval list : %+v ", gitlabUserName, gitlabGroupApprovalList)
-
-		for _, gitlabGroupApproval := range gitlabGroupApprovalList {
-			isApproved, err := s.checkGitLabGroupApproval(ctx, gitlabUserName, gitlabGroupApproval)
-			if err != nil {
-				log.WithFields(f).WithError(err).Warn("unable to get username")
-				break
-			}
-			if isApproved == true {
-				log.WithFields(f).Debugf(" found gitlab username : %s in gitlab org approval list : %+v", gitlabUserName, gitlabGroupApprovalList)
-				return true
-			}
-		}
-	}
-
-	log.WithFields(f).Errorf("unable to find user in any approval list")
-	return false
-
+	return identity
 }
 
 /**
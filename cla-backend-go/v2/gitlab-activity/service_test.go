@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
 	"github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 	"github.com/xanzy/go-gitlab"
@@ -117,11 +118,14 @@ func TestPrepareMrCommentContent(t *testing.T) {
 		missingUserContains := ":x: The commit associated with %s is missing the User's ID"
 		missingAffiliationContains := "%s is authorized, but they must confirm their affiliation"
 		missingApprovalContains := "%s's commit is not authorized under a signed CLA"
+		reportedCoAuthorContains := ":warning: %s. This co-author is not authorized under a signed CLA"
+		exemptBotContains := ":robot: %s. This commit author is exempt from the CLA check"
 
 		testCases := []struct {
 			name          string
 			signed        []*gitlab.User
 			missing       []*gatedGitlabUser
+			exempt        []*exemptGitlabUser
 			expectedMsgs  []string
 			expectedBadge string
 		}{
@@ -167,11 +171,41 @@ func TestPrepareMrCommentContent(t *testing.T) {
 				expectedMsgs:  []string{signedContains, missingApprovalContains},
 				expectedBadge: "cla-not-signed.svg",
 			},
+			{
+				name: "reported co-author",
+				signed: []*gitlab.User{
+					{ID: 1, Username: "neo"},
+				},
+				missing: []*gatedGitlabUser{
+					{err: missingID, User: &gitlab.User{Name: "trinity", Email: "trinity@example.com"}, optional: true},
+				},
+				expectedMsgs:  []string{signedContains, reportedCoAuthorContains},
+				expectedBadge: "cla-signed.svg",
+			},
+			{
+				name: "exempt bot",
+				exempt: []*exemptGitlabUser{
+					{User: &gitlab.User{ID: 6, Username: "renovate-bot"}, allowlistEntry: "renovate-bot"},
+				},
+				expectedMsgs:  []string{exemptBotContains},
+				expectedBadge: "cla-signed.svg",
+			},
+			{
+				name: "exempt bot and missing approval",
+				missing: []*gatedGitlabUser{
+					{err: missingCompanyApproval, User: &gitlab.User{ID: 5, Username: "approvalUser"}},
+				},
+				exempt: []*exemptGitlabUser{
+					{User: &gitlab.User{Name: "dependabot", Email: "bot@dependabot.com"}, allowlistEntry: "*@dependabot.com"},
+				},
+				expectedMsgs:  []string{exemptBotContains, missingApprovalContains},
+				expectedBadge: "cla-not-signed.svg",
+			},
 		}
 
 		for _, tc := range testCases {
 			t.Run(tc.name, func(tt *testing.T) {
-				result := PrepareMrCommentContent(tc.missing, tc.signed, "https://sign.com")
+				result := PrepareMrCommentContent(tc.missing, tc.signed, tc.exempt, "https://sign.com")
 				tt.Logf("the result is : %s", result)
 				parts := strings.Split(result, "<li>")
 				assert.Len(tt, parts, len(tc.expectedMsgs)+1)
@@ -184,6 +218,10 @@ func TestPrepareMrCommentContent(t *testing.T) {
 					}
 				}
 
+				for _, e := range tc.exempt {
+					allUsers = append(allUsers, e.User)
+				}
+
 				if len(tc.missing) > 0 {
 					for _, m := range tc.missing {
 						allUsers = append(allUsers, m.User)
@@ -201,3 +239,20 @@ func TestPrepareMrCommentContent(t *testing.T) {
 	}
 
 }
+
+func TestSplitExemptGitlabUsers(t *testing.T) {
+	allowlist := &utils.BotAllowlist{
+		Logins:        []string{"renovate-bot"},
+		EmailPatterns: []string{"*@dependabot.com"},
+	}
+	renovate := &gitlab.User{ID: 6, Username: "renovate-bot"}
+	// the commit email is set by the committer, it never exempts a user
+	spoofed := &gitlab.User{ID: 7, Username: "mallory", Email: "bot@dependabot.com"}
+	unresolved := &gitlab.User{Username: "renovate-bot", Email: "bot@dependabot.com"}
+
+	checked, exempt := splitExemptGitlabUsers([]*gitlab.User{renovate, spoofed, unresolved}, allowlist)
+	assert.Equal(t, []*gitlab.User{spoofed, unresolved}, checked)
+	assert.Len(t, exempt, 1)
+	assert.Equal(t, renovate, exempt[0].User)
+	assert.Equal(t, "renovate-bot", exempt[0].allowlistEntry)
+}
@@ -38,4 +38,8 @@ const (
 	GitLabOrganizationsExternalGitLabGroupIDColumn = "external_gitlab_group_id"
 	// GitLabOrganizationsAuthExpiryTimeColumn constant
 	GitLabOrganizationsAuthExpiryTimeColumn = "auth_expiry_time"
+	// GitLabOrganizationsBotLoginAllowlistColumn constant
+	GitLabOrganizationsBotLoginAllowlistColumn = "bot_login_allowlist"
+	// GitLabOrganizationsBotEmailAllowlistColumn constant
+	GitLabOrganizationsBotEmailAllowlistColumn = "bot_email_allowlist"
 )
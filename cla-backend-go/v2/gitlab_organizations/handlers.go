@@ -310,6 +310,7 @@ func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventServic
 			BranchProtectionEnabled: params.Body.BranchProtectionEnabled,
 			ExternalGroupID:         params.GitLabGroupID,
 			Enabled:                 true,
+			BotAllowlist:            params.Body.BotAllowlist,
 		}
 
 		if parentProjectModel != nil {
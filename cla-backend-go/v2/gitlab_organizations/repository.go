@@ -650,6 +650,15 @@ func (repo *Repository) UpdateGitLabOrganization(ctx context.Context, input *com
 		updateExpression = fmt.Sprintf("%s, #NL = :nl ", updateExpression)
 	}
 
+	// An empty allowlist clears the exempt bots, no allowlist leaves the stored one unchanged
+	if input.BotAllowlist != nil {
+		expressionAttributeNames["#BLA"] = aws.String(GitLabOrganizationsBotLoginAllowlistColumn)
+		expressionAttributeValues[":bla"] = utils.StringListAttribute(utils.RemoveDuplicates(input.BotAllowlist.Logins))
+		expressionAttributeNames["#BEA"] = aws.String(GitLabOrganizationsBotEmailAllowlistColumn)
+		expressionAttributeValues[":bea"] = utils.StringListAttribute(utils.RemoveDuplicates(input.BotAllowlist.EmailPatterns))
+		updateExpression = fmt.Sprintf("%s, #BLA = :bla, #BEA = :bea ", updateExpression)
+	}
+
 	updateItemInput := &dynamodb.UpdateItemInput{
 		Key: map[string]*dynamodb.AttributeValue{
 			GitLabOrganizationsOrganizationIDColumn: {
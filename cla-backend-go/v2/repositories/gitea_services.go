@@ -0,0 +1,146 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package repositories
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v2Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	repoModels "github.com/linuxfoundation/easycla/cla-backend-go/repositories"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// GiteaAddRepositories registers the repositories of the Gitea organization, the repositories already registered are
+// left as is. Returns the complete repository list of the organization.
+func (s *Service) GiteaAddRepositories(ctx context.Context, input *GiteaAddRepoModel) (*v2Models.GiteaRepositoriesList, error) {
+	f := logrus.Fields{
+		"functionName":     "v2.repositories.gitea_services.GiteaAddRepositories",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"instanceURL":      input.InstanceURL,
+		"organizationName": input.OrganizationName,
+		"projectSFID":      input.ProjectSFID,
+		"claGroupID":       input.ClaGroupID,
+		"enabled":          input.Enabled,
+	}
+
+	for _, giteaRepo := range input.Repositories {
+		existing, err := s.gitV2Repository.GiteaGetRepositoryByExternalID(ctx, input.InstanceURL, giteaRepo.ID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem loading Gitea repository: %s", giteaRepo.FullName)
+			return nil, err
+		}
+		if existing != nil {
+			log.WithFields(f).Debugf("gitea repository: %s already registered", giteaRepo.FullName)
+			continue
+		}
+
+		inputDBModel := &repoModels.RepositoryDBModel{
+			RepositorySfdcID:           input.ProjectSFID,
+			ProjectSFID:                input.ProjectSFID,
+			RepositoryExternalID:       strconv.FormatInt(giteaRepo.ID, 10),
+			RepositoryName:             giteaRepo.FullName, // Name column is the full name, as with GitHub and GitLab
+			RepositoryFullPath:         giteaRepo.FullName,
+			RepositoryURL:              giteaRepo.HTMLURL,
+			RepositoryOrganizationName: input.OrganizationName,
+			RepositoryCLAGroupID:       input.ClaGroupID,
+			RepositoryType:             utils.GiteaLower,
+			Enabled:                    input.Enabled,
+		}
+		if _, err := s.gitV2Repository.GiteaAddRepository(ctx, input.ProjectSFID, input.InstanceURL, inputDBModel); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem adding Gitea repository: %s", giteaRepo.FullName)
+			return nil, err
+		}
+
+		// Log the event
+		s.eventService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.RepositoryAdded,
+			ProjectSFID: input.ProjectSFID,
+			CLAGroupID:  input.ClaGroupID,
+			LfUsername:  utils.GetUserNameFromContext(ctx),
+			EventData: &events.RepositoryAddedEventData{
+				RepositoryName: giteaRepo.FullName,
+			},
+		})
+	}
+
+	return s.GiteaGetRepositoriesByOrganizationName(ctx, input.InstanceURL, input.OrganizationName)
+}
+
+// GiteaGetRepositoriesByOrganizationName returns the repositories of the Gitea organization
+func (s *Service) GiteaGetRepositoriesByOrganizationName(ctx context.Context, instanceURL, orgName string) (*v2Models.GiteaRepositoriesList, error) {
+	dbModels, err := s.gitV2Repository.GiteaGetRepositoriesByOrganizationName(ctx, instanceURL, orgName)
+	if err != nil {
+		return nil, err
+	}
+
+	responses, err := dbModelsToGiteaRepositories(dbModels)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v2Models.GiteaRepositoriesList{
+		List: responses,
+	}, nil
+}
+
+// GiteaGetRepositoryByExternalID returns the Gitea repository of the instance by its Gitea ID, returns nil if the
+// repository is not registered
+func (s *Service) GiteaGetRepositoryByExternalID(ctx context.Context, instanceURL string, repositoryExternalID int64) (*v2Models.GiteaRepository, error) {
+	dbModel, err := s.gitV2Repository.GiteaGetRepositoryByExternalID(ctx, instanceURL, repositoryExternalID)
+	if err != nil || dbModel == nil {
+		return nil, err
+	}
+	return dbModelToGiteaRepository(dbModel)
+}
+
+// GiteaEnrollRepository enrolls or unenrolls the Gitea repository for the CLA Group
+func (s *Service) GiteaEnrollRepository(ctx context.Context, repositoryID, claGroupID string, enrollValue bool) error {
+	return s.gitV2Repository.GiteaEnrollRepository(ctx, repositoryID, claGroupID, enrollValue)
+}
+
+// GiteaDeleteRepositories deletes the repositories of the Gitea organization
+func (s *Service) GiteaDeleteRepositories(ctx context.Context, instanceURL, orgName string) error {
+	return s.gitV2Repository.GiteaDeleteRepositories(ctx, instanceURL, orgName)
+}
+
+// dbModelToGiteaRepository converts the database model to a v2 response model
+func dbModelToGiteaRepository(dbModel *repoModels.RepositoryDBModel) (*v2Models.GiteaRepository, error) {
+	giteaExternalID, err := strconv.ParseInt(dbModel.RepositoryExternalID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	return &v2Models.GiteaRepository{
+		RepositoryID:               dbModel.RepositoryID,
+		RepositoryProjectSfid:      dbModel.ProjectSFID,
+		RepositoryClaGroupID:       dbModel.RepositoryCLAGroupID,
+		RepositoryExternalID:       giteaExternalID,
+		RepositoryName:             dbModel.RepositoryName,
+		RepositoryOrganizationName: dbModel.RepositoryOrganizationName,
+		RepositoryURL:              dbModel.RepositoryURL,
+		RepositoryType:             dbModel.RepositoryType,
+		Enabled:                    dbModel.Enabled,
+		DateCreated:                dbModel.DateCreated,
+		DateModified:               dbModel.DateModified,
+		Note:                       dbModel.Note,
+		Version:                    dbModel.Version,
+	}, nil
+}
+
+// dbModelsToGiteaRepositories converts the slice of database models to a slice of v2 response model
+func dbModelsToGiteaRepositories(dbModels []*repoModels.RepositoryDBModel) ([]*v2Models.GiteaRepository, error) {
+	responses := make([]*v2Models.GiteaRepository, 0, len(dbModels))
+	for _, dbModel := range dbModels {
+		response, err := dbModelToGiteaRepository(dbModel)
+		if err != nil {
+			return nil, err
+		}
+		responses = append(responses, response)
+	}
+	return responses, nil
+}
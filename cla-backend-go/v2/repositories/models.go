@@ -3,6 +3,10 @@
 
 package repositories
 
+import (
+	gitea "github.com/linuxfoundation/easycla/cla-backend-go/gitea_api"
+)
+
 // GitLabAddRepoModel data model for GitLab add repository
 type GitLabAddRepoModel struct {
 	ClaGroupID    string
@@ -11,3 +15,13 @@ type GitLabAddRepoModel struct {
 	GroupFullPath string
 	ProjectIDList []int64
 }
+
+// GiteaAddRepoModel data model for Gitea add repository
+type GiteaAddRepoModel struct {
+	InstanceURL      string
+	OrganizationName string
+	ProjectSFID      string
+	ClaGroupID       string
+	Repositories     []*gitea.Repository
+	Enabled          bool
+}
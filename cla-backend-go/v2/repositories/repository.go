@@ -39,6 +39,12 @@ type RepositoryInterface interface {
 	GitLabEnableCLAGroupRepositories(ctx context.Context, claGroupID string, enrollValue bool) error
 	GitLabDeleteRepositories(ctx context.Context, gitLabGroupPath string) error
 	GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLabExternalID int64) error
+
+	GiteaGetRepositoryByExternalID(ctx context.Context, instanceURL string, repositoryExternalID int64) (*repoModels.RepositoryDBModel, error)
+	GiteaGetRepositoriesByOrganizationName(ctx context.Context, instanceURL, orgName string) ([]*repoModels.RepositoryDBModel, error)
+	GiteaAddRepository(ctx context.Context, projectSFID, instanceURL string, input *repoModels.RepositoryDBModel) (*repoModels.RepositoryDBModel, error)
+	GiteaEnrollRepository(ctx context.Context, repositoryID, claGroupID string, enrollValue bool) error
+	GiteaDeleteRepositories(ctx context.Context, instanceURL, orgName string) error
 }
 
 // Repository object/struct
@@ -497,6 +503,160 @@ func (r *Repository) GitLabDeleteRepositoryByExternalID(ctx context.Context, git
 	return deleteErr
 }
 
+// GiteaGetRepositoryByExternalID returns the database model of the Gitea repository, the external IDs are only unique
+// within a Gitea instance so the record is matched against the instance URL. Returns nil if not found.
+func (r *Repository) GiteaGetRepositoryByExternalID(ctx context.Context, instanceURL string, repositoryExternalID int64) (*repoModels.RepositoryDBModel, error) {
+	condition := expression.Key(repoModels.RepositoryExternalIDColumn).Equal(expression.Value(strconv.FormatInt(repositoryExternalID, 10)))
+	filter := expression.Name(repoModels.RepositoryTypeColumn).Equal(expression.Value(utils.GiteaLower))
+	records, err := r.getRepositoriesWithConditionFilter(ctx, condition, filter, repoModels.RepositoryExternalIDIndex)
+	if err != nil {
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); ok {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	for _, record := range records {
+		if isGiteaInstanceRepository(record, instanceURL) {
+			return record, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GiteaGetRepositoriesByOrganizationName returns the Gitea repositories of the organization on the Gitea instance
+func (r *Repository) GiteaGetRepositoriesByOrganizationName(ctx context.Context, instanceURL, orgName string) ([]*repoModels.RepositoryDBModel, error) {
+	condition := expression.Key(repoModels.RepositoryOrganizationNameColumn).Equal(expression.Value(orgName))
+	filter := expression.Name(repoModels.RepositoryTypeColumn).Equal(expression.Value(utils.GiteaLower))
+	records, err := r.getRepositoriesWithConditionFilter(ctx, condition, filter, repoModels.RepositoryOrganizationNameIndex)
+	if err != nil {
+		if _, ok := err.(*utils.GitLabRepositoryNotFound); ok {
+			return []*repoModels.RepositoryDBModel{}, nil
+		}
+		return nil, err
+	}
+
+	results := make([]*repoModels.RepositoryDBModel, 0, len(records))
+	for _, record := range records {
+		if isGiteaInstanceRepository(record, instanceURL) {
+			results = append(results, record)
+		}
+	}
+
+	return results, nil
+}
+
+// GiteaAddRepository creates a new entry in the repositories table for the Gitea repository, the existing entry is
+// returned when the repository is already registered
+func (r *Repository) GiteaAddRepository(ctx context.Context, projectSFID, instanceURL string, input *repoModels.RepositoryDBModel) (*repoModels.RepositoryDBModel, error) {
+	f := logrus.Fields{
+		"functionName":               "v2.repositories.repository.GiteaAddRepository",
+		utils.XREQUESTID:             ctx.Value(utils.XREQUESTID),
+		"projectSFID":                projectSFID,
+		"instanceURL":                instanceURL,
+		"repositoryExternalID":       input.RepositoryExternalID,
+		"repositoryURL":              input.RepositoryURL,
+		"repositoryName":             input.RepositoryName,
+		"repositoryCLAGroupID":       input.RepositoryCLAGroupID,
+		"repositoryOrganizationName": input.RepositoryOrganizationName,
+	}
+
+	externalID, err := strconv.ParseInt(input.RepositoryExternalID, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	existing, err := r.GiteaGetRepositoryByExternalID(ctx, instanceURL, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		log.WithFields(f).Debugf("gitea repository already registered with ID: %s", existing.RepositoryID)
+		return existing, nil
+	}
+
+	_, currentTime := utils.CurrentTime()
+	repoID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+
+	input.RepositoryID = repoID.String()
+	input.RepositoryType = utils.GiteaLower
+	input.DateCreated = currentTime
+	input.DateModified = currentTime
+	input.Note = fmt.Sprintf("created on %s", currentTime)
+	input.Version = "v1"
+
+	av, err := dynamodbattribute.MarshalMap(input)
+	if err != nil {
+		log.WithFields(f).Warnf("problem marshalling the input, error: %+v", err)
+		return nil, err
+	}
+
+	_, err = r.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(r.repositoryTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to add gitea repository")
+		return nil, err
+	}
+
+	return input, nil
+}
+
+// GiteaEnrollRepository enables or disables the Gitea repository for the CLA Group
+func (r *Repository) GiteaEnrollRepository(ctx context.Context, repositoryID, claGroupID string, enrollValue bool) error {
+	existingModel, err := r.GitLabGetRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if existingModel.RepositoryType != utils.GiteaLower {
+		return &utils.InvalidRepositoryTypeError{
+			RepositoryType: existingModel.RepositoryType,
+		}
+	}
+
+	return r.updateRepositoryEnabledValue(ctx, existingModel, claGroupID, enrollValue)
+}
+
+// GiteaDeleteRepositories deletes the repositories of the Gitea organization
+func (r *Repository) GiteaDeleteRepositories(ctx context.Context, instanceURL, orgName string) error {
+	f := logrus.Fields{
+		"functionName":     "v2.repositories.repository.GiteaDeleteRepositories",
+		utils.XREQUESTID:   ctx.Value(utils.XREQUESTID),
+		"instanceURL":      instanceURL,
+		"organizationName": orgName,
+	}
+
+	repositories, err := r.GiteaGetRepositoriesByOrganizationName(ctx, instanceURL, orgName)
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	for _, repo := range repositories {
+		_, err := r.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+			Key: map[string]*dynamodb.AttributeValue{
+				repoModels.RepositoryIDColumn: {S: aws.String(repo.RepositoryID)},
+			},
+			TableName: aws.String(r.repositoryTableName),
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("error deleting repository with ID:%s", repo.RepositoryID)
+			lastErr = err
+		}
+	}
+
+	return lastErr
+}
+
+// isGiteaInstanceRepository returns true if the repository record belongs to the Gitea instance
+func isGiteaInstanceRepository(record *repoModels.RepositoryDBModel, instanceURL string) bool {
+	return strings.HasPrefix(record.RepositoryURL, strings.TrimSuffix(instanceURL, "/")+"/")
+}
+
 // getRepositoryWithConditionFilter fetches the repository entry based on the specified condition and filter criteria using the provided index
 func (r *Repository) getRepositoryWithConditionFilter(ctx context.Context, condition expression.KeyConditionBuilder, filter expression.ConditionBuilder, indexName string) (*repoModels.RepositoryDBModel, error) {
 	f := logrus.Fields{
@@ -608,12 +768,26 @@ func (r *Repository) setRepositoryEnabledValue(ctx context.Context, claGroupID s
 	// Load the existing model - need to fetch the old values, if available
 	existingModel, getErr := r.GitLabGetRepositoryByExternalID(ctx, repositoryExternalID)
 	if getErr != nil {
+		log.WithFields(f).WithError(getErr).Warnf("unable to load repository by external ID: %d", repositoryExternalID)
 		return getErr
 	}
 	if existingModel == nil {
 		return fmt.Errorf("unable to locate existing repository entry by external ID: %d", repositoryExternalID)
 	}
 
+	return r.updateRepositoryEnabledValue(ctx, existingModel, claGroupID, enabledValue)
+}
+
+// updateRepositoryEnabledValue sets the enabled value of the repository record, the CLA Group is updated when specified
+func (r *Repository) updateRepositoryEnabledValue(ctx context.Context, existingModel *repoModels.RepositoryDBModel, claGroupID string, enabledValue bool) error {
+	f := logrus.Fields{
+		"functionName":   "v2.repositories.repository.updateRepositoryEnabledValue",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"repositoryID":   existingModel.RepositoryID,
+		"claGroupID":     claGroupID,
+		"enabledValue":   enabledValue,
+	}
+
 	// If we have an old note - grab it/save it
 	var existingNote = ""
 	if existingModel.Note != "" {
@@ -69,6 +69,14 @@ type ServiceInterface interface {
 	GitLabEnrollCLAGroupRepositories(ctx context.Context, claGroupID string, enrollValue bool) error
 	GitLabDeleteRepositories(ctx context.Context, gitLabGroupPath string) error
 	GitLabDeleteRepositoryByExternalID(ctx context.Context, gitLabExternalID int64) error
+
+	// Gitea
+
+	GiteaAddRepositories(ctx context.Context, input *GiteaAddRepoModel) (*v2Models.GiteaRepositoriesList, error)
+	GiteaGetRepositoriesByOrganizationName(ctx context.Context, instanceURL, orgName string) (*v2Models.GiteaRepositoriesList, error)
+	GiteaGetRepositoryByExternalID(ctx context.Context, instanceURL string, repositoryExternalID int64) (*v2Models.GiteaRepository, error)
+	GiteaEnrollRepository(ctx context.Context, repositoryID, claGroupID string, enrollValue bool) error
+	GiteaDeleteRepositories(ctx context.Context, instanceURL, orgName string) error
 }
 
 // GitLabOrgRepo redefine the interface here to avoid circular dependency issues
@@ -437,7 +445,7 @@ func (s *Service) GitHubGetProtectedBranch(ctx context.Context, projectSFID, rep
 		BranchName: &branchName,
 	}
 
-	branchProtection, err := branchProtectionRepository.GetProtectedBranch(ctx, owner, githubRepoName, branchName)
+	branchProtection, err := branchProtectionRepository.GetBranchProtection(ctx, owner, githubRepoName, branchName)
 	if err != nil {
 		if errors.Is(err, branch_protection.ErrBranchNotProtected) {
 			return result, nil
@@ -447,12 +455,21 @@ func (s *Service) GitHubGetProtectedBranch(ctx context.Context, projectSFID, rep
 	}
 
 	result.ProtectionEnabled = true
-	if branch_protection.IsEnforceAdminEnabled(branchProtection) {
+	result.ProtectionMechanism = branchProtection.Mechanism
+	if branch_protection.IsEnforceAdminEnabled(branchProtection.Rule) {
 		result.EnforceAdmin = true
 	}
+	for _, ruleset := range branchProtection.Rulesets {
+		result.Rulesets = append(result.Rulesets, &v2Models.GithubRepositoryBranchProtectionRuleset{
+			ID:         ruleset.ID,
+			Name:       ruleset.Name,
+			SourceType: ruleset.SourceType,
+			Source:     ruleset.Source,
+		})
+	}
 
 	requiredChecks := requiredBranchProtectionChecks
-	requiredChecksResult := s.getRequiredProtectedBranchCheckStatus(branchProtection, requiredChecks)
+	requiredChecksResult := s.getRequiredProtectedBranchCheckStatus(branchProtection.Rule, requiredChecks)
 	result.StatusChecks = requiredChecksResult
 
 	return result, nil
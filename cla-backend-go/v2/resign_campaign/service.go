@@ -0,0 +1,389 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaign
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/project"
+	v1Signatures "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// claGroupPageSize is the number of CLA Groups loaded per scan
+	claGroupPageSize = int64(100)
+	// signaturePageSize is the number of signatures loaded per query
+	signaturePageSize = int64(1000)
+	// signatureResignVersionColumn records the major document version the signers were notified about
+	signatureResignVersionColumn = "signature_resign_version"
+	// signatureAccessRemovedVersionColumn records the major document version the Gerrit group access was removed for
+	signatureAccessRemovedVersionColumn = "signature_access_removed_version"
+	// accessRemovedBy is the user name recorded in the gerrit user removed events
+	accessRemovedBy = "EasyCLA signature version policy"
+)
+
+// Service contains the re-sign campaign methods
+type Service interface {
+	NotifyOutdatedSignatures(ctx context.Context) error
+}
+
+// GerritGroupService removes users from the LF LDAP groups granting access to the CLA Group Gerrit instances
+type GerritGroupService interface {
+	RemoveUserFromGroup(ctx context.Context, authUser *auth.User, claGroupID, groupName, userName string) error
+}
+
+type service struct {
+	claGroupRepo  repository.ProjectRepository
+	signatureRepo signatures.SignatureRepository
+	usersRepo     users.UserRepository
+	gerritService gerrits.Service
+	lfGroup       GerritGroupService
+}
+
+// recipient is a single re-sign campaign email recipient
+type recipient struct {
+	name  string
+	email string
+}
+
+// NewService creates a new re-sign campaign service, the Gerrit group access isn't removed when gerritService or
+// lfGroup is nil
+func NewService(claGroupRepo repository.ProjectRepository, signatureRepo signatures.SignatureRepository, usersRepo users.UserRepository,
+	gerritService gerrits.Service, lfGroup GerritGroupService) Service {
+	return &service{
+		claGroupRepo:  claGroupRepo,
+		signatureRepo: signatureRepo,
+		usersRepo:     usersRepo,
+		gerritService: gerritService,
+		lfGroup:       lfGroup,
+	}
+}
+
+// NotifyOutdatedSignatures emails the signers and CLA Managers of signatures made against a previous major document
+// version for every CLA Group with a resign or expire signature version policy. Each signature is notified once per
+// major document version. Once the policy no longer honors a signature, its signer or acknowledged employees are
+// removed from the CLA Group Gerrit LDAP groups.
+func (s *service) NotifyOutdatedSignatures(ctx context.Context) error {
+	f := logrus.Fields{
+		"functionName":   "v2.resign_campaign.service.NotifyOutdatedSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	var nextKey string
+	for {
+		claGroups, err := s.claGroupRepo.GetCLAGroups(ctx, &project.GetProjectsParams{
+			NextKey:  aws.String(nextKey),
+			PageSize: aws.Int64(claGroupPageSize),
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load CLA Groups")
+			return err
+		}
+
+		for i := range claGroups.Projects {
+			claGroup := &claGroups.Projects[i]
+			if claGroup.SignatureVersionPolicy != common.SignatureVersionPolicyResign && claGroup.SignatureVersionPolicy != common.SignatureVersionPolicyExpire {
+				continue
+			}
+			if claGroup.ProjectICLAEnabled {
+				s.notifyCLAGroupSignatures(ctx, claGroup, utils.ClaTypeICLA)
+			}
+			if claGroup.ProjectCCLAEnabled {
+				s.notifyCLAGroupSignatures(ctx, claGroup, utils.ClaTypeCCLA)
+			}
+		}
+
+		if claGroups.LastKeyScanned == "" {
+			break
+		}
+		nextKey = claGroups.LastKeyScanned
+	}
+
+	return nil
+}
+
+// notifyCLAGroupSignatures notifies the outdated signatures of the specified CLA type for the CLA Group
+func (s *service) notifyCLAGroupSignatures(ctx context.Context, claGroup *models.ClaGroup, claType string) {
+	f := logrus.Fields{
+		"functionName":           "v2.resign_campaign.service.notifyCLAGroupSignatures",
+		utils.XREQUESTID:         ctx.Value(utils.XREQUESTID),
+		"claGroupID":             claGroup.ProjectID,
+		"claGroupName":           claGroup.ProjectName,
+		"claType":                claType,
+		"signatureVersionPolicy": claGroup.SignatureVersionPolicy,
+	}
+
+	now := time.Now()
+	var nextKey *string
+	notified, removed := 0, 0
+	for {
+		sigs, err := s.signatureRepo.GetProjectSignatures(ctx, v1Signatures.GetProjectSignaturesParams{
+			ClaType:   aws.String(claType),
+			ProjectID: claGroup.ProjectID,
+			Approved:  aws.Bool(true),
+			Signed:    aws.Bool(true),
+			PageSize:  aws.Int64(signaturePageSize),
+			NextKey:   nextKey,
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to load CLA Group signatures - skipping")
+			return
+		}
+
+		for _, sig := range sigs.Signatures {
+			if s.notifySignature(ctx, claGroup, sig) {
+				notified++
+			}
+			if s.removeOutdatedGerritAccess(ctx, claGroup, sig, now) {
+				removed++
+			}
+		}
+
+		if sigs.LastKeyScanned == "" {
+			break
+		}
+		nextKey = aws.String(sigs.LastKeyScanned)
+	}
+
+	log.WithFields(f).Infof("notified %d outdated signatures, removed the gerrit group access of %d signatures", notified, removed)
+}
+
+// notifySignature emails the recipients of the signature if it was made against a previous major document version
+// and the recipients haven't been notified about the current major version yet, returns true if the email was sent
+func (s *service) notifySignature(ctx context.Context, claGroup *models.ClaGroup, sig *models.Signature) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.resign_campaign.service.notifySignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+		"signatureID":    sig.SignatureID,
+	}
+
+	outdated, currentMajor := common.IsSignatureDocumentOutdated(ctx, claGroup, sig)
+	if !outdated {
+		return false
+	}
+	currentVersion := strconv.Itoa(currentMajor)
+	if sig.SignatureResignVersion == currentVersion {
+		return false
+	}
+
+	recipients := s.getRecipients(ctx, sig)
+	if len(recipients) == 0 {
+		log.WithFields(f).Warn("unable to determine any recipients for the outdated signature - skipping")
+		return false
+	}
+
+	// The signature is marked as notified once any recipient got the email, the others aren't retried so the
+	// recipients who were emailed don't get the email again on the next run
+	subject := fmt.Sprintf("EasyCLA: Action Required - New CLA Version for %s", claGroup.ProjectName)
+	sent := false
+	for _, r := range recipients {
+		body := resignEmailBody(claGroup, sig, r.name, currentVersion)
+		if err := utils.SendEmail(subject, body, []string{r.email}); err != nil {
+			log.WithFields(f).WithError(err).Warnf("problem sending email with subject: %s to recipient: %s", subject, r.email)
+			continue
+		}
+		sent = true
+		log.WithFields(f).Debugf("sent email with subject: %s to recipient: %s", subject, r.email)
+	}
+	if !sent {
+		log.WithFields(f).Warn("unable to notify any recipient of the outdated signature")
+		return false
+	}
+
+	err := s.signatureRepo.UpdateSignature(ctx, sig.SignatureID, map[string]interface{}{
+		signatureResignVersionColumn: currentVersion,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to record the re-sign notification on the signature")
+	}
+
+	return true
+}
+
+// removeOutdatedGerritAccess removes the signer or the acknowledged employees of a signature the signature version
+// policy no longer honors from the CLA Group Gerrit LDAP groups, returns true if the removal was recorded. Gerrit
+// only checks the group membership, which the signers keep otherwise.
+func (s *service) removeOutdatedGerritAccess(ctx context.Context, claGroup *models.ClaGroup, sig *models.Signature, now time.Time) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.resign_campaign.service.removeOutdatedGerritAccess",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroup.ProjectID,
+		"signatureID":    sig.SignatureID,
+	}
+
+	if s.gerritService == nil || s.lfGroup == nil || common.IsSignatureVersionAccepted(ctx, claGroup, sig, now) {
+		return false
+	}
+	_, currentMajor := common.IsSignatureDocumentOutdated(ctx, claGroup, sig)
+	currentVersion := strconv.Itoa(currentMajor)
+	if sig.SignatureAccessRemovedVersion == currentVersion {
+		return false
+	}
+
+	gerritList, err := s.gerritService.GetClaGroupGerrits(ctx, claGroup.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the CLA Group gerrit instances - skipping")
+		return false
+	}
+	if gerritList == nil || len(gerritList.List) == 0 {
+		return false
+	}
+
+	lfUsernames, err := s.getSignatureLfUsernames(ctx, claGroup.ProjectID, sig)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the users of the outdated signature - skipping")
+		return false
+	}
+
+	// The removal is retried on the next run when any of the removals fail
+	removedBy := &auth.User{UserName: accessRemovedBy}
+	failed := false
+	for _, gerrit := range gerritList.List {
+		// The self-hosted instances check the signatures on each change instead of the group membership
+		if gerrit.Provider == gerrits.ProviderGerritREST {
+			continue
+		}
+		groupID := gerrit.GroupIDIcla
+		if sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+			groupID = gerrit.GroupIDCcla
+		}
+		if groupID == "" {
+			continue
+		}
+		for _, lfUsername := range lfUsernames {
+			log.WithFields(f).Debugf("removing user: %s from gerrit: %s group: %s", lfUsername, gerrit.GerritName, groupID)
+			if removeErr := s.lfGroup.RemoveUserFromGroup(ctx, removedBy, claGroup.ProjectID, groupID, lfUsername); removeErr != nil {
+				log.WithFields(f).WithError(removeErr).Warnf("unable to remove user: %s from gerrit group: %s", lfUsername, groupID)
+				failed = true
+			}
+		}
+	}
+	if failed {
+		return false
+	}
+
+	err = s.signatureRepo.UpdateSignature(ctx, sig.SignatureID, map[string]interface{}{
+		signatureAccessRemovedVersionColumn: currentVersion,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to record the gerrit group access removal on the signature")
+	}
+
+	return true
+}
+
+// getSignatureLfUsernames returns the LF username of the signer for individual signatures and of the acknowledged
+// employees for corporate signatures
+func (s *service) getSignatureLfUsernames(ctx context.Context, claGroupID string, sig *models.Signature) ([]string, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.resign_campaign.service.getSignatureLfUsernames",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+	}
+
+	userIDs := []string{sig.SignatureReferenceID}
+	if sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		employeeSignatures, err := s.signatureRepo.GetProjectCompanyEmployeeSignatures(ctx, v1Signatures.GetProjectCompanyEmployeeSignaturesParams{
+			CompanyID: sig.SignatureReferenceID,
+			ProjectID: claGroupID,
+			PageSize:  aws.Int64(signaturePageSize),
+		}, nil)
+		if err != nil {
+			return nil, err
+		}
+		userIDs = nil
+		for _, employeeSignature := range employeeSignatures.Signatures {
+			userIDs = append(userIDs, employeeSignature.SignatureReferenceID)
+		}
+	}
+
+	var lfUsernames []string
+	for _, userID := range userIDs {
+		userModel, err := s.usersRepo.GetUser(userID)
+		if err != nil || userModel == nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load user by ID: %s - skipping", userID)
+			continue
+		}
+		if userModel.LfUsername == "" {
+			log.WithFields(f).Debugf("user: %s has no LF username - unable to remove from the gerrit groups", userID)
+			continue
+		}
+		lfUsernames = append(lfUsernames, userModel.LfUsername)
+	}
+	return lfUsernames, nil
+}
+
+// getRecipients returns the signer for individual signatures and the CLA Managers for corporate signatures
+func (s *service) getRecipients(ctx context.Context, sig *models.Signature) []recipient {
+	f := logrus.Fields{
+		"functionName":   "v2.resign_campaign.service.getRecipients",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+	}
+
+	var recipients []recipient
+	if sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		for i := range sig.SignatureACL {
+			claManager := &sig.SignatureACL[i]
+			if email := utils.GetBestEmail(claManager); email != "" {
+				recipients = append(recipients, recipient{name: utils.GetBestUsername(claManager), email: email})
+			}
+		}
+		return recipients
+	}
+
+	userModel, err := s.usersRepo.GetUser(sig.SignatureReferenceID)
+	if err != nil || userModel == nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load signer by user ID: %s", sig.SignatureReferenceID)
+		return recipients
+	}
+	if email := utils.GetBestEmail(userModel); email != "" {
+		recipients = append(recipients, recipient{name: utils.GetBestUsername(userModel), email: email})
+	}
+	return recipients
+}
+
+// resignEmailBody builds the re-sign campaign email body for the recipient
+func resignEmailBody(claGroup *models.ClaGroup, sig *models.Signature, recipientName, currentVersion string) string {
+	var agreement, action string
+	if sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		agreement = fmt.Sprintf("the Corporate CLA signed on behalf of %s", sig.CompanyName)
+		action = fmt.Sprintf(`<p>Please ask your company signatory to sign the new version of the Corporate CLA from the
+<a href="%s" target="_blank">EasyCLA Corporate Console</a> to keep your company's contributors authorized.</p>`,
+			utils.GetCorporateURL(claGroup.Version == utils.V2))
+	} else {
+		agreement = "your Individual CLA"
+		action = "<p>You will be asked to sign the new version of the CLA from the EasyCLA check on your next contribution.</p>"
+	}
+
+	deadline := "Your current signature is no longer accepted for new contributions."
+	if claGroup.SignatureVersionPolicy == common.SignatureVersionPolicyExpire {
+		deadline = fmt.Sprintf("Your current signature will no longer be accepted after %s.", claGroup.SignatureExpiryDate)
+	}
+
+	return fmt.Sprintf(`
+<p>Hello %s,</p>
+<p>This is a notification email from EasyCLA regarding the CLA Group %s.</p>
+<p>The CLA Group has published version %s of its Contributor License Agreement and %s was signed against version %s. %s</p>
+%s
+%s
+%s`,
+		recipientName, claGroup.ProjectName, currentVersion, agreement, sig.SignatureDocumentMajorVersion, deadline, action,
+		utils.GetEmailHelpContent(claGroup.Version == utils.V2), utils.GetEmailSignOffContent())
+}
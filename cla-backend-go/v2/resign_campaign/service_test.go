@@ -0,0 +1,309 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package resign_campaign
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/project"
+	v1Signatures "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/restapi/operations/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gerrits"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/common"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeCLAGroupRepo struct {
+	repository.ProjectRepository
+	// pages are returned in order, the last key scanned links them
+	pages []*models.ClaGroups
+}
+
+func (r *fakeCLAGroupRepo) GetCLAGroups(ctx context.Context, params *project.GetProjectsParams) (*models.ClaGroups, error) {
+	for i, page := range r.pages {
+		if (i == 0 && *params.NextKey == "") || (i > 0 && r.pages[i-1].LastKeyScanned == *params.NextKey) {
+			return page, nil
+		}
+	}
+	return nil, errors.New("unexpected next key")
+}
+
+type fakeSignatureRepo struct {
+	signatures.SignatureRepository
+	// signatures of each CLA Group and CLA type
+	signatures map[string][]*models.Signature
+	queried    []string
+	updates    map[string]map[string]interface{}
+}
+
+func (r *fakeSignatureRepo) GetProjectSignatures(ctx context.Context, params v1Signatures.GetProjectSignaturesParams) (*models.Signatures, error) {
+	key := params.ProjectID + "/" + *params.ClaType
+	r.queried = append(r.queried, key)
+	return &models.Signatures{ProjectID: params.ProjectID, Signatures: r.signatures[key]}, nil
+}
+
+func (r *fakeSignatureRepo) GetProjectCompanyEmployeeSignatures(ctx context.Context, params v1Signatures.GetProjectCompanyEmployeeSignaturesParams, criteria *signatures.ApprovalCriteria) (*models.Signatures, error) {
+	return &models.Signatures{ProjectID: params.ProjectID, Signatures: r.signatures[params.ProjectID+"/"+params.CompanyID]}, nil
+}
+
+func (r *fakeSignatureRepo) UpdateSignature(ctx context.Context, signatureID string, updates map[string]interface{}) error {
+	if r.updates[signatureID] == nil {
+		r.updates[signatureID] = make(map[string]interface{})
+	}
+	for column, value := range updates {
+		r.updates[signatureID][column] = value
+	}
+	return nil
+}
+
+type fakeUsersRepo struct {
+	users.UserRepository
+}
+
+func (r *fakeUsersRepo) GetUser(userID string) (*models.User, error) {
+	if userID == "unknown-user" {
+		return nil, nil
+	}
+	return &models.User{UserID: userID, Username: userID, LfUsername: "lf-" + userID, LfEmail: "signer@example.org"}, nil
+}
+
+type fakeGerritService struct {
+	gerrits.Service
+	gerrits []*models.Gerrit
+}
+
+func (g *fakeGerritService) GetClaGroupGerrits(ctx context.Context, claGroupID string) (*models.GerritList, error) {
+	return &models.GerritList{List: g.gerrits}, nil
+}
+
+type fakeLFGroup struct {
+	// removed lists the group/username of each removal
+	removed []string
+}
+
+func (g *fakeLFGroup) RemoveUserFromGroup(ctx context.Context, authUser *auth.User, claGroupID, groupName, userName string) error {
+	g.removed = append(g.removed, groupName+"/"+userName)
+	return nil
+}
+
+type fakeEmailSender struct {
+	recipients []string
+	bodies     []string
+	err        error
+	// failing recipients are rejected with err, every recipient fails when empty
+	failing []string
+}
+
+func (e *fakeEmailSender) SendEmail(subject string, body string, recipients []string) error {
+	if e.err != nil && (len(e.failing) == 0 || utils.StringInSlice(recipients[0], e.failing)) {
+		return e.err
+	}
+	e.recipients = append(e.recipients, recipients...)
+	e.bodies = append(e.bodies, body)
+	return nil
+}
+
+// testCLAGroup returns a CLA Group whose documents were updated from version 1 to version 2
+func testCLAGroup(claGroupID, policy, expiryDate string) models.ClaGroup {
+	documents := []models.ClaGroupDocument{
+		{DocumentMajorVersion: "1", DocumentMinorVersion: "0", DocumentCreationDate: "2020-01-01T00:00:00Z"},
+		{DocumentMajorVersion: "2", DocumentMinorVersion: "0", DocumentCreationDate: "2026-01-01T00:00:00Z"},
+	}
+	return models.ClaGroup{
+		ProjectID:                  claGroupID,
+		ProjectName:                "Test CLA Group",
+		ProjectICLAEnabled:         true,
+		ProjectCCLAEnabled:         true,
+		SignatureVersionPolicy:     policy,
+		SignatureExpiryDate:        expiryDate,
+		ProjectIndividualDocuments: documents,
+		ProjectCorporateDocuments:  documents,
+	}
+}
+
+func newTestService(t *testing.T, pages []*models.ClaGroups, sigs map[string][]*models.Signature) (Service, *fakeSignatureRepo, *fakeEmailSender) {
+	s, signatureRepo, emailSender, _ := newTestServiceWithGerrits(t, pages, sigs, nil)
+	return s, signatureRepo, emailSender
+}
+
+func newTestServiceWithGerrits(t *testing.T, pages []*models.ClaGroups, sigs map[string][]*models.Signature, gerritList []*models.Gerrit) (Service, *fakeSignatureRepo, *fakeEmailSender, *fakeLFGroup) {
+	emailSender := &fakeEmailSender{}
+	previousSender := utils.GetEmailSender()
+	utils.SetEmailSender(emailSender)
+	t.Cleanup(func() { utils.SetEmailSender(previousSender) })
+
+	signatureRepo := &fakeSignatureRepo{signatures: sigs, updates: make(map[string]map[string]interface{})}
+	lfGroup := &fakeLFGroup{}
+	s := NewService(&fakeCLAGroupRepo{pages: pages}, signatureRepo, &fakeUsersRepo{}, &fakeGerritService{gerrits: gerritList}, lfGroup)
+	return s, signatureRepo, emailSender, lfGroup
+}
+
+func TestNotifyOutdatedSignaturesIndividual(t *testing.T) {
+	s, signatureRepo, emailSender := newTestService(t, []*models.ClaGroups{
+		{Projects: []models.ClaGroup{testCLAGroup("resign-group", common.SignatureVersionPolicyResign, "")}},
+	}, map[string][]*models.Signature{
+		"resign-group/" + utils.ClaTypeICLA: {
+			{SignatureID: "outdated", SignatureReferenceID: "user-1", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1"},
+			{SignatureID: "current", SignatureReferenceID: "user-2", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "2"},
+			{SignatureID: "notified", SignatureReferenceID: "user-3", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1", SignatureResignVersion: "2"},
+			{SignatureID: "no-recipient", SignatureReferenceID: "unknown-user", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1"},
+		},
+	})
+
+	assert.NoError(t, s.NotifyOutdatedSignatures(context.Background()))
+
+	assert.Equal(t, []string{"signer@example.org"}, emailSender.recipients)
+	if assert.Len(t, emailSender.bodies, 1) {
+		assert.Contains(t, emailSender.bodies[0], "version 2")
+		assert.Contains(t, emailSender.bodies[0], "no longer accepted for new contributions")
+	}
+	assert.Equal(t, map[string]map[string]interface{}{
+		"outdated": {signatureResignVersionColumn: "2"},
+	}, signatureRepo.updates)
+}
+
+func TestNotifyOutdatedSignaturesCorporate(t *testing.T) {
+	s, signatureRepo, emailSender := newTestService(t, []*models.ClaGroups{
+		{Projects: []models.ClaGroup{testCLAGroup("expire-group", common.SignatureVersionPolicyExpire, "2026-12-31T00:00:00Z")}},
+	}, map[string][]*models.Signature{
+		"expire-group/" + utils.ClaTypeCCLA: {
+			{
+				SignatureID:                   "ccla",
+				SignatureReferenceType:        utils.SignatureReferenceTypeCompany,
+				SignatureDocumentMajorVersion: "1",
+				CompanyName:                   "Acme",
+				SignatureACL: []models.User{
+					{UserID: "manager-1", LfEmail: "manager1@example.org"},
+					{UserID: "manager-2", LfEmail: "manager2@example.org"},
+					// CLA Managers without an email are skipped
+					{UserID: "manager-3"},
+				},
+			},
+		},
+	})
+
+	assert.NoError(t, s.NotifyOutdatedSignatures(context.Background()))
+
+	assert.Equal(t, []string{"manager1@example.org", "manager2@example.org"}, emailSender.recipients)
+	if assert.NotEmpty(t, emailSender.bodies) {
+		assert.Contains(t, emailSender.bodies[0], "Corporate CLA signed on behalf of Acme")
+		assert.Contains(t, emailSender.bodies[0], "no longer be accepted after 2026-12-31T00:00:00Z")
+	}
+	assert.Contains(t, signatureRepo.updates, "ccla")
+}
+
+func TestNotifyOutdatedSignaturesSkipsAcceptPreviousPolicy(t *testing.T) {
+	s, signatureRepo, emailSender := newTestService(t, []*models.ClaGroups{
+		{
+			Projects: []models.ClaGroup{
+				testCLAGroup("accept-group", common.SignatureVersionPolicyAcceptPrevious, ""),
+				testCLAGroup("default-group", "", ""),
+			},
+			LastKeyScanned: "page-2",
+		},
+		{Projects: []models.ClaGroup{testCLAGroup("resign-group", common.SignatureVersionPolicyResign, "")}},
+	}, map[string][]*models.Signature{})
+
+	assert.NoError(t, s.NotifyOutdatedSignatures(context.Background()))
+
+	// only the CLA Group of the second page has a policy requiring a re-sign
+	assert.Equal(t, []string{"resign-group/" + utils.ClaTypeICLA, "resign-group/" + utils.ClaTypeCCLA}, signatureRepo.queried)
+	assert.Empty(t, emailSender.recipients)
+}
+
+func TestNotifyOutdatedSignaturesEmailFailure(t *testing.T) {
+	s, signatureRepo, emailSender := newTestService(t, []*models.ClaGroups{
+		{Projects: []models.ClaGroup{testCLAGroup("resign-group", common.SignatureVersionPolicyResign, "")}},
+	}, map[string][]*models.Signature{
+		"resign-group/" + utils.ClaTypeICLA: {
+			{SignatureID: "outdated", SignatureReferenceID: "user-1", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1"},
+		},
+	})
+	emailSender.err = errors.New("sns unavailable")
+
+	assert.NoError(t, s.NotifyOutdatedSignatures(context.Background()))
+
+	// the signature isn't marked as notified so the next run tries again
+	assert.Empty(t, signatureRepo.updates)
+}
+
+func TestNotifyOutdatedSignaturesPartialEmailFailure(t *testing.T) {
+	s, signatureRepo, emailSender := newTestService(t, []*models.ClaGroups{
+		{Projects: []models.ClaGroup{testCLAGroup("resign-group", common.SignatureVersionPolicyResign, "")}},
+	}, map[string][]*models.Signature{
+		"resign-group/" + utils.ClaTypeCCLA: {
+			{
+				SignatureID:                   "ccla",
+				SignatureReferenceType:        utils.SignatureReferenceTypeCompany,
+				SignatureDocumentMajorVersion: "1",
+				SignatureACL: []models.User{
+					{UserID: "manager-1", LfEmail: "manager1@example.org"},
+					{UserID: "manager-2", LfEmail: "manager2@example.org"},
+				},
+			},
+		},
+	})
+	emailSender.err = errors.New("mailbox unavailable")
+	emailSender.failing = []string{"manager1@example.org"}
+
+	assert.NoError(t, s.NotifyOutdatedSignatures(context.Background()))
+
+	// the signature is marked as notified so manager2 doesn't get the email again on the next run
+	assert.Equal(t, []string{"manager2@example.org"}, emailSender.recipients)
+	assert.Equal(t, map[string]map[string]interface{}{
+		"ccla": {signatureResignVersionColumn: "2"},
+	}, signatureRepo.updates)
+}
+
+func TestNotifyOutdatedSignaturesRemovesGerritAccess(t *testing.T) {
+	gerritList := []*models.Gerrit{
+		{GerritName: "ldap", GroupIDIcla: "icla-group", GroupIDCcla: "ccla-group"},
+		// the self-hosted instances check the signatures on each change
+		{GerritName: "rest", Provider: gerrits.ProviderGerritREST, GroupIDIcla: "rest-group", GroupIDCcla: "rest-group"},
+	}
+	s, signatureRepo, _, lfGroup := newTestServiceWithGerrits(t, []*models.ClaGroups{
+		{
+			Projects: []models.ClaGroup{
+				testCLAGroup("resign-group", common.SignatureVersionPolicyResign, ""),
+				// the outdated signatures are honored until the expiry date
+				testCLAGroup("expire-group", common.SignatureVersionPolicyExpire, "2999-12-31T00:00:00Z"),
+			},
+		},
+	}, map[string][]*models.Signature{
+		"resign-group/" + utils.ClaTypeICLA: {
+			{SignatureID: "outdated", SignatureReferenceID: "user-1", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1"},
+			{SignatureID: "current", SignatureReferenceID: "user-2", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "2"},
+			{SignatureID: "removed", SignatureReferenceID: "user-3", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1",
+				SignatureResignVersion: "2", SignatureAccessRemovedVersion: "2"},
+		},
+		"resign-group/" + utils.ClaTypeCCLA: {
+			{SignatureID: "ccla", SignatureReferenceID: "company-1", SignatureReferenceType: utils.SignatureReferenceTypeCompany, SignatureDocumentMajorVersion: "1",
+				SignatureResignVersion: "2"},
+		},
+		"resign-group/company-1": {
+			{SignatureID: "ack-1", SignatureReferenceID: "employee-1"},
+			{SignatureID: "ack-2", SignatureReferenceID: "employee-2"},
+		},
+		"expire-group/" + utils.ClaTypeICLA: {
+			{SignatureID: "expiring", SignatureReferenceID: "user-4", SignatureReferenceType: utils.SignatureReferenceTypeUser, SignatureDocumentMajorVersion: "1"},
+		},
+	}, gerritList)
+
+	assert.NoError(t, s.NotifyOutdatedSignatures(context.Background()))
+
+	assert.Equal(t, []string{"icla-group/lf-user-1", "ccla-group/lf-employee-1", "ccla-group/lf-employee-2"}, lfGroup.removed)
+	assert.Equal(t, map[string]interface{}{
+		signatureResignVersionColumn:        "2",
+		signatureAccessRemovedVersionColumn: "2",
+	}, signatureRepo.updates["outdated"])
+	assert.Equal(t, map[string]interface{}{signatureAccessRemovedVersionColumn: "2"}, signatureRepo.updates["ccla"])
+	assert.Equal(t, map[string]interface{}{signatureResignVersionColumn: "2"}, signatureRepo.updates["expiring"])
+}
@@ -0,0 +1,522 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// ClickThroughStatusSent is the status of a click-through envelope waiting for the signer
+	ClickThroughStatusSent = "sent"
+	// ClickThroughStatusCompleted is the status of an accepted click-through envelope
+	ClickThroughStatusCompleted = EnvelopeStatusCompleted
+	// ClickThroughStatusVoided is the status of a voided click-through envelope
+	ClickThroughStatusVoided = "voided"
+
+	// click-through audit trail events
+	clickThroughEventCreated  = "created"
+	clickThroughEventSent     = "sent"
+	clickThroughEventViewed   = "viewed"
+	clickThroughEventAccepted = "accepted"
+	clickThroughEventVoided   = "voided"
+
+	clickThroughCallbackTimeout = 30 * time.Second
+	clickThroughTokenBytes      = 32
+)
+
+var (
+	// ErrClickThroughEnvelopeNotFound is returned when the click-through envelope does not exist
+	ErrClickThroughEnvelopeNotFound = errors.New("click-through envelope not found")
+	// ErrClickThroughEnvelopeNotPending is returned when the click-through envelope can no longer be accepted
+	ErrClickThroughEnvelopeNotPending = errors.New("click-through envelope is not pending signature")
+	// ErrClickThroughInvalidToken is returned when the signing token does not match the click-through envelope
+	ErrClickThroughInvalidToken = errors.New("invalid click-through signing token")
+	// ErrClickThroughSignerMismatch is returned when the accepted name is not the name of the envelope signer
+	ErrClickThroughSignerMismatch = errors.New("the full name does not match the signer of the click-through envelope")
+)
+
+// ClickThroughEnvelope is the envelope record of the click-through signature provider, the record
+// is stored as JSON in the signature files bucket next to the original and the signed document.
+// Only the SHA-256 of the signing token is stored, the token itself is part of the sign URL
+// that is handed to the signer, the envelope ID alone does not grant access to the envelope.
+type ClickThroughEnvelope struct {
+	EnvelopeID     string                   `json:"envelope_id"`
+	SignatureID    string                   `json:"signature_id"`
+	DocumentID     string                   `json:"document_id"`
+	DocumentName   string                   `json:"document_name"`
+	DocumentSHA256 string                   `json:"document_sha256"`
+	TokenSHA256    string                   `json:"token_sha256"`
+	Locale         string                   `json:"locale,omitempty"`
+	SignerName     string                   `json:"signer_name"`
+	SignerEmail    string                   `json:"signer_email"`
+	ClientUserID   string                   `json:"client_user_id"`
+	CallbackURL    string                   `json:"callback_url"`
+	ReturnURL      string                   `json:"return_url"`
+	Status         string                   `json:"status"`
+	FullName       string                   `json:"full_name,omitempty"`
+	SignedDate     string                   `json:"signed_date,omitempty"`
+	DateCreated    string                   `json:"date_created"`
+	DateModified   string                   `json:"date_modified"`
+	AuditTrail     []ClickThroughAuditEvent `json:"audit_trail"`
+
+	// DocumentURL is a pre-signed download URL of the document, it is not persisted
+	DocumentURL string `json:"-"`
+}
+
+// ClickThroughAuditEvent is a single entry of the click-through envelope audit trail
+type ClickThroughAuditEvent struct {
+	Event     string `json:"event"`
+	Timestamp string `json:"timestamp"`
+	IPAddress string `json:"ip_address,omitempty"`
+	UserAgent string `json:"user_agent,omitempty"`
+	Detail    string `json:"detail,omitempty"`
+}
+
+// ClickThroughCallback is the payload posted to the envelope callback URL once the signer accepted the document
+type ClickThroughCallback struct {
+	Provider   string `json:"provider"`
+	EnvelopeID string `json:"envelope_id"`
+}
+
+// clickThroughStorage stores the click-through envelope records and documents
+type clickThroughStorage interface {
+	UploadObject(key string, content []byte) error
+	Download(key string) ([]byte, error)
+	KeyExists(key string) (bool, error)
+	GetPresignedURL(key string) (string, error)
+}
+
+// s3ClickThroughStorage stores the click-through objects in the signature files bucket
+type s3ClickThroughStorage struct{}
+
+func (s3ClickThroughStorage) UploadObject(key string, content []byte) error {
+	return utils.UploadObjectToS3(key, content)
+}
+
+func (s3ClickThroughStorage) Download(key string) ([]byte, error) {
+	return utils.DownloadFromS3(key)
+}
+
+func (s3ClickThroughStorage) KeyExists(key string) (bool, error) {
+	return utils.DocumentExists(key)
+}
+
+func (s3ClickThroughStorage) GetPresignedURL(key string) (string, error) {
+	return utils.GetDownloadLink(key)
+}
+
+// clickThroughProvider is the built-in SignatureProvider implementation where the signer accepts the
+// document with a click instead of signing through an external e-signature platform
+type clickThroughProvider struct {
+	claV4ApiURL string
+	httpClient  *http.Client
+	storage     clickThroughStorage
+}
+
+// newClickThroughProvider returns a new click-through signature provider
+func newClickThroughProvider(claV4ApiURL string) *clickThroughProvider {
+	return &clickThroughProvider{
+		claV4ApiURL: claV4ApiURL,
+		httpClient:  &http.Client{Timeout: clickThroughCallbackTimeout},
+		storage:     s3ClickThroughStorage{},
+	}
+}
+
+// Name returns the provider name
+func (p *clickThroughProvider) Name() string {
+	return ProviderClickThrough
+}
+
+// CreateEnvelope stores the document and the envelope record and returns the click-through sign URL,
+// the sign URL points to the signing page and carries the signing token of the envelope
+func (p *clickThroughProvider) CreateEnvelope(ctx context.Context, req *EnvelopeRequest) (*EnvelopeResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.clickThroughProvider.CreateEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    req.SignatureID,
+	}
+
+	envelopeID := uuid.Must(uuid.NewV4()).String()
+	f["envelopeID"] = envelopeID
+
+	token, err := newClickThroughToken()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate click-through signing token")
+		return nil, err
+	}
+
+	log.WithFields(f).Debug("storing click-through document...")
+	err = p.storage.UploadObject(clickThroughDocumentKey(envelopeID), req.Document)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store click-through document")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	documentHash := sha256.Sum256(req.Document)
+	envelope := &ClickThroughEnvelope{
+		EnvelopeID:     envelopeID,
+		SignatureID:    req.SignatureID,
+		DocumentID:     req.DocumentID,
+		DocumentName:   req.DocumentName,
+		DocumentSHA256: hex.EncodeToString(documentHash[:]),
+		TokenSHA256:    clickThroughTokenHash(token),
+		Locale:         req.Locale,
+		SignerName:     req.SignerName,
+		SignerEmail:    req.SignerEmail,
+		ClientUserID:   req.ClientUserID,
+		CallbackURL:    req.CallbackURL,
+		ReturnURL:      req.ReturnURL,
+		Status:         ClickThroughStatusSent,
+		DateCreated:    currentTime,
+		DateModified:   currentTime,
+	}
+	envelope.addAuditEvent(clickThroughEventCreated, "", "", "")
+
+	signURL := fmt.Sprintf("%s/v4/sign/click-through/%s/page?token=%s", p.claV4ApiURL, envelopeID, token)
+	if req.SendAsEmail {
+		log.WithFields(f).Debugf("sending click-through sign link to: %s", req.SignerEmail)
+		body := fmt.Sprintf("%s<p>Please review and accept the document at: <a href=\"%s\" target=\"_blank\">%s</a></p>", req.EmailBody, signURL, signURL)
+		err = utils.SendEmail(req.EmailSubject, body, []string{req.SignerEmail})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to send click-through sign link to: %s", req.SignerEmail)
+			return nil, err
+		}
+		envelope.addAuditEvent(clickThroughEventSent, "", "", req.SignerEmail)
+	}
+
+	err = p.saveEnvelope(envelope)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to store click-through envelope")
+		return nil, err
+	}
+
+	result := &EnvelopeResult{
+		EnvelopeID: envelopeID,
+	}
+	if !req.SendAsEmail {
+		result.SignURL = signURL
+	}
+
+	return result, nil
+}
+
+// VoidEnvelope voids a pending click-through envelope
+func (p *clickThroughProvider) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.clickThroughProvider.VoidEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     envelopeID,
+	}
+
+	envelope, err := p.GetEnvelope(envelopeID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load click-through envelope")
+		return err
+	}
+
+	if envelope.Status != ClickThroughStatusSent {
+		log.WithFields(f).Warnf("unable to void click-through envelope with status: %s", envelope.Status)
+		return ErrClickThroughEnvelopeNotPending
+	}
+
+	envelope.Status = ClickThroughStatusVoided
+	envelope.addAuditEvent(clickThroughEventVoided, "", "", message)
+
+	return p.saveEnvelope(envelope)
+}
+
+// ParseCallback parses the click-through callback payload. The callback only carries the envelope ID,
+// the signature details are always loaded from the stored envelope record so that a forged callback
+// can't mark a signature as signed.
+func (p *clickThroughProvider) ParseCallback(ctx context.Context, payload []byte) (*EnvelopeCallback, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.clickThroughProvider.ParseCallback",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(payload), []byte("{")) {
+		return nil, ErrCallbackNotRecognized
+	}
+
+	var callback ClickThroughCallback
+	err := json.Unmarshal(payload, &callback)
+	if err != nil || callback.Provider != ProviderClickThrough {
+		return nil, ErrCallbackNotRecognized
+	}
+
+	envelope, err := p.GetEnvelope(callback.EnvelopeID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load click-through envelope: %s", callback.EnvelopeID)
+		return nil, err
+	}
+
+	if envelope.Status != ClickThroughStatusCompleted {
+		log.WithFields(f).Warnf("click-through envelope: %s has not been accepted - status: %s", envelope.EnvelopeID, envelope.Status)
+		return nil, ErrClickThroughEnvelopeNotPending
+	}
+
+	return &EnvelopeCallback{
+		Provider:        ProviderClickThrough,
+		EnvelopeID:      envelope.EnvelopeID,
+		ClientUserID:    envelope.ClientUserID,
+		RecipientStatus: envelope.Status,
+		EnvelopeStatus:  envelope.Status,
+		SignedDate:      envelope.SignedDate,
+		DocumentID:      envelope.DocumentID,
+		FullName:        envelope.FullName,
+	}, nil
+}
+
+// GetSignedDocument returns the signed click-through document
+func (p *clickThroughProvider) GetSignedDocument(_ context.Context, envelopeID, _ string) ([]byte, error) {
+	return p.storage.Download(clickThroughSignedDocumentKey(envelopeID))
+}
+
+// GetEnvelope loads the click-through envelope record
+func (p *clickThroughProvider) GetEnvelope(envelopeID string) (*ClickThroughEnvelope, error) {
+	exists, err := p.storage.KeyExists(clickThroughEnvelopeKey(envelopeID))
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, ErrClickThroughEnvelopeNotFound
+	}
+
+	content, err := p.storage.Download(clickThroughEnvelopeKey(envelopeID))
+	if err != nil {
+		return nil, err
+	}
+
+	var envelope ClickThroughEnvelope
+	err = json.Unmarshal(content, &envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	return &envelope, nil
+}
+
+// getAuthorizedEnvelope loads the click-through envelope and verifies the signing token
+func (p *clickThroughProvider) getAuthorizedEnvelope(envelopeID, token string) (*ClickThroughEnvelope, error) {
+	envelope, err := p.GetEnvelope(envelopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if envelope.TokenSHA256 == "" || subtle.ConstantTimeCompare([]byte(clickThroughTokenHash(token)), []byte(envelope.TokenSHA256)) != 1 {
+		return nil, ErrClickThroughInvalidToken
+	}
+
+	return envelope, nil
+}
+
+// ViewEnvelope records that the signer opened the envelope and returns it
+func (p *clickThroughProvider) ViewEnvelope(ctx context.Context, envelopeID, token, ipAddress, userAgent string) (*ClickThroughEnvelope, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.clickThroughProvider.ViewEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     envelopeID,
+	}
+
+	envelope, err := p.getAuthorizedEnvelope(envelopeID, token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load click-through envelope")
+		return nil, err
+	}
+
+	if envelope.Status == ClickThroughStatusSent {
+		envelope.addAuditEvent(clickThroughEventViewed, ipAddress, userAgent, "")
+		err = p.saveEnvelope(envelope)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to store click-through envelope")
+			return nil, err
+		}
+	}
+
+	envelope.DocumentURL, err = p.storage.GetPresignedURL(clickThroughDocumentKey(envelopeID))
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate the click-through document download link")
+		return nil, err
+	}
+
+	return envelope, nil
+}
+
+// AcceptEnvelope records the signer acceptance, stamps the signature page on the document and
+// notifies the envelope callback URL. The signing token must match the envelope and the full name
+// must match the signer of the envelope. Accepting an already accepted envelope only re-sends the
+// callback so that a failed callback can be retried by the signer.
+func (p *clickThroughProvider) AcceptEnvelope(ctx context.Context, envelopeID, token, fullName, ipAddress, userAgent string) (*ClickThroughEnvelope, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.clickThroughProvider.AcceptEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"envelopeID":     envelopeID,
+	}
+
+	envelope, err := p.getAuthorizedEnvelope(envelopeID, token)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load click-through envelope")
+		return nil, err
+	}
+
+	if envelope.SignerName != "" && !strings.EqualFold(strings.Join(strings.Fields(fullName), " "), strings.Join(strings.Fields(envelope.SignerName), " ")) {
+		log.WithFields(f).Warnf("full name: %s does not match the envelope signer: %s", fullName, envelope.SignerName)
+		return nil, ErrClickThroughSignerMismatch
+	}
+
+	switch envelope.Status {
+	case ClickThroughStatusCompleted:
+		log.WithFields(f).Debug("click-through envelope already accepted, re-sending callback...")
+	case ClickThroughStatusSent:
+		document, downloadErr := p.storage.Download(clickThroughDocumentKey(envelopeID))
+		if downloadErr != nil {
+			log.WithFields(f).WithError(downloadErr).Warn("unable to load click-through document")
+			return nil, downloadErr
+		}
+
+		_, currentTime := utils.CurrentTime()
+		signedDocument, stampErr := utils.AppendSignaturePage(document, []string{
+			"Electronic Signature Record",
+			"",
+			fmt.Sprintf("Accepted by: %s", fullName),
+			fmt.Sprintf("Email: %s", envelope.SignerEmail),
+			fmt.Sprintf("Date: %s", currentTime),
+			fmt.Sprintf("IP address: %s", ipAddress),
+			fmt.Sprintf("Envelope ID: %s", envelope.EnvelopeID),
+			fmt.Sprintf("Document SHA-256: %s", envelope.DocumentSHA256),
+		})
+		if stampErr != nil {
+			log.WithFields(f).WithError(stampErr).Warn("unable to stamp the signature page on the click-through document")
+			return nil, stampErr
+		}
+
+		err = p.storage.UploadObject(clickThroughSignedDocumentKey(envelopeID), signedDocument)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to store signed click-through document")
+			return nil, err
+		}
+
+		envelope.Status = ClickThroughStatusCompleted
+		envelope.FullName = fullName
+		envelope.SignedDate = currentTime
+		envelope.addAuditEvent(clickThroughEventAccepted, ipAddress, userAgent, fullName)
+		err = p.saveEnvelope(envelope)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to store click-through envelope")
+			return nil, err
+		}
+	default:
+		log.WithFields(f).Warnf("unable to accept click-through envelope with status: %s", envelope.Status)
+		return nil, ErrClickThroughEnvelopeNotPending
+	}
+
+	if envelope.CallbackURL != "" {
+		err = p.sendCallback(ctx, envelope)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to notify callback url: %s", envelope.CallbackURL)
+			return nil, err
+		}
+	}
+
+	return envelope, nil
+}
+
+// sendCallback posts the click-through callback payload to the envelope callback URL
+func (p *clickThroughProvider) sendCallback(ctx context.Context, envelope *ClickThroughEnvelope) error {
+	payload, err := json.Marshal(&ClickThroughCallback{
+		Provider:   ProviderClickThrough,
+		EnvelopeID: envelope.EnvelopeID,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, envelope.CallbackURL, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Add("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("problem closing the response body")
+		}
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(resp.Body) // nolint
+		return fmt.Errorf("callback returned status code: %d - response: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return nil
+}
+
+func (p *clickThroughProvider) saveEnvelope(envelope *ClickThroughEnvelope) error {
+	_, envelope.DateModified = utils.CurrentTime()
+	content, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+	return p.storage.UploadObject(clickThroughEnvelopeKey(envelope.EnvelopeID), content)
+}
+
+func (e *ClickThroughEnvelope) addAuditEvent(event, ipAddress, userAgent, detail string) {
+	_, currentTime := utils.CurrentTime()
+	e.AuditTrail = append(e.AuditTrail, ClickThroughAuditEvent{
+		Event:     event,
+		Timestamp: currentTime,
+		IPAddress: ipAddress,
+		UserAgent: userAgent,
+		Detail:    detail,
+	})
+}
+
+// newClickThroughToken returns a new random signing token
+func newClickThroughToken() (string, error) {
+	token := make([]byte, clickThroughTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
+
+func clickThroughTokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+func clickThroughEnvelopeKey(envelopeID string) string {
+	return strings.Join([]string{"click-through", envelopeID, "envelope.json"}, "/")
+}
+
+func clickThroughDocumentKey(envelopeID string) string {
+	return strings.Join([]string{"click-through", envelopeID, "document.pdf"}, "/")
+}
+
+func clickThroughSignedDocumentKey(envelopeID string) string {
+	return strings.Join([]string{"click-through", envelopeID, "signed.pdf"}, "/")
+}
@@ -0,0 +1,101 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+)
+
+// clickThroughPageTemplate is the signing page of a click-through envelope, the signer reviews the
+// document, types the full name and accepts the document. The acceptance is posted to the
+// click-through accept endpoint together with the signing token from the sign URL.
+var clickThroughPageTemplate = template.Must(template.New("click-through").Parse(`<!DOCTYPE html>
+<html lang="{{ if .Envelope.Locale }}{{ .Envelope.Locale }}{{ else }}en{{ end }}">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="referrer" content="no-referrer">
+<title>{{ .Envelope.DocumentName }}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; max-width: 720px; margin: 40px auto; padding: 0 16px; color: #333; }
+label { display: block; margin: 16px 0 4px; }
+input[type=text] { width: 100%; padding: 8px; box-sizing: border-box; }
+button { margin-top: 24px; padding: 10px 24px; }
+.error { color: #b00020; }
+</style>
+</head>
+<body>
+<h1>{{ .Envelope.DocumentName }}</h1>
+{{ if .Pending }}
+<p>Please review the <a href="{{ .Envelope.DocumentURL }}" target="_blank" rel="noopener">document</a> before accepting it.</p>
+<p>SHA-256: <code>{{ .Envelope.DocumentSHA256 }}</code></p>
+<form id="accept-form">
+<label for="full-name">Full name{{ if .Envelope.SignerName }} ({{ .Envelope.SignerName }}){{ end }}</label>
+<input id="full-name" type="text" autocomplete="name" required>
+<label><input id="accept" type="checkbox" required> I, {{ if .Envelope.SignerName }}{{ .Envelope.SignerName }}{{ else }}the signer{{ end }} ({{ .Envelope.SignerEmail }}), accept the terms of this document.</label>
+<button type="submit">Accept</button>
+<p id="error" class="error"></p>
+</form>
+<script>
+document.getElementById("accept-form").addEventListener("submit", function (event) {
+  event.preventDefault();
+  var error = document.getElementById("error");
+  error.textContent = "";
+  fetch({{ .AcceptURL }}, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify({
+      full_name: document.getElementById("full-name").value,
+      accept: document.getElementById("accept").checked,
+      token: {{ .Token }}
+    })
+  }).then(function (response) {
+    return response.json().then(function (body) {
+      if (!response.ok) {
+        throw new Error(body.Message || body.message || response.statusText);
+      }
+      if (body.return_url) {
+        window.location.assign(body.return_url);
+      } else {
+        document.getElementById("accept-form").outerHTML = "<p>Thank you, the document has been accepted.</p>";
+      }
+    });
+  }).catch(function (err) {
+    error.textContent = err.message;
+  });
+});
+</script>
+{{ else if .Completed }}
+<p>The document has been accepted.</p>
+{{ if .Envelope.ReturnURL }}<p><a href="{{ .Envelope.ReturnURL }}">Continue</a></p>{{ end }}
+{{ else }}
+<p>The document is no longer available for signature.</p>
+{{ end }}
+</body>
+</html>
+`))
+
+// RenderSigningPage renders the HTML signing page of the click-through envelope
+func (p *clickThroughProvider) RenderSigningPage(envelope *ClickThroughEnvelope, token string) ([]byte, error) {
+	var b bytes.Buffer
+	err := clickThroughPageTemplate.Execute(&b, struct {
+		Envelope  *ClickThroughEnvelope
+		AcceptURL string
+		Token     string
+		Pending   bool
+		Completed bool
+	}{
+		Envelope:  envelope,
+		AcceptURL: fmt.Sprintf("%s/v4/sign/click-through/%s", p.claV4ApiURL, envelope.EnvelopeID),
+		Token:     token,
+		Pending:   envelope.Status == ClickThroughStatusSent,
+		Completed: envelope.Status == ClickThroughStatusCompleted,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
@@ -0,0 +1,245 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/LF-Engineering/aws-lambda-go-api-proxy/core"
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryClickThroughStorage is an in-memory clickThroughStorage
+type memoryClickThroughStorage struct {
+	objects map[string][]byte
+}
+
+func (m *memoryClickThroughStorage) UploadObject(key string, content []byte) error {
+	m.objects[key] = content
+	return nil
+}
+
+func (m *memoryClickThroughStorage) Download(key string) ([]byte, error) {
+	content, ok := m.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("key not found: %s", key)
+	}
+	return content, nil
+}
+
+func (m *memoryClickThroughStorage) KeyExists(key string) (bool, error) {
+	_, ok := m.objects[key]
+	return ok, nil
+}
+
+func (m *memoryClickThroughStorage) GetPresignedURL(key string) (string, error) {
+	return "https://bucket.example.org/" + key, nil
+}
+
+// testPDF builds a minimal single page PDF document
+func testPDF() []byte {
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	content := "BT /F1 12 Tf 72 720 Td (Contributor License Agreement) Tj ET"
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		"<< /Type /Page /Parent 2 0 R /MediaBox [0 0 612 792] /Resources << /Font << /F1 4 0 R >> >> /Contents 5 0 R >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%s\nendstream", len(content), content),
+	}
+	var offsets []int
+	for i, object := range objects {
+		offsets = append(offsets, b.Len())
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, object)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return b.Bytes()
+}
+
+// callbackRecorder is a test callback endpoint that records the received payloads
+type callbackRecorder struct {
+	mu       sync.Mutex
+	payloads [][]byte
+}
+
+func (c *callbackRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body) // nolint
+	c.mu.Lock()
+	c.payloads = append(c.payloads, body)
+	c.mu.Unlock()
+	w.WriteHeader(http.StatusOK)
+}
+
+func newTestClickThroughProvider() (*clickThroughProvider, *memoryClickThroughStorage) {
+	storage := &memoryClickThroughStorage{objects: map[string][]byte{}}
+	provider := newClickThroughProvider("https://api.example.org")
+	provider.storage = storage
+	return provider, storage
+}
+
+// createTestEnvelope creates a click-through envelope and returns the envelope ID and the signing token
+func createTestEnvelope(t *testing.T, provider *clickThroughProvider, callbackURL string) (string, string) {
+	result, err := provider.CreateEnvelope(context.Background(), &EnvelopeRequest{
+		SignatureID:  "signature-1",
+		DocumentID:   "document-1",
+		DocumentName: "Individual CLA",
+		Document:     testPDF(),
+		SignerName:   "Jane Doe",
+		SignerEmail:  "jane@example.org",
+		ClientUserID: "user-1",
+		CallbackURL:  callbackURL,
+		ReturnURL:    "https://example.org/return",
+	})
+	assert.NoError(t, err)
+
+	signURL, err := url.Parse(result.SignURL)
+	assert.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("/v4/sign/click-through/%s/page", result.EnvelopeID), signURL.Path)
+	token := signURL.Query().Get("token")
+	assert.Len(t, token, clickThroughTokenBytes*2)
+	return result.EnvelopeID, token
+}
+
+func TestClickThroughCreateEnvelope(t *testing.T) {
+	provider, storage := newTestClickThroughProvider()
+	envelopeID, token := createTestEnvelope(t, provider, "")
+
+	envelope, err := provider.GetEnvelope(envelopeID)
+	assert.NoError(t, err)
+	assert.Equal(t, ClickThroughStatusSent, envelope.Status)
+	assert.Equal(t, clickThroughTokenHash(token), envelope.TokenSHA256)
+	assert.Equal(t, testPDF(), storage.objects[clickThroughDocumentKey(envelopeID)])
+	// the token itself is never persisted
+	assert.NotContains(t, string(storage.objects[clickThroughEnvelopeKey(envelopeID)]), token)
+
+	_, err = provider.GetEnvelope("unknown")
+	assert.Equal(t, ErrClickThroughEnvelopeNotFound, err)
+}
+
+func TestClickThroughViewEnvelope(t *testing.T) {
+	provider, _ := newTestClickThroughProvider()
+	envelopeID, token := createTestEnvelope(t, provider, "")
+
+	_, err := provider.ViewEnvelope(context.Background(), envelopeID, "", "192.0.2.1", "test")
+	assert.Equal(t, ErrClickThroughInvalidToken, err)
+	_, err = provider.ViewEnvelope(context.Background(), envelopeID, strings.Repeat("0", len(token)), "192.0.2.1", "test")
+	assert.Equal(t, ErrClickThroughInvalidToken, err)
+
+	envelope, err := provider.ViewEnvelope(context.Background(), envelopeID, token, "192.0.2.1", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bucket.example.org/"+clickThroughDocumentKey(envelopeID), envelope.DocumentURL)
+	last := envelope.AuditTrail[len(envelope.AuditTrail)-1]
+	assert.Equal(t, clickThroughEventViewed, last.Event)
+	assert.Equal(t, "192.0.2.1", last.IPAddress)
+
+	page, err := provider.RenderSigningPage(envelope, token)
+	assert.NoError(t, err)
+	assert.Contains(t, string(page), `id="accept-form"`)
+	assert.Contains(t, string(page), fmt.Sprintf(`fetch("https://api.example.org/v4/sign/click-through/%s"`, envelopeID))
+	assert.Contains(t, string(page), fmt.Sprintf(`token: "%s"`, token))
+}
+
+func TestClickThroughAcceptEnvelope(t *testing.T) {
+	callbacks := &callbackRecorder{}
+	server := httptest.NewServer(callbacks)
+	defer server.Close()
+
+	provider, storage := newTestClickThroughProvider()
+	envelopeID, token := createTestEnvelope(t, provider, server.URL)
+
+	// the envelope ID alone is not enough to accept the envelope
+	_, err := provider.AcceptEnvelope(context.Background(), envelopeID, "", "Jane Doe", "192.0.2.1", "test")
+	assert.Equal(t, ErrClickThroughInvalidToken, err)
+
+	// only the signer can accept the envelope
+	_, err = provider.AcceptEnvelope(context.Background(), envelopeID, token, "John Smith", "192.0.2.1", "test")
+	assert.Equal(t, ErrClickThroughSignerMismatch, err)
+	assert.NotContains(t, storage.objects, clickThroughSignedDocumentKey(envelopeID))
+
+	envelope, err := provider.AcceptEnvelope(context.Background(), envelopeID, token, "  jane   DOE ", "192.0.2.1", "test")
+	assert.NoError(t, err)
+	assert.Equal(t, ClickThroughStatusCompleted, envelope.Status)
+	assert.NotEmpty(t, envelope.SignedDate)
+	last := envelope.AuditTrail[len(envelope.AuditTrail)-1]
+	assert.Equal(t, clickThroughEventAccepted, last.Event)
+	assert.Equal(t, "192.0.2.1", last.IPAddress)
+
+	signedDocument, err := provider.GetSignedDocument(context.Background(), envelopeID, "")
+	assert.NoError(t, err)
+	pageCount, err := api.PageCount(bytes.NewReader(signedDocument), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pageCount)
+
+	assert.Len(t, callbacks.payloads, 1)
+	var callback ClickThroughCallback
+	assert.NoError(t, json.Unmarshal(callbacks.payloads[0], &callback))
+	assert.Equal(t, ClickThroughCallback{Provider: ProviderClickThrough, EnvelopeID: envelopeID}, callback)
+
+	// accepting again only re-sends the callback
+	_, err = provider.AcceptEnvelope(context.Background(), envelopeID, token, "Jane Doe", "192.0.2.1", "test")
+	assert.NoError(t, err)
+	assert.Len(t, callbacks.payloads, 2)
+
+	// a voided envelope can't be accepted
+	voidedID, voidedToken := createTestEnvelope(t, provider, "")
+	assert.NoError(t, provider.VoidEnvelope(context.Background(), voidedID, "voided"))
+	_, err = provider.AcceptEnvelope(context.Background(), voidedID, voidedToken, "Jane Doe", "192.0.2.1", "test")
+	assert.Equal(t, ErrClickThroughEnvelopeNotPending, err)
+}
+
+func TestClickThroughParseCallback(t *testing.T) {
+	provider, _ := newTestClickThroughProvider()
+	envelopeID, token := createTestEnvelope(t, provider, "")
+
+	_, err := provider.ParseCallback(context.Background(), []byte("<DocuSignEnvelopeInformation/>"))
+	assert.Equal(t, ErrCallbackNotRecognized, err)
+	_, err = provider.ParseCallback(context.Background(), []byte(`{"provider":"docusign","envelope_id":"x"}`))
+	assert.Equal(t, ErrCallbackNotRecognized, err)
+
+	payload := []byte(fmt.Sprintf(`{"provider":"%s","envelope_id":"%s"}`, ProviderClickThrough, envelopeID))
+	// a forged callback for a pending envelope is rejected
+	_, err = provider.ParseCallback(context.Background(), payload)
+	assert.Equal(t, ErrClickThroughEnvelopeNotPending, err)
+
+	_, err = provider.AcceptEnvelope(context.Background(), envelopeID, token, "Jane Doe", "192.0.2.1", "test")
+	assert.NoError(t, err)
+
+	callback, err := provider.ParseCallback(context.Background(), payload)
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderClickThrough, callback.Provider)
+	assert.Equal(t, envelopeID, callback.EnvelopeID)
+	assert.Equal(t, "user-1", callback.ClientUserID)
+	assert.Equal(t, EnvelopeStatusCompleted, callback.EnvelopeStatus)
+	assert.Equal(t, "document-1", callback.DocumentID)
+	assert.Equal(t, "Jane Doe", callback.FullName)
+}
+
+func TestClientIPAddress(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/v4/sign/click-through/id/page", nil)
+	r.RemoteAddr = "198.51.100.7:1234"
+	r.Header.Set("X-Forwarded-For", "203.0.113.9")
+	assert.Equal(t, "198.51.100.7", clientIPAddress(r))
+
+	// the lambda proxy request context is added after the client headers
+	r.Header.Add(core.APIGwContextHeader, `{"identity":{"sourceIp":"203.0.113.9"}}`)
+	r.Header.Add(core.APIGwContextHeader, `{"identity":{"sourceIp":"192.0.2.10"}}`)
+	assert.Equal(t, "192.0.2.10", clientIPAddress(r))
+}
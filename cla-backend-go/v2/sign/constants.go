@@ -12,4 +12,10 @@ const (
 
 	// Gitlab is a constant for gitlab
 	Gitlab = "gitlab"
+
+	// Bitbucket is a constant for bitbucket
+	Bitbucket = "bitbucket"
+
+	// Gitea is a constant for gitea
+	Gitea = "gitea"
 )
@@ -6,7 +6,9 @@ package sign
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
@@ -19,14 +21,199 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// docuSignProvider is the DocuSign implementation of the SignatureProvider interface
+type docuSignProvider struct {
+	privateKey string
+}
+
+// newDocuSignProvider returns a new DocuSign signature provider
+func newDocuSignProvider(privateKey string) SignatureProvider {
+	return &docuSignProvider{
+		privateKey: privateKey,
+	}
+}
+
+// Name returns the provider name
+func (p *docuSignProvider) Name() string {
+	return ProviderDocuSign
+}
+
+// CreateEnvelope creates and sends a DocuSign envelope for the signer. When the request is not sent
+// as an email the signer is an embedded recipient and the returned result contains the sign URL.
+func (p *docuSignProvider) CreateEnvelope(ctx context.Context, req *EnvelopeRequest) (*EnvelopeResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.docuSignProvider.CreateEnvelope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    req.SignatureID,
+		"callbackURL":    req.CallbackURL,
+	}
+
+	signer := DocuSignRecipient{
+		Email:       req.SignerEmail,
+		Name:        req.SignerName,
+		Tabs:        req.Tabs,
+		RecipientId: "1",
+		RoleName:    "signer",
+	}
+	if !req.SendAsEmail {
+		// Assigning a clientUserId does not send an email, the user signs through the embedded sign URL
+		signer.ClientUserId = req.ClientUserID
+	}
+	if language := docuSignSupportedLanguage(req.Locale); language != "" {
+		// The recipient email notification sets the language of the signing email and of the signing experience
+		log.WithFields(f).Debugf("using the signer language: %s", language)
+		signer.EmailNotification = &DocuSignEmailNotification{
+			EmailSubject:      req.EmailSubject,
+			EmailBody:         req.EmailBody,
+			SupportedLanguage: language,
+		}
+	}
+
+	envelopeRequest := DocuSignEnvelopeRequest{
+		Documents: []DocuSignDocument{
+			{
+				Name:           req.DocumentName,
+				DocumentId:     req.DocumentID,
+				FileExtension:  "pdf",
+				FileFormatHint: "pdf",
+				Order:          "1",
+				DocumentBase64: base64.StdEncoding.EncodeToString(req.Document),
+			},
+		},
+		EmailSubject: req.EmailSubject,
+		EmailBlurb:   req.EmailBody,
+		Status:       "sent",
+		Recipients: DocuSignRecipientType{
+			Signers: []DocuSignRecipient{
+				signer,
+			},
+		},
+	}
+
+	if req.CallbackURL != "" {
+		// Webhook properties for callbacks after the user signs the document.
+		// Ensure that a webhook is returned on the status "Completed" where
+		// all signers on a document finish signing the document.
+		log.WithFields(f).Debugf("setting up webhook properties with callback url: %s", req.CallbackURL)
+		envelopeRequest.EventNotification = DocuSignEventNotification{
+			URL:            req.CallbackURL,
+			LoggingEnabled: true,
+			EnvelopeEvents: []DocuSignRecipientEvent{
+				{
+					EnvelopeEventStatusCode: "Completed",
+				},
+			},
+		}
+	}
+
+	envelopeResponse, err := p.PrepareSignRequest(ctx, &envelopeRequest)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create envelope")
+		return nil, err
+	}
+
+	log.WithFields(f).Debugf("envelopeID: %s", envelopeResponse.EnvelopeId)
+	result := &EnvelopeResult{
+		EnvelopeID: envelopeResponse.EnvelopeId,
+	}
+
+	if req.SendAsEmail {
+		return result, nil
+	}
+
+	recipients, err := p.getEnvelopeRecipients(ctx, envelopeResponse.EnvelopeId)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to fetch recipients for envelope: %s", envelopeResponse.EnvelopeId)
+		return nil, err
+	}
+
+	if len(recipients) == 0 {
+		log.WithFields(f).Warnf("no envelope recipients found : %s", envelopeResponse.EnvelopeId)
+		return nil, errors.New("no envelope recipients found")
+	}
+
+	log.WithFields(f).Debugf("generating signature sign_url, using return-url as: %s", req.ReturnURL)
+	signURL, err := p.GetSignURL(signer.Email, signer.RecipientId, signer.Name, signer.ClientUserId, envelopeResponse.EnvelopeId, req.ReturnURL)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to get sign url for envelope: %s", envelopeResponse.EnvelopeId)
+		return nil, err
+	}
+	result.SignURL = signURL
+
+	return result, nil
+}
+
+// docuSignLanguages are the signing languages supported by DocuSign
+var docuSignLanguages = map[string]bool{
+	"ar": true, "bg": true, "cs": true, "da": true, "de": true, "el": true, "en_GB": true, "es": true, "es_MX": true,
+	"et": true, "fa": true, "fi": true, "fr": true, "fr_CA": true, "he": true, "hi": true, "hr": true, "hu": true,
+	"hy": true, "id": true, "it": true, "ja": true, "ko": true, "lt": true, "lv": true, "ms": true, "nl": true,
+	"no": true, "pl": true, "pt": true, "pt_BR": true, "ro": true, "ru": true, "sk": true, "sl": true, "sr": true,
+	"sv": true, "th": true, "tr": true, "uk": true, "vi": true, "zh_CN": true, "zh_TW": true,
+}
+
+// docuSignSupportedLanguage returns the DocuSign signing language of the BCP 47 locale, the region specific language
+// is preferred over the base language. An empty string is returned for English and for the languages DocuSign
+// doesn't support, the account default language is used in that case.
+func docuSignSupportedLanguage(locale string) string {
+	parts := strings.Split(strings.ReplaceAll(locale, "_", "-"), "-")
+	base := strings.ToLower(parts[0])
+	if len(parts) > 1 {
+		regional := base + "_" + strings.ToUpper(parts[len(parts)-1])
+		if docuSignLanguages[regional] {
+			return regional
+		}
+	}
+	if docuSignLanguages[base] {
+		return base
+	}
+	return ""
+}
+
+// ParseCallback parses the DocuSign Connect XML payload
+func (p *docuSignProvider) ParseCallback(ctx context.Context, payload []byte) (*EnvelopeCallback, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.docuSignProvider.ParseCallback",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	if !bytes.HasPrefix(bytes.TrimSpace(payload), []byte("<")) {
+		return nil, ErrCallbackNotRecognized
+	}
+
+	var info DocuSignEnvelopeInformation
+	err := xml.Unmarshal(payload, &info)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		return nil, err
+	}
+
+	if len(info.EnvelopeStatus.RecipientStatuses) == 0 || len(info.EnvelopeStatus.DocumentStatuses) == 0 {
+		log.WithFields(f).Warnf("missing recipient or document status for envelope: %s", info.EnvelopeStatus.EnvelopeID)
+		return nil, errors.New("invalid DocuSign callback payload - missing recipient or document status")
+	}
+
+	recipient := info.EnvelopeStatus.RecipientStatuses[0]
+	return &EnvelopeCallback{
+		Provider:        ProviderDocuSign,
+		EnvelopeID:      info.EnvelopeStatus.EnvelopeID,
+		ClientUserID:    recipient.ClientUserId,
+		RecipientStatus: recipient.Status,
+		EnvelopeStatus:  info.EnvelopeStatus.Status,
+		SignedDate:      recipient.Signed,
+		DocumentID:      info.EnvelopeStatus.DocumentStatuses[0].ID,
+		FullName:        fetchFullName(info),
+	}, nil
+}
+
 // getAccessToken retrieves an access token for the DocuSign API using a JWT assertion.
-func (s *service) getAccessToken(ctx context.Context) (string, error) {
+func (p *docuSignProvider) getAccessToken(ctx context.Context) (string, error) {
 	f := logrus.Fields{
 		"functionName":   "v2.getAccessToken",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
 	}
 
-	jwtAssertion, err := jwtToken(s.docsignPrivateKey)
+	jwtAssertion, err := jwtToken(p.privateKey)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem generating the JWT token")
 		return "", err
@@ -93,7 +280,7 @@ func (s *service) getAccessToken(ctx context.Context) (string, error) {
 }
 
 // Void envelope
-func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
+func (p *docuSignProvider) VoidEnvelope(ctx context.Context, envelopeID, message string) error {
 	f := logrus.Fields{
 		"functionName":   "v2.VoidEnvelope",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -101,7 +288,7 @@ func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string)
 		"message":        message,
 	}
 
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
 		return err
@@ -160,7 +347,7 @@ func (s *service) VoidEnvelope(ctx context.Context, envelopeID, message string)
 
 }
 
-func (s *service) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeRequest) (string, error) {
+func (p *docuSignProvider) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeRequest) (string, error) {
 	f := logrus.Fields{
 		"functionName":   "v2.createEnvelope",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -175,7 +362,7 @@ func (s *service) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeR
 	log.WithFields(f).Debugf("sign request: %+v", string(requestJSON))
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		return "", err
@@ -235,7 +422,7 @@ func (s *service) createEnvelope(ctx context.Context, payload *DocuSignEnvelopeR
 
 }
 
-func (s *service) addDocumentToEnvelope(ctx context.Context, envelopeID, documentName string, document []byte) error {
+func (p *docuSignProvider) addDocumentToEnvelope(ctx context.Context, envelopeID, documentName string, document []byte) error {
 	f := logrus.Fields{
 		"functionName": "v2.addDocumentToEnvelope",
 	}
@@ -243,7 +430,7 @@ func (s *service) addDocumentToEnvelope(ctx context.Context, envelopeID, documen
 	const method = "PUT"
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		return err
@@ -329,14 +516,14 @@ func (s *service) addDocumentToEnvelope(ctx context.Context, envelopeID, documen
 
 }
 
-func (s *service) getEnvelopeRecipients(ctx context.Context, envelopeID string) ([]Signer, error) {
+func (p *docuSignProvider) getEnvelopeRecipients(ctx context.Context, envelopeID string) ([]Signer, error) {
 	f := logrus.Fields{
 		"functionName": "v2.getEnvelopeRecipients",
 		"envelopeID":   envelopeID,
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		return nil, err
@@ -399,7 +586,7 @@ func (s *service) getEnvelopeRecipients(ctx context.Context, envelopeID string)
 }
 
 // Function to create a DocuSign envelope
-func (s *service) PrepareSignRequest(ctx context.Context, signRequest *DocuSignEnvelopeRequest) (*DocusignEnvelopeResponse, error) {
+func (p *docuSignProvider) PrepareSignRequest(ctx context.Context, signRequest *DocuSignEnvelopeRequest) (*DocusignEnvelopeResponse, error) {
 	f := logrus.Fields{
 		"functionName":   "v2.PrepareSignRequest",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -412,7 +599,7 @@ func (s *service) PrepareSignRequest(ctx context.Context, signRequest *DocuSignE
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		return nil, err
@@ -480,7 +667,7 @@ type RecipientViewResponse struct {
 
 // GetSignURL fetches the signing URL for the specified envelope and recipient
 
-func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelopeID, returnURL string) (string, error) {
+func (p *docuSignProvider) GetSignURL(email, recipientID, userName, clientUserId, envelopeID, returnURL string) (string, error) {
 
 	f := logrus.Fields{
 		"functionName": "v2.GetSignURL",
@@ -489,7 +676,7 @@ func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelop
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(context.Background())
+	accessToken, err := p.getAccessToken(context.Background())
 
 	if err != nil {
 		return "", err
@@ -566,14 +753,14 @@ func (s *service) GetSignURL(email, recipientID, userName, clientUserId, envelop
 	return viewResponse.URL, nil
 }
 
-func (s service) GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error) {
+func (p *docuSignProvider) GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error) {
 	f := logrus.Fields{
 		"functionName": "v2.getSignedDocument",
 		"envelopeID":   envelopeID,
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
@@ -624,14 +811,14 @@ func (s service) GetSignedDocument(ctx context.Context, envelopeID, documentID s
 
 }
 
-func (s *service) GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error) {
+func (p *docuSignProvider) GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error) {
 	f := logrus.Fields{
 		"functionName": "v2.GetEnvelopeDocuments",
 		"envelopeID":   envelopeID,
 	}
 
 	// Get the access token
-	accessToken, err := s.getAccessToken(ctx)
+	accessToken, err := p.getAccessToken(ctx)
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("problem getting the access token")
@@ -6,12 +6,18 @@ package sign
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"strings"
 
+	"github.com/LF-Engineering/aws-lambda-go-api-proxy/core"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/go-openapi/runtime"
+
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
 	"github.com/linuxfoundation/easycla/cla-backend-go/users"
@@ -98,7 +104,7 @@ func Configure(api *operations.EasyclaAPI, service Service, userService users.Se
 				return sign.NewRequestCorporateSignatureForbidden().WithPayload(utils.ErrorResponseForbidden(reqID, msg))
 			}
 
-			resp, err := service.RequestCorporateSignature(ctx, utils.StringValue(params.XUSERNAME), params.Authorization, params.Input)
+			resp, err := service.RequestCorporateSignature(ctx, utils.StringValue(params.XUSERNAME), params.Authorization, utils.StringValue(params.AcceptLanguage), params.Input)
 			if err != nil {
 				if strings.Contains(err.Error(), "does not exist") {
 					return sign.NewRequestCorporateSignatureNotFound().WithPayload(errorResponse(reqID, err))
@@ -148,7 +154,8 @@ func Configure(api *operations.EasyclaAPI, service Service, userService users.Se
 			var err error
 			var preferredEmail string
 
-			if strings.ToLower(params.Input.ReturnURLType) == Github || strings.ToLower(params.Input.ReturnURLType) == Gitlab {
+			returnURLType := strings.ToLower(params.Input.ReturnURLType)
+			if returnURLType == Github || returnURLType == Gitlab || returnURLType == Bitbucket || returnURLType == Gitea {
 				log.WithFields(f).Debug("fetching user emails")
 				user, userErr := userService.GetUser(*params.Input.UserID)
 				if userErr != nil {
@@ -160,11 +167,11 @@ func Configure(api *operations.EasyclaAPI, service Service, userService users.Se
 					return sign.NewRequestIndividualSignatureBadRequest().WithPayload(errorResponse(reqId, errors.New(msg)))
 				}
 				preferredEmail = user.Emails[0]
-				log.WithFields(f).Debug("requesting individual signature for github/gitlab")
-				resp, err = service.RequestIndividualSignature(ctx, params.Input, preferredEmail)
-			} else if strings.ToLower(params.Input.ReturnURLType) == "gerrit" {
+				log.WithFields(f).Debug("requesting individual signature for github/gitlab/bitbucket/gitea")
+				resp, err = service.RequestIndividualSignature(ctx, params.Input, preferredEmail, utils.StringValue(params.AcceptLanguage))
+			} else if returnURLType == "gerrit" {
 				log.WithFields(f).Debug("requesting individual signature for gerrit")
-				resp, err = service.RequestIndividualSignatureGerrit(ctx, params.Input)
+				resp, err = service.RequestIndividualSignatureGerrit(ctx, params.Input, utils.StringValue(params.AcceptLanguage))
 			} else {
 				msg := fmt.Sprintf("invalid return URL type: %s", params.Input.ReturnURLType)
 				log.WithFields(f).Warn(msg)
@@ -213,6 +220,40 @@ func Configure(api *operations.EasyclaAPI, service Service, userService users.Se
 			return sign.NewCclaCallbackOK()
 		})
 
+	api.SignIclaCallbackBitbucketHandler = sign.IclaCallbackBitbucketHandlerFunc(
+		func(params sign.IclaCallbackBitbucketParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignIclaCallbackBitbucketHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			}
+			log.WithFields(f).Debug("bitbucket callback")
+
+			err := service.SignedIndividualCallbackBitbucket(ctx, iclaGitHubPayload, params.UserID, params.OrganizationID, params.BitbucketRepositoryID, params.PullRequestID)
+			if err != nil {
+				return sign.NewIclaCallbackBitbucketBadRequest()
+			}
+			return sign.NewCclaCallbackOK()
+		})
+
+	api.SignIclaCallbackGiteaHandler = sign.IclaCallbackGiteaHandlerFunc(
+		func(params sign.IclaCallbackGiteaParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignIclaCallbackGiteaHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			}
+			log.WithFields(f).Debug("gitea callback")
+
+			err := service.SignedIndividualCallbackGitea(ctx, iclaGitHubPayload, params.UserID, params.OrganizationID, params.GiteaRepositoryID, params.PullRequestID)
+			if err != nil {
+				return sign.NewIclaCallbackGiteaBadRequest()
+			}
+			return sign.NewCclaCallbackOK()
+		})
+
 	api.SignIclaCallbackGerritHandler = sign.IclaCallbackGerritHandlerFunc(
 		func(params sign.IclaCallbackGerritParams) middleware.Responder {
 			reqId := utils.GetRequestID(params.XREQUESTID)
@@ -248,12 +289,144 @@ func Configure(api *operations.EasyclaAPI, service Service, userService users.Se
 			return sign.NewCclaCallbackOK()
 		})
 
+	api.SignGetClickThroughEnvelopeHandler = sign.GetClickThroughEnvelopeHandlerFunc(
+		func(params sign.GetClickThroughEnvelopeParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignGetClickThroughEnvelopeHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"envelopeID":     params.EnvelopeID,
+			}
+
+			envelope, err := service.GetClickThroughEnvelope(ctx, params.EnvelopeID, params.Token, clientIPAddress(params.HTTPRequest), params.HTTPRequest.UserAgent())
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to load click-through envelope")
+				if err == ErrClickThroughEnvelopeNotFound {
+					return sign.NewGetClickThroughEnvelopeNotFound().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+				}
+				if err == ErrClickThroughInvalidToken {
+					return sign.NewGetClickThroughEnvelopeForbidden().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+				}
+				return sign.NewGetClickThroughEnvelopeInternalServerError().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+			}
+
+			return sign.NewGetClickThroughEnvelopeOK().WithXRequestID(reqId).WithPayload(&models.ClickThroughEnvelope{
+				EnvelopeID:     envelope.EnvelopeID,
+				DocumentName:   envelope.DocumentName,
+				DocumentURL:    envelope.DocumentURL,
+				DocumentSha256: envelope.DocumentSHA256,
+				Locale:         envelope.Locale,
+				SignerName:     envelope.SignerName,
+				SignerEmail:    envelope.SignerEmail,
+				Status:         envelope.Status,
+			})
+		})
+
+	api.SignGetClickThroughSigningPageHandler = sign.GetClickThroughSigningPageHandlerFunc(
+		func(params sign.GetClickThroughSigningPageParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignGetClickThroughSigningPageHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"envelopeID":     params.EnvelopeID,
+			}
+
+			return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+				page, err := service.GetClickThroughSigningPage(ctx, params.EnvelopeID, params.Token, clientIPAddress(params.HTTPRequest), params.HTTPRequest.UserAgent())
+				if err != nil {
+					log.WithFields(f).WithError(err).Warn("unable to render click-through signing page")
+					switch err {
+					case ErrClickThroughEnvelopeNotFound:
+						http.Error(rw, err.Error(), http.StatusNotFound)
+					case ErrClickThroughInvalidToken:
+						http.Error(rw, err.Error(), http.StatusForbidden)
+					default:
+						http.Error(rw, "unable to load the document", http.StatusInternalServerError)
+					}
+					return
+				}
+
+				rw.Header().Set(runtime.HeaderContentType, "text/html; charset=utf-8")
+				rw.Header().Set("Cache-Control", "no-store")
+				rw.WriteHeader(http.StatusOK)
+				if _, err = rw.Write(page); err != nil {
+					log.WithFields(f).WithError(err).Warn("problem writing the click-through signing page")
+				}
+			})
+		})
+
+	api.SignAcceptClickThroughEnvelopeHandler = sign.AcceptClickThroughEnvelopeHandlerFunc(
+		func(params sign.AcceptClickThroughEnvelopeParams) middleware.Responder {
+			reqId := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTIDKey, reqId)
+			f := logrus.Fields{
+				"functionName":   "v2.sign.handlers.SignAcceptClickThroughEnvelopeHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"envelopeID":     params.EnvelopeID,
+			}
+
+			if !utils.BoolValue(params.Input.Accept) {
+				msg := "the document must be accepted to complete the signature"
+				log.WithFields(f).Warn(msg)
+				return sign.NewAcceptClickThroughEnvelopeBadRequest().WithXRequestID(reqId).WithPayload(errorResponse(reqId, errors.New(msg)))
+			}
+
+			fullName := strings.TrimSpace(utils.StringValue(params.Input.FullName))
+			if fullName == "" {
+				msg := "full_name is required to complete the signature"
+				log.WithFields(f).Warn(msg)
+				return sign.NewAcceptClickThroughEnvelopeBadRequest().WithXRequestID(reqId).WithPayload(errorResponse(reqId, errors.New(msg)))
+			}
+
+			envelope, err := service.AcceptClickThroughEnvelope(ctx, params.EnvelopeID, utils.StringValue(params.Input.Token), fullName, clientIPAddress(params.HTTPRequest), params.HTTPRequest.UserAgent())
+			if err != nil {
+				log.WithFields(f).WithError(err).Warn("unable to accept click-through envelope")
+				if err == ErrClickThroughEnvelopeNotFound {
+					return sign.NewAcceptClickThroughEnvelopeNotFound().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+				}
+				if err == ErrClickThroughInvalidToken {
+					return sign.NewAcceptClickThroughEnvelopeForbidden().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+				}
+				if err == ErrClickThroughSignerMismatch {
+					return sign.NewAcceptClickThroughEnvelopeBadRequest().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+				}
+				if err == ErrClickThroughEnvelopeNotPending {
+					return sign.NewAcceptClickThroughEnvelopeConflict().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+				}
+				return sign.NewAcceptClickThroughEnvelopeInternalServerError().WithXRequestID(reqId).WithPayload(errorResponse(reqId, err))
+			}
+
+			return sign.NewAcceptClickThroughEnvelopeOK().WithXRequestID(reqId).WithPayload(&models.ClickThroughAcceptOutput{
+				EnvelopeID: envelope.EnvelopeID,
+				Status:     envelope.Status,
+				ReturnURL:  envelope.ReturnURL,
+			})
+		})
 }
 
 type codedResponse interface {
 	Code() string
 }
 
+// clientIPAddress returns the address of the client. In the lambda the source IP of the API Gateway request
+// context is used, the X-Forwarded-For header is sent by the client and can't be trusted. The lambda proxy adds
+// the request context header after the client headers, so only the last value is considered.
+func clientIPAddress(r *http.Request) string {
+	if values := r.Header.Values(core.APIGwContextHeader); len(values) > 0 {
+		var requestContext events.APIGatewayProxyRequestContext
+		if err := json.Unmarshal([]byte(values[len(values)-1]), &requestContext); err == nil && requestContext.Identity.SourceIP != "" {
+			return requestContext.Identity.SourceIP
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
 func errorResponse(reqID string, err error) *models.ErrorResponse {
 	code := ""
 	if e, ok := err.(codedResponse); ok {
@@ -7,8 +7,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strconv"
 
-	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	"github.com/linuxfoundation/easycla/cla-backend-go/github"
 	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
@@ -53,94 +54,23 @@ func (s service) updateChangeRequest(ctx context.Context, installationID, reposi
 	}
 	log.WithFields(f).Debugf("found %d commit authors for %s/%s for PR: %d", len(authors), gitHubOrgName, gitHubRepoName, pullRequestID)
 
-	signed := make([]*github.UserCommitSummary, 0)
-	unsigned := make([]*github.UserCommitSummary, 0)
+	// bots of the CLA Group and GitHub organization allowlists are exempt from the CLA check
+	authors, exempt := github.SplitExemptBotAuthors(authors, s.gitHubBotAllowlist(ctx, projectID, gitHubOrgName))
+	s.logBotExemptions(ctx, projectID, fmt.Sprintf("%s/%s", gitHubOrgName, gitHubRepoName), pullRequestID, exempt)
 
 	// triage signed and unsigned users
 	log.WithFields(f).Debugf("triaging %d commit authors for PR: %d using repository %s/%s",
 		len(authors), pullRequestID, gitHubOrgName, gitHubRepoName)
-	for _, userSummary := range authors {
-
-		if !userSummary.IsValid() {
-			log.WithFields(f).Debugf("invalid user summary: %+v", *userSummary)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		commitAuthorID := userSummary.GetCommitAuthorID()
-		commitAuthorUsername := userSummary.GetCommitAuthorUsername()
-		commitAuthorEmail := userSummary.GetCommitAuthorEmail()
-
-		log.WithFields(f).Debugf("checking user - sha: %s, user ID: %s, username: %s, email: %s",
-			userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
-
-		var user *models.User
-		var userErr error
-
-		if commitAuthorID != "" {
-			log.WithFields(f).Debugf("looking up user by ID: %s", commitAuthorID)
-			user, userErr = s.userService.GetUserByGitHubID(commitAuthorID)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github id: %s", commitAuthorID)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by ID: %s", commitAuthorID)
-			}
-		}
-		if user == nil && commitAuthorUsername != "" {
-			log.WithFields(f).Debugf("looking up user by username: %s", commitAuthorUsername)
-			user, userErr = s.userService.GetUserByGitHubUsername(commitAuthorUsername)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by github username: %s", commitAuthorUsername)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by username: %s", commitAuthorUsername)
-			}
-		}
-		if user == nil && commitAuthorEmail != "" {
-			log.WithFields(f).Debugf("looking up user by email: %s", commitAuthorEmail)
-			user, userErr = s.userService.GetUserByEmail(commitAuthorEmail)
-			if userErr != nil {
-				log.WithFields(f).WithError(userErr).Warnf("unable to get user by user email: %s", commitAuthorEmail)
-			}
-			if user != nil {
-				log.WithFields(f).Debugf("found user by email: %s", commitAuthorEmail)
-			}
-		}
-
-		if user == nil {
-			log.WithFields(f).Debugf("unable to find user for commit author - sha: %s, user ID: %s, username: %s, email: %s",
-				userSummary.SHA, commitAuthorID, commitAuthorUsername, commitAuthorEmail)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		log.WithFields(f).Debugf("checking to see if user has signed an ICLA or ECLA for project: %s", projectID)
-		userSigned, companyAffiliation, signedErr := s.hasUserSigned(ctx, user, projectID)
-		if signedErr != nil {
-			log.WithFields(f).WithError(signedErr).Warnf("has user signed error - user: %+v, project: %s", user, projectID)
-			unsigned = append(unsigned, userSummary)
-			continue
-		}
-
-		if companyAffiliation != nil {
-			userSummary.Affiliated = *companyAffiliation
-		}
-
-		if userSigned != nil {
-			userSummary.Authorized = *userSigned
-			if userSummary.Authorized {
-				signed = append(signed, userSummary)
-			} else {
-				unsigned = append(unsigned, userSummary)
-			}
-		}
+	signed, unsigned, evalErr := s.claCheckService.TriageGitHubAuthors(ctx, projectID, authors)
+	if evalErr != nil {
+		log.WithFields(f).WithError(evalErr).Warnf("unable to evaluate the commit authors of PR: %d", pullRequestID)
+		return evalErr
 	}
 
 	log.WithFields(f).Debugf("commit authors status => signed: %+v and missing: %+v", signed, unsigned)
 
 	// update pull request
-	updateErr := github.UpdatePullRequest(ctx, installationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, s.ClaV1ApiURL, s.claLandingPage, s.claLogoURL)
+	updateErr := github.UpdatePullRequest(ctx, installationID, int(pullRequestID), gitHubOrgName, gitHubRepoName, githubRepository.ID, *latestSHA, signed, unsigned, exempt, s.ClaV1ApiURL, s.claLandingPage, s.claLogoURL)
 	if updateErr != nil {
 		log.WithFields(f).Debugf("unable to update PR: %d", pullRequestID)
 		return updateErr
@@ -149,75 +79,52 @@ func (s service) updateChangeRequest(ctx context.Context, installationID, reposi
 	return nil
 }
 
-// hasUserSigned checks to see if the user has signed an ICLA or ECLA for the project, returns:
-// false, false, nil if user is not authorized for ICLA or ECLA
-// false, false, some error if user is not authorized for ICLA or ECLA - we has some problem looking up stuff
-// true, false, nil if user has an ICLA (authorized, but not company affiliation, no error)
-// true, true, nil if user has an ECLA (authorized, with company affiliation, no error)
-func (s service) hasUserSigned(ctx context.Context, user *models.User, projectID string) (*bool, *bool, error) {
+// gitHubBotAllowlist returns the union of the CLA Group and the GitHub organization bot allowlists - an allowlist which
+// can't be loaded is skipped, its bots are then checked as any other commit author
+func (s service) gitHubBotAllowlist(ctx context.Context, claGroupID, gitHubOrgName string) *utils.BotAllowlist {
 	f := logrus.Fields{
-		"functionName": "v1.signatures.service.updateChangeRequest",
-		"projectID":    projectID,
-		"user":         user,
+		"functionName":   "v2.sign.helpers.gitHubBotAllowlist",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"gitHubOrgName":  gitHubOrgName,
 	}
-	var hasSigned bool
-	var companyAffiliation bool
-
-	approved := true
-	signed := true
 
-	// Check for ICLA
-	log.WithFields(f).Debugf("checking to see if user has signed an ICLA")
-	signature, sigErr := s.signatureService.GetIndividualSignature(ctx, projectID, user.UserID, &approved, &signed)
-	if sigErr != nil {
-		log.WithFields(f).WithError(sigErr).Warnf("problem checking for ICLA signature for user: %s", user.UserID)
-		return &hasSigned, &companyAffiliation, sigErr
+	var claGroupAllowlist, orgAllowlist *utils.BotAllowlist
+	claGroup, claGroupErr := s.projectRepo.GetCLAGroupByID(ctx, claGroupID, false)
+	if claGroupErr != nil || claGroup == nil {
+		log.WithFields(f).WithError(claGroupErr).Warn("unable to load the CLA Group bot allowlist")
+	} else {
+		claGroupAllowlist = utils.BotAllowlistFromV1Model(claGroup.BotAllowlist)
 	}
-	if signature != nil {
-		hasSigned = true
-		log.WithFields(f).Debugf("ICLA signature check passed for user: %+v on project : %s", user, projectID)
-		return &hasSigned, &companyAffiliation, nil // ICLA passes, no company affiliation
+	gitHubOrg, orgErr := s.githubOrgService.GetGitHubOrganizationByName(ctx, gitHubOrgName)
+	if orgErr != nil || gitHubOrg == nil {
+		log.WithFields(f).WithError(orgErr).Warn("unable to load the GitHub organization bot allowlist")
 	} else {
-		log.WithFields(f).Debugf("ICLA signature check failed for user: %+v on project: %s - ICLA not signed", user, projectID)
+		orgAllowlist = utils.BotAllowlistFromV1Model(gitHubOrg.BotAllowlist)
 	}
+	return utils.MergeBotAllowlists(claGroupAllowlist, orgAllowlist)
+}
 
-	// Check for Employee Acknowledgment ECLA
-	companyID := user.CompanyID
-	log.WithFields(f).Debugf("checking to see if user has signed a ECLA for company: %s", companyID)
-
-	if companyID != "" {
-		companyAffiliation = true
-
-		// Get employee signature
-		log.WithFields(f).Debugf("ECLA signature check - user has a company: %s - looking for user's employee acknowledgement...", companyID)
-
-		// Load the company - make sure it is valid
-		companyModel, compModelErr := s.companyService.GetCompany(ctx, companyID)
-		if compModelErr != nil {
-			log.WithFields(f).WithError(compModelErr).Warnf("problem looking up company: %s", companyID)
-			return &hasSigned, &companyAffiliation, compModelErr
-		}
-
-		// Load the CLA Group - make sure it is valid
-		claGroupModel, claGroupModelErr := s.claGroupService.GetCLAGroup(ctx, projectID)
-		if claGroupModelErr != nil {
-			log.WithFields(f).WithError(claGroupModelErr).Warnf("problem looking up project: %s", projectID)
-			return &hasSigned, &companyAffiliation, claGroupModelErr
-		}
-
-		employeeSigned, err := s.signatureService.ProcessEmployeeSignature(ctx, companyModel, claGroupModel, user)
-
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("problem looking up employee signature for company: %s", companyID)
-			return &hasSigned, &companyAffiliation, err
+// logBotExemptions logs an event for each commit author exempt from the CLA check by the bot allowlist
+func (s service) logBotExemptions(ctx context.Context, claGroupID, repositoryName string, pullRequestID int64, exempt []*github.UserCommitSummary) {
+	for _, summary := range exempt {
+		email := summary.GetCommitAuthorEmail()
+		if email == "" {
+			email = summary.GitEmail
 		}
-		if employeeSigned != nil {
-			hasSigned = *employeeSigned
-		}
-
-	} else {
-		log.WithFields(f).Debugf("ECLA signature check - user does not have a company ID assigned - skipping...")
+		s.eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:  events.CLACheckBotExempted,
+			CLAGroupID: claGroupID,
+			UserID:     "easycla system",
+			UserName:   "easycla system",
+			EventData: &events.CLACheckBotExemptedEventData{
+				Provider:        utils.GitHubType,
+				RepositoryName:  repositoryName,
+				ChangeRequestID: strconv.FormatInt(pullRequestID, 10),
+				Login:           summary.GetCommitAuthorUsername(),
+				Email:           email,
+				AllowlistEntry:  summary.ExemptBy,
+			},
+		})
 	}
-
-	return &hasSigned, &companyAffiliation, nil
 }
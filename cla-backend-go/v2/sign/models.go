@@ -136,6 +136,15 @@ type DocuSignRecipient struct {
 	Note      string `json:"note,omitempty"`      // A note sent to the recipient in the signing email. This note is unique to this recipient. In the user interface, it appears near the upper left corner of the document on the signing screen. Maximum Length: 1000 characters.
 
 	Tabs DocuSignTab `json:"tabs"` // The tabs associated with the recipient. The tabs property enables you to programmatically position tabs on the document. For example, you can specify that the SIGN_HERE tab is placed at a given (x,y) location on the document. You can also specify the font, font color, font size, and other properties of the text in the tab. You can also specify the location and size of the tab. For example, you can specify that the tab is 50 pixels wide and 20 pixels high. You can also specify the page number on which the tab is located and whether the tab is located in a document, a template, or an inline template. For more information about tabs, see the Tabs section of the REST API documentation.
+
+	EmailNotification *DocuSignEmailNotification `json:"emailNotification,omitempty"` // The email and signing experience language of the recipient, overrides the envelope email subject and blurb.
+}
+
+// DocuSignEmailNotification is the recipient specific email notification
+type DocuSignEmailNotification struct {
+	EmailSubject      string `json:"emailSubject,omitempty"`      // The subject of the email sent to the recipient. Maximum Length: 100 characters.
+	EmailBody         string `json:"emailBody,omitempty"`         // The body of the email sent to the recipient. Maximum Length: 10000 characters.
+	SupportedLanguage string `json:"supportedLanguage,omitempty"` // The language of the email and the signing experience, for example: fr, pt_BR or zh_CN.
 }
 
 // TextOptionalTab
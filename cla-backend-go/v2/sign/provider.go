@@ -0,0 +1,166 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"context"
+	"errors"
+
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+)
+
+const (
+	// ProviderDocuSign is the name of the DocuSign signature provider
+	ProviderDocuSign = "docusign"
+	// ProviderClickThrough is the name of the built-in click-through signature provider
+	ProviderClickThrough = "click-through"
+
+	// EnvelopeStatusCompleted is the provider neutral status reported once the signer has completed the envelope
+	EnvelopeStatusCompleted = DocusignCompleted
+)
+
+// ErrCallbackNotRecognized is returned by a provider when the callback payload was not produced by that provider
+var ErrCallbackNotRecognized = errors.New("callback payload not recognized by the signature provider")
+
+// SignatureProvider is the abstraction over the e-signature backends used to collect CLA signatures.
+// DocuSign is the default implementation, the click-through provider is a lightweight alternative
+// for CLA groups that don't require a full e-signature platform.
+type SignatureProvider interface {
+	// Name returns the provider name that is stored on the signature record
+	Name() string
+	// CreateEnvelope creates a new envelope for the signer and returns the envelope ID and,
+	// for embedded signing, the URL the signer should be redirected to
+	CreateEnvelope(ctx context.Context, req *EnvelopeRequest) (*EnvelopeResult, error)
+	// VoidEnvelope voids a pending envelope so that the signer can't complete it anymore
+	VoidEnvelope(ctx context.Context, envelopeID, message string) error
+	// ParseCallback parses the provider specific callback payload into a provider neutral callback
+	ParseCallback(ctx context.Context, payload []byte) (*EnvelopeCallback, error)
+	// GetSignedDocument returns the signed PDF for the envelope
+	GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error)
+}
+
+// EnvelopeRequest is the provider neutral signing request
+type EnvelopeRequest struct {
+	SignatureID  string
+	DocumentID   string
+	DocumentName string
+	Document     []byte
+	Tabs         DocuSignTab
+	SignerName   string
+	SignerEmail  string
+	ClientUserID string
+	EmailSubject string
+	EmailBody    string
+	CallbackURL  string
+	ReturnURL    string
+	SendAsEmail  bool
+	// Locale is the BCP 47 locale of the document, providers use it for the signer facing messages
+	Locale string
+}
+
+// EnvelopeResult is the result of creating an envelope
+type EnvelopeResult struct {
+	EnvelopeID string
+	SignURL    string
+}
+
+// EnvelopeCallback is the provider neutral representation of a signing callback
+type EnvelopeCallback struct {
+	Provider        string
+	EnvelopeID      string
+	ClientUserID    string
+	RecipientStatus string
+	EnvelopeStatus  string
+	SignedDate      string
+	DocumentID      string
+	FullName        string
+}
+
+// getSignatureProvider returns the provider registered under the specified name, an empty name
+// resolves to DocuSign as signatures created before the provider was recorded were all DocuSign envelopes
+func (s *service) getSignatureProvider(name string) (SignatureProvider, error) {
+	if name == "" {
+		name = ProviderDocuSign
+	}
+	provider, ok := s.signatureProviders[name]
+	if !ok {
+		return nil, errors.New("unsupported signature provider: " + name)
+	}
+	return provider, nil
+}
+
+// getCLAGroupSignatureProvider returns the provider collecting the signatures of the CLA Group, the CLA Groups
+// without a provider use the default one of the deployment
+func (s *service) getCLAGroupSignatureProvider(claGroup *v1Models.ClaGroup) (SignatureProvider, error) {
+	if claGroup.SignatureProvider != "" {
+		return s.getSignatureProvider(claGroup.SignatureProvider)
+	}
+	return s.getSignatureProvider(s.signatureProvider)
+}
+
+// parseEnvelopeCallback asks each registered provider to parse the callback payload, the first
+// provider that recognizes the payload wins
+func (s *service) parseEnvelopeCallback(ctx context.Context, payload []byte) (*EnvelopeCallback, error) {
+	for _, name := range []string{ProviderClickThrough, ProviderDocuSign} {
+		provider, ok := s.signatureProviders[name]
+		if !ok {
+			continue
+		}
+		callback, err := provider.ParseCallback(ctx, payload)
+		if errors.Is(err, ErrCallbackNotRecognized) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return callback, nil
+	}
+	return nil, ErrCallbackNotRecognized
+}
+
+// VoidEnvelope voids the envelope using the signature provider that created it
+func (s *service) VoidEnvelope(ctx context.Context, signatureProvider, envelopeID, message string) error {
+	provider, err := s.getSignatureProvider(signatureProvider)
+	if err != nil {
+		return err
+	}
+	return provider.VoidEnvelope(ctx, envelopeID, message)
+}
+
+// GetSignedDocument returns the signed document using the signature provider that created the envelope
+func (s *service) GetSignedDocument(ctx context.Context, signatureProvider, envelopeID, documentID string) ([]byte, error) {
+	provider, err := s.getSignatureProvider(signatureProvider)
+	if err != nil {
+		return nil, err
+	}
+	return provider.GetSignedDocument(ctx, envelopeID, documentID)
+}
+
+// GetEnvelopeDocuments returns the documents of a DocuSign envelope
+func (s *service) GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error) {
+	provider, ok := s.signatureProviders[ProviderDocuSign].(*docuSignProvider)
+	if !ok {
+		return nil, errors.New("docusign signature provider not configured")
+	}
+	return provider.GetEnvelopeDocuments(ctx, envelopeID)
+}
+
+// GetClickThroughEnvelope returns the click-through envelope and records the view in the audit trail
+func (s *service) GetClickThroughEnvelope(ctx context.Context, envelopeID, token, ipAddress, userAgent string) (*ClickThroughEnvelope, error) {
+	return s.clickThroughProvider.ViewEnvelope(ctx, envelopeID, token, ipAddress, userAgent)
+}
+
+// AcceptClickThroughEnvelope records the signer acceptance of the click-through envelope
+func (s *service) AcceptClickThroughEnvelope(ctx context.Context, envelopeID, token, fullName, ipAddress, userAgent string) (*ClickThroughEnvelope, error) {
+	return s.clickThroughProvider.AcceptEnvelope(ctx, envelopeID, token, fullName, ipAddress, userAgent)
+}
+
+// GetClickThroughSigningPage returns the HTML signing page of the click-through envelope
+func (s *service) GetClickThroughSigningPage(ctx context.Context, envelopeID, token, ipAddress, userAgent string) ([]byte, error) {
+	envelope, err := s.clickThroughProvider.ViewEnvelope(ctx, envelopeID, token, ipAddress, userAgent)
+	if err != nil {
+		return nil, err
+	}
+	return s.clickThroughProvider.RenderSigningPage(envelope, token)
+}
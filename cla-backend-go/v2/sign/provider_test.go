@@ -0,0 +1,34 @@
+// Copyright The Linux Foundation and each contributor to LFX.
+// SPDX-License-Identifier: MIT
+
+package sign
+
+import (
+	"testing"
+
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCLAGroupSignatureProvider(t *testing.T) {
+	clickThrough, _ := newTestClickThroughProvider()
+	s := &service{
+		signatureProviders: map[string]SignatureProvider{
+			ProviderDocuSign:     &docuSignProvider{},
+			ProviderClickThrough: clickThrough,
+		},
+		signatureProvider: ProviderDocuSign,
+	}
+
+	// the CLA Groups without a provider use the deployment default
+	provider, err := s.getCLAGroupSignatureProvider(&v1Models.ClaGroup{ProjectID: "default-group"})
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderDocuSign, provider.Name())
+
+	provider, err = s.getCLAGroupSignatureProvider(&v1Models.ClaGroup{ProjectID: "click-through-group", SignatureProvider: ProviderClickThrough})
+	assert.NoError(t, err)
+	assert.Equal(t, ProviderClickThrough, provider.Name())
+
+	_, err = s.getCLAGroupSignatureProvider(&v1Models.ClaGroup{ProjectID: "unknown-group", SignatureProvider: "unknown"})
+	assert.EqualError(t, err, "unsupported signature provider: unknown")
+}
@@ -5,8 +5,6 @@ package sign
 
 import (
 	"context"
-	"encoding/base64"
-	"encoding/xml"
 	"errors"
 	"fmt"
 	"io"
@@ -29,7 +27,10 @@ import (
 	"github.com/linuxfoundation/easycla/cla-backend-go/repositories"
 	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
 	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	bitbucket_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/bitbucket-activity"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_check"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/cla_groups"
+	gitea_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gitea-activity"
 	gitlab_activity "github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab-activity"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/gitlab_organizations"
 	"github.com/linuxfoundation/easycla/cla-backend-go/v2/store"
@@ -75,19 +76,20 @@ type ProjectRepo interface {
 
 // Service interface defines the sign service methods
 type Service interface {
-	VoidEnvelope(ctx context.Context, envelopeID, message string) error
-	PrepareSignRequest(ctx context.Context, signRequest *DocuSignEnvelopeRequest) (*DocusignEnvelopeResponse, error)
-	GetSignURL(email, recipientID, userName, clientUserId, envelopeID, returnURL string) (string, error)
-	createEnvelope(ctx context.Context, payload *DocuSignEnvelopeRequest) (string, error)
-	addDocumentToEnvelope(ctx context.Context, envelopeID, documentName string, document []byte) error
-	GetSignedDocument(ctx context.Context, envelopeID, documentID string) ([]byte, error)
+	VoidEnvelope(ctx context.Context, signatureProvider, envelopeID, message string) error
+	GetSignedDocument(ctx context.Context, signatureProvider, envelopeID, documentID string) ([]byte, error)
 	GetEnvelopeDocuments(ctx context.Context, envelopeID string) ([]DocuSignDocument, error)
+	GetClickThroughEnvelope(ctx context.Context, envelopeID, token, ipAddress, userAgent string) (*ClickThroughEnvelope, error)
+	GetClickThroughSigningPage(ctx context.Context, envelopeID, token, ipAddress, userAgent string) ([]byte, error)
+	AcceptClickThroughEnvelope(ctx context.Context, envelopeID, token, fullName, ipAddress, userAgent string) (*ClickThroughEnvelope, error)
 
-	RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error)
-	RequestIndividualSignature(ctx context.Context, input *models.IndividualSignatureInput, preferredEmail string) (*models.IndividualSignatureOutput, error)
-	RequestIndividualSignatureGerrit(ctx context.Context, input *models.IndividualSignatureInput) (*models.IndividualSignatureOutput, error)
+	RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, acceptLanguage string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error)
+	RequestIndividualSignature(ctx context.Context, input *models.IndividualSignatureInput, preferredEmail string, acceptLanguage string) (*models.IndividualSignatureOutput, error)
+	RequestIndividualSignatureGerrit(ctx context.Context, input *models.IndividualSignatureInput, acceptLanguage string) (*models.IndividualSignatureOutput, error)
 	SignedIndividualCallbackGithub(ctx context.Context, payload []byte, installationID, changeRequestID, repositoryID string) error
 	SignedIndividualCallbackGitlab(ctx context.Context, payload []byte, userID, organizationID, repositoryID, mergeRequestID string) error
+	SignedIndividualCallbackBitbucket(ctx context.Context, payload []byte, userID, organizationID, repositoryID, pullRequestID string) error
+	SignedIndividualCallbackGitea(ctx context.Context, payload []byte, userID, organizationID, repositoryID, pullRequestID string) error
 	SignedIndividualCallbackGerrit(ctx context.Context, payload []byte, userID string) error
 	SignedCorporateCallback(ctx context.Context, payload []byte, companyID, projectID string) error
 }
@@ -101,7 +103,9 @@ type service struct {
 	projectClaGroupsRepo  projects_cla_groups.Repository
 	companyService        company.IService
 	claGroupService       cla_groups.Service
-	docsignPrivateKey     string
+	signatureProviders    map[string]SignatureProvider
+	signatureProvider     string
+	clickThroughProvider  *clickThroughProvider
 	userService           users.Service
 	signatureService      signatures.SignatureService
 	storeRepository       store.Repository
@@ -115,12 +119,24 @@ type service struct {
 	gitlabActivityService gitlab_activity.Service
 	gitlabApp             *gitlab_api.App
 	gerritService         gerrits.Service
+
+	bitbucketActivityService bitbucket_activity.Service
+	giteaActivityService     gitea_activity.Service
+	claCheckService          cla_check.Service
 }
 
 // NewService returns an instance of v2 project service
 func NewService(apiURL, v1API string, compRepo company.IRepository, projectRepo ProjectRepo, pcgRepo projects_cla_groups.Repository, compService company.IService, claGroupService cla_groups.Service, docsignPrivateKey string, userService users.Service, signatureService signatures.SignatureService, storeRepository store.Repository,
 	repositoryService repositories.Service, githubOrgService github_organizations.Service, gitlabOrgService gitlab_organizations.ServiceInterface, claLandingPage string, claLogoURL string, emailTemplateService emails.EmailTemplateService, eventsService events.Service, gitlabActivityService gitlab_activity.Service, gitlabApp *gitlab_api.App,
-	gerritService gerrits.Service) Service {
+	gerritService gerrits.Service, signatureProvider string, bitbucketActivityService bitbucket_activity.Service, giteaActivityService gitea_activity.Service, claCheckService cla_check.Service) Service {
+	if signatureProvider == "" {
+		signatureProvider = ProviderDocuSign
+	}
+	clickThrough := newClickThroughProvider(apiURL)
+	signatureProviders := map[string]SignatureProvider{
+		ProviderDocuSign:     newDocuSignProvider(docsignPrivateKey),
+		ProviderClickThrough: clickThrough,
+	}
 	return &service{
 		ClaV4ApiURL:           apiURL,
 		ClaV1ApiURL:           v1API,
@@ -129,7 +145,9 @@ func NewService(apiURL, v1API string, compRepo company.IRepository, projectRepo
 		projectClaGroupsRepo:  pcgRepo,
 		companyService:        compService,
 		claGroupService:       claGroupService,
-		docsignPrivateKey:     docsignPrivateKey,
+		signatureProviders:    signatureProviders,
+		signatureProvider:     signatureProvider,
+		clickThroughProvider:  clickThrough,
 		userService:           userService,
 		signatureService:      signatureService,
 		storeRepository:       storeRepository,
@@ -143,6 +161,10 @@ func NewService(apiURL, v1API string, compRepo company.IRepository, projectRepo
 		gitlabApp:             gitlabApp,
 		gerritService:         gerritService,
 		eventsService:         eventsService,
+
+		bitbucketActivityService: bitbucketActivityService,
+		giteaActivityService:     giteaActivityService,
+		claCheckService:          claCheckService,
 	}
 }
 
@@ -154,6 +176,8 @@ type requestCorporateSignatureInput struct {
 	AuthorityName     string `json:"authority_name,omitempty"`
 	AuthorityEmail    string `json:"authority_email,omitempty"`
 	ReturnURL         string `json:"return_url,omitempty"`
+	Locale            string `json:"locale,omitempty"`
+	AcceptLanguage    string `json:"-"`
 }
 
 func validateCorporateSignatureInput(input *models.CorporateSignatureInput) error {
@@ -180,7 +204,7 @@ func validateCorporateSignatureInput(input *models.CorporateSignatureInput) erro
 	return nil
 }
 
-func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error) { // nolint
+func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername string, authorizationHeader string, acceptLanguage string, input *models.CorporateSignatureInput) (*models.CorporateSignatureOutput, error) { // nolint
 	f := logrus.Fields{
 		"functionName":      "sign.RequestCorporateSignature",
 		utils.XREQUESTID:    ctx.Value(utils.XREQUESTID),
@@ -354,6 +378,8 @@ func (s *service) RequestCorporateSignature(ctx context.Context, lfUsername stri
 		AuthorityName:     input.AuthorityName,
 		AuthorityEmail:    input.AuthorityEmail.String(),
 		ReturnURL:         input.ReturnURL.String(),
+		Locale:            input.Locale,
+		AcceptLanguage:    acceptLanguage,
 	}, comp, proj, lfUsername, currentUserEmail)
 
 	if err != nil {
@@ -399,20 +425,18 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 
 	log.WithFields(f).Debug("processing signed individual callback...")
 
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	callback, err := s.parseEnvelopeCallback(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signature callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
-	signatureID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
-	status := info.EnvelopeStatus.RecipientStatuses[0].Status
-	signedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
-	documentID := info.EnvelopeStatus.DocumentStatuses[0].ID
-	fullName := fetchFullName(info)
+	envelopeID := callback.EnvelopeID
+	signatureID := callback.ClientUserID
+	status := callback.RecipientStatus
+	signedDate := callback.SignedDate
+	documentID := callback.DocumentID
+	fullName := callback.FullName
 
 	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", envelopeID, signatureID, status, signedDate, fullName)
 
@@ -429,7 +453,7 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 		return errors.New("unable to lookup signature by ID - signature not found")
 	}
 
-	if status == DocusignCompleted {
+	if status == EnvelopeStatusCompleted {
 		log.WithFields(f).Debugf("envelope signed - status: %s", status)
 		updates := map[string]interface{}{
 			"signature_signed":          true,
@@ -507,7 +531,7 @@ func (s *service) SignedIndividualCallbackGithub(ctx context.Context, payload []
 
 		//Get signed document
 		log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
-		signedDocument, err := s.GetSignedDocument(ctx, envelopeID, documentID)
+		signedDocument, err := s.GetSignedDocument(ctx, callback.Provider, envelopeID, documentID)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
 			return err
@@ -635,20 +659,17 @@ func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []
 	}
 
 	log.WithFields(f).Debug("processing signed individual callback...")
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	callback, err := s.parseEnvelopeCallback(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signature callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
-	signatureID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
-	status := info.EnvelopeStatus.RecipientStatuses[0].Status
-	signedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
-	documentID := info.EnvelopeStatus.DocumentStatuses[0].ID
-	fullName := fetchFullName(info)
+	envelopeID := callback.EnvelopeID
+	signatureID := callback.ClientUserID
+	status := callback.RecipientStatus
+	signedDate := callback.SignedDate
+	fullName := callback.FullName
 
 	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", envelopeID, signatureID, status, signedDate, fullName)
 
@@ -665,7 +686,7 @@ func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []
 		return errors.New("unable to lookup signature by ID - signature not found")
 	}
 
-	if status == DocusignCompleted {
+	if status == EnvelopeStatusCompleted {
 		log.WithFields(f).Debugf("envelope signed - status: %s", status)
 		updates := map[string]interface{}{
 			"signature_signed":          true,
@@ -748,116 +769,241 @@ func (s *service) SignedIndividualCallbackGitlab(ctx context.Context, payload []
 			return err
 		}
 
-		claUser, userErr := s.userService.GetUser(signature.SignatureReferenceID)
-		if userErr != nil {
-			log.WithFields(f).WithError(userErr).Warnf("unable to lookup user by ID: %s", signature.SignatureReferenceID)
-			return userErr
-		}
+		return s.completeIndividualSignature(ctx, f, callback, signature)
+	} else {
+		log.WithFields(f).Debugf("envelope not signed - status: %s", status)
+	}
 
-		if claUser.Username == "" {
-			if fullName != "" {
-				log.WithFields(f).Debugf("setting username for user with :%s", fullName)
-				updates := map[string]interface{}{
-					"user_name": fullName,
-				}
-				log.WithFields(f).Debugf("updating user with username: %s", fullName)
-				_, err = s.userService.UpdateUser(signature.SignatureReferenceID, updates)
-				if err != nil {
-					log.WithFields(f).WithError(err).Warnf("unable to update user with username: %s", fullName)
-					return err
-				}
-			}
-		}
+	return nil
+}
 
-		// Remove the active signature
-		log.WithFields(f).Debugf("removing active signature metadata for user: %s", signature.SignatureReferenceID)
-		key := fmt.Sprintf("active_signature:%s", signature.SignatureReferenceID)
-		err = s.storeRepository.DeleteActiveSignatureMetaData(ctx, key)
+// SignedIndividualCallbackBitbucket handles the signature callback of an individual signing from a Bitbucket pull
+// request, the pull request CLA check is run again once signed
+func (s *service) SignedIndividualCallbackBitbucket(ctx context.Context, payload []byte, userID, organizationID, repositoryID, pullRequestID string) error {
+	f := logrus.Fields{
+		"functionName":   "sign.SignedIndividualCallbackBitbucket",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userID,
+		"organizationID": organizationID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+	}
+
+	return s.signedIndividualCallbackPullRequest(ctx, f, payload, func() error {
+		pullRequestIDInt, err := strconv.Atoi(pullRequestID)
 		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to remove active signature metadata for user: %s", signature.SignatureReferenceID)
+			log.WithFields(f).WithError(err).Warnf("unable to convert pull request ID to int: %s", pullRequestID)
 			return err
 		}
 
-		//Get signed document
-		log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
-		signedDocument, err := s.GetSignedDocument(ctx, envelopeID, documentID)
+		return s.bitbucketActivityService.ProcessPullRequestActivity(ctx, &bitbucket_activity.ProcessPullRequestActivityInput{
+			OrganizationID: organizationID,
+			RepositoryID:   repositoryID,
+			PullRequestID:  pullRequestIDInt,
+		})
+	})
+}
+
+// SignedIndividualCallbackGitea handles the signature callback of an individual signing from a Gitea pull request,
+// the pull request CLA check is run again once signed
+func (s *service) SignedIndividualCallbackGitea(ctx context.Context, payload []byte, userID, organizationID, repositoryID, pullRequestID string) error {
+	f := logrus.Fields{
+		"functionName":   "sign.SignedIndividualCallbackGitea",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"userID":         userID,
+		"organizationID": organizationID,
+		"repositoryID":   repositoryID,
+		"pullRequestID":  pullRequestID,
+	}
+
+	return s.signedIndividualCallbackPullRequest(ctx, f, payload, func() error {
+		repositoryIDInt, err := strconv.ParseInt(repositoryID, 10, 64)
 		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
+			log.WithFields(f).WithError(err).Warnf("unable to convert repository ID to int: %s", repositoryID)
 			return err
 		}
-
-		// send email to user
-		log.WithFields(f).Debugf("sending email to user... ")
-		log.WithFields(f).Debugf("getting claGroupID: %s", signature.ProjectID)
-		claGroup, err := s.claGroupService.GetCLAGroup(ctx, signature.ProjectID)
+		pullRequestIDInt, err := strconv.ParseInt(pullRequestID, 10, 64)
 		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to lookup CLA Group by ID: %s", signature.ProjectID)
+			log.WithFields(f).WithError(err).Warnf("unable to convert pull request ID to int: %s", pullRequestID)
 			return err
 		}
 
-		subject := fmt.Sprintf("EasyCLA: Individual CLA Signed for %s", claGroup.ProjectName)
-		pdfLink := fmt.Sprintf("%s/v3/signatures/%s/%s/icla/pdf", s.ClaV1ApiURL, signature.ProjectID, signature.SignatureReferenceID)
-		emailParams := emails.DocumentSignedTemplateParams{
-			CommonEmailParams: emails.CommonEmailParams{
-				RecipientName: fullName,
-			},
-			PdfLink: pdfLink,
-			ICLA:    true,
-		}
-		email := utils.GetBestEmail(claUser)
-		if email == "" {
-			log.WithFields(f).Warnf("unable to find email for user: %+v", claUser)
-			return errors.New("unable to find email for user")
-		}
+		return s.giteaActivityService.ProcessPullRequestActivity(ctx, &gitea_activity.ProcessPullRequestActivityInput{
+			OrganizationID: organizationID,
+			RepositoryID:   repositoryIDInt,
+			PullRequestID:  pullRequestIDInt,
+		})
+	})
+}
 
-		recipients := []string{utils.GetBestEmail(claUser)}
+// signedIndividualCallbackPullRequest marks the individual signature of the callback signed, then runs
+// updatePullRequest to refresh the CLA check of the pull request before completing the signature
+func (s *service) signedIndividualCallbackPullRequest(ctx context.Context, f logrus.Fields, payload []byte, updatePullRequest func() error) error {
+	log.WithFields(f).Debug("processing signed individual callback...")
+	callback, err := s.parseEnvelopeCallback(ctx, payload)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to parse the signature callback payload")
+		return err
+	}
 
-		body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, claGroup.Version, claGroup.ProjectExternalID, emailParams)
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to render document signed template for project version: %s, project ID: %s", claGroup.Version, claGroup.ProjectID)
-			return err
-		}
+	signatureID := callback.ClientUserID
+	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", callback.EnvelopeID, signatureID, callback.RecipientStatus, callback.SignedDate, callback.FullName)
 
-		// send email to user
-		log.WithFields(f).Debugf("sending email to user... ")
-		err = utils.SendEmail(subject, body, recipients)
+	signature, err := s.signatureService.GetSignature(ctx, signatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to lookup signature by ID")
+		return err
+	}
+	if signature == nil {
+		log.WithFields(f).Warn("unable to lookup signature by ID - signature not found")
+		return errors.New("unable to lookup signature by ID - signature not found")
+	}
 
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to send email to user: %s", claUser.Username)
-			return err
-		}
+	if callback.RecipientStatus != EnvelopeStatusCompleted {
+		log.WithFields(f).Debugf("envelope not signed - status: %s", callback.RecipientStatus)
+		return nil
+	}
 
-		log.WithFields(f).Debugf("email sent to user: %s", claUser.Username)
+	_, currentTime := utils.CurrentTime()
+	updates := map[string]interface{}{
+		"signature_signed":          true,
+		"signature_embargo_acked":   true,
+		"date_modified":             currentTime,
+		"signed_on":                 currentTime,
+		"user_docusign_raw_xml":     string(payload),
+		"user_docusign_name":        callback.FullName,
+		"user_docusign_date_signed": callback.SignedDate,
+	}
+	if err := s.signatureService.UpdateSignature(ctx, signatureID, updates); err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to update signature record with envelope ID: %s", callback.EnvelopeID)
+		return err
+	}
+	log.WithFields(f).Debugf("updated signature record: %s", signatureID)
 
-		if claUser.UserID == "" {
-			return fmt.Errorf("user id is empty for user: %s", claUser.Username)
-		}
+	if err := updatePullRequest(); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update change request")
+		return err
+	}
 
-		// store document on S3
-		log.WithFields(f).Debugf("storing signed document on S3...")
-		err = utils.UploadToS3(signedDocument, signature.ProjectID, utils.ClaTypeICLA, claUser.UserID, signature.SignatureID)
-		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to store signed document on S3")
-			return err
+	return s.completeIndividualSignature(ctx, f, callback, signature)
+}
+
+// completeIndividualSignature finishes a signed individual signature once the change request has been updated: it
+// sets the user name, clears the active signature, stores the signed document, emails the contributor and logs the
+// signed event
+func (s *service) completeIndividualSignature(ctx context.Context, f logrus.Fields, callback *EnvelopeCallback, signature *v1Models.Signature) error {
+	envelopeID := callback.EnvelopeID
+	documentID := callback.DocumentID
+	fullName := callback.FullName
+	var err error
+
+	claUser, userErr := s.userService.GetUser(signature.SignatureReferenceID)
+	if userErr != nil {
+		log.WithFields(f).WithError(userErr).Warnf("unable to lookup user by ID: %s", signature.SignatureReferenceID)
+		return userErr
+	}
+
+	if claUser.Username == "" {
+		if fullName != "" {
+			log.WithFields(f).Debugf("setting username for user with :%s", fullName)
+			updates := map[string]interface{}{
+				"user_name": fullName,
+			}
+			log.WithFields(f).Debugf("updating user with username: %s", fullName)
+			_, err = s.userService.UpdateUser(signature.SignatureReferenceID, updates)
+			if err != nil {
+				log.WithFields(f).WithError(err).Warnf("unable to update user with username: %s", fullName)
+				return err
+			}
 		}
+	}
 
-		// Log the event
-		log.WithFields(f).Debugf("logging event...")
-		s.eventsService.LogEvent(&events.LogEventArgs{
-			EventType: events.IndividualSignatureSigned,
-			ProjectID: signature.ProjectID,
-			UserID:    claUser.UserID,
-			EventData: &events.IndividualSignatureSignedEventData{
-				ProjectName: claGroup.ProjectName,
-				Username:    fullName,
-				ProjectID:   signature.ProjectID,
-			},
-			CLAGroupID: signature.ProjectID,
-		})
-	} else {
-		log.WithFields(f).Debugf("envelope not signed - status: %s", status)
+	// Remove the active signature
+	log.WithFields(f).Debugf("removing active signature metadata for user: %s", signature.SignatureReferenceID)
+	key := fmt.Sprintf("active_signature:%s", signature.SignatureReferenceID)
+	err = s.storeRepository.DeleteActiveSignatureMetaData(ctx, key)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to remove active signature metadata for user: %s", signature.SignatureReferenceID)
+		return err
+	}
+
+	//Get signed document
+	log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
+	signedDocument, err := s.GetSignedDocument(ctx, callback.Provider, envelopeID, documentID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
+		return err
+	}
+
+	// send email to user
+	log.WithFields(f).Debugf("sending email to user... ")
+	log.WithFields(f).Debugf("getting claGroupID: %s", signature.ProjectID)
+	claGroup, err := s.claGroupService.GetCLAGroup(ctx, signature.ProjectID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to lookup CLA Group by ID: %s", signature.ProjectID)
+		return err
+	}
+
+	subject := fmt.Sprintf("EasyCLA: Individual CLA Signed for %s", claGroup.ProjectName)
+	pdfLink := fmt.Sprintf("%s/v3/signatures/%s/%s/icla/pdf", s.ClaV1ApiURL, signature.ProjectID, signature.SignatureReferenceID)
+	emailParams := emails.DocumentSignedTemplateParams{
+		CommonEmailParams: emails.CommonEmailParams{
+			RecipientName: fullName,
+		},
+		PdfLink: pdfLink,
+		ICLA:    true,
+	}
+	email := utils.GetBestEmail(claUser)
+	if email == "" {
+		log.WithFields(f).Warnf("unable to find email for user: %+v", claUser)
+		return errors.New("unable to find email for user")
+	}
+
+	recipients := []string{utils.GetBestEmail(claUser)}
+
+	body, err := emails.RenderDocumentSignedTemplate(s.emailTemplateService, claGroup.Version, claGroup.ProjectExternalID, emailParams)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to render document signed template for project version: %s, project ID: %s", claGroup.Version, claGroup.ProjectID)
+		return err
+	}
+
+	// send email to user
+	log.WithFields(f).Debugf("sending email to user... ")
+	err = utils.SendEmail(subject, body, recipients)
+
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to send email to user: %s", claUser.Username)
+		return err
+	}
+
+	log.WithFields(f).Debugf("email sent to user: %s", claUser.Username)
+
+	if claUser.UserID == "" {
+		return fmt.Errorf("user id is empty for user: %s", claUser.Username)
 	}
 
+	// store document on S3
+	log.WithFields(f).Debugf("storing signed document on S3...")
+	err = utils.UploadToS3(signedDocument, signature.ProjectID, utils.ClaTypeICLA, claUser.UserID, signature.SignatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to store signed document on S3")
+		return err
+	}
+
+	// Log the event
+	log.WithFields(f).Debugf("logging event...")
+	s.eventsService.LogEvent(&events.LogEventArgs{
+		EventType: events.IndividualSignatureSigned,
+		ProjectID: signature.ProjectID,
+		UserID:    claUser.UserID,
+		EventData: &events.IndividualSignatureSignedEventData{
+			ProjectName: claGroup.ProjectName,
+			Username:    fullName,
+			ProjectID:   signature.ProjectID,
+		},
+		CLAGroupID: signature.ProjectID,
+	})
+
 	return nil
 }
 
@@ -869,20 +1015,18 @@ func (s *service) SignedIndividualCallbackGerrit(ctx context.Context, payload []
 	}
 
 	log.WithFields(f).Debug("processing signed individual callback...")
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	callback, err := s.parseEnvelopeCallback(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signature callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
-	signatureID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
-	status := info.EnvelopeStatus.RecipientStatuses[0].Status
-	signedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
-	documentID := info.EnvelopeStatus.DocumentStatuses[0].ID
-	fullName := fetchFullName(info)
+	envelopeID := callback.EnvelopeID
+	signatureID := callback.ClientUserID
+	status := callback.RecipientStatus
+	signedDate := callback.SignedDate
+	documentID := callback.DocumentID
+	fullName := callback.FullName
 
 	log.WithFields(f).Debugf("envelopeID: %s, signatureID: %s, status: %s, signedDate: %s, fullName: %s", envelopeID, signatureID, status, signedDate, fullName)
 
@@ -899,7 +1043,7 @@ func (s *service) SignedIndividualCallbackGerrit(ctx context.Context, payload []
 		return errors.New("unable to lookup signature by ID - signature not found")
 	}
 
-	if status == DocusignCompleted {
+	if status == EnvelopeStatusCompleted {
 		log.WithFields(f).Debugf("envelope signed - status: %s", status)
 		updates := map[string]interface{}{
 			"signature_signed":          true,
@@ -941,7 +1085,7 @@ func (s *service) SignedIndividualCallbackGerrit(ctx context.Context, payload []
 
 		//Get signed document
 		log.WithFields(f).Debugf("getting signed document for envelope ID: %s", envelopeID)
-		signedDocument, err := s.GetSignedDocument(ctx, envelopeID, documentID)
+		signedDocument, err := s.GetSignedDocument(ctx, callback.Provider, envelopeID, documentID)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
 			return err
@@ -1049,15 +1193,13 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 	}
 
 	log.WithFields(f).Debug("processing signed corporate callback...")
-	var info DocuSignEnvelopeInformation
-
-	err := xml.Unmarshal(payload, &info)
+	callback, err := s.parseEnvelopeCallback(ctx, payload)
 	if err != nil {
-		log.WithFields(f).WithError(err).Warn("unable to unmarshal xml payload")
+		log.WithFields(f).WithError(err).Warn("unable to parse the signature callback payload")
 		return err
 	}
 
-	envelopeID := info.EnvelopeStatus.EnvelopeID
+	envelopeID := callback.EnvelopeID
 
 	log.WithFields(f).Debugf("envelopeID: %s", envelopeID)
 
@@ -1086,7 +1228,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 	// Assumme only one signature per company/project
 	var signatureID string
 	var signature *v1Models.Signature
-	clientUserID := info.EnvelopeStatus.RecipientStatuses[0].ClientUserId
+	clientUserID := callback.ClientUserID
 	if clientUserID == "" {
 		approved := true
 		var sigErr error
@@ -1148,8 +1290,8 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 	}
 
 	// Update the signature status if changed
-	status := info.EnvelopeStatus.Status
-	if status == DocusignCompleted && !signature.SignatureSigned {
+	status := callback.EnvelopeStatus
+	if status == EnvelopeStatusCompleted && !signature.SignatureSigned {
 		_, currentTime := utils.CurrentTime()
 		updates := map[string]interface{}{
 			"signature_signed":        true,
@@ -1158,7 +1300,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 			"signed_on":               currentTime,
 		}
 
-		userSignedDate := info.EnvelopeStatus.RecipientStatuses[0].Signed
+		userSignedDate := callback.SignedDate
 		if userSignedDate != "" {
 			updates["user_docusign_date_signed"] = userSignedDate
 		}
@@ -1178,7 +1320,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 
 	// store document on S3
 	log.WithFields(f).Debugf("storing signed document on S3...")
-	signedDocument, err := s.GetSignedDocument(ctx, envelopeID, info.EnvelopeStatus.DocumentStatuses[0].ID)
+	signedDocument, err := s.GetSignedDocument(ctx, callback.Provider, envelopeID, callback.DocumentID)
 
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("unable to get signed document for envelope ID: %s", envelopeID)
@@ -1238,7 +1380,7 @@ func (s *service) SignedCorporateCallback(ctx context.Context, payload []byte, c
 
 }
 
-func (s *service) RequestIndividualSignature(ctx context.Context, input *models.IndividualSignatureInput, preferredEmail string) (*models.IndividualSignatureOutput, error) {
+func (s *service) RequestIndividualSignature(ctx context.Context, input *models.IndividualSignatureInput, preferredEmail string, acceptLanguage string) (*models.IndividualSignatureOutput, error) {
 	f := logrus.Fields{
 		"functionName":   "sign.RequestIndividualSignature",
 		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
@@ -1340,6 +1482,12 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 			log.WithFields(f).WithError(err).Warnf("unable to get signature callback url for user: %s", *input.UserID)
 			return nil, err
 		}
+	} else if strings.ToLower(input.ReturnURLType) == utils.BitbucketLower || strings.ToLower(input.ReturnURLType) == utils.GiteaLower {
+		callBackURL, err = s.getIndividualSignatureCallbackURLPullRequest(ctx, strings.ToLower(input.ReturnURLType), *input.UserID, activeSignatureMetadata)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to get signature callback url for user: %s", *input.UserID)
+			return nil, err
+		}
 	}
 
 	log.WithFields(f).Debugf("signature callback url: %s", callBackURL)
@@ -1349,6 +1497,9 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 		acl = fmt.Sprintf("%s:%s", strings.ToLower(input.ReturnURLType), user.GithubID)
 	} else if strings.ToLower(input.ReturnURLType) == "gitlab" {
 		acl = fmt.Sprintf("%s:%s", strings.ToLower(input.ReturnURLType), user.GitlabID)
+	} else if strings.ToLower(input.ReturnURLType) == utils.BitbucketLower || strings.ToLower(input.ReturnURLType) == utils.GiteaLower {
+		// Bitbucket and Gitea users are matched by their email - there is no provider ID on the user record
+		acl = fmt.Sprintf("%s:%s", strings.ToLower(input.ReturnURLType), preferredEmail)
 	}
 
 	log.WithFields(f).Debugf("acl: %s", acl)
@@ -1367,6 +1518,10 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 		return nil, err
 	}
 
+	// The document translation is selected by the user's preferred locale or the browser's Accept-Language header
+	locale := common.MatchDocumentLocale(latestDocument, input.Locale, acceptLanguage)
+	log.WithFields(f).Debugf("signing the document in the locale: %s", locale)
+
 	if latestSignature != nil {
 		log.WithFields(f).Debugf("comparing latest signature document version: %s to latest document version: %s", latestSignature.SignatureDocumentMajorVersion, latestDocument.DocumentMajorVersion)
 		if latestDocument.DocumentMajorVersion == latestSignature.SignatureDocumentMajorVersion {
@@ -1381,6 +1536,7 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 				DateModified:                  currentTime,
 				SignatureReferenceType:        latestSignature.SignatureReferenceType,
 				SignatureEnvelopeID:           latestSignature.SignatureEnvelopeID,
+				SignatureProvider:             latestSignature.SignatureProvider,
 				SignatureType:                 latestSignature.SignatureType,
 				SignatureReferenceID:          latestSignature.SignatureReferenceID,
 				SignatureProjectID:            latestSignature.ProjectID,
@@ -1396,6 +1552,7 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 				SignatureACL:                  []string{acl},
 				SignatureDocumentMajorVersion: majorVersion,
 				SignatureDocumentMinorVersion: minorVersion,
+				SignatureLocale:               locale,
 			}
 			signErr := s.populateSignURL(ctx, &itemSignature, callBackURL, "", "", false, "", "", defaultValues, preferredEmail)
 			if signErr != nil {
@@ -1472,6 +1629,7 @@ func (s *service) RequestIndividualSignature(ctx context.Context, input *models.
 		SignatureReferenceType:        "user",
 		SignatureACL:                  []string{acl},
 		SignatureReferenceNameLower:   strings.ToLower(getUserName(user)),
+		SignatureLocale:               locale,
 	}
 
 	// 10. Populate sign url
@@ -1568,6 +1726,39 @@ func (s *service) getIndividualSignatureCallbackURLGitlab(ctx context.Context, u
 
 }
 
+// getIndividualSignatureCallbackURLPullRequest returns the signature callback URL of the Bitbucket or Gitea pull
+// request stored in the active signature metadata
+func (s *service) getIndividualSignatureCallbackURLPullRequest(ctx context.Context, provider, userID string, metadata map[string]interface{}) (string, error) {
+	f := logrus.Fields{
+		"functionName":   "sign.getIndividualSignatureCallbackURLPullRequest",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"provider":       provider,
+		"userID":         userID,
+	}
+
+	var err error
+	if metadata == nil {
+		metadata, err = s.storeRepository.GetActiveSignatureMetaData(ctx, userID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to get active signature meta data for user: %s", userID)
+			return "", err
+		}
+	}
+
+	values := make(map[string]string)
+	for _, key := range []string{"organization_id", "repository_id", "pull_request_id"} {
+		value, ok := metadata[key].(string)
+		if !ok || value == "" {
+			msg := fmt.Sprintf("unable to get %s from the active signature metadata for user: %s", key, userID)
+			log.WithFields(f).Warn(msg)
+			return "", errors.New(msg)
+		}
+		values[key] = value
+	}
+
+	return fmt.Sprintf("%s/v4/signed/%s/individual/%s/%s/%s/%s", s.ClaV4ApiURL, provider, userID, values["organization_id"], values["repository_id"], values["pull_request_id"]), nil
+}
+
 func (s *service) getIndividualSignatureCallbackURL(ctx context.Context, userID string, metadata map[string]interface{}) (string, error) {
 	f := logrus.Fields{
 		"functionName": "sign.getIndividualSignatureCallbackURL",
@@ -1661,7 +1852,8 @@ func (s *service) populateSignURL(ctx context.Context,
 	var project *v1Models.ClaGroup
 	var companyModel *v1Models.Company
 	var err error
-	var signer DocuSignRecipient
+	var signerName string
+	var signerEmail string
 	var emailBody string
 	var emailSubject string
 
@@ -1706,14 +1898,19 @@ func (s *service) populateSignURL(ctx context.Context,
 		}
 	}
 
+	// Sign the translation selected when the signature was requested, the original document is used when the
+	// translation was removed in the meantime
+	document = common.GetDocumentLocaleVariant(document, latestSignature.SignatureLocale)
+	log.WithFields(f).Debugf("signing the document: %s in the locale: %s", document.DocumentName, common.GetDocumentLocale(document))
+
 	// Void the existing envelope to prevent multiple envelopes pending for a signer
 	envelopeID := latestSignature.SignatureEnvelopeID
 	if envelopeID != "" {
-		message := fmt.Sprintf("You are getting this message because your DocuSign Session for project %s expired. A new session will be in place for your signing process.", project.ProjectName)
+		message := fmt.Sprintf("You are getting this message because your signing session for project %s expired. A new session will be in place for your signing process.", project.ProjectName)
 		log.WithFields(f).Debug(message)
-		err = s.VoidEnvelope(ctx, envelopeID, message)
+		err = s.VoidEnvelope(ctx, latestSignature.SignatureProvider, envelopeID, message)
 		if err != nil {
-			log.WithFields(f).WithError(err).Warnf("DocuSign error while voiding the envelope - regardless, continuing on..., error: %s", err)
+			log.WithFields(f).WithError(err).Warnf("error while voiding the envelope - regardless, continuing on..., error: %s", err)
 		}
 	}
 
@@ -1778,13 +1975,8 @@ func (s *service) populateSignURL(ctx context.Context,
 		emailSubject, emailBody = claSignatoryEmailContent(*claSignatoryParams)
 		log.WithFields(f).Debugf("subject: %s, body: %s", emailSubject, emailBody)
 
-		signer = DocuSignRecipient{
-			Email:       signatoryEmail,
-			Name:        signatoryName,
-			Tabs:        tab,
-			RecipientId: "1",
-			RoleName:    "signer",
-		}
+		signerName = signatoryName
+		signerEmail = signatoryEmail
 
 	} else {
 		// This will be the Initial CLA Manager
@@ -1820,14 +2012,8 @@ func (s *service) populateSignURL(ctx context.Context,
 
 		emailBody = fmt.Sprintf("CLA Sign Request for %s", userIdentifier)
 
-		signer = DocuSignRecipient{
-			Email:        signatoryEmail,
-			Name:         signatoryName,
-			Tabs:         tab,
-			RecipientId:  "1",
-			ClientUserId: latestSignature.SignatureID,
-			RoleName:     "signer",
-		}
+		signerName = signatoryName
+		signerEmail = signatoryEmail
 	}
 
 	contentType := document.DocumentContentType
@@ -1858,107 +2044,49 @@ func (s *service) populateSignURL(ctx context.Context,
 	log.WithFields(f).Debugf("documentName: %s", documentName)
 	log.WithFields(f).Debugf("contentType: %s", contentType)
 
-	docusignDocument := DocuSignDocument{
-		Name:           documentName,
-		DocumentId:     documentID,
-		FileExtension:  "pdf",
-		FileFormatHint: "pdf",
-		Order:          "1",
-		DocumentBase64: base64.StdEncoding.EncodeToString(pdf),
-	}
-
-	var envelopeRequest DocuSignEnvelopeRequest
-
-	if callbackURL != "" {
-		// Webhook properties for callbacks after the user signs the document.
-		// Ensure that a webhook is returned on the status "Completed" where
-		// all signers on a document finish signing the document.
-		log.WithFields(f).Debugf("setting up webhook properties with callback url: %s", callbackURL)
-		recipientEvents := []DocuSignRecipientEvent{
-			{
-				EnvelopeEventStatusCode: "Completed",
-			},
-		}
-
-		eventNotification := DocuSignEventNotification{
-			URL:            callbackURL,
-			LoggingEnabled: true,
-			EnvelopeEvents: recipientEvents,
-		}
-
-		envelopeRequest = DocuSignEnvelopeRequest{
-			Documents: []DocuSignDocument{
-				docusignDocument,
-			},
-			EmailSubject:      emailSubject,
-			EmailBlurb:        emailBody,
-			EventNotification: eventNotification,
-			Status:            "sent",
-			Recipients: DocuSignRecipientType{
-				Signers: []DocuSignRecipient{
-					signer,
-				},
-			},
-		}
-
-	} else {
-
-		envelopeRequest = DocuSignEnvelopeRequest{
-			Documents: []DocuSignDocument{
-				docusignDocument,
-			},
-			EmailSubject: emailSubject,
-			EmailBlurb:   emailBody,
-			Status:       "sent",
-			Recipients: DocuSignRecipientType{
-				Signers: []DocuSignRecipient{
-					signer,
-				},
-			},
-		}
-
+	provider, err := s.getCLAGroupSignatureProvider(project)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to load signature provider of CLA Group: %s", project.ProjectID)
+		return err
 	}
 
-	envelopeResponse, err := s.PrepareSignRequest(ctx, &envelopeRequest)
+	envelopeRequest := &EnvelopeRequest{
+		SignatureID:  latestSignature.SignatureID,
+		DocumentID:   documentID,
+		DocumentName: documentName,
+		Document:     pdf,
+		Tabs:         tab,
+		SignerName:   signerName,
+		SignerEmail:  signerEmail,
+		ClientUserID: latestSignature.SignatureID,
+		EmailSubject: emailSubject,
+		EmailBody:    emailBody,
+		CallbackURL:  callbackURL,
+		// The URL the user will be redirected to after signing.
+		// This route will be in charge of extracting the signature's return_url and redirecting.
+		ReturnURL:   fmt.Sprintf("%s/v2/return-url/%s", s.ClaV1ApiURL, latestSignature.SignatureID),
+		SendAsEmail: sendAsEmail,
+		Locale:      common.GetDocumentLocale(document),
+	}
 
+	log.WithFields(f).Debugf("creating envelope using signature provider: %s", provider.Name())
+	envelope, err := provider.CreateEnvelope(ctx, envelopeRequest)
 	if err != nil {
 		log.WithFields(f).WithError(err).Warnf("unable to create envelope for user: %s", latestSignature.SignatureReferenceID)
 		return err
 	}
 
-	log.WithFields(f).Debugf("envelopeID: %s", envelopeResponse.EnvelopeId)
+	log.WithFields(f).Debugf("envelopeID: %s", envelope.EnvelopeID)
 
 	if !sendAsEmail {
-		// The URL the user will be redirected to after signing.
-		// This route will be in charge of extracting the signature's return_url and redirecting.
-		recipients, recipientErr := s.getEnvelopeRecipients(ctx, envelopeResponse.EnvelopeId)
-		if recipientErr != nil {
-			log.WithFields(f).Debugf("unable to fetch recipients for envelope: %s", envelopeResponse.EnvelopeId)
-			return recipientErr
-		}
-
-		if len(recipients) == 0 {
-			log.WithFields(f).Debugf("no envelope recipients found : %s", envelopeResponse.EnvelopeId)
-			return errors.New("no envelope recipients found")
-		}
-		recipient := recipients[0]
-		returnURL := fmt.Sprintf("%s/v2/return-url/%s", s.ClaV1ApiURL, recipient.ClientUserId)
-
-		log.WithFields(f).Debugf("generating signature sign_url, using return-url as: %s", returnURL)
-		signURL, signErr := s.GetSignURL(signer.Email, signer.RecipientId, signer.Name, signer.ClientUserId, envelopeResponse.EnvelopeId, returnURL)
-
-		if signErr != nil {
-			log.WithFields(f).WithError(err).Warnf("unable to get sign url for user: %s", latestSignature.SignatureReferenceID)
-			return signErr
-		}
-
-		log.WithFields(f).Debugf("setting signature sign_url as: %s", signURL)
-		latestSignature.SignatureSignURL = signURL
+		log.WithFields(f).Debugf("setting signature sign_url as: %s", envelope.SignURL)
+		latestSignature.SignatureSignURL = envelope.SignURL
 	}
 
 	// Save Envelope ID in signature.
 	log.WithFields(f).Debugf("saving signature to database...")
-	latestSignature.SignatureEnvelopeID = envelopeResponse.EnvelopeId
+	latestSignature.SignatureEnvelopeID = envelope.EnvelopeID
+	latestSignature.SignatureProvider = provider.Name()
 
 	log.WithFields(f).Debugf("signature: %+v", latestSignature)
 
@@ -2265,7 +2393,7 @@ func getLatestSignature(signatures []*v1Models.Signature) *v1Models.Signature {
 	return latestSignature
 }
 
-func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *models.IndividualSignatureInput) (*models.IndividualSignatureOutput, error) {
+func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *models.IndividualSignatureInput, acceptLanguage string) (*models.IndividualSignatureOutput, error) {
 	f := logrus.Fields{
 		"functionName":  "sign.RequestIndividualSignatureGerrit",
 		"projectID":     input.ProjectID,
@@ -2342,6 +2470,9 @@ func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *m
 		return nil, err
 	}
 
+	locale := common.MatchDocumentLocale(latestDocument, input.Locale, acceptLanguage)
+	log.WithFields(f).Debugf("signing the document in the locale: %s", locale)
+
 	// Get gerrits by claGroupID
 	gerrits, err := s.gerritService.GetClaGroupGerrits(ctx, *input.ProjectID)
 	if err != nil {
@@ -2371,6 +2502,7 @@ func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *m
 				DateModified:                  currentTime,
 				SignatureReferenceType:        latestSignature.SignatureReferenceType,
 				SignatureEnvelopeID:           latestSignature.SignatureEnvelopeID,
+				SignatureProvider:             latestSignature.SignatureProvider,
 				SignatureType:                 latestSignature.SignatureType,
 				SignatureReferenceID:          latestSignature.SignatureReferenceID,
 				SignatureProjectID:            latestSignature.ProjectID,
@@ -2386,6 +2518,7 @@ func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *m
 				SignatureACL:                  []string{user.LfUsername},
 				SignatureDocumentMajorVersion: majorVersion,
 				SignatureDocumentMinorVersion: minorVersion,
+				SignatureLocale:               locale,
 			}
 			signErr := s.populateSignURL(ctx, &itemSignature, callbackURL, "", "", false, "", "", defaultValues, preferredEmail)
 			if signErr != nil {
@@ -2426,6 +2559,7 @@ func (s *service) RequestIndividualSignatureGerrit(ctx context.Context, input *m
 		SignatureDocumentMajorVersion: majorVersion,
 		SignatureDocumentMinorVersion: minorVersion,
 		SignatureReferenceNameLower:   strings.ToLower(getUserName(user)),
+		SignatureLocale:               locale,
 	}
 
 	log.WithFields(f).Debugf("populating sign url for user: %s...", *input.UserID)
@@ -2584,6 +2718,7 @@ func (s *service) requestCorporateSignature(ctx context.Context, apiURL string,
 			SignatureReferenceType:        companySignature.SignatureReferenceType,
 			SignatureProjectID:            companySignature.ProjectID,
 			SignatureEnvelopeID:           companySignature.SignatureEnvelopeID,
+			SignatureProvider:             companySignature.SignatureProvider,
 			SignatureCallbackURL:          companySignature.SignatureCallbackURL,
 			SignatureReturnURL:            companySignature.SignatureReturnURL,
 			SignatureType:                 companySignature.SignatureType,
@@ -2647,6 +2782,10 @@ func (s *service) requestCorporateSignature(ctx context.Context, apiURL string,
 	log.WithFields(f).Debugf("setting signature ACL...")
 	itemSignature.SignatureACL = []string{claUser.LfUsername}
 
+	// The document translation is selected by the signatory's preferred locale or the browser's Accept-Language header
+	itemSignature.SignatureLocale = common.MatchDocumentLocale(latestDocument, input.Locale, input.AcceptLanguage)
+	log.WithFields(f).Debugf("signing the document in the locale: %s", itemSignature.SignatureLocale)
+
 	// 7. Populate sign url
 	log.WithFields(f).Debugf("populating sign url...")
 	log.WithFields(f).Debugf("itemSignature: %+v", itemSignature)
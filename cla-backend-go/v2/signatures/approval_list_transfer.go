@@ -0,0 +1,508 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/linuxfoundation/easycla/cla-backend-go/v2/approvals"
+	"github.com/sirupsen/logrus"
+)
+
+// approval list transfer formats and row statuses
+const (
+	ApprovalListFormatCSV  = "csv"
+	ApprovalListFormatJSON = "json"
+
+	ApprovalListRowAdded     = "added"
+	ApprovalListRowUnchanged = "unchanged"
+	ApprovalListRowDuplicate = "duplicate"
+	ApprovalListRowInvalid   = "invalid"
+)
+
+// approvalListCriteria is the ordered list of approval list entry types
+var approvalListCriteria = []string{
+	utils.EmailApprovalCriteria,
+	utils.DomainApprovalCriteria,
+	utils.GithubUsernameApprovalCriteria,
+	utils.GithubOrgApprovalCriteria,
+	utils.GitlabUsernameApprovalCriteria,
+	utils.GitlabOrgApprovalCriteria,
+}
+
+// approvalListColumns maps the approval list entry types to the signature columns
+var approvalListColumns = map[string]string{
+	utils.EmailApprovalCriteria:          signatures.SignatureEmailApprovalListColumn,
+	utils.DomainApprovalCriteria:         signatures.SignatureDomainApprovalListColumn,
+	utils.GithubUsernameApprovalCriteria: signatures.SignatureGitHubUsernameApprovalListColumn,
+	utils.GithubOrgApprovalCriteria:      signatures.SignatureGitHubOrgApprovalListColumn,
+	utils.GitlabUsernameApprovalCriteria: signatures.SignatureGitlabUsernameApprovalListColumn,
+	utils.GitlabOrgApprovalCriteria:      signatures.SignatureGitlabOrgApprovalListColumn,
+}
+
+var (
+	// ErrInvalidApprovalListDocument is returned when the imported approval list document can't be parsed
+	ErrInvalidApprovalListDocument = errors.New("invalid approval list document")
+	// ErrCorporateSignatureNotFound is returned when the company has no signed and approved corporate signature
+	ErrCorporateSignatureNotFound = errors.New("corporate signature not found")
+)
+
+// ExportApprovalList returns the approval list entries of the company corporate signature for the CLA Group
+func (s *Service) ExportApprovalList(ctx context.Context, claGroupID, companyID string) (*models.ApprovalListExport, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.service.ExportApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"companyID":      companyID,
+	}
+
+	corporateSignature, err := s.getCorporateSignature(ctx, claGroupID, companyID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Approval table entries carry the date each entry was added and its expiry, if any
+	activeItems := make(map[string]approvals.ApprovalItem)
+	approvalItems, approvalErr := s.approvalsRepos.GetApprovalListBySignature(corporateSignature.SignatureID)
+	if approvalErr != nil {
+		log.WithFields(f).WithError(approvalErr).Warn("unable to load the approval list items - exporting without dates")
+	}
+	for _, approvalItem := range approvalItems {
+		if approvalItem.Active {
+			activeItems[approvalListKey(approvalItem.ApprovalCriteria, approvalItem.ApprovalName)] = approvalItem
+		}
+	}
+
+	export := &models.ApprovalListExport{
+		SignatureID: corporateSignature.SignatureID,
+		ClaGroupID:  claGroupID,
+		CompanyID:   companyID,
+		Entries:     []*models.ApprovalListEntry{},
+	}
+	currentLists := approvalListsFromSignature(corporateSignature)
+	for _, criteria := range approvalListCriteria {
+		for _, value := range currentLists[criteria] {
+			approvalItem := activeItems[approvalListKey(criteria, value)]
+			export.Entries = append(export.Entries, &models.ApprovalListEntry{
+				Type:      aws.String(criteria),
+				Value:     aws.String(value),
+				DateAdded: approvalItem.DateAdded,
+				ExpiresAt: approvalItem.ExpiresAt,
+			})
+		}
+	}
+
+	log.WithFields(f).Debugf("exporting %d approval list entries", len(export.Entries))
+	return export, nil
+}
+
+// ApprovalListExportCsv returns the approval list export as a CSV document
+func ApprovalListExportCsv(export *models.ApprovalListExport) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write([]string{"type", "value", "dateAdded", "expiresAt"}); err != nil {
+		return nil, err
+	}
+	for _, entry := range export.Entries {
+		if err := writer.Write([]string{utils.StringValue(entry.Type), utils.StringValue(entry.Value), entry.DateAdded, entry.ExpiresAt}); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+	return buf.Bytes(), writer.Error()
+}
+
+// ImportApprovalList validates the imported approval list entries and computes the changes against the current
+// approval list of the company corporate signature. Unless it's a dry run or some entries are invalid, the changes
+// are applied: removed entries go through the regular approval list update, which invalidates the affected employee
+// signatures, added entries are written to the signature and to the approvals table in batches. A single event
+// summarizes the import.
+func (s *Service) ImportApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *v1Models.ClaGroup, companyModel *v1Models.Company, input *models.ApprovalListImport, projectSFID string, eventsService events.Service) (*models.ApprovalListImportResult, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.service.ImportApprovalList",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupModel.ProjectID,
+		"companyID":      companyModel.CompanyID,
+		"format":         utils.StringValue(input.Format),
+		"replace":        input.Replace,
+		"dryRun":         input.DryRun,
+		"authUserName":   authUser.UserName,
+	}
+
+	entries, parseErr := approvalListImportEntries(input)
+	if parseErr != nil {
+		log.WithFields(f).WithError(parseErr).Warn("unable to parse the approval list document")
+		return nil, parseErr
+	}
+
+	corporateSignature, err := s.getCorporateSignature(ctx, claGroupModel.ProjectID, companyModel.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !utils.CurrentUserInACL(authUser, corporateSignature.SignatureACL) {
+		msg := fmt.Sprintf("CLA Manager %s is not authorized to import the approval list for company ID: %s, CLA Group ID: %s",
+			authUser.UserName, companyModel.CompanyID, claGroupModel.ProjectID)
+		log.WithFields(f).Warn(msg)
+		return nil, signatures.NewForbiddenError(msg)
+	}
+
+	currentLists := approvalListsFromSignature(corporateSignature)
+	result, changes := diffApprovalList(currentLists, entries, input.Replace)
+	result.SignatureID = corporateSignature.SignatureID
+	result.DryRun = input.DryRun
+	result.Replace = input.Replace
+	log.WithFields(f).Debugf("approval list import: %d added, %d removed, %d unchanged, %d invalid",
+		result.AddedCount, result.RemovedCount, result.UnchangedCount, result.InvalidCount)
+
+	if input.DryRun || result.InvalidCount > 0 || (result.AddedCount == 0 && result.RemovedCount == 0) {
+		return result, nil
+	}
+
+	claManager, userErr := s.usersService.GetUserByUserName(authUser.UserName, true)
+	if userErr != nil {
+		log.WithFields(f).WithError(userErr).Warnf("unable to lookup CLA Manager user by user name: %s", authUser.UserName)
+		return nil, userErr
+	}
+	if claManager == nil {
+		log.WithFields(f).Warnf("unable to locate CLA Manager user by user name: %s", authUser.UserName)
+		return nil, &utils.UserNotFound{
+			Message:  "CLA Manager user not found",
+			UserName: authUser.UserName,
+		}
+	}
+
+	// Removals invalidate the signatures of the employees no longer covered by the approval list
+	if result.RemovedCount > 0 {
+		log.WithFields(f).Debugf("removing %d approval list entries...", result.RemovedCount)
+		_, removeErr := s.v1SignatureRepo.UpdateApprovalList(ctx, claManager, claGroupModel, companyModel.CompanyID, &v1Models.ApprovalList{
+			RemoveEmailApprovalList:          changes.removed[utils.EmailApprovalCriteria],
+			RemoveDomainApprovalList:         changes.removed[utils.DomainApprovalCriteria],
+			RemoveGithubUsernameApprovalList: changes.removed[utils.GithubUsernameApprovalCriteria],
+			RemoveGithubOrgApprovalList:      changes.removed[utils.GithubOrgApprovalCriteria],
+			RemoveGitlabUsernameApprovalList: changes.removed[utils.GitlabUsernameApprovalCriteria],
+			RemoveGitlabOrgApprovalList:      changes.removed[utils.GitlabOrgApprovalCriteria],
+		}, &events.LogEventArgs{
+			EventType:     events.InvalidatedSignature,
+			ProjectID:     claGroupModel.ProjectExternalID,
+			ClaGroupModel: claGroupModel,
+			CompanyID:     companyModel.CompanyID,
+			CompanyModel:  companyModel,
+			LfUsername:    claManager.LfUsername,
+			UserID:        claManager.UserID,
+			UserModel:     claManager,
+			ProjectSFID:   projectSFID,
+		})
+		if removeErr != nil {
+			log.WithFields(f).WithError(removeErr).Warn("unable to remove the approval list entries")
+			return nil, removeErr
+		}
+	}
+
+	if result.AddedCount > 0 {
+		updates := make(map[string]interface{})
+		for _, criteria := range approvalListCriteria {
+			if len(changes.added[criteria]) > 0 {
+				updates[approvalListColumns[criteria]] = changes.final[criteria]
+			}
+		}
+		log.WithFields(f).Debugf("adding %d approval list entries...", result.AddedCount)
+		if updateErr := s.v1SignatureRepo.UpdateSignature(ctx, corporateSignature.SignatureID, updates); updateErr != nil {
+			log.WithFields(f).WithError(updateErr).Warn("unable to add the approval list entries")
+			return nil, updateErr
+		}
+	}
+
+	if batchErr := s.approvalsRepos.BatchAddApprovalList(s.buildApprovalItems(ctx, corporateSignature, changes)); batchErr != nil {
+		log.WithFields(f).WithError(batchErr).Warn("unable to update the approvals table")
+	}
+	result.Applied = true
+
+	eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+		EventType:     events.ApprovalListImported,
+		ProjectID:     claGroupModel.ProjectExternalID,
+		ClaGroupModel: claGroupModel,
+		CompanyID:     companyModel.CompanyID,
+		CompanyModel:  companyModel,
+		LfUsername:    claManager.LfUsername,
+		UserID:        claManager.UserID,
+		UserModel:     claManager,
+		ProjectSFID:   projectSFID,
+		EventData: &events.ApprovalListImportedEventData{
+			Format:       utils.StringValue(input.Format),
+			Replace:      input.Replace,
+			AddedCount:   int(result.AddedCount),
+			RemovedCount: int(result.RemovedCount),
+		},
+	})
+
+	return result, nil
+}
+
+// getCorporateSignature returns the signed and approved corporate signature of the company for the CLA Group
+func (s *Service) getCorporateSignature(ctx context.Context, claGroupID, companyID string) (*v1Models.Signature, error) {
+	signed, approved := true, true
+	corporateSignature, err := s.v1SignatureService.GetProjectCompanySignature(ctx, companyID, claGroupID, &signed, &approved, nil, aws.Int64(1))
+	if err != nil {
+		return nil, err
+	}
+	if corporateSignature == nil {
+		return nil, fmt.Errorf("%w - company ID: %s, CLA Group ID: %s", ErrCorporateSignatureNotFound, companyID, claGroupID)
+	}
+	return corporateSignature, nil
+}
+
+// buildApprovalItems returns the approvals table items for the added and removed entries - existing items are updated
+// in place so that the history of each entry is kept
+func (s *Service) buildApprovalItems(ctx context.Context, corporateSignature *v1Models.Signature, changes *approvalListChanges) []approvals.ApprovalItem {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.service.buildApprovalItems",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    corporateSignature.SignatureID,
+	}
+
+	existing := make(map[string]approvals.ApprovalItem)
+	approvalItems, err := s.approvalsRepos.GetApprovalListBySignature(corporateSignature.SignatureID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the approval list items - adding new items only")
+	}
+	for _, approvalItem := range approvalItems {
+		existing[approvalListKey(approvalItem.ApprovalCriteria, approvalItem.ApprovalName)] = approvalItem
+	}
+
+	_, currentTime := utils.CurrentTime()
+	var items []approvals.ApprovalItem
+	buildItem := func(criteria, value string, active bool) {
+		approvalItem, ok := existing[approvalListKey(criteria, value)]
+		if !ok {
+			approvalID, uuidErr := uuid.NewV4()
+			if uuidErr != nil {
+				log.WithFields(f).WithError(uuidErr).Warnf("unable to generate UUID for approval list item: %s", value)
+				return
+			}
+			approvalItem = approvals.ApprovalItem{
+				ApprovalID:          approvalID.String(),
+				SignatureID:         corporateSignature.SignatureID,
+				ApprovalName:        value,
+				ApprovalCriteria:    criteria,
+				CompanyID:           corporateSignature.SignatureReferenceID,
+				ProjectID:           corporateSignature.ProjectID,
+				ApprovalCompanyName: corporateSignature.CompanyName,
+				DateCreated:         currentTime,
+			}
+		}
+		approvalItem.Active = active
+		approvalItem.DateModified = currentTime
+		if active {
+			approvalItem.DateAdded = currentTime
+			approvalItem.ExpiresAt = ""
+			approvalItem.ExpiryNotified = false
+			approvalItem.Note = "Imported"
+		} else {
+			approvalItem.DateRemoved = currentTime
+			approvalItem.Note = "Removed by import"
+		}
+		items = append(items, approvalItem)
+	}
+
+	for _, criteria := range approvalListCriteria {
+		for _, value := range changes.added[criteria] {
+			buildItem(criteria, value, true)
+		}
+		for _, value := range changes.removed[criteria] {
+			buildItem(criteria, value, false)
+		}
+	}
+
+	return items
+}
+
+// approvalListChanges holds the added, removed and resulting entries of an approval list import, keyed by type
+type approvalListChanges struct {
+	added   map[string][]string
+	removed map[string][]string
+	final   map[string][]string
+}
+
+// approvalListImportEntries returns the entries of the imported document
+func approvalListImportEntries(input *models.ApprovalListImport) ([]*models.ApprovalListEntry, error) {
+	switch utils.StringValue(input.Format) {
+	case ApprovalListFormatJSON:
+		return input.Entries, nil
+	case ApprovalListFormatCSV:
+		return parseApprovalListCsv(input.CsvData)
+	}
+	return nil, fmt.Errorf("%w: unsupported format %s", ErrInvalidApprovalListDocument, utils.StringValue(input.Format))
+}
+
+// parseApprovalListCsv parses a CSV document with a type,value header line - additional columns are ignored
+func parseApprovalListCsv(data string) ([]*models.ApprovalListEntry, error) {
+	reader := csv.NewReader(strings.NewReader(data))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to read the CSV header line - %v", ErrInvalidApprovalListDocument, err)
+	}
+	if len(header) < 2 || !strings.EqualFold(strings.TrimSpace(header[0]), "type") || !strings.EqualFold(strings.TrimSpace(header[1]), "value") {
+		return nil, fmt.Errorf("%w: the CSV header line must start with type,value", ErrInvalidApprovalListDocument)
+	}
+
+	var entries []*models.ApprovalListEntry
+	for {
+		record, readErr := reader.Read()
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidApprovalListDocument, readErr)
+		}
+		entry := &models.ApprovalListEntry{Type: aws.String(""), Value: aws.String("")}
+		if len(record) > 0 {
+			entry.Type = aws.String(strings.TrimSpace(record[0]))
+		}
+		if len(record) > 1 {
+			entry.Value = aws.String(strings.TrimSpace(record[1]))
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// diffApprovalList validates the imported entries and compares them with the current approval lists. In merge mode
+// the entries are added to the current lists, in replace mode the entries become the new lists.
+func diffApprovalList(currentLists map[string][]string, entries []*models.ApprovalListEntry, replace bool) (*models.ApprovalListImportResult, *approvalListChanges) {
+	result := &models.ApprovalListImportResult{
+		Rows:    []*models.ApprovalListImportRow{},
+		Removed: []*models.ApprovalListEntry{},
+	}
+	changes := &approvalListChanges{
+		added:   make(map[string][]string),
+		removed: make(map[string][]string),
+		final:   make(map[string][]string),
+	}
+
+	// Keep the current spelling of the unchanged entries
+	current := make(map[string]string)
+	for criteria, values := range currentLists {
+		for _, value := range values {
+			current[approvalListKey(criteria, value)] = value
+		}
+	}
+
+	imported := make(map[string]bool)
+	for i, entry := range entries {
+		criteria := strings.TrimSpace(utils.StringValue(entry.Type))
+		value := strings.TrimSpace(utils.StringValue(entry.Value))
+		row := &models.ApprovalListImportRow{
+			Row:   int64(i + 1),
+			Type:  criteria,
+			Value: value,
+		}
+		result.Rows = append(result.Rows, row)
+
+		if msg, valid := validateApprovalListEntry(criteria, value); !valid {
+			row.Status = ApprovalListRowInvalid
+			row.Message = msg
+			result.InvalidCount++
+			continue
+		}
+
+		key := approvalListKey(criteria, value)
+		currentValue, exists := current[key]
+		switch {
+		case imported[key]:
+			row.Status = ApprovalListRowDuplicate
+			continue
+		case exists:
+			row.Status = ApprovalListRowUnchanged
+			result.UnchangedCount++
+			value = currentValue
+		default:
+			row.Status = ApprovalListRowAdded
+			result.AddedCount++
+			changes.added[criteria] = append(changes.added[criteria], value)
+		}
+		imported[key] = true
+		changes.final[criteria] = append(changes.final[criteria], value)
+	}
+
+	for _, criteria := range approvalListCriteria {
+		for _, value := range currentLists[criteria] {
+			if imported[approvalListKey(criteria, value)] {
+				continue
+			}
+			if !replace {
+				// Keep the existing entries ahead of the imported ones
+				changes.final[criteria] = append([]string{value}, changes.final[criteria]...)
+				continue
+			}
+			changes.removed[criteria] = append(changes.removed[criteria], value)
+			result.Removed = append(result.Removed, &models.ApprovalListEntry{
+				Type:  aws.String(criteria),
+				Value: aws.String(value),
+			})
+			result.RemovedCount++
+		}
+	}
+
+	return result, changes
+}
+
+// validateApprovalListEntry validates an approval list entry value based on its type, returns false and a message
+// if invalid
+func validateApprovalListEntry(criteria, value string) (string, bool) {
+	switch criteria {
+	case utils.EmailApprovalCriteria:
+		if !utils.ValidEmail(value) {
+			return fmt.Sprintf("invalid email %s", value), false
+		}
+		return "", true
+	case utils.DomainApprovalCriteria:
+		return utils.ValidDomain(value, true)
+	case utils.GithubUsernameApprovalCriteria:
+		return utils.ValidGitHubUsername(value)
+	case utils.GithubOrgApprovalCriteria:
+		return utils.ValidGitHubOrg(value)
+	case utils.GitlabUsernameApprovalCriteria:
+		return utils.ValidGitlabUsername(value)
+	case utils.GitlabOrgApprovalCriteria:
+		return utils.ValidGitlabOrg(value)
+	}
+	return fmt.Sprintf("invalid type %s - expecting one of: %s", criteria, strings.Join(approvalListCriteria, ", ")), false
+}
+
+// approvalListsFromSignature returns the approval lists of the corporate signature keyed by type
+func approvalListsFromSignature(corporateSignature *v1Models.Signature) map[string][]string {
+	return map[string][]string{
+		utils.EmailApprovalCriteria:          corporateSignature.EmailApprovalList,
+		utils.DomainApprovalCriteria:         corporateSignature.DomainApprovalList,
+		utils.GithubUsernameApprovalCriteria: corporateSignature.GithubUsernameApprovalList,
+		utils.GithubOrgApprovalCriteria:      corporateSignature.GithubOrgApprovalList,
+		utils.GitlabUsernameApprovalCriteria: corporateSignature.GitlabUsernameApprovalList,
+		utils.GitlabOrgApprovalCriteria:      corporateSignature.GitlabOrgApprovalList,
+	}
+}
+
+// approvalListKey returns the case-insensitive lookup key of an approval list entry
+func approvalListKey(criteria, value string) string {
+	return fmt.Sprintf("%s#%s", criteria, strings.ToLower(strings.TrimSpace(value)))
+}
@@ -0,0 +1,77 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseApprovalListCsv(t *testing.T) {
+	entries, err := parseApprovalListCsv("Type,Value,Note\nemail, bob@example.org ,new hire\ndomain,*.example.org\n")
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, utils.EmailApprovalCriteria, *entries[0].Type)
+		assert.Equal(t, "bob@example.org", *entries[0].Value)
+		assert.Equal(t, utils.DomainApprovalCriteria, *entries[1].Type)
+		assert.Equal(t, "*.example.org", *entries[1].Value)
+	}
+
+	_, err = parseApprovalListCsv("value,type\nbob@example.org,email\n")
+	assert.True(t, errors.Is(err, ErrInvalidApprovalListDocument))
+
+	_, err = parseApprovalListCsv("")
+	assert.True(t, errors.Is(err, ErrInvalidApprovalListDocument))
+}
+
+func TestDiffApprovalList(t *testing.T) {
+	currentLists := map[string][]string{
+		utils.EmailApprovalCriteria:          {"alice@example.org", "carol@example.org"},
+		utils.GithubUsernameApprovalCriteria: {"alice"},
+	}
+	entries := []*models.ApprovalListEntry{
+		{Type: aws.String(utils.EmailApprovalCriteria), Value: aws.String("Alice@example.org")},
+		{Type: aws.String(utils.EmailApprovalCriteria), Value: aws.String("bob@example.org")},
+		{Type: aws.String(utils.EmailApprovalCriteria), Value: aws.String("BOB@example.org")},
+		{Type: aws.String(utils.DomainApprovalCriteria), Value: aws.String("*.example.org")},
+	}
+
+	// Merge - the current entries are kept
+	result, changes := diffApprovalList(currentLists, entries, false)
+	assert.Equal(t, int64(2), result.AddedCount)
+	assert.Equal(t, int64(1), result.UnchangedCount)
+	assert.Equal(t, int64(0), result.RemovedCount)
+	assert.Equal(t, int64(0), result.InvalidCount)
+	assert.Equal(t, ApprovalListRowUnchanged, result.Rows[0].Status)
+	assert.Equal(t, ApprovalListRowAdded, result.Rows[1].Status)
+	assert.Equal(t, ApprovalListRowDuplicate, result.Rows[2].Status)
+	assert.Equal(t, ApprovalListRowAdded, result.Rows[3].Status)
+	assert.Equal(t, []string{"bob@example.org"}, changes.added[utils.EmailApprovalCriteria])
+	assert.Equal(t, []string{"carol@example.org", "alice@example.org", "bob@example.org"}, changes.final[utils.EmailApprovalCriteria])
+	assert.Equal(t, []string{"*.example.org"}, changes.final[utils.DomainApprovalCriteria])
+
+	// Replace - the current entries missing from the import are removed
+	result, changes = diffApprovalList(currentLists, entries, true)
+	assert.Equal(t, int64(2), result.RemovedCount)
+	assert.Equal(t, []string{"carol@example.org"}, changes.removed[utils.EmailApprovalCriteria])
+	assert.Equal(t, []string{"alice"}, changes.removed[utils.GithubUsernameApprovalCriteria])
+	assert.Len(t, result.Removed, 2)
+
+	// Invalid rows are reported with a message
+	result, _ = diffApprovalList(currentLists, []*models.ApprovalListEntry{
+		{Type: aws.String(utils.EmailApprovalCriteria), Value: aws.String("not-an-email")},
+		{Type: aws.String("phone"), Value: aws.String("555-0100")},
+		{Type: aws.String(utils.DomainApprovalCriteria), Value: aws.String("*.com")},
+	}, false)
+	assert.Equal(t, int64(3), result.InvalidCount)
+	for _, row := range result.Rows {
+		assert.Equal(t, ApprovalListRowInvalid, row.Status)
+		assert.NotEmpty(t, row.Message)
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/signatures"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/project/repository"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// configureExportJobs configures the signature export job handlers
+func configureExportJobs(api *operations.EasyclaAPI, exportService ExportServiceInterface, projectRepo repository.ProjectRepository, projectClaGroupsRepo projects_cla_groups.Repository) {
+	api.SignaturesCreateSignatureExportJobHandler = signatures.CreateSignatureExportJobHandlerFunc(func(params signatures.CreateSignatureExportJobParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.export_handlers.SignaturesCreateSignatureExportJobHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"authUserName":   authUser.UserName,
+			"authUserEmail":  authUser.Email,
+		}
+
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s does not have access to export the signatures of the CLA Group: %s", authUser.UserName, params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewCreateSignatureExportJobForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		log.WithFields(f).Debug("submitting the signature export job...")
+		result, err := exportService.SubmitExportJob(ctx, params.ClaGroupID, params.Body, authUser.UserName)
+		if err != nil {
+			if errors.Is(err, ErrInvalidExportFormat) || errors.Is(err, ErrInvalidExportFilter) {
+				log.WithFields(f).WithError(err).Warn("invalid signature export request")
+				return signatures.NewCreateSignatureExportJobBadRequest().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseBadRequestWithError(reqID, "invalid signature export request", err))
+			}
+			msg := "problem submitting the signature export job"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewCreateSignatureExportJobInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewCreateSignatureExportJobOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.SignaturesListSignatureExportJobsHandler = signatures.ListSignatureExportJobsHandlerFunc(func(params signatures.ListSignatureExportJobsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.export_handlers.SignaturesListSignatureExportJobsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"authUserName":   authUser.UserName,
+			"authUserEmail":  authUser.Email,
+		}
+
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s does not have access to the signature exports of the CLA Group: %s", authUser.UserName, params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewListSignatureExportJobsForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := exportService.ListExportJobs(ctx, params.ClaGroupID)
+		if err != nil {
+			msg := "problem loading the signature export jobs"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewListSignatureExportJobsInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewListSignatureExportJobsOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
+	api.SignaturesGetSignatureExportJobHandler = signatures.GetSignatureExportJobHandlerFunc(func(params signatures.GetSignatureExportJobParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.export_handlers.SignaturesGetSignatureExportJobHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"jobID":          params.JobID,
+			"authUserName":   authUser.UserName,
+			"authUserEmail":  authUser.Email,
+		}
+
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s does not have access to the signature exports of the CLA Group: %s", authUser.UserName, params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewGetSignatureExportJobForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		result, err := exportService.GetExportJob(ctx, params.ClaGroupID, params.JobID)
+		if err != nil {
+			if errors.Is(err, ErrExportJobNotFound) {
+				msg := fmt.Sprintf("signature export job not found: %s", params.JobID)
+				log.WithFields(f).Warn(msg)
+				return signatures.NewGetSignatureExportJobNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFound(reqID, msg))
+			}
+			msg := "problem loading the signature export job"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewGetSignatureExportJobInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return signatures.NewGetSignatureExportJobOK().WithXRequestID(reqID).WithPayload(result)
+	})
+}
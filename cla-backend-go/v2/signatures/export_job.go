@@ -0,0 +1,337 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Signature export job statuses
+const (
+	ExportJobStatusPending   = "pending"
+	ExportJobStatusRunning   = "running"
+	ExportJobStatusCompleted = "completed"
+	ExportJobStatusFailed    = "failed"
+)
+
+// Signature export file formats
+const (
+	ExportFormatCSV     = "csv"
+	ExportFormatJSONL   = "jsonl"
+	ExportFormatXLSX    = "xlsx"
+	ExportFormatParquet = "parquet"
+)
+
+// ExportJobClaGroupIndex is the index of the export jobs by CLA Group and creation date
+const ExportJobClaGroupIndex = "signature-export-cla-group-index"
+
+var (
+	// ErrExportJobNotFound is returned when the export job doesn't exist or belongs to another CLA Group
+	ErrExportJobNotFound = errors.New("signature export job not found")
+)
+
+// ExportJob represents a signature export job in the database
+type ExportJob struct {
+	JobID         string `dynamodbav:"job_id"`
+	ClaGroupID    string `dynamodbav:"cla_group_id"`
+	JobStatus     string `dynamodbav:"job_status"`
+	Format        string `dynamodbav:"format"`
+	ClaType       string `dynamodbav:"cla_type,omitempty"`
+	CompanyID     string `dynamodbav:"company_id,omitempty"`
+	FromDate      string `dynamodbav:"from_date,omitempty"`
+	ToDate        string `dynamodbav:"to_date,omitempty"`
+	Approved      *bool  `dynamodbav:"approved,omitempty"`
+	Signed        *bool  `dynamodbav:"signed,omitempty"`
+	S3Key         string `dynamodbav:"s3_key,omitempty"`
+	RowCount      int64  `dynamodbav:"row_count"`
+	FileSize      int64  `dynamodbav:"file_size"`
+	ErrorMessage  string `dynamodbav:"error_message,omitempty"`
+	RequestedBy   string `dynamodbav:"requested_by"`
+	DateCreated   string `dynamodbav:"date_created"`
+	DateModified  string `dynamodbav:"date_modified"`
+	DateCompleted string `dynamodbav:"date_completed,omitempty"`
+}
+
+// toModel converts the export job into a response model, the download URL is set by the caller
+func (j *ExportJob) toModel() *models.SignatureExportJob {
+	return &models.SignatureExportJob{
+		JobID:         j.JobID,
+		ClaGroupID:    j.ClaGroupID,
+		Status:        j.JobStatus,
+		Format:        j.Format,
+		ClaType:       j.ClaType,
+		CompanyID:     j.CompanyID,
+		FromDate:      j.FromDate,
+		ToDate:        j.ToDate,
+		Approved:      j.Approved,
+		Signed:        j.Signed,
+		RowCount:      j.RowCount,
+		FileSize:      j.FileSize,
+		ErrorMessage:  j.ErrorMessage,
+		RequestedBy:   j.RequestedBy,
+		DateCreated:   j.DateCreated,
+		DateModified:  j.DateModified,
+		DateCompleted: j.DateCompleted,
+	}
+}
+
+// ExportRepository defines the signature export job database functions
+type ExportRepository interface {
+	CreateExportJob(ctx context.Context, job *ExportJob) error
+	GetExportJob(ctx context.Context, jobID string) (*ExportJob, error)
+	GetExportJobsByClaGroup(ctx context.Context, claGroupID string) ([]*ExportJob, error)
+	UpdateExportJob(ctx context.Context, job *ExportJob) error
+	QueryClaGroupSignatures(ctx context.Context, claGroupID string, approved, signed *bool, pageHandler func([]signatures.ItemSignature) error) error
+}
+
+type exportRepo struct {
+	dynamoDBClient     *dynamodb.DynamoDB
+	exportJobTableName string
+	signatureTableName string
+}
+
+// NewExportRepository creates a new signature export job repository
+func NewExportRepository(awsSession *session.Session, stage string) ExportRepository {
+	return &exportRepo{
+		dynamoDBClient:     dynamodb.New(awsSession),
+		exportJobTableName: fmt.Sprintf("cla-%s-signature-export-jobs", stage),
+		signatureTableName: fmt.Sprintf("cla-%s-signatures", stage),
+	}
+}
+
+// CreateExportJob stores a new export job
+func (repo *exportRepo) CreateExportJob(ctx context.Context, job *ExportJob) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_job.CreateExportJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"jobID":          job.JobID,
+		"claGroupID":     job.ClaGroupID,
+	}
+	if err := repo.putExportJob(job); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the signature export job")
+		return err
+	}
+	return nil
+}
+
+// GetExportJob returns the export job, or ErrExportJobNotFound
+func (repo *exportRepo) GetExportJob(ctx context.Context, jobID string) (*ExportJob, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_job.GetExportJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"jobID":          jobID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"job_id": {S: aws.String(jobID)},
+		},
+		TableName: aws.String(repo.exportJobTableName),
+		// the lambda loads the job right after it is created
+		ConsistentRead: aws.Bool(true),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signature export job")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrExportJobNotFound
+	}
+
+	var job ExportJob
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &job); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the signature export job")
+		return nil, err
+	}
+	return &job, nil
+}
+
+// GetExportJobsByClaGroup returns the export jobs of the CLA Group, most recent first
+func (repo *exportRepo) GetExportJobsByClaGroup(ctx context.Context, claGroupID string) ([]*ExportJob, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_job.GetExportJobsByClaGroup",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	condition := expression.Key("cla_group_id").Equal(expression.Value(claGroupID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the signature export jobs query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.exportJobTableName),
+		IndexName:                 aws.String(ExportJobClaGroupIndex),
+	}
+
+	var jobs []*ExportJob
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query the signature export jobs")
+			return nil, queryErr
+		}
+
+		var page []*ExportJob
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the signature export jobs")
+			return nil, err
+		}
+		jobs = append(jobs, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].DateCreated > jobs[j].DateCreated
+	})
+	return jobs, nil
+}
+
+// UpdateExportJob replaces the export job
+func (repo *exportRepo) UpdateExportJob(ctx context.Context, job *ExportJob) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_job.UpdateExportJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"jobID":          job.JobID,
+		"jobStatus":      job.JobStatus,
+	}
+	if err := repo.putExportJob(job); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the signature export job")
+		return err
+	}
+	return nil
+}
+
+// QueryClaGroupSignatures loads all the signatures of the CLA Group page by page, the approved and signed filters are
+// applied by the query when set
+func (repo *exportRepo) QueryClaGroupSignatures(ctx context.Context, claGroupID string, approved, signed *bool, pageHandler func([]signatures.ItemSignature) error) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_job.QueryClaGroupSignatures",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"approved":       aws.BoolValue(approved),
+		"signed":         aws.BoolValue(signed),
+	}
+
+	condition := expression.Key("signature_project_id").Equal(expression.Value(claGroupID))
+	builder := expression.NewBuilder().WithKeyCondition(condition).WithProjection(exportProjection())
+
+	var filter expression.ConditionBuilder
+	var filterAdded bool
+	if approved != nil {
+		filter = expression.Name("signature_approved").Equal(expression.Value(*approved))
+		filterAdded = true
+	}
+	if signed != nil {
+		signedFilter := expression.Name("signature_signed").Equal(expression.Value(*signed))
+		if filterAdded {
+			filter = filter.And(signedFilter)
+		} else {
+			filter = signedFilter
+			filterAdded = true
+		}
+	}
+	if filterAdded {
+		builder = builder.WithFilter(filter)
+	}
+
+	expr, err := builder.Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the CLA Group signatures query")
+		return err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		ProjectionExpression:      expr.Projection(),
+		FilterExpression:          expr.Filter(),
+		TableName:                 aws.String(repo.signatureTableName),
+		IndexName:                 aws.String(signatures.SignatureProjectIDIndex),
+	}
+
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query the CLA Group signatures")
+			return queryErr
+		}
+
+		var page []signatures.ItemSignature
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the CLA Group signatures")
+			return err
+		}
+		if err = pageHandler(page); err != nil {
+			return err
+		}
+
+		if len(results.LastEvaluatedKey) == 0 {
+			return nil
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+}
+
+// exportProjection returns the signature columns needed by the exports
+func exportProjection() expression.ProjectionBuilder {
+	return expression.NamesList(
+		expression.Name("signature_id"),
+		expression.Name("date_created"),
+		expression.Name("signature_approved"),
+		expression.Name("signature_signed"),
+		expression.Name("signature_document_major_version"),
+		expression.Name("signature_document_minor_version"),
+		expression.Name("signature_reference_id"),
+		expression.Name("signature_reference_name"),
+		expression.Name("signature_reference_type"),
+		expression.Name("signature_project_id"),
+		expression.Name("signature_type"),
+		expression.Name("signature_user_ccla_company_id"),
+		expression.Name("signed_on"),
+		expression.Name("signatory_name"),
+		expression.Name("user_name"),
+		expression.Name("user_email"),
+		expression.Name("user_lf_username"),
+		expression.Name("user_github_username"),
+		expression.Name("user_gitlab_username"),
+	)
+}
+
+// putExportJob marshals and stores the export job
+func (repo *exportRepo) putExportJob(job *ExportJob) error {
+	av, err := dynamodbattribute.MarshalMap(job)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(repo.exportJobTableName),
+	})
+	return err
+}
@@ -0,0 +1,44 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"io"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+const (
+	// parquetRowGroupSize is the number of records of a row group
+	parquetRowGroupSize = 50000
+	parquetCreatedBy    = "EasyCLA signature export"
+)
+
+// parquetExportWriter writes the records with the schema of the exportRecord parquet tags: one required column per
+// field, the strings are UTF8 annotated byte arrays. The file is completed on Close.
+type parquetExportWriter struct {
+	writer *parquet.GenericWriter[exportRecord]
+	rows   int
+}
+
+func newParquetExportWriter(w io.Writer) (*parquetExportWriter, error) {
+	return &parquetExportWriter{
+		writer: parquet.NewGenericWriter[exportRecord](w, parquet.CreatedBy(parquetCreatedBy, "", ""), parquet.Compression(&parquet.Snappy)),
+	}, nil
+}
+
+func (p *parquetExportWriter) Write(record *exportRecord) error {
+	if _, err := p.writer.Write([]exportRecord{*record}); err != nil {
+		return err
+	}
+	p.rows++
+	if p.rows%parquetRowGroupSize == 0 {
+		return p.writer.Flush()
+	}
+	return nil
+}
+
+func (p *parquetExportWriter) Close() error {
+	return p.writer.Close()
+}
@@ -0,0 +1,551 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/lambda"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/company"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/users"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// exportDateFormat is the format of the export date range filter
+const exportDateFormat = "2006-01-02"
+
+// validation errors
+var (
+	ErrInvalidExportFormat = errors.New("invalid signature export format")
+	ErrInvalidExportFilter = errors.New("invalid signature export filter")
+)
+
+// ExportJobEvent is the payload of the signature export lambda
+type ExportJobEvent struct {
+	JobID string `json:"job_id"`
+}
+
+// ExportServiceInterface defines the signature export job functions
+type ExportServiceInterface interface {
+	SubmitExportJob(ctx context.Context, claGroupID string, input *models.SignatureExportInput, requestedBy string) (*models.SignatureExportJob, error)
+	GetExportJob(ctx context.Context, claGroupID, jobID string) (*models.SignatureExportJob, error)
+	ListExportJobs(ctx context.Context, claGroupID string) (*models.SignatureExportJobList, error)
+	RunExportJob(ctx context.Context, jobID string) error
+}
+
+// ExportService submits the signature export jobs and runs them in the signature export lambda
+type ExportService struct {
+	repo          ExportRepository
+	companyRepo   company.IRepository
+	usersRepo     users.UserRepository
+	eventsService events.Service
+	s3            *s3.S3
+	lambdaClient  *lambda.Lambda
+	bucketName    string
+	functionName  string
+}
+
+// NewExportService creates a new signature export service, the exports are stored in the signature files bucket
+func NewExportService(awsSession *session.Session, stage, bucketName string, repo ExportRepository, companyRepo company.IRepository, usersRepo users.UserRepository, eventsService events.Service) *ExportService {
+	return &ExportService{
+		repo:          repo,
+		companyRepo:   companyRepo,
+		usersRepo:     usersRepo,
+		eventsService: eventsService,
+		s3:            s3.New(awsSession),
+		lambdaClient:  lambda.New(awsSession),
+		bucketName:    bucketName,
+		functionName:  fmt.Sprintf("cla-backend-%s-signature-export-lambda", stage),
+	}
+}
+
+// SubmitExportJob validates the filters, stores a pending export job and starts the signature export lambda
+func (s *ExportService) SubmitExportJob(ctx context.Context, claGroupID string, input *models.SignatureExportInput, requestedBy string) (*models.SignatureExportJob, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_service.SubmitExportJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"requestedBy":    requestedBy,
+	}
+
+	jobID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the signature export job")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	job := &ExportJob{
+		JobID:        jobID.String(),
+		ClaGroupID:   claGroupID,
+		JobStatus:    ExportJobStatusPending,
+		Format:       strings.ToLower(utils.StringValue(input.Format)),
+		ClaType:      strings.ToLower(input.ClaType),
+		CompanyID:    strings.TrimSpace(input.CompanyID),
+		FromDate:     strings.TrimSpace(input.FromDate),
+		ToDate:       strings.TrimSpace(input.ToDate),
+		Approved:     input.Approved,
+		Signed:       input.Signed,
+		RequestedBy:  requestedBy,
+		DateCreated:  currentTime,
+		DateModified: currentTime,
+	}
+	if err = validateExportJob(job); err != nil {
+		return nil, err
+	}
+	if err = s.repo.CreateExportJob(ctx, job); err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(ExportJobEvent{JobID: job.JobID})
+	if err != nil {
+		return nil, err
+	}
+	log.WithFields(f).Debugf("invoking %s for the signature export job: %s", s.functionName, job.JobID)
+	_, err = s.lambdaClient.Invoke(&lambda.InvokeInput{
+		FunctionName:   aws.String(s.functionName),
+		InvocationType: aws.String(lambda.InvocationTypeEvent),
+		Payload:        payload,
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to invoke the signature export lambda")
+		s.failExportJob(ctx, job, "unable to start the export")
+		return nil, err
+	}
+
+	s.eventsService.LogEventWithContext(ctx, exportEventArgs(job, events.SignatureExportRequested, &events.SignatureExportRequestedEventData{
+		JobID:   job.JobID,
+		Format:  job.Format,
+		Filters: exportFilters(job),
+	}))
+	return job.toModel(), nil
+}
+
+// GetExportJob returns the export job of the CLA Group with the presigned download URL of the export file once the
+// job is completed
+func (s *ExportService) GetExportJob(ctx context.Context, claGroupID, jobID string) (*models.SignatureExportJob, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_service.GetExportJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"jobID":          jobID,
+	}
+
+	job, err := s.repo.GetExportJob(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.ClaGroupID != claGroupID {
+		return nil, ErrExportJobNotFound
+	}
+
+	result := job.toModel()
+	if job.JobStatus == ExportJobStatusCompleted && job.S3Key != "" {
+		result.DownloadURL, err = utils.GetDownloadLink(job.S3Key)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to generate the download URL of the export: %s", job.S3Key)
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ListExportJobs returns the export jobs of the CLA Group, the download URLs are returned by GetExportJob
+func (s *ExportService) ListExportJobs(ctx context.Context, claGroupID string) (*models.SignatureExportJobList, error) {
+	jobs, err := s.repo.GetExportJobsByClaGroup(ctx, claGroupID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.SignatureExportJob, 0, len(jobs))
+	for _, job := range jobs {
+		list = append(list, job.toModel())
+	}
+	return &models.SignatureExportJobList{List: list}, nil
+}
+
+// RunExportJob writes the signatures matching the filters of the export job to S3. The failures are recorded on the
+// job so the lambda isn't retried.
+func (s *ExportService) RunExportJob(ctx context.Context, jobID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_service.RunExportJob",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"jobID":          jobID,
+	}
+
+	job, err := s.repo.GetExportJob(ctx, jobID)
+	if err != nil {
+		return err
+	}
+	if job.JobStatus != ExportJobStatusPending {
+		log.WithFields(f).Warnf("skipping the signature export job with status: %s", job.JobStatus)
+		return nil
+	}
+
+	job.JobStatus = ExportJobStatusRunning
+	_, job.DateModified = utils.CurrentTime()
+	if err = s.repo.UpdateExportJob(ctx, job); err != nil {
+		return err
+	}
+
+	log.WithFields(f).Debugf("exporting the signatures of the CLA Group: %s as %s", job.ClaGroupID, job.Format)
+	key, rowCount, fileSize, err := s.writeExport(ctx, job)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to export the signatures")
+		reason := "unable to export the signatures"
+		if errors.Is(err, errExportTooLarge) {
+			reason = err.Error()
+		}
+		s.failExportJob(ctx, job, reason)
+		return nil
+	}
+
+	job.JobStatus = ExportJobStatusCompleted
+	job.S3Key = key
+	job.RowCount = rowCount
+	job.FileSize = fileSize
+	_, job.DateModified = utils.CurrentTime()
+	job.DateCompleted = job.DateModified
+	if err = s.repo.UpdateExportJob(ctx, job); err != nil {
+		return err
+	}
+	log.WithFields(f).Debugf("exported %d signatures to %s", rowCount, key)
+
+	s.eventsService.LogEventWithContext(ctx, exportEventArgs(job, events.SignatureExportCompleted, &events.SignatureExportCompletedEventData{
+		JobID:    job.JobID,
+		Format:   job.Format,
+		RowCount: rowCount,
+	}))
+	return nil
+}
+
+// failExportJob marks the export job as failed and records the failure event
+func (s *ExportService) failExportJob(ctx context.Context, job *ExportJob, reason string) {
+	job.JobStatus = ExportJobStatusFailed
+	job.ErrorMessage = reason
+	_, job.DateModified = utils.CurrentTime()
+	job.DateCompleted = job.DateModified
+	if err := s.repo.UpdateExportJob(ctx, job); err != nil {
+		return
+	}
+
+	s.eventsService.LogEventWithContext(ctx, exportEventArgs(job, events.SignatureExportFailed, &events.SignatureExportFailedEventData{
+		JobID:  job.JobID,
+		Format: job.Format,
+		Reason: reason,
+	}))
+}
+
+// writeExport writes the export to a temporary file and uploads it, the S3 key, number of signatures and file size
+// are returned
+func (s *ExportService) writeExport(ctx context.Context, job *ExportJob) (string, int64, int64, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_service.writeExport",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"jobID":          job.JobID,
+		"claGroupID":     job.ClaGroupID,
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("signature-export-%s-*.%s", job.JobID, job.Format))
+	if err != nil {
+		return "", 0, 0, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			log.WithFields(f).WithError(closeErr).Warn("problem closing the temporary export file")
+		}
+		if removeErr := os.Remove(file.Name()); removeErr != nil {
+			log.WithFields(f).WithError(removeErr).Warn("problem removing the temporary export file")
+		}
+	}()
+
+	writer, err := newExportWriter(job.Format, file)
+	if err != nil {
+		return "", 0, 0, err
+	}
+
+	lookups := newExportLookups(s.companyRepo, s.usersRepo)
+	var rowCount int64
+	err = s.repo.QueryClaGroupSignatures(ctx, job.ClaGroupID, job.Approved, job.Signed, func(page []signatures.ItemSignature) error {
+		for i := range page {
+			record, ok := exportRecordFromSignature(job, &page[i])
+			if !ok {
+				continue
+			}
+			lookups.enrich(ctx, record)
+			if writeErr := writer.Write(record); writeErr != nil {
+				return writeErr
+			}
+			rowCount++
+		}
+		return nil
+	})
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if err = writer.Close(); err != nil {
+		return "", 0, 0, err
+	}
+
+	fileSize, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return "", 0, 0, err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return "", 0, 0, err
+	}
+
+	key := s3ExportFilepath(job.ClaGroupID, job.JobID, job.Format)
+	uploader := s3manager.NewUploaderWithClient(s.s3)
+	_, err = uploader.Upload(&s3manager.UploadInput{
+		Bucket:      aws.String(s.bucketName),
+		Key:         aws.String(key),
+		Body:        file,
+		ContentType: aws.String(exportContentType(job.Format)),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warnf("unable to upload the export: %s", key)
+		return "", 0, 0, err
+	}
+	return key, rowCount, fileSize, nil
+}
+
+func s3ExportFilepath(claGroupID, jobID, format string) string {
+	return fmt.Sprintf("contract-group/%s/exports/%s.%s", claGroupID, jobID, format)
+}
+
+// validateExportJob checks the format and the filters of the export job
+func validateExportJob(job *ExportJob) error {
+	switch job.Format {
+	case ExportFormatCSV, ExportFormatJSONL, ExportFormatXLSX, ExportFormatParquet:
+	default:
+		return fmt.Errorf("%w: %s", ErrInvalidExportFormat, job.Format)
+	}
+
+	switch job.ClaType {
+	case "", utils.ClaTypeICLA, utils.ClaTypeCCLA, utils.ClaTypeECLA:
+	default:
+		return fmt.Errorf("%w: unsupported CLA type %s", ErrInvalidExportFilter, job.ClaType)
+	}
+	if job.ClaType == utils.ClaTypeICLA && job.CompanyID != "" {
+		return fmt.Errorf("%w: individual signatures don't have a company", ErrInvalidExportFilter)
+	}
+
+	var fromDate, toDate time.Time
+	var err error
+	if job.FromDate != "" {
+		if fromDate, err = time.Parse(exportDateFormat, job.FromDate); err != nil {
+			return fmt.Errorf("%w: the from date must use the YYYY-MM-DD format", ErrInvalidExportFilter)
+		}
+	}
+	if job.ToDate != "" {
+		if toDate, err = time.Parse(exportDateFormat, job.ToDate); err != nil {
+			return fmt.Errorf("%w: the to date must use the YYYY-MM-DD format", ErrInvalidExportFilter)
+		}
+	}
+	if job.FromDate != "" && job.ToDate != "" && toDate.Before(fromDate) {
+		return fmt.Errorf("%w: the from date is after the to date", ErrInvalidExportFilter)
+	}
+	return nil
+}
+
+// exportClaType returns the CLA type of the signature, or an empty string for the signatures which aren't exported
+func exportClaType(sig *signatures.ItemSignature) string {
+	switch {
+	case sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany && sig.SignatureType == utils.SignatureTypeCCLA:
+		return utils.ClaTypeCCLA
+	case sig.SignatureReferenceType == utils.SignatureReferenceTypeUser && sig.SignatureType == utils.SignatureTypeCLA && sig.SignatureUserCompanyID != "":
+		return utils.ClaTypeECLA
+	case sig.SignatureReferenceType == utils.SignatureReferenceTypeUser && sig.SignatureType == utils.SignatureTypeCLA:
+		return utils.ClaTypeICLA
+	}
+	return ""
+}
+
+// exportRecordFromSignature converts the signature into an export record, false is returned when the signature
+// doesn't match the CLA type, company or date range filters of the export job
+func exportRecordFromSignature(job *ExportJob, sig *signatures.ItemSignature) (*exportRecord, bool) {
+	claType := exportClaType(sig)
+	if claType == "" || (job.ClaType != "" && claType != job.ClaType) {
+		return nil, false
+	}
+
+	record := &exportRecord{
+		SignatureID:     sig.SignatureID,
+		ClaType:         claType,
+		ClaGroupID:      sig.SignatureProjectID,
+		SignatoryName:   sig.SignatoryName,
+		DocumentVersion: fmt.Sprintf("%d.%d", sig.SignatureDocumentMajorVersion, sig.SignatureDocumentMinorVersion),
+		Approved:        sig.SignatureApproved,
+		Signed:          sig.SignatureSigned,
+	}
+	switch claType {
+	case utils.ClaTypeCCLA:
+		record.CompanyID = sig.SignatureReferenceID
+		record.CompanyName = sig.SignatureReferenceName
+	case utils.ClaTypeECLA:
+		record.CompanyID = sig.SignatureUserCompanyID
+	}
+	if claType != utils.ClaTypeCCLA {
+		record.UserID = sig.SignatureReferenceID
+		record.UserName = sig.UserName
+		if record.UserName == "" {
+			record.UserName = sig.SignatureReferenceName
+		}
+		record.LFUsername = sig.UserLFUsername
+		record.Email = sig.UserEmail
+		record.GitHubUsername = sig.UserGithubUsername
+		record.GitLabUsername = sig.UserGitlabUsername
+	}
+	if job.CompanyID != "" && record.CompanyID != job.CompanyID {
+		return nil, false
+	}
+
+	// older signatures don't have the signed on date, the creation date is used instead
+	record.SignedOn = sig.SignedOn
+	if record.SignedOn == "" {
+		record.SignedOn = sig.DateCreated
+	}
+	signedOn, err := utils.ParseDateTime(record.SignedOn)
+	if err != nil {
+		return record, job.FromDate == "" && job.ToDate == ""
+	}
+	record.SignedOn = utils.TimeToString(signedOn)
+	signedDate := signedOn.UTC().Format(exportDateFormat)
+	if (job.FromDate != "" && signedDate < job.FromDate) || (job.ToDate != "" && signedDate > job.ToDate) {
+		return nil, false
+	}
+	return record, true
+}
+
+// exportFilters describes the filters of the export job for the audit events
+func exportFilters(job *ExportJob) string {
+	var filters []string
+	if job.ClaType != "" {
+		filters = append(filters, fmt.Sprintf("CLA type %s", strings.ToUpper(job.ClaType)))
+	}
+	if job.CompanyID != "" {
+		filters = append(filters, fmt.Sprintf("company ID %s", job.CompanyID))
+	}
+	if job.FromDate != "" {
+		filters = append(filters, fmt.Sprintf("signed from %s", job.FromDate))
+	}
+	if job.ToDate != "" {
+		filters = append(filters, fmt.Sprintf("signed to %s", job.ToDate))
+	}
+	if job.Approved != nil {
+		filters = append(filters, fmt.Sprintf("approved %t", *job.Approved))
+	}
+	if job.Signed != nil {
+		filters = append(filters, fmt.Sprintf("signed %t", *job.Signed))
+	}
+	return strings.Join(filters, ", ")
+}
+
+// exportEventArgs returns the event arguments of a signature export event, attributed to the user who requested it
+func exportEventArgs(job *ExportJob, eventType string, eventData events.EventData) *events.LogEventArgs {
+	return &events.LogEventArgs{
+		EventType:  eventType,
+		CLAGroupID: job.ClaGroupID,
+		ProjectID:  job.ClaGroupID,
+		LfUsername: job.RequestedBy,
+		UserName:   job.RequestedBy,
+		EventData:  eventData,
+	}
+}
+
+// exportLookups completes the export records with the company names and the user details missing from the older
+// signatures, the lookups are cached for the duration of the export
+type exportLookups struct {
+	companyRepo  company.IRepository
+	usersRepo    users.UserRepository
+	companyNames map[string]string
+	users        map[string]*v1Models.User
+}
+
+func newExportLookups(companyRepo company.IRepository, usersRepo users.UserRepository) *exportLookups {
+	return &exportLookups{
+		companyRepo:  companyRepo,
+		usersRepo:    usersRepo,
+		companyNames: make(map[string]string),
+		users:        make(map[string]*v1Models.User),
+	}
+}
+
+func (l *exportLookups) enrich(ctx context.Context, record *exportRecord) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.export_service.enrich",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    record.SignatureID,
+	}
+
+	if record.CompanyID != "" {
+		companyName, ok := l.companyNames[record.CompanyID]
+		if !ok {
+			companyModel, err := l.companyRepo.GetCompany(ctx, record.CompanyID)
+			if err != nil || companyModel == nil {
+				log.WithFields(f).WithError(err).Warnf("unable to load the company: %s", record.CompanyID)
+			} else {
+				companyName = companyModel.CompanyName
+			}
+			l.companyNames[record.CompanyID] = companyName
+		}
+		if companyName != "" {
+			record.CompanyName = companyName
+		}
+	}
+
+	if record.UserID == "" || (record.Email != "" && record.LFUsername != "" && record.UserName != "") {
+		return
+	}
+	userModel, ok := l.users[record.UserID]
+	if !ok {
+		var err error
+		userModel, err = l.usersRepo.GetUser(record.UserID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to load the user: %s", record.UserID)
+			userModel = nil
+		}
+		l.users[record.UserID] = userModel
+	}
+	if userModel == nil {
+		return
+	}
+	if record.UserName == "" {
+		record.UserName = userModel.Username
+	}
+	if record.LFUsername == "" {
+		record.LFUsername = userModel.LfUsername
+	}
+	if record.Email == "" {
+		record.Email = userModel.LfEmail.String()
+		if record.Email == "" && len(userModel.Emails) > 0 {
+			record.Email = userModel.Emails[0]
+		}
+	}
+	if record.GitHubUsername == "" {
+		record.GitHubUsername = userModel.GithubUsername
+	}
+	if record.GitLabUsername == "" {
+		record.GitLabUsername = userModel.GitlabUsername
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"testing"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateExportJob(t *testing.T) {
+	tests := []struct {
+		name string
+		job  ExportJob
+		err  error
+	}{
+		{name: "no filters", job: ExportJob{Format: ExportFormatCSV}},
+		{name: "all filters", job: ExportJob{Format: ExportFormatParquet, ClaType: utils.ClaTypeCCLA, CompanyID: "company", FromDate: "2021-01-01", ToDate: "2021-01-01"}},
+		{name: "unknown format", job: ExportJob{Format: "pdf"}, err: ErrInvalidExportFormat},
+		{name: "unknown CLA type", job: ExportJob{Format: ExportFormatCSV, ClaType: "cla"}, err: ErrInvalidExportFilter},
+		{name: "ICLA with company", job: ExportJob{Format: ExportFormatCSV, ClaType: utils.ClaTypeICLA, CompanyID: "company"}, err: ErrInvalidExportFilter},
+		{name: "invalid date", job: ExportJob{Format: ExportFormatCSV, FromDate: "01/02/2021"}, err: ErrInvalidExportFilter},
+		{name: "reversed dates", job: ExportJob{Format: ExportFormatCSV, FromDate: "2021-02-01", ToDate: "2021-01-31"}, err: ErrInvalidExportFilter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateExportJob(&tt.job)
+			if tt.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func TestExportRecordFromSignature(t *testing.T) {
+	icla := signatures.ItemSignature{
+		SignatureID:                   "icla",
+		SignatureProjectID:            "cla-group",
+		SignatureReferenceID:          "user-1",
+		SignatureReferenceName:        "Jane Doe",
+		SignatureReferenceType:        utils.SignatureReferenceTypeUser,
+		SignatureType:                 utils.SignatureTypeCLA,
+		SignatureDocumentMajorVersion: 2,
+		SignatureDocumentMinorVersion: 1,
+		SignatureApproved:             true,
+		SignatureSigned:               true,
+		UserEmail:                     "jane@example.org",
+		SignedOn:                      "2021-03-04T23:30:00-05:00",
+	}
+	ecla := signatures.ItemSignature{
+		SignatureID:            "ecla",
+		SignatureReferenceID:   "user-2",
+		SignatureReferenceType: utils.SignatureReferenceTypeUser,
+		SignatureType:          utils.SignatureTypeCLA,
+		SignatureUserCompanyID: "company-1",
+		DateCreated:            "2021-01-15T10:00:00Z",
+	}
+	ccla := signatures.ItemSignature{
+		SignatureID:            "ccla",
+		SignatureReferenceID:   "company-2",
+		SignatureReferenceName: "Acme",
+		SignatureReferenceType: utils.SignatureReferenceTypeCompany,
+		SignatureType:          utils.SignatureTypeCCLA,
+		SignedOn:               "unknown",
+	}
+
+	record, ok := exportRecordFromSignature(&ExportJob{}, &icla)
+	assert.True(t, ok)
+	assert.Equal(t, utils.ClaTypeICLA, record.ClaType)
+	assert.Equal(t, "user-1", record.UserID)
+	assert.Equal(t, "Jane Doe", record.UserName)
+	assert.Empty(t, record.CompanyID)
+	assert.Equal(t, "2.1", record.DocumentVersion)
+	assert.Equal(t, "2021-03-05T04:30:00Z", record.SignedOn)
+
+	record, ok = exportRecordFromSignature(&ExportJob{}, &ecla)
+	assert.True(t, ok)
+	assert.Equal(t, utils.ClaTypeECLA, record.ClaType)
+	assert.Equal(t, "company-1", record.CompanyID)
+	assert.Equal(t, "2021-01-15T10:00:00Z", record.SignedOn)
+
+	record, ok = exportRecordFromSignature(&ExportJob{}, &ccla)
+	assert.True(t, ok)
+	assert.Equal(t, utils.ClaTypeCCLA, record.ClaType)
+	assert.Equal(t, "company-2", record.CompanyID)
+	assert.Equal(t, "Acme", record.CompanyName)
+	assert.Empty(t, record.UserID)
+	assert.Equal(t, "unknown", record.SignedOn)
+
+	// the CLA type filter
+	_, ok = exportRecordFromSignature(&ExportJob{ClaType: utils.ClaTypeICLA}, &ecla)
+	assert.False(t, ok)
+	_, ok = exportRecordFromSignature(&ExportJob{ClaType: utils.ClaTypeECLA}, &ecla)
+	assert.True(t, ok)
+
+	// the company filter applies to the corporate and employee signatures
+	_, ok = exportRecordFromSignature(&ExportJob{CompanyID: "company-1"}, &ecla)
+	assert.True(t, ok)
+	_, ok = exportRecordFromSignature(&ExportJob{CompanyID: "company-1"}, &ccla)
+	assert.False(t, ok)
+	_, ok = exportRecordFromSignature(&ExportJob{CompanyID: "company-1"}, &icla)
+	assert.False(t, ok)
+
+	// the date range is inclusive and compared in UTC
+	_, ok = exportRecordFromSignature(&ExportJob{FromDate: "2021-03-05", ToDate: "2021-03-05"}, &icla)
+	assert.True(t, ok)
+	_, ok = exportRecordFromSignature(&ExportJob{ToDate: "2021-03-04"}, &icla)
+	assert.False(t, ok)
+	_, ok = exportRecordFromSignature(&ExportJob{FromDate: "2021-01-16"}, &ecla)
+	assert.False(t, ok)
+	_, ok = exportRecordFromSignature(&ExportJob{FromDate: "2020-01-01"}, &ccla)
+	assert.False(t, ok)
+}
+
+func TestExportFilters(t *testing.T) {
+	approved := true
+	job := &ExportJob{ClaType: utils.ClaTypeCCLA, CompanyID: "company", FromDate: "2021-01-01", Approved: &approved}
+	assert.Equal(t, "CLA type CCLA, company ID company, signed from 2021-01-01, approved true", exportFilters(job))
+	assert.Empty(t, exportFilters(&ExportJob{}))
+}
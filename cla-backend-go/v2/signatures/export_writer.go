@@ -0,0 +1,310 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"archive/zip"
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const (
+	// xlsxMaxRows is the maximum number of rows of an Excel worksheet, including the header row
+	xlsxMaxRows = 1048576
+)
+
+var (
+	// errExportTooLarge is returned when the signatures don't fit in the export format
+	errExportTooLarge = errors.New("too many signatures for the export format")
+)
+
+// exportRecord is a signature row of an export
+type exportRecord struct {
+	SignatureID     string `json:"signature_id" parquet:"signature_id"`
+	ClaType         string `json:"cla_type" parquet:"cla_type"`
+	ClaGroupID      string `json:"cla_group_id" parquet:"cla_group_id"`
+	CompanyID       string `json:"company_id" parquet:"company_id"`
+	CompanyName     string `json:"company_name" parquet:"company_name"`
+	UserID          string `json:"user_id" parquet:"user_id"`
+	UserName        string `json:"user_name" parquet:"user_name"`
+	LFUsername      string `json:"lf_username" parquet:"lf_username"`
+	Email           string `json:"email" parquet:"email"`
+	GitHubUsername  string `json:"github_username" parquet:"github_username"`
+	GitLabUsername  string `json:"gitlab_username" parquet:"gitlab_username"`
+	SignatoryName   string `json:"signatory_name" parquet:"signatory_name"`
+	SignedOn        string `json:"signed_on" parquet:"signed_on"`
+	DocumentVersion string `json:"document_version" parquet:"document_version"`
+	Approved        bool   `json:"approved" parquet:"approved"`
+	Signed          bool   `json:"signed" parquet:"signed"`
+}
+
+// exportColumn describes a column of the exports, the columns are strings unless boolean is set
+type exportColumn struct {
+	name    string
+	boolean bool
+}
+
+// exportColumns are the export columns, in the order of the exportRecord fields
+var exportColumns = []exportColumn{
+	{name: "signature_id"},
+	{name: "cla_type"},
+	{name: "cla_group_id"},
+	{name: "company_id"},
+	{name: "company_name"},
+	{name: "user_id"},
+	{name: "user_name"},
+	{name: "lf_username"},
+	{name: "email"},
+	{name: "github_username"},
+	{name: "gitlab_username"},
+	{name: "signatory_name"},
+	{name: "signed_on"},
+	{name: "document_version"},
+	{name: "approved", boolean: true},
+	{name: "signed", boolean: true},
+}
+
+// values returns the record values in the order of the export columns, as string or bool values
+func (r *exportRecord) values() []interface{} {
+	return []interface{}{
+		r.SignatureID,
+		r.ClaType,
+		r.ClaGroupID,
+		r.CompanyID,
+		r.CompanyName,
+		r.UserID,
+		r.UserName,
+		r.LFUsername,
+		r.Email,
+		r.GitHubUsername,
+		r.GitLabUsername,
+		r.SignatoryName,
+		r.SignedOn,
+		r.DocumentVersion,
+		r.Approved,
+		r.Signed,
+	}
+}
+
+// exportWriter writes the export records to a file, Close must be called to complete the file
+type exportWriter interface {
+	Write(record *exportRecord) error
+	Close() error
+}
+
+// newExportWriter returns the writer of the export format
+func newExportWriter(format string, w io.Writer) (exportWriter, error) {
+	switch format {
+	case ExportFormatCSV:
+		return newCSVExportWriter(w)
+	case ExportFormatJSONL:
+		return newJSONLExportWriter(w), nil
+	case ExportFormatXLSX:
+		return newXLSXExportWriter(w)
+	case ExportFormatParquet:
+		return newParquetExportWriter(w)
+	}
+	return nil, fmt.Errorf("unsupported export format: %s", format)
+}
+
+// exportContentType returns the content type of the export format
+func exportContentType(format string) string {
+	switch format {
+	case ExportFormatCSV:
+		return "text/csv"
+	case ExportFormatJSONL:
+		return "application/x-ndjson"
+	case ExportFormatXLSX:
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	case ExportFormatParquet:
+		return "application/vnd.apache.parquet"
+	}
+	return "application/octet-stream"
+}
+
+// csvExportWriter writes the records as CSV with a header row
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) (*csvExportWriter, error) {
+	writer := &csvExportWriter{w: csv.NewWriter(w)}
+	header := make([]string, 0, len(exportColumns))
+	for _, column := range exportColumns {
+		header = append(header, column.name)
+	}
+	if err := writer.w.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (c *csvExportWriter) Write(record *exportRecord) error {
+	values := record.values()
+	row := make([]string, 0, len(values))
+	for _, value := range values {
+		switch v := value.(type) {
+		case bool:
+			row = append(row, strconv.FormatBool(v))
+		case string:
+			row = append(row, v)
+		}
+	}
+	return c.w.Write(row)
+}
+
+func (c *csvExportWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// jsonlExportWriter writes one JSON object per line
+type jsonlExportWriter struct {
+	w       *bufio.Writer
+	encoder *json.Encoder
+}
+
+func newJSONLExportWriter(w io.Writer) *jsonlExportWriter {
+	buffered := bufio.NewWriter(w)
+	return &jsonlExportWriter{w: buffered, encoder: json.NewEncoder(buffered)}
+}
+
+func (j *jsonlExportWriter) Write(record *exportRecord) error {
+	return j.encoder.Encode(record)
+}
+
+func (j *jsonlExportWriter) Close() error {
+	return j.w.Flush()
+}
+
+// The static parts of the XLSX package, the worksheet is streamed as the last part
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/></Types>`
+	xlsxRootRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+	xlsxWorkbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Signatures" sheetId="1" r:id="rId1"/></sheets></workbook>`
+	xlsxWorkbookRelationships = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/></Relationships>`
+	xlsxWorksheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxWorksheetEnd = `</sheetData></worksheet>`
+)
+
+// xlsxExportWriter writes a single worksheet XLSX workbook with inline strings
+type xlsxExportWriter struct {
+	zipWriter *zip.Writer
+	sheet     *bufio.Writer
+	rows      int
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	zipWriter := zip.NewWriter(w)
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{name: "[Content_Types].xml", content: xlsxContentTypes},
+		{name: "_rels/.rels", content: xlsxRootRelationships},
+		{name: "xl/workbook.xml", content: xlsxWorkbook},
+		{name: "xl/_rels/workbook.xml.rels", content: xlsxWorkbookRelationships},
+	}
+	for _, part := range parts {
+		partWriter, err := zipWriter.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err = io.WriteString(partWriter, part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	sheetWriter, err := zipWriter.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	writer := &xlsxExportWriter{zipWriter: zipWriter, sheet: bufio.NewWriter(sheetWriter)}
+	if _, err = writer.sheet.WriteString(xlsxWorksheetStart); err != nil {
+		return nil, err
+	}
+
+	header := make([]interface{}, 0, len(exportColumns))
+	for _, column := range exportColumns {
+		header = append(header, column.name)
+	}
+	if err = writer.writeRow(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (x *xlsxExportWriter) Write(record *exportRecord) error {
+	if x.rows >= xlsxMaxRows {
+		return errExportTooLarge
+	}
+	return x.writeRow(record.values())
+}
+
+// writeRow writes a worksheet row, the strings are written inline so the workbook doesn't need a shared strings part
+func (x *xlsxExportWriter) writeRow(values []interface{}) error {
+	x.rows++
+	if _, err := fmt.Fprintf(x.sheet, `<row r="%d">`, x.rows); err != nil {
+		return err
+	}
+	for i, value := range values {
+		cell := xlsxCellReference(i, x.rows)
+		switch v := value.(type) {
+		case bool:
+			flag := 0
+			if v {
+				flag = 1
+			}
+			if _, err := fmt.Fprintf(x.sheet, `<c r="%s" t="b"><v>%d</v></c>`, cell, flag); err != nil {
+				return err
+			}
+		case string:
+			if v == "" {
+				continue
+			}
+			if _, err := fmt.Fprintf(x.sheet, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, cell); err != nil {
+				return err
+			}
+			if err := xml.EscapeText(x.sheet, []byte(v)); err != nil {
+				return err
+			}
+			if _, err := x.sheet.WriteString(`</t></is></c>`); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := x.sheet.WriteString(`</row>`)
+	return err
+}
+
+func (x *xlsxExportWriter) Close() error {
+	if _, err := x.sheet.WriteString(xlsxWorksheetEnd); err != nil {
+		return err
+	}
+	if err := x.sheet.Flush(); err != nil {
+		return err
+	}
+	return x.zipWriter.Close()
+}
+
+// xlsxCellReference returns the A1 reference of the zero based column index and the one based row number
+func xlsxCellReference(column int, row int) string {
+	var name []byte
+	for column >= 0 {
+		name = append([]byte{byte('A' + column%26)}, name...)
+		column = column/26 - 1
+	}
+	return fmt.Sprintf("%s%d", name, row)
+}
@@ -0,0 +1,142 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func exportTestRecords() []*exportRecord {
+	return []*exportRecord{
+		{
+			SignatureID:     "signature-1",
+			ClaType:         "icla",
+			ClaGroupID:      "cla-group",
+			UserID:          "user-1",
+			UserName:        "Jane <Doe> & Co",
+			Email:           "jane@example.org",
+			SignedOn:        "2021-03-04T10:00:00Z",
+			DocumentVersion: "2.0",
+			Approved:        true,
+			Signed:          true,
+		},
+		{
+			SignatureID:     "signature-2",
+			ClaType:         "ccla",
+			ClaGroupID:      "cla-group",
+			CompanyID:       "company-1",
+			CompanyName:     "Acme, Inc.",
+			SignedOn:        "2021-03-05T10:00:00Z",
+			DocumentVersion: "2.1",
+			Approved:        true,
+			Signed:          false,
+		},
+	}
+}
+
+func writeTestExport(t *testing.T, format string) []byte {
+	var b bytes.Buffer
+	writer, err := newExportWriter(format, &b)
+	assert.NoError(t, err)
+	for _, record := range exportTestRecords() {
+		assert.NoError(t, writer.Write(record))
+	}
+	assert.NoError(t, writer.Close())
+	return b.Bytes()
+}
+
+func TestExportWriterCSV(t *testing.T) {
+	rows, err := csv.NewReader(bytes.NewReader(writeTestExport(t, ExportFormatCSV))).ReadAll()
+	assert.NoError(t, err)
+	assert.Len(t, rows, 3)
+	assert.Equal(t, "signature_id", rows[0][0])
+	assert.Equal(t, "signed", rows[0][len(exportColumns)-1])
+	assert.Equal(t, "Acme, Inc.", rows[2][4])
+	assert.Equal(t, []string{"true", "false"}, rows[2][len(exportColumns)-2:])
+}
+
+func TestExportWriterJSONL(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(writeTestExport(t, ExportFormatJSONL))), "\n")
+	assert.Len(t, lines, 2)
+
+	var record map[string]interface{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &record))
+	assert.Equal(t, "signature-2", record["signature_id"])
+	assert.Equal(t, "Acme, Inc.", record["company_name"])
+	assert.Equal(t, false, record["signed"])
+	assert.Len(t, record, len(exportColumns))
+}
+
+func TestExportWriterXLSX(t *testing.T) {
+	data := writeTestExport(t, ExportFormatXLSX)
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+
+	parts := make(map[string]string)
+	for _, file := range zipReader.File {
+		reader, openErr := file.Open()
+		assert.NoError(t, openErr)
+		content, readErr := io.ReadAll(reader)
+		assert.NoError(t, readErr)
+		parts[file.Name] = string(content)
+	}
+	assert.Contains(t, parts, "[Content_Types].xml")
+	assert.Contains(t, parts, "xl/workbook.xml")
+
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	assert.True(t, strings.HasSuffix(sheet, "</sheetData></worksheet>"))
+	assert.Contains(t, sheet, `<row r="3">`)
+	assert.Contains(t, sheet, `<c r="A1" t="inlineStr"><is><t xml:space="preserve">signature_id</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="G2" t="inlineStr"><is><t xml:space="preserve">Jane &lt;Doe&gt; &amp; Co</t></is></c>`)
+	assert.Contains(t, sheet, `<c r="P3" t="b"><v>0</v></c>`)
+	// empty values don't have a cell
+	assert.NotContains(t, sheet, `<c r="D2"`)
+}
+
+func TestXLSXCellReference(t *testing.T) {
+	assert.Equal(t, "A1", xlsxCellReference(0, 1))
+	assert.Equal(t, "Z10", xlsxCellReference(25, 10))
+	assert.Equal(t, "AA2", xlsxCellReference(26, 2))
+	assert.Equal(t, "AZ3", xlsxCellReference(51, 3))
+	assert.Equal(t, "BA4", xlsxCellReference(52, 4))
+}
+
+func TestExportWriterParquet(t *testing.T) {
+	data := writeTestExport(t, ExportFormatParquet)
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), file.NumRows())
+	assert.Contains(t, file.Metadata().CreatedBy, parquetCreatedBy)
+
+	columns := file.Schema().Columns()
+	assert.Len(t, columns, len(exportColumns))
+	for i, column := range exportColumns {
+		assert.Equal(t, []string{column.name}, columns[i])
+		leaf, _ := file.Schema().Lookup(column.name)
+		assert.False(t, leaf.Node.Optional())
+		if column.boolean {
+			assert.Equal(t, parquet.Boolean, leaf.Node.Type().Kind())
+		} else {
+			assert.Equal(t, parquet.ByteArray, leaf.Node.Type().Kind())
+			assert.NotNil(t, leaf.Node.Type().LogicalType().UTF8)
+		}
+	}
+
+	records, err := parquet.Read[exportRecord](bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+	expected := exportTestRecords()
+	assert.Len(t, records, len(expected))
+	for i := range expected {
+		assert.Equal(t, *expected[i], records[i])
+	}
+}
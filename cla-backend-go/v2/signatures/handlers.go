@@ -5,6 +5,7 @@ package signatures
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -42,7 +43,7 @@ import (
 )
 
 // Configure setups handlers on api with service
-func Configure(api *operations.EasyclaAPI, claGroupService service.Service, projectRepo repository.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2SignatureService ServiceInterface, projectClaGroupsRepo projects_cla_groups.Repository) { //nolint
+func Configure(api *operations.EasyclaAPI, claGroupService service.Service, projectRepo repository.ProjectRepository, companyService company.IService, v1SignatureService signatureService.SignatureService, sessionStore *dynastore.Store, eventsService events.Service, v2SignatureService ServiceInterface, projectClaGroupsRepo projects_cla_groups.Repository, exportService ExportServiceInterface) { //nolint
 
 	const problemLoadingCLAGroupByID = "problem loading cla group by ID"
 	const iclaNotSupportedForCLAGroup = "individual contribution is not supported for this project"
@@ -93,6 +94,67 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		return signatures.NewGetSignatureOK().WithXRequestID(reqID).WithPayload(resp)
 	})
 
+	// Revoke Signature
+	api.SignaturesRevokeSignatureHandler = signatures.RevokeSignatureHandlerFunc(func(params signatures.RevokeSignatureParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesRevokeSignatureHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"signatureID":    params.SignatureID,
+		}
+
+		log.WithFields(f).Debug("loading signature...")
+		signature, err := v1SignatureService.GetSignature(ctx, params.SignatureID)
+		if err != nil {
+			msg := "error retrieving signatures by signature ID"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewRevokeSignatureBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+		if signature == nil {
+			msg := "signature search by ID not found"
+			log.WithFields(f).Warn(msg)
+			return signatures.NewRevokeSignatureNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		log.WithFields(f).Debug("checking access control permissions for user...")
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, signature.ProjectID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s is not authorized to revoke signatures of CLA Group: %s", authUser.UserName, signature.ProjectID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewRevokeSignatureForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		revokedSignature, err := v2SignatureService.RevokeSignature(ctx, authUser, signature, params.Body, eventsService)
+		if err != nil {
+			msg := "unable to revoke signature"
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrSignatureAlreadyRevoked) || errors.Is(err, ErrInvalidRevocationDate) {
+				return signatures.NewRevokeSignatureBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+			if errors.Is(err, ErrRevocationCLAGroupNotFound) {
+				return signatures.NewRevokeSignatureNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewRevokeSignatureInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		if revokedSignature == nil {
+			msg := "revoked signature not found"
+			log.WithFields(f).Warn(msg)
+			return signatures.NewRevokeSignatureNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		resp, err := v2Signature(revokedSignature)
+		if err != nil {
+			msg := "problem converting v1 signature to v2"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewRevokeSignatureBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		log.WithFields(f).Debug("returning revoked signature to caller...")
+		return signatures.NewRevokeSignatureOK().WithXRequestID(reqID).WithPayload(resp)
+	})
+
 	api.SignaturesUpdateApprovalListHandler = signatures.UpdateApprovalListHandlerFunc(func(params signatures.UpdateApprovalListParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
@@ -184,6 +246,132 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		return signatures.NewUpdateApprovalListOK().WithXRequestID(reqID).WithPayload(&v2Sig)
 	})
 
+	api.SignaturesExportApprovalListHandler = signatures.ExportApprovalListHandlerFunc(func(params signatures.ExportApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesExportApprovalListHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+			"format":         utils.StringValue(params.Format),
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to locate company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewExportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewExportApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(ctx, authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user '%s' does not have access to export the Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, companyModel.CompanyExternalID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewExportApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		export, err := v2SignatureService.ExportApprovalList(ctx, params.ClaGroupID, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to export the approval list for company ID: %s, CLA Group ID: %s", params.CompanyID, params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, ErrCorporateSignatureNotFound) {
+				return signatures.NewExportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewExportApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		contentType := "text/csv"
+		var result []byte
+		if utils.StringValue(params.Format) == ApprovalListFormatJSON {
+			contentType = "application/json"
+			result, err = json.Marshal(export)
+		} else {
+			result, err = ApprovalListExportCsv(export)
+		}
+		if err != nil {
+			msg := "unable to encode the approval list export"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return signatures.NewExportApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+			rw.Header().Set("Content-Type", contentType)
+			rw.Header().Set(utils.XREQUESTID, reqID)
+			rw.WriteHeader(http.StatusOK)
+			_, writeErr := rw.Write(result)
+			if writeErr != nil {
+				log.WithFields(f).WithError(writeErr).Warn("error writing approval list export")
+			}
+		})
+	})
+
+	api.SignaturesImportApprovalListHandler = signatures.ImportApprovalListHandlerFunc(func(params signatures.ImportApprovalListParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesImportApprovalListHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"projectSFID":    params.ProjectSFID,
+			"companyID":      params.CompanyID,
+		}
+
+		companyModel, err := companyService.GetCompany(ctx, params.CompanyID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to locate company by ID: %s", params.CompanyID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*utils.CompanyNotFound); ok {
+				return signatures.NewImportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewImportApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		// Must be in the Project|Organization Scope - signature ACL is double-checked in the service level when the signature is loaded
+		if !utils.IsUserAuthorizedForProjectOrganizationTree(ctx, authUser, params.ProjectSFID, companyModel.CompanyExternalID, utils.DISALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user '%s' does not have access to import the Project Company Approval List with Project|Organization scope of %s | %s",
+				authUser.UserName, params.ProjectSFID, companyModel.CompanyExternalID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewImportApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		claGroupModel, projErr := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if projErr != nil || claGroupModel == nil {
+			msg := fmt.Sprintf("unable to locate project by CLA Group ID: %s", params.ClaGroupID)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewImportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFound(reqID, msg))
+		}
+
+		result, err := v2SignatureService.ImportApprovalList(ctx, authUser, claGroupModel, companyModel, params.Body, params.ProjectSFID, eventsService)
+		if err != nil {
+			msg := fmt.Sprintf("unable to import the approval list for company ID: %s, CLA Group ID: %s", params.CompanyID, params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if _, ok := err.(*signatureService.ForbiddenError); ok {
+				return signatures.NewImportApprovalListForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbiddenWithError(reqID, msg, err))
+			}
+			if errors.Is(err, ErrCorporateSignatureNotFound) {
+				return signatures.NewImportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			var userNotFound *utils.UserNotFound
+			if errors.As(err, &userNotFound) {
+				return signatures.NewImportApprovalListNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			if errors.Is(err, ErrInvalidApprovalListDocument) {
+				return signatures.NewImportApprovalListBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+			}
+			return signatures.NewImportApprovalListInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		log.WithFields(f).Debugf("returning approval list import result to caller - applied: %t", result.Applied)
+		return signatures.NewImportApprovalListOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
 	// Retrieve GitHub Approval Entries
 	api.SignaturesGetGitHubOrgWhitelistHandler = signatures.GetGitHubOrgWhitelistHandlerFunc(func(params signatures.GetGitHubOrgWhitelistParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -1062,6 +1250,61 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		return signatures.NewDownloadProjectSignatureICLAsOK().WithXRequestID(reqID).WithPayload(result)
 	})
 
+	// Download the ICLA signed PDF archive parts
+	api.SignaturesDownloadProjectSignatureICLAArchiveHandler = signatures.DownloadProjectSignatureICLAArchiveHandlerFunc(func(params signatures.DownloadProjectSignatureICLAArchiveParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesDownloadProjectSignatureICLAArchiveHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		log.WithFields(f).Debug("loading cla group by id...")
+		claGroupModel, err := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn(problemLoadingCLAGroupByID)
+			if err == repository.ErrProjectDoesNotExist {
+				return signatures.NewDownloadProjectSignatureICLAArchiveNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, problemLoadingCLAGroupByID, err))
+			}
+			return signatures.NewDownloadProjectSignatureICLAArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, problemLoadingCLAGroupByID, err))
+		}
+
+		if !claGroupModel.ProjectICLAEnabled {
+			log.WithFields(f).Warn(iclaNotSupportedForCLAGroup)
+			return signatures.NewDownloadProjectSignatureICLAArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequest(reqID, "icla is not enabled for this cla group"))
+		}
+
+		log.WithFields(f).Debug("checking access control permissions for user...")
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s is not authorized to view project ICLA signatures any scope of project", authUser.UserName)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDownloadProjectSignatureICLAArchiveForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+		log.WithFields(f).Debug("user has access for this query")
+
+		log.WithFields(f).Debug("loading the ICLA signed pdf archive...")
+		result, err := v2SignatureService.GetSignedPDFArchive(ctx, params.ClaGroupID, utils.ClaTypeICLA)
+		if err != nil {
+			if err == ErrZipNotPresent {
+				msg := "the icla signed pdf archive has not been built for this cla group"
+				log.WithFields(f).Warn(msg)
+				return signatures.NewDownloadProjectSignatureICLAArchiveNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewDownloadProjectSignatureICLAArchiveInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, "unable to load the signed pdf archive", err))
+		}
+
+		log.WithFields(f).Debugf("returning %d archive parts to caller...", len(result.Parts))
+		return signatures.NewDownloadProjectSignatureICLAArchiveOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
 	// Download ICLAs as a CSV document
 	api.SignaturesDownloadProjectSignatureICLAAsCSVHandler = signatures.DownloadProjectSignatureICLAAsCSVHandlerFunc(func(params signatures.DownloadProjectSignatureICLAAsCSVParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -1184,6 +1427,61 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		return signatures.NewDownloadProjectSignatureCCLAsOK().WithXRequestID(reqID).WithPayload(result)
 	})
 
+	// Download the CCLA signed PDF archive parts
+	api.SignaturesDownloadProjectSignatureCCLAArchiveHandler = signatures.DownloadProjectSignatureCCLAArchiveHandlerFunc(func(params signatures.DownloadProjectSignatureCCLAArchiveParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.signatures.handlers.SignaturesDownloadProjectSignatureCCLAArchiveHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		log.WithFields(f).Debug("loading cla group by id...")
+		claGroupModel, err := claGroupService.GetCLAGroupByID(ctx, params.ClaGroupID)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn(problemLoadingCLAGroupByID)
+			if err == repository.ErrProjectDoesNotExist {
+				return signatures.NewDownloadProjectSignatureCCLAArchiveNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, problemLoadingCLAGroupByID, err))
+			}
+			return signatures.NewDownloadProjectSignatureCCLAArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequestWithError(reqID, problemLoadingCLAGroupByID, err))
+		}
+
+		if !claGroupModel.ProjectCCLAEnabled {
+			log.WithFields(f).Warn(cclaNotSupportedForCLAGroup)
+			return signatures.NewDownloadProjectSignatureCCLAArchiveBadRequest().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseBadRequest(reqID, cclaNotSupportedForCLAGroup))
+		}
+
+		log.WithFields(f).Debug("checking access control permissions for user...")
+		if !isUserHaveAccessToCLAGroupProjects(ctx, authUser, params.ClaGroupID, projectClaGroupsRepo, projectRepo) {
+			msg := fmt.Sprintf("user %s is not authorized to view project CCLA signatures any scope of project", authUser.UserName)
+			log.WithFields(f).Warn(msg)
+			return signatures.NewDownloadProjectSignatureCCLAArchiveForbidden().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseForbidden(reqID, msg))
+		}
+		log.WithFields(f).Debug("user has access for this query")
+
+		log.WithFields(f).Debug("loading the CCLA signed pdf archive...")
+		result, err := v2SignatureService.GetSignedPDFArchive(ctx, params.ClaGroupID, utils.ClaTypeCCLA)
+		if err != nil {
+			if err == ErrZipNotPresent {
+				msg := "the ccla signed pdf archive has not been built for this cla group"
+				log.WithFields(f).Warn(msg)
+				return signatures.NewDownloadProjectSignatureCCLAArchiveNotFound().WithXRequestID(reqID).WithPayload(
+					utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return signatures.NewDownloadProjectSignatureCCLAArchiveInternalServerError().WithXRequestID(reqID).WithPayload(
+				utils.ErrorResponseInternalServerErrorWithError(reqID, "unable to load the signed pdf archive", err))
+		}
+
+		log.WithFields(f).Debugf("returning %d archive parts to caller...", len(result.Parts))
+		return signatures.NewDownloadProjectSignatureCCLAArchiveOK().WithXRequestID(reqID).WithPayload(result)
+	})
+
 	// Download CCLAs as a CSV document
 	api.SignaturesDownloadProjectSignatureCCLAAsCSVHandler = signatures.DownloadProjectSignatureCCLAAsCSVHandlerFunc(func(params signatures.DownloadProjectSignatureCCLAAsCSVParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
@@ -1416,6 +1714,8 @@ func Configure(api *operations.EasyclaAPI, claGroupService service.Service, proj
 		log.WithFields(f).Debug("returning authorization result to caller...")
 		return signatures.NewIsAuthorizedOK().WithXRequestID(reqID).WithPayload(result)
 	})
+
+	configureExportJobs(api, exportService, projectRepo, projectClaGroupsRepo)
 }
 
 // getProjectIDsFromModels is a helper function to extract the project SFIDs from the project CLA Group models
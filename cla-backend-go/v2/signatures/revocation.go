@@ -0,0 +1,208 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/signatures"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// ErrSignatureAlreadyRevoked is returned when the signature was already revoked
+	ErrSignatureAlreadyRevoked = errors.New("signature already revoked")
+	// ErrInvalidRevocationDate is returned when the revocation effective date is invalid
+	ErrInvalidRevocationDate = errors.New("invalid revocation effective date - expecting a date time")
+	// ErrRevocationCLAGroupNotFound is returned when the CLA Group of the revoked signature does not exist
+	ErrRevocationCLAGroupNotFound = errors.New("CLA Group of the signature not found")
+)
+
+// EnvelopeVoider voids pending signature envelopes
+type EnvelopeVoider interface {
+	VoidEnvelope(ctx context.Context, signatureProvider, envelopeID, message string) error
+}
+
+// RevokeSignature revokes the ICLA, ECLA or CCLA signature and voids the open envelopes of the signer. The signature
+// update is propagated asynchronously by the DynamoDB stream handler - the affected contributors are removed from the
+// CLA Group Gerrit LDAP groups and the CLA status of their open pull/merge requests is updated. A revocation with a
+// future effective date is stored as pending, the signature remains approved until ApplyPendingRevocations revokes it.
+func (s *Service) RevokeSignature(ctx context.Context, authUser *auth.User, sig *v1Models.Signature, input *models.SignatureRevocationInput, eventsService events.Service) (*v1Models.Signature, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.service.RevokeSignature",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     sig.ProjectID,
+		"reason":         utils.StringValue(input.Reason),
+		"effectiveDate":  input.EffectiveDate,
+		"authUserName":   authUser.UserName,
+	}
+
+	if sig.SignatureRevokedReason != "" {
+		log.WithFields(f).Warnf("signature already revoked on %s by %s", sig.SignatureRevokedDate, sig.SignatureRevokedBy)
+		return nil, ErrSignatureAlreadyRevoked
+	}
+
+	now, currentTime := utils.CurrentTime()
+	effectiveDate := currentTime
+	pending := false
+	if input.EffectiveDate != "" {
+		effectiveTime, parseErr := utils.ParseDateTime(input.EffectiveDate)
+		if parseErr != nil {
+			log.WithFields(f).WithError(parseErr).Warn("invalid revocation effective date")
+			return nil, ErrInvalidRevocationDate
+		}
+		effectiveDate = utils.TimeToString(effectiveTime)
+		pending = effectiveTime.After(now)
+	}
+
+	claGroup, claGroupErr := s.v1ProjectService.GetCLAGroupByID(ctx, sig.ProjectID)
+	if claGroupErr != nil {
+		log.WithFields(f).WithError(claGroupErr).Warn("unable to load the CLA Group of the signature")
+		return nil, claGroupErr
+	}
+	if claGroup == nil {
+		log.WithFields(f).Warn("the CLA Group of the signature does not exist")
+		return nil, ErrRevocationCLAGroupNotFound
+	}
+
+	note := fmt.Sprintf("Signature revoked by %s on %s with reason: %s", authUser.UserName, effectiveDate, utils.StringValue(input.Reason))
+	if input.Note != "" {
+		note = fmt.Sprintf("%s - %s", note, input.Note)
+	}
+
+	updates := map[string]interface{}{
+		"note":                                  note,
+		"date_modified":                         currentTime,
+		signatures.SignatureRevokedReasonColumn: utils.StringValue(input.Reason),
+		signatures.SignatureRevokedDateColumn:   effectiveDate,
+		signatures.SignatureRevokedByColumn:     authUser.UserName,
+	}
+	if pending {
+		log.WithFields(f).Debug("scheduling the signature revocation...")
+		updates[signatures.SignatureRevocationPendingColumn] = true
+	} else {
+		log.WithFields(f).Debug("revoking signature...")
+		updates["signature_approved"] = false
+	}
+	updateErr := s.v1SignatureRepo.UpdateSignature(ctx, sig.SignatureID, updates)
+	if updateErr != nil {
+		log.WithFields(f).WithError(updateErr).Warn("unable to revoke signature")
+		return nil, updateErr
+	}
+
+	s.voidOpenEnvelopes(ctx, sig, claGroup.ProjectName)
+
+	eventArgs := &events.LogEventArgs{
+		EventType:     events.SignatureRevoked,
+		CLAGroupID:    claGroup.ProjectID,
+		ClaGroupModel: claGroup,
+		ProjectName:   claGroup.ProjectName,
+		LfUsername:    authUser.UserName,
+		EventData: &events.SignatureRevokedEventData{
+			SignatureID:   sig.SignatureID,
+			SignatureType: sig.ClaType,
+			Reason:        utils.StringValue(input.Reason),
+			EffectiveDate: effectiveDate,
+			RevokedBy:     authUser.UserName,
+		},
+	}
+	if sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		eventArgs.CompanyID = sig.SignatureReferenceID
+		eventArgs.CompanyName = sig.CompanyName
+	} else {
+		eventArgs.UserID = sig.SignatureReferenceID
+		eventArgs.UserName = sig.SignatureReferenceName
+	}
+	eventsService.LogEventWithContext(ctx, eventArgs)
+
+	return s.v1SignatureService.GetSignature(ctx, sig.SignatureID)
+}
+
+// ApplyPendingRevocations revokes the signatures whose pending revocation is effective, the DynamoDB stream handler
+// propagates the revocations once the signatures are updated
+func (s *Service) ApplyPendingRevocations(ctx context.Context) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.service.ApplyPendingRevocations",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+	}
+
+	_, currentTime := utils.CurrentTime()
+	pendingSignatures, err := s.v1SignatureRepo.GetPendingRevocationSignatures(ctx, currentTime)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the signatures with a pending revocation")
+		return err
+	}
+
+	revoked := 0
+	for _, sig := range pendingSignatures {
+		log.WithFields(f).Debugf("revoking signature: %s of CLA Group: %s effective on %s", sig.SignatureID, sig.SignatureProjectID, sig.SignatureRevokedDate)
+		updateErr := s.v1SignatureRepo.UpdateSignature(ctx, sig.SignatureID, map[string]interface{}{
+			"signature_approved": false,
+			"date_modified":      currentTime,
+			signatures.SignatureRevocationPendingColumn: false,
+		})
+		if updateErr != nil {
+			// The revocation remains pending and is retried on the next run
+			log.WithFields(f).WithError(updateErr).Warnf("unable to revoke signature: %s - continuing", sig.SignatureID)
+			continue
+		}
+		revoked++
+	}
+
+	log.WithFields(f).Infof("revoked %d of %d signatures with an effective pending revocation", revoked, len(pendingSignatures))
+	return nil
+}
+
+// voidOpenEnvelopes voids the envelope of the revoked signature and of any pending (unsigned) signature for the same
+// signer in the CLA Group
+func (s *Service) voidOpenEnvelopes(ctx context.Context, sig *v1Models.Signature, claGroupName string) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.service.voidOpenEnvelopes",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"signatureID":    sig.SignatureID,
+		"claGroupID":     sig.ProjectID,
+	}
+
+	if s.envelopeVoider == nil {
+		log.WithFields(f).Debug("no envelope voider configured - skipping")
+		return
+	}
+
+	var pending []*v1Models.Signature
+	var pendingErr error
+	if sig.SignatureReferenceType == utils.SignatureReferenceTypeCompany {
+		pending, pendingErr = s.v1SignatureService.GetCorporateSignatures(ctx, sig.ProjectID, sig.SignatureReferenceID, nil, aws.Bool(false))
+	} else {
+		pending, pendingErr = s.v1SignatureService.GetIndividualSignatures(ctx, sig.ProjectID, sig.SignatureReferenceID, nil, aws.Bool(false))
+	}
+	if pendingErr != nil {
+		log.WithFields(f).WithError(pendingErr).Warn("unable to load the pending signatures of the signer")
+	}
+	if !sig.SignatureSigned {
+		pending = append(pending, sig)
+	}
+
+	message := fmt.Sprintf("You are getting this message because your signature for %s was revoked.", claGroupName)
+	voided := make(map[string]bool)
+	for _, pendingSig := range pending {
+		if pendingSig == nil || pendingSig.SignatureEnvelopeID == "" || voided[pendingSig.SignatureEnvelopeID] {
+			continue
+		}
+		voided[pendingSig.SignatureEnvelopeID] = true
+		log.WithFields(f).Debugf("voiding envelope: %s of signature: %s", pendingSig.SignatureEnvelopeID, pendingSig.SignatureID)
+		if err := s.envelopeVoider.VoidEnvelope(ctx, pendingSig.SignatureProvider, pendingSig.SignatureEnvelopeID, message); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to void envelope: %s - continuing", pendingSig.SignatureEnvelopeID)
+		}
+	}
+}
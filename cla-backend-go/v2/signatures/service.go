@@ -8,7 +8,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
+	projectCommon "github.com/linuxfoundation/easycla/cla-backend-go/project/common"
 	"github.com/linuxfoundation/easycla/cla-backend-go/project/service"
 
 	"github.com/LF-Engineering/lfx-kit/auth"
@@ -57,9 +59,13 @@ type ServiceInterface interface {
 	GetSignedDocument(ctx context.Context, signatureID string) (*models.SignedDocument, error)
 	GetSignedIclaZipPdf(claGroupID string) (*models.URLObject, error)
 	GetSignedCclaZipPdf(claGroupID string) (*models.URLObject, error)
+	GetSignedPDFArchive(ctx context.Context, claGroupID, claType string) (*models.SignatureArchive, error)
 	InvalidateICLA(ctx context.Context, claGroupID string, userID string, authUser *auth.User, eventsService events.Service, eventArgs *events.LogEventArgs) error
 	EclaAutoCreate(ctx context.Context, signatureID string, autoCreateECLA bool) error
 	IsUserAuthorized(ctx context.Context, lfid, claGroupId string) (*models.LfidAuthorizedResponse, error)
+	RevokeSignature(ctx context.Context, authUser *auth.User, sig *v1Models.Signature, input *models.SignatureRevocationInput, eventsService events.Service) (*v1Models.Signature, error)
+	ExportApprovalList(ctx context.Context, claGroupID, companyID string) (*models.ApprovalListExport, error)
+	ImportApprovalList(ctx context.Context, authUser *auth.User, claGroupModel *v1Models.ClaGroup, companyModel *v1Models.Company, input *models.ApprovalListImport, projectSFID string, eventsService events.Service) (*models.ApprovalListImportResult, error)
 }
 
 // Service structure/model
@@ -73,13 +79,15 @@ type Service struct {
 	s3                    *s3.S3
 	signaturesBucket      string
 	approvalsRepos        approvals.IRepository
+	envelopeVoider        EnvelopeVoider
 }
 
 // NewService creates instance of v2 signature service
 func NewService(awsSession *session.Session, signaturesBucketName string, v1ProjectService service.Service,
 	v1CompanyService company.IService,
 	v1SignatureService signatures.SignatureService,
-	pcgRepo projects_cla_groups.Repository, v1SignatureRepo signatures.SignatureRepository, usersService users.Service, approvalsRepo approvals.IRepository) *Service {
+	pcgRepo projects_cla_groups.Repository, v1SignatureRepo signatures.SignatureRepository, usersService users.Service, approvalsRepo approvals.IRepository,
+	envelopeVoider EnvelopeVoider) *Service {
 	return &Service{
 		v1ProjectService:      v1ProjectService,
 		v1CompanyService:      v1CompanyService,
@@ -90,6 +98,7 @@ func NewService(awsSession *session.Session, signaturesBucketName string, v1Proj
 		s3:                    s3.New(awsSession),
 		signaturesBucket:      signaturesBucketName,
 		approvalsRepos:        approvalsRepo,
+		envelopeVoider:        envelopeVoider,
 	}
 }
 
@@ -492,7 +501,9 @@ func (s *Service) IsUserAuthorized(ctx context.Context, lfid, claGroupId string)
 		log.WithFields(f).WithError(iclaErr).Debug("unable to get individual signature")
 	}
 
-	if icla != nil {
+	if icla != nil && !projectCommon.IsSignatureVersionAccepted(ctx, claGroup, icla, time.Now()) {
+		log.WithFields(f).Debugf("user has signed ICLA document version %s which requires a re-sign", icla.SignatureDocumentMajorVersion)
+	} else if icla != nil {
 		log.WithFields(f).Debug("user has signed ICLA")
 		response.ICLA = true
 		hasSigned = true
@@ -8,6 +8,8 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
 	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
 	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
@@ -17,6 +19,7 @@ import (
 	mock_company "github.com/linuxfoundation/easycla/cla-backend-go/company/mocks"
 	ini "github.com/linuxfoundation/easycla/cla-backend-go/init"
 	mock_project "github.com/linuxfoundation/easycla/cla-backend-go/project/mocks"
+	v1Signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures"
 	mock_v1_signatures "github.com/linuxfoundation/easycla/cla-backend-go/signatures/mocks"
 	mock_users "github.com/linuxfoundation/easycla/cla-backend-go/v2/signatures/mock_users"
 	"github.com/stretchr/testify/assert"
@@ -270,12 +273,12 @@ func TestService_IsUserAuthorized(t *testing.T) {
 				mockCompanyService := mock_company.NewMockIService(ctrl)
 				mockCompanyService.EXPECT().GetCompany(context.Background(), tc.companyID).Return(tc.getCompanyResult, tc.getCompanyError)
 
-				service := NewService(awsSession, "", mockProjectService, mockCompanyService, mockSignatureService, nil, nil, mockUserService, nil)
+				service := NewService(awsSession, "", mockProjectService, mockCompanyService, mockSignatureService, nil, nil, mockUserService, nil, nil)
 
 				result, err = service.IsUserAuthorized(context.Background(), tc.lfid, tc.projectID)
 
 			} else {
-				service := NewService(awsSession, "", mockProjectService, nil, nil, nil, nil, mockUserService, nil)
+				service := NewService(awsSession, "", mockProjectService, nil, nil, nil, nil, mockUserService, nil, nil)
 				result, err = service.IsUserAuthorized(context.Background(), tc.lfid, tc.projectID)
 			}
 			assert.Nil(t, err)
@@ -287,3 +290,190 @@ func TestService_IsUserAuthorized(t *testing.T) {
 		})
 	}
 }
+
+func TestService_RevokeSignatureValidation(t *testing.T) {
+	s := &Service{}
+	authUser := &auth.User{UserName: "foobar_1"}
+	reason := "employment-ended"
+
+	_, err := s.RevokeSignature(context.Background(), authUser, &v1Models.Signature{
+		SignatureID:            "signature-123",
+		SignatureRevokedReason: "duplicate",
+	}, &models.SignatureRevocationInput{Reason: &reason}, nil)
+	assert.True(t, errors.Is(err, ErrSignatureAlreadyRevoked))
+
+	_, err = s.RevokeSignature(context.Background(), authUser, &v1Models.Signature{
+		SignatureID: "signature-123",
+	}, &models.SignatureRevocationInput{Reason: &reason, EffectiveDate: "not-a-date"}, nil)
+	assert.True(t, errors.Is(err, ErrInvalidRevocationDate))
+}
+
+type fakeRevocationEvents struct {
+	events.Service
+	logged []*events.LogEventArgs
+}
+
+func (e *fakeRevocationEvents) LogEventWithContext(ctx context.Context, args *events.LogEventArgs) {
+	e.logged = append(e.logged, args)
+}
+
+func TestService_RevokeSignatureCLAGroupNotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authUser := &auth.User{UserName: "foobar_1"}
+	reason := "employment-ended"
+
+	mockProjectService := mock_project.NewMockService(ctrl)
+	mockProjectService.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-123").Return(nil, nil)
+	// the signature must not be updated
+	mockSignatureRepo := mock_v1_signatures.NewMockSignatureRepository(ctrl)
+
+	s := &Service{v1ProjectService: mockProjectService, v1SignatureRepo: mockSignatureRepo}
+	result, err := s.RevokeSignature(context.Background(), authUser, &v1Models.Signature{
+		SignatureID: "signature-123",
+		ProjectID:   "cla-group-123",
+	}, &models.SignatureRevocationInput{Reason: &reason}, &fakeRevocationEvents{})
+	assert.Nil(t, result)
+	assert.True(t, errors.Is(err, ErrRevocationCLAGroupNotFound))
+}
+
+func TestService_RevokeSignature(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authUser := &auth.User{UserName: "foobar_1"}
+	reason := "employment-ended"
+	sig := &v1Models.Signature{
+		SignatureID:            "signature-123",
+		ProjectID:              "cla-group-123",
+		ClaType:                utils.ClaTypeCCLA,
+		SignatureReferenceType: utils.SignatureReferenceTypeCompany,
+		SignatureReferenceID:   "company-123",
+		CompanyName:            "Acme",
+		SignatureSigned:        true,
+		SignatureApproved:      true,
+	}
+
+	mockProjectService := mock_project.NewMockService(ctrl)
+	mockProjectService.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-123").Return(&v1Models.ClaGroup{
+		ProjectID:   "cla-group-123",
+		ProjectName: "Acme CLA Group",
+	}, nil)
+
+	var updates map[string]interface{}
+	mockSignatureRepo := mock_v1_signatures.NewMockSignatureRepository(ctrl)
+	mockSignatureRepo.EXPECT().UpdateSignature(gomock.Any(), "signature-123", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, signatureID string, values map[string]interface{}) error {
+			updates = values
+			return nil
+		})
+
+	revoked := *sig
+	revoked.SignatureApproved = false
+	revoked.SignatureRevokedReason = reason
+	mockSignatureService := mock_v1_signatures.NewMockSignatureService(ctrl)
+	mockSignatureService.EXPECT().GetSignature(gomock.Any(), "signature-123").Return(&revoked, nil)
+
+	eventsService := &fakeRevocationEvents{}
+	s := &Service{v1ProjectService: mockProjectService, v1SignatureRepo: mockSignatureRepo, v1SignatureService: mockSignatureService}
+	result, err := s.RevokeSignature(context.Background(), authUser, sig, &models.SignatureRevocationInput{
+		Reason:        &reason,
+		EffectiveDate: "2021-01-01T00:00:00Z",
+		Note:          "left the company",
+	}, eventsService)
+	assert.NoError(t, err)
+	assert.Equal(t, reason, result.SignatureRevokedReason)
+
+	// the stream handler propagates the revocation from the updated columns
+	assert.Equal(t, false, updates["signature_approved"])
+	assert.Equal(t, reason, updates["signature_revoked_reason"])
+	assert.Equal(t, "foobar_1", updates["signature_revoked_by"])
+	assert.Contains(t, updates["note"], "left the company")
+	assert.NotEmpty(t, updates["signature_revoked_date"])
+
+	if assert.Len(t, eventsService.logged, 1) {
+		assert.Equal(t, events.SignatureRevoked, eventsService.logged[0].EventType)
+		assert.Equal(t, "company-123", eventsService.logged[0].CompanyID)
+	}
+}
+
+func TestService_RevokeSignatureFutureEffectiveDate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	authUser := &auth.User{UserName: "foobar_1"}
+	reason := "employment-ended"
+	sig := &v1Models.Signature{
+		SignatureID:            "signature-123",
+		ProjectID:              "cla-group-123",
+		ClaType:                utils.ClaTypeICLA,
+		SignatureReferenceType: utils.SignatureReferenceTypeUser,
+		SignatureReferenceID:   "user-123",
+		SignatureSigned:        true,
+		SignatureApproved:      true,
+	}
+
+	mockProjectService := mock_project.NewMockService(ctrl)
+	mockProjectService.EXPECT().GetCLAGroupByID(gomock.Any(), "cla-group-123").Return(&v1Models.ClaGroup{
+		ProjectID:   "cla-group-123",
+		ProjectName: "Acme CLA Group",
+	}, nil)
+
+	var updates map[string]interface{}
+	mockSignatureRepo := mock_v1_signatures.NewMockSignatureRepository(ctrl)
+	mockSignatureRepo.EXPECT().UpdateSignature(gomock.Any(), "signature-123", gomock.Any()).DoAndReturn(
+		func(ctx context.Context, signatureID string, values map[string]interface{}) error {
+			updates = values
+			return nil
+		})
+
+	pending := *sig
+	pending.SignatureRevokedReason = reason
+	pending.SignatureRevocationPending = true
+	mockSignatureService := mock_v1_signatures.NewMockSignatureService(ctrl)
+	mockSignatureService.EXPECT().GetSignature(gomock.Any(), "signature-123").Return(&pending, nil)
+
+	s := &Service{v1ProjectService: mockProjectService, v1SignatureRepo: mockSignatureRepo, v1SignatureService: mockSignatureService}
+	result, err := s.RevokeSignature(context.Background(), authUser, sig, &models.SignatureRevocationInput{
+		Reason:        &reason,
+		EffectiveDate: "2999-01-01T00:00:00Z",
+	}, &fakeRevocationEvents{})
+	assert.NoError(t, err)
+	assert.True(t, result.SignatureRevocationPending)
+
+	// the signature remains approved until the scheduled job applies the revocation
+	_, approvedUpdated := updates["signature_approved"]
+	assert.False(t, approvedUpdated)
+	assert.Equal(t, true, updates["signature_revocation_pending"])
+	assert.Equal(t, "2999-01-01T00:00:00Z", updates["signature_revoked_date"])
+}
+
+func TestService_ApplyPendingRevocations(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSignatureRepo := mock_v1_signatures.NewMockSignatureRepository(ctrl)
+	mockSignatureRepo.EXPECT().GetPendingRevocationSignatures(gomock.Any(), gomock.Any()).Return([]v1Signatures.ItemSignature{
+		{SignatureID: "signature-123", SignatureRevokedReason: "employment-ended", SignatureRevocationPending: true},
+		{SignatureID: "signature-456", SignatureRevokedReason: "company-request", SignatureRevocationPending: true},
+	}, nil)
+
+	updates := make(map[string]map[string]interface{})
+	mockSignatureRepo.EXPECT().UpdateSignature(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, signatureID string, values map[string]interface{}) error {
+			updates[signatureID] = values
+			if signatureID == "signature-456" {
+				return errors.New("throttled")
+			}
+			return nil
+		}).Times(2)
+
+	s := &Service{v1SignatureRepo: mockSignatureRepo}
+	assert.NoError(t, s.ApplyPendingRevocations(context.Background()))
+
+	if assert.Len(t, updates, 2) {
+		assert.Equal(t, false, updates["signature-123"]["signature_approved"])
+		assert.Equal(t, false, updates["signature-123"]["signature_revocation_pending"])
+	}
+}
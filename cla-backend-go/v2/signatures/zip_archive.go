@@ -0,0 +1,287 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5" // nolint:gosec // md5 is only used to compare the downloaded files with their S3 ETag
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// archiveManifestVersion is the version of the signed PDF archive manifest format
+	archiveManifestVersion = 1
+	// archivePartNameFormat is the format of the archive part names, the signed PDFs are archived by the month they
+	// were uploaded
+	archivePartNameFormat = "2006-01"
+)
+
+var (
+	// errArchiveFileInvalid is returned when a downloaded signed PDF doesn't match its S3 object
+	errArchiveFileInvalid = errors.New("invalid signed pdf")
+
+	// md5ETagRegex matches the S3 ETags that are the MD5 checksum of the object, the ETags of multipart uploads are not
+	md5ETagRegex = regexp.MustCompile(`^[0-9a-f]{32}$`)
+)
+
+// SignatureArchiveManifest describes the monthly parts of the signed PDF archive of a CLA Group
+type SignatureArchiveManifest struct {
+	Version    int                     `json:"version"`
+	ClaGroupID string                  `json:"cla_group_id"`
+	ClaType    string                  `json:"cla_type"`
+	Updated    string                  `json:"updated"`
+	Parts      []*SignatureArchivePart `json:"parts"`
+}
+
+// SignatureArchivePart is a zip file with the signed PDFs uploaded in one month
+type SignatureArchivePart struct {
+	Name    string                 `json:"name"`
+	Key     string                 `json:"key"`
+	Size    int64                  `json:"size"`
+	SHA256  string                 `json:"sha256"`
+	Updated string                 `json:"updated"`
+	Files   []SignatureArchiveFile `json:"files"`
+}
+
+// SignatureArchiveFile is a signed PDF of an archive part
+type SignatureArchiveFile struct {
+	Name   string `json:"name"`
+	ETag   string `json:"etag"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256"`
+}
+
+// archiveSource is a signed PDF stored in S3
+type archiveSource struct {
+	filename     string
+	key          string
+	eTag         string
+	size         int64
+	lastModified time.Time
+}
+
+// archivePlan lists the parts of the archive that have to be rebuilt and the parts that no longer have signed PDFs
+type archivePlan struct {
+	sources map[string][]archiveSource
+	rebuild []string
+	removed []string
+}
+
+func s3ArchivePrefix(claType string, claGroupID string) string {
+	return fmt.Sprintf("contract-group/%s/%s-archive/", claGroupID, claType)
+}
+
+func s3ArchiveManifestFilepath(claType string, claGroupID string) string {
+	return s3ArchivePrefix(claType, claGroupID) + "manifest.json"
+}
+
+func s3ArchivePartFilepath(claType string, claGroupID string, partName string) string {
+	return fmt.Sprintf("%s%s-%s.zip", s3ArchivePrefix(claType, claGroupID), claType, partName)
+}
+
+// archivePartName returns the name of the part the signed PDF belongs to
+func archivePartName(source archiveSource) string {
+	return source.lastModified.UTC().Format(archivePartNameFormat)
+}
+
+// planArchive groups the signed PDFs by part and compares the parts with the manifest, a part is rebuilt when it is
+// missing from the manifest or when its signed PDFs were added, removed or replaced since it was built
+func planArchive(sources []archiveSource, manifest *SignatureArchiveManifest) *archivePlan {
+	plan := &archivePlan{
+		sources: make(map[string][]archiveSource),
+	}
+	for _, source := range sources {
+		name := archivePartName(source)
+		plan.sources[name] = append(plan.sources[name], source)
+	}
+
+	for name, partSources := range plan.sources {
+		part := manifest.part(name)
+		if part == nil || !part.matches(partSources) {
+			plan.rebuild = append(plan.rebuild, name)
+		}
+	}
+	for _, part := range manifest.Parts {
+		if _, ok := plan.sources[part.Name]; !ok {
+			plan.removed = append(plan.removed, part.Name)
+		}
+	}
+
+	sort.Strings(plan.rebuild)
+	sort.Strings(plan.removed)
+	return plan
+}
+
+// matches returns true when the part contains exactly the signed PDFs
+func (p *SignatureArchivePart) matches(sources []archiveSource) bool {
+	if len(p.Files) != len(sources) {
+		return false
+	}
+	eTags := make(map[string]string, len(p.Files))
+	for _, file := range p.Files {
+		eTags[file.Name] = file.ETag
+	}
+	for _, source := range sources {
+		eTag, ok := eTags[source.filename]
+		if !ok || eTag != source.eTag {
+			return false
+		}
+	}
+	return true
+}
+
+// part returns the part with the name or nil when the manifest doesn't have the part
+func (m *SignatureArchiveManifest) part(name string) *SignatureArchivePart {
+	for _, part := range m.Parts {
+		if part.Name == name {
+			return part
+		}
+	}
+	return nil
+}
+
+// setPart adds or replaces the part, the parts are kept ordered by name
+func (m *SignatureArchiveManifest) setPart(part *SignatureArchivePart) {
+	m.removePart(part.Name)
+	m.Parts = append(m.Parts, part)
+	sort.Slice(m.Parts, func(i, j int) bool {
+		return m.Parts[i].Name < m.Parts[j].Name
+	})
+}
+
+// removePart removes the part with the name
+func (m *SignatureArchiveManifest) removePart(name string) {
+	parts := m.Parts[:0]
+	for _, part := range m.Parts {
+		if part.Name != name {
+			parts = append(parts, part)
+		}
+	}
+	m.Parts = parts
+}
+
+// fileCount returns the number of signed PDFs in the archive
+func (m *SignatureArchiveManifest) fileCount() int64 {
+	var count int64
+	for _, part := range m.Parts {
+		count += int64(len(part.Files))
+	}
+	return count
+}
+
+// validateArchiveFile checks the downloaded signed PDF against its S3 object and returns the SHA-256 checksum of the
+// content
+func validateArchiveFile(source archiveSource, content []byte) (string, error) {
+	if int64(len(content)) != source.size {
+		return "", fmt.Errorf("%w: %s: expected %d bytes, downloaded %d bytes", errArchiveFileInvalid, source.filename, source.size, len(content))
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-")) {
+		return "", fmt.Errorf("%w: %s: missing the PDF header", errArchiveFileInvalid, source.filename)
+	}
+	if md5ETagRegex.MatchString(source.eTag) {
+		md5Sum := md5.Sum(content) // nolint:gosec
+		if hex.EncodeToString(md5Sum[:]) != source.eTag {
+			return "", fmt.Errorf("%w: %s: the MD5 checksum doesn't match the ETag", errArchiveFileInvalid, source.filename)
+		}
+	}
+	sha256Sum := sha256.Sum256(content)
+	return hex.EncodeToString(sha256Sum[:]), nil
+}
+
+// normalizeETag removes the quotes S3 adds around the ETag values
+func normalizeETag(eTag string) string {
+	return strings.ToLower(strings.Trim(eTag, `"`))
+}
+
+// getArchiveManifest loads the archive manifest from S3, nil is returned when the archive doesn't exist yet
+func getArchiveManifest(s3Client *s3.S3, bucketName, claType, claGroupID string) (*SignatureArchiveManifest, error) {
+	output, err := s3Client.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(bucketName),
+		Key:    aws.String(s3ArchiveManifestFilepath(claType, claGroupID)),
+	})
+	if err != nil {
+		aerr, ok := err.(awserr.Error)
+		if ok && aerr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer func() {
+		if closeErr := output.Body.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("problem closing the archive manifest")
+		}
+	}()
+
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, err
+	}
+	var manifest SignatureArchiveManifest
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// GetSignedPDFArchive returns the presigned download URLs of the signed PDF archive parts of the CLA Group
+func (s *Service) GetSignedPDFArchive(ctx context.Context, claGroupID, claType string) (*models.SignatureArchive, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.zip_archive.GetSignedPDFArchive",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+		"claType":        claType,
+	}
+
+	manifest, err := getArchiveManifest(s.s3, s.signaturesBucket, claType, claGroupID)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the archive manifest")
+		return nil, err
+	}
+	if manifest == nil || len(manifest.Parts) == 0 {
+		return nil, ErrZipNotPresent
+	}
+
+	archive := &models.SignatureArchive{
+		ClaGroupID: claGroupID,
+		ClaType:    claType,
+		Updated:    manifest.Updated,
+		FileCount:  manifest.fileCount(),
+		Parts:      make([]*models.SignatureArchivePart, 0, len(manifest.Parts)),
+	}
+	for _, part := range manifest.Parts {
+		signedURL, urlErr := utils.GetDownloadLink(part.Key)
+		if urlErr != nil {
+			log.WithFields(f).WithError(urlErr).Warnf("unable to generate the download URL of the archive part: %s", part.Key)
+			return nil, urlErr
+		}
+		archive.Parts = append(archive.Parts, &models.SignatureArchivePart{
+			Name:      part.Name,
+			URL:       signedURL,
+			FileCount: int64(len(part.Files)),
+			Size:      part.Size,
+			Sha256:    part.SHA256,
+			Updated:   part.Updated,
+		})
+	}
+
+	return archive, nil
+}
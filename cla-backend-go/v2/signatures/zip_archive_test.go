@@ -0,0 +1,103 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	"crypto/md5" // nolint:gosec
+	"encoding/hex"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func archiveTestSource(filename, eTag string, month time.Month) archiveSource {
+	return archiveSource{
+		filename:     filename,
+		key:          "contract-group/cla-group/icla/user/" + filename,
+		eTag:         eTag,
+		size:         100,
+		lastModified: time.Date(2021, month, 15, 12, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestPlanArchive(t *testing.T) {
+	sources := []archiveSource{
+		archiveTestSource("a.pdf", "etag-a", time.January),
+		archiveTestSource("b.pdf", "etag-b", time.January),
+		archiveTestSource("c.pdf", "etag-c", time.February),
+		archiveTestSource("d.pdf", "etag-d", time.March),
+		archiveTestSource("e.pdf", "etag-e", time.April),
+	}
+
+	// every part is built the first time
+	plan := planArchive(sources, &SignatureArchiveManifest{})
+	assert.Equal(t, []string{"2021-01", "2021-02", "2021-03", "2021-04"}, plan.rebuild)
+	assert.Empty(t, plan.removed)
+	assert.Len(t, plan.sources["2021-01"], 2)
+
+	manifest := &SignatureArchiveManifest{
+		Parts: []*SignatureArchivePart{
+			// up to date
+			{Name: "2021-01", Files: []SignatureArchiveFile{{Name: "b.pdf", ETag: "etag-b"}, {Name: "a.pdf", ETag: "etag-a"}}},
+			// the signed pdf was replaced
+			{Name: "2021-02", Files: []SignatureArchiveFile{{Name: "c.pdf", ETag: "etag-old"}}},
+			// a signed pdf was added
+			{Name: "2021-03", Files: []SignatureArchiveFile{}},
+			// the signed pdfs were removed
+			{Name: "2020-12", Files: []SignatureArchiveFile{{Name: "z.pdf", ETag: "etag-z"}}},
+		},
+	}
+	plan = planArchive(sources, manifest)
+	assert.Equal(t, []string{"2021-02", "2021-03", "2021-04"}, plan.rebuild)
+	assert.Equal(t, []string{"2020-12"}, plan.removed)
+}
+
+func TestSignatureArchiveManifestParts(t *testing.T) {
+	manifest := &SignatureArchiveManifest{}
+	manifest.setPart(&SignatureArchivePart{Name: "2021-03", Files: make([]SignatureArchiveFile, 3)})
+	manifest.setPart(&SignatureArchivePart{Name: "2021-01", Files: make([]SignatureArchiveFile, 2)})
+	manifest.setPart(&SignatureArchivePart{Name: "2021-03", Files: make([]SignatureArchiveFile, 4)})
+
+	assert.Len(t, manifest.Parts, 2)
+	assert.Equal(t, "2021-01", manifest.Parts[0].Name)
+	assert.Equal(t, "2021-03", manifest.Parts[1].Name)
+	assert.Equal(t, int64(6), manifest.fileCount())
+	assert.Nil(t, manifest.part("2021-02"))
+
+	manifest.removePart("2021-01")
+	assert.Len(t, manifest.Parts, 1)
+	assert.Equal(t, int64(4), manifest.fileCount())
+}
+
+func TestValidateArchiveFile(t *testing.T) {
+	content := []byte("%PDF-1.4 signed document")
+	md5Sum := md5.Sum(content) // nolint:gosec
+	source := archiveSource{filename: "a.pdf", eTag: hex.EncodeToString(md5Sum[:]), size: int64(len(content))}
+
+	sha256Sum, err := validateArchiveFile(source, content)
+	assert.NoError(t, err)
+	assert.Len(t, sha256Sum, 64)
+
+	// the ETags of multipart uploads are not checksums
+	multipart := source
+	multipart.eTag = "d41d8cd98f00b204e9800998ecf8427e-2"
+	_, err = validateArchiveFile(multipart, content)
+	assert.NoError(t, err)
+
+	mismatch := source
+	mismatch.eTag = "d41d8cd98f00b204e9800998ecf8427e"
+	_, err = validateArchiveFile(mismatch, content)
+	assert.ErrorIs(t, err, errArchiveFileInvalid)
+
+	truncated := source
+	truncated.size++
+	_, err = validateArchiveFile(truncated, content)
+	assert.ErrorIs(t, err, errArchiveFileInvalid)
+
+	_, err = validateArchiveFile(archiveSource{filename: "b.pdf", size: 6}, []byte("<html>"))
+	assert.ErrorIs(t, err, errArchiveFileInvalid)
+
+	assert.Equal(t, "d41d8cd98f00b204e9800998ecf8427e", normalizeETag(`"D41D8CD98F00B204E9800998ECF8427E"`))
+}
@@ -4,10 +4,19 @@
 package signatures
 
 import (
+	stdzip "archive/zip"
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"io"
+	"os"
+	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
@@ -19,8 +28,6 @@ import (
 
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 
-	"github.com/aws/aws-sdk-go/aws/awserr"
-
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws/aws-sdk-go/aws/session"
@@ -30,6 +37,9 @@ import (
 // constants
 const (
 	ParallelDownloader = 100
+
+	// archiveDeadlineMargin is the time reserved to finish the current archive part before the build deadline
+	archiveDeadlineMargin = 2 * time.Minute
 )
 
 // Zipper implements ZipBuilder interface
@@ -40,8 +50,8 @@ type Zipper struct {
 
 // ZipBuilder provides method to build ICLA/CCLA zip
 type ZipBuilder interface {
-	BuildICLAPDFZip(claGroupID string) error
-	BuildCCLAPDFZip(claGroupID string) error
+	BuildICLAPDFZip(ctx context.Context, claGroupID string) error
+	BuildCCLAPDFZip(ctx context.Context, claGroupID string) error
 	BuildICLACSVZip(claGroupID string) error
 	BuildCCLACSVZip(claGroupID string) error
 	BuildECLACSVZip(claGroupID string) error
@@ -55,22 +65,18 @@ func NewZipBuilder(awsSession *session.Session, bucketName string) ZipBuilder {
 	}
 }
 
-func s3ZipFilepath(claType string, claGroupID string) string {
-	return fmt.Sprintf("contract-group/%s/%s.zip", claGroupID, claType)
-}
-
 func s3ZipPrefix(claType string, claGroupID string) string {
 	return fmt.Sprintf("contract-group/%s/%s/", claGroupID, claType)
 }
 
-// BuildICLAPDFZip builds ICLA pdfs zip for cla-group and upload it on s3
-func (z *Zipper) BuildICLAPDFZip(claGroupID string) error {
-	return z.buildPDFZip(utils.ClaTypeICLA, claGroupID)
+// BuildICLAPDFZip builds ICLA pdfs archive for cla-group and upload it on s3
+func (z *Zipper) BuildICLAPDFZip(ctx context.Context, claGroupID string) error {
+	return z.buildPDFZip(ctx, utils.ClaTypeICLA, claGroupID)
 }
 
-// BuildCCLAPDFZip builds CCLA pdfs zip for cla-group and upload it on s3
-func (z *Zipper) BuildCCLAPDFZip(claGroupID string) error {
-	return z.buildPDFZip(utils.ClaTypeCCLA, claGroupID)
+// BuildCCLAPDFZip builds CCLA pdfs archive for cla-group and upload it on s3
+func (z *Zipper) BuildCCLAPDFZip(ctx context.Context, claGroupID string) error {
+	return z.buildPDFZip(ctx, utils.ClaTypeCCLA, claGroupID)
 }
 
 // BuildICLACSVZip builds ICLA csvs zip for cla-group and upload it to AWS s3
@@ -88,217 +94,416 @@ func (z *Zipper) BuildECLACSVZip(claGroupID string) error {
 	return z.buildCSVZip(utils.ClaTypeECLA, claGroupID)
 }
 
-func (z *Zipper) buildPDFZip(claType string, claGroupID string) error {
-	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
-	// get zip file from s3
-	buff, err := z.getZipFileFromS3(claType, claGroupID)
+// buildPDFZip incrementally builds the sharded signed PDF archive of the CLA Group: the signed PDFs are archived in
+// monthly zip files and only the parts with new, removed or replaced signed PDFs are rebuilt. The manifest is saved
+// after each part, a build that is interrupted resumes with the remaining parts on the next run. Once all the parts
+// are built the single zip file served by the deprecated download endpoints is refreshed from the parts.
+func (z *Zipper) buildPDFZip(ctx context.Context, claType string, claGroupID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.zip_builder.buildPDFZip",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"cla_group_id":   claGroupID,
+		"cla_type":       claType,
+	}
+
+	manifest, err := getArchiveManifest(z.s3, z.bucketName, claType, claGroupID)
 	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the archive manifest")
 		return err
 	}
-	var files *utils.StringSet
-	if len(buff.Bytes()) != 0 {
-		// read files already present in zip
-		log.Debug("reading files present in zip")
-		files, err = getZipFiles(buff)
-		if err != nil {
-			return err
+	if manifest == nil {
+		log.WithFields(f).Debug("archive manifest does not exist, building the archive")
+		manifest = &SignatureArchiveManifest{
+			Version:    archiveManifestVersion,
+			ClaGroupID: claGroupID,
+			ClaType:    claType,
 		}
 	}
-	log.WithFields(f).Debug("getting zip writer")
-	writer, err := getZipWriter(buff)
+
+	log.WithFields(f).Debug("listing the signed pdfs")
+	sources, err := z.listArchiveSources(claType, claGroupID)
 	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to list the signed pdfs")
 		return err
 	}
-	var zipUpdated bool
-	log.WithFields(f).Debug("getting s3 files")
-	downloaderInputChan := make(chan *DownloadFileInput)
-	downloaderOutputChan := make(chan *FileContent)
-	var wg sync.WaitGroup
-	wg.Add(ParallelDownloader)
-	for i := 1; i <= ParallelDownloader; i++ {
-		go z.downloader(&wg, downloaderInputChan, downloaderOutputChan)
+
+	plan := planArchive(sources, manifest)
+	for _, part := range manifest.Parts {
+		// The parts that were deleted or overwritten outside of the builder are rebuilt
+		if _, ok := plan.sources[part.Name]; ok && !z.archivePartExists(part) && !utils.StringInSlice(part.Name, plan.rebuild) {
+			log.WithFields(f).Warnf("archive part: %s is missing or does not match the manifest", part.Key)
+			plan.rebuild = append(plan.rebuild, part.Name)
+		}
 	}
-	go func() {
-		wg.Wait()
-		close(downloaderOutputChan)
-	}()
-	go func() {
-		err = z.s3.ListObjectsPages(&s3.ListObjectsInput{
+	sort.Strings(plan.rebuild)
+	log.WithFields(f).Debugf("%d signed pdfs in %d parts, rebuilding %d parts, removing %d parts",
+		len(sources), len(plan.sources), len(plan.rebuild), len(plan.removed))
+
+	for _, name := range plan.removed {
+		part := manifest.part(name)
+		log.WithFields(f).Debugf("removing archive part: %s", part.Key)
+		_, err = z.s3.DeleteObject(&s3.DeleteObjectInput{
 			Bucket: aws.String(z.bucketName),
-			Prefix: aws.String(s3ZipPrefix(claType, claGroupID)),
-		}, func(output *s3.ListObjectsOutput, b bool) bool {
-			for _, obj := range output.Contents {
-				key := utils.StringValue(obj.Key)
-				tmp := strings.Split(key, "/")
-				if len(tmp) != 5 {
-					continue
-				}
-				filename := tmp[4]
-				if files != nil && files.Include(filename) {
-					// skip files which are already present in zip
-					log.Debugf("file %s already present in zip", filename)
-					continue
-				}
-				downloaderInputChan <- &DownloadFileInput{
-					filename: filename,
-					key:      obj.Key,
-				}
-			}
-			return true
+			Key:    aws.String(part.Key),
 		})
-		close(downloaderInputChan)
-	}()
-	zipUpdated = writeFileToZip(writer, downloaderOutputChan)
-	if err != nil {
-		return err
-	}
-	writer.Close()
-	if zipUpdated {
-		remoteZipFileKey := s3ZipFilepath(claType, claGroupID)
-		log.Debugf("Uploading zip file %s", remoteZipFileKey)
-		err := z.uploadFile(buff, remoteZipFileKey)
 		if err != nil {
-			log.Warnf("Uploading zip file %s failed. error = %s", remoteZipFileKey, err.Error())
+			log.WithFields(f).WithError(err).Warnf("unable to remove archive part: %s", part.Key)
+			return err
+		}
+		manifest.removePart(name)
+	}
+	if len(plan.removed) > 0 {
+		if err = z.saveArchiveManifest(manifest); err != nil {
 			return err
 		}
-		log.Debugf("Uploaded zip file %s", remoteZipFileKey)
 	}
-	return nil
-}
-func (z *Zipper) buildCSVZip(claType string, claGroupID string) error {
-	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
-	// TODO: DAD - requires query to the signatures table to get the list of signatures, then encode as CSV, then build a zip file, and upload to S3
-	log.WithFields(f).Infof("building %s csv zip for cla-group: %s is currently not supported", claType, claGroupID)
-	return nil
-}
-
-// FileContent contains file content of s3 file
-type FileContent struct {
-	buff     *aws.WriteAtBuffer
-	filename string
-}
 
-// DownloadFileInput is input to downloader
-type DownloadFileInput struct {
-	filename string
-	key      *string
-}
+	for i, name := range plan.rebuild {
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < archiveDeadlineMargin {
+			log.WithFields(f).Infof("stopping before the deadline, the remaining %d parts are built on the next run",
+				len(plan.rebuild)-i)
+			return nil
+		}
 
-func writeFileToZip(writer *zip.Writer, filesInput chan *FileContent) bool {
-	var zipUpdated bool
-	// log.Infof("writing files")
-	for fileContent := range filesInput {
-		filename := fileContent.filename
-		buff := fileContent.buff
-		log.Infof("Adding file: %s to zip", filename)
-		header := &zip.FileHeader{
-			Name:   filename,
-			Method: zip.Deflate,
+		part, partErr := z.buildArchivePart(ctx, claType, claGroupID, name, plan.sources[name])
+		if partErr != nil {
+			log.WithFields(f).WithError(partErr).Warnf("unable to build archive part: %s", name)
+			return partErr
 		}
-		header.SetMode(0644)
-		f, err := writer.CreateHeader(header)
-		if err != nil {
-			log.WithField("file", filename).Error("unable to write file header in zip")
+		if part == nil {
+			// The previous version of the part is kept until the signed PDFs can be archived
 			continue
 		}
-		_, err = f.Write(buff.Bytes())
-		if err != nil {
-			log.WithField("file", filename).Error("unable to write file data in zip")
-			continue
+		manifest.setPart(part)
+		if err = z.saveArchiveManifest(manifest); err != nil {
+			return err
 		}
-		zipUpdated = true
 	}
-	return zipUpdated
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < archiveDeadlineMargin {
+		log.WithFields(f).Info("stopping before the deadline, the single zip file is refreshed on the next run")
+		return nil
+	}
+	return z.refreshLegacyZip(ctx, claType, claGroupID, manifest)
 }
 
-func (z *Zipper) downloader(wg *sync.WaitGroup, inputChan chan *DownloadFileInput, outputChan chan *FileContent) {
-	defer wg.Done()
-	for in := range inputChan {
-		log.Debugf("Downloading file : %s", in.filename)
-		buff := &aws.WriteAtBuffer{}
-		downloader := s3manager.NewDownloaderWithClient(z.s3)
-		_, err := downloader.Download(buff,
-			&s3.GetObjectInput{
-				Bucket: aws.String(z.bucketName),
-				Key:    in.key,
-			})
-		if err != nil {
-			log.WithField("key", utils.StringValue(in.key)).Error("unable to download file from s3", err)
-			continue
+// refreshLegacyZip rebuilds the single zip file of the CLA Group from the archive parts when the archive changed since
+// the zip file was built, the zip file is removed when the archive has no parts
+func (z *Zipper) refreshLegacyZip(ctx context.Context, claType string, claGroupID string, manifest *SignatureArchiveManifest) error {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.zip_builder.refreshLegacyZip",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"cla_group_id":   claGroupID,
+		"cla_type":       claType,
+	}
+
+	key := utils.SignedClaGroupZipFilename(claGroupID, claType)
+	if len(manifest.Parts) == 0 {
+		log.WithFields(f).Debugf("removing the single zip file: %s", key)
+		_, err := z.s3.DeleteObject(&s3.DeleteObjectInput{
+			Bucket: aws.String(z.bucketName),
+			Key:    aws.String(key),
+		})
+		return err
+	}
+
+	output, err := z.s3.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(z.bucketName),
+		Key:    aws.String(key),
+	})
+	if err == nil && !legacyZipOutdated(aws.TimeValue(output.LastModified), manifest) {
+		log.WithFields(f).Debugf("the single zip file: %s is up to date", key)
+		return nil
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("%s-%s-*.zip", claGroupID, claType))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			log.WithFields(f).WithError(closeErr).Warn("problem closing the temporary zip file")
+		}
+		if removeErr := os.Remove(file.Name()); removeErr != nil {
+			log.WithFields(f).WithError(removeErr).Warn("problem removing the temporary zip file")
 		}
-		outputChan <- &FileContent{
-			buff:     buff,
-			filename: in.filename,
+	}()
+
+	writer := stdzip.NewWriter(file)
+	for _, part := range manifest.Parts {
+		if err = z.copyArchivePart(ctx, writer, part); err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to copy the archive part: %s", part.Key)
+			return err
 		}
 	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	log.WithFields(f).Debugf("uploading the single zip file %s with %d signed pdfs", key, manifest.fileCount())
+	return z.uploadFile(file, key, "application/zip")
 }
 
-func getZipFiles(buff *bytes.Buffer) (*utils.StringSet, error) {
-	reader := bytes.NewReader(buff.Bytes())
-	files := utils.NewStringSet()
-	r, err := zip.NewReader(reader, reader.Size())
+// copyArchivePart downloads the archive part and copies its compressed signed PDFs to the zip writer
+func (z *Zipper) copyArchivePart(ctx context.Context, writer *stdzip.Writer, part *SignatureArchivePart) error {
+	file, err := os.CreateTemp("", "archive-part-*.zip")
 	if err != nil {
-		return nil, err
+		return err
 	}
-	for _, file := range r.File {
-		files.Add(file.Name)
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("problem closing the temporary archive part")
+		}
+		if removeErr := os.Remove(file.Name()); removeErr != nil {
+			log.WithError(removeErr).Warn("problem removing the temporary archive part")
+		}
+	}()
+
+	size, err := s3manager.NewDownloaderWithClient(z.s3).DownloadWithContext(ctx, file, &s3.GetObjectInput{
+		Bucket: aws.String(z.bucketName),
+		Key:    aws.String(part.Key),
+	})
+	if err != nil {
+		return err
 	}
-	return files, nil
+	return copyZipEntries(writer, file, size)
 }
 
-func getZipWriter(buff *bytes.Buffer) (*zip.Writer, error) {
-	var writer *zip.Writer
-	if len(buff.Bytes()) == 0 {
-		writer = zip.NewWriter(buff)
-	} else {
-		reader := bytes.NewReader(buff.Bytes())
-		r, err := zip.NewReader(reader, reader.Size())
-		if err != nil {
-			return nil, err
+// copyZipEntries copies the entries of the zip file to the zip writer as they are, without recompressing them
+func copyZipEntries(writer *stdzip.Writer, zipFile io.ReaderAt, size int64) error {
+	reader, err := stdzip.NewReader(zipFile, size)
+	if err != nil {
+		return err
+	}
+	for _, entry := range reader.File {
+		if err = writer.Copy(entry); err != nil {
+			return err
 		}
-		writer = r.Append(buff)
 	}
-	return writer, nil
+	return nil
 }
 
-func (z *Zipper) getZipFileFromS3(claType string, claGroupID string) (*bytes.Buffer, error) {
-	var buff aws.WriteAtBuffer
-	remoteFileKey := s3ZipFilepath(claType, claGroupID)
-	_, err := z.s3.GetObject(&s3.GetObjectInput{
+// legacyZipOutdated returns true when the archive manifest, saved after each added, rebuilt or removed part, was
+// updated after the single zip file was built
+func legacyZipOutdated(lastModified time.Time, manifest *SignatureArchiveManifest) bool {
+	updated, err := time.Parse(time.RFC3339, manifest.Updated)
+	return err != nil || !updated.Before(lastModified)
+}
+
+// listArchiveSources returns the signed PDFs of the CLA type, the keys have the
+// contract-group/<cla_group_id>/<cla_type>/<reference_id>/<signature_id>.pdf format
+func (z *Zipper) listArchiveSources(claType string, claGroupID string) ([]archiveSource, error) {
+	var sources []archiveSource
+	err := z.s3.ListObjectsPages(&s3.ListObjectsInput{
+		Bucket: aws.String(z.bucketName),
+		Prefix: aws.String(s3ZipPrefix(claType, claGroupID)),
+	}, func(output *s3.ListObjectsOutput, b bool) bool {
+		for _, obj := range output.Contents {
+			key := utils.StringValue(obj.Key)
+			tmp := strings.Split(key, "/")
+			if len(tmp) != 5 {
+				continue
+			}
+			sources = append(sources, archiveSource{
+				filename:     tmp[4],
+				key:          key,
+				eTag:         normalizeETag(utils.StringValue(obj.ETag)),
+				size:         aws.Int64Value(obj.Size),
+				lastModified: aws.TimeValue(obj.LastModified),
+			})
+		}
+		return true
+	})
+	return sources, err
+}
+
+// archivePartExists returns true when the part zip file exists and has the size recorded in the manifest
+func (z *Zipper) archivePartExists(part *SignatureArchivePart) bool {
+	output, err := z.s3.HeadObject(&s3.HeadObjectInput{
 		Bucket: aws.String(z.bucketName),
-		Key:    aws.String(remoteFileKey),
+		Key:    aws.String(part.Key),
 	})
 	if err != nil {
-		aerr, ok := err.(awserr.Error)
-		if ok && aerr.Code() == s3.ErrCodeNoSuchKey {
-			log.Debugf("zip file %s does not exist on s3", remoteFileKey)
-			return bytes.NewBuffer(buff.Bytes()), nil
+		return false
+	}
+	return aws.Int64Value(output.ContentLength) == part.Size
+}
+
+// buildArchivePart streams the signed PDFs of the part into a temporary zip file and uploads it, the signed PDFs that
+// can't be downloaded or don't match their checksum are left out and the part is rebuilt on the next run. nil is
+// returned when none of the signed PDFs could be archived.
+func (z *Zipper) buildArchivePart(ctx context.Context, claType string, claGroupID string, name string, sources []archiveSource) (*SignatureArchivePart, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.signatures.zip_builder.buildArchivePart",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"cla_group_id":   claGroupID,
+		"cla_type":       claType,
+		"part":           name,
+	}
+
+	file, err := os.CreateTemp("", fmt.Sprintf("%s-%s-%s-*.zip", claGroupID, claType, name))
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := file.Close(); closeErr != nil {
+			log.WithFields(f).WithError(closeErr).Warn("problem closing the temporary zip file")
+		}
+		if removeErr := os.Remove(file.Name()); removeErr != nil {
+			log.WithFields(f).WithError(removeErr).Warn("problem removing the temporary zip file")
+		}
+	}()
+
+	hash := sha256.New()
+	writer := zip.NewWriter(io.MultiWriter(file, hash))
+	part := &SignatureArchivePart{
+		Name: name,
+		Key:  s3ArchivePartFilepath(claType, claGroupID, name),
+	}
+	// the downloads are cancelled when the part can't be written, otherwise the workers would block forever
+	downloadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	for fileContent := range z.downloadArchiveFiles(downloadCtx, sources) {
+		if fileContent.err != nil {
+			log.WithFields(f).WithError(fileContent.err).Warnf("skipping file: %s", fileContent.source.key)
+			continue
+		}
+		header := &zip.FileHeader{
+			Name:   fileContent.source.filename,
+			Method: zip.Deflate,
 		}
+		header.SetMode(0644)
+		w, headerErr := writer.CreateHeader(header)
+		if headerErr != nil {
+			return nil, headerErr
+		}
+		if _, err = w.Write(fileContent.content); err != nil {
+			return nil, err
+		}
+		part.Files = append(part.Files, SignatureArchiveFile{
+			Name:   fileContent.source.filename,
+			ETag:   fileContent.source.eTag,
+			Size:   fileContent.source.size,
+			SHA256: fileContent.sha256,
+		})
+	}
+	if err = writer.Close(); err != nil {
 		return nil, err
 	}
-	log.Debugf("Downloading zip file %s", remoteFileKey)
+	if len(part.Files) == 0 {
+		log.WithFields(f).Warn("none of the signed pdfs of the part could be archived")
+		return nil, nil
+	}
+	sort.Slice(part.Files, func(i, j int) bool {
+		return part.Files[i].Name < part.Files[j].Name
+	})
 
-	downloader := s3manager.NewDownloaderWithClient(z.s3)
+	if part.Size, err = file.Seek(0, io.SeekCurrent); err != nil {
+		return nil, err
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	part.SHA256 = hex.EncodeToString(hash.Sum(nil))
+	_, part.Updated = utils.CurrentTime()
 
-	_, err = downloader.Download(&buff,
-		&s3.GetObjectInput{
-			Bucket: aws.String(z.bucketName),
-			Key:    aws.String(remoteFileKey),
-		})
-	if err != nil {
+	log.WithFields(f).Debugf("uploading archive part %s with %d of %d signed pdfs", part.Key, len(part.Files), len(sources))
+	if err = z.uploadFile(file, part.Key, "application/zip"); err != nil {
 		return nil, err
 	}
-	log.Debugf("Downloading zip file %s completed", remoteFileKey)
-	return bytes.NewBuffer(buff.Bytes()), nil
+	return part, nil
 }
 
-func (z *Zipper) uploadFile(localFileContent *bytes.Buffer, s3ZipFile string) error {
+// archiveFileContent is a downloaded and validated signed PDF
+type archiveFileContent struct {
+	source  archiveSource
+	content []byte
+	sha256  string
+	err     error
+}
+
+// downloadArchiveFiles downloads and validates the signed PDFs in parallel, the channel is closed once all the signed
+// PDFs are processed or the context is cancelled
+func (z *Zipper) downloadArchiveFiles(ctx context.Context, sources []archiveSource) <-chan *archiveFileContent {
+	inputChan := make(chan archiveSource)
+	outputChan := make(chan *archiveFileContent)
+
+	workers := ParallelDownloader
+	if len(sources) < workers {
+		workers = len(sources)
+	}
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			downloader := s3manager.NewDownloaderWithClient(z.s3)
+			for source := range inputChan {
+				buff := &aws.WriteAtBuffer{}
+				result := &archiveFileContent{source: source}
+				_, result.err = downloader.DownloadWithContext(ctx, buff, &s3.GetObjectInput{
+					Bucket: aws.String(z.bucketName),
+					Key:    aws.String(source.key),
+				})
+				if result.err == nil {
+					result.content = buff.Bytes()
+					result.sha256, result.err = validateArchiveFile(source, result.content)
+				}
+				select {
+				case outputChan <- result:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(inputChan)
+		for _, source := range sources {
+			select {
+			case inputChan <- source:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(outputChan)
+	}()
+	return outputChan
+}
+
+// saveArchiveManifest uploads the archive manifest
+func (z *Zipper) saveArchiveManifest(manifest *SignatureArchiveManifest) error {
+	_, manifest.Updated = utils.CurrentTime()
+	content, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	key := s3ArchiveManifestFilepath(manifest.ClaType, manifest.ClaGroupID)
+	log.Debugf("uploading archive manifest %s", key)
+	return z.uploadFile(bytes.NewReader(content), key, "application/json")
+}
+
+func (z *Zipper) buildCSVZip(claType string, claGroupID string) error {
+	f := logrus.Fields{"cla_group_id": claGroupID, "cla_type": claType}
+	// TODO: DAD - requires query to the signatures table to get the list of signatures, then encode as CSV, then build a zip file, and upload to S3
+	log.WithFields(f).Infof("building %s csv zip for cla-group: %s is currently not supported", claType, claGroupID)
+	return nil
+}
+
+func (z *Zipper) uploadFile(localFileContent io.Reader, s3ZipFile string, contentType string) error {
 	uploader := s3manager.NewUploaderWithClient(z.s3)
 	// Upload the file to S3.
 	_, err := uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(z.bucketName),
-		Key:    aws.String(s3ZipFile),
-		Body:   localFileContent,
+		Bucket:      aws.String(z.bucketName),
+		Key:         aws.String(s3ZipFile),
+		Body:        localFileContent,
+		ContentType: aws.String(contentType),
 	})
 
 	//in case it fails to upload
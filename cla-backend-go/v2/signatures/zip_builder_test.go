@@ -0,0 +1,111 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package signatures
+
+import (
+	stdzip "archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+func testZip(t *testing.T, files map[string]string) []byte {
+	var b bytes.Buffer
+	writer := stdzip.NewWriter(&b)
+	for name, content := range files {
+		w, err := writer.CreateHeader(&stdzip.FileHeader{Name: name, Method: stdzip.Deflate})
+		assert.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		assert.NoError(t, err)
+	}
+	assert.NoError(t, writer.Close())
+	return b.Bytes()
+}
+
+func TestCopyZipEntries(t *testing.T) {
+	parts := [][]byte{
+		testZip(t, map[string]string{"a.pdf": "%PDF-a", "b.pdf": "%PDF-b"}),
+		testZip(t, map[string]string{"c.pdf": "%PDF-c"}),
+	}
+
+	var b bytes.Buffer
+	writer := stdzip.NewWriter(&b)
+	for _, part := range parts {
+		assert.NoError(t, copyZipEntries(writer, bytes.NewReader(part), int64(len(part))))
+	}
+	assert.NoError(t, writer.Close())
+
+	reader, err := stdzip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	assert.NoError(t, err)
+	contents := map[string]string{}
+	for _, entry := range reader.File {
+		r, openErr := entry.Open()
+		assert.NoError(t, openErr)
+		content, readErr := io.ReadAll(r)
+		assert.NoError(t, readErr)
+		contents[entry.Name] = string(content)
+	}
+	assert.Equal(t, map[string]string{"a.pdf": "%PDF-a", "b.pdf": "%PDF-b", "c.pdf": "%PDF-c"}, contents)
+}
+
+func TestLegacyZipOutdated(t *testing.T) {
+	built := time.Date(2021, time.March, 1, 12, 0, 0, 0, time.UTC)
+	assert.False(t, legacyZipOutdated(built, &SignatureArchiveManifest{Updated: "2021-03-01T11:59:00Z"}))
+	assert.True(t, legacyZipOutdated(built, &SignatureArchiveManifest{Updated: "2021-03-01T12:01:00Z"}))
+	assert.True(t, legacyZipOutdated(built, &SignatureArchiveManifest{}))
+}
+
+func TestDownloadArchiveFilesCancel(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "6")
+		_, _ = w.Write([]byte("%PDF-a")) // nolint
+	}))
+	defer server.Close()
+
+	awsSession := session.Must(session.NewSession(&aws.Config{
+		Endpoint:         aws.String(server.URL),
+		Region:           aws.String("us-east-1"),
+		Credentials:      credentials.NewStaticCredentials("id", "secret", ""),
+		S3ForcePathStyle: aws.Bool(true),
+	}))
+	z := &Zipper{s3: s3.New(awsSession), bucketName: "bucket"}
+
+	sources := make([]archiveSource, 0, ParallelDownloader*2)
+	for i := 0; i < ParallelDownloader*2; i++ {
+		sources = append(sources, archiveSource{filename: "a.pdf", key: "contract-group/cla-group/icla/user/a.pdf", size: 6})
+	}
+
+	// the consumer stops after the first file, the workers must stop instead of blocking on the channel forever
+	ctx, cancel := context.WithCancel(context.Background())
+	files := z.downloadArchiveFiles(ctx, sources)
+	first := <-files
+	assert.NoError(t, first.err)
+	cancel()
+	time.Sleep(200 * time.Millisecond)
+
+	done := make(chan int)
+	go func() {
+		received := 0
+		for range files {
+			received++
+		}
+		done <- received
+	}()
+	select {
+	case received := <-done:
+		assert.Less(t, received, len(sources)/2)
+	case <-time.After(10 * time.Second):
+		t.Fatal("the download workers did not stop after the cancellation")
+	}
+}
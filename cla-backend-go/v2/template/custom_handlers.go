@@ -0,0 +1,534 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/copier"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/template"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	v1ProjectsCLAGroups "github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	v1Template "github.com/linuxfoundation/easycla/cla-backend-go/template"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// configureCustomTemplates sets up the custom template catalog handlers
+func configureCustomTemplates(api *operations.EasyclaAPI, service v1Template.ServiceInterface, v1ProjectClaGroupService v1ProjectsCLAGroups.Service, eventsService events.Service) { // nolint
+	api.TemplateValidateCustomTemplateHandler = template.ValidateCustomTemplateHandlerFunc(func(params template.ValidateCustomTemplateParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateValidateCustomTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		}
+
+		input, err := toCustomTemplateInput(params.Body)
+		if err != nil {
+			msg := "problem converting the template input"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewValidateCustomTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		validation := service.ValidateCustomTemplate(ctx, input)
+		return template.NewValidateCustomTemplateOK().WithXRequestID(reqID).WithPayload(&models.TemplateValidation{
+			Valid:    validation.Valid(),
+			Errors:   validation.Errors,
+			Warnings: validation.Warnings,
+		})
+	})
+
+	api.TemplateCreateCustomTemplateHandler = template.CreateCustomTemplateHandlerFunc(func(params template.CreateCustomTemplateParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateCreateCustomTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"foundationSFID": params.Body.FoundationSFID,
+			"authUserName":   authUser.UserName,
+		}
+
+		if params.Body.FoundationSFID == "" {
+			msg := "foundation SFID is required"
+			return template.NewCreateCustomTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequest(reqID, msg))
+		}
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.Body.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to CreateCustomTemplate with Project scope of %s", authUser.UserName, params.Body.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return template.NewCreateCustomTemplateForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		input, err := toCustomTemplateInput(params.Body)
+		if err != nil {
+			msg := "problem converting the template input"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewCreateCustomTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		customTemplate, version, err := service.CreateCustomTemplate(ctx, input, authUser.UserName)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to create the custom template")
+			return customTemplateErrorResponder(reqID, "unable to create the custom template", err)
+		}
+
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.CLACustomTemplateCreated,
+			ProjectSFID: customTemplate.FoundationSFID,
+			LfUsername:  authUser.UserName,
+			UserName:    authUser.UserName,
+			EventData: &events.CLACustomTemplateCreatedEventData{
+				TemplateID:   customTemplate.TemplateID,
+				TemplateName: customTemplate.Name,
+			},
+		})
+
+		response, err := toCustomTemplateModel(customTemplate, version)
+		if err != nil {
+			msg := "problem converting the custom template"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewCreateCustomTemplateInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		return template.NewCreateCustomTemplateOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateListCustomTemplatesHandler = template.ListCustomTemplatesHandlerFunc(func(params template.ListCustomTemplatesParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateListCustomTemplatesHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"foundationSFID": params.FoundationSFID,
+		}
+
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to ListCustomTemplates with Project scope of %s", authUser.UserName, params.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return template.NewListCustomTemplatesForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		customTemplates, err := service.ListCustomTemplates(ctx, params.FoundationSFID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom templates of the foundation: %s", params.FoundationSFID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewListCustomTemplatesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		response := &models.CustomTemplateList{List: []*models.CustomTemplate{}}
+		for _, customTemplate := range customTemplates {
+			item, convertErr := toCustomTemplateModel(customTemplate, nil)
+			if convertErr != nil {
+				msg := "problem converting the custom templates"
+				log.WithFields(f).WithError(convertErr).Warn(msg)
+				return template.NewListCustomTemplatesInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, convertErr))
+			}
+			response.List = append(response.List, item)
+		}
+		return template.NewListCustomTemplatesOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateGetCustomTemplateHandler = template.GetCustomTemplateHandlerFunc(func(params template.GetCustomTemplateParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateGetCustomTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"templateID":     params.TemplateID,
+		}
+
+		customTemplate, version, err := service.GetCustomTemplate(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+		if responder := authorizeCustomTemplate(ctx, authUser, reqID, customTemplate, "GetCustomTemplate"); responder != nil {
+			return responder
+		}
+
+		response, err := toCustomTemplateModel(customTemplate, version)
+		if err != nil {
+			msg := "problem converting the custom template"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewGetCustomTemplateInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		return template.NewGetCustomTemplateOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateUpdateCustomTemplateHandler = template.UpdateCustomTemplateHandlerFunc(func(params template.UpdateCustomTemplateParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateUpdateCustomTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"templateID":     params.TemplateID,
+			"authUserName":   authUser.UserName,
+		}
+
+		existing, _, err := service.GetCustomTemplate(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+		if responder := authorizeCustomTemplate(ctx, authUser, reqID, existing, "UpdateCustomTemplate"); responder != nil {
+			return responder
+		}
+
+		input, err := toCustomTemplateInput(params.Body)
+		if err != nil {
+			msg := "problem converting the template input"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewUpdateCustomTemplateBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+		}
+
+		customTemplate, version, err := service.UpdateCustomTemplate(ctx, params.TemplateID, input, authUser.UserName)
+		if err != nil {
+			msg := fmt.Sprintf("unable to update the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.CLACustomTemplateVersionCreated,
+			ProjectSFID: customTemplate.FoundationSFID,
+			LfUsername:  authUser.UserName,
+			UserName:    authUser.UserName,
+			EventData: &events.CLACustomTemplateVersionCreatedEventData{
+				TemplateID:   customTemplate.TemplateID,
+				TemplateName: customTemplate.Name,
+				Version:      version.Version,
+			},
+		})
+
+		response, err := toCustomTemplateModel(customTemplate, version)
+		if err != nil {
+			msg := "problem converting the custom template"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewUpdateCustomTemplateInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		return template.NewUpdateCustomTemplateOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateArchiveCustomTemplateHandler = template.ArchiveCustomTemplateHandlerFunc(func(params template.ArchiveCustomTemplateParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateArchiveCustomTemplateHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"templateID":     params.TemplateID,
+			"authUserName":   authUser.UserName,
+		}
+
+		customTemplate, _, err := service.GetCustomTemplate(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+		if responder := authorizeCustomTemplate(ctx, authUser, reqID, customTemplate, "ArchiveCustomTemplate"); responder != nil {
+			return responder
+		}
+
+		if err = service.ArchiveCustomTemplate(ctx, params.TemplateID); err != nil {
+			msg := fmt.Sprintf("unable to archive the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:   events.CLACustomTemplateArchived,
+			ProjectSFID: customTemplate.FoundationSFID,
+			LfUsername:  authUser.UserName,
+			UserName:    authUser.UserName,
+			EventData: &events.CLACustomTemplateArchivedEventData{
+				TemplateID:   customTemplate.TemplateID,
+				TemplateName: customTemplate.Name,
+			},
+		})
+
+		return template.NewArchiveCustomTemplateNoContent().WithXRequestID(reqID)
+	})
+
+	api.TemplateListCustomTemplateVersionsHandler = template.ListCustomTemplateVersionsHandlerFunc(func(params template.ListCustomTemplateVersionsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateListCustomTemplateVersionsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"templateID":     params.TemplateID,
+		}
+
+		customTemplate, _, err := service.GetCustomTemplate(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+		if responder := authorizeCustomTemplate(ctx, authUser, reqID, customTemplate, "ListCustomTemplateVersions"); responder != nil {
+			return responder
+		}
+
+		versions, err := service.ListCustomTemplateVersions(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the versions of the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+
+		response := &models.CustomTemplateVersionList{List: []*models.CustomTemplateVersion{}}
+		for _, version := range versions {
+			item, convertErr := toCustomTemplateVersionModel(version, false)
+			if convertErr != nil {
+				msg := "problem converting the custom template versions"
+				log.WithFields(f).WithError(convertErr).Warn(msg)
+				return template.NewListCustomTemplateVersionsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, convertErr))
+			}
+			response.List = append(response.List, item)
+		}
+		return template.NewListCustomTemplateVersionsOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateGetCustomTemplateVersionHandler = template.GetCustomTemplateVersionHandlerFunc(func(params template.GetCustomTemplateVersionParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateGetCustomTemplateVersionHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"templateID":     params.TemplateID,
+			"version":        params.Version,
+		}
+
+		customTemplate, _, err := service.GetCustomTemplate(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+		if responder := authorizeCustomTemplate(ctx, authUser, reqID, customTemplate, "GetCustomTemplateVersion"); responder != nil {
+			return responder
+		}
+
+		version, err := service.GetCustomTemplateVersion(ctx, params.TemplateID, params.Version)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the version %d of the custom template by ID: %s", params.Version, params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+
+		response, err := toCustomTemplateVersionModel(version, true)
+		if err != nil {
+			msg := "problem converting the custom template version"
+			log.WithFields(f).WithError(err).Warn(msg)
+			return template.NewGetCustomTemplateVersionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+		return template.NewGetCustomTemplateVersionOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateDiffCustomTemplateVersionsHandler = template.DiffCustomTemplateVersionsHandlerFunc(func(params template.DiffCustomTemplateVersionsParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateDiffCustomTemplateVersionsHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"templateID":     params.TemplateID,
+			"fromVersion":    params.FromVersion,
+			"toVersion":      params.ToVersion,
+		}
+
+		customTemplate, _, err := service.GetCustomTemplate(ctx, params.TemplateID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the custom template by ID: %s", params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+		if responder := authorizeCustomTemplate(ctx, authUser, reqID, customTemplate, "DiffCustomTemplateVersions"); responder != nil {
+			return responder
+		}
+
+		diff, err := service.DiffCustomTemplateVersions(ctx, params.TemplateID, params.FromVersion, params.ToVersion)
+		if err != nil {
+			msg := fmt.Sprintf("unable to compare the versions %d and %d of the custom template by ID: %s", params.FromVersion, params.ToVersion, params.TemplateID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return customTemplateErrorResponder(reqID, msg, err)
+		}
+
+		return template.NewDiffCustomTemplateVersionsOK().WithXRequestID(reqID).WithPayload(&models.TemplateVersionDiff{
+			TemplateID:        diff.TemplateID,
+			FromVersion:       diff.FromVersion,
+			ToVersion:         diff.ToVersion,
+			IclaDiff:          diff.IclaDiff,
+			CclaDiff:          diff.CclaDiff,
+			MetaFieldsAdded:   diff.MetaFieldsAdded,
+			MetaFieldsRemoved: diff.MetaFieldsRemoved,
+			IclaFieldsChanged: diff.IclaFieldsChanged,
+			CclaFieldsChanged: diff.CclaFieldsChanged,
+		})
+	})
+
+	api.TemplateGetCLAGroupTemplatePinHandler = template.GetCLAGroupTemplatePinHandlerFunc(func(params template.GetCLAGroupTemplatePinParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.custom_handlers.TemplateGetCLAGroupTemplatePinHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+		}
+
+		projectCLAGroups, lookupErr := v1ProjectClaGroupService.GetProjectsIdsForClaGroup(ctx, params.ClaGroupID)
+		if lookupErr != nil || len(projectCLAGroups) == 0 {
+			msg := fmt.Sprintf("unable to lookup CLA Group mapping using CLA Group ID: %s", params.ClaGroupID)
+			return template.NewGetCLAGroupTemplatePinNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, lookupErr))
+		}
+		projectSFIDs := getProjectSFIDList(projectCLAGroups)
+		if !utils.IsUserAuthorizedForAnyProjects(ctx, authUser, projectSFIDs, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("authUser '%s' does not have access to get the CLA Group template with Project scope of any %s",
+				authUser.UserName, strings.Join(projectSFIDs, ","))
+			log.WithFields(f).Debug(msg)
+			return template.NewGetCLAGroupTemplatePinForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+		}
+
+		pin, err := service.GetCLAGroupTemplatePin(ctx, params.ClaGroupID)
+		if err != nil {
+			msg := fmt.Sprintf("unable to load the template of the CLA Group: %s", params.ClaGroupID)
+			log.WithFields(f).WithError(err).Warn(msg)
+			if errors.Is(err, v1Template.ErrCLAGroupNotFound) {
+				return template.NewGetCLAGroupTemplatePinNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+			}
+			return template.NewGetCLAGroupTemplatePinInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+		}
+
+		return template.NewGetCLAGroupTemplatePinOK().WithXRequestID(reqID).WithPayload(&models.TemplatePin{
+			ClaGroupID:      pin.CLAGroupID,
+			TemplateID:      pin.TemplateID,
+			TemplateVersion: pin.TemplateVersion,
+			DatePinned:      pin.DatePinned,
+		})
+	})
+}
+
+// authorizeCustomTemplate returns a forbidden responder if the user doesn't have access to the foundation of the
+// custom template
+func authorizeCustomTemplate(ctx context.Context, authUser *auth.User, reqID string, customTemplate *v1Template.CustomTemplate, operation string) middleware.Responder {
+	if utils.IsUserAuthorizedForProjectTree(ctx, authUser, customTemplate.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+		return nil
+	}
+	msg := fmt.Sprintf("user %s does not have access to %s with Project scope of %s", authUser.UserName, operation, customTemplate.FoundationSFID)
+	log.WithFields(logrus.Fields{
+		"functionName":   "v2.template.custom_handlers.authorizeCustomTemplate",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"templateID":     customTemplate.TemplateID,
+	}).Debug(msg)
+	return writeResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseForbidden(reqID, msg))
+}
+
+// customTemplateErrorResponder maps the custom template service errors to the response status codes
+func customTemplateErrorResponder(reqID, msg string, err error) middleware.Responder {
+	var validationErr *v1Template.TemplateValidationError
+	switch {
+	case errors.As(err, &validationErr):
+		return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+	case errors.Is(err, v1Template.ErrTemplateArchived):
+		return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+	case errors.Is(err, v1Template.ErrTemplateNotFound), errors.Is(err, v1Template.ErrTemplateVersionNotFound):
+		return writeResponse(http.StatusNotFound, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+	case errors.Is(err, v1Template.ErrTemplateVersionConflict):
+		return writeResponse(http.StatusConflict, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseConflictWithError(reqID, msg, err))
+	}
+	return writeResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+}
+
+// toCustomTemplateInput maps the API input to the custom template service input
+func toCustomTemplateInput(body *models.CustomTemplateInput) (*v1Template.CustomTemplateInput, error) {
+	input := &v1Template.CustomTemplateInput{
+		FoundationSFID: body.FoundationSFID,
+		Name:           body.Name,
+		Description:    body.Description,
+		BaseVersion:    body.BaseVersion,
+		IclaHTMLBody:   body.IclaHTMLBody,
+		CclaHTMLBody:   body.CclaHTMLBody,
+		Comment:        body.Comment,
+	}
+	if err := copier.Copy(&input.MetaFields, &body.MetaFields); err != nil {
+		return nil, err
+	}
+	if err := copier.Copy(&input.IclaFields, &body.IclaFields); err != nil {
+		return nil, err
+	}
+	if err := copier.Copy(&input.CclaFields, &body.CclaFields); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// toCustomTemplateModel maps the custom template to the API model, the latest version is only set when provided
+func toCustomTemplateModel(customTemplate *v1Template.CustomTemplate, latest *v1Template.CustomTemplateVersion) (*models.CustomTemplate, error) {
+	response := &models.CustomTemplate{
+		TemplateID:     customTemplate.TemplateID,
+		FoundationSFID: customTemplate.FoundationSFID,
+		Name:           customTemplate.Name,
+		Description:    customTemplate.Description,
+		Status:         customTemplate.Status,
+		LatestVersion:  customTemplate.LatestVersion,
+		CreatedBy:      customTemplate.CreatedBy,
+		DateCreated:    customTemplate.DateCreated,
+		DateModified:   customTemplate.DateModified,
+	}
+	if latest != nil {
+		version, err := toCustomTemplateVersionModel(latest, true)
+		if err != nil {
+			return nil, err
+		}
+		response.Latest = version
+	}
+	return response, nil
+}
+
+// toCustomTemplateVersionModel maps the custom template version to the API model, the content is only set when
+// includeContent is true
+func toCustomTemplateVersionModel(version *v1Template.CustomTemplateVersion, includeContent bool) (*models.CustomTemplateVersion, error) {
+	response := &models.CustomTemplateVersion{
+		TemplateID:  version.TemplateID,
+		Version:     version.Version,
+		Comment:     version.Comment,
+		CreatedBy:   version.CreatedBy,
+		DateCreated: version.DateCreated,
+	}
+	if !includeContent {
+		return response, nil
+	}
+
+	response.IclaHTMLBody = version.IclaHTMLBody
+	response.CclaHTMLBody = version.CclaHTMLBody
+	if err := copier.Copy(&response.MetaFields, &version.MetaFields); err != nil {
+		return nil, err
+	}
+	if err := copier.Copy(&response.IclaFields, &version.IclaFields); err != nil {
+		return nil, err
+	}
+	if err := copier.Copy(&response.CclaFields, &version.CclaFields); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
@@ -0,0 +1,171 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package template
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/jinzhu/copier"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	v1Models "github.com/linuxfoundation/easycla/cla-backend-go/gen/v1/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/template"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	v1ProjectsCLAGroups "github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	v1Template "github.com/linuxfoundation/easycla/cla-backend-go/template"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// configureDocumentLocales sets up the CLA Group document translation handlers
+func configureDocumentLocales(api *operations.EasyclaAPI, service v1Template.ServiceInterface, v1ProjectClaGroupService v1ProjectsCLAGroups.Service, eventsService events.Service) {
+	api.TemplatePutCLAGroupDocumentLocaleHandler = template.PutCLAGroupDocumentLocaleHandlerFunc(func(params template.PutCLAGroupDocumentLocaleParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.document_locale_handlers.TemplatePutCLAGroupDocumentLocaleHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+			"locale":         params.Locale,
+			"authUserName":   authUser.UserName,
+		}
+
+		projectCLAGroups, responder := authorizeCLAGroupDocuments(ctx, authUser, reqID, params.ClaGroupID, "update the CLA Group document translations", v1ProjectClaGroupService)
+		if responder != nil {
+			return responder
+		}
+
+		var documentTabs []v1Models.DocumentTab
+		if err := copier.Copy(&documentTabs, &params.Body.DocumentTabs); err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting the document tabs")
+			return writeResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), reqID, errorResponse(reqID, err))
+		}
+
+		variant, err := service.PutCLAGroupDocumentLocale(ctx, params.ClaGroupID, &v1Template.DocumentLocaleInput{
+			ClaType:            params.ClaType,
+			Locale:             params.Locale,
+			DocumentName:       params.Body.DocumentName,
+			DocumentReviewedBy: utils.StringValue(params.Body.DocumentReviewedBy),
+			DocumentContent:    params.Body.DocumentContent,
+			DocumentTabs:       documentTabs,
+		})
+		if err != nil {
+			msg := fmt.Sprintf("unable to update the %s translation of the CLA Group %s document", params.Locale, params.ClaType)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return documentLocaleErrorResponder(reqID, msg, err)
+		}
+
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:         events.CLAGroupDocumentLocaleUpdated,
+			ClaGroupModel:     nil,
+			ProjectID:         params.ClaGroupID,
+			ProjectSFID:       projectCLAGroups[0].ProjectSFID,
+			ParentProjectSFID: projectCLAGroups[0].FoundationSFID,
+			LfUsername:        authUser.UserName,
+			UserName:          authUser.UserName,
+			EventData: &events.CLAGroupDocumentLocaleUpdatedEventData{
+				ClaType:    params.ClaType,
+				Locale:     variant.Locale,
+				ReviewedBy: variant.DocumentReviewedBy,
+			},
+		})
+
+		response := &models.ClaGroupDocumentLocale{}
+		if err = copier.Copy(response, variant); err != nil {
+			log.WithFields(f).WithError(err).Warn("problem converting the document translation")
+			return writeResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), reqID, errorResponse(reqID, err))
+		}
+		return template.NewPutCLAGroupDocumentLocaleOK().WithXRequestID(reqID).WithPayload(response)
+	})
+
+	api.TemplateDeleteCLAGroupDocumentLocaleHandler = template.DeleteCLAGroupDocumentLocaleHandlerFunc(func(params template.DeleteCLAGroupDocumentLocaleParams, authUser *auth.User) middleware.Responder {
+		reqID := utils.GetRequestID(params.XREQUESTID)
+		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+		f := logrus.Fields{
+			"functionName":   "v2.template.document_locale_handlers.TemplateDeleteCLAGroupDocumentLocaleHandler",
+			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"claGroupID":     params.ClaGroupID,
+			"claType":        params.ClaType,
+			"locale":         params.Locale,
+			"authUserName":   authUser.UserName,
+		}
+
+		projectCLAGroups, responder := authorizeCLAGroupDocuments(ctx, authUser, reqID, params.ClaGroupID, "delete the CLA Group document translations", v1ProjectClaGroupService)
+		if responder != nil {
+			return responder
+		}
+
+		if err := service.DeleteCLAGroupDocumentLocale(ctx, params.ClaGroupID, params.ClaType, params.Locale); err != nil {
+			msg := fmt.Sprintf("unable to delete the %s translation of the CLA Group %s document", params.Locale, params.ClaType)
+			log.WithFields(f).WithError(err).Warn(msg)
+			return documentLocaleErrorResponder(reqID, msg, err)
+		}
+
+		eventsService.LogEventWithContext(ctx, &events.LogEventArgs{
+			EventType:         events.CLAGroupDocumentLocaleDeleted,
+			ProjectID:         params.ClaGroupID,
+			ProjectSFID:       projectCLAGroups[0].ProjectSFID,
+			ParentProjectSFID: projectCLAGroups[0].FoundationSFID,
+			LfUsername:        authUser.UserName,
+			UserName:          authUser.UserName,
+			EventData: &events.CLAGroupDocumentLocaleDeletedEventData{
+				ClaType: params.ClaType,
+				Locale:  params.Locale,
+			},
+		})
+
+		return template.NewDeleteCLAGroupDocumentLocaleNoContent().WithXRequestID(reqID)
+	})
+}
+
+// authorizeCLAGroupDocuments returns the projects of the CLA Group or a responder when the CLA Group doesn't exist or
+// the user doesn't have access to any of its projects
+func authorizeCLAGroupDocuments(ctx context.Context, authUser *auth.User, reqID, claGroupID, operation string, v1ProjectClaGroupService v1ProjectsCLAGroups.Service) ([]*v1ProjectsCLAGroups.ProjectClaGroup, middleware.Responder) {
+	f := logrus.Fields{
+		"functionName":   "v2.template.document_locale_handlers.authorizeCLAGroupDocuments",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"claGroupID":     claGroupID,
+	}
+
+	projectCLAGroups, lookupErr := v1ProjectClaGroupService.GetProjectsIdsForClaGroup(ctx, claGroupID)
+	if lookupErr != nil || len(projectCLAGroups) == 0 {
+		msg := fmt.Sprintf("unable to lookup CLA Group mapping using CLA Group ID: %s", claGroupID)
+		log.WithFields(f).WithError(lookupErr).Warn(msg)
+		return nil, writeResponse(http.StatusNotFound, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseNotFoundWithError(reqID, msg, lookupErr))
+	}
+
+	projectSFIDs := getProjectSFIDList(projectCLAGroups)
+	if !utils.IsUserAuthorizedForAnyProjects(ctx, authUser, projectSFIDs, utils.ALLOW_ADMIN_SCOPE) {
+		msg := fmt.Sprintf("authUser '%s' does not have access to %s with Project scope of any %s",
+			authUser.UserName, operation, strings.Join(projectSFIDs, ","))
+		log.WithFields(f).Debug(msg)
+		return nil, writeResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseForbidden(reqID, msg))
+	}
+
+	return projectCLAGroups, nil
+}
+
+// documentLocaleErrorResponder maps the CLA Group document translation service errors to the response status codes
+func documentLocaleErrorResponder(reqID, msg string, err error) middleware.Responder {
+	switch {
+	case errors.Is(err, v1Template.ErrInvalidDocumentLocale):
+		return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+	case errors.Is(err, v1Template.ErrCLAGroupNotFound), errors.Is(err, v1Template.ErrCLADocumentNotFound), errors.Is(err, v1Template.ErrDocumentLocaleNotFound):
+		return writeResponse(http.StatusNotFound, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+	case errors.Is(err, v1Template.ErrCLADocumentChanged):
+		return writeResponse(http.StatusConflict, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseConflictWithError(reqID, msg, err))
+	}
+	return writeResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+}
@@ -5,6 +5,7 @@ package template
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -130,25 +131,36 @@ func Configure(api *operations.EasyclaAPI, service v1Template.ServiceInterface,
 		return template.NewCreateCLAGroupTemplateOK().WithPayload(response)
 	})
 
-	api.TemplateTemplatePreviewHandler = template.TemplatePreviewHandlerFunc(func(params template.TemplatePreviewParams, user *auth.User) middleware.Responder {
+	api.TemplateTemplatePreviewHandler = template.TemplatePreviewHandlerFunc(func(params template.TemplatePreviewParams, authUser *auth.User) middleware.Responder {
 		reqID := utils.GetRequestID(params.XREQUESTID)
 		ctx := context.WithValue(params.HTTPRequest.Context(), utils.XREQUESTID, reqID) // nolint
-		utils.SetAuthUserProperties(user, params.XUSERNAME, params.XEMAIL)
+		utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
 		f := logrus.Fields{
 			"functionName":   "v2.template.handlers.TemplateTemplatePreviewHandler",
 			utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+			"foundationSFID": params.FoundationSFID,
 			"templateFor":    params.TemplateFor,
 		}
 
+		// Check authorization
+		if !utils.IsUserAuthorizedForProjectTree(ctx, authUser, params.FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			msg := fmt.Sprintf("user %s does not have access to TemplatePreview with Project scope of %s", authUser.UserName, params.FoundationSFID)
+			log.WithFields(f).Debug(msg)
+			return writeResponse(http.StatusForbidden, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseForbidden(reqID, msg))
+		}
+
 		var param v1Models.CreateClaGroupTemplate
 		err := copier.Copy(&param, &params.TemplatePreviewInput)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warn("problem converting templates")
 			return writeResponse(http.StatusInternalServerError, runtime.JSONMime, runtime.JSONProducer(), reqID, errorResponse(reqID, err))
 		}
-		pdf, err := service.CreateTemplatePreview(ctx, &param, params.TemplateFor)
+		pdf, err := service.CreateTemplatePreview(ctx, params.FoundationSFID, &param, params.TemplateFor)
 		if err != nil {
 			log.WithFields(f).WithError(err).Warnf("Error generating PDFs from provided templates, error: %v", err)
+			if errors.Is(err, v1Template.ErrTemplateNotFound) || errors.Is(err, v1Template.ErrTemplateVersionNotFound) {
+				return writeResponse(http.StatusNotFound, runtime.JSONMime, runtime.JSONProducer(), reqID, utils.ErrorResponseNotFoundWithError(reqID, "template not found", err))
+			}
 			return writeResponse(http.StatusBadRequest, runtime.JSONMime, runtime.JSONProducer(), reqID, errorResponse(reqID, err))
 		}
 		return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
@@ -181,6 +193,9 @@ func Configure(api *operations.EasyclaAPI, service v1Template.ServiceInterface,
 			}
 		})
 	})
+
+	configureCustomTemplates(api, service, v1ProjectClaGroupService, eventsService)
+	configureDocumentLocales(api, service, v1ProjectClaGroupService, eventsService)
 }
 
 // getProjectSFIDList is a helper function to extract the project SFID values from the list of project to CLA group mapping records
@@ -0,0 +1,269 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// maxDeliveryAttempts is the number of attempts before an event is recorded as a dead letter, the failed attempts
+	// are retried by the delivery queue once the message becomes visible again
+	maxDeliveryAttempts = 3
+	// deliveryTimeout is the timeout of each delivery attempt
+	deliveryTimeout = 10 * time.Second
+)
+
+// ErrDeliveryQueueNotConfigured is returned when the events are queued without a delivery queue
+var ErrDeliveryQueueNotConfigured = errors.New("webhook delivery queue not configured")
+
+// QueueEvent queues the delivery of the event to the enabled webhook subscriptions of the foundation, CLA Group and
+// company of the event whose event type filter matches. The deliveries are processed by the webhook delivery lambda.
+func (s *Service) QueueEvent(ctx context.Context, payload *EventPayload) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.QueueEvent",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"eventID":        payload.EventID,
+		"eventType":      payload.EventType,
+	}
+
+	subscriptions, err := s.matchingSubscriptions(ctx, payload)
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+	if s.queue == nil {
+		return ErrDeliveryQueueNotConfigured
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to marshal the webhook payload")
+		return err
+	}
+
+	for _, subscription := range subscriptions {
+		deliveryID, uuidErr := uuid.NewV4()
+		if uuidErr != nil {
+			return uuidErr
+		}
+		err = s.queue.Enqueue(ctx, &DeliveryMessage{
+			DeliveryID:     deliveryID.String(),
+			SubscriptionID: subscription.SubscriptionID,
+			EventID:        payload.EventID,
+			EventType:      payload.EventType,
+			Payload:        string(body),
+		})
+		if err != nil {
+			log.WithFields(f).WithError(err).Warnf("unable to queue the delivery to the webhook subscription: %s", subscription.SubscriptionID)
+			return err
+		}
+		log.WithFields(f).Debugf("queued the delivery to the webhook subscription: %s", subscription.SubscriptionID)
+	}
+
+	return nil
+}
+
+// ProcessDelivery makes a single delivery attempt of the queued message. A retryable failure is returned as an error
+// so that the queue delivers the message again, the delivery is recorded as a dead letter once it failed
+// maxDeliveryAttempts times or with a non retryable status.
+func (s *Service) ProcessDelivery(ctx context.Context, message *DeliveryMessage, receiveCount int) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.ProcessDelivery",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": message.SubscriptionID,
+		"eventID":        message.EventID,
+		"receiveCount":   receiveCount,
+	}
+
+	subscription, err := s.repo.GetSubscription(ctx, message.SubscriptionID)
+	if errors.Is(err, ErrSubscriptionNotFound) {
+		log.WithFields(f).Debug("the webhook subscription was deleted - dropping the delivery")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !subscription.Enabled {
+		log.WithFields(f).Debug("the webhook subscription is disabled - dropping the delivery")
+		return nil
+	}
+
+	statusCode, err := s.post(ctx, subscription, message.EventType, message.DeliveryID, []byte(message.Payload))
+	if err == nil {
+		log.WithFields(f).Debug("delivered the event to the webhook subscription")
+		return nil
+	}
+	if isRetryable(statusCode) && !errors.Is(err, ErrBlockedAddress) && receiveCount < maxDeliveryAttempts {
+		log.WithFields(f).WithError(err).Warn("unable to deliver the event to the webhook subscription - the delivery will be retried")
+		return err
+	}
+
+	log.WithFields(f).WithError(err).Warn("unable to deliver the event to the webhook subscription - recording a dead letter")
+	return s.createDeadLetter(ctx, message, int64(receiveCount), statusCode, err)
+}
+
+// RedeliverDeadLetter delivers the dead letter event to the webhook subscription again and records the outcome
+func (s *Service) RedeliverDeadLetter(ctx context.Context, subscriptionID, deadLetterID string) (*models.WebhookDeadLetter, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.RedeliverDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+		"deadLetterID":   deadLetterID,
+	}
+
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	deadLetter, err := s.repo.GetDeadLetter(ctx, deadLetterID)
+	if err != nil {
+		return nil, err
+	}
+	if deadLetter.SubscriptionID != subscription.SubscriptionID {
+		return nil, ErrDeadLetterNotFound
+	}
+
+	deliveryID, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	statusCode, err := s.post(ctx, subscription, deadLetter.EventType, deliveryID.String(), []byte(deadLetter.Payload))
+	deadLetter.Attempts++
+	deadLetter.LastStatusCode = statusCode
+	deadLetter.LastError = ""
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to redeliver the dead letter")
+		deadLetter.LastError = err.Error()
+	} else {
+		deadLetter.Redelivered = true
+	}
+	_, deadLetter.DateModified = utils.CurrentTime()
+
+	if err = s.repo.UpdateDeadLetter(ctx, deadLetter); err != nil {
+		return nil, err
+	}
+	return deadLetter.toModel(), nil
+}
+
+// matchingSubscriptions returns the enabled subscriptions of the event scopes whose event type filter matches
+func (s *Service) matchingSubscriptions(ctx context.Context, payload *EventPayload) ([]*Subscription, error) {
+	scopes := []struct {
+		scopeType string
+		scopeID   string
+	}{
+		{scopeType: ScopeFoundation, scopeID: payload.FoundationSFID},
+		{scopeType: ScopeCLAGroup, scopeID: payload.CLAGroupID},
+		{scopeType: ScopeCompany, scopeID: payload.CompanySFID},
+	}
+
+	var matching []*Subscription
+	seen := make(map[string]bool)
+	for _, scope := range scopes {
+		if scope.scopeID == "" {
+			continue
+		}
+		subscriptions, err := s.repo.GetSubscriptionsByScope(ctx, scope.scopeType, scope.scopeID)
+		if err != nil {
+			return nil, err
+		}
+		for _, subscription := range subscriptions {
+			if !subscription.Enabled || seen[subscription.SubscriptionID] || !subscription.matchesEventType(payload.EventType) {
+				continue
+			}
+			seen[subscription.SubscriptionID] = true
+			matching = append(matching, subscription)
+		}
+	}
+	return matching, nil
+}
+
+// post sends a single signed delivery request and returns the response status code. The response body is never
+// read, the error of a failed delivery only contains the status code.
+func (s *Service) post(ctx context.Context, subscription *Subscription, eventType, deliveryID string, body []byte) (int64, error) {
+	secret, err := s.secrets.Decrypt(ctx, subscription.SubscriptionID, subscription.EncryptedSecret)
+	if err != nil {
+		return 0, fmt.Errorf("unable to decrypt the webhook signing secret: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "EasyCLA-Webhook")
+	req.Header.Set(SignatureHeader, Sign(secret, body))
+	req.Header.Set(EventHeader, eventType)
+	req.Header.Set(DeliveryHeader, deliveryID)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(err, ErrBlockedAddress) {
+			return 0, ErrBlockedAddress
+		}
+		return 0, err
+	}
+	defer func() {
+		if closeErr := resp.Body.Close(); closeErr != nil {
+			log.WithError(closeErr).Warn("error closing the webhook response body")
+		}
+	}()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return int64(resp.StatusCode), nil
+	}
+	return int64(resp.StatusCode), fmt.Errorf("webhook URL responded with status %d", resp.StatusCode)
+}
+
+// createDeadLetter records the delivery which could not be completed
+func (s *Service) createDeadLetter(ctx context.Context, message *DeliveryMessage, attempts, statusCode int64, deliveryErr error) error {
+	deadLetterID, err := uuid.NewV4()
+	if err != nil {
+		return err
+	}
+	_, currentTime := utils.CurrentTime()
+	return s.repo.CreateDeadLetter(ctx, &DeadLetter{
+		DeadLetterID:   deadLetterID.String(),
+		SubscriptionID: message.SubscriptionID,
+		EventID:        message.EventID,
+		EventType:      message.EventType,
+		Payload:        message.Payload,
+		Attempts:       attempts,
+		LastStatusCode: statusCode,
+		LastError:      deliveryErr.Error(),
+		DateCreated:    currentTime,
+		DateModified:   currentTime,
+	})
+}
+
+// isRetryable returns true if a failed delivery with the status code is retried - the status code is zero when the
+// URL could not be reached
+func isRetryable(statusCode int64) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
+
+// Sign returns the value of the signature header of the body: the hex encoded HMAC-SHA256 of the body using the
+// subscription secret, prefixed with sha256=
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body) // nolint
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
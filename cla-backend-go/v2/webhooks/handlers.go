@@ -0,0 +1,364 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/LF-Engineering/lfx-kit/auth"
+	"github.com/go-openapi/runtime"
+	"github.com/go-openapi/runtime/middleware"
+	"github.com/linuxfoundation/easycla/cla-backend-go/events"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/restapi/operations/webhooks"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/projects_cla_groups"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// Configure sets up the webhook subscription handlers
+func Configure(api *operations.EasyclaAPI, service ServiceInterface, eventsService events.Service, projectsClaGroupsRepo projects_cla_groups.Repository) { // nolint
+	api.WebhooksCreateWebhookSubscriptionHandler = webhooks.CreateWebhookSubscriptionHandlerFunc(
+		func(params webhooks.CreateWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksCreateWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"scopeType":      utils.StringValue(params.Body.ScopeType),
+				"scopeID":        utils.StringValue(params.Body.ScopeID),
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			scopeType, scopeID := utils.StringValue(params.Body.ScopeType), utils.StringValue(params.Body.ScopeID)
+			if !isUserAuthorizedForScope(ctx, authUser, scopeType, scopeID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to CreateWebhookSubscription with %s scope of %s", authUser.UserName, scopeType, scopeID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewCreateWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			subscription, err := service.CreateSubscription(ctx, params.Body, authUser.UserName)
+			if err != nil {
+				msg := "unable to create the webhook subscription"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isValidationError(err) {
+					return webhooks.NewCreateWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				return webhooks.NewCreateWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventsService.LogEventWithContext(ctx, scopeEventArgs(authUser, subscription.ScopeType, subscription.ScopeID, events.WebhookSubscriptionCreated, &events.WebhookSubscriptionCreatedEventData{
+				SubscriptionID: subscription.SubscriptionID,
+				ScopeType:      subscription.ScopeType,
+				ScopeID:        subscription.ScopeID,
+				URL:            subscription.URL,
+			}))
+
+			return webhooks.NewCreateWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(subscription)
+		})
+
+	api.WebhooksListWebhookSubscriptionsHandler = webhooks.ListWebhookSubscriptionsHandlerFunc(
+		func(params webhooks.ListWebhookSubscriptionsParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksListWebhookSubscriptionsHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"scopeType":      params.ScopeType,
+				"scopeID":        params.ScopeID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			if !isUserAuthorizedForScope(ctx, authUser, params.ScopeType, params.ScopeID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to ListWebhookSubscriptions with %s scope of %s", authUser.UserName, params.ScopeType, params.ScopeID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewListWebhookSubscriptionsForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			result, err := service.ListSubscriptions(ctx, params.ScopeType, params.ScopeID)
+			if err != nil {
+				msg := "unable to list the webhook subscriptions"
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isValidationError(err) {
+					return webhooks.NewListWebhookSubscriptionsBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				return webhooks.NewListWebhookSubscriptionsInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return webhooks.NewListWebhookSubscriptionsOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.WebhooksGetWebhookSubscriptionHandler = webhooks.GetWebhookSubscriptionHandlerFunc(
+		func(params webhooks.GetWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksGetWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"subscriptionID": params.SubscriptionID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			subscription, err := service.GetSubscription(ctx, params.SubscriptionID)
+			if err != nil {
+				msg := fmt.Sprintf("unable to load the webhook subscription by ID: %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				if errors.Is(err, ErrSubscriptionNotFound) {
+					return webhooks.NewGetWebhookSubscriptionNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				return webhooks.NewGetWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			if !isUserAuthorizedForScope(ctx, authUser, subscription.ScopeType, subscription.ScopeID, projectsClaGroupsRepo) {
+				msg := fmt.Sprintf("user %s does not have access to GetWebhookSubscription with %s scope of %s", authUser.UserName, subscription.ScopeType, subscription.ScopeID)
+				log.WithFields(f).Warn(msg)
+				return webhooks.NewGetWebhookSubscriptionForbidden().WithXRequestID(reqID).WithPayload(utils.ErrorResponseForbidden(reqID, msg))
+			}
+
+			return webhooks.NewGetWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(subscription)
+		})
+
+	api.WebhooksUpdateWebhookSubscriptionHandler = webhooks.UpdateWebhookSubscriptionHandlerFunc(
+		func(params webhooks.UpdateWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksUpdateWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"subscriptionID": params.SubscriptionID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			existing, responder := loadAuthorizedSubscription(ctx, authUser, service, projectsClaGroupsRepo, reqID, params.SubscriptionID, "UpdateWebhookSubscription")
+			if responder != nil {
+				return responder
+			}
+
+			subscription, err := service.UpdateSubscription(ctx, existing.SubscriptionID, params.Body)
+			if err != nil {
+				msg := fmt.Sprintf("unable to update the webhook subscription by ID: %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				if isValidationError(err) {
+					return webhooks.NewUpdateWebhookSubscriptionBadRequest().WithXRequestID(reqID).WithPayload(utils.ErrorResponseBadRequestWithError(reqID, msg, err))
+				}
+				return webhooks.NewUpdateWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventsService.LogEventWithContext(ctx, scopeEventArgs(authUser, subscription.ScopeType, subscription.ScopeID, events.WebhookSubscriptionUpdated, &events.WebhookSubscriptionUpdatedEventData{
+				SubscriptionID: subscription.SubscriptionID,
+				ScopeType:      subscription.ScopeType,
+				ScopeID:        subscription.ScopeID,
+				URL:            subscription.URL,
+				SecretRotated:  params.Body.RotateSecret,
+			}))
+
+			return webhooks.NewUpdateWebhookSubscriptionOK().WithXRequestID(reqID).WithPayload(subscription)
+		})
+
+	api.WebhooksDeleteWebhookSubscriptionHandler = webhooks.DeleteWebhookSubscriptionHandlerFunc(
+		func(params webhooks.DeleteWebhookSubscriptionParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksDeleteWebhookSubscriptionHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"subscriptionID": params.SubscriptionID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			subscription, responder := loadAuthorizedSubscription(ctx, authUser, service, projectsClaGroupsRepo, reqID, params.SubscriptionID, "DeleteWebhookSubscription")
+			if responder != nil {
+				return responder
+			}
+
+			if err := service.DeleteSubscription(ctx, subscription.SubscriptionID); err != nil {
+				msg := fmt.Sprintf("unable to delete the webhook subscription by ID: %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewDeleteWebhookSubscriptionInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			eventsService.LogEventWithContext(ctx, scopeEventArgs(authUser, subscription.ScopeType, subscription.ScopeID, events.WebhookSubscriptionDeleted, &events.WebhookSubscriptionDeletedEventData{
+				SubscriptionID: subscription.SubscriptionID,
+				ScopeType:      subscription.ScopeType,
+				ScopeID:        subscription.ScopeID,
+				URL:            subscription.URL,
+			}))
+
+			return webhooks.NewDeleteWebhookSubscriptionNoContent().WithXRequestID(reqID)
+		})
+
+	api.WebhooksListWebhookDeadLettersHandler = webhooks.ListWebhookDeadLettersHandlerFunc(
+		func(params webhooks.ListWebhookDeadLettersParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksListWebhookDeadLettersHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"subscriptionID": params.SubscriptionID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			subscription, responder := loadAuthorizedSubscription(ctx, authUser, service, projectsClaGroupsRepo, reqID, params.SubscriptionID, "ListWebhookDeadLetters")
+			if responder != nil {
+				return responder
+			}
+
+			result, err := service.ListDeadLetters(ctx, subscription.SubscriptionID)
+			if err != nil {
+				msg := fmt.Sprintf("unable to list the dead letters of the webhook subscription: %s", params.SubscriptionID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				return webhooks.NewListWebhookDeadLettersInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return webhooks.NewListWebhookDeadLettersOK().WithXRequestID(reqID).WithPayload(result)
+		})
+
+	api.WebhooksRedeliverWebhookDeadLetterHandler = webhooks.RedeliverWebhookDeadLetterHandlerFunc(
+		func(params webhooks.RedeliverWebhookDeadLetterParams, authUser *auth.User) middleware.Responder {
+			reqID := utils.GetRequestID(params.XREQUESTID)
+			ctx := context.WithValue(context.Background(), utils.XREQUESTID, reqID) // nolint
+			utils.SetAuthUserProperties(authUser, params.XUSERNAME, params.XEMAIL)
+			f := logrus.Fields{
+				"functionName":   "v2.webhooks.handlers.WebhooksRedeliverWebhookDeadLetterHandler",
+				utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+				"subscriptionID": params.SubscriptionID,
+				"deadLetterID":   params.DeadLetterID,
+				"authUserName":   authUser.UserName,
+				"authUserEmail":  authUser.Email,
+			}
+
+			subscription, responder := loadAuthorizedSubscription(ctx, authUser, service, projectsClaGroupsRepo, reqID, params.SubscriptionID, "RedeliverWebhookDeadLetter")
+			if responder != nil {
+				return responder
+			}
+
+			result, err := service.RedeliverDeadLetter(ctx, subscription.SubscriptionID, params.DeadLetterID)
+			if err != nil {
+				msg := fmt.Sprintf("unable to redeliver the dead letter: %s", params.DeadLetterID)
+				log.WithFields(f).WithError(err).Warn(msg)
+				if errors.Is(err, ErrDeadLetterNotFound) {
+					return webhooks.NewRedeliverWebhookDeadLetterNotFound().WithXRequestID(reqID).WithPayload(utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+				}
+				return webhooks.NewRedeliverWebhookDeadLetterInternalServerError().WithXRequestID(reqID).WithPayload(utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+			}
+
+			return webhooks.NewRedeliverWebhookDeadLetterOK().WithXRequestID(reqID).WithPayload(result)
+		})
+}
+
+// loadAuthorizedSubscription loads the webhook subscription and verifies the user has access to its scope - a
+// responder is returned if the subscription is not found or the user is not authorized
+func loadAuthorizedSubscription(ctx context.Context, authUser *auth.User, service ServiceInterface, projectsClaGroupsRepo projects_cla_groups.Repository, reqID, subscriptionID, operation string) (*models.WebhookSubscription, middleware.Responder) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.handlers.loadAuthorizedSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+		"operation":      operation,
+	}
+
+	subscription, err := service.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		msg := fmt.Sprintf("unable to load the webhook subscription by ID: %s", subscriptionID)
+		log.WithFields(f).WithError(err).Warn(msg)
+		if errors.Is(err, ErrSubscriptionNotFound) {
+			return nil, errorResponder(http.StatusNotFound, reqID, utils.ErrorResponseNotFoundWithError(reqID, msg, err))
+		}
+		return nil, errorResponder(http.StatusInternalServerError, reqID, utils.ErrorResponseInternalServerErrorWithError(reqID, msg, err))
+	}
+
+	if !isUserAuthorizedForScope(ctx, authUser, subscription.ScopeType, subscription.ScopeID, projectsClaGroupsRepo) {
+		msg := fmt.Sprintf("user %s does not have access to %s with %s scope of %s", authUser.UserName, operation, subscription.ScopeType, subscription.ScopeID)
+		log.WithFields(f).Warn(msg)
+		return nil, errorResponder(http.StatusForbidden, reqID, utils.ErrorResponseForbidden(reqID, msg))
+	}
+
+	return subscription, nil
+}
+
+// errorResponder returns a responder writing the error payload with the status code - used by the helpers shared
+// between the operations
+func errorResponder(httpStatus int, reqID string, payload *models.ErrorResponse) middleware.Responder {
+	return middleware.ResponderFunc(func(rw http.ResponseWriter, pr runtime.Producer) {
+		rw.Header().Set(utils.XREQUESTID, reqID)
+		rw.WriteHeader(httpStatus)
+		if err := pr.Produce(rw, payload); err != nil {
+			log.Warnf("failed to write data. error = %v", err)
+		}
+	})
+}
+
+// isUserAuthorizedForScope returns true if the user has access to the subscription scope: the foundation project
+// tree, the project tree of the foundation of the CLA Group, or the company
+func isUserAuthorizedForScope(ctx context.Context, authUser *auth.User, scopeType, scopeID string, projectsClaGroupsRepo projects_cla_groups.Repository) bool {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.handlers.isUserAuthorizedForScope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      scopeType,
+		"scopeID":        scopeID,
+	}
+
+	switch scopeType {
+	case ScopeFoundation:
+		return utils.IsUserAuthorizedForProjectTree(ctx, authUser, scopeID, utils.ALLOW_ADMIN_SCOPE)
+	case ScopeCompany:
+		return utils.IsUserAuthorizedForOrganization(ctx, authUser, scopeID, utils.ALLOW_ADMIN_SCOPE)
+	case ScopeCLAGroup:
+		projectCLAGroups, err := projectsClaGroupsRepo.GetProjectsIdsForClaGroup(ctx, scopeID)
+		if err != nil || len(projectCLAGroups) == 0 {
+			log.WithFields(f).WithError(err).Warn("unable to load the projects of the CLA Group")
+			return false
+		}
+		if utils.IsUserAuthorizedForProjectTree(ctx, authUser, projectCLAGroups[0].FoundationSFID, utils.ALLOW_ADMIN_SCOPE) {
+			return true
+		}
+		for _, projectCLAGroup := range projectCLAGroups {
+			if !utils.IsUserAuthorizedForProject(ctx, authUser, projectCLAGroup.ProjectSFID, utils.ALLOW_ADMIN_SCOPE) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// isValidationError returns true if the error is caused by an invalid input
+func isValidationError(err error) bool {
+	return errors.Is(err, ErrInvalidScope) || errors.Is(err, ErrInvalidURL) || errors.Is(err, ErrInvalidSecret)
+}
+
+// scopeEventArgs returns the event arguments of a webhook subscription event, attached to the subscription scope
+func scopeEventArgs(authUser *auth.User, scopeType, scopeID, eventType string, eventData events.EventData) *events.LogEventArgs {
+	args := &events.LogEventArgs{
+		EventType:  eventType,
+		LfUsername: authUser.UserName,
+		UserName:   authUser.UserName,
+		EventData:  eventData,
+	}
+	switch scopeType {
+	case ScopeFoundation:
+		args.ProjectSFID = scopeID
+	case ScopeCLAGroup:
+		args.CLAGroupID = scopeID
+	case ScopeCompany:
+		args.CompanySFID = scopeID
+	}
+	return args
+}
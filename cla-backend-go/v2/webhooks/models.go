@@ -0,0 +1,139 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+)
+
+// Subscription scope types
+const (
+	ScopeFoundation = "foundation"
+	ScopeCLAGroup   = "cla-group"
+	ScopeCompany    = "company"
+)
+
+// Delivery request headers
+const (
+	SignatureHeader = "X-EasyCLA-Signature"
+	EventHeader     = "X-EasyCLA-Event"
+	DeliveryHeader  = "X-EasyCLA-Delivery"
+)
+
+// Subscription represents a webhook subscription in the database - the signing secret is stored encrypted
+type Subscription struct {
+	SubscriptionID  string   `dynamodbav:"subscription_id"`
+	ScopeType       string   `dynamodbav:"scope_type"`
+	ScopeID         string   `dynamodbav:"scope_id"`
+	ScopeKey        string   `dynamodbav:"scope_key"`
+	URL             string   `dynamodbav:"url"`
+	EncryptedSecret string   `dynamodbav:"encrypted_secret"`
+	EventTypes      []string `dynamodbav:"event_types,omitempty"`
+	Enabled         bool     `dynamodbav:"enabled"`
+	CreatedBy       string   `dynamodbav:"created_by"`
+	DateCreated     string   `dynamodbav:"date_created"`
+	DateModified    string   `dynamodbav:"date_modified"`
+}
+
+// DeadLetter represents an event which could not be delivered to a webhook subscription
+type DeadLetter struct {
+	DeadLetterID   string `dynamodbav:"dead_letter_id"`
+	SubscriptionID string `dynamodbav:"subscription_id"`
+	EventID        string `dynamodbav:"event_id"`
+	EventType      string `dynamodbav:"event_type"`
+	Payload        string `dynamodbav:"payload"`
+	Attempts       int64  `dynamodbav:"attempts"`
+	LastStatusCode int64  `dynamodbav:"last_status_code"`
+	LastError      string `dynamodbav:"last_error"`
+	Redelivered    bool   `dynamodbav:"redelivered"`
+	DateCreated    string `dynamodbav:"date_created"`
+	DateModified   string `dynamodbav:"date_modified"`
+}
+
+// DeliveryMessage is the queued delivery of an event to a subscription
+type DeliveryMessage struct {
+	DeliveryID     string `json:"deliveryID"`
+	SubscriptionID string `json:"subscriptionID"`
+	EventID        string `json:"eventID"`
+	EventType      string `json:"eventType"`
+	Payload        string `json:"payload"`
+}
+
+// EventPayload is the JSON body posted to the webhook subscription URLs
+type EventPayload struct {
+	EventID         string `json:"eventID"`
+	EventType       string `json:"eventType"`
+	EventTime       string `json:"eventTime"`
+	EventTimeEpoch  int64  `json:"eventTimeEpoch"`
+	EventSummary    string `json:"eventSummary"`
+	EventData       string `json:"eventData"`
+	EventUserID     string `json:"eventUserID,omitempty"`
+	EventUserName   string `json:"eventUserName,omitempty"`
+	EventLFUsername string `json:"eventLFUsername,omitempty"`
+	FoundationSFID  string `json:"foundationSFID,omitempty"`
+	ProjectSFID     string `json:"projectSFID,omitempty"`
+	ProjectName     string `json:"projectName,omitempty"`
+	CLAGroupID      string `json:"claGroupID,omitempty"`
+	CLAGroupName    string `json:"claGroupName,omitempty"`
+	CompanyID       string `json:"companyID,omitempty"`
+	CompanySFID     string `json:"companySFID,omitempty"`
+	CompanyName     string `json:"companyName,omitempty"`
+}
+
+// scopeKey returns the value of the scope index key
+func scopeKey(scopeType, scopeID string) string {
+	return fmt.Sprintf("%s#%s", scopeType, scopeID)
+}
+
+// matchesEventType returns true if the subscription event type filter matches the event type. An empty filter or
+// a "*" entry matches all the event types, an entry ending with ".*" matches all the event types of that prefix.
+func (s *Subscription) matchesEventType(eventType string) bool {
+	if len(s.EventTypes) == 0 {
+		return true
+	}
+	for _, filter := range s.EventTypes {
+		switch {
+		case filter == "*", filter == eventType:
+			return true
+		case strings.HasSuffix(filter, ".*") && strings.HasPrefix(eventType, strings.TrimSuffix(filter, "*")):
+			return true
+		}
+	}
+	return false
+}
+
+// toModel converts the subscription into a response model - the secret is not included
+func (s *Subscription) toModel() *models.WebhookSubscription {
+	return &models.WebhookSubscription{
+		SubscriptionID: s.SubscriptionID,
+		ScopeType:      s.ScopeType,
+		ScopeID:        s.ScopeID,
+		URL:            s.URL,
+		EventTypes:     s.EventTypes,
+		Enabled:        s.Enabled,
+		CreatedBy:      s.CreatedBy,
+		DateCreated:    s.DateCreated,
+		DateModified:   s.DateModified,
+	}
+}
+
+// toModel converts the dead letter into a response model
+func (d *DeadLetter) toModel() *models.WebhookDeadLetter {
+	return &models.WebhookDeadLetter{
+		DeadLetterID:   d.DeadLetterID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		Redelivered:    d.Redelivered,
+		DateCreated:    d.DateCreated,
+		DateModified:   d.DateModified,
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// DeliveryQueue queues the deliveries of the events to the subscriptions, the deliveries are processed by the
+// webhook delivery lambda
+type DeliveryQueue interface {
+	Enqueue(ctx context.Context, message *DeliveryMessage) error
+}
+
+type sqsDeliveryQueue struct {
+	sqsClient *sqs.SQS
+	queueURL  string
+}
+
+// NewSQSDeliveryQueue returns a DeliveryQueue sending the deliveries to the SQS queue
+func NewSQSDeliveryQueue(awsSession *session.Session, queueURL string) DeliveryQueue {
+	return &sqsDeliveryQueue{
+		sqsClient: sqs.New(awsSession),
+		queueURL:  queueURL,
+	}
+}
+
+// Enqueue sends the delivery message to the queue
+func (q *sqsDeliveryQueue) Enqueue(ctx context.Context, message *DeliveryMessage) error {
+	body, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = q.sqsClient.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+		QueueUrl:    aws.String(q.queueURL),
+		MessageBody: aws.String(string(body)),
+	})
+	return err
+}
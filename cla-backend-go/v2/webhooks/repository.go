@@ -0,0 +1,314 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/dynamodb"
+	"github.com/aws/aws-sdk-go/service/dynamodb/dynamodbattribute"
+	"github.com/aws/aws-sdk-go/service/dynamodb/expression"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// errors
+var (
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrDeadLetterNotFound   = errors.New("webhook dead letter not found")
+)
+
+// index names
+const (
+	ScopeIndex          = "webhook-scope-index"
+	SubscriptionIDIndex = "webhook-subscription-id-index"
+)
+
+// Repository defines the webhook subscription and dead letter database functions
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error)
+	GetSubscriptionsByScope(ctx context.Context, scopeType, scopeID string) ([]*Subscription, error)
+	UpdateSubscription(ctx context.Context, subscription *Subscription) error
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+	CreateDeadLetter(ctx context.Context, deadLetter *DeadLetter) error
+	GetDeadLetter(ctx context.Context, deadLetterID string) (*DeadLetter, error)
+	GetDeadLetters(ctx context.Context, subscriptionID string) ([]*DeadLetter, error)
+	UpdateDeadLetter(ctx context.Context, deadLetter *DeadLetter) error
+}
+
+type repo struct {
+	stage                 string
+	dynamoDBClient        *dynamodb.DynamoDB
+	subscriptionTableName string
+	deadLetterTableName   string
+}
+
+// NewRepository creates a new webhook repository
+func NewRepository(awsSession *session.Session, stage string) Repository {
+	return &repo{
+		stage:                 stage,
+		dynamoDBClient:        dynamodb.New(awsSession),
+		subscriptionTableName: fmt.Sprintf("cla-%s-webhook-subscriptions", stage),
+		deadLetterTableName:   fmt.Sprintf("cla-%s-webhook-dead-letters", stage),
+	}
+}
+
+// CreateSubscription stores a new webhook subscription
+func (repo *repo) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.CreateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+	}
+	if err := repo.putItem(repo.subscriptionTableName, subscription); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the webhook subscription")
+		return err
+	}
+	return nil
+}
+
+// GetSubscription returns the webhook subscription, or ErrSubscriptionNotFound
+func (repo *repo) GetSubscription(ctx context.Context, subscriptionID string) (*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.GetSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(subscriptionID)},
+		},
+		TableName: aws.String(repo.subscriptionTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the webhook subscription")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrSubscriptionNotFound
+	}
+
+	var subscription Subscription
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &subscription); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook subscription")
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// GetSubscriptionsByScope returns the webhook subscriptions of the scope, sorted by creation date
+func (repo *repo) GetSubscriptionsByScope(ctx context.Context, scopeType, scopeID string) ([]*Subscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.GetSubscriptionsByScope",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"scopeType":      scopeType,
+		"scopeID":        scopeID,
+	}
+
+	condition := expression.Key("scope_key").Equal(expression.Value(scopeKey(scopeType, scopeID)))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the webhook subscriptions query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.subscriptionTableName),
+		IndexName:                 aws.String(ScopeIndex),
+	}
+
+	var subscriptions []*Subscription
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query the webhook subscriptions")
+			return nil, queryErr
+		}
+
+		var page []*Subscription
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook subscriptions")
+			return nil, err
+		}
+		subscriptions = append(subscriptions, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	sort.Slice(subscriptions, func(i, j int) bool {
+		return subscriptions[i].DateCreated < subscriptions[j].DateCreated
+	})
+	return subscriptions, nil
+}
+
+// UpdateSubscription replaces the webhook subscription
+func (repo *repo) UpdateSubscription(ctx context.Context, subscription *Subscription) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.UpdateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscription.SubscriptionID,
+	}
+	if err := repo.putItem(repo.subscriptionTableName, subscription); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the webhook subscription")
+		return err
+	}
+	return nil
+}
+
+// DeleteSubscription deletes the webhook subscription
+func (repo *repo) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.DeleteSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+	}
+
+	_, err := repo.dynamoDBClient.DeleteItem(&dynamodb.DeleteItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"subscription_id": {S: aws.String(subscriptionID)},
+		},
+		TableName: aws.String(repo.subscriptionTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to delete the webhook subscription")
+		return err
+	}
+	return nil
+}
+
+// CreateDeadLetter stores an event which could not be delivered
+func (repo *repo) CreateDeadLetter(ctx context.Context, deadLetter *DeadLetter) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.CreateDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": deadLetter.SubscriptionID,
+		"eventID":        deadLetter.EventID,
+	}
+	if err := repo.putItem(repo.deadLetterTableName, deadLetter); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to create the webhook dead letter")
+		return err
+	}
+	return nil
+}
+
+// GetDeadLetter returns the dead letter, or ErrDeadLetterNotFound
+func (repo *repo) GetDeadLetter(ctx context.Context, deadLetterID string) (*DeadLetter, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.GetDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   deadLetterID,
+	}
+
+	result, err := repo.dynamoDBClient.GetItem(&dynamodb.GetItemInput{
+		Key: map[string]*dynamodb.AttributeValue{
+			"dead_letter_id": {S: aws.String(deadLetterID)},
+		},
+		TableName: aws.String(repo.deadLetterTableName),
+	})
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to load the webhook dead letter")
+		return nil, err
+	}
+	if len(result.Item) == 0 {
+		return nil, ErrDeadLetterNotFound
+	}
+
+	var deadLetter DeadLetter
+	if err = dynamodbattribute.UnmarshalMap(result.Item, &deadLetter); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook dead letter")
+		return nil, err
+	}
+	return &deadLetter, nil
+}
+
+// GetDeadLetters returns the dead letters of the webhook subscription, most recent first
+func (repo *repo) GetDeadLetters(ctx context.Context, subscriptionID string) ([]*DeadLetter, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.GetDeadLetters",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+	}
+
+	condition := expression.Key("subscription_id").Equal(expression.Value(subscriptionID))
+	expr, err := expression.NewBuilder().WithKeyCondition(condition).Build()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("error building expression for the webhook dead letters query")
+		return nil, err
+	}
+
+	queryInput := &dynamodb.QueryInput{
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		KeyConditionExpression:    expr.KeyCondition(),
+		TableName:                 aws.String(repo.deadLetterTableName),
+		IndexName:                 aws.String(SubscriptionIDIndex),
+	}
+
+	var deadLetters []*DeadLetter
+	for {
+		results, queryErr := repo.dynamoDBClient.Query(queryInput)
+		if queryErr != nil {
+			log.WithFields(f).WithError(queryErr).Warn("unable to query the webhook dead letters")
+			return nil, queryErr
+		}
+
+		var page []*DeadLetter
+		if err = dynamodbattribute.UnmarshalListOfMaps(results.Items, &page); err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to unmarshal the webhook dead letters")
+			return nil, err
+		}
+		deadLetters = append(deadLetters, page...)
+
+		if len(results.LastEvaluatedKey) == 0 {
+			break
+		}
+		queryInput.ExclusiveStartKey = results.LastEvaluatedKey
+	}
+
+	sort.Slice(deadLetters, func(i, j int) bool {
+		return deadLetters[i].DateCreated > deadLetters[j].DateCreated
+	})
+	return deadLetters, nil
+}
+
+// UpdateDeadLetter replaces the dead letter
+func (repo *repo) UpdateDeadLetter(ctx context.Context, deadLetter *DeadLetter) error {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.repository.UpdateDeadLetter",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"deadLetterID":   deadLetter.DeadLetterID,
+	}
+	if err := repo.putItem(repo.deadLetterTableName, deadLetter); err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to update the webhook dead letter")
+		return err
+	}
+	return nil
+}
+
+// putItem marshals and stores the item in the table
+func (repo *repo) putItem(tableName string, item interface{}) error {
+	av, err := dynamodbattribute.MarshalMap(item)
+	if err != nil {
+		return err
+	}
+	_, err = repo.dynamoDBClient.PutItem(&dynamodb.PutItemInput{
+		Item:      av,
+		TableName: aws.String(tableName),
+	})
+	return err
+}
@@ -0,0 +1,67 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/kms"
+)
+
+// SecretCipher encrypts the signing secrets of the subscriptions before they are stored
+type SecretCipher interface {
+	Encrypt(ctx context.Context, subscriptionID, secret string) (string, error)
+	Decrypt(ctx context.Context, subscriptionID, encryptedSecret string) (string, error)
+}
+
+type kmsSecretCipher struct {
+	kmsClient *kms.KMS
+	keyID     string
+}
+
+// NewKMSSecretCipher returns a SecretCipher using the cla-webhook-secrets KMS key of the stage - the subscription
+// ID is bound to the ciphertext as the encryption context so that a secret can't be moved to another subscription
+func NewKMSSecretCipher(awsSession *session.Session, stage string) SecretCipher {
+	return &kmsSecretCipher{
+		kmsClient: kms.New(awsSession),
+		keyID:     fmt.Sprintf("alias/cla-webhook-secrets-%s", stage),
+	}
+}
+
+// Encrypt returns the base64 encoded ciphertext of the secret
+func (c *kmsSecretCipher) Encrypt(ctx context.Context, subscriptionID, secret string) (string, error) {
+	output, err := c.kmsClient.EncryptWithContext(ctx, &kms.EncryptInput{
+		KeyId:             aws.String(c.keyID),
+		Plaintext:         []byte(secret),
+		EncryptionContext: encryptionContext(subscriptionID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(output.CiphertextBlob), nil
+}
+
+// Decrypt returns the secret of the base64 encoded ciphertext
+func (c *kmsSecretCipher) Decrypt(ctx context.Context, subscriptionID, encryptedSecret string) (string, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedSecret)
+	if err != nil {
+		return "", err
+	}
+	output, err := c.kmsClient.DecryptWithContext(ctx, &kms.DecryptInput{
+		CiphertextBlob:    ciphertext,
+		EncryptionContext: encryptionContext(subscriptionID),
+	})
+	if err != nil {
+		return "", err
+	}
+	return string(output.Plaintext), nil
+}
+
+func encryptionContext(subscriptionID string) map[string]*string {
+	return map[string]*string{"subscription_id": aws.String(subscriptionID)}
+}
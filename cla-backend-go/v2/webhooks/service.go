@@ -0,0 +1,282 @@
+// Copyright The Linux Foundation and each contributor to CommunityBridge.
+// SPDX-License-Identifier: MIT
+
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gofrs/uuid"
+	"github.com/linuxfoundation/easycla/cla-backend-go/gen/v2/models"
+	log "github.com/linuxfoundation/easycla/cla-backend-go/logging"
+	"github.com/linuxfoundation/easycla/cla-backend-go/utils"
+	"github.com/sirupsen/logrus"
+)
+
+// validation errors
+var (
+	ErrInvalidScope  = errors.New("invalid webhook subscription scope")
+	ErrInvalidURL    = errors.New("invalid webhook subscription URL - an https URL is required")
+	ErrInvalidSecret = fmt.Errorf("the webhook signing secret must be at least %d characters long", minSecretLength)
+)
+
+const (
+	// generatedSecretLength is the number of random bytes of the generated signing secrets
+	generatedSecretLength = 32
+	// minSecretLength is the minimum length of the signing secrets provided by the users
+	minSecretLength = 16
+)
+
+// ServiceInterface defines the webhook service functions
+type ServiceInterface interface {
+	CreateSubscription(ctx context.Context, input *models.WebhookSubscriptionInput, createdBy string) (*models.WebhookSubscription, error)
+	GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error)
+	ListSubscriptions(ctx context.Context, scopeType, scopeID string) (*models.WebhookSubscriptionList, error)
+	UpdateSubscription(ctx context.Context, subscriptionID string, input *models.WebhookSubscriptionUpdateInput) (*models.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, subscriptionID string) error
+	ListDeadLetters(ctx context.Context, subscriptionID string) (*models.WebhookDeadLetterList, error)
+	RedeliverDeadLetter(ctx context.Context, subscriptionID, deadLetterID string) (*models.WebhookDeadLetter, error)
+	QueueEvent(ctx context.Context, payload *EventPayload) error
+	ProcessDelivery(ctx context.Context, message *DeliveryMessage, receiveCount int) error
+}
+
+// Service is the webhook service
+type Service struct {
+	repo       Repository
+	secrets    SecretCipher
+	queue      DeliveryQueue
+	httpClient *http.Client
+}
+
+// NewService creates a new webhook service, the delivery queue is only required to queue the events
+func NewService(repo Repository, secrets SecretCipher, queue DeliveryQueue) *Service {
+	return &Service{
+		repo:       repo,
+		secrets:    secrets,
+		queue:      queue,
+		httpClient: newDeliveryClient(),
+	}
+}
+
+// CreateSubscription validates and stores a new webhook subscription. The signing secret is only returned by this
+// call - a random secret is generated when none is provided.
+func (s *Service) CreateSubscription(ctx context.Context, input *models.WebhookSubscriptionInput, createdBy string) (*models.WebhookSubscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.CreateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"createdBy":      createdBy,
+	}
+
+	scopeType, scopeID := utils.StringValue(input.ScopeType), strings.TrimSpace(utils.StringValue(input.ScopeID))
+	if !isValidScopeType(scopeType) || scopeID == "" {
+		return nil, ErrInvalidScope
+	}
+	webhookURL, err := validateURL(utils.StringValue(input.URL))
+	if err != nil {
+		return nil, err
+	}
+
+	secret := input.Secret
+	if secret == "" {
+		secret, err = generateSecret()
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to generate the webhook signing secret")
+			return nil, err
+		}
+	} else if len(secret) < minSecretLength {
+		return nil, ErrInvalidSecret
+	}
+
+	subscriptionID, err := uuid.NewV4()
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to generate a UUID for the webhook subscription")
+		return nil, err
+	}
+
+	encryptedSecret, err := s.secrets.Encrypt(ctx, subscriptionID.String(), secret)
+	if err != nil {
+		log.WithFields(f).WithError(err).Warn("unable to encrypt the webhook signing secret")
+		return nil, err
+	}
+
+	_, currentTime := utils.CurrentTime()
+	subscription := &Subscription{
+		SubscriptionID:  subscriptionID.String(),
+		ScopeType:       scopeType,
+		ScopeID:         scopeID,
+		ScopeKey:        scopeKey(scopeType, scopeID),
+		URL:             webhookURL,
+		EncryptedSecret: encryptedSecret,
+		EventTypes:      normalizeEventTypes(input.EventTypes),
+		Enabled:         input.Enabled == nil || *input.Enabled,
+		CreatedBy:       createdBy,
+		DateCreated:     currentTime,
+		DateModified:    currentTime,
+	}
+	if err = s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	result := subscription.toModel()
+	result.Secret = secret
+	return result, nil
+}
+
+// GetSubscription returns the webhook subscription
+func (s *Service) GetSubscription(ctx context.Context, subscriptionID string) (*models.WebhookSubscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	return subscription.toModel(), nil
+}
+
+// ListSubscriptions returns the webhook subscriptions of the scope
+func (s *Service) ListSubscriptions(ctx context.Context, scopeType, scopeID string) (*models.WebhookSubscriptionList, error) {
+	if !isValidScopeType(scopeType) || scopeID == "" {
+		return nil, ErrInvalidScope
+	}
+	subscriptions, err := s.repo.GetSubscriptionsByScope(ctx, scopeType, scopeID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		list = append(list, subscription.toModel())
+	}
+	return &models.WebhookSubscriptionList{List: list}, nil
+}
+
+// UpdateSubscription updates the URL, event type filter or enabled flag of the webhook subscription, and rotates the
+// signing secret when requested - the new secret is only returned by this call
+func (s *Service) UpdateSubscription(ctx context.Context, subscriptionID string, input *models.WebhookSubscriptionUpdateInput) (*models.WebhookSubscription, error) {
+	f := logrus.Fields{
+		"functionName":   "v2.webhooks.service.UpdateSubscription",
+		utils.XREQUESTID: ctx.Value(utils.XREQUESTID),
+		"subscriptionID": subscriptionID,
+	}
+
+	subscription, err := s.repo.GetSubscription(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != "" {
+		subscription.URL, err = validateURL(input.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if input.EventTypes != nil {
+		subscription.EventTypes = normalizeEventTypes(input.EventTypes)
+	}
+	if input.Enabled != nil {
+		subscription.Enabled = *input.Enabled
+	}
+	var secret string
+	if input.RotateSecret {
+		secret, err = generateSecret()
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to generate the webhook signing secret")
+			return nil, err
+		}
+		subscription.EncryptedSecret, err = s.secrets.Encrypt(ctx, subscription.SubscriptionID, secret)
+		if err != nil {
+			log.WithFields(f).WithError(err).Warn("unable to encrypt the webhook signing secret")
+			return nil, err
+		}
+	}
+
+	_, subscription.DateModified = utils.CurrentTime()
+	if err = s.repo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	result := subscription.toModel()
+	if input.RotateSecret {
+		result.Secret = secret
+	}
+	return result, nil
+}
+
+// DeleteSubscription deletes the webhook subscription
+func (s *Service) DeleteSubscription(ctx context.Context, subscriptionID string) error {
+	if _, err := s.repo.GetSubscription(ctx, subscriptionID); err != nil {
+		return err
+	}
+	return s.repo.DeleteSubscription(ctx, subscriptionID)
+}
+
+// ListDeadLetters returns the events which could not be delivered to the webhook subscription
+func (s *Service) ListDeadLetters(ctx context.Context, subscriptionID string) (*models.WebhookDeadLetterList, error) {
+	deadLetters, err := s.repo.GetDeadLetters(ctx, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]*models.WebhookDeadLetter, 0, len(deadLetters))
+	for _, deadLetter := range deadLetters {
+		list = append(list, deadLetter.toModel())
+	}
+	return &models.WebhookDeadLetterList{List: list}, nil
+}
+
+// isValidScopeType returns true if the scope type is supported
+func isValidScopeType(scopeType string) bool {
+	switch scopeType {
+	case ScopeFoundation, ScopeCLAGroup, ScopeCompany:
+		return true
+	}
+	return false
+}
+
+// validateURL returns the trimmed URL if it is an absolute https URL which does not target a blocked address or a
+// local host name - the resolved addresses are checked again on each delivery when the connection is dialed
+func validateURL(rawURL string) (string, error) {
+	rawURL = strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Hostname() == "" || parsed.User != nil {
+		return "", ErrInvalidURL
+	}
+	host := strings.ToLower(strings.TrimSuffix(parsed.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") || strings.HasSuffix(host, ".internal") {
+		return "", ErrInvalidURL
+	}
+	if ip := net.ParseIP(host); ip != nil && isBlockedIP(ip) {
+		return "", ErrInvalidURL
+	}
+	return rawURL, nil
+}
+
+// normalizeEventTypes trims and removes the empty and duplicate event types
+func normalizeEventTypes(eventTypes []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, eventType := range eventTypes {
+		eventType = strings.TrimSpace(eventType)
+		if eventType == "" || seen[eventType] {
+			continue
+		}
+		seen[eventType] = true
+		normalized = append(normalized, eventType)
+	}
+	return normalized
+}
+
+// generateSecret returns a random hex encoded signing secret
+func generateSecret() (string, error) {
+	secret := make([]byte, generatedSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(secret), nil
+}
@@ -284,10 +284,6 @@ def activity(action: str, event_type: str, body: dict):
     elif event_type == "issue_comment":
         cla.log.debug(f'{fn} - received issue_comment action: {action}...')
         handle_pull_request_comment_event(action, body)
-    
-    # Github Merge Group Event
-    elif event_type == 'merge_group':
-        handle_merge_group_event(action, body)
 
     else:
         cla.log.debug(f'{fn} - ignoring github activity event, action: {action}...')
@@ -363,21 +359,6 @@ def handle_pull_request_event(action: str, body: dict):
     else:
         cla.log.debug(f'{func_name} - ignoring github pull_request activity for action: {action}')
 
-def handle_merge_group_event(action: str, body: dict):
-    func_name = 'github.activity.handle_merge_group_event'
-    cla.log.debug(f'{func_name} - processing github merge_group activity callback...')
-
-    # Checks Requested action
-    if action == 'checks_requested':
-        cla.log.debug(f'{func_name} - processing github merge_group activity for action: {action}')
-        # Copied from repository_service.py
-        service = cla.utils.get_repository_service('github')
-        result = service.received_activity(body)
-        return result
-    else:
-        cla.log.debug(f'{func_name} - ignoring github merge_group activity for action: {action}')
-
-
 def handle_pull_request_comment_event(action: str, body: dict):
     func_name = 'github.activity.handle_pull_request_comment_event'
     cla.log.debug(f'{func_name} - processing github pull_request comment activity callback...')
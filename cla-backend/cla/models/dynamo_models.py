@@ -35,7 +35,7 @@ import cla
 from cla.models import model_interfaces, key_value_store_interface, DoesNotExist
 from cla.models.event_types import EventType
 from cla.models.model_interfaces import User, Signature, ProjectCLAGroup, Repository, Gerrit
-from cla.models.model_utils import is_uuidv4
+from cla.models.model_utils import is_uuidv4, email_domain_matches_pattern
 from cla.project_service import ProjectService
 
 stage = os.environ.get("STAGE", "")
@@ -1190,6 +1190,9 @@ class ProjectModel(BaseModel):
     foundation_sfid = UnicodeAttribute(null=True)
     root_project_repositories_count = NumberAttribute(null=True)
     note = UnicodeAttribute(null=True)
+    # Managed by the Go backend: accept-previous (default), resign or expire
+    signature_version_policy = UnicodeAttribute(null=True)
+    signature_expiry_date = UnicodeAttribute(null=True)
     # Indexes
     project_external_id_index = ExternalProjectIndex()
     project_name_search_index = ProjectNameIndex()
@@ -1426,6 +1429,12 @@ class Project(model_interfaces.Project):  # pylint: disable=too-many-public-meth
     def get_project_ccla_requires_icla_signature(self):
         return self.model.project_ccla_requires_icla_signature
 
+    def get_signature_version_policy(self) -> Optional[str]:
+        return self.model.signature_version_policy
+
+    def get_signature_expiry_date(self) -> Optional[str]:
+        return self.model.signature_expiry_date
+
     def get_project_latest_major_version(self):
         pass
         # @todo: Loop through documents for this project, return the highest version of them all.
@@ -2017,19 +2026,9 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
         """
         fn = 'dynamo_models.preprocess_pattern'
         for pattern in patterns:
-            if pattern.startswith("*."):
-                pattern = pattern.replace("*.", ".*")
-            elif pattern.startswith("*"):
-                pattern = pattern.replace("*", ".*")
-            elif pattern.startswith("."):
-                pattern = pattern.replace(".", ".*")
-
-            preprocessed_pattern = "^.*@" + pattern + "$"
-            pat = re.compile(preprocessed_pattern)
-            for email in emails:
-                if pat.match(email) is not None:
-                    self.log_debug(f'{fn} - found user email in email approval pattern')
-                    return True
+            if email_domain_matches_pattern(emails, pattern):
+                self.log_debug(f'{fn} - found user email in email approval pattern: {pattern}')
+                return True
         return False
 
     # Accepts a Signature object
@@ -2066,10 +2065,9 @@ class User(model_interfaces.User):  # pylint: disable=too-many-public-methods
             cla.log.debug(f'{fn} - no email whitelist match for user: {self}')
 
         # Secondly, let's check domain whitelist
-        # If a naked domain (e.g. google.com) is provided, we prefix it with '^.*@',
-        # so that sub-domains are not allowed.
-        # If a '*', '*.' or '.' prefix is provided, we replace the prefix with '.*\.',
-        # which will allow subdomains.
+        # A naked domain (e.g. google.com) only matches that exact domain, sub-domains are not allowed.
+        # A '*.' prefix (or the older '*' and '.' prefixes) matches the domain and all of its sub-domains,
+        # e.g. *.google.com matches google.com, eu.google.com and dev.us.google.com.
         patterns = ccla_signature.get_domain_whitelist()
         cla.log.debug(f'{fn} - testing user email domains: {emails} with '
                       f'domain approval values: {patterns}')
@@ -77,9 +77,9 @@ class GitHub(repository_service_interface.RepositoryService):
 
     def received_activity(self, data):
         cla.log.debug("github_models.received_activity - Received GitHub activity: %s", data)
-        if "pull_request" not in data and "merge_group" not in data:
+        if "pull_request" not in data:
             cla.log.debug("github_models.received_activity - Activity not related to pull request - ignoring")
-            return {"message": "Not a pull request nor a merge group  - no action performed"}
+            return {"message": "Not a pull request - no action performed"}
         if data["action"] == "opened":
             cla.log.debug("github_models.received_activity - Handling opened pull request")
             return self.process_opened_pull_request(data)
@@ -92,9 +92,6 @@ class GitHub(repository_service_interface.RepositoryService):
         elif data["action"] == "synchronize":
             cla.log.debug("github_models.received_activity - Handling synchronized pull request")
             return self.process_synchronized_pull_request(data)
-        elif data["action"] == "checks_requested":
-            cla.log.debug("github_models.received_activity - Handling checks requested pull request")
-            return self.process_checks_requested_merge_group(data)
         else:
             cla.log.debug("github_models.received_activity - Ignoring unsupported action: {}".format(data["action"]))
 
@@ -412,22 +409,6 @@ class GitHub(repository_service_interface.RepositoryService):
         installation_id = data["installation"]["id"]
         self.update_change_request(installation_id, github_repository_id, pull_request_id)
 
-    def process_checks_requested_merge_group(self, data):
-        """
-        Helper method to handle a webhook fired from GitHub for a merge group event.
-
-        :param data: The data returned from GitHub on this webhook.
-        :type data: dict
-        """
-        merge_group_sha = data["merge_group"]["head_sha"]
-        github_repository_id = data["repository"]["id"]
-        installation_id = data["installation"]["id"]
-        pull_request_message = data["merge_group"]["head_commit"]["message"]
-
-        # Extract the pull request number from the message
-        pull_request_id = cla.utils.extract_pull_request_number(pull_request_message)
-        self.update_merge_group(installation_id, github_repository_id, merge_group_sha, pull_request_id)
-
     def process_easycla_command_comment(self, data):
         """
         Processes easycla command comment if present
@@ -508,239 +489,6 @@ class GitHub(repository_service_interface.RepositoryService):
         cla.log.debug(f"{fn} - found organization by GH name: {organization_name}")
         return True
 
-    def get_pull_request_retry(self, github_repository_id, change_request_id, installation_id, retries=3) -> dict:
-        """
-        Helper function to retry getting a pull request from GitHub.
-        """
-        fn = "get_pull_request_retry"
-        pull_request = {}
-        for i in range(retries):
-            try:
-                # check if change_request_id is a valid int
-                _ = int(change_request_id)
-                pull_request = self.get_pull_request(github_repository_id, change_request_id, installation_id)
-            except ValueError as ve:
-                cla.log.error(
-                    f"{fn} - Invalid PR: {change_request_id} - error: {ve}. Unable to fetch "
-                    f"PR {change_request_id} from GitHub repository {github_repository_id} "
-                    f"using installation id {installation_id}."
-                )
-                if i <= retries:
-                    cla.log.debug(f"{fn} - attempt {i + 1} - waiting to retry...")
-                    time.sleep(2)
-                    continue
-                else:
-                    cla.log.warning(
-                        f"{fn} - attempt {i + 1} - exhausted retries - unable to load PR "
-                        f"{change_request_id} from GitHub repository {github_repository_id} "
-                        f"using installation id {installation_id}."
-                    )
-                    # TODO: DAD - possibly update the PR status?
-                    return
-            # Fell through - no error, exit loop and continue on
-            break
-        cla.log.debug(f"{fn} - retrieved pull request: {pull_request}")
-
-        return pull_request
-
-    def update_merge_group_status(
-        self, installation_id, repository_id, pull_request, merge_commit_sha, signed, missing, project_version
-    ):
-        """
-        Helper function to update a merge queue entrys status based on the list of signers.
-        :param installation_id: The ID of the GitHub installation
-        :type installation_id: int
-        :param repository_id: The ID of the GitHub repository this PR belongs to.
-        :type repository_id: int
-        :param pull_request: The GitHub PullRequest object for this PR.
-        """
-        fn = "update_merge_group_status"
-        context_name = os.environ.get("GH_STATUS_CTX_NAME")
-        if context_name is None:
-            context_name = "communitybridge/cla"
-        if missing is not None and len(missing) > 0:
-            state = "failure"
-            context, body = cla.utils.assemble_cla_status(context_name, signed=False)
-            sign_url = cla.utils.get_full_sign_url(
-                "github", str(installation_id), repository_id, pull_request.number, project_version
-            )
-            cla.log.debug(
-                f"{fn} - Creating new CLA '{state}' status - {len(signed)} passed, {missing} failed, "
-                f"signing url: {sign_url}"
-            )
-        elif signed is not None and len(signed) > 0:
-            state = "success"
-            # For status, we change the context from author_name to 'communitybridge/cla' or the
-            # specified default value per issue #166
-            context, body = cla.utils.assemble_cla_status(context_name, signed=True)
-            sign_url = cla.conf["CLA_LANDING_PAGE"]  # Remove this once signature detail page ready.
-            sign_url = os.path.join(sign_url, "#/")
-            sign_url = append_project_version_to_url(address=sign_url, project_version=project_version)
-            cla.log.debug(
-                f"{fn} - Creating new CLA '{state}' status - {len(signed)} passed, {missing} failed, "
-                f"signing url: {sign_url}"
-            )
-        else:
-            # error condition - should have at least one committer, and they would be in one of the above
-            # lists: missing or signed
-            state = "failure"
-            # For status, we change the context from author_name to 'communitybridge/cla' or the
-            # specified default value per issue #166
-            context, body = cla.utils.assemble_cla_status(context_name, signed=False)
-            sign_url = cla.utils.get_full_sign_url(
-                "github", str(installation_id), repository_id, pull_request.number, project_version
-            )
-            cla.log.debug(
-                f"{fn} - Creating new CLA '{state}' status - {len(signed)} passed, {missing} failed, "
-                f"signing url: {sign_url}"
-            )
-            cla.log.warning(
-                "{fn} - This is an error condition - "
-                f"should have at least one committer in one of these lists: "
-                f"{len(signed)} passed, {missing}"
-            )
-
-        # Create the commit status on the merge commit
-        if self.client is None:
-            self.client = self._get_github_client(installation_id)
-
-        # Get repository
-        cla.log.debug(f"{fn} - Getting repository by ID: {repository_id}")
-        repository = self.client.get_repo(int(repository_id))
-
-        # Get the commit object
-        cla.log.debug(f"{fn} - Getting commit by SHA: {merge_commit_sha}")
-        commit_obj = repository.get_commit(merge_commit_sha)
-
-        cla.log.debug(
-            f"{fn} - Creating commit status for merge commit: {merge_commit_sha} "
-            f"with state: {state}, context: {context}, body: {body}"
-        )
-
-        create_commit_status_for_merge_group(commit_obj, merge_commit_sha, state, sign_url, body, context)
-
-    def update_merge_group(self, installation_id, github_repository_id, merge_group_sha, pull_request_id):
-        fn = "update_queue_entry"
-
-        # Note: late 2021/early 2022 we observed that sometimes we get the event for a PR, then go back to GitHub
-        # to query for the PR details and discover the PR is 404, not available for some reason.  Added retry
-        # logic to retry a couple of times to address any timing issues.
-
-        try:
-            # Get the pull request details from GitHub
-            cla.log.debug(
-                f"{fn} - fetching pull request details for GH repo ID: {github_repository_id} "
-                f"PR ID: {pull_request_id}..."
-            )
-            pull_request = self.get_pull_request_retry(github_repository_id, pull_request_id, installation_id)
-        except Exception as e:
-            cla.log.warning(
-                f"{fn} - unable to load PR {pull_request_id} from GitHub repository "
-                f"{github_repository_id} using installation id {installation_id} - error: {e}"
-            )
-            return
-
-        try:
-            # Get Commit authors
-            commit_authors = get_pull_request_commit_authors(pull_request, installation_id)
-            cla.log.debug(f"{fn} - commit authors: {commit_authors}")
-        except Exception as e:
-            cla.log.warning(
-                f"{fn} - unable to load commit authors for PR {pull_request_id} from GitHub repository "
-                f"{github_repository_id} using installation id {installation_id} - error: {e}"
-            )
-            return
-
-        try:
-            # Get existing repository info using the repository's external ID,
-            # which is the repository ID assigned by github.
-            cla.log.debug(f"{fn} - PR: {pull_request.number}, Loading GitHub repository by id: {github_repository_id}")
-            repository = Repository().get_repository_by_external_id(github_repository_id, "github")
-            if repository is None:
-                cla.log.warning(
-                    f"{fn} - PR: {pull_request.number}, Failed to load GitHub repository by "
-                    f"id: {github_repository_id} in our DB - repository reference is None - "
-                    "Is this org/repo configured in the Project Console?"
-                    " Unable to update status."
-                )
-                # Optionally, we could add a comment or add a status to the PR informing the users that the EasyCLA
-                # app/bot is enabled in GitHub (which is why we received the event in the first place), but the
-                # repository is not setup/configured in EasyCLA from the administration console
-                return
-
-            # If the repository is not enabled in our database, we don't process it.
-            if not repository.get_enabled():
-                cla.log.warning(
-                    f"{fn} - repository {repository.get_repository_url()} associated with "
-                    f"PR: {pull_request.number} is NOT enabled"
-                    " - ignoring PR request"
-                )
-                # Optionally, we could add a comment or add a status to the PR informing the users that the EasyCLA
-                # app/bot is enabled in GitHub (which is why we received the event in the first place), but the
-                # repository is NOT enabled in the administration console
-                return
-
-        except DoesNotExist:
-            cla.log.warning(
-                f"{fn} - PR: {pull_request.number}, could not find repository with the "
-                f"repository ID: {github_repository_id}"
-            )
-            cla.log.warning(
-                f"{fn} - PR: {pull_request.number}, failed to update change request of "
-                f"repository {github_repository_id} - returning"
-            )
-            return
-
-        # Get GitHub Organization name that the repository is configured to.
-        organization_name = repository.get_repository_organization_name()
-        cla.log.debug(f"{fn} - PR: {pull_request.number}, determined github organization is: {organization_name}")
-
-        # Check that the GitHub Organization exists.
-        github_org = GitHubOrg()
-        try:
-            github_org.load(organization_name)
-        except DoesNotExist:
-            cla.log.warning(
-                f"{fn} - PR: {pull_request.number}, Could not find Github Organization "
-                f"with the following organization name: {organization_name}"
-            )
-            cla.log.warning(
-                f"{fn}- PR: {pull_request.number}, Failed to update change request of "
-                f"repository {github_repository_id} - returning"
-            )
-            return
-
-            # Ensure that installation ID for this organization matches the given installation ID
-        if github_org.get_organization_installation_id() != installation_id:
-            cla.log.warning(
-                f"{fn} - PR: {pull_request.number}, "
-                f"the installation ID: {github_org.get_organization_installation_id()} "
-                f"of this organization does not match installation ID: {installation_id} "
-                "given by the pull request."
-            )
-            cla.log.error(
-                f"{fn} - PR: {pull_request.number}, Failed to update change request "
-                f"of repository {github_repository_id} - returning"
-            )
-            return
-
-        project_id = repository.get_repository_project_id()
-        project = get_project_instance()
-        project.load(project_id)
-
-        signed = []
-        missing = []
-
-        # Check if the user has signed the CLA
-        cla.log.debug(f"{fn} - checking if the user has signed the CLA...")
-        for user_commit_summary in commit_authors:
-            handle_commit_from_user(project, user_commit_summary, signed, missing)
-
-        # update Merge group status
-        self.update_merge_group_status(
-            installation_id, github_repository_id, pull_request, merge_group_sha, signed, missing, project.get_version()
-        )
-
     def update_change_request(self, installation_id, github_repository_id, change_request_id):
         fn = "update_change_request"
         # Queries GH for the complete pull request details, see:
@@ -1382,46 +1130,6 @@ def handle_commit_from_user(
         missing.append(user_commit_summary)
 
 
-def get_merge_group_commit_authors(merge_group_sha, installation_id=None) -> List[UserCommitSummary]:
-    """
-    Helper function to extract all committer information for a GitHub merge group.
-
-    :param: merge_group_sha: A GitHub merge group sha to examine.
-    :type: merge_group_sha: string
-    :return: A list of User Commit Summary objects containing the commit sha and available user information
-    """
-
-    fn = "cla.models.github_models.get_merge_group_commit_authors"
-    cla.log.debug(f"Querying merge group {merge_group_sha} for commit authors...")
-    commit_authors = []
-    try:
-        g = cla.utils.get_github_integration_instance(installation_id=installation_id)
-        commit = g.get_commit(merge_group_sha)
-        for parent in commit.parents:
-            try:
-                cla.log.debug(f"{fn} - Querying parent commit {parent.sha} for commit authors...")
-                commit = g.get_commit(parent.sha)
-                cla.log.debug(f"{fn} - Found {commit.commit.author.name} as the author of parent commit {parent.sha}")
-                commit_authors.append(
-                    UserCommitSummary(
-                        parent.sha,
-                        commit.author.id,
-                        commit.author.login,
-                        commit.author.name,
-                        commit.author.email,
-                        False,
-                        False,
-                    )
-                )
-            except (GithubException, IncompletableObject) as e:
-                cla.log.warning(f"{fn} - Unable to query parent commit {parent.sha} for commit authors: {e}")
-                commit_authors.append(UserCommitSummary(parent.sha, None, None, None, None, False, False))
-
-    except Exception as e:
-        cla.log.warning(f"{fn} - Unable to query merge group {merge_group_sha} for commit authors: {e}")
-
-    return commit_authors
-
 
 def get_author_summary(commit, pr, installation_id) -> List[UserCommitSummary]:
     """
@@ -1751,30 +1459,6 @@ def update_pull_request(
             create_commit_status(pull_request, last_commit.sha, state, sign_url, body, context)
 
 
-def create_commit_status_for_merge_group(commit_obj, merge_commit_sha, state, sign_url, body, context):
-    """
-    Helper function to create a pull request commit status message.
-
-    :param commit_obj: The commit object to post a status on.
-    :type commit_obj: Commit
-    :param merge_commit_sha: The commit hash to post a status on.
-    :type merge_commit_sha: string
-    :param state: The state of the status.
-    :type state: string
-    :param sign_url: The link the user will be taken to when clicking on the status message.
-    :type sign_url: string
-    :param body: The contents of the status message.
-    :type body: string
-    """
-    try:
-        # Create status
-        cla.log.debug(f"Creating commit status for merge commit {merge_commit_sha}")
-        commit_obj.create_status(state=state, target_url=sign_url, description=body, context=context)
-
-    except Exception as e:
-        cla.log.warning(f"Unable to create commit status for  " f"and merge commit {merge_commit_sha}: {e}")
-
-
 def create_commit_status(pull_request, commit_hash, state, sign_url, body, context):
     """
     Helper function to create a pull request commit status message given the PR and commit hash.
@@ -118,6 +118,25 @@ class Project(object):  # pylint: disable=too-many-public-methods
         """
         raise NotImplementedError()
 
+    def get_signature_version_policy(self):
+        """
+        Getter for the project's signature version policy.
+
+        :return: accept-previous, resign or expire - None is the same as accept-previous.
+        :rtype: string
+        """
+        raise NotImplementedError()
+
+    def get_signature_expiry_date(self):
+        """
+        Getter for the date the signatures of a previous major document version expire on, only used with the
+        expire signature version policy.
+
+        :return: The signature expiry date.
+        :rtype: string
+        """
+        raise NotImplementedError()
+
     def get_project_current_major_version(self):
         """
         Getter for the project's current Major Document Version.
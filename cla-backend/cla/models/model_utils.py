@@ -4,6 +4,7 @@
 """
 Utility functions for the models
 """
+from typing import List
 from uuid import UUID
 
 
@@ -22,3 +23,33 @@ def is_uuidv4(uuid_string: str) -> bool:
     except ValueError:
         # If it's a value error, then the string is not a valid UUID.
         return False
+
+
+def email_domain_matches_pattern(emails: List[str], pattern: str) -> bool:
+    """
+    Helper function for determining if the domain of any of the specified emails matches a domain approval list
+    pattern. A plain domain such as example.com only matches that exact domain. A wildcard pattern such as
+    *.example.com matches example.com and any of its sub-domains at any depth. Older entries using the '*' or '.'
+    prefix are handled as wildcard patterns. Comparisons are case-insensitive.
+    :param emails: the list of user emails
+    :param pattern: the domain approval list pattern
+    :return: True if at least one email matches the pattern, False otherwise
+    """
+    pattern = (pattern or '').strip().lower()
+    wildcard = False
+    for prefix in ('*.', '*', '.'):
+        if pattern.startswith(prefix):
+            pattern = pattern[len(prefix):]
+            wildcard = True
+            break
+    if not pattern:
+        return False
+
+    for email in emails or []:
+        email = (email or '').strip().lower()
+        if '@' not in email:
+            continue
+        email_domain = email.rsplit('@', 1)[1]
+        if email_domain == pattern or (wildcard and email_domain.endswith('.' + pattern)):
+            return True
+    return False
@@ -1648,6 +1648,7 @@ def github_app_activity(body, request, response):
     if event_type == "installation_repositories" or \
             event_type == "integration_installation_repositories" or \
             event_type == "repository" or \
+            event_type == "merge_group" or \
             (event_type == "push" and action and action == "created"):
         try:
             cla.log.debug(f'{fn} - redirecting event type: \'{event_type}\' with action: \'{action}\' to v4 golang api')
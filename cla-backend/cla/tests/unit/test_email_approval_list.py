@@ -64,6 +64,21 @@ def test_pattern_with_dot_prefix(create_user):
     assert create_user.preprocess_pattern(domain_emails, patterns) == True
 
 
+def test_wildcard_pattern_nested_subdomains(create_user):
+    """Test given user email on nested subdomains against a wildcard pattern """
+    patterns = ["*.bar.com"]
+    assert create_user.preprocess_pattern(["harold@dev.us.bar.com"], patterns) == True
+    assert create_user.preprocess_pattern(["Harold@EU.Bar.com"], patterns) == True
+    assert create_user.preprocess_pattern(["harold@foobar.com"], patterns) == False
+    assert create_user.preprocess_pattern(["harold@bar.com.evil.org"], patterns) == False
+
+
+def test_naked_domain_is_not_a_regex(create_user):
+    """Test that dots in a naked domain pattern are not handled as wildcards """
+    patterns = ["bar.com"]
+    assert create_user.preprocess_pattern(["harold@barxcom"], patterns) == False
+
+
 def test_email_approval_list_fail(create_user):
     """Test email that fails domain and email approval list checks """
     signature = Signature()
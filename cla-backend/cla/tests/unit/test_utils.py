@@ -9,7 +9,8 @@ from cla import utils
 from cla.models.dynamo_models import Project, Signature, User
 from cla.utils import (append_email_help_sign_off_content, extract_pull_request_number,
                        append_project_version_to_url, get_email_help_content,
-                       get_email_sign_off_content, get_full_sign_url)
+                       get_email_sign_off_content, get_full_sign_url,
+                       signature_version_accepted)
 
 
 class TestUtils(unittest.TestCase):
@@ -163,6 +164,43 @@ class TestUtils(unittest.TestCase):
         self.assertTrue(utils.is_approved(signature, github_username='foo'))
 
 
+def _signature_version_project(policy, expiry_date=None) -> Project:
+    """
+    Returns a project whose documents were updated from version 1 to version 2
+    """
+    documents = []
+    for major in (1, 2):
+        document = Mock()
+        document.get_document_major_version = Mock(return_value=major)
+        document.get_document_minor_version = Mock(return_value=0)
+        documents.append(document)
+    project = Project()
+    project.model.signature_version_policy = policy
+    project.model.signature_expiry_date = expiry_date
+    project.get_project_individual_documents = Mock(return_value=documents)
+    project.get_project_corporate_documents = Mock(return_value=documents)
+    return project
+
+
+def test_signature_version_accepted():
+    outdated = Signature(signature_reference_type="user", signature_document_major_version=1)
+    current = Signature(signature_reference_type="user", signature_document_major_version=2)
+    outdated_ccla = Signature(signature_reference_type="company", signature_document_major_version=1)
+
+    # the previous document versions are honored by default
+    assert signature_version_accepted(_signature_version_project(None), outdated)
+    assert signature_version_accepted(_signature_version_project("accept-previous"), outdated)
+
+    resign = _signature_version_project("resign")
+    assert not signature_version_accepted(resign, outdated)
+    assert not signature_version_accepted(resign, outdated_ccla)
+    assert signature_version_accepted(resign, current)
+
+    # the previous document versions are honored until the expiry date
+    assert signature_version_accepted(_signature_version_project("expire", "2999-12-31T00:00:00Z"), outdated)
+    assert not signature_version_accepted(_signature_version_project("expire", "2020-12-31T00:00:00Z"), outdated)
+
+
 def test_append_email_help_sign_off_content():
     body = "hello John,"
     new_bod = append_email_help_sign_off_content(body, "v2")
@@ -13,7 +13,7 @@ import secrets
 import base64
 import urllib.parse
 import urllib.parse as urlparse
-from datetime import datetime
+from datetime import datetime, timezone
 from typing import List, Optional
 from urllib.parse import urlencode
 
@@ -38,6 +38,10 @@ CORPORATE_BASE = os.environ.get("CLA_CORPORATE_BASE", "")
 CORPORATE_V2_BASE = os.environ.get("CLA_CORPORATE_V2_BASE", "")
 SVG_VERSION = "?v=2"
 
+# The CLA Group signature version policies rejecting signatures of a previous major document version
+SIGNATURE_VERSION_POLICY_RESIGN = "resign"
+SIGNATURE_VERSION_POLICY_EXPIRE = "expire"
+
 def get_cla_path():
     """Returns the CLA code root directory on the current system."""
     cla_folder_dir = os.path.dirname(os.path.abspath(inspect.getfile(inspect.currentframe())))
@@ -591,6 +595,54 @@ def get_last_version(documents):
     return last_major, last_minor
 
 
+def signature_version_accepted(project: Project, signature: Signature) -> bool:
+    """
+    Helper function to check if the signature is still honored by the CLA Group signature version policy. Signatures
+    of a previous major document version are rejected by the resign policy, and by the expire policy once the CLA
+    Group signature expiry date has passed.
+
+    :param project: The CLA Group of the signature.
+    :type project: cla.models.model_interfaces.Project
+    :param signature: The individual or corporate signature to check.
+    :type signature: cla.models.model_interfaces.Signature
+    :return: Whether or not the signature is honored.
+    :rtype: boolean
+    """
+    fn = "utils.signature_version_accepted"
+    policy = project.get_signature_version_policy()
+    if policy not in (SIGNATURE_VERSION_POLICY_RESIGN, SIGNATURE_VERSION_POLICY_EXPIRE):
+        return True
+
+    if signature.get_signature_reference_type() == "company":
+        document_models = project.get_project_corporate_documents()
+    else:
+        document_models = project.get_project_individual_documents()
+    current_major, _ = get_last_version(document_models)
+    signature_major = signature.get_signature_document_major_version()
+    # Signatures without a recorded document version can't be compared - treat them as current
+    if signature_major is None or int(signature_major) >= current_major:
+        return True
+
+    if policy == SIGNATURE_VERSION_POLICY_EXPIRE:
+        expiry_date = get_time_from_string(project.get_signature_expiry_date())
+        if expiry_date is None:
+            cla.log.warning(
+                f"{fn} - unable to parse the signature expiry date: {project.get_signature_expiry_date()} "
+                f"of project: {project.get_project_id()} - accepting signature"
+            )
+            return True
+        if expiry_date.tzinfo is None:
+            expiry_date = expiry_date.replace(tzinfo=timezone.utc)
+        if datetime.now(timezone.utc) < expiry_date:
+            return True
+
+    cla.log.debug(
+        f"{fn} - signature: {signature.get_signature_id()} document major version {signature_major} is older than "
+        f"the current major version {current_major} under the {policy} policy - re-sign required"
+    )
+    return False
+
+
 def user_icla_check(user: User, project: Project, signature: Signature, latest_major_version=False) -> bool:
     cla.log.debug(
         f"ICLA signature found for user: {user} on project: {project}, " f"signature_id: {signature.get_signature_id()}"
@@ -672,7 +724,14 @@ def user_signed_project_signature(user: User, project: Project) -> bool:
     signature = user.get_latest_signature(project.get_project_id(), signature_signed=True, signature_approved=True)
     icla_pass = False
     if signature is not None:
-        icla_pass = True
+        # Make sure the ICLA document version is still honored by the CLA Group signature version policy
+        if signature_version_accepted(project, signature):
+            icla_pass = True
+        else:
+            cla.log.debug(
+                f"{fn} - ICLA signature: {signature.get_signature_id()} of User: {user} on project: {project} "
+                f"requires a re-sign"
+            )
     else:
         cla.log.debug(f"{fn} - ICLA signature NOT found for User: {user} on project: {project}")
 
@@ -723,8 +782,14 @@ def user_signed_project_signature(user: User, project: Project) -> bool:
             signature = company.get_latest_signature(
                 project.get_project_id(), signature_signed=True, signature_approved=True
             )
+            if signature is not None and not signature_version_accepted(project, signature):
+                cla.log.debug(
+                    f"{fn} - CCLA signature check - CCLA signature: {signature.get_signature_id()} "
+                    f"requires a re-sign - ignoring signature"
+                )
+                signature = None
 
-            # Don't check the version for employee signatures.
+            # Don't check the version for employee signatures, only the CCLA version is checked above.
             if signature is not None:
                 cla.log.debug(
                     f"{fn} - CCLA signature check - loaded signed CCLA for project|company, "
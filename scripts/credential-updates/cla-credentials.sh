@@ -32,6 +32,10 @@ AUTH0_ALGORITHM='';
 
 # DocuSign and Docraptor Credentials
 DOCRAPTOR_API_KEY=''
+# PDF renderer of the CLA Group documents - docraptor (the local renderer only renders the template previews)
+PDF_RENDERER=''
+# PDF renderer of the template previews - docraptor or local, defaults to the PDF_RENDERER
+PREVIEW_PDF_RENDERER=''
 DOCUSIGN_USERNAME=''
 DOCUSIGN_PASSWORD=''
 DOCUSIGN_INTEGRATOR_KEY=''
@@ -164,6 +168,16 @@ if [ -n "$DOCRAPTOR_API_KEY" ]; then
     aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-doc-raptor-api-key-$ENV" --description "Docraptor API Key" --value "$DOCRAPTOR_API_KEY" --type "String" --overwrite
 fi
 
+if [ -n "$PDF_RENDERER" ]; then
+    echo "updating PDF Renderer: $PDF_RENDERER"
+    aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-pdf-renderer-$ENV" --description "CLA template PDF renderer" --value "$PDF_RENDERER" --type "String" --overwrite
+fi
+
+if [ -n "$PREVIEW_PDF_RENDERER" ]; then
+    echo "updating Preview PDF Renderer: $PREVIEW_PDF_RENDERER"
+    aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-preview-pdf-renderer-$ENV" --description "CLA template preview PDF renderer" --value "$PREVIEW_PDF_RENDERER" --type "String" --overwrite
+fi
+
 if [ -n "$DOCUSIGN_USERNAME" ]; then
     echo "updating DocuSign Username: $DOCUSIGN_USERNAME"
     aws ssm put-parameter --profile $PROFILE --region us-east-1 --name "cla-docusign-username-$ENV" --description "DocuSign Username" --value "$DOCUSIGN_USERNAME" --type "String" --overwrite